@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/config"
+	authadapters "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/auth/adapters"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/auth/handlers"
+	healthdomain "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/health/domain"
+	healthhandlers "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/health/handlers"
+	routingdomain "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/routing/domain"
+	routinghandlers "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/routing/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/server"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Printf("Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	log, err := logger.NewLogger(logger.Config{
+		Level:  cfg.Log.Level,
+		Format: cfg.Log.Format,
+		Output: cfg.Log.Output,
+	})
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer log.Sync()
+
+	log.Info("Starting API gateway",
+		zap.String("name", cfg.App.Name),
+		zap.String("version", cfg.App.Version),
+	)
+
+	// Initialize route table
+	upstreams := make(map[string]routingdomain.UpstreamDefinition, len(cfg.Upstreams))
+	for name, upstream := range cfg.Upstreams {
+		upstreams[name] = routingdomain.UpstreamDefinition{
+			URL:        upstream.URL,
+			HealthPath: upstream.HealthPath,
+			Timeout:    upstream.Timeout,
+		}
+	}
+	routes := make([]routingdomain.RouteDefinition, 0, len(cfg.Routes))
+	for _, route := range cfg.Routes {
+		routes = append(routes, routingdomain.RouteDefinition{
+			Prefix:   route.Prefix,
+			Upstream: route.Upstream,
+			Public:   route.Public,
+		})
+	}
+
+	routeTable, err := routingdomain.NewRouteTable(upstreams, routes)
+	if err != nil {
+		log.Fatal("Invalid route configuration", zap.Error(err))
+	}
+
+	// Initialize HTTP server
+	httpServer := server.NewHTTPServer(cfg.Server.HTTP, log)
+	httpServer.SetupMiddleware()
+
+	// Initialize central rate limiting
+	var proxyMiddleware []gin.HandlerFunc
+	if cfg.RateLimit.Enabled {
+		limiter := ratelimit.NewTokenBucket(cfg.RateLimit.RequestsPerMinute, cfg.RateLimit.Burst)
+		proxyMiddleware = append(proxyMiddleware, server.RateLimitMiddleware(limiter, server.ClientIPKey, log))
+	}
+
+	// Initialize authentication
+	if cfg.Auth.Enabled {
+		validator, err := authadapters.NewJWTValidator(authadapters.JWTValidatorConfig{
+			Issuer:        cfg.Auth.Issuer,
+			Audience:      cfg.Auth.Audience,
+			Secret:        cfg.Auth.Secret,
+			PublicKeyFile: cfg.Auth.PublicKeyFile,
+		})
+		if err != nil {
+			log.Fatal("Failed to create JWT validator", zap.Error(err))
+		}
+
+		authHandler := authhandlers.NewAuthHandler(validator, routeTable, log)
+		if cfg.Auth.APIKeyUpstream != "" {
+			upstream, ok := routeTable.Upstream(cfg.Auth.APIKeyUpstream)
+			if !ok {
+				log.Fatal("Unknown API key upstream", zap.String("upstream", cfg.Auth.APIKeyUpstream))
+			}
+			authHandler.SetAPIKeyValidator(authadapters.NewAPIKeyIntrospector(upstream.URL, cfg.Auth.APIKeyPath, upstream.Timeout))
+		}
+		proxyMiddleware = append(proxyMiddleware, authHandler.Middleware())
+	} else {
+		log.Warn("Authentication is disabled, requests are forwarded without verification")
+	}
+
+	// Initialize handlers
+	healthHandler := healthhandlers.NewHealthHandler(healthdomain.NewHealthService(routeTable.Upstreams(), 5*time.Second))
+	proxyHandler := routinghandlers.NewProxyHandler(routeTable, log)
+
+	// Register routes
+	healthHandler.RegisterRoutes(httpServer.Router())
+	proxyHandler.RegisterRoutes(httpServer.Router(), proxyMiddleware...)
+
+	// Start server in a separate goroutine
+	go func() {
+		if err := httpServer.Start(); err != nil {
+			log.Fatal("Failed to start HTTP server", zap.Error(err))
+		}
+	}()
+
+	for _, route := range routeTable.Routes() {
+		log.Info("Route registered",
+			zap.String("prefix", route.Prefix),
+			zap.String("upstream", route.Upstream.Name),
+			zap.String("url", route.Upstream.URL.String()),
+		)
+	}
+
+	// Wait for interrupt signal to gracefully shut down the server
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Info("Shutting down API gateway...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := httpServer.Stop(ctx); err != nil {
+		log.Error("HTTP server shutdown error", zap.Error(err))
+	}
+
+	log.Info("API gateway stopped")
+}
@@ -0,0 +1,71 @@
+package config
+
+import "time"
+
+// Config represents the application configuration
+type Config struct {
+	App       AppConfig
+	Server    ServerConfig
+	Upstreams map[string]UpstreamConfig
+	Routes    []RouteConfig
+	Auth      AuthConfig
+	RateLimit RateLimitConfig
+	Log       LogConfig
+}
+
+// AppConfig contains application metadata
+type AppConfig struct {
+	Name    string
+	Version string
+}
+
+// ServerConfig contains server configuration
+type ServerConfig struct {
+	HTTP HTTPServerConfig
+}
+
+// HTTPServerConfig contains HTTP server configuration
+type HTTPServerConfig struct {
+	Port         int
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// UpstreamConfig contains the address of a backend service
+type UpstreamConfig struct {
+	URL        string        // Base URL of the service (e.g. http://scanner-service:8081)
+	HealthPath string        // Path of the health endpoint of the service
+	Timeout    time.Duration // Timeout of proxied requests
+}
+
+// RouteConfig maps a path prefix to an upstream
+type RouteConfig struct {
+	Prefix   string // Path prefix (e.g. /api/v1)
+	Upstream string // Name of the upstream
+	Public   bool   // Whether the route is accessible without credentials
+}
+
+// AuthConfig contains authentication configuration
+type AuthConfig struct {
+	Enabled        bool
+	Issuer         string
+	Audience       string
+	Secret         string // HS256 shared secret
+	PublicKeyFile  string // PEM encoded RSA or ECDSA public key for RS256/ES256
+	APIKeyUpstream string // Upstream validating API keys, empty to reject them
+	APIKeyPath     string // Path of the endpoint of the upstream describing the caller
+}
+
+// RateLimitConfig contains rate limiting configuration
+type RateLimitConfig struct {
+	Enabled           bool
+	RequestsPerMinute float64
+	Burst             int
+}
+
+// LogConfig contains logging configuration
+type LogConfig struct {
+	Level  string
+	Format string
+	Output string
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// LoadConfig loads configuration from file and environment variables
+func LoadConfig() (*Config, error) {
+	// Set default configuration file path
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("./configs")
+	viper.AddConfigPath("../configs")
+	viper.AddConfigPath("/etc/api-gateway")
+	viper.AddConfigPath("$HOME/.api-gateway")
+
+	// Read environment variables with prefix GATEWAY_
+	viper.SetEnvPrefix("GATEWAY")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	// Read configuration file
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+			// Config file not found, continue with defaults and env vars
+			fmt.Println("Config file not found, using defaults and environment variables")
+		} else {
+			// Config file was found but another error occurred
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+
+	config := &Config{}
+
+	// App configuration
+	config.App.Name = viper.GetString("app.name")
+	config.App.Version = viper.GetString("app.version")
+
+	// HTTP Server configuration
+	config.Server.HTTP.Port = viper.GetInt("server.http.port")
+	config.Server.HTTP.ReadTimeout = viper.GetDuration("server.http.read_timeout")
+	config.Server.HTTP.WriteTimeout = viper.GetDuration("server.http.write_timeout")
+
+	// Upstream configuration
+	config.Upstreams = make(map[string]UpstreamConfig)
+	for name := range viper.GetStringMap("upstreams") {
+		key := "upstreams." + name
+		config.Upstreams[name] = UpstreamConfig{
+			URL:        viper.GetString(key + ".url"),
+			HealthPath: viper.GetString(key + ".health_path"),
+			Timeout:    viper.GetDuration(key + ".timeout"),
+		}
+	}
+
+	// Route configuration
+	if err := viper.UnmarshalKey("routes", &config.Routes); err != nil {
+		return nil, fmt.Errorf("error reading routes: %w", err)
+	}
+
+	// Auth configuration
+	config.Auth.Enabled = viper.GetBool("auth.enabled")
+	config.Auth.Issuer = viper.GetString("auth.issuer")
+	config.Auth.Audience = viper.GetString("auth.audience")
+	config.Auth.Secret = viper.GetString("auth.secret")
+	config.Auth.PublicKeyFile = viper.GetString("auth.public_key_file")
+	config.Auth.APIKeyUpstream = viper.GetString("auth.api_key_upstream")
+	config.Auth.APIKeyPath = viper.GetString("auth.api_key_path")
+
+	// Rate limit configuration
+	config.RateLimit.Enabled = viper.GetBool("rate_limit.enabled")
+	config.RateLimit.RequestsPerMinute = viper.GetFloat64("rate_limit.requests_per_minute")
+	config.RateLimit.Burst = viper.GetInt("rate_limit.burst")
+
+	// Logging configuration
+	config.Log.Level = viper.GetString("log.level")
+	config.Log.Format = viper.GetString("log.format")
+	config.Log.Output = viper.GetString("log.output")
+
+	// Set defaults if not provided
+	setDefaults(config)
+
+	return config, nil
+}
+
+// setDefaults sets default values for configuration if not provided
+func setDefaults(config *Config) {
+	// App defaults
+	if config.App.Name == "" {
+		config.App.Name = "api-gateway"
+	}
+	if config.App.Version == "" {
+		config.App.Version = "0.1.0"
+	}
+
+	// HTTP Server defaults
+	if config.Server.HTTP.Port == 0 {
+		config.Server.HTTP.Port = 8080
+	}
+	if config.Server.HTTP.ReadTimeout == 0 {
+		config.Server.HTTP.ReadTimeout = 15 * time.Second
+	}
+	if config.Server.HTTP.WriteTimeout == 0 {
+		config.Server.HTTP.WriteTimeout = 60 * time.Second
+	}
+
+	// Upstream defaults
+	if len(config.Upstreams) == 0 {
+		config.Upstreams["scanner"] = UpstreamConfig{URL: "http://localhost:8081"}
+	}
+	for name, upstream := range config.Upstreams {
+		if upstream.HealthPath == "" {
+			upstream.HealthPath = "/health"
+		}
+		if upstream.Timeout == 0 {
+			upstream.Timeout = 30 * time.Second
+		}
+		config.Upstreams[name] = upstream
+	}
+
+	// Route defaults
+	if len(config.Routes) == 0 {
+		config.Routes = []RouteConfig{{Prefix: "/api/", Upstream: "scanner"}}
+	}
+
+	// Auth defaults
+	if config.Auth.APIKeyPath == "" {
+		config.Auth.APIKeyPath = "/api/v1/auth/me"
+	}
+
+	// Rate limit defaults
+	if config.RateLimit.RequestsPerMinute == 0 {
+		config.RateLimit.RequestsPerMinute = 300
+	}
+	if config.RateLimit.Burst == 0 {
+		config.RateLimit.Burst = 50
+	}
+
+	// Logging defaults
+	if config.Log.Level == "" {
+		config.Log.Level = "info"
+	}
+	if config.Log.Format == "" {
+		config.Log.Format = "json"
+	}
+	if config.Log.Output == "" {
+		config.Log.Output = "stdout"
+	}
+}
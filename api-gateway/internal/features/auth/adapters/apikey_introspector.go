@@ -0,0 +1,59 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/auth/domain"
+)
+
+// APIKeyIntrospector validates API keys by asking the service that issued them who the
+// key belongs to
+type APIKeyIntrospector struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewAPIKeyIntrospector creates a new APIKeyIntrospector calling the endpoint at path
+// of the service at baseURL
+func NewAPIKeyIntrospector(baseURL *url.URL, path string, timeout time.Duration) *APIKeyIntrospector {
+	return &APIKeyIntrospector{
+		endpoint: baseURL.JoinPath(path).String(),
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// ValidateAPIKey validates a key and returns its principal. Any response other than
+// 200 OK rejects the key.
+func (i *APIKeyIntrospector) ValidateAPIKey(ctx context.Context, key string) (*domain.Principal, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, i.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-Key", key)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to introspect API key: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API key rejected with status %d", resp.StatusCode)
+	}
+
+	var principal domain.Principal
+	if err := json.NewDecoder(resp.Body).Decode(&principal); err != nil {
+		return nil, fmt.Errorf("failed to decode principal: %w", err)
+	}
+	if principal.UserID == "" {
+		return nil, fmt.Errorf("API key has no user")
+	}
+
+	return &principal, nil
+}
@@ -0,0 +1,111 @@
+package adapters
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/auth/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTValidatorConfig contains the settings of a JWTValidator
+type JWTValidatorConfig struct {
+	Issuer        string
+	Audience      string
+	Secret        string // HS256 shared secret
+	PublicKeyFile string // PEM encoded RSA or ECDSA public key
+}
+
+// JWTValidator validates JWT access tokens signed with a shared secret or a public key
+type JWTValidator struct {
+	config JWTValidatorConfig
+	key    interface{}
+	parser *jwt.Parser
+}
+
+// NewJWTValidator creates a new JWTValidator
+func NewJWTValidator(config JWTValidatorConfig) (*JWTValidator, error) {
+	options := []jwt.ParserOption{jwt.WithExpirationRequired()}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	validator := &JWTValidator{config: config}
+
+	switch {
+	case config.PublicKeyFile != "":
+		key, err := loadPublicKey(config.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		validator.key = key
+		options = append(options, jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}))
+	case config.Secret != "":
+		validator.key = []byte(config.Secret)
+		options = append(options, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	default:
+		return nil, fmt.Errorf("either a secret or a public key file is required")
+	}
+
+	validator.parser = jwt.NewParser(options...)
+	return validator, nil
+}
+
+// ValidateToken validates a token and returns its principal
+func (v *JWTValidator) ValidateToken(ctx context.Context, token string) (*domain.Principal, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return v.key, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+
+	principal := &domain.Principal{UserID: subject}
+	if roles, ok := claims["roles"].([]interface{}); ok {
+		for _, role := range roles {
+			if s, ok := role.(string); ok {
+				principal.Roles = append(principal.Roles, s)
+			}
+		}
+	}
+
+	return principal, nil
+}
+
+// loadPublicKey reads a PEM encoded RSA or ECDSA public key
+func loadPublicKey(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("public key file %s is not PEM encoded", path)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+// Principal represents a caller authenticated by the gateway
+type Principal struct {
+	UserID string   `json:"user_id"` // Subject of the token
+	Roles  []string `json:"roles"`   // Roles claimed by the token
+}
+
+// TokenValidator validates bearer tokens
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*Principal, error)
+}
+
+// APIKeyValidator validates API keys
+type APIKeyValidator interface {
+	ValidateAPIKey(ctx context.Context, key string) (*Principal, error)
+}
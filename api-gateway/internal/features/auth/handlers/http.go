@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/auth/domain"
+	routingdomain "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/routing/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AuthHandler authenticates requests before they are proxied
+type AuthHandler struct {
+	validator domain.TokenValidator
+	apiKeys   domain.APIKeyValidator // Validates X-API-Key headers, nil to reject them
+	routes    *routingdomain.RouteTable
+	logger    *logger.Logger
+}
+
+// NewAuthHandler creates a new AuthHandler
+func NewAuthHandler(validator domain.TokenValidator, routes *routingdomain.RouteTable, logger *logger.Logger) *AuthHandler {
+	return &AuthHandler{
+		validator: validator,
+		routes:    routes,
+		logger:    logger,
+	}
+}
+
+// SetAPIKeyValidator sets the validator of API keys. Requests with an X-API-Key header
+// are rejected without one.
+func (h *AuthHandler) SetAPIKeyValidator(validator domain.APIKeyValidator) {
+	h.apiKeys = validator
+}
+
+// Middleware rejects requests to non-public routes without valid credentials.
+// Bearer tokens and API keys are validated by the gateway and forwarded unchanged,
+// so upstream services can apply their own authorization.
+func (h *AuthHandler) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if route, ok := h.routes.Match(c.Request.URL.Path); ok && route.Public {
+			c.Next()
+			return
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+			if h.apiKeys == nil {
+				h.unauthorized(c, "API keys are not accepted")
+				return
+			}
+
+			principal, err := h.apiKeys.ValidateAPIKey(c.Request.Context(), apiKey)
+			if err != nil {
+				h.logger.WithContext(c.Request.Context()).Debug("API key rejected", zap.Error(err))
+				h.unauthorized(c, "Invalid API key")
+				return
+			}
+
+			c.Set("user_id", principal.UserID)
+			c.Next()
+			return
+		}
+
+		authHeader := c.GetHeader("Authorization")
+		token, ok := strings.CutPrefix(authHeader, "Bearer ")
+		if !ok || token == "" {
+			h.unauthorized(c, "Missing credentials")
+			return
+		}
+
+		principal, err := h.validator.ValidateToken(c.Request.Context(), token)
+		if err != nil {
+			h.logger.WithContext(c.Request.Context()).Debug("Token rejected", zap.Error(err))
+			h.unauthorized(c, "Invalid token")
+			return
+		}
+
+		c.Set("user_id", principal.UserID)
+		c.Next()
+	}
+}
+
+// unauthorized aborts the request with 401 Unauthorized
+func (h *AuthHandler) unauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="nmap-ui"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"error": message,
+	})
+}
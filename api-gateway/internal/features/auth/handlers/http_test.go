@@ -0,0 +1,86 @@
+package handlers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/auth/adapters"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/auth/handlers"
+	routingdomain "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/routing/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// rejectingValidator rejects every bearer token
+type rejectingValidator struct{}
+
+func (rejectingValidator) ValidateToken(ctx context.Context, token string) (*domain.Principal, error) {
+	return nil, fmt.Errorf("invalid token")
+}
+
+// newRouter returns a router authenticating requests with the middleware of handler
+// and responding with the authenticated user
+func newRouter(t *testing.T, handler *handlers.AuthHandler) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.NoRoute(handler.Middleware(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("user_id"))
+	})
+	return router
+}
+
+func TestMiddlewareValidatesAPIKeys(t *testing.T) {
+	// The scanner service knows a single key
+	scanner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/auth/me" || r.Header.Get("X-API-Key") != "nmap_valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"user_id":"alice","roles":["operator"]}`))
+	}))
+	defer scanner.Close()
+	scannerURL, err := url.Parse(scanner.URL)
+	require.NoError(t, err)
+
+	routes, err := routingdomain.NewRouteTable(map[string]routingdomain.UpstreamDefinition{
+		"scanner": {URL: scanner.URL},
+	}, []routingdomain.RouteDefinition{{Prefix: "/api/", Upstream: "scanner"}})
+	require.NoError(t, err)
+
+	log := &logger.Logger{Logger: zap.NewNop()}
+	handler := handlers.NewAuthHandler(rejectingValidator{}, routes, log)
+
+	request := func(router *gin.Engine, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+		req.Header.Set("X-API-Key", key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Without a validator API keys are rejected
+	w := request(newRouter(t, handler), "nmap_valid")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	handler.SetAPIKeyValidator(adapters.NewAPIKeyIntrospector(scannerURL, "/api/v1/auth/me", time.Second))
+	router := newRouter(t, handler)
+
+	w = request(router, "x")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Invalid API key")
+
+	w = request(router, "nmap_valid")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "alice", w.Body.String())
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	routingdomain "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/routing/domain"
+)
+
+// Health status constants
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+)
+
+// ServiceHealth represents the health of a single upstream service
+type ServiceHealth struct {
+	Status     string                 `json:"status"`            // healthy or unhealthy
+	StatusCode int                    `json:"status_code"`       // HTTP status of the health endpoint
+	Latency    float64                `json:"latency_ms"`        // Response time in milliseconds
+	Error      string                 `json:"error,omitempty"`   // Error message if unreachable
+	Details    map[string]interface{} `json:"details,omitempty"` // Body returned by the service
+}
+
+// Health represents the aggregated health of the gateway and its upstreams
+type Health struct {
+	Status    string                    `json:"status"`    // healthy, degraded or unhealthy
+	Services  map[string]*ServiceHealth `json:"services"`  // Health of every upstream
+	Timestamp time.Time                 `json:"timestamp"` // When the check was made
+}
+
+// HealthService checks the health of all upstream services
+type HealthService struct {
+	upstreams []*routingdomain.Upstream
+	client    *http.Client
+}
+
+// NewHealthService creates a new HealthService
+func NewHealthService(upstreams []*routingdomain.Upstream, timeout time.Duration) *HealthService {
+	return &HealthService{
+		upstreams: upstreams,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+// Check checks all upstreams concurrently.
+// The result is healthy when all upstreams are healthy, unhealthy when none is,
+// and degraded otherwise.
+func (s *HealthService) Check(ctx context.Context) *Health {
+	health := &Health{
+		Services:  make(map[string]*ServiceHealth, len(s.upstreams)),
+		Timestamp: time.Now(),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, upstream := range s.upstreams {
+		wg.Add(1)
+		go func(upstream *routingdomain.Upstream) {
+			defer wg.Done()
+
+			serviceHealth := s.checkUpstream(ctx, upstream)
+
+			mu.Lock()
+			health.Services[upstream.Name] = serviceHealth
+			mu.Unlock()
+		}(upstream)
+	}
+	wg.Wait()
+
+	healthy := 0
+	for _, serviceHealth := range health.Services {
+		if serviceHealth.Status == StatusHealthy {
+			healthy++
+		}
+	}
+
+	switch {
+	case healthy == len(health.Services):
+		health.Status = StatusHealthy
+	case healthy == 0:
+		health.Status = StatusUnhealthy
+	default:
+		health.Status = StatusDegraded
+	}
+
+	return health
+}
+
+// checkUpstream calls the health endpoint of a single upstream
+func (s *HealthService) checkUpstream(ctx context.Context, upstream *routingdomain.Upstream) *ServiceHealth {
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL.JoinPath(upstream.HealthPath).String(), nil)
+	if err != nil {
+		return &ServiceHealth{Status: StatusUnhealthy, Error: err.Error()}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return &ServiceHealth{
+			Status:  StatusUnhealthy,
+			Latency: float64(time.Since(start).Microseconds()) / 1000,
+			Error:   err.Error(),
+		}
+	}
+	defer resp.Body.Close()
+
+	serviceHealth := &ServiceHealth{
+		Status:     StatusHealthy,
+		StatusCode: resp.StatusCode,
+		Latency:    float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if resp.StatusCode != http.StatusOK {
+		serviceHealth.Status = StatusUnhealthy
+	}
+
+	// The body is informational only
+	_ = json.NewDecoder(resp.Body).Decode(&serviceHealth.Details)
+
+	return serviceHealth
+}
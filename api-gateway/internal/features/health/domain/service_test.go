@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/health/domain"
+	routingdomain "github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/routing/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newUpstream(t *testing.T, name string, status int) *routingdomain.Upstream {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	return &routingdomain.Upstream{Name: name, URL: target, HealthPath: "/health"}
+}
+
+func TestHealthCheck(t *testing.T) {
+	healthy := newUpstream(t, "scanner", http.StatusOK)
+	unhealthy := newUpstream(t, "storage", http.StatusServiceUnavailable)
+
+	health := domain.NewHealthService([]*routingdomain.Upstream{healthy}, time.Second).Check(context.Background())
+	assert.Equal(t, domain.StatusHealthy, health.Status)
+	assert.Equal(t, "ok", health.Services["scanner"].Details["status"])
+
+	health = domain.NewHealthService([]*routingdomain.Upstream{healthy, unhealthy}, time.Second).Check(context.Background())
+	assert.Equal(t, domain.StatusDegraded, health.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, health.Services["storage"].StatusCode)
+
+	health = domain.NewHealthService([]*routingdomain.Upstream{unhealthy}, time.Second).Check(context.Background())
+	assert.Equal(t, domain.StatusUnhealthy, health.Status)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/health/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler handles health check requests
+type HealthHandler struct {
+	healthService *domain.HealthService
+}
+
+// NewHealthHandler creates a new HealthHandler
+func NewHealthHandler(healthService *domain.HealthService) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+// GetHealth handles the aggregated health check endpoint.
+// Degraded gateways still report 200 so that load balancers keep routing to them.
+func (h *HealthHandler) GetHealth(c *gin.Context) {
+	health := h.healthService.Check(c.Request.Context())
+
+	status := http.StatusOK
+	if health.Status == domain.StatusUnhealthy {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, health)
+}
+
+// RegisterRoutes registers the health handler routes to the router
+func (h *HealthHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/health", h.GetHealth)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"net/url"
+	"time"
+)
+
+// Upstream represents a backend service requests are routed to
+type Upstream struct {
+	Name       string        `json:"name"`        // Unique name of the service
+	URL        *url.URL      `json:"url"`         // Base URL of the service
+	HealthPath string        `json:"health_path"` // Path of the health endpoint
+	Timeout    time.Duration `json:"timeout"`     // Timeout of proxied requests
+}
+
+// Route maps a path prefix to an upstream
+type Route struct {
+	Prefix   string    `json:"prefix"`   // Path prefix (e.g. /api/v1)
+	Upstream *Upstream `json:"upstream"` // Target service
+	Public   bool      `json:"public"`   // Whether the route is accessible without credentials
+}
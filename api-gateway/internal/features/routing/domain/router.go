@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RouteTable resolves request paths to routes by longest prefix
+type RouteTable struct {
+	routes    []*Route
+	upstreams map[string]*Upstream
+}
+
+// UpstreamDefinition describes an upstream before its URL is parsed
+type UpstreamDefinition struct {
+	URL        string
+	HealthPath string
+	Timeout    time.Duration
+}
+
+// RouteDefinition describes a route before its upstream is resolved
+type RouteDefinition struct {
+	Prefix   string
+	Upstream string
+	Public   bool
+}
+
+// NewRouteTable creates a new RouteTable from upstream and route definitions
+func NewRouteTable(upstreams map[string]UpstreamDefinition, routes []RouteDefinition) (*RouteTable, error) {
+	table := &RouteTable{
+		upstreams: make(map[string]*Upstream, len(upstreams)),
+	}
+
+	for name, definition := range upstreams {
+		target, err := url.Parse(definition.URL)
+		if err != nil || target.Scheme == "" || target.Host == "" {
+			return nil, fmt.Errorf("invalid URL %q for upstream %s", definition.URL, name)
+		}
+
+		table.upstreams[name] = &Upstream{
+			Name:       name,
+			URL:        target,
+			HealthPath: definition.HealthPath,
+			Timeout:    definition.Timeout,
+		}
+	}
+
+	for _, definition := range routes {
+		if !strings.HasPrefix(definition.Prefix, "/") {
+			return nil, fmt.Errorf("route prefix %q must start with /", definition.Prefix)
+		}
+
+		upstream, ok := table.upstreams[definition.Upstream]
+		if !ok {
+			return nil, fmt.Errorf("route %s refers to unknown upstream %s", definition.Prefix, definition.Upstream)
+		}
+
+		table.routes = append(table.routes, &Route{
+			Prefix:   definition.Prefix,
+			Upstream: upstream,
+			Public:   definition.Public,
+		})
+	}
+
+	// Longest prefix first
+	sort.Slice(table.routes, func(i, j int) bool {
+		return len(table.routes[i].Prefix) > len(table.routes[j].Prefix)
+	})
+
+	return table, nil
+}
+
+// Match returns the route with the longest prefix matching path. Prefixes match whole
+// path segments: /api/v1/scans matches /api/v1/scans and /api/v1/scans/1 but not
+// /api/v1/scansXYZ.
+func (t *RouteTable) Match(path string) (*Route, bool) {
+	for _, route := range t.routes {
+		if matchesPrefix(path, route.Prefix) {
+			return route, true
+		}
+	}
+	return nil, false
+}
+
+// matchesPrefix reports whether path is prefix or continues it with a new segment
+func matchesPrefix(path, prefix string) bool {
+	rest, ok := strings.CutPrefix(path, prefix)
+	return ok && (rest == "" || strings.HasSuffix(prefix, "/") || strings.HasPrefix(rest, "/"))
+}
+
+// Routes returns all routes, longest prefix first
+func (t *RouteTable) Routes() []*Route {
+	return t.routes
+}
+
+// Upstream returns the upstream with the given name
+func (t *RouteTable) Upstream(name string) (*Upstream, bool) {
+	upstream, ok := t.upstreams[name]
+	return upstream, ok
+}
+
+// Upstreams returns all upstreams sorted by name
+func (t *RouteTable) Upstreams() []*Upstream {
+	upstreams := make([]*Upstream, 0, len(t.upstreams))
+	for _, upstream := range t.upstreams {
+		upstreams = append(upstreams, upstream)
+	}
+
+	sort.Slice(upstreams, func(i, j int) bool {
+		return upstreams[i].Name < upstreams[j].Name
+	})
+
+	return upstreams
+}
@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/routing/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteTableMatch(t *testing.T) {
+	table, err := domain.NewRouteTable(map[string]domain.UpstreamDefinition{
+		"scanner": {URL: "http://scanner:8081"},
+		"storage": {URL: "http://storage:8082"},
+	}, []domain.RouteDefinition{
+		{Prefix: "/api/", Upstream: "scanner"},
+		{Prefix: "/api/v1/exports", Upstream: "storage"},
+	})
+	assert.NoError(t, err)
+
+	route, ok := table.Match("/api/v1/scans")
+	assert.True(t, ok)
+	assert.Equal(t, "scanner", route.Upstream.Name)
+
+	route, ok = table.Match("/api/v1/exports/123")
+	assert.True(t, ok)
+	assert.Equal(t, "storage", route.Upstream.Name)
+
+	route, ok = table.Match("/api/v1/exports")
+	assert.True(t, ok)
+	assert.Equal(t, "storage", route.Upstream.Name)
+
+	// Prefixes only match whole path segments
+	route, ok = table.Match("/api/v1/exportsXYZ")
+	assert.True(t, ok)
+	assert.Equal(t, "scanner", route.Upstream.Name)
+
+	_, ok = table.Match("/apiXYZ")
+	assert.False(t, ok)
+
+	_, ok = table.Match("/metrics")
+	assert.False(t, ok)
+}
+
+func TestNewRouteTableInvalid(t *testing.T) {
+	_, err := domain.NewRouteTable(map[string]domain.UpstreamDefinition{
+		"scanner": {URL: "scanner:8081"},
+	}, nil)
+	assert.Error(t, err)
+
+	_, err = domain.NewRouteTable(map[string]domain.UpstreamDefinition{
+		"scanner": {URL: "http://scanner:8081"},
+	}, []domain.RouteDefinition{{Prefix: "/api/", Upstream: "unknown"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/features/routing/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// statusClientClosedRequest is the non-standard status logged for requests the client
+// cancelled before the upstream responded
+const statusClientClosedRequest = 499
+
+// ProxyHandler forwards requests to upstream services
+type ProxyHandler struct {
+	routes  *domain.RouteTable
+	proxies map[string]*httputil.ReverseProxy
+	logger  *logger.Logger
+}
+
+// NewProxyHandler creates a new ProxyHandler
+func NewProxyHandler(routes *domain.RouteTable, logger *logger.Logger) *ProxyHandler {
+	h := &ProxyHandler{
+		routes:  routes,
+		proxies: make(map[string]*httputil.ReverseProxy),
+		logger:  logger,
+	}
+
+	for _, upstream := range routes.Upstreams() {
+		h.proxies[upstream.Name] = h.newReverseProxy(upstream)
+	}
+
+	return h
+}
+
+// newReverseProxy creates the reverse proxy of an upstream
+func (h *ProxyHandler) newReverseProxy(upstream *domain.Upstream) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(upstream.URL)
+			r.SetXForwarded()
+			r.Out.Header.Set(requestid.Header, requestid.FromContext(r.In.Context()))
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			// The gateway sets these headers itself
+			for header := range resp.Header {
+				if strings.HasPrefix(header, "Access-Control-") {
+					resp.Header.Del(header)
+				}
+			}
+			resp.Header.Del(requestid.Header)
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			// The client went away, there is nobody to respond to
+			if errors.Is(err, context.Canceled) {
+				h.logger.WithContext(r.Context()).Debug("Client cancelled proxied request",
+					zap.String("upstream", upstream.Name),
+					zap.String("path", r.URL.Path),
+				)
+				w.WriteHeader(statusClientClosedRequest)
+				return
+			}
+
+			h.logger.WithContext(r.Context()).Error("Upstream request failed",
+				zap.String("upstream", upstream.Name),
+				zap.String("path", r.URL.Path),
+				zap.Error(err),
+			)
+
+			// Transport errors wrap the deadline of the request context
+			status := http.StatusBadGateway
+			if errors.Is(err, context.DeadlineExceeded) {
+				status = http.StatusGatewayTimeout
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":"Upstream service ` + upstream.Name + ` is unavailable"}`))
+		},
+	}
+}
+
+// Proxy handles a request by forwarding it to the upstream of the matching route
+func (h *ProxyHandler) Proxy(c *gin.Context) {
+	route, ok := h.routes.Match(c.Request.URL.Path)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "No route for " + c.Request.URL.Path,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if route.Upstream.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, route.Upstream.Timeout)
+		defer cancel()
+	}
+
+	h.proxies[route.Upstream.Name].ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+}
+
+// RegisterRoutes registers the proxy for every unmatched request.
+// The given middleware is applied to all proxied requests.
+func (h *ProxyHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	router.NoRoute(append(middleware, h.Proxy)...)
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/internal/config"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// HTTPServer represents an HTTP server
+type HTTPServer struct {
+	server *http.Server
+	router *gin.Engine
+	logger *logger.Logger
+	config config.HTTPServerConfig
+}
+
+// NewHTTPServer creates a new HTTP server
+func NewHTTPServer(cfg config.HTTPServerConfig, log *logger.Logger) *HTTPServer {
+	// Create router
+	router := gin.New()
+
+	// Create server
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.Port),
+		Handler:      router,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}
+
+	return &HTTPServer{
+		server: server,
+		router: router,
+		logger: log,
+		config: cfg,
+	}
+}
+
+// Router returns the Gin router
+func (s *HTTPServer) Router() *gin.Engine {
+	return s.router
+}
+
+// Start starts the HTTP server
+func (s *HTTPServer) Start() error {
+	s.logger.Info("Starting HTTP server", zap.Int("port", s.config.Port))
+	return s.server.ListenAndServe()
+}
+
+// Stop stops the HTTP server
+func (s *HTTPServer) Stop(ctx context.Context) error {
+	s.logger.Info("Stopping HTTP server")
+	return s.server.Shutdown(ctx)
+}
+
+// SetupMiddleware sets up common middleware
+func (s *HTTPServer) SetupMiddleware() {
+	// Recovery middleware
+	s.router.Use(gin.Recovery())
+
+	// Request ID middleware
+	s.router.Use(RequestIDMiddleware())
+
+	// Logger middleware
+	s.router.Use(func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		s.logger.Info("HTTP request",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("request_id", requestid.FromContext(c.Request.Context())),
+		)
+	})
+
+	// CORS middleware
+	s.router.Use(func(c *gin.Context) {
+		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, X-Request-ID")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, Retry-After")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	})
+}
+
+// RequestIDMiddleware accepts the X-Request-ID of the client or generates a new one.
+// The ID is echoed in the response and forwarded to upstream services.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := requestid.Ensure(c.GetHeader(requestid.Header))
+
+		c.Set("request_id", id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/api-gateway/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KeyFunc extracts the rate limiting key of a request
+type KeyFunc func(c *gin.Context) string
+
+// ClientIPKey limits requests per client IP
+func ClientIPKey(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// UserKey limits requests per authenticated user, falling back to the client IP
+func UserKey(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return "user:" + userID
+	}
+	return ClientIPKey(c)
+}
+
+// RateLimitMiddleware rejects requests over the limit with 429 Too Many Requests
+func RateLimitMiddleware(limiter ratelimit.Limiter, keyFunc KeyFunc, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := keyFunc(c)
+
+		allowed, retryAfter := limiter.Allow(key)
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+
+			log.Warn("Rate limit exceeded",
+				zap.String("key", key),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+			)
+
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": "Rate limit exceeded, retry after " + strconv.Itoa(seconds) + " seconds",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
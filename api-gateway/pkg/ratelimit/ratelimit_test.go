@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	limiter := NewTokenBucket(60, 2)
+	limiter.now = func() time.Time { return now }
+
+	// Burst is available immediately
+	allowed, _ := limiter.Allow("alice")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow("alice")
+	assert.True(t, allowed)
+
+	allowed, retryAfter := limiter.Allow("alice")
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Keys are limited independently
+	allowed, _ = limiter.Allow("bob")
+	assert.True(t, allowed)
+
+	// One token is refilled per second
+	now = now.Add(time.Second)
+	allowed, _ = limiter.Allow("alice")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow("alice")
+	assert.False(t, allowed)
+}
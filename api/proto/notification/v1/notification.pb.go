@@ -0,0 +1,440 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: notification/v1/notification.proto
+
+package notificationv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventType is the event a notification is about
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED            EventType = 0
+	EventType_EVENT_TYPE_SCAN_COMPLETED         EventType = 1
+	EventType_EVENT_TYPE_SCAN_FAILED            EventType = 2
+	EventType_EVENT_TYPE_SCAN_CANCELLED         EventType = 3
+	EventType_EVENT_TYPE_WORKFLOW_RUN_COMPLETED EventType = 4
+	EventType_EVENT_TYPE_WORKFLOW_RUN_FAILED    EventType = 5
+	EventType_EVENT_TYPE_SCHEDULED_RUN_SKIPPED  EventType = 6
+	// A service recovered from a panic while serving a request, for operators
+	EventType_EVENT_TYPE_SERVICE_PANIC EventType = 7
+	// A recurring monitoring scan found changes since the previous scan of its targets
+	EventType_EVENT_TYPE_MONITOR_CHANGES EventType = 8
+	// A completed scan found ports open that an alert rule does not allow
+	EventType_EVENT_TYPE_ALERT_RULE_TRIGGERED EventType = 9
+	// A certificate observed by scans expires soon
+	EventType_EVENT_TYPE_CERTIFICATE_EXPIRING EventType = 10
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0:  "EVENT_TYPE_UNSPECIFIED",
+		1:  "EVENT_TYPE_SCAN_COMPLETED",
+		2:  "EVENT_TYPE_SCAN_FAILED",
+		3:  "EVENT_TYPE_SCAN_CANCELLED",
+		4:  "EVENT_TYPE_WORKFLOW_RUN_COMPLETED",
+		5:  "EVENT_TYPE_WORKFLOW_RUN_FAILED",
+		6:  "EVENT_TYPE_SCHEDULED_RUN_SKIPPED",
+		7:  "EVENT_TYPE_SERVICE_PANIC",
+		8:  "EVENT_TYPE_MONITOR_CHANGES",
+		9:  "EVENT_TYPE_ALERT_RULE_TRIGGERED",
+		10: "EVENT_TYPE_CERTIFICATE_EXPIRING",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":            0,
+		"EVENT_TYPE_SCAN_COMPLETED":         1,
+		"EVENT_TYPE_SCAN_FAILED":            2,
+		"EVENT_TYPE_SCAN_CANCELLED":         3,
+		"EVENT_TYPE_WORKFLOW_RUN_COMPLETED": 4,
+		"EVENT_TYPE_WORKFLOW_RUN_FAILED":    5,
+		"EVENT_TYPE_SCHEDULED_RUN_SKIPPED":  6,
+		"EVENT_TYPE_SERVICE_PANIC":          7,
+		"EVENT_TYPE_MONITOR_CHANGES":        8,
+		"EVENT_TYPE_ALERT_RULE_TRIGGERED":   9,
+		"EVENT_TYPE_CERTIFICATE_EXPIRING":   10,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_notification_v1_notification_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_notification_v1_notification_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{0}
+}
+
+// Notification is a notification about an event
+type Notification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique ID, notifications with the same ID are delivered once
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type       EventType              `protobuf:"varint,2,opt,name=type,proto3,enum=nmapui.notification.v1.EventType" json:"type,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// User notified
+	UserId string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Tenant (organization) of the user
+	TenantId string `protobuf:"bytes,5,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Scan the event is about
+	ScanId string `protobuf:"bytes,6,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	// Workflow the event is about
+	WorkflowId string `protobuf:"bytes,7,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	// Workflow run the event is about
+	RunId string `protobuf:"bytes,8,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// Short summary, e.g. an e-mail subject
+	Subject string `protobuf:"bytes,9,opt,name=subject,proto3" json:"subject,omitempty"`
+	Message string `protobuf:"bytes,10,opt,name=message,proto3" json:"message,omitempty"`
+	// Additional values of the event, e.g. open_ports
+	Attributes    map[string]string `protobuf:"bytes,11,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_notification_v1_notification_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *Notification) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Notification) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Notification) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Notification) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+func (x *Notification) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *Notification) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *Notification) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Notification) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Notification) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// SendRequest is the request of Send
+type SendRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Notification *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	// Channels the notification is delivered over, e.g. email or slack, empty for the preferences of the user
+	Channels      []string `protobuf:"bytes,2,rep,name=channels,proto3" json:"channels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendRequest) Reset() {
+	*x = SendRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendRequest) ProtoMessage() {}
+
+func (x *SendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendRequest.ProtoReflect.Descriptor instead.
+func (*SendRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SendRequest) GetNotification() *Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *SendRequest) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// SendResponse is the response of Send
+type SendResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Channels the notification was delivered over
+	Delivered     []string `protobuf:"bytes,1,rep,name=delivered,proto3" json:"delivered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendResponse) Reset() {
+	*x = SendResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendResponse) ProtoMessage() {}
+
+func (x *SendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendResponse.ProtoReflect.Descriptor instead.
+func (*SendResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SendResponse) GetDelivered() []string {
+	if x != nil {
+		return x.Delivered
+	}
+	return nil
+}
+
+var File_notification_v1_notification_proto protoreflect.FileDescriptor
+
+const file_notification_v1_notification_proto_rawDesc = "" +
+	"\n" +
+	"\"notification/v1/notification.proto\x12\x16nmapui.notification.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe2\x03\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x125\n" +
+	"\x04type\x18\x02 \x01(\x0e2!.nmapui.notification.v1.EventTypeR\x04type\x12;\n" +
+	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttenant_id\x18\x05 \x01(\tR\btenantId\x12\x17\n" +
+	"\ascan_id\x18\x06 \x01(\tR\x06scanId\x12\x1f\n" +
+	"\vworkflow_id\x18\a \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\b \x01(\tR\x05runId\x12\x18\n" +
+	"\asubject\x18\t \x01(\tR\asubject\x12\x18\n" +
+	"\amessage\x18\n" +
+	" \x01(\tR\amessage\x12T\n" +
+	"\n" +
+	"attributes\x18\v \x03(\v24.nmapui.notification.v1.Notification.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"s\n" +
+	"\vSendRequest\x12H\n" +
+	"\fnotification\x18\x01 \x01(\v2$.nmapui.notification.v1.NotificationR\fnotification\x12\x1a\n" +
+	"\bchannels\x18\x02 \x03(\tR\bchannels\",\n" +
+	"\fSendResponse\x12\x1c\n" +
+	"\tdelivered\x18\x01 \x03(\tR\tdelivered*\xfa\x02\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19EVENT_TYPE_SCAN_COMPLETED\x10\x01\x12\x1a\n" +
+	"\x16EVENT_TYPE_SCAN_FAILED\x10\x02\x12\x1d\n" +
+	"\x19EVENT_TYPE_SCAN_CANCELLED\x10\x03\x12%\n" +
+	"!EVENT_TYPE_WORKFLOW_RUN_COMPLETED\x10\x04\x12\"\n" +
+	"\x1eEVENT_TYPE_WORKFLOW_RUN_FAILED\x10\x05\x12$\n" +
+	" EVENT_TYPE_SCHEDULED_RUN_SKIPPED\x10\x06\x12\x1c\n" +
+	"\x18EVENT_TYPE_SERVICE_PANIC\x10\a\x12\x1e\n" +
+	"\x1aEVENT_TYPE_MONITOR_CHANGES\x10\b\x12#\n" +
+	"\x1fEVENT_TYPE_ALERT_RULE_TRIGGERED\x10\t\x12#\n" +
+	"\x1fEVENT_TYPE_CERTIFICATE_EXPIRING\x10\n2h\n" +
+	"\x13NotificationService\x12Q\n" +
+	"\x04Send\x12#.nmapui.notification.v1.SendRequest\x1a$.nmapui.notification.v1.SendResponseBZZXgithub.com/furkansarikaya/nmap-ui-microservices/api/proto/notification/v1;notificationv1b\x06proto3"
+
+var (
+	file_notification_v1_notification_proto_rawDescOnce sync.Once
+	file_notification_v1_notification_proto_rawDescData []byte
+)
+
+func file_notification_v1_notification_proto_rawDescGZIP() []byte {
+	file_notification_v1_notification_proto_rawDescOnce.Do(func() {
+		file_notification_v1_notification_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_notification_v1_notification_proto_rawDesc), len(file_notification_v1_notification_proto_rawDesc)))
+	})
+	return file_notification_v1_notification_proto_rawDescData
+}
+
+var file_notification_v1_notification_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_notification_v1_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_notification_v1_notification_proto_goTypes = []any{
+	(EventType)(0),                // 0: nmapui.notification.v1.EventType
+	(*Notification)(nil),          // 1: nmapui.notification.v1.Notification
+	(*SendRequest)(nil),           // 2: nmapui.notification.v1.SendRequest
+	(*SendResponse)(nil),          // 3: nmapui.notification.v1.SendResponse
+	nil,                           // 4: nmapui.notification.v1.Notification.AttributesEntry
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_notification_v1_notification_proto_depIdxs = []int32{
+	0, // 0: nmapui.notification.v1.Notification.type:type_name -> nmapui.notification.v1.EventType
+	5, // 1: nmapui.notification.v1.Notification.occurred_at:type_name -> google.protobuf.Timestamp
+	4, // 2: nmapui.notification.v1.Notification.attributes:type_name -> nmapui.notification.v1.Notification.AttributesEntry
+	1, // 3: nmapui.notification.v1.SendRequest.notification:type_name -> nmapui.notification.v1.Notification
+	2, // 4: nmapui.notification.v1.NotificationService.Send:input_type -> nmapui.notification.v1.SendRequest
+	3, // 5: nmapui.notification.v1.NotificationService.Send:output_type -> nmapui.notification.v1.SendResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_notification_v1_notification_proto_init() }
+func file_notification_v1_notification_proto_init() {
+	if File_notification_v1_notification_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_v1_notification_proto_rawDesc), len(file_notification_v1_notification_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_notification_v1_notification_proto_goTypes,
+		DependencyIndexes: file_notification_v1_notification_proto_depIdxs,
+		EnumInfos:         file_notification_v1_notification_proto_enumTypes,
+		MessageInfos:      file_notification_v1_notification_proto_msgTypes,
+	}.Build()
+	File_notification_v1_notification_proto = out.File
+	file_notification_v1_notification_proto_goTypes = nil
+	file_notification_v1_notification_proto_depIdxs = nil
+}
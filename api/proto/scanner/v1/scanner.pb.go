@@ -0,0 +1,2150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: scanner/v1/scanner.proto
+
+package scannerv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ScanStatus is the status of a scan
+type ScanStatus int32
+
+const (
+	ScanStatus_SCAN_STATUS_UNSPECIFIED ScanStatus = 0
+	ScanStatus_SCAN_STATUS_PENDING     ScanStatus = 1
+	ScanStatus_SCAN_STATUS_RUNNING     ScanStatus = 2
+	ScanStatus_SCAN_STATUS_COMPLETED   ScanStatus = 3
+	ScanStatus_SCAN_STATUS_FAILED      ScanStatus = 4
+	ScanStatus_SCAN_STATUS_CANCELLED   ScanStatus = 5
+)
+
+// Enum value maps for ScanStatus.
+var (
+	ScanStatus_name = map[int32]string{
+		0: "SCAN_STATUS_UNSPECIFIED",
+		1: "SCAN_STATUS_PENDING",
+		2: "SCAN_STATUS_RUNNING",
+		3: "SCAN_STATUS_COMPLETED",
+		4: "SCAN_STATUS_FAILED",
+		5: "SCAN_STATUS_CANCELLED",
+	}
+	ScanStatus_value = map[string]int32{
+		"SCAN_STATUS_UNSPECIFIED": 0,
+		"SCAN_STATUS_PENDING":     1,
+		"SCAN_STATUS_RUNNING":     2,
+		"SCAN_STATUS_COMPLETED":   3,
+		"SCAN_STATUS_FAILED":      4,
+		"SCAN_STATUS_CANCELLED":   5,
+	}
+)
+
+func (x ScanStatus) Enum() *ScanStatus {
+	p := new(ScanStatus)
+	*p = x
+	return p
+}
+
+func (x ScanStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScanStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_scanner_v1_scanner_proto_enumTypes[0].Descriptor()
+}
+
+func (ScanStatus) Type() protoreflect.EnumType {
+	return &file_scanner_v1_scanner_proto_enumTypes[0]
+}
+
+func (x ScanStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScanStatus.Descriptor instead.
+func (ScanStatus) EnumDescriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{0}
+}
+
+// ScanOptions are the options a scan runs with
+type ScanOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Target host(s) or network
+	Target string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	// Port specification, e.g. "22,80,443" or "1-1000"
+	Ports string `protobuf:"bytes,2,opt,name=ports,proto3" json:"ports,omitempty"`
+	// SYN, CONNECT, UDP, VERSION, SCRIPT, ALL or PING
+	ScanType string `protobuf:"bytes,3,opt,name=scan_type,json=scanType,proto3" json:"scan_type,omitempty"`
+	// Timing template (0-5)
+	TimingTemplate   int32 `protobuf:"varint,4,opt,name=timing_template,json=timingTemplate,proto3" json:"timing_template,omitempty"`
+	ServiceDetection bool  `protobuf:"varint,5,opt,name=service_detection,json=serviceDetection,proto3" json:"service_detection,omitempty"`
+	OsDetection      bool  `protobuf:"varint,6,opt,name=os_detection,json=osDetection,proto3" json:"os_detection,omitempty"`
+	ScriptScan       bool  `protobuf:"varint,7,opt,name=script_scan,json=scriptScan,proto3" json:"script_scan,omitempty"`
+	// Extra command-line options
+	ExtraOptions []string `protobuf:"bytes,8,rep,name=extra_options,json=extraOptions,proto3" json:"extra_options,omitempty"`
+	// Scan timeout, 0 for the server default
+	TimeoutSeconds int32 `protobuf:"varint,9,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// Time spent on a single host before it is skipped, 0 for no limit
+	HostTimeoutSeconds int32 `protobuf:"varint,10,opt,name=host_timeout_seconds,json=hostTimeoutSeconds,proto3" json:"host_timeout_seconds,omitempty"`
+	// Packets per second sent at least, 0 for the timing template default
+	MinRate int32 `protobuf:"varint,11,opt,name=min_rate,json=minRate,proto3" json:"min_rate,omitempty"`
+	// Packets per second sent at most, 0 for no limit
+	MaxRate int32 `protobuf:"varint,12,opt,name=max_rate,json=maxRate,proto3" json:"max_rate,omitempty"`
+	// Probes outstanding at most, 0 for the timing template default
+	MaxParallelism int32 `protobuf:"varint,13,opt,name=max_parallelism,json=maxParallelism,proto3" json:"max_parallelism,omitempty"`
+	// Probe retransmissions at most, unset for the server default
+	MaxRetries *int32 `protobuf:"varint,14,opt,name=max_retries,json=maxRetries,proto3,oneof" json:"max_retries,omitempty"`
+	// Decoy addresses the probes appear to come from as well (-D)
+	Decoys []string `protobuf:"bytes,15,rep,name=decoys,proto3" json:"decoys,omitempty"`
+	// Source port of the probes (-g), 0 for random ports
+	SourcePort int32 `protobuf:"varint,16,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	// Split probes into small IP fragments (-f)
+	Fragment bool `protobuf:"varint,17,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	// Random bytes appended to the probes (--data-length)
+	DataLength int32 `protobuf:"varint,18,opt,name=data_length,json=dataLength,proto3" json:"data_length,omitempty"`
+	// Methods expanding a domain target into hosts: zone_transfer, bruteforce or ct
+	Discovery []string `protobuf:"bytes,19,rep,name=discovery,proto3" json:"discovery,omitempty"`
+	// Agent running the scan from its network, empty for the local scanner
+	Agent string `protobuf:"bytes,20,opt,name=agent,proto3" json:"agent,omitempty"`
+	// Scanner running the scan, empty for nmap
+	Engine string `protobuf:"bytes,21,opt,name=engine,proto3" json:"engine,omitempty"`
+	// Preset selecting the engine, e.g. fast
+	Mode          string `protobuf:"bytes,22,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanOptions) Reset() {
+	*x = ScanOptions{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanOptions) ProtoMessage() {}
+
+func (x *ScanOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanOptions.ProtoReflect.Descriptor instead.
+func (*ScanOptions) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ScanOptions) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ScanOptions) GetPorts() string {
+	if x != nil {
+		return x.Ports
+	}
+	return ""
+}
+
+func (x *ScanOptions) GetScanType() string {
+	if x != nil {
+		return x.ScanType
+	}
+	return ""
+}
+
+func (x *ScanOptions) GetTimingTemplate() int32 {
+	if x != nil {
+		return x.TimingTemplate
+	}
+	return 0
+}
+
+func (x *ScanOptions) GetServiceDetection() bool {
+	if x != nil {
+		return x.ServiceDetection
+	}
+	return false
+}
+
+func (x *ScanOptions) GetOsDetection() bool {
+	if x != nil {
+		return x.OsDetection
+	}
+	return false
+}
+
+func (x *ScanOptions) GetScriptScan() bool {
+	if x != nil {
+		return x.ScriptScan
+	}
+	return false
+}
+
+func (x *ScanOptions) GetExtraOptions() []string {
+	if x != nil {
+		return x.ExtraOptions
+	}
+	return nil
+}
+
+func (x *ScanOptions) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ScanOptions) GetHostTimeoutSeconds() int32 {
+	if x != nil {
+		return x.HostTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ScanOptions) GetMinRate() int32 {
+	if x != nil {
+		return x.MinRate
+	}
+	return 0
+}
+
+func (x *ScanOptions) GetMaxRate() int32 {
+	if x != nil {
+		return x.MaxRate
+	}
+	return 0
+}
+
+func (x *ScanOptions) GetMaxParallelism() int32 {
+	if x != nil {
+		return x.MaxParallelism
+	}
+	return 0
+}
+
+func (x *ScanOptions) GetMaxRetries() int32 {
+	if x != nil && x.MaxRetries != nil {
+		return *x.MaxRetries
+	}
+	return 0
+}
+
+func (x *ScanOptions) GetDecoys() []string {
+	if x != nil {
+		return x.Decoys
+	}
+	return nil
+}
+
+func (x *ScanOptions) GetSourcePort() int32 {
+	if x != nil {
+		return x.SourcePort
+	}
+	return 0
+}
+
+func (x *ScanOptions) GetFragment() bool {
+	if x != nil {
+		return x.Fragment
+	}
+	return false
+}
+
+func (x *ScanOptions) GetDataLength() int32 {
+	if x != nil {
+		return x.DataLength
+	}
+	return 0
+}
+
+func (x *ScanOptions) GetDiscovery() []string {
+	if x != nil {
+		return x.Discovery
+	}
+	return nil
+}
+
+func (x *ScanOptions) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+func (x *ScanOptions) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *ScanOptions) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// Scan is a scan job
+type Scan struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// User who started the scan
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Tenant (organization) of the user
+	TenantId string       `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Options  *ScanOptions `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+	Status   ScanStatus   `protobuf:"varint,5,opt,name=status,proto3,enum=nmapui.scanner.v1.ScanStatus" json:"status,omitempty"`
+	// Progress percentage (0-100)
+	Progress    float64                `protobuf:"fixed64,6,opt,name=progress,proto3" json:"progress,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// Error message if the scan failed
+	Error string `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	// ID of the result, empty until the scan completed
+	ResultId string `protobuf:"bytes,11,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`
+	// ID of the API request that started the scan
+	RequestId string `protobuf:"bytes,12,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Pipeline the scan is a stage of
+	PipelineId string `protobuf:"bytes,13,opt,name=pipeline_id,json=pipelineId,proto3" json:"pipeline_id,omitempty"`
+	// Workflow run the scan is a step of
+	WorkflowRunId string `protobuf:"bytes,14,opt,name=workflow_run_id,json=workflowRunId,proto3" json:"workflow_run_id,omitempty"`
+	// Scan this scan is a shard of
+	ParentId string `protobuf:"bytes,15,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Number of shards the scan was split into
+	ShardCount int32 `protobuf:"varint,16,opt,name=shard_count,json=shardCount,proto3" json:"shard_count,omitempty"`
+	// Whether the progress of the failed or cancelled scan was saved
+	Resumable bool `protobuf:"varint,17,opt,name=resumable,proto3" json:"resumable,omitempty"`
+	// When the scan was moved to the trash, unset unless it is in the trash
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Phase of the running scan: discovery, port_scan, service_detection or scripts
+	CurrentPhase string `protobuf:"bytes,19,opt,name=current_phase,json=currentPhase,proto3" json:"current_phase,omitempty"`
+	// When the current phase is estimated to complete, unset if unknown
+	EstimatedCompletion *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=estimated_completion,json=estimatedCompletion,proto3" json:"estimated_completion,omitempty"`
+	// How the scanner process of the failed scan failed, unset if it did not
+	Failure       *ScanFailure `protobuf:"bytes,21,opt,name=failure,proto3" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Scan) Reset() {
+	*x = Scan{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Scan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scan) ProtoMessage() {}
+
+func (x *Scan) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scan.ProtoReflect.Descriptor instead.
+func (*Scan) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Scan) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Scan) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Scan) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *Scan) GetOptions() *ScanOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Scan) GetStatus() ScanStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ScanStatus_SCAN_STATUS_UNSPECIFIED
+}
+
+func (x *Scan) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Scan) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Scan) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Scan) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Scan) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Scan) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+func (x *Scan) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *Scan) GetPipelineId() string {
+	if x != nil {
+		return x.PipelineId
+	}
+	return ""
+}
+
+func (x *Scan) GetWorkflowRunId() string {
+	if x != nil {
+		return x.WorkflowRunId
+	}
+	return ""
+}
+
+func (x *Scan) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Scan) GetShardCount() int32 {
+	if x != nil {
+		return x.ShardCount
+	}
+	return 0
+}
+
+func (x *Scan) GetResumable() bool {
+	if x != nil {
+		return x.Resumable
+	}
+	return false
+}
+
+func (x *Scan) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Scan) GetCurrentPhase() string {
+	if x != nil {
+		return x.CurrentPhase
+	}
+	return ""
+}
+
+func (x *Scan) GetEstimatedCompletion() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedCompletion
+	}
+	return nil
+}
+
+func (x *Scan) GetFailure() *ScanFailure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+// ScanFailure describes the scanner process of a failed scan
+type ScanFailure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Command line the scanner was run with
+	Command string `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	// Exit code, -1 if the process did not exit by itself
+	ExitCode int32 `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// End of the standard error output
+	Stderr string `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// Kind of failure: INTERNAL, PERMISSION_DENIED, UNRESOLVABLE_HOST, INTERFACE_NOT_FOUND or INVALID_PORT_SPEC
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// How the user can remedy the failure
+	Hint          string `protobuf:"bytes,5,opt,name=hint,proto3" json:"hint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanFailure) Reset() {
+	*x = ScanFailure{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanFailure) ProtoMessage() {}
+
+func (x *ScanFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanFailure.ProtoReflect.Descriptor instead.
+func (*ScanFailure) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ScanFailure) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ScanFailure) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ScanFailure) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+func (x *ScanFailure) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ScanFailure) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+// StartScanRequest is the request of StartScan
+type StartScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *ScanOptions           `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartScanRequest) Reset() {
+	*x = StartScanRequest{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartScanRequest) ProtoMessage() {}
+
+func (x *StartScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartScanRequest.ProtoReflect.Descriptor instead.
+func (*StartScanRequest) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StartScanRequest) GetOptions() *ScanOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// GetScanRequest is the request of GetScan
+type GetScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScanRequest) Reset() {
+	*x = GetScanRequest{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScanRequest) ProtoMessage() {}
+
+func (x *GetScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScanRequest.ProtoReflect.Descriptor instead.
+func (*GetScanRequest) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetScanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ListScansRequest is the request of ListScans
+type ListScansRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User whose scans are listed, empty for the caller. Admins only.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// List the scans of all users. Admins only.
+	All    bool       `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	Status ScanStatus `protobuf:"varint,3,opt,name=status,proto3,enum=nmapui.scanner.v1.ScanStatus" json:"status,omitempty"`
+	Target string     `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	// List the shards of a scan
+	ParentId string `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// List the scans in the trash instead
+	Deleted       bool                   `protobuf:"varint,6,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Sort field: created_at, completed_at, duration or status
+	Sort string `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Sort order: asc or desc
+	Order string `protobuf:"bytes,10,opt,name=order,proto3" json:"order,omitempty"`
+	// Maximum number of scans (1-100), 0 for 10
+	Limit  int32 `protobuf:"varint,11,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,12,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Cursor of the page from a previous response, takes precedence over the offset
+	Cursor        string `protobuf:"bytes,13,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScansRequest) Reset() {
+	*x = ListScansRequest{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScansRequest) ProtoMessage() {}
+
+func (x *ListScansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScansRequest.ProtoReflect.Descriptor instead.
+func (*ListScansRequest) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListScansRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListScansRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *ListScansRequest) GetStatus() ScanStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ScanStatus_SCAN_STATUS_UNSPECIFIED
+}
+
+func (x *ListScansRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ListScansRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *ListScansRequest) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *ListScansRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListScansRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListScansRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListScansRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListScansRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListScansRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListScansRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// ListScansResponse is the response of ListScans
+type ListScansResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Scans []*Scan                `protobuf:"bytes,1,rep,name=scans,proto3" json:"scans,omitempty"`
+	// Number of scans matching the filter
+	TotalCount int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	HasMore    bool  `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	// Cursor of the next page, empty on the last page
+	NextCursor    string `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScansResponse) Reset() {
+	*x = ListScansResponse{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScansResponse) ProtoMessage() {}
+
+func (x *ListScansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScansResponse.ProtoReflect.Descriptor instead.
+func (*ListScansResponse) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListScansResponse) GetScans() []*Scan {
+	if x != nil {
+		return x.Scans
+	}
+	return nil
+}
+
+func (x *ListScansResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListScansResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListScansResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// CancelScanRequest is the request of CancelScan
+type CancelScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScanRequest) Reset() {
+	*x = CancelScanRequest{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScanRequest) ProtoMessage() {}
+
+func (x *CancelScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScanRequest.ProtoReflect.Descriptor instead.
+func (*CancelScanRequest) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelScanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// CancelScanResponse is the response of CancelScan
+type CancelScanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScanResponse) Reset() {
+	*x = CancelScanResponse{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScanResponse) ProtoMessage() {}
+
+func (x *CancelScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScanResponse.ProtoReflect.Descriptor instead.
+func (*CancelScanResponse) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{8}
+}
+
+// GetScanResultRequest is the request of GetScanResult
+type GetScanResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScanResultRequest) Reset() {
+	*x = GetScanResultRequest{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScanResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScanResultRequest) ProtoMessage() {}
+
+func (x *GetScanResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScanResultRequest.ProtoReflect.Descriptor instead.
+func (*GetScanResultRequest) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetScanResultRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ScanResult is the result of a completed scan
+type ScanResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ScanId string                 `protobuf:"bytes,2,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	// User who started the scan
+	UserId    string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Duration in seconds
+	Duration float64 `protobuf:"fixed64,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Command that was run
+	Command string `protobuf:"bytes,7,opt,name=command,proto3" json:"command,omitempty"`
+	Summary string `protobuf:"bytes,8,opt,name=summary,proto3" json:"summary,omitempty"`
+	// Number of hosts scanned
+	TotalHosts int32 `protobuf:"varint,9,opt,name=total_hosts,json=totalHosts,proto3" json:"total_hosts,omitempty"`
+	// Number of hosts that were up
+	UpHosts int32   `protobuf:"varint,10,opt,name=up_hosts,json=upHosts,proto3" json:"up_hosts,omitempty"`
+	Hosts   []*Host `protobuf:"bytes,11,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	// Whether the scan timed out or was cancelled before it completed all hosts
+	Partial       bool `protobuf:"varint,12,opt,name=partial,proto3" json:"partial,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanResult) Reset() {
+	*x = ScanResult{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResult) ProtoMessage() {}
+
+func (x *ScanResult) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResult.ProtoReflect.Descriptor instead.
+func (*ScanResult) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ScanResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScanResult) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+func (x *ScanResult) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ScanResult) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ScanResult) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ScanResult) GetDuration() float64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *ScanResult) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ScanResult) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *ScanResult) GetTotalHosts() int32 {
+	if x != nil {
+		return x.TotalHosts
+	}
+	return 0
+}
+
+func (x *ScanResult) GetUpHosts() int32 {
+	if x != nil {
+		return x.UpHosts
+	}
+	return 0
+}
+
+func (x *ScanResult) GetHosts() []*Host {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *ScanResult) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+// Host is a host of a scan result
+type Host struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Ip        string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Hostnames []string               `protobuf:"bytes,2,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	// up or down
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// Operating system
+	Os       string        `protobuf:"bytes,4,opt,name=os,proto3" json:"os,omitempty"`
+	Ports    []*Port       `protobuf:"bytes,5,rep,name=ports,proto3" json:"ports,omitempty"`
+	Scripts  []*Script     `protobuf:"bytes,6,rep,name=scripts,proto3" json:"scripts,omitempty"`
+	Metadata *HostMetadata `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Geolocation and autonomous system, set by GeoIP enrichment
+	Geo *GeoInfo `protobuf:"bytes,8,opt,name=geo,proto3" json:"geo,omitempty"`
+	// Registered owner of the netblock, set by RDAP enrichment
+	Owner *NetworkOwner `protobuf:"bytes,9,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Annotations users attached to the host
+	Notes         []*Note `protobuf:"bytes,10,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Host) Reset() {
+	*x = Host{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Host) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Host) ProtoMessage() {}
+
+func (x *Host) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Host.ProtoReflect.Descriptor instead.
+func (*Host) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Host) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *Host) GetHostnames() []string {
+	if x != nil {
+		return x.Hostnames
+	}
+	return nil
+}
+
+func (x *Host) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Host) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *Host) GetPorts() []*Port {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *Host) GetScripts() []*Script {
+	if x != nil {
+		return x.Scripts
+	}
+	return nil
+}
+
+func (x *Host) GetMetadata() *HostMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Host) GetGeo() *GeoInfo {
+	if x != nil {
+		return x.Geo
+	}
+	return nil
+}
+
+func (x *Host) GetOwner() *NetworkOwner {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *Host) GetNotes() []*Note {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+// Port is a port of a host
+type Port struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Port  int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// tcp or udp
+	Protocol string `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// open, closed or filtered
+	State         string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Service       string `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	Product       string `protobuf:"bytes,5,opt,name=product,proto3" json:"product,omitempty"`
+	Version       string `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	ExtraInfo     string `protobuf:"bytes,7,opt,name=extra_info,json=extraInfo,proto3" json:"extra_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Port) Reset() {
+	*x = Port{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Port) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Port) ProtoMessage() {}
+
+func (x *Port) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Port.ProtoReflect.Descriptor instead.
+func (*Port) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Port) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Port) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *Port) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Port) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Port) GetProduct() string {
+	if x != nil {
+		return x.Product
+	}
+	return ""
+}
+
+func (x *Port) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Port) GetExtraInfo() string {
+	if x != nil {
+		return x.ExtraInfo
+	}
+	return ""
+}
+
+// Script is the output of an NSE script
+type Script struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Output string                 `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	// Structured output
+	Data map[string]string `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Port the script ran against, 0 for host scripts
+	Port          int32  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	Protocol      string `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Script) Reset() {
+	*x = Script{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Script) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Script) ProtoMessage() {}
+
+func (x *Script) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Script.ProtoReflect.Descriptor instead.
+func (*Script) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Script) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Script) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *Script) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Script) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Script) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+// HostMetadata is additional information about a host
+type HostMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Network distance (TTL)
+	Distance int32 `protobuf:"varint,1,opt,name=distance,proto3" json:"distance,omitempty"`
+	// System uptime in seconds
+	Uptime   float64                `protobuf:"fixed64,2,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	LastBoot *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_boot,json=lastBoot,proto3" json:"last_boot,omitempty"`
+	// TCP sequence prediction
+	TcpSequence string `protobuf:"bytes,4,opt,name=tcp_sequence,json=tcpSequence,proto3" json:"tcp_sequence,omitempty"`
+	// IP ID sequence generation
+	IpIdSequence  string `protobuf:"bytes,5,opt,name=ip_id_sequence,json=ipIdSequence,proto3" json:"ip_id_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostMetadata) Reset() {
+	*x = HostMetadata{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostMetadata) ProtoMessage() {}
+
+func (x *HostMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostMetadata.ProtoReflect.Descriptor instead.
+func (*HostMetadata) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HostMetadata) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *HostMetadata) GetUptime() float64 {
+	if x != nil {
+		return x.Uptime
+	}
+	return 0
+}
+
+func (x *HostMetadata) GetLastBoot() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastBoot
+	}
+	return nil
+}
+
+func (x *HostMetadata) GetTcpSequence() string {
+	if x != nil {
+		return x.TcpSequence
+	}
+	return ""
+}
+
+func (x *HostMetadata) GetIpIdSequence() string {
+	if x != nil {
+		return x.IpIdSequence
+	}
+	return ""
+}
+
+// GeoInfo is the geolocation and autonomous system of a host
+type GeoInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO 3166-1 alpha-2 country code
+	CountryCode string  `protobuf:"bytes,1,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Country     string  `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	City        string  `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Latitude    float64 `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude   float64 `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// Autonomous system number
+	Asn            uint32 `protobuf:"varint,6,opt,name=asn,proto3" json:"asn,omitempty"`
+	AsOrganization string `protobuf:"bytes,7,opt,name=as_organization,json=asOrganization,proto3" json:"as_organization,omitempty"`
+	// Internet service provider
+	Isp           string `protobuf:"bytes,8,opt,name=isp,proto3" json:"isp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoInfo) Reset() {
+	*x = GeoInfo{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoInfo) ProtoMessage() {}
+
+func (x *GeoInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoInfo.ProtoReflect.Descriptor instead.
+func (*GeoInfo) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GeoInfo) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *GeoInfo) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *GeoInfo) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GeoInfo) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoInfo) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoInfo) GetAsn() uint32 {
+	if x != nil {
+		return x.Asn
+	}
+	return 0
+}
+
+func (x *GeoInfo) GetAsOrganization() string {
+	if x != nil {
+		return x.AsOrganization
+	}
+	return ""
+}
+
+func (x *GeoInfo) GetIsp() string {
+	if x != nil {
+		return x.Isp
+	}
+	return ""
+}
+
+// NetworkOwner is the registration data of the netblock containing a host
+type NetworkOwner struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Registry handle of the netblock
+	Handle string `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// First address of the netblock
+	StartAddress string `protobuf:"bytes,3,opt,name=start_address,json=startAddress,proto3" json:"start_address,omitempty"`
+	// Last address of the netblock
+	EndAddress string `protobuf:"bytes,4,opt,name=end_address,json=endAddress,proto3" json:"end_address,omitempty"`
+	// Registration country
+	Country      string   `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	Organization string   `protobuf:"bytes,6,opt,name=organization,proto3" json:"organization,omitempty"`
+	AbuseEmails  []string `protobuf:"bytes,7,rep,name=abuse_emails,json=abuseEmails,proto3" json:"abuse_emails,omitempty"`
+	// URL of the RDAP record
+	Source        string `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetworkOwner) Reset() {
+	*x = NetworkOwner{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetworkOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkOwner) ProtoMessage() {}
+
+func (x *NetworkOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkOwner.ProtoReflect.Descriptor instead.
+func (*NetworkOwner) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *NetworkOwner) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *NetworkOwner) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NetworkOwner) GetStartAddress() string {
+	if x != nil {
+		return x.StartAddress
+	}
+	return ""
+}
+
+func (x *NetworkOwner) GetEndAddress() string {
+	if x != nil {
+		return x.EndAddress
+	}
+	return ""
+}
+
+func (x *NetworkOwner) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *NetworkOwner) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *NetworkOwner) GetAbuseEmails() []string {
+	if x != nil {
+		return x.AbuseEmails
+	}
+	return nil
+}
+
+func (x *NetworkOwner) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// Note is an annotation a user attached to a host
+type Note struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// User who wrote the note
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_scanner_v1_scanner_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_scanner_v1_scanner_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_scanner_v1_scanner_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Note) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Note) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Note) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Note) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_scanner_v1_scanner_proto protoreflect.FileDescriptor
+
+const file_scanner_v1_scanner_proto_rawDesc = "" +
+	"\n" +
+	"\x18scanner/v1/scanner.proto\x12\x11nmapui.scanner.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdd\x05\n" +
+	"\vScanOptions\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x14\n" +
+	"\x05ports\x18\x02 \x01(\tR\x05ports\x12\x1b\n" +
+	"\tscan_type\x18\x03 \x01(\tR\bscanType\x12'\n" +
+	"\x0ftiming_template\x18\x04 \x01(\x05R\x0etimingTemplate\x12+\n" +
+	"\x11service_detection\x18\x05 \x01(\bR\x10serviceDetection\x12!\n" +
+	"\fos_detection\x18\x06 \x01(\bR\vosDetection\x12\x1f\n" +
+	"\vscript_scan\x18\a \x01(\bR\n" +
+	"scriptScan\x12#\n" +
+	"\rextra_options\x18\b \x03(\tR\fextraOptions\x12'\n" +
+	"\x0ftimeout_seconds\x18\t \x01(\x05R\x0etimeoutSeconds\x120\n" +
+	"\x14host_timeout_seconds\x18\n" +
+	" \x01(\x05R\x12hostTimeoutSeconds\x12\x19\n" +
+	"\bmin_rate\x18\v \x01(\x05R\aminRate\x12\x19\n" +
+	"\bmax_rate\x18\f \x01(\x05R\amaxRate\x12'\n" +
+	"\x0fmax_parallelism\x18\r \x01(\x05R\x0emaxParallelism\x12$\n" +
+	"\vmax_retries\x18\x0e \x01(\x05H\x00R\n" +
+	"maxRetries\x88\x01\x01\x12\x16\n" +
+	"\x06decoys\x18\x0f \x03(\tR\x06decoys\x12\x1f\n" +
+	"\vsource_port\x18\x10 \x01(\x05R\n" +
+	"sourcePort\x12\x1a\n" +
+	"\bfragment\x18\x11 \x01(\bR\bfragment\x12\x1f\n" +
+	"\vdata_length\x18\x12 \x01(\x05R\n" +
+	"dataLength\x12\x1c\n" +
+	"\tdiscovery\x18\x13 \x03(\tR\tdiscovery\x12\x14\n" +
+	"\x05agent\x18\x14 \x01(\tR\x05agent\x12\x16\n" +
+	"\x06engine\x18\x15 \x01(\tR\x06engine\x12\x12\n" +
+	"\x04mode\x18\x16 \x01(\tR\x04modeB\x0e\n" +
+	"\f_max_retries\"\xee\x06\n" +
+	"\x04Scan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x128\n" +
+	"\aoptions\x18\x04 \x01(\v2\x1e.nmapui.scanner.v1.ScanOptionsR\aoptions\x125\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1d.nmapui.scanner.v1.ScanStatusR\x06status\x12\x1a\n" +
+	"\bprogress\x18\x06 \x01(\x01R\bprogress\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12\x1b\n" +
+	"\tresult_id\x18\v \x01(\tR\bresultId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\f \x01(\tR\trequestId\x12\x1f\n" +
+	"\vpipeline_id\x18\r \x01(\tR\n" +
+	"pipelineId\x12&\n" +
+	"\x0fworkflow_run_id\x18\x0e \x01(\tR\rworkflowRunId\x12\x1b\n" +
+	"\tparent_id\x18\x0f \x01(\tR\bparentId\x12\x1f\n" +
+	"\vshard_count\x18\x10 \x01(\x05R\n" +
+	"shardCount\x12\x1c\n" +
+	"\tresumable\x18\x11 \x01(\bR\tresumable\x129\n" +
+	"\n" +
+	"deleted_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12#\n" +
+	"\rcurrent_phase\x18\x13 \x01(\tR\fcurrentPhase\x12M\n" +
+	"\x14estimated_completion\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\x13estimatedCompletion\x128\n" +
+	"\afailure\x18\x15 \x01(\v2\x1e.nmapui.scanner.v1.ScanFailureR\afailure\"\x84\x01\n" +
+	"\vScanFailure\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x1b\n" +
+	"\texit_code\x18\x02 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06stderr\x18\x03 \x01(\tR\x06stderr\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x12\n" +
+	"\x04hint\x18\x05 \x01(\tR\x04hint\"L\n" +
+	"\x10StartScanRequest\x128\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1e.nmapui.scanner.v1.ScanOptionsR\aoptions\" \n" +
+	"\x0eGetScanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb7\x03\n" +
+	"\x10ListScansRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\x125\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1d.nmapui.scanner.v1.ScanStatusR\x06status\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\tR\bparentId\x12\x18\n" +
+	"\adeleted\x18\x06 \x01(\bR\adeleted\x12?\n" +
+	"\rcreated_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x12\n" +
+	"\x04sort\x18\t \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\n" +
+	" \x01(\tR\x05order\x12\x14\n" +
+	"\x05limit\x18\v \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\f \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\r \x01(\tR\x06cursor\"\x9f\x01\n" +
+	"\x11ListScansResponse\x12-\n" +
+	"\x05scans\x18\x01 \x03(\v2\x17.nmapui.scanner.v1.ScanR\x05scans\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\"#\n" +
+	"\x11CancelScanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
+	"\x12CancelScanResponse\"&\n" +
+	"\x14GetScanResultRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x95\x03\n" +
+	"\n" +
+	"ScanResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\ascan_id\x18\x02 \x01(\tR\x06scanId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1a\n" +
+	"\bduration\x18\x06 \x01(\x01R\bduration\x12\x18\n" +
+	"\acommand\x18\a \x01(\tR\acommand\x12\x18\n" +
+	"\asummary\x18\b \x01(\tR\asummary\x12\x1f\n" +
+	"\vtotal_hosts\x18\t \x01(\x05R\n" +
+	"totalHosts\x12\x19\n" +
+	"\bup_hosts\x18\n" +
+	" \x01(\x05R\aupHosts\x12-\n" +
+	"\x05hosts\x18\v \x03(\v2\x17.nmapui.scanner.v1.HostR\x05hosts\x12\x18\n" +
+	"\apartial\x18\f \x01(\bR\apartial\"\x91\x03\n" +
+	"\x04Host\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x1c\n" +
+	"\thostnames\x18\x02 \x03(\tR\thostnames\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x0e\n" +
+	"\x02os\x18\x04 \x01(\tR\x02os\x12-\n" +
+	"\x05ports\x18\x05 \x03(\v2\x17.nmapui.scanner.v1.PortR\x05ports\x123\n" +
+	"\ascripts\x18\x06 \x03(\v2\x19.nmapui.scanner.v1.ScriptR\ascripts\x12;\n" +
+	"\bmetadata\x18\a \x01(\v2\x1f.nmapui.scanner.v1.HostMetadataR\bmetadata\x12,\n" +
+	"\x03geo\x18\b \x01(\v2\x1a.nmapui.scanner.v1.GeoInfoR\x03geo\x125\n" +
+	"\x05owner\x18\t \x01(\v2\x1f.nmapui.scanner.v1.NetworkOwnerR\x05owner\x12-\n" +
+	"\x05notes\x18\n" +
+	" \x03(\v2\x17.nmapui.scanner.v1.NoteR\x05notes\"\xb9\x01\n" +
+	"\x04Port\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\x05R\x04port\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x18\n" +
+	"\aservice\x18\x04 \x01(\tR\aservice\x12\x18\n" +
+	"\aproduct\x18\x05 \x01(\tR\aproduct\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"extra_info\x18\a \x01(\tR\textraInfo\"\xd2\x01\n" +
+	"\x06Script\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06output\x18\x02 \x01(\tR\x06output\x127\n" +
+	"\x04data\x18\x03 \x03(\v2#.nmapui.scanner.v1.Script.DataEntryR\x04data\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x1a\n" +
+	"\bprotocol\x18\x05 \x01(\tR\bprotocol\x1a7\n" +
+	"\tDataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc4\x01\n" +
+	"\fHostMetadata\x12\x1a\n" +
+	"\bdistance\x18\x01 \x01(\x05R\bdistance\x12\x16\n" +
+	"\x06uptime\x18\x02 \x01(\x01R\x06uptime\x127\n" +
+	"\tlast_boot\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\blastBoot\x12!\n" +
+	"\ftcp_sequence\x18\x04 \x01(\tR\vtcpSequence\x12$\n" +
+	"\x0eip_id_sequence\x18\x05 \x01(\tR\fipIdSequence\"\xe1\x01\n" +
+	"\aGeoInfo\x12!\n" +
+	"\fcountry_code\x18\x01 \x01(\tR\vcountryCode\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12\x10\n" +
+	"\x03asn\x18\x06 \x01(\rR\x03asn\x12'\n" +
+	"\x0fas_organization\x18\a \x01(\tR\x0easOrganization\x12\x10\n" +
+	"\x03isp\x18\b \x01(\tR\x03isp\"\xf9\x01\n" +
+	"\fNetworkOwner\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\tR\x06handle\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rstart_address\x18\x03 \x01(\tR\fstartAddress\x12\x1f\n" +
+	"\vend_address\x18\x04 \x01(\tR\n" +
+	"endAddress\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x12\"\n" +
+	"\forganization\x18\x06 \x01(\tR\forganization\x12!\n" +
+	"\fabuse_emails\x18\a \x03(\tR\vabuseEmails\x12\x16\n" +
+	"\x06source\x18\b \x01(\tR\x06source\"~\n" +
+	"\x04Note\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt*\xa9\x01\n" +
+	"\n" +
+	"ScanStatus\x12\x1b\n" +
+	"\x17SCAN_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SCAN_STATUS_PENDING\x10\x01\x12\x17\n" +
+	"\x13SCAN_STATUS_RUNNING\x10\x02\x12\x19\n" +
+	"\x15SCAN_STATUS_COMPLETED\x10\x03\x12\x16\n" +
+	"\x12SCAN_STATUS_FAILED\x10\x04\x12\x19\n" +
+	"\x15SCAN_STATUS_CANCELLED\x10\x052\xbb\x04\n" +
+	"\x0eScannerService\x12i\n" +
+	"\tStartScan\x12#.nmapui.scanner.v1.StartScanRequest\x1a\x17.nmapui.scanner.v1.Scan\"\x1e\x82\xd3\xe4\x93\x02\x18:\aoptions\"\r/api/v2/scans\x12a\n" +
+	"\aGetScan\x12!.nmapui.scanner.v1.GetScanRequest\x1a\x17.nmapui.scanner.v1.Scan\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v2/scans/{id}\x12m\n" +
+	"\tListScans\x12#.nmapui.scanner.v1.ListScansRequest\x1a$.nmapui.scanner.v1.ListScansResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v2/scans\x12u\n" +
+	"\n" +
+	"CancelScan\x12$.nmapui.scanner.v1.CancelScanRequest\x1a%.nmapui.scanner.v1.CancelScanResponse\"\x1a\x82\xd3\xe4\x93\x02\x14*\x12/api/v2/scans/{id}\x12u\n" +
+	"\rGetScanResult\x12'.nmapui.scanner.v1.GetScanResultRequest\x1a\x1d.nmapui.scanner.v1.ScanResult\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v2/results/{id}BPZNgithub.com/furkansarikaya/nmap-ui-microservices/api/proto/scanner/v1;scannerv1b\x06proto3"
+
+var (
+	file_scanner_v1_scanner_proto_rawDescOnce sync.Once
+	file_scanner_v1_scanner_proto_rawDescData []byte
+)
+
+func file_scanner_v1_scanner_proto_rawDescGZIP() []byte {
+	file_scanner_v1_scanner_proto_rawDescOnce.Do(func() {
+		file_scanner_v1_scanner_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scanner_v1_scanner_proto_rawDesc), len(file_scanner_v1_scanner_proto_rawDesc)))
+	})
+	return file_scanner_v1_scanner_proto_rawDescData
+}
+
+var file_scanner_v1_scanner_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_scanner_v1_scanner_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_scanner_v1_scanner_proto_goTypes = []any{
+	(ScanStatus)(0),               // 0: nmapui.scanner.v1.ScanStatus
+	(*ScanOptions)(nil),           // 1: nmapui.scanner.v1.ScanOptions
+	(*Scan)(nil),                  // 2: nmapui.scanner.v1.Scan
+	(*ScanFailure)(nil),           // 3: nmapui.scanner.v1.ScanFailure
+	(*StartScanRequest)(nil),      // 4: nmapui.scanner.v1.StartScanRequest
+	(*GetScanRequest)(nil),        // 5: nmapui.scanner.v1.GetScanRequest
+	(*ListScansRequest)(nil),      // 6: nmapui.scanner.v1.ListScansRequest
+	(*ListScansResponse)(nil),     // 7: nmapui.scanner.v1.ListScansResponse
+	(*CancelScanRequest)(nil),     // 8: nmapui.scanner.v1.CancelScanRequest
+	(*CancelScanResponse)(nil),    // 9: nmapui.scanner.v1.CancelScanResponse
+	(*GetScanResultRequest)(nil),  // 10: nmapui.scanner.v1.GetScanResultRequest
+	(*ScanResult)(nil),            // 11: nmapui.scanner.v1.ScanResult
+	(*Host)(nil),                  // 12: nmapui.scanner.v1.Host
+	(*Port)(nil),                  // 13: nmapui.scanner.v1.Port
+	(*Script)(nil),                // 14: nmapui.scanner.v1.Script
+	(*HostMetadata)(nil),          // 15: nmapui.scanner.v1.HostMetadata
+	(*GeoInfo)(nil),               // 16: nmapui.scanner.v1.GeoInfo
+	(*NetworkOwner)(nil),          // 17: nmapui.scanner.v1.NetworkOwner
+	(*Note)(nil),                  // 18: nmapui.scanner.v1.Note
+	nil,                           // 19: nmapui.scanner.v1.Script.DataEntry
+	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
+}
+var file_scanner_v1_scanner_proto_depIdxs = []int32{
+	1,  // 0: nmapui.scanner.v1.Scan.options:type_name -> nmapui.scanner.v1.ScanOptions
+	0,  // 1: nmapui.scanner.v1.Scan.status:type_name -> nmapui.scanner.v1.ScanStatus
+	20, // 2: nmapui.scanner.v1.Scan.created_at:type_name -> google.protobuf.Timestamp
+	20, // 3: nmapui.scanner.v1.Scan.started_at:type_name -> google.protobuf.Timestamp
+	20, // 4: nmapui.scanner.v1.Scan.completed_at:type_name -> google.protobuf.Timestamp
+	20, // 5: nmapui.scanner.v1.Scan.deleted_at:type_name -> google.protobuf.Timestamp
+	20, // 6: nmapui.scanner.v1.Scan.estimated_completion:type_name -> google.protobuf.Timestamp
+	3,  // 7: nmapui.scanner.v1.Scan.failure:type_name -> nmapui.scanner.v1.ScanFailure
+	1,  // 8: nmapui.scanner.v1.StartScanRequest.options:type_name -> nmapui.scanner.v1.ScanOptions
+	0,  // 9: nmapui.scanner.v1.ListScansRequest.status:type_name -> nmapui.scanner.v1.ScanStatus
+	20, // 10: nmapui.scanner.v1.ListScansRequest.created_after:type_name -> google.protobuf.Timestamp
+	20, // 11: nmapui.scanner.v1.ListScansRequest.created_before:type_name -> google.protobuf.Timestamp
+	2,  // 12: nmapui.scanner.v1.ListScansResponse.scans:type_name -> nmapui.scanner.v1.Scan
+	20, // 13: nmapui.scanner.v1.ScanResult.start_time:type_name -> google.protobuf.Timestamp
+	20, // 14: nmapui.scanner.v1.ScanResult.end_time:type_name -> google.protobuf.Timestamp
+	12, // 15: nmapui.scanner.v1.ScanResult.hosts:type_name -> nmapui.scanner.v1.Host
+	13, // 16: nmapui.scanner.v1.Host.ports:type_name -> nmapui.scanner.v1.Port
+	14, // 17: nmapui.scanner.v1.Host.scripts:type_name -> nmapui.scanner.v1.Script
+	15, // 18: nmapui.scanner.v1.Host.metadata:type_name -> nmapui.scanner.v1.HostMetadata
+	16, // 19: nmapui.scanner.v1.Host.geo:type_name -> nmapui.scanner.v1.GeoInfo
+	17, // 20: nmapui.scanner.v1.Host.owner:type_name -> nmapui.scanner.v1.NetworkOwner
+	18, // 21: nmapui.scanner.v1.Host.notes:type_name -> nmapui.scanner.v1.Note
+	19, // 22: nmapui.scanner.v1.Script.data:type_name -> nmapui.scanner.v1.Script.DataEntry
+	20, // 23: nmapui.scanner.v1.HostMetadata.last_boot:type_name -> google.protobuf.Timestamp
+	20, // 24: nmapui.scanner.v1.Note.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 25: nmapui.scanner.v1.ScannerService.StartScan:input_type -> nmapui.scanner.v1.StartScanRequest
+	5,  // 26: nmapui.scanner.v1.ScannerService.GetScan:input_type -> nmapui.scanner.v1.GetScanRequest
+	6,  // 27: nmapui.scanner.v1.ScannerService.ListScans:input_type -> nmapui.scanner.v1.ListScansRequest
+	8,  // 28: nmapui.scanner.v1.ScannerService.CancelScan:input_type -> nmapui.scanner.v1.CancelScanRequest
+	10, // 29: nmapui.scanner.v1.ScannerService.GetScanResult:input_type -> nmapui.scanner.v1.GetScanResultRequest
+	2,  // 30: nmapui.scanner.v1.ScannerService.StartScan:output_type -> nmapui.scanner.v1.Scan
+	2,  // 31: nmapui.scanner.v1.ScannerService.GetScan:output_type -> nmapui.scanner.v1.Scan
+	7,  // 32: nmapui.scanner.v1.ScannerService.ListScans:output_type -> nmapui.scanner.v1.ListScansResponse
+	9,  // 33: nmapui.scanner.v1.ScannerService.CancelScan:output_type -> nmapui.scanner.v1.CancelScanResponse
+	11, // 34: nmapui.scanner.v1.ScannerService.GetScanResult:output_type -> nmapui.scanner.v1.ScanResult
+	30, // [30:35] is the sub-list for method output_type
+	25, // [25:30] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_scanner_v1_scanner_proto_init() }
+func file_scanner_v1_scanner_proto_init() {
+	if File_scanner_v1_scanner_proto != nil {
+		return
+	}
+	file_scanner_v1_scanner_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scanner_v1_scanner_proto_rawDesc), len(file_scanner_v1_scanner_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_scanner_v1_scanner_proto_goTypes,
+		DependencyIndexes: file_scanner_v1_scanner_proto_depIdxs,
+		EnumInfos:         file_scanner_v1_scanner_proto_enumTypes,
+		MessageInfos:      file_scanner_v1_scanner_proto_msgTypes,
+	}.Build()
+	File_scanner_v1_scanner_proto = out.File
+	file_scanner_v1_scanner_proto_goTypes = nil
+	file_scanner_v1_scanner_proto_depIdxs = nil
+}
@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: scanner/v1/scanner.proto
+
+package scannerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScannerService_StartScan_FullMethodName     = "/nmapui.scanner.v1.ScannerService/StartScan"
+	ScannerService_GetScan_FullMethodName       = "/nmapui.scanner.v1.ScannerService/GetScan"
+	ScannerService_ListScans_FullMethodName     = "/nmapui.scanner.v1.ScannerService/ListScans"
+	ScannerService_CancelScan_FullMethodName    = "/nmapui.scanner.v1.ScannerService/CancelScan"
+	ScannerService_GetScanResult_FullMethodName = "/nmapui.scanner.v1.ScannerService/GetScanResult"
+)
+
+// ScannerServiceClient is the client API for ScannerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScannerService starts and manages the scans of the scanner service
+type ScannerServiceClient interface {
+	// StartScan starts a scan and returns it in the pending state
+	StartScan(ctx context.Context, in *StartScanRequest, opts ...grpc.CallOption) (*Scan, error)
+	// GetScan returns a scan by ID
+	GetScan(ctx context.Context, in *GetScanRequest, opts ...grpc.CallOption) (*Scan, error)
+	// ListScans returns a page of the scans of the caller, newest first
+	ListScans(ctx context.Context, in *ListScansRequest, opts ...grpc.CallOption) (*ListScansResponse, error)
+	// CancelScan cancels a pending or running scan
+	CancelScan(ctx context.Context, in *CancelScanRequest, opts ...grpc.CallOption) (*CancelScanResponse, error)
+	// GetScanResult returns a scan result by ID
+	GetScanResult(ctx context.Context, in *GetScanResultRequest, opts ...grpc.CallOption) (*ScanResult, error)
+}
+
+type scannerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScannerServiceClient(cc grpc.ClientConnInterface) ScannerServiceClient {
+	return &scannerServiceClient{cc}
+}
+
+func (c *scannerServiceClient) StartScan(ctx context.Context, in *StartScanRequest, opts ...grpc.CallOption) (*Scan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Scan)
+	err := c.cc.Invoke(ctx, ScannerService_StartScan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerServiceClient) GetScan(ctx context.Context, in *GetScanRequest, opts ...grpc.CallOption) (*Scan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Scan)
+	err := c.cc.Invoke(ctx, ScannerService_GetScan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerServiceClient) ListScans(ctx context.Context, in *ListScansRequest, opts ...grpc.CallOption) (*ListScansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScansResponse)
+	err := c.cc.Invoke(ctx, ScannerService_ListScans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerServiceClient) CancelScan(ctx context.Context, in *CancelScanRequest, opts ...grpc.CallOption) (*CancelScanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScanResponse)
+	err := c.cc.Invoke(ctx, ScannerService_CancelScan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scannerServiceClient) GetScanResult(ctx context.Context, in *GetScanResultRequest, opts ...grpc.CallOption) (*ScanResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanResult)
+	err := c.cc.Invoke(ctx, ScannerService_GetScanResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScannerServiceServer is the server API for ScannerService service.
+// All implementations must embed UnimplementedScannerServiceServer
+// for forward compatibility.
+//
+// ScannerService starts and manages the scans of the scanner service
+type ScannerServiceServer interface {
+	// StartScan starts a scan and returns it in the pending state
+	StartScan(context.Context, *StartScanRequest) (*Scan, error)
+	// GetScan returns a scan by ID
+	GetScan(context.Context, *GetScanRequest) (*Scan, error)
+	// ListScans returns a page of the scans of the caller, newest first
+	ListScans(context.Context, *ListScansRequest) (*ListScansResponse, error)
+	// CancelScan cancels a pending or running scan
+	CancelScan(context.Context, *CancelScanRequest) (*CancelScanResponse, error)
+	// GetScanResult returns a scan result by ID
+	GetScanResult(context.Context, *GetScanResultRequest) (*ScanResult, error)
+	mustEmbedUnimplementedScannerServiceServer()
+}
+
+// UnimplementedScannerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScannerServiceServer struct{}
+
+func (UnimplementedScannerServiceServer) StartScan(context.Context, *StartScanRequest) (*Scan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartScan not implemented")
+}
+func (UnimplementedScannerServiceServer) GetScan(context.Context, *GetScanRequest) (*Scan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScan not implemented")
+}
+func (UnimplementedScannerServiceServer) ListScans(context.Context, *ListScansRequest) (*ListScansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScans not implemented")
+}
+func (UnimplementedScannerServiceServer) CancelScan(context.Context, *CancelScanRequest) (*CancelScanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScan not implemented")
+}
+func (UnimplementedScannerServiceServer) GetScanResult(context.Context, *GetScanResultRequest) (*ScanResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScanResult not implemented")
+}
+func (UnimplementedScannerServiceServer) mustEmbedUnimplementedScannerServiceServer() {}
+func (UnimplementedScannerServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeScannerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScannerServiceServer will
+// result in compilation errors.
+type UnsafeScannerServiceServer interface {
+	mustEmbedUnimplementedScannerServiceServer()
+}
+
+func RegisterScannerServiceServer(s grpc.ServiceRegistrar, srv ScannerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScannerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScannerService_ServiceDesc, srv)
+}
+
+func _ScannerService_StartScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerServiceServer).StartScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerService_StartScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerServiceServer).StartScan(ctx, req.(*StartScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerService_GetScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerServiceServer).GetScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerService_GetScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerServiceServer).GetScan(ctx, req.(*GetScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerService_ListScans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerServiceServer).ListScans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerService_ListScans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerServiceServer).ListScans(ctx, req.(*ListScansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerService_CancelScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerServiceServer).CancelScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerService_CancelScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerServiceServer).CancelScan(ctx, req.(*CancelScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScannerService_GetScanResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScanResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScannerServiceServer).GetScanResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScannerService_GetScanResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScannerServiceServer).GetScanResult(ctx, req.(*GetScanResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScannerService_ServiceDesc is the grpc.ServiceDesc for ScannerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScannerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nmapui.scanner.v1.ScannerService",
+	HandlerType: (*ScannerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartScan",
+			Handler:    _ScannerService_StartScan_Handler,
+		},
+		{
+			MethodName: "GetScan",
+			Handler:    _ScannerService_GetScan_Handler,
+		},
+		{
+			MethodName: "ListScans",
+			Handler:    _ScannerService_ListScans_Handler,
+		},
+		{
+			MethodName: "CancelScan",
+			Handler:    _ScannerService_CancelScan_Handler,
+		},
+		{
+			MethodName: "GetScanResult",
+			Handler:    _ScannerService_GetScanResult_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scanner/v1/scanner.proto",
+}
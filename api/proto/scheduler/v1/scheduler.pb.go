@@ -0,0 +1,525 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: scheduler/v1/scheduler.proto
+
+package schedulerv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Schedule is the schedule of a workflow and its state
+type Schedule struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	// Name of the workflow
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Cron expression of the scheduled runs
+	Cron string `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	// Whether scheduled runs are skipped
+	Paused   bool                   `protobuf:"varint,4,opt,name=paused,proto3" json:"paused,omitempty"`
+	PausedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=paused_at,json=pausedAt,proto3" json:"paused_at,omitempty"`
+	// User who paused the schedule
+	PausedBy string `protobuf:"bytes,6,opt,name=paused_by,json=pausedBy,proto3" json:"paused_by,omitempty"`
+	// Number of scheduled runs that were not started
+	SkippedRuns   int32                  `protobuf:"varint,7,opt,name=skipped_runs,json=skippedRuns,proto3" json:"skipped_runs,omitempty"`
+	LastSkippedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_skipped_at,json=lastSkippedAt,proto3" json:"last_skipped_at,omitempty"`
+	// Why the last scheduled run was skipped
+	LastSkipReason string `protobuf:"bytes,9,opt,name=last_skip_reason,json=lastSkipReason,proto3" json:"last_skip_reason,omitempty"`
+	// Most recent run of the workflow
+	LastRunId     string `protobuf:"bytes,10,opt,name=last_run_id,json=lastRunId,proto3" json:"last_run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_scheduler_v1_scheduler_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Schedule) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *Schedule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Schedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *Schedule) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *Schedule) GetPausedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PausedAt
+	}
+	return nil
+}
+
+func (x *Schedule) GetPausedBy() string {
+	if x != nil {
+		return x.PausedBy
+	}
+	return ""
+}
+
+func (x *Schedule) GetSkippedRuns() int32 {
+	if x != nil {
+		return x.SkippedRuns
+	}
+	return 0
+}
+
+func (x *Schedule) GetLastSkippedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSkippedAt
+	}
+	return nil
+}
+
+func (x *Schedule) GetLastSkipReason() string {
+	if x != nil {
+		return x.LastSkipReason
+	}
+	return ""
+}
+
+func (x *Schedule) GetLastRunId() string {
+	if x != nil {
+		return x.LastRunId
+	}
+	return ""
+}
+
+// ListSchedulesRequest is the request of ListSchedules
+type ListSchedulesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List the schedules of all users. Admins only.
+	All           bool `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_v1_scheduler_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListSchedulesRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+// ListSchedulesResponse is the response of ListSchedules
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*Schedule            `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_scheduler_v1_scheduler_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+// PauseScheduleRequest is the request of PauseSchedule
+type PauseScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduleRequest) Reset() {
+	*x = PauseScheduleRequest{}
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduleRequest) ProtoMessage() {}
+
+func (x *PauseScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduleRequest.ProtoReflect.Descriptor instead.
+func (*PauseScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_v1_scheduler_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PauseScheduleRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+// ResumeScheduleRequest is the request of ResumeSchedule
+type ResumeScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeScheduleRequest) Reset() {
+	*x = ResumeScheduleRequest{}
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeScheduleRequest) ProtoMessage() {}
+
+func (x *ResumeScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeScheduleRequest.ProtoReflect.Descriptor instead.
+func (*ResumeScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_v1_scheduler_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ResumeScheduleRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+// RunScheduleRequest is the request of RunSchedule
+type RunScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunScheduleRequest) Reset() {
+	*x = RunScheduleRequest{}
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunScheduleRequest) ProtoMessage() {}
+
+func (x *RunScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunScheduleRequest.ProtoReflect.Descriptor instead.
+func (*RunScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_scheduler_v1_scheduler_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RunScheduleRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+// RunScheduleResponse is the response of RunSchedule
+type RunScheduleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the started run
+	RunId         string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunScheduleResponse) Reset() {
+	*x = RunScheduleResponse{}
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunScheduleResponse) ProtoMessage() {}
+
+func (x *RunScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduler_v1_scheduler_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunScheduleResponse.ProtoReflect.Descriptor instead.
+func (*RunScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_scheduler_v1_scheduler_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RunScheduleResponse) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+var File_scheduler_v1_scheduler_proto protoreflect.FileDescriptor
+
+const file_scheduler_v1_scheduler_proto_rawDesc = "" +
+	"\n" +
+	"\x1cscheduler/v1/scheduler.proto\x12\x13nmapui.scheduler.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf2\x02\n" +
+	"\bSchedule\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04cron\x18\x03 \x01(\tR\x04cron\x12\x16\n" +
+	"\x06paused\x18\x04 \x01(\bR\x06paused\x127\n" +
+	"\tpaused_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bpausedAt\x12\x1b\n" +
+	"\tpaused_by\x18\x06 \x01(\tR\bpausedBy\x12!\n" +
+	"\fskipped_runs\x18\a \x01(\x05R\vskippedRuns\x12B\n" +
+	"\x0flast_skipped_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rlastSkippedAt\x12(\n" +
+	"\x10last_skip_reason\x18\t \x01(\tR\x0elastSkipReason\x12\x1e\n" +
+	"\vlast_run_id\x18\n" +
+	" \x01(\tR\tlastRunId\"(\n" +
+	"\x14ListSchedulesRequest\x12\x10\n" +
+	"\x03all\x18\x01 \x01(\bR\x03all\"T\n" +
+	"\x15ListSchedulesResponse\x12;\n" +
+	"\tschedules\x18\x01 \x03(\v2\x1d.nmapui.scheduler.v1.ScheduleR\tschedules\"7\n" +
+	"\x14PauseScheduleRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"8\n" +
+	"\x15ResumeScheduleRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"5\n" +
+	"\x12RunScheduleRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\",\n" +
+	"\x13RunScheduleResponse\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId2\xbf\x04\n" +
+	"\x10SchedulerService\x12\x81\x01\n" +
+	"\rListSchedules\x12).nmapui.scheduler.v1.ListSchedulesRequest\x1a*.nmapui.scheduler.v1.ListSchedulesResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v2/schedules\x12\x88\x01\n" +
+	"\rPauseSchedule\x12).nmapui.scheduler.v1.PauseScheduleRequest\x1a\x1d.nmapui.scheduler.v1.Schedule\"-\x82\xd3\xe4\x93\x02'\"%/api/v2/schedules/{workflow_id}/pause\x12\x8b\x01\n" +
+	"\x0eResumeSchedule\x12*.nmapui.scheduler.v1.ResumeScheduleRequest\x1a\x1d.nmapui.scheduler.v1.Schedule\".\x82\xd3\xe4\x93\x02(\"&/api/v2/schedules/{workflow_id}/resume\x12\x8d\x01\n" +
+	"\vRunSchedule\x12'.nmapui.scheduler.v1.RunScheduleRequest\x1a(.nmapui.scheduler.v1.RunScheduleResponse\"+\x82\xd3\xe4\x93\x02%\"#/api/v2/schedules/{workflow_id}/runBTZRgithub.com/furkansarikaya/nmap-ui-microservices/api/proto/scheduler/v1;schedulerv1b\x06proto3"
+
+var (
+	file_scheduler_v1_scheduler_proto_rawDescOnce sync.Once
+	file_scheduler_v1_scheduler_proto_rawDescData []byte
+)
+
+func file_scheduler_v1_scheduler_proto_rawDescGZIP() []byte {
+	file_scheduler_v1_scheduler_proto_rawDescOnce.Do(func() {
+		file_scheduler_v1_scheduler_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scheduler_v1_scheduler_proto_rawDesc), len(file_scheduler_v1_scheduler_proto_rawDesc)))
+	})
+	return file_scheduler_v1_scheduler_proto_rawDescData
+}
+
+var file_scheduler_v1_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_scheduler_v1_scheduler_proto_goTypes = []any{
+	(*Schedule)(nil),              // 0: nmapui.scheduler.v1.Schedule
+	(*ListSchedulesRequest)(nil),  // 1: nmapui.scheduler.v1.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil), // 2: nmapui.scheduler.v1.ListSchedulesResponse
+	(*PauseScheduleRequest)(nil),  // 3: nmapui.scheduler.v1.PauseScheduleRequest
+	(*ResumeScheduleRequest)(nil), // 4: nmapui.scheduler.v1.ResumeScheduleRequest
+	(*RunScheduleRequest)(nil),    // 5: nmapui.scheduler.v1.RunScheduleRequest
+	(*RunScheduleResponse)(nil),   // 6: nmapui.scheduler.v1.RunScheduleResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_scheduler_v1_scheduler_proto_depIdxs = []int32{
+	7, // 0: nmapui.scheduler.v1.Schedule.paused_at:type_name -> google.protobuf.Timestamp
+	7, // 1: nmapui.scheduler.v1.Schedule.last_skipped_at:type_name -> google.protobuf.Timestamp
+	0, // 2: nmapui.scheduler.v1.ListSchedulesResponse.schedules:type_name -> nmapui.scheduler.v1.Schedule
+	1, // 3: nmapui.scheduler.v1.SchedulerService.ListSchedules:input_type -> nmapui.scheduler.v1.ListSchedulesRequest
+	3, // 4: nmapui.scheduler.v1.SchedulerService.PauseSchedule:input_type -> nmapui.scheduler.v1.PauseScheduleRequest
+	4, // 5: nmapui.scheduler.v1.SchedulerService.ResumeSchedule:input_type -> nmapui.scheduler.v1.ResumeScheduleRequest
+	5, // 6: nmapui.scheduler.v1.SchedulerService.RunSchedule:input_type -> nmapui.scheduler.v1.RunScheduleRequest
+	2, // 7: nmapui.scheduler.v1.SchedulerService.ListSchedules:output_type -> nmapui.scheduler.v1.ListSchedulesResponse
+	0, // 8: nmapui.scheduler.v1.SchedulerService.PauseSchedule:output_type -> nmapui.scheduler.v1.Schedule
+	0, // 9: nmapui.scheduler.v1.SchedulerService.ResumeSchedule:output_type -> nmapui.scheduler.v1.Schedule
+	6, // 10: nmapui.scheduler.v1.SchedulerService.RunSchedule:output_type -> nmapui.scheduler.v1.RunScheduleResponse
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_scheduler_v1_scheduler_proto_init() }
+func file_scheduler_v1_scheduler_proto_init() {
+	if File_scheduler_v1_scheduler_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scheduler_v1_scheduler_proto_rawDesc), len(file_scheduler_v1_scheduler_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_scheduler_v1_scheduler_proto_goTypes,
+		DependencyIndexes: file_scheduler_v1_scheduler_proto_depIdxs,
+		MessageInfos:      file_scheduler_v1_scheduler_proto_msgTypes,
+	}.Build()
+	File_scheduler_v1_scheduler_proto = out.File
+	file_scheduler_v1_scheduler_proto_goTypes = nil
+	file_scheduler_v1_scheduler_proto_depIdxs = nil
+}
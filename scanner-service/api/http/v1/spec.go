@@ -0,0 +1,33 @@
+// Package v1 contains the OpenAPI specification of the v1 HTTP API.
+package v1
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+// SpecYAML returns the OpenAPI specification in YAML format
+func SpecYAML() []byte {
+	return specYAML
+}
+
+// SpecJSON returns the OpenAPI specification converted to JSON
+func SpecJSON() ([]byte, error) {
+	var spec map[string]interface{}
+	if err := yaml.Unmarshal(specYAML, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI specification: %w", err)
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI specification: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecJSON(t *testing.T) {
+	data, err := SpecJSON()
+	require.NoError(t, err)
+
+	var spec struct {
+		OpenAPI string                            `json:"openapi"`
+		Paths   map[string]map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(data, &spec))
+
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+	assert.Contains(t, spec.Paths, "/api/v1/scans")
+	assert.Contains(t, spec.Paths["/api/v1/scans"], "post")
+	assert.Contains(t, spec.Paths, "/api/v1/openapi.json")
+}
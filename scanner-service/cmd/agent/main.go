@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	agentadapters "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/agent/adapters"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/adapters"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/secrets"
+	"go.uber.org/zap"
+)
+
+// version is the agent build version, set with -ldflags "-X main.version=..."
+var version = "dev"
+
+func main() {
+	hostname, _ := os.Hostname()
+
+	// Define command-line flags
+	serverAddress := flag.String("server", envOrDefault("SCANNER_AGENT_SERVER", "localhost:9081"), "gRPC address of the scanner service (default $SCANNER_AGENT_SERVER)")
+	name := flag.String("name", envOrDefault("SCANNER_AGENT_NAME", hostname), "Unique agent name (default $SCANNER_AGENT_NAME or the hostname)")
+	token := flag.String("token", os.Getenv("SCANNER_AGENT_TOKEN"), "Shared agent token or a file: reference to it (default $SCANNER_AGENT_TOKEN)")
+	nmapPath := flag.String("nmap", "nmap", "Path of the nmap binary")
+	networks := flag.String("networks", os.Getenv("SCANNER_AGENT_NETWORKS"), "Comma-separated CIDRs reachable from the agent, empty for any target (default $SCANNER_AGENT_NETWORKS)")
+	maxJobs := flag.Int("max-jobs", 2, "Maximum concurrent scans, 0 for no limit")
+	maxRetries := flag.Int("max-retries", -1, "Probe retransmissions of scans not setting them, -1 for the nmap default")
+	useTLS := flag.Bool("tls", false, "Connect to the scanner service with TLS")
+	logLevel := flag.String("log-level", "info", "Log level (debug, info, warn, error)")
+
+	// Parse command-line flags
+	flag.Parse()
+
+	// The token may refer to a mounted secret file, e.g. file:agent_token
+	resolvedToken, err := secrets.NewResolver("/run/secrets").Resolve(context.Background(), *token)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	*token = resolvedToken
+
+	if *token == "" || *name == "" {
+		fmt.Println("Error: token and name are required")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	log, err := logger.NewLogger(logger.Config{
+		Level:  *logLevel,
+		Format: "json",
+		Output: "stdout",
+	})
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer log.Sync()
+
+	// Initialize nmap adapter
+	nmapAdapter := adapters.NewNmapAdapter(*nmapPath, log)
+	nmapAdapter.SetDefaultMaxRetries(*maxRetries)
+	if !nmapAdapter.IsAvailable() {
+		log.Fatal("Nmap is not available. Please install nmap and try again.")
+	}
+
+	client := agentadapters.NewAgentClient(agentadapters.ClientConfig{
+		ServerAddress: *serverAddress,
+		Token:         *token,
+		Name:          *name,
+		Version:       version,
+		TLS:           *useTLS,
+		Networks:      splitList(*networks),
+		MaxJobs:       *maxJobs,
+	}, nmapAdapter, log)
+
+	// Run until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Info("Starting scanning agent",
+		zap.String("name", *name),
+		zap.String("server", *serverAddress),
+		zap.String("version", version),
+	)
+
+	if err := client.Run(ctx); err != nil {
+		log.Fatal("Agent stopped", zap.Error(err))
+	}
+
+	log.Info("Agent stopped")
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// envOrDefault returns the environment variable or the default value if it is not set
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -3,20 +3,68 @@ package main
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
+	scannerv1 "github.com/furkansarikaya/nmap-ui-microservices/api/proto/scanner/v1"
+	schedulerv1 "github.com/furkansarikaya/nmap-ui-microservices/api/proto/scheduler/v1"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	agentdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/agent/domain"
+	agenthandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/agent/handlers"
+	alertingdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/alerting/domain"
+	alertinghandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/alerting/handlers"
+	alertingrepository "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/alerting/repository"
+	authadapters "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/adapters"
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	authrepository "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/repository"
+	certificatedomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/certificate/domain"
+	certificatehandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/certificate/handlers"
+	certificaterepository "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/certificate/repository"
+	compliancedomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/compliance/domain"
+	compliancehandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/compliance/handlers"
+	compliancerepository "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/compliance/repository"
+	discoveryadapters "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/discovery/adapters"
+	docshandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/docs/handlers"
+	enrichmentadapters "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/enrichment/adapters"
+	enrichmenthandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/enrichment/handlers"
+	graphqldomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/graphql/domain"
+	graphqlhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/graphql/handlers"
+	logginghandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/logging/handlers"
+	monitordomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/monitor/domain"
+	monitorhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/monitor/handlers"
+	monitorrepository "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/monitor/repository"
+	notificationadapters "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/adapters"
+	notificationdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	policydomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/policy/domain"
+	policyhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/policy/handlers"
+	policyrepository "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/policy/repository"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/adapters"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/handlers"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/repository"
+	workflowdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/domain"
+	workflowhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/handlers"
+	workflowrepository "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/repository"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/server"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/ratelimit"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/secrets"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Build metadata reported by the health endpoint, set with -ldflags "-X main.commit=... -X main.buildDate=..."
+var (
+	commit    = ""
+	buildDate = ""
 )
 
 func main() {
@@ -32,6 +80,16 @@ func main() {
 		Level:  cfg.Log.Level,
 		Format: cfg.Log.Format,
 		Output: cfg.Log.Output,
+		Sampling: logger.SamplingConfig{
+			Enabled:    cfg.Log.Sampling.Enabled,
+			Initial:    cfg.Log.Sampling.Initial,
+			Thereafter: cfg.Log.Sampling.Thereafter,
+			Tick:       cfg.Log.Sampling.Tick,
+		},
+		Redaction: logger.RedactionConfig{
+			Targets: cfg.Log.Redaction.Targets,
+			Fields:  cfg.Log.Redaction.Fields,
+		},
 	})
 	if err != nil {
 		fmt.Printf("Failed to initialize logger: %v\n", err)
@@ -39,43 +97,553 @@ func main() {
 	}
 	defer log.Sync()
 
+	// Initialize access logger, writing HTTP and gRPC requests to their own sink if enabled
+	accessLog := log
+	if cfg.Log.Access.Enabled {
+		accessLog, err = logger.NewAccessLogger(logger.Config{
+			Format: cfg.Log.Access.Format,
+			Output: cfg.Log.Access.Output,
+			Redaction: logger.RedactionConfig{
+				Targets: cfg.Log.Redaction.Targets,
+				Fields:  cfg.Log.Redaction.Fields,
+			},
+		})
+		if err != nil {
+			log.Fatal("Failed to initialize access logger", zap.Error(err))
+		}
+		defer accessLog.Sync()
+	}
+
 	log.Info("Starting Scanner Service",
 		zap.String("name", cfg.App.Name),
 		zap.String("version", cfg.App.Version),
 	)
+	log.Info("Effective configuration", zap.Strings("config", cfg.Summary()))
+
+	// Resolve secret settings, which may refer to environment variables, files or Vault
+	secretResolver := secrets.NewResolver(cfg.Secrets.FileDir)
+	if cfg.Secrets.Vault.Address != "" {
+		vaultToken, err := secretResolver.Resolve(context.Background(), cfg.Secrets.Vault.Token)
+		if err != nil {
+			log.Fatal("Failed to resolve Vault token", zap.Error(err))
+		}
+		secretResolver.Register(secrets.SchemeVault, secrets.NewVaultProvider(secrets.VaultConfig{
+			Address:   cfg.Secrets.Vault.Address,
+			Token:     vaultToken,
+			Namespace: cfg.Secrets.Vault.Namespace,
+		}))
+	}
+	resolveSecret := func(setting, value string) *secrets.Secret {
+		secret, err := secretResolver.Secret(context.Background(), value)
+		if err != nil {
+			log.Fatal("Failed to resolve secret", zap.String("setting", setting), zap.Error(err))
+		}
+		return secret
+	}
+	authSecret := resolveSecret("auth.secret", cfg.Auth.Secret)
+	oidcClientSecret := resolveSecret("auth.oidc.client_secret", cfg.Auth.OIDC.ClientSecret)
+	agentToken := resolveSecret("agents.token", cfg.Agents.Token)
+	redisPassword := resolveSecret("rate_limit.redis.password", cfg.RateLimit.Redis.Password)
+	secretsCtx, stopSecrets := context.WithCancel(context.Background())
+	defer stopSecrets()
+	if cfg.Secrets.RefreshInterval > 0 {
+		go secretResolver.Watch(secretsCtx, cfg.Secrets.RefreshInterval, log, authSecret, oidcClientSecret, agentToken, redisPassword)
+	}
 
-	// Initialize nmap adapter
-	nmapAdapter := adapters.NewNmapAdapter(cfg.Nmap.Path, log)
+	// Initialize nmap adapter, running nmap locally, in a container or a Kubernetes Job per scan, or
+	// returning canned results in dry-run mode
+	resourceLimits := adapters.ResourceLimits{
+		Nice:         cfg.Nmap.Resources.Nice,
+		IOClass:      cfg.Nmap.Resources.IOClass,
+		CPUs:         cfg.Nmap.Resources.CPUs,
+		MemoryBytes:  int64(cfg.Nmap.Resources.MemoryMB) << 20,
+		MaxRuntime:   cfg.Nmap.Resources.MaxRuntime,
+		CgroupParent: cfg.Nmap.Resources.CgroupParent,
+	}
+	var nmapAdapter domain.ScanAdapter
+	switch {
+	case cfg.Nmap.DryRun:
+		dryRunAdapter, err := adapters.NewDryRunAdapter(cfg.Nmap.FixturesDir, cfg.Nmap.DryRunDelay, log)
+		if err != nil {
+			log.Fatal("Failed to load scan fixtures", zap.Error(err))
+		}
+		log.Warn("Dry-run mode enabled, scans return canned results without running nmap")
+		nmapAdapter = dryRunAdapter
+	case cfg.Nmap.Backend == "docker":
+		dockerNmap := adapters.NewDockerAdapter(adapters.ContainerOptions{
+			DockerPath: cfg.Nmap.Docker.Path,
+			Image:      cfg.Nmap.Docker.Image,
+			NmapPath:   cfg.Nmap.Docker.NmapPath,
+			Network:    cfg.Nmap.Docker.Network,
+			CapAdd:     cfg.Nmap.Docker.CapAdd,
+			CapDrop:    cfg.Nmap.Docker.CapDrop,
+		}, log)
+		dockerNmap.SetDefaultMaxRetries(cfg.Nmap.MaxRetries)
+		dockerNmap.SetResourceLimits(resourceLimits)
+		log.Info("Scans run in containers", zap.String("image", cfg.Nmap.Docker.Image))
+		nmapAdapter = dockerNmap
+	case cfg.Nmap.Backend == "kubernetes":
+		kubernetesNmap, err := adapters.NewKubernetesAdapter(adapters.KubernetesOptions{
+			Namespace:      cfg.Nmap.Kubernetes.Namespace,
+			Image:          cfg.Nmap.Kubernetes.Image,
+			NmapPath:       cfg.Nmap.Kubernetes.NmapPath,
+			NodeSelector:   cfg.Nmap.Kubernetes.NodeSelector,
+			Labels:         cfg.Nmap.Kubernetes.Labels,
+			ServiceAccount: cfg.Nmap.Kubernetes.ServiceAccount,
+			CapAdd:         cfg.Nmap.Kubernetes.CapAdd,
+			CapDrop:        cfg.Nmap.Kubernetes.CapDrop,
+			PollInterval:   cfg.Nmap.Kubernetes.PollInterval,
+		}, log)
+		if err != nil {
+			log.Fatal("Failed to connect to the Kubernetes API", zap.Error(err))
+		}
+		kubernetesNmap.SetDefaultMaxRetries(cfg.Nmap.MaxRetries)
+		kubernetesNmap.SetResourceLimits(resourceLimits)
+		log.Info("Scans run in Kubernetes Jobs", zap.String("image", cfg.Nmap.Kubernetes.Image))
+		nmapAdapter = kubernetesNmap
+	default:
+		localNmap := adapters.NewNmapAdapter(cfg.Nmap.Path, log)
+		localNmap.SetDefaultMaxRetries(cfg.Nmap.MaxRetries)
+		localNmap.SetResourceLimits(resourceLimits)
+		nmapAdapter = localNmap
+	}
 
 	// Check if nmap is available
 	if !nmapAdapter.IsAvailable() {
 		log.Fatal("Nmap is not available. Please install nmap and try again.")
 	}
 
+	// Register the scan engines selectable besides nmap
+	engineRegistry := adapters.NewEngineRegistry(nmapAdapter)
+	optionalEngines := []struct {
+		engine  domain.ScanEngine
+		enabled bool
+		adapter domain.ScanAdapter
+	}{
+		{domain.ScanEngineMasscan, cfg.Engines.Masscan.Enabled,
+			adapters.NewMasscanAdapter(cfg.Engines.Masscan.Path, cfg.Engines.Masscan.Rate, log)},
+		{domain.ScanEngineRustscan, cfg.Engines.Rustscan.Enabled,
+			adapters.NewRustscanAdapter(cfg.Engines.Rustscan.Path, cfg.Engines.Rustscan.BatchSize, cfg.Engines.Rustscan.Timeout, nmapAdapter, log)},
+		{domain.ScanEngineZmap, cfg.Engines.Zmap.Enabled,
+			adapters.NewZmapAdapter(cfg.Engines.Zmap.Path, cfg.Engines.Zmap.Rate, log)},
+		{domain.ScanEngineNaabu, cfg.Engines.Naabu.Enabled,
+			adapters.NewNaabuAdapter(cfg.Engines.Naabu.Path, cfg.Engines.Naabu.Rate, log)},
+	}
+	for _, optional := range optionalEngines {
+		if !optional.enabled {
+			continue
+		}
+		if !optional.adapter.IsAvailable() {
+			log.Fatal("Scan engine is enabled but not available", zap.String("engine", string(optional.engine)))
+		}
+		engineRegistry.Register(optional.engine, optional.adapter)
+	}
+	if cfg.Engines.Hybrid.Enabled {
+		sweepEngine := domain.ScanEngine(cfg.Engines.Hybrid.SweepEngine)
+		sweepAdapter, ok := engineRegistry.Lookup(sweepEngine)
+		if !ok || sweepEngine == domain.ScanEngineNmap {
+			log.Fatal("Sweep engine of hybrid scans is not enabled", zap.String("engine", string(sweepEngine)))
+		}
+		engineRegistry.Register(domain.ScanEngineHybrid, adapters.NewHybridAdapter(sweepEngine, sweepAdapter, nmapAdapter, log))
+	}
+
 	// Initialize repository
 	scanRepo := repository.NewMemoryScanRepository(log, cfg.Storage.RetentionPeriod)
+	scanRepo.SetRetentionOverrides(cfg.Storage.UserRetention, cfg.Storage.TenantRetention)
+	scanRepo.SetResultCompression(cfg.Storage.CompressResults)
+
+	// Initialize policy service
+	policyRepo := policyrepository.NewMemoryPolicyRepository(log)
+	policyService := policydomain.NewPolicyService(policyRepo, log, cfg.Policy.RequireAllowlist)
+
+	blocklist, err := policydomain.NewBlocklist(cfg.Policy.Blocklist, nil, log)
+	if err != nil {
+		log.Fatal("Invalid target blocklist", zap.Error(err))
+	}
+
+	optionPolicy, err := policydomain.NewOptionPolicy(cfg.Policy.OptionRules, log)
+	if err != nil {
+		log.Fatal("Invalid scan option rules", zap.Error(err))
+	}
+
+	// Initialize scanning agents, dispatching scans that name an agent to it
+	var scanAdapter domain.ScanAdapter = engineRegistry
+	var agentService *agentdomain.AgentService
+	if cfg.Agents.Enabled {
+		if agentToken.Value() == "" {
+			log.Fatal("Scanning agents are enabled but the agent token is empty")
+		}
+		agentService = agentdomain.NewAgentService(log, cfg.Agents.HeartbeatTimeout)
+		agentService.Start()
+		scanAdapter = agentdomain.NewDispatcher(engineRegistry, agentService)
+	}
 
 	// Initialize scan service
-	scanService := domain.NewScanService(nmapAdapter, scanRepo, log, cfg.Nmap.MaxConcurrentScans)
+	scanService := domain.NewScanService(scanAdapter, scanRepo, log, cfg.Nmap.MaxConcurrentScans)
+	scanService.SetScanQueueSize(cfg.Nmap.QueueSize)
+	scanService.SetTargetAuthorizer(policyService)
+	scanService.SetTargetBlocklist(blocklist)
+	scanService.SetOptionAuthorizer(optionPolicy)
+	scanService.SetSharding(cfg.Nmap.ShardSize, cfg.Nmap.ShardConcurrency)
+	scanService.SetScanLimits(cfg.Nmap.MaxTimeout, cfg.Nmap.MaxHosts)
+	scanService.SetRateLimits(cfg.Nmap.MaxRate, cfg.Nmap.MaxParallelism)
+	scanService.SetEvasionEnabled(cfg.Nmap.EvasionEnabled)
+	scanService.SetDuplicateScanPolicy(domain.DuplicateScanPolicy(cfg.Nmap.DuplicateScans))
+	scanService.SetLogCapture(cfg.Nmap.LogLines, cfg.Nmap.LogScans)
+	scanService.SetBuildInfo(domain.BuildInfo{Version: cfg.App.Version, Commit: commit, BuildDate: buildDate})
+	if agentService != nil {
+		scanService.AddHealthChecker(agentService)
+	}
+	if cfg.Nmap.StateDir != "" {
+		if err := os.MkdirAll(cfg.Nmap.StateDir, 0o700); err != nil {
+			log.Fatal("Failed to create scan state directory", zap.Error(err))
+		}
+		scanService.SetStateDir(cfg.Nmap.StateDir)
+	}
+	if len(cfg.Storage.RetentionRules) > 0 {
+		retentionRules := make(map[domain.DataClass]time.Duration, len(cfg.Storage.RetentionRules))
+		for class, period := range cfg.Storage.RetentionRules {
+			retentionRules[domain.DataClass(class)] = period
+		}
+		scanService.SetRetentionRules(retentionRules)
+		scanService.StartRetention(cfg.Storage.RetentionInterval, cfg.Storage.RetentionDryRun)
+	}
+
+	// Initialize result enrichment
+	if cfg.Enrichment.GeoIP.Enabled {
+		geoIPEnricher, err := enrichmentadapters.NewGeoIPEnricher(enrichmentadapters.GeoIPConfig{
+			CityDBPath: cfg.Enrichment.GeoIP.CityDBPath,
+			ASNDBPath:  cfg.Enrichment.GeoIP.ASNDBPath,
+		}, log)
+		if err != nil {
+			log.Fatal("Failed to initialize GeoIP enrichment", zap.Error(err))
+		}
+		defer geoIPEnricher.Close()
+		scanService.AddResultEnricher(geoIPEnricher)
+	}
+
+	// Initialize target discovery
+	if cfg.Discovery.Enabled {
+		var wordlist []string
+		if cfg.Discovery.WordlistPath != "" {
+			wordlist, err = discoveryadapters.LoadWordlist(cfg.Discovery.WordlistPath)
+			if err != nil {
+				log.Fatal("Failed to load discovery wordlist", zap.Error(err))
+			}
+		}
+		scanService.SetTargetDiscoverer(discoveryadapters.NewDNSDiscoverer(discoveryadapters.DNSDiscoveryConfig{
+			CTURL:       cfg.Discovery.CTURL,
+			Timeout:     cfg.Discovery.Timeout,
+			MaxHosts:    cfg.Discovery.MaxHosts,
+			Wordlist:    wordlist,
+			Concurrency: cfg.Discovery.Concurrency,
+		}, nil, log))
+	}
+
+	var enrichmentHandler *enrichmenthandlers.EnrichmentHandler
+	if cfg.Enrichment.RDAP.Enabled {
+		rdapClient := enrichmentadapters.NewRDAPClient(enrichmentadapters.RDAPConfig{
+			BaseURL:  cfg.Enrichment.RDAP.BaseURL,
+			Timeout:  cfg.Enrichment.RDAP.Timeout,
+			CacheTTL: cfg.Enrichment.RDAP.CacheTTL,
+		}, log)
+		scanService.AddResultEnricher(enrichmentadapters.NewRDAPEnricher(rdapClient, cfg.Enrichment.RDAP.MaxLookups, log))
+		enrichmentHandler = enrichmenthandlers.NewEnrichmentHandler(rdapClient, log)
+	}
+
+	// Initialize workflow service
+	workflowRepo := workflowrepository.NewMemoryWorkflowRepository(log)
+	workflowService := workflowdomain.NewWorkflowService(workflowRepo, scanService, log)
+	workflowService.Start()
+
+	// Initialize compliance service
+	complianceRepo := compliancerepository.NewMemoryTemplateRepository(log)
+	complianceService := compliancedomain.NewComplianceService(complianceRepo, scanService, log)
+
+	// Initialize monitor service, alerting about changes through the notification service if enabled
+	monitorRepo := monitorrepository.NewMemoryMonitorRepository(log)
+	monitorService := monitordomain.NewMonitorService(monitorRepo, scanService, log)
+
+	// Initialize alert rules, checked against every completed scan
+	ruleRepo := alertingrepository.NewMemoryRuleRepository(log)
+	ruleService := alertingdomain.NewRuleService(ruleRepo, log)
+	scanService.AddCompletionListener(ruleService)
+
+	// Initialize certificate inventory, alerting owners before their certificates expire
+	certificateRepo := certificaterepository.NewMemoryCertificateRepository(log)
+	certificateService := certificatedomain.NewCertificateService(certificateRepo, log)
+	certificateService.SetExpiryAlerts(cfg.Notifications.CertificateExpiryDays)
+	scanService.AddCompletionListener(certificateService)
+
+	// Initialize panic alerts through the notification service if enabled
+	var panicHook server.PanicHook
+	if cfg.Notifications.Address != "" {
+		notifier, err := notificationadapters.NewGRPCNotifier(cfg.Notifications.Address, cfg.Notifications.Channels)
+		if err != nil {
+			log.Fatal("Failed to create notification service client", zap.Error(err))
+		}
+		defer notifier.Close()
+		monitorService.SetNotifier(notifier, cfg.Notifications.Timeout)
+		ruleService.SetNotifier(notifier, cfg.Notifications.Timeout)
+		certificateService.SetNotifier(notifier, cfg.Notifications.Timeout)
+
+		if cfg.Notifications.PanicAlerts {
+			alertService := notificationdomain.NewAlertService(notifier, cfg.App.Name, cfg.Notifications.Timeout, log)
+			panicHook = func(ctx context.Context, report server.PanicReport) {
+				alertService.AlertPanic(ctx, notificationdomain.Panic{
+					Value:     report.Value,
+					Stack:     report.Stack,
+					Method:    report.Method,
+					Path:      report.Path,
+					RequestID: report.RequestID,
+				})
+			}
+		}
+	}
+
+	monitorService.Start()
+	certificateService.Start()
 
 	// Initialize HTTP server
 	httpServer := server.NewHTTPServer(cfg.Server.HTTP, log)
+	httpServer.SetAccessLogger(accessLog)
+	httpServer.SetPanicHook(panicHook)
 	httpServer.SetupMiddleware()
 
 	// Initialize scan handler
 	scanHandler := handlers.NewScanHandler(scanService, log)
 
+	// Initialize policy handler
+	policyHandler := policyhandlers.NewPolicyHandler(policyService, log)
+
+	// Initialize workflow handler
+	workflowHandler := workflowhandlers.NewWorkflowHandler(workflowService, log)
+
+	// Initialize compliance handler
+	complianceHandler := compliancehandlers.NewComplianceHandler(complianceService, log)
+
+	// Initialize monitor handler
+	monitorHandler := monitorhandlers.NewMonitorHandler(monitorService, log)
+
+	// Initialize alert rule handler
+	ruleHandler := alertinghandlers.NewRuleHandler(ruleService, log)
+
+	// Initialize certificate handler
+	certificateHandler := certificatehandlers.NewCertificateHandler(certificateService, log)
+
+	// Initialize GraphQL handler
+	graphqlSchema, err := graphqldomain.NewSchema(scanService)
+	if err != nil {
+		log.Fatal("Failed to create GraphQL schema", zap.Error(err))
+	}
+	graphqlHandler := graphqlhandlers.NewGraphQLHandler(graphqlSchema, log)
+
+	// Initialize documentation handler
+	docsHandler, err := docshandlers.NewDocsHandler(log)
+	if err != nil {
+		log.Fatal("Failed to load OpenAPI specification", zap.Error(err))
+	}
+
+	// Initialize rate limiters, sharing their state through Redis if configured
+	var redisClient *redis.Client
+	if cfg.RateLimit.Enabled && cfg.RateLimit.Redis.Address != "" {
+		redisClient = redis.NewClient(&redis.Options{
+			Addr: cfg.RateLimit.Redis.Address,
+			DB:   cfg.RateLimit.Redis.DB,
+			CredentialsProvider: func() (string, string) {
+				return "", redisPassword.Value()
+			},
+		})
+		defer redisClient.Close()
+	}
+	newLimiter := func(name string, rule config.RateLimitRule) ratelimit.Limiter {
+		if redisClient == nil {
+			return ratelimit.NewTokenBucket(rule.RequestsPerMinute, rule.Burst)
+		}
+		limiter := ratelimit.NewRedisTokenBucket(redisClient, "nmapui:ratelimit:"+name+":", rule.RequestsPerMinute, rule.Burst)
+		limiter.SetErrorHandler(func(err error) {
+			log.Warn("Rate limit state unavailable, limiting in memory", zap.String("limit", name), zap.Error(err))
+		})
+		return limiter
+	}
+
+	// Initialize per-IP rate limiting, applied before authentication
+	var apiMiddleware []gin.HandlerFunc
+	if cfg.RateLimit.Enabled {
+		ipLimiter := newLimiter("per_ip", cfg.RateLimit.PerIP)
+		apiMiddleware = append(apiMiddleware, server.RateLimitMiddleware(ipLimiter, server.ClientIPKey, log))
+	}
+	publicMiddleware := append([]gin.HandlerFunc(nil), apiMiddleware...)
+
+	// Log request and response bodies of API routes at debug level if enabled
+	if cfg.Server.HTTP.BodyLogging.Enabled {
+		apiMiddleware = append(apiMiddleware, server.BodyLoggingMiddleware(cfg.Server.HTTP.BodyLogging, log))
+	}
+
+	// Initialize authentication
+	var authHandler *authhandlers.AuthHandler
+	var grpcAuth grpc.UnaryServerInterceptor
+	if cfg.Auth.Enabled {
+		var tokenValidator authdomain.TokenValidator
+		if cfg.Auth.OIDC.IssuerURL != "" {
+			oidcValidator, err := authadapters.NewOIDCValidator(context.Background(), authadapters.OIDCValidatorConfig{
+				IssuerURL:             cfg.Auth.OIDC.IssuerURL,
+				Audience:              cfg.Auth.Audience,
+				ClientID:              cfg.Auth.OIDC.ClientID,
+				ClientSecret:          oidcClientSecret.Value(),
+				GroupsClaim:           cfg.Auth.OIDC.GroupsClaim,
+				GroupRoles:            cfg.Auth.OIDC.GroupRoles,
+				Introspection:         cfg.Auth.OIDC.Introspection,
+				IntrospectionCacheTTL: cfg.Auth.OIDC.IntrospectionCacheTTL,
+				JWKSRefreshInterval:   cfg.Auth.JWKSRefreshInterval,
+			}, log)
+			if err != nil {
+				log.Fatal("Failed to configure OIDC provider", zap.Error(err))
+			}
+			oidcValidator.SetClientSecretSource(oidcClientSecret.Value)
+			tokenValidator = oidcValidator
+		} else if authSecret.Value() != "" || cfg.Auth.JWKSURL != "" {
+			jwtValidator, err := authadapters.NewJWTValidator(authadapters.JWTValidatorConfig{
+				Issuer:          cfg.Auth.Issuer,
+				Audience:        cfg.Auth.Audience,
+				JWKSURL:         cfg.Auth.JWKSURL,
+				Secret:          authSecret.Value(),
+				RolesClaim:      cfg.Auth.RolesClaim,
+				RefreshInterval: cfg.Auth.JWKSRefreshInterval,
+			}, log)
+			if err != nil {
+				log.Fatal("Failed to create JWT validator", zap.Error(err))
+			}
+			jwtValidator.SetSecretSource(authSecret.Value)
+			tokenValidator = jwtValidator
+		} else {
+			log.Warn("No JWT secret, JWKS URL or OIDC issuer configured, only API key authentication is available")
+		}
+
+		apiKeyRepo := authrepository.NewMemoryAPIKeyRepository(log)
+		defaultRole, ok := authdomain.ParseRole(cfg.Auth.DefaultRole)
+		if !ok {
+			log.Fatal("Invalid default role", zap.String("role", cfg.Auth.DefaultRole))
+		}
+
+		authService := authdomain.NewAuthService(tokenValidator, apiKeyRepo, authdomain.PrincipalConfig{
+			AdminUsers:  cfg.Auth.AdminUsers,
+			DefaultRole: defaultRole,
+			TenantClaim: cfg.Auth.TenantClaim,
+		}, log)
+		authHandler = authhandlers.NewAuthHandler(authService, log)
+		apiMiddleware = append(apiMiddleware, authHandler.Middleware())
+		grpcAuth = authHandler.UnaryInterceptor()
+	} else {
+		log.Warn("Authentication is disabled, all requests are attributed to the default user")
+		apiMiddleware = append(apiMiddleware, authhandlers.AnonymousMiddleware("default-user"))
+		grpcAuth = authhandlers.AnonymousUnaryInterceptor("default-user")
+	}
+
+	// Initialize per-user rate limiting
+	if cfg.RateLimit.Enabled {
+		userLimiter := newLimiter("per_user", cfg.RateLimit.PerUser)
+		startScanLimiter := newLimiter("start_scan", cfg.RateLimit.StartScan)
+		apiMiddleware = append(apiMiddleware,
+			server.RateLimitMiddleware(userLimiter, server.UserKey, log),
+			server.RouteRateLimitMiddleware(http.MethodPost, "/api/v1/scans", startScanLimiter, server.UserKey, log),
+			server.RouteRateLimitMiddleware(http.MethodPost, server.GatewayPrefix+"/scans", startScanLimiter, server.UserKey, log),
+		)
+
+		// Configured limits of single routes, per client IP or user
+		for _, name := range slices.Sorted(maps.Keys(cfg.RateLimit.Routes)) {
+			route := cfg.RateLimit.Routes[name]
+			keyFunc := server.ClientIPKey
+			if route.Key == "user" {
+				keyFunc = server.UserKey
+			}
+			apiMiddleware = append(apiMiddleware,
+				server.RouteRateLimitMiddleware(route.Method, route.Path, newLimiter("route:"+name, route.RateLimitRule), keyFunc, log))
+		}
+	}
+
 	// Register routes
 	httpServer.RegisterRoutes(func(router *gin.Engine) {
 		// Register scan handler routes
-		scanHandler.RegisterRoutes(router)
+		scanHandler.RegisterRoutes(router, apiMiddleware...)
+
+		// Register policy handler routes
+		policyHandler.RegisterRoutes(router, apiMiddleware...)
+
+		// Register workflow handler routes
+		workflowHandler.RegisterRoutes(router, apiMiddleware...)
+
+		// Register compliance handler routes
+		complianceHandler.RegisterRoutes(router, apiMiddleware...)
+
+		// Register monitor handler routes
+		monitorHandler.RegisterRoutes(router, apiMiddleware...)
+
+		// Register alert rule handler routes
+		ruleHandler.RegisterRoutes(router, apiMiddleware...)
+
+		// Register certificate handler routes
+		certificateHandler.RegisterRoutes(router, apiMiddleware...)
+
+		// Register documentation routes, accessible without credentials
+		docsHandler.RegisterRoutes(router, publicMiddleware...)
+
+		// Register log level routes
+		logginghandlers.NewLogLevelHandler(log).RegisterRoutes(router, apiMiddleware...)
+
+		// Register GraphQL handler routes
+		graphqlHandler.RegisterRoutes(router, apiMiddleware...)
+
+		// Register enrichment handler routes
+		if enrichmentHandler != nil {
+			enrichmentHandler.RegisterRoutes(router, apiMiddleware...)
+		}
+
+		// Register auth handler routes
+		if authHandler != nil {
+			authHandler.RegisterRoutes(router, apiMiddleware...)
+		}
+
+		// Register agent handler routes
+		if agentService != nil {
+			agenthandlers.NewAgentHandler(agentService, log).RegisterRoutes(router, apiMiddleware...)
+		}
 	})
 
 	// Initialize gRPC server
-	grpcServer, err := server.NewGRPCServer(cfg.Server.GRPC, log)
+	grpcServer, err := server.NewGRPCServer(cfg.Server.GRPC, log, grpcAuth)
 	if err != nil {
 		log.Fatal("Failed to create gRPC server", zap.Error(err))
 	}
+	grpcServer.SetAccessLogger(accessLog)
+	grpcServer.SetPanicHook(panicHook)
+
+	// Register the scanner and scheduler services of api/proto on the gRPC server
+	handlers.NewScanGRPCHandler(scanService, log).Register(grpcServer.Server())
+	workflowhandlers.NewScheduleGRPCHandler(workflowService, log).Register(grpcServer.Server())
+
+	// Register the agent service on the gRPC server
+	if agentService != nil {
+		agentHandler := agenthandlers.NewAgentGRPCHandler(agentService, agentToken.Value(), log)
+		agentHandler.SetTokenSource(agentToken.Value)
+		agentHandler.Register(grpcServer.Server())
+	}
+
+	// Initialize the REST gateway of the scanner and scheduler services, transcoded
+	// from api/proto. It passes the same middleware as /api/v1, so rate limits and
+	// body logging apply to both, and calls are authenticated again by the gRPC server.
+	// /api/v1 is kept: it serves every feature and existing clients, while the
+	// gateway only covers the scanner and scheduler services of api/proto.
+	gateway, err := server.NewGateway(context.Background(), grpcServer.LocalAddr(),
+		scannerv1.RegisterScannerServiceHandler,
+		schedulerv1.RegisterSchedulerServiceHandler,
+	)
+	if err != nil {
+		log.Fatal("Failed to create REST gateway", zap.Error(err))
+	}
+	httpServer.RegisterRoutes(func(router *gin.Engine) {
+		gateway.RegisterRoutes(router, apiMiddleware...)
+	})
 
 	// Start servers in separate goroutines
 	go func() {
@@ -102,10 +670,45 @@ func main() {
 
 	log.Info("Shutting down servers...")
 
+	// Stop accepting new scans and give active scans time to finish while the
+	// servers keep answering status requests
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.Server.DrainTimeout)
+	go func() {
+		// A second signal aborts the remaining scans immediately
+		select {
+		case <-quit:
+			log.Warn("Received second signal, aborting active scans")
+			cancelDrain()
+		case <-drainCtx.Done():
+		}
+	}()
+	aborted := scanService.Drain(drainCtx)
+	cancelDrain()
+	if aborted > 0 {
+		log.Warn("Aborted scans that did not finish in time", zap.Int("aborted_scans", aborted))
+	}
+
 	// Create a deadline to wait for
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Stop scheduling workflows and cancel active runs
+	workflowService.Stop()
+
+	// Stop scheduling monitors and cancel active runs
+	monitorService.Stop()
+
+	// Stop checking certificates for expiry
+	certificateService.Stop()
+
+	// Stop applying the retention rules
+	scanService.StopRetention()
+
+	// Stop marking stale agents offline
+	if agentService != nil {
+		agentService.Stop()
+	}
+
 	// Stop gRPC server
 	grpcServer.Stop()
 
@@ -114,5 +717,10 @@ func main() {
 		log.Error("Failed to gracefully shutdown HTTP server", zap.Error(err))
 	}
 
+	// Close the connection of the REST gateway
+	if err := gateway.Close(); err != nil {
+		log.Error("Failed to close REST gateway", zap.Error(err))
+	}
+
 	log.Info("Servers successfully shutdown")
 }
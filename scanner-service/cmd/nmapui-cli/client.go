@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+)
+
+// apiClient sends requests to the scanner service API
+type apiClient struct {
+	serverURL  string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// newRequest creates a request to an API path with authentication headers
+func (c *apiClient) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.serverURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	return req, nil
+}
+
+// send sends a request and returns the response if its status is one of the expected ones
+func (c *apiClient) send(method, path string, body io.Reader, expected ...int) (*http.Response, error) {
+	req, err := c.newRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if len(expected) == 0 {
+		expected = []int{http.StatusOK}
+	}
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return resp, nil
+		}
+	}
+
+	defer resp.Body.Close()
+	return nil, responseError(resp)
+}
+
+// responseError describes an unexpected response, using the message of API errors
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	var apiErr apimodels.ErrorResponse
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		message := fmt.Sprintf("%s (%d %s)", apiErr.Message, resp.StatusCode, apiErr.Type)
+		for _, field := range apiErr.Fields {
+			message += fmt.Sprintf("\n  %s: %s", field.Field, field.Message)
+		}
+		return fmt.Errorf("%s", message)
+	}
+	return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into result
+func (c *apiClient) do(method, path string, body, result any, expected ...int) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	resp, err := c.send(method, path, reader, expected...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// startScan starts a scan and returns the scan ID
+func (c *apiClient) startScan(req apimodels.StartScanRequest) (string, error) {
+	var result apimodels.StartScanResponse
+	if err := c.do(http.MethodPost, "/api/v1/scans", req, &result, http.StatusAccepted); err != nil {
+		return "", err
+	}
+	if result.ScanID == "" {
+		return "", fmt.Errorf("invalid response format, scan_id not found")
+	}
+	return result.ScanID, nil
+}
+
+// getScan gets a scan by ID
+func (c *apiClient) getScan(scanID string) (*apimodels.Scan, error) {
+	var scan apimodels.Scan
+	if err := c.do(http.MethodGet, "/api/v1/scans/"+url.PathEscape(scanID), nil, &scan); err != nil {
+		return nil, err
+	}
+	return &scan, nil
+}
+
+// getResult gets a scan result by ID
+func (c *apiClient) getResult(resultID string) (*apimodels.ScanResult, error) {
+	var result apimodels.ScanResult
+	if err := c.do(http.MethodGet, "/api/v1/results/"+url.PathEscape(resultID), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// getScanResult gets the result of a completed scan
+func (c *apiClient) getScanResult(scanID string) (*apimodels.ScanResult, error) {
+	scan, err := c.getScan(scanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if scan.ResultID == "" {
+		return nil, fmt.Errorf("no result available for scan %s with status %s", scanID, scan.Status)
+	}
+	return c.getResult(scan.ResultID)
+}
+
+// downloadExport downloads a scan result in an export format and writes it to a file
+func (c *apiClient) downloadExport(resultID, format, path string) error {
+	resp, err := c.send(http.MethodGet, "/api/v1/results/"+url.PathEscape(resultID)+"/export?format="+url.QueryEscape(format), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// A failed download must not leave a truncated file behind
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to download result: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+	"github.com/spf13/cobra"
+)
+
+// newDiffCommand creates the command comparing two scan results
+func newDiffCommand(c *cli) *cobra.Command {
+	var byScan bool
+
+	cmd := &cobra.Command{
+		Use:   "diff <old-result-id> <new-result-id>",
+		Short: "Show the hosts and open ports that changed between two scan results",
+		Long: `Compares two scan results and lists hosts up that appeared or disappeared, ports that
+were opened or closed and services whose product or version changed.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := c.client()
+			before, err := getResultArg(client, args[0], byScan)
+			if err != nil {
+				return err
+			}
+			after, err := getResultArg(client, args[1], byScan)
+			if err != nil {
+				return err
+			}
+
+			changes := diffResults(before, after)
+			header := []string{"change", "ip", "port", "before", "after"}
+			rows := make([][]string, len(changes))
+			for i, change := range changes {
+				port := ""
+				if change.Port != 0 {
+					port = fmt.Sprintf("%d/%s", change.Port, change.Protocol)
+				}
+				rows[i] = []string{string(change.Type), change.Host, port, change.Before, change.After}
+			}
+			if err := renderTable(os.Stdout, c.output, header, rows, nil); err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				fmt.Fprintln(os.Stderr, "No changes found")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&byScan, "scan", false, "The arguments are the IDs of the scans instead of their results")
+	return cmd
+}
+
+// diffResults returns the changes from one result to another, ordered by host, port and
+// change type, as the monitors of the service report them
+func diffResults(before, after *apimodels.ScanResult) []scandomain.ResultChange {
+	return scandomain.DiffResults(domainResult(before), domainResult(after))
+}
+
+// domainResult converts the hosts and ports of a result to the model of the service
+func domainResult(result *apimodels.ScanResult) *scandomain.ScanResult {
+	converted := &scandomain.ScanResult{Hosts: make([]scandomain.Host, len(result.Hosts))}
+	for i, host := range result.Hosts {
+		converted.Hosts[i] = scandomain.Host{IP: host.IP, Status: host.Status, Ports: make([]scandomain.Port, len(host.Ports))}
+		for j, port := range host.Ports {
+			converted.Hosts[i].Ports[j] = scandomain.Port(port)
+		}
+	}
+	return converted
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffResults(t *testing.T) {
+	before := &apimodels.ScanResult{Hosts: []apimodels.Host{
+		{IP: "10.0.0.10", Status: "up", Ports: []apimodels.Port{
+			{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH", Version: "8.9"},
+			{Port: 80, Protocol: "tcp", State: "open", Service: "http"},
+			{Port: 443, Protocol: "tcp", State: "closed"},
+		}},
+		{IP: "10.0.0.2", Status: "up", Ports: []apimodels.Port{
+			{Port: 53, Protocol: "udp", State: "open", Service: "domain"},
+		}},
+		{IP: "10.0.0.3", Status: "down"},
+	}}
+	after := &apimodels.ScanResult{Hosts: []apimodels.Host{
+		{IP: "10.0.0.3", Status: "up", Ports: []apimodels.Port{
+			{Port: 3389, Protocol: "tcp", State: "open", Service: "ms-wbt-server"},
+		}},
+		{IP: "10.0.0.10", Status: "up", Ports: []apimodels.Port{
+			{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH", Version: "9.6"},
+			{Port: 80, Protocol: "tcp", State: "filtered", Service: "http"},
+			{Port: 443, Protocol: "tcp", State: "open", Service: "https"},
+		}},
+	}}
+
+	tests := []struct {
+		name    string
+		before  *apimodels.ScanResult
+		after   *apimodels.ScanResult
+		changes []scandomain.ResultChange
+	}{
+		{
+			name:   "changes ordered by host address and port",
+			before: before,
+			after:  after,
+			changes: []scandomain.ResultChange{
+				{Type: scandomain.ResultChangeHostRemoved, Host: "10.0.0.2"},
+				{Type: scandomain.ResultChangeHostAdded, Host: "10.0.0.3"},
+				{Type: scandomain.ResultChangePortOpened, Host: "10.0.0.3", Port: 3389, Protocol: "tcp", After: "ms-wbt-server"},
+				{Type: scandomain.ResultChangeServiceChanged, Host: "10.0.0.10", Port: 22, Protocol: "tcp", Before: "ssh OpenSSH 8.9", After: "ssh OpenSSH 9.6"},
+				{Type: scandomain.ResultChangePortClosed, Host: "10.0.0.10", Port: 80, Protocol: "tcp", Before: "http"},
+				{Type: scandomain.ResultChangePortOpened, Host: "10.0.0.10", Port: 443, Protocol: "tcp", After: "https"},
+			},
+		},
+		{
+			name:   "same result",
+			before: after,
+			after:  after,
+		},
+		{
+			name:   "empty results",
+			before: &apimodels.ScanResult{},
+			after:  &apimodels.ScanResult{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.changes, diffResults(test.before, test.after))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Command nmapui-cli starts, follows and manages scans of the scanner service
+// from the command line.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// version is the CLI build version, set with -ldflags "-X main.version=..."
+var version = "dev"
+
+// exitError ends the CLI with a specific exit status
+type exitError struct {
+	code    int
+	message string
+}
+
+// Error returns the message of the error
+func (e *exitError) Error() string {
+	return e.message
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+)
+
+// column is a column of the host table, holding a field of a host or of one of its ports.
+// port is nil for hosts without ports.
+type column struct {
+	name  string
+	value func(host *apimodels.Host, port *apimodels.Port) string
+}
+
+// portField returns a column of a port field, empty for hosts without ports
+func portField(name string, value func(port *apimodels.Port) string) column {
+	return column{name, func(_ *apimodels.Host, port *apimodels.Port) string {
+		if port == nil {
+			return ""
+		}
+		return value(port)
+	}}
+}
+
+// columns lists the columns of the host table in their order with --output wide
+var columns = []column{
+	{"ip", func(host *apimodels.Host, _ *apimodels.Port) string { return host.IP }},
+	{"hostnames", func(host *apimodels.Host, _ *apimodels.Port) string { return strings.Join(host.Hostnames, ",") }},
+	{"status", func(host *apimodels.Host, _ *apimodels.Port) string { return host.Status }},
+	{"os", func(host *apimodels.Host, _ *apimodels.Port) string { return host.OS }},
+	portField("port", func(port *apimodels.Port) string { return strconv.Itoa(port.Port) }),
+	portField("protocol", func(port *apimodels.Port) string { return port.Protocol }),
+	portField("state", func(port *apimodels.Port) string { return port.State }),
+	portField("service", func(port *apimodels.Port) string { return port.Service }),
+	portField("product", func(port *apimodels.Port) string { return port.Product }),
+	portField("version", func(port *apimodels.Port) string { return port.Version }),
+}
+
+// defaultColumns are the columns shown unless --output wide or --columns is given
+var defaultColumns = []string{"ip", "port", "protocol", "state", "service"}
+
+// columnNames returns the names of all columns
+func columnNames() []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.name
+	}
+	return names
+}
+
+// selectColumns returns the columns of an output format, or the named columns if any.
+// JSON output without named columns holds the whole result and has no columns.
+func selectColumns(output string, names []string) ([]column, error) {
+	if len(names) == 0 {
+		switch output {
+		case "json":
+			return nil, nil
+		case "wide":
+			return columns, nil
+		}
+		names = defaultColumns
+	}
+
+	var result []column
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		i := slices.IndexFunc(columns, func(c column) bool { return c.name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		result = append(result, columns[i])
+	}
+	return result, nil
+}
+
+// hostRows returns the values of the columns for every port of the result, and for
+// every host without ports
+func hostRows(result *apimodels.ScanResult, cols []column) [][]string {
+	var rows [][]string
+	row := func(host *apimodels.Host, port *apimodels.Port) {
+		values := make([]string, len(cols))
+		for i, col := range cols {
+			values[i] = col.value(host, port)
+		}
+		rows = append(rows, values)
+	}
+
+	for i := range result.Hosts {
+		host := &result.Hosts[i]
+		if len(host.Ports) == 0 {
+			row(host, nil)
+			continue
+		}
+		for j := range host.Ports {
+			row(host, &host.Ports[j])
+		}
+	}
+	return rows
+}
+
+// renderResult writes the hosts of a result as a table, CSV or JSON. JSON holds the
+// whole result unless columns were selected, then the selected columns of every row.
+func renderResult(w io.Writer, result *apimodels.ScanResult, output string, cols []column) error {
+	if output == "json" && cols == nil {
+		return writeJSON(w, result)
+	}
+
+	header := make([]string, len(cols))
+	for i, col := range cols {
+		header[i] = col.name
+	}
+	return renderTable(w, output, header, hostRows(result, cols), nil)
+}
+
+// renderTable writes rows as a table or CSV. JSON holds value, or objects keyed by the
+// header of every row if value is nil.
+func renderTable(w io.Writer, output string, header []string, rows [][]string, value any) error {
+	switch output {
+	case "json":
+		if value != nil {
+			return writeJSON(w, value)
+		}
+		objects := make([]map[string]string, len(rows))
+		for i, row := range rows {
+			objects[i] = make(map[string]string, len(header))
+			for j, cell := range row {
+				objects[i][header[j]] = cell
+			}
+		}
+		return writeJSON(w, objects)
+	case "csv":
+		writer := csv.NewWriter(w)
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// writeJSON writes a value as indented JSON
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
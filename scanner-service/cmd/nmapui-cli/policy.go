@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+)
+
+// exitPolicyViolation is the exit status when findings violate the --fail-on policy,
+// distinct from errors (1)
+const exitPolicyViolation = 3
+
+// gatePolicy describes the findings that fail the CLI for CI gating
+type gatePolicy struct {
+	ports      map[int]bool // Ports that must not be open
+	vulnerable bool         // Whether scripts must not report vulnerabilities
+}
+
+// enabled reports whether the policy checks anything
+func (p gatePolicy) enabled() bool {
+	return len(p.ports) > 0 || p.vulnerable
+}
+
+// violations returns the findings of a result that violate the policy. As in the scan
+// summaries of the service, a script reports a host as vulnerable when its output
+// contains VULNERABLE.
+func (p gatePolicy) violations(result *apimodels.ScanResult) []string {
+	var violations []string
+	for _, host := range result.Hosts {
+		for _, port := range host.Ports {
+			if port.State == "open" && p.ports[port.Port] {
+				violations = append(violations, fmt.Sprintf("%s: port %d/%s is open", host.IP, port.Port, port.Protocol))
+			}
+		}
+
+		if !p.vulnerable {
+			continue
+		}
+		for _, script := range host.Scripts {
+			if strings.Contains(script.Output, "VULNERABLE") {
+				violations = append(violations, fmt.Sprintf("%s: script %s reports a vulnerability", host.IP, script.ID))
+			}
+		}
+	}
+	return violations
+}
+
+// parsePortList parses a comma-separated list of ports and port ranges
+func parsePortList(list string) (map[int]bool, error) {
+	ports := make(map[int]bool)
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		low, high, isRange := strings.Cut(item, "-")
+		first, err := strconv.Atoi(low)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", item)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(high); err != nil {
+				return nil, fmt.Errorf("invalid port range %q", item)
+			}
+		}
+		if first < 1 || last > 65535 || first > last {
+			return nil, fmt.Errorf("invalid port range %q, ports must be between 1 and 65535", item)
+		}
+		for port := first; port <= last; port++ {
+			ports[port] = true
+		}
+	}
+	return ports, nil
+}
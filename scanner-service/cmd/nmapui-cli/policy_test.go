@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortList(t *testing.T) {
+	tests := []struct {
+		list  string
+		ports []int
+		err   string
+	}{
+		{list: "22", ports: []int{22}},
+		{list: "22, 80-82 ,443", ports: []int{22, 80, 81, 82, 443}},
+		{list: "1-3,2,3-4,2", ports: []int{1, 2, 3, 4}},
+		{list: "65535", ports: []int{65535}},
+		{list: "", err: `invalid port ""`},
+		{list: "ssh", err: `invalid port "ssh"`},
+		{list: "22,", err: `invalid port ""`},
+		{list: "-80", err: `invalid port "-80"`},
+		{list: "80-", err: `invalid port range "80-"`},
+		{list: "80-http", err: `invalid port range "80-http"`},
+		{list: "1-2-3", err: `invalid port range "1-2-3"`},
+		{list: "0", err: `invalid port range "0", ports must be between 1 and 65535`},
+		{list: "65536", err: `invalid port range "65536", ports must be between 1 and 65535`},
+		{list: "1-65536", err: `invalid port range "1-65536", ports must be between 1 and 65535`},
+		{list: "90-80", err: `invalid port range "90-80", ports must be between 1 and 65535`},
+	}
+	for _, test := range tests {
+		t.Run(test.list, func(t *testing.T) {
+			ports, err := parsePortList(test.list)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			expected := make(map[int]bool)
+			for _, port := range test.ports {
+				expected[port] = true
+			}
+			assert.Equal(t, expected, ports)
+		})
+	}
+}
+
+func TestGatePolicyViolations(t *testing.T) {
+	result := &apimodels.ScanResult{Hosts: []apimodels.Host{
+		{IP: "10.0.0.1", Ports: []apimodels.Port{
+			{Port: 22, Protocol: "tcp", State: "open"},
+			{Port: 23, Protocol: "tcp", State: "filtered"},
+			{Port: 3389, Protocol: "tcp", State: "open"},
+		}, Scripts: []apimodels.Script{
+			{ID: "ssl-heartbleed", Output: "State: VULNERABLE"},
+			{ID: "http-title", Output: "Welcome"},
+		}},
+		{IP: "10.0.0.2", Ports: []apimodels.Port{
+			{Port: 23, Protocol: "tcp", State: "open"},
+		}},
+	}}
+
+	tests := []struct {
+		name       string
+		policy     gatePolicy
+		violations []string
+	}{
+		{
+			name:   "nothing checked",
+			policy: gatePolicy{},
+		},
+		{
+			name:   "open ports",
+			policy: gatePolicy{ports: map[int]bool{23: true, 3389: true, 445: true}},
+			violations: []string{
+				"10.0.0.1: port 3389/tcp is open",
+				"10.0.0.2: port 23/tcp is open",
+			},
+		},
+		{
+			name:       "vulnerabilities",
+			policy:     gatePolicy{vulnerable: true},
+			violations: []string{"10.0.0.1: script ssl-heartbleed reports a vulnerability"},
+		},
+		{
+			name:   "open ports and vulnerabilities",
+			policy: gatePolicy{ports: map[int]bool{22: true}, vulnerable: true},
+			violations: []string{
+				"10.0.0.1: port 22/tcp is open",
+				"10.0.0.1: script ssl-heartbleed reports a vulnerability",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.violations, test.policy.violations(result))
+		})
+	}
+	assert.False(t, gatePolicy{}.enabled())
+	assert.True(t, gatePolicy{vulnerable: true}.enabled())
+}
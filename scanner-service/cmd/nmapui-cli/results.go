@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+	"github.com/spf13/cobra"
+)
+
+// newResultsCommand creates the command group reading, exporting and deleting scan results
+func newResultsCommand(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "results",
+		Aliases: []string{"result"},
+		Short:   "Read, export and delete scan results",
+	}
+	cmd.AddCommand(newResultsGetCommand(c), newResultsExportCommand(c), newResultsDeleteCommand(c))
+	return cmd
+}
+
+// newResultsGetCommand creates the command printing the hosts of a result
+func newResultsGetCommand(c *cli) *cobra.Command {
+	var byScan bool
+
+	cmd := &cobra.Command{
+		Use:   "get <result-id>",
+		Short: "Print the hosts of a scan result",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cols, err := selectColumns(c.output, c.columns)
+			if err != nil {
+				return err
+			}
+
+			result, err := getResultArg(c.client(), args[0], byScan)
+			if err != nil {
+				return err
+			}
+			return renderResult(os.Stdout, result, c.output, cols)
+		},
+	}
+	cmd.Flags().BoolVar(&byScan, "scan", false, "The argument is the ID of the scan instead of its result")
+	return cmd
+}
+
+// newResultsExportCommand creates the command downloading a result as a document
+func newResultsExportCommand(c *cli) *cobra.Command {
+	var format, file string
+	var byScan bool
+
+	cmd := &cobra.Command{
+		Use:   "export <result-id>",
+		Short: "Download a scan result as a JSON, XML or HTML document",
+		Long:  "Downloads a scan result as a document. The format is taken from the extension of --file unless --format is given.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format == "" {
+				var err error
+				if format, err = fileExportFormat(file); err != nil {
+					return err
+				}
+			}
+
+			client := c.client()
+			var err error
+			if byScan {
+				err = saveScanResult(client, args[0], format, file)
+			} else {
+				err = client.downloadExport(args[0], format, file)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Scan result saved to %s\n", file)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&file, "file", "", "File the document is written to (required)")
+	flags.StringVar(&format, "format", "", "Document format (json, xml, html), by default from the file extension")
+	flags.BoolVar(&byScan, "scan", false, "The argument is the ID of the scan instead of its result")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagFilename("file", "json", "xml", "html")
+	_ = cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"json", "xml", "html"}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+// newResultsDeleteCommand creates the command deleting results
+func newResultsDeleteCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <result-id>...",
+		Short: "Delete scan results",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := c.client()
+			for _, resultID := range args {
+				if err := client.do(http.MethodDelete, "/api/v1/results/"+url.PathEscape(resultID), nil, nil); err != nil {
+					return fmt.Errorf("failed to delete scan result %s: %w", resultID, err)
+				}
+				fmt.Fprintf(os.Stderr, "Scan result %s deleted\n", resultID)
+			}
+			return nil
+		},
+	}
+}
+
+// getResultArg gets the result with the ID, or the result of the scan with the ID
+func getResultArg(client *apiClient, id string, byScan bool) (*apimodels.ScanResult, error) {
+	if byScan {
+		return client.getScanResult(id)
+	}
+	return client.getResult(id)
+}
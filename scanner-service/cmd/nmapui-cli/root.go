@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// cli holds the global flags shared by all commands
+type cli struct {
+	serverURL string
+	apiKey    string
+	output    string
+	columns   []string
+}
+
+// client returns a client of the scanner service configured by the global flags
+func (c *cli) client() *apiClient {
+	apiKey := c.apiKey
+	if apiKey == "" {
+		apiKey = os.Getenv("SCANNER_API_KEY")
+	}
+	return &apiClient{serverURL: c.serverURL, apiKey: apiKey, httpClient: http.DefaultClient}
+}
+
+// newRootCommand creates the nmapui-cli command with all subcommands
+func newRootCommand() *cobra.Command {
+	c := &cli{}
+
+	root := &cobra.Command{
+		Use:   "nmapui-cli",
+		Short: "Start, follow and manage scans of the scanner service",
+		Long: `nmapui-cli talks to the scanner service API to start scans, follow their progress,
+read and export their results and manage workflow schedules.
+
+Status messages are written to stderr, so results written to stdout can be piped.`,
+		Version:       version,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			switch c.output {
+			case "table", "json", "csv", "wide":
+				return nil
+			default:
+				return fmt.Errorf("unknown output format %q, expected table, json, csv or wide", c.output)
+			}
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&c.serverURL, "server", envOrDefault("SCANNER_SERVER", "http://localhost:8081"), "Scanner service URL, also set with $SCANNER_SERVER")
+	// The key is read from the environment when used so that help does not print it
+	flags.StringVar(&c.apiKey, "api-key", "", "API key for authentication (default $SCANNER_API_KEY)")
+	flags.StringVarP(&c.output, "output", "o", "table", "Output format (table, json, csv, wide)")
+	flags.StringSliceVar(&c.columns, "columns", nil, "Columns of host tables (ip, hostnames, status, os, port, protocol, state, service, product, version)")
+
+	_ = root.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"table", "json", "csv", "wide"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = root.RegisterFlagCompletionFunc("columns", cobra.FixedCompletions(columnNames(), cobra.ShellCompDirectiveNoFileComp))
+
+	root.AddCommand(
+		newScanCommand(c),
+		newGetCommand(c),
+		newListCommand(c),
+		newWatchCommand(c),
+		newCancelCommand(c),
+		newDeleteCommand(c),
+		newResultsCommand(c),
+		newDiffCommand(c),
+		newSchedulesCommand(c),
+		newManCommand(root),
+	)
+	return root
+}
+
+// newManCommand creates the command generating man pages of all commands
+func newManCommand(root *cobra.Command) *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: "Generate man pages",
+		Long:  "Generates a man page of every command into a directory, e.g. /usr/local/share/man/man1.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+			header := &doc.GenManHeader{Title: "NMAPUI-CLI", Section: "1", Source: "nmapui-cli " + version}
+			if err := doc.GenManTree(root, header, dir); err != nil {
+				return fmt.Errorf("failed to generate man pages: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Man pages written to %s\n", dir)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "man", "Directory the man pages are written to")
+	return cmd
+}
+
+// envOrDefault returns the value of an environment variable, or the default if it is unset
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
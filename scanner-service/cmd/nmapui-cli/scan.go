@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+	"github.com/spf13/cobra"
+)
+
+// maxTargetItems is the number of targets the scanner service accepts in a scan
+const maxTargetItems = 1024
+
+// scanFlags holds the flags of the scan command besides the scan options
+type scanFlags struct {
+	targets        []string
+	targetsFile    string
+	wait           bool
+	watch          bool
+	interval       time.Duration
+	out            string
+	failOnOpenPort string
+	failOnVuln     bool
+}
+
+// newScanCommand creates the command starting scans
+func newScanCommand(c *cli) *cobra.Command {
+	var req apimodels.StartScanRequest
+	var f scanFlags
+
+	cmd := &cobra.Command{
+		Use:   "scan [target...]",
+		Short: "Start a scan",
+		Long: `Starts a scan of the targets given as arguments, with --target or in a targets file.
+Targets beyond the per-scan limit of the service are submitted as several scans.
+
+With --fail-on-open-port or --fail-on-vuln the command waits for the scan and exits with
+status 3 when findings violate the policy, so it can gate CI pipelines.`,
+		Example: `  nmapui-cli scan 10.0.0.0/24 --ports 22,80,443 --watch
+  nmapui-cli scan --targets-file hosts.txt --service --out evidence.html
+  cat hosts.txt | nmapui-cli scan --targets-file - --fail-on-open-port 23,3389`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScan(c, req, f, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&f.targets, "target", "t", nil, "Targets to scan, comma separated or repeated")
+	flags.StringVar(&f.targetsFile, "targets-file", "", "File listing targets to scan, one or more per line, - for stdin")
+	flags.StringVar(&req.Ports, "ports", "1-1000", "Ports to scan")
+	flags.StringVar(&req.ScanType, "type", "SYN", "Scan type (SYN, CONNECT, UDP, VERSION, SCRIPT, ALL)")
+	flags.IntVar(&req.TimingTemplate, "timing", 4, "Timing template (0-5)")
+	flags.BoolVar(&req.ServiceDetection, "service", false, "Enable service detection")
+	flags.BoolVar(&req.OSDetection, "os", false, "Enable OS detection")
+	flags.BoolVar(&req.ScriptScan, "script", false, "Enable script scanning")
+	flags.IntVar(&req.TimeoutSeconds, "timeout", 300, "Timeout in seconds")
+	flags.BoolVar(&f.wait, "wait", false, "Wait for the scan to complete and print the result")
+	flags.BoolVar(&f.watch, "watch", false, "Show the progress of the scan until it completes, then a summary")
+	flags.DurationVar(&f.interval, "interval", 2*time.Second, "Interval between progress updates")
+	flags.StringVar(&f.out, "out", "", "Wait for the scan and save the result to a file in the format of its extension (.json, .xml, .html)")
+	flags.StringVar(&f.failOnOpenPort, "fail-on-open-port", "", "Wait for the scan and exit with status 3 if any of these ports (e.g. 23,3389,8000-8080) is open")
+	flags.BoolVar(&f.failOnVuln, "fail-on-vuln", false, "Wait for the scan and exit with status 3 if a script reports a host as vulnerable")
+
+	_ = cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions([]string{"SYN", "CONNECT", "UDP", "VERSION", "SCRIPT", "ALL"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.MarkFlagFilename("out", "json", "xml", "html")
+	return cmd
+}
+
+// runScan starts the scans and follows them as requested by the flags
+func runScan(c *cli, req apimodels.StartScanRequest, f scanFlags, args []string) error {
+	targets := args
+	for _, target := range f.targets {
+		targets = append(targets, splitTargets(target)...)
+	}
+	if f.targetsFile != "" {
+		fileTargets, err := readTargets(f.targetsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read targets: %w", err)
+		}
+		targets = append(targets, fileTargets...)
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("target is required")
+	}
+
+	cols, err := selectColumns(c.output, c.columns)
+	if err != nil {
+		return err
+	}
+
+	var exportFormat string
+	if f.out != "" {
+		if exportFormat, err = fileExportFormat(f.out); err != nil {
+			return err
+		}
+	}
+
+	policy := gatePolicy{vulnerable: f.failOnVuln}
+	if f.failOnOpenPort != "" {
+		if policy.ports, err = parsePortList(f.failOnOpenPort); err != nil {
+			return fmt.Errorf("invalid --fail-on-open-port: %w", err)
+		}
+	}
+
+	// Start a scan per batch of targets
+	client := c.client()
+	batches := batchTargets(targets, maxTargetItems)
+	scanIDs := make([]string, 0, len(batches))
+	for _, batch := range batches {
+		req.Target = strings.Join(batch, " ")
+		scanID, err := client.startScan(req)
+		if err != nil {
+			return fmt.Errorf("failed to start scan: %w", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Scan started with ID: %s (%d targets)\n", scanID, len(batch))
+		scanIDs = append(scanIDs, scanID)
+	}
+
+	// Without anything to wait for only the scan IDs are printed
+	if !f.wait && !f.watch && f.out == "" && !policy.enabled() {
+		for _, scanID := range scanIDs {
+			fmt.Println(scanID)
+		}
+		return nil
+	}
+
+	violations := 0
+	for i, scanID := range scanIDs {
+		if f.watch {
+			if err := watchScan(os.Stderr, client, scanID, f.interval); err != nil {
+				return fmt.Errorf("failed to watch scan: %w", err)
+			}
+		} else if err := waitScan(client, scanID, f.interval); err != nil {
+			return fmt.Errorf("failed to wait for scan: %w", err)
+		}
+
+		// Save the result if requested, numbering the files of batches
+		if f.out != "" {
+			path := f.out
+			if len(scanIDs) > 1 {
+				ext := filepath.Ext(path)
+				path = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), i+1, ext)
+			}
+			if err := saveScanResult(client, scanID, exportFormat, path); err != nil {
+				return fmt.Errorf("failed to save scan result: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Scan result saved to %s\n", path)
+		}
+
+		if f.wait {
+			result, err := client.getScanResult(scanID)
+			if err != nil {
+				return err
+			}
+			if err := renderResult(os.Stdout, result, c.output, cols); err != nil {
+				return err
+			}
+		}
+
+		// Check the findings against the policy if requested
+		if policy.enabled() {
+			result, err := client.getScanResult(scanID)
+			if err != nil {
+				// A scan without result cannot prove the policy holds
+				return fmt.Errorf("failed to check scan result: %w", err)
+			}
+			found := policy.violations(result)
+			for _, violation := range found {
+				fmt.Fprintf(os.Stderr, "Policy violation: %s\n", violation)
+			}
+			violations += len(found)
+		}
+	}
+
+	if violations > 0 {
+		return &exitError{code: exitPolicyViolation, message: fmt.Sprintf("%d policy violations found", violations)}
+	}
+	return nil
+}
+
+// waitScan prints the status of a scan to stderr until it completes
+func waitScan(client *apiClient, scanID string, interval time.Duration) error {
+	fmt.Fprintf(os.Stderr, "Waiting for scan %s to complete...\n", scanID)
+	var last apimodels.ScanStatus
+	for {
+		scan, err := client.getScan(scanID)
+		if err != nil {
+			return err
+		}
+
+		if scan.Status != last {
+			fmt.Fprintf(os.Stderr, "Scan status: %s\n", scan.Status)
+			last = scan.Status
+		}
+		if scan.Status.Terminal() {
+			return nil
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// splitTargets splits a target list separated by commas or whitespace
+func splitTargets(list string) []string {
+	return strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// readTargets reads the targets listed in a file, or stdin for "-". Text after a #
+// is a comment.
+func readTargets(path string) ([]string, error) {
+	in := os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var targets []string
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		targets = append(targets, splitTargets(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// batchTargets splits targets into batches of at most size targets, dropping duplicates
+func batchTargets(targets []string, size int) [][]string {
+	seen := make(map[string]bool, len(targets))
+	var batches [][]string
+	var batch []string
+	for _, target := range targets {
+		if seen[target] {
+			continue
+		}
+		seen[target] = true
+
+		batch = append(batch, target)
+		if len(batch) == size {
+			batches = append(batches, batch)
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// fileExportFormat returns the export format of a result file from its extension
+func fileExportFormat(path string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json", ".xml", ".html":
+		return ext[1:], nil
+	case ".htm":
+		return "html", nil
+	default:
+		return "", fmt.Errorf("unknown result file extension %q, expected .json, .xml or .html", ext)
+	}
+}
+
+// saveScanResult downloads the result of a completed scan in the export format and
+// writes it to a file
+func saveScanResult(client *apiClient, scanID, format, path string) error {
+	scan, err := client.getScan(scanID)
+	if err != nil {
+		return err
+	}
+
+	if scan.ResultID == "" {
+		return fmt.Errorf("no result available for scan %s with status %s", scanID, scan.Status)
+	}
+	return client.downloadExport(scan.ResultID, format, path)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+	"github.com/spf13/cobra"
+)
+
+// scanHeader is the header of scan tables
+var scanHeader = []string{"id", "status", "progress", "target", "created_at", "result_id"}
+
+// scanRow returns the values of a scan in a scan table
+func scanRow(scan *apimodels.Scan) []string {
+	return []string{
+		scan.ID,
+		string(scan.Status),
+		fmt.Sprintf("%.0f%%", scan.Progress),
+		scan.Options.Target,
+		scan.CreatedAt.Format(time.RFC3339),
+		scan.ResultID,
+	}
+}
+
+// newGetCommand creates the command showing a scan
+func newGetCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <scan-id>",
+		Short: "Show a scan",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scan, err := c.client().getScan(args[0])
+			if err != nil {
+				return err
+			}
+			return renderTable(os.Stdout, c.output, scanHeader, [][]string{scanRow(scan)}, scan)
+		},
+	}
+}
+
+// newListCommand creates the command listing scans
+func newListCommand(c *cli) *cobra.Command {
+	var status, target, sort, order, cursor string
+	var limit int
+	var all, deleted bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List scans",
+		Long:  "Lists the scans of the caller, newest first. Admins may list the scans of all users with --all.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{}
+			query.Set("limit", strconv.Itoa(limit))
+			for name, value := range map[string]string{
+				"status": status, "target": target, "sort": sort, "order": order, "cursor": cursor,
+			} {
+				if value != "" {
+					query.Set(name, value)
+				}
+			}
+			if all {
+				query.Set("all", "true")
+			}
+			if deleted {
+				query.Set("deleted", "true")
+			}
+
+			var page apimodels.ScanPage
+			if err := c.client().do(http.MethodGet, "/api/v1/scans?"+query.Encode(), nil, &page); err != nil {
+				return err
+			}
+
+			rows := make([][]string, len(page.Scans))
+			for i := range page.Scans {
+				rows[i] = scanRow(&page.Scans[i])
+			}
+			if err := renderTable(os.Stdout, c.output, scanHeader, rows, page.Scans); err != nil {
+				return err
+			}
+			if page.HasMore {
+				fmt.Fprintf(os.Stderr, "%d of %d scans shown, next page with --cursor %s\n", len(page.Scans), page.TotalCount, page.NextCursor)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&status, "status", "", "Only list scans with this status (PENDING, RUNNING, COMPLETED, FAILED, CANCELLED)")
+	flags.StringVar(&target, "target", "", "Only list scans of this target")
+	flags.StringVar(&sort, "sort", "", "Sort field (created_at, completed_at, duration, status)")
+	flags.StringVar(&order, "order", "", "Sort order (asc, desc)")
+	flags.StringVar(&cursor, "cursor", "", "Cursor of the page to list, from a previous listing")
+	flags.IntVar(&limit, "limit", 10, "Maximum number of scans to list (1-100)")
+	flags.BoolVar(&all, "all", false, "List the scans of all users (admin only)")
+	flags.BoolVar(&deleted, "deleted", false, "List the scans in the trash")
+
+	_ = cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions([]string{"PENDING", "RUNNING", "COMPLETED", "FAILED", "CANCELLED"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions([]string{"created_at", "duration", "status"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("order", cobra.FixedCompletions([]string{"asc", "desc"}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+// newCancelCommand creates the command cancelling scans
+func newCancelCommand(c *cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <scan-id>...",
+		Short: "Cancel pending or running scans",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := c.client()
+			for _, scanID := range args {
+				if err := client.do(http.MethodDelete, "/api/v1/scans/"+url.PathEscape(scanID), nil, nil); err != nil {
+					return fmt.Errorf("failed to cancel scan %s: %w", scanID, err)
+				}
+				fmt.Fprintf(os.Stderr, "Scan %s cancelled\n", scanID)
+			}
+			return nil
+		},
+	}
+}
+
+// newDeleteCommand creates the command moving scans to the trash or purging them
+func newDeleteCommand(c *cli) *cobra.Command {
+	var purge bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <scan-id>...",
+		Short: "Move scans to the trash or purge them",
+		Long:  "Moves finished scans to the trash. With --purge the scans and their results are deleted permanently.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := c.client()
+			for _, scanID := range args {
+				if err := deleteScan(client, scanID, purge); err != nil {
+					return fmt.Errorf("failed to delete scan %s: %w", scanID, err)
+				}
+				if purge {
+					fmt.Fprintf(os.Stderr, "Scan %s purged\n", scanID)
+				} else {
+					fmt.Fprintf(os.Stderr, "Scan %s moved to trash\n", scanID)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&purge, "purge", false, "Permanently delete the scans and their results")
+	return cmd
+}
+
+// deleteScan moves a scan to the trash and purges it if requested
+func deleteScan(client *apiClient, scanID string, purge bool) error {
+	path := "/api/v1/scans/" + url.PathEscape(scanID)
+
+	// Only scans in the trash can be purged, so scans already there are purged directly
+	trashed := false
+	if purge {
+		scan, err := client.getScan(scanID)
+		if err != nil {
+			return err
+		}
+		trashed = scan.DeletedAt != nil
+	}
+
+	if !trashed {
+		if err := client.do(http.MethodPost, path+"/trash", nil, nil); err != nil {
+			return err
+		}
+	}
+	if purge {
+		return client.do(http.MethodPost, path+"/purge", nil, nil)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+	"github.com/spf13/cobra"
+)
+
+// newSchedulesCommand creates the command group managing the schedules of workflows
+func newSchedulesCommand(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "schedules",
+		Aliases: []string{"schedule"},
+		Short:   "List, pause, resume and run workflow schedules",
+	}
+	cmd.AddCommand(
+		newSchedulesListCommand(c),
+		newScheduleActionCommand(c, "pause", "Pause the schedule of a workflow", "pause", "paused"),
+		newScheduleActionCommand(c, "resume", "Resume the paused schedule of a workflow", "resume", "resumed"),
+		newScheduleActionCommand(c, "run", "Start a run of a scheduled workflow now", "run", "started"),
+	)
+	return cmd
+}
+
+// newSchedulesListCommand creates the command listing the workflows with a schedule
+func newSchedulesListCommand(c *cli) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the workflows with a schedule",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "/api/v1/workflows"
+			if all {
+				path += "?all=true"
+			}
+
+			var list apimodels.WorkflowList
+			if err := c.client().do(http.MethodGet, path, nil, &list); err != nil {
+				return err
+			}
+
+			header := []string{"id", "name", "schedule", "paused", "skipped_runs", "last_run_id"}
+			var rows [][]string
+			scheduled := make([]apimodels.Workflow, 0, len(list.Workflows))
+			for _, workflow := range list.Workflows {
+				if workflow.Definition.Schedule == "" {
+					continue
+				}
+				rows = append(rows, []string{
+					workflow.ID,
+					workflow.Definition.Name,
+					workflow.Definition.Schedule,
+					strconv.FormatBool(workflow.Schedule.Paused),
+					strconv.Itoa(workflow.Schedule.SkippedRuns),
+					workflow.LastRunID,
+				})
+				scheduled = append(scheduled, workflow)
+			}
+			return renderTable(os.Stdout, c.output, header, rows, scheduled)
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "List the workflows of all users (admin only)")
+	return cmd
+}
+
+// newScheduleActionCommand creates a command posting a schedule action of a workflow
+func newScheduleActionCommand(c *cli, use, short, action, done string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <workflow-id>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var response apimodels.StartRunResponse
+			path := "/api/v1/workflows/" + url.PathEscape(args[0]) + "/schedule/" + action
+			if err := c.client().do(http.MethodPost, path, nil, &response, http.StatusOK, http.StatusAccepted); err != nil {
+				return fmt.Errorf("failed to %s schedule of workflow %s: %w", action, args[0], err)
+			}
+
+			if response.RunID != "" {
+				fmt.Fprintf(os.Stderr, "Workflow run %s %s\n", response.RunID, done)
+			} else {
+				fmt.Fprintf(os.Stderr, "Schedule of workflow %s %s\n", args[0], done)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+	"github.com/spf13/cobra"
+)
+
+// newWatchCommand creates the command following the progress of a scan
+func newWatchCommand(c *cli) *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch <scan-id>",
+		Short: "Show the progress of a scan until it completes",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return watchScan(os.Stdout, c.client(), args[0], interval)
+		},
+	}
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "Interval between progress updates")
+	return cmd
+}
+
+// watchScan renders the progress of a scan to out until it completes, then prints a
+// summary table. The scan service has no progress stream, so the scan is polled.
+func watchScan(out *os.File, client *apiClient, scanID string, interval time.Duration) error {
+	// Terminals get a single line redrawn in place, pipes a line per change
+	live := isTerminal(out)
+	last := ""
+	for {
+		scan, err := client.getScan(scanID)
+		if err != nil {
+			if live {
+				fmt.Fprintln(out)
+			}
+			return err
+		}
+
+		line := fmt.Sprintf("%s %5.1f%%  %-9s  %s%s", progressBar(scan.Progress, 30), scan.Progress, scan.Status, elapsed(scan), phase(scan))
+		if live {
+			fmt.Fprintf(out, "\r\033[K%s", line)
+		} else if state := fmt.Sprintf("%.1f %s %s", scan.Progress, scan.Status, scan.CurrentPhase); state != last {
+			// Elapsed time alone does not make a new line
+			fmt.Fprintln(out, line)
+			last = state
+		}
+
+		if scan.Status.Terminal() {
+			if live {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintln(out)
+			return printScanSummary(out, client, scan)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// progressBar renders a progress percentage as a bar of the given width
+func progressBar(progress float64, width int) string {
+	filled := int(progress / 100 * float64(width))
+	filled = max(0, min(filled, width))
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", width-filled) + "]"
+}
+
+// phase returns the phase of a running scan and its estimated completion, if known
+func phase(scan *apimodels.Scan) string {
+	if scan.Status.Terminal() || scan.CurrentPhase == "" {
+		return ""
+	}
+	text := "  " + strings.ReplaceAll(scan.CurrentPhase, "_", " ")
+	if scan.EstimatedCompletion != nil {
+		text += ", ends in ~" + max(0, time.Until(*scan.EstimatedCompletion).Round(time.Second)).String()
+	}
+	return text
+}
+
+// elapsed returns how long a scan has been running, or ran if it completed
+func elapsed(scan *apimodels.Scan) string {
+	if scan.StartedAt == nil {
+		return "-"
+	}
+	if scan.CompletedAt == nil {
+		return time.Since(*scan.StartedAt).Round(time.Second).String()
+	}
+	return scan.Duration().Round(time.Second).String()
+}
+
+// printScanSummary writes a table summarizing a completed scan and its result
+func printScanSummary(out io.Writer, client *apiClient, scan *apimodels.Scan) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Scan ID\t%s\n", scan.ID)
+	fmt.Fprintf(w, "Target\t%s\n", scan.Options.Target)
+	fmt.Fprintf(w, "Status\t%s\n", scan.Status)
+	fmt.Fprintf(w, "Duration\t%s\n", elapsed(scan))
+	if scan.Error != "" {
+		fmt.Fprintf(w, "Error\t%s\n", scan.Error)
+	}
+	if scan.Failure != nil {
+		fmt.Fprintf(w, "Command\t%s\n", scan.Failure.Command)
+		fmt.Fprintf(w, "Exit Code\t%d\n", scan.Failure.ExitCode)
+		if scan.Failure.Hint != "" {
+			fmt.Fprintf(w, "Hint\t%s\n", scan.Failure.Hint)
+		}
+	}
+
+	if scan.ResultID != "" {
+		result, err := client.getResult(scan.ResultID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "Hosts Up\t%d/%d\n", result.UpHosts, result.TotalHosts)
+		fmt.Fprintf(w, "Open Ports\t%d\n", countOpenPorts(result))
+		if result.Partial {
+			fmt.Fprintf(w, "Result\tpartial, hosts completed before the scan stopped\n")
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// The scanner output spans several lines, it is written below the table
+	if scan.Failure != nil && scan.Failure.Stderr != "" {
+		fmt.Fprintf(out, "\nScanner error output:\n%s", scan.Failure.Stderr)
+		if !strings.HasSuffix(scan.Failure.Stderr, "\n") {
+			fmt.Fprintln(out)
+		}
+	}
+	return nil
+}
+
+// countOpenPorts returns the number of open ports of all hosts of a result
+func countOpenPorts(result *apimodels.ScanResult) int {
+	openPorts := 0
+	for _, host := range result.Hosts {
+		for _, port := range host.Ports {
+			if port.State == "open" {
+				openPorts++
+			}
+		}
+	}
+	return openPorts
+}
+
+// isTerminal reports whether the file is a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -4,11 +4,20 @@ import "time"
 
 // Config represents the application configuration
 type Config struct {
-	App     AppConfig
-	Server  ServerConfig
-	Nmap    NmapConfig
-	Log     LogConfig
-	Storage StorageConfig
+	App           AppConfig
+	Server        ServerConfig
+	Nmap          NmapConfig
+	Log           LogConfig
+	Storage       StorageConfig
+	Auth          AuthConfig
+	Policy        PolicyConfig
+	RateLimit     RateLimitConfig
+	Enrichment    EnrichmentConfig
+	Discovery     DiscoveryConfig
+	Agents        AgentsConfig
+	Engines       EnginesConfig
+	Secrets       SecretsConfig
+	Notifications NotificationsConfig
 }
 
 // AppConfig contains application metadata
@@ -19,8 +28,9 @@ type AppConfig struct {
 
 // ServerConfig contains server configuration
 type ServerConfig struct {
-	HTTP HTTPServerConfig
-	GRPC GRPCServerConfig
+	HTTP         HTTPServerConfig
+	GRPC         GRPCServerConfig
+	DrainTimeout time.Duration // How long shutdown waits for active scans before aborting them
 }
 
 // HTTPServerConfig contains HTTP server configuration
@@ -29,6 +39,61 @@ type HTTPServerConfig struct {
 	Timeout      time.Duration
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	TLS          TLSConfig
+	Compression  CompressionConfig
+	BodyLogging  BodyLoggingConfig
+	Security     SecurityHeadersConfig
+}
+
+// SecurityHeadersConfig contains the security headers of HTTP responses. Empty values
+// omit the header.
+type SecurityHeadersConfig struct {
+	Enabled               bool
+	ContentTypeOptions    string // X-Content-Type-Options
+	FrameOptions          string // X-Frame-Options, DENY or SAMEORIGIN
+	ContentSecurityPolicy string // Content-Security-Policy
+	ReferrerPolicy        string // Referrer-Policy
+	HSTS                  HSTSConfig
+}
+
+// HSTSConfig contains the Strict-Transport-Security header of HTTPS responses
+type HSTSConfig struct {
+	Enabled           bool
+	MaxAge            time.Duration // How long browsers only connect over HTTPS
+	IncludeSubdomains bool
+	Preload           bool // Consent to inclusion in the HSTS preload lists of browsers
+}
+
+// BodyLoggingConfig contains debug logging configuration of request and response bodies
+type BodyLoggingConfig struct {
+	Enabled bool
+	MaxSize int // Bodies are cut after this many bytes
+}
+
+// CompressionConfig contains response compression configuration of the HTTP server
+type CompressionConfig struct {
+	Enabled     bool
+	Encodings   []string // Supported encodings in order of preference, gzip and br
+	MinSize     int      // Responses smaller than this many bytes are sent uncompressed
+	GzipLevel   int      // 1 (fastest) to 9 (smallest)
+	BrotliLevel int      // 0 (fastest) to 11 (smallest)
+}
+
+// TLSConfig contains HTTPS configuration of the HTTP server
+type TLSConfig struct {
+	Enabled  bool
+	CertFile string
+	KeyFile  string
+	Autocert AutocertConfig
+}
+
+// AutocertConfig contains ACME (Let's Encrypt) certificate configuration
+type AutocertConfig struct {
+	Enabled  bool
+	Domains  []string
+	Email    string
+	CacheDir string
+	HTTPPort int // Port of the HTTP-01 challenge and HTTPS redirect listener
 }
 
 // GRPCServerConfig contains gRPC server configuration
@@ -42,17 +107,274 @@ type NmapConfig struct {
 	Path               string
 	Timeout            time.Duration
 	MaxConcurrentScans int
+	QueueSize          int           // Scans that may wait while all workers are busy, 0 to reject them
+	MaxTimeout         time.Duration // Maximum timeout a scan may request, 0 for no limit
+	MaxHosts           int           // Maximum addresses a scan target may cover, 0 for no limit
+	MaxRate            int           // Highest packets per second a scan may request, 0 for no limit
+	MaxParallelism     int           // Highest probe parallelism a scan may request, 0 for no limit
+	MaxRetries         int           // Probe retransmissions of scans not setting them, -1 for the nmap default
+	ShardSize          int           // Networks with more addresses are split into child scans, 0 to disable
+	ShardConcurrency   int           // Maximum child scans of a scan running in parallel
+	DryRun             bool          // Return canned results instead of running nmap
+	FixturesDir        string        // Directory of JSON scan results returned in dry-run mode
+	DryRunDelay        time.Duration // Simulated duration of dry-run scans
+	EvasionEnabled     bool          // Allow decoys, source port, fragmentation and padding for the advanced role
+	StateDir           string        // Directory where scans record their progress for resumption, empty to disable
+	DuplicateScans     string        // Handling of scans identical to a running scan of the user: allow, reuse or reject
+	LogLines           int           // Output lines of the scanner processes kept per scan, 0 to disable capture
+	LogScans           int           // Most recent scans whose output is kept
+	Resources          NmapResourcesConfig
+	Backend            string // Where nmap runs: local, docker or kubernetes
+	Docker             NmapDockerConfig
+	Kubernetes         NmapKubernetesConfig
+}
+
+// NmapKubernetesConfig contains configuration of running each scan in a Kubernetes Job
+type NmapKubernetesConfig struct {
+	Namespace      string            // Namespace of the Jobs, the namespace of the service if empty
+	Image          string            // Image providing nmap
+	NmapPath       string            // nmap binary in the image
+	NodeSelector   map[string]string // Labels of the nodes scans may run on
+	Labels         map[string]string // Labels of the scan pods, e.g. selected by their network policy
+	ServiceAccount string            // Service account of the scan pods
+	CapAdd         []string          // Capabilities added to the nmap container
+	CapDrop        []string          // Capabilities dropped from the nmap container
+	PollInterval   time.Duration     // How often the status of a running Job is checked
+}
+
+// NmapDockerConfig contains configuration of running each scan in a container
+type NmapDockerConfig struct {
+	Path     string   // docker CLI
+	Image    string   // Image providing nmap
+	NmapPath string   // nmap binary in the image
+	Network  string   // Network mode of the containers
+	CapAdd   []string // Capabilities added to the containers
+	CapDrop  []string // Capabilities dropped from the containers
+}
+
+// NmapResourcesConfig contains the resource controls nmap runs under
+type NmapResourcesConfig struct {
+	Nice         int           // Scheduling priority adjustment of nmap, 0 to 19
+	IOClass      string        // I/O scheduling class of nmap: best-effort or idle, empty to inherit
+	CPUs         float64       // CPU cores a scan may use, 0 for no limit; needs a cgroup parent
+	MemoryMB     int           // Memory a scan may use in MiB, 0 for no limit
+	MaxRuntime   time.Duration // Wall-clock time after which nmap is killed, 0 for no limit
+	CgroupParent string        // cgroup v2 directory the cgroups of scans are created in, empty to disable
 }
 
 // LogConfig contains logging configuration
 type LogConfig struct {
-	Level  string
-	Format string
-	Output string
+	Level     string
+	Format    string
+	Output    string
+	Sampling  LogSamplingConfig
+	Redaction LogRedactionConfig
+	Access    LogAccessConfig
+}
+
+// LogAccessConfig contains configuration of the access log of HTTP and gRPC requests
+type LogAccessConfig struct {
+	Enabled bool   // Write access logs to their own sink instead of the application log
+	Format  string // json or console
+	Output  string // stdout, stderr or a file path
+}
+
+// LogRedactionConfig contains masking configuration of sensitive log fields. Auth tokens
+// and API keys are always masked.
+type LogRedactionConfig struct {
+	Targets bool     // Mask scan targets (IP addresses and hostnames) in regulated environments
+	Fields  []string // Additional field names to mask
+}
+
+// LogSamplingConfig contains sampling configuration of debug and info logs
+type LogSamplingConfig struct {
+	Enabled    bool
+	Initial    int           // Entries with the same message logged per tick before sampling
+	Thereafter int           // Every Thereafter-th entry with the same message is logged after Initial
+	Tick       time.Duration // Interval the counts are reset at
 }
 
 // StorageConfig contains storage configuration
 type StorageConfig struct {
 	Type            string
 	RetentionPeriod time.Duration
+	UserRetention   map[string]time.Duration // User ID -> retention period overriding RetentionPeriod
+	TenantRetention map[string]time.Duration // Tenant ID -> retention period overriding RetentionPeriod
+	CompressResults bool                     // Store the hosts of scan results gzip-compressed
+
+	RetentionRules    map[string]time.Duration // Data class -> retention period of the data of the class, see RetentionClasses
+	RetentionInterval time.Duration            // How often the retention rules are applied
+	RetentionDryRun   bool                     // Only log the data the retention rules would delete
+}
+
+// AuthConfig contains authentication configuration
+type AuthConfig struct {
+	Enabled             bool
+	Issuer              string
+	Audience            string
+	JWKSURL             string
+	Secret              string
+	JWKSRefreshInterval time.Duration
+	AdminUsers          []string
+	RolesClaim          string
+	DefaultRole         string
+	TenantClaim         string
+	OIDC                OIDCConfig
+}
+
+// OIDCConfig contains OpenID Connect provider configuration
+type OIDCConfig struct {
+	IssuerURL             string
+	ClientID              string
+	ClientSecret          string
+	GroupsClaim           string
+	GroupRoles            map[string]string
+	Introspection         bool
+	IntrospectionCacheTTL time.Duration
+}
+
+// PolicyConfig contains scan policy configuration
+type PolicyConfig struct {
+	RequireAllowlist bool
+	Blocklist        []string
+	OptionRules      map[string]string // Scan option name -> minimum role
+}
+
+// RateLimitConfig contains HTTP API rate limiting configuration
+type RateLimitConfig struct {
+	Enabled   bool
+	PerIP     RateLimitRule             // Applied to every API request before authentication
+	PerUser   RateLimitRule             // Applied to every API request of an authenticated user
+	StartScan RateLimitRule             // Applied per user to POST /api/v1/scans and /api/v2/scans
+	Routes    map[string]RouteRateLimit // Additional limits of single routes by name
+	Redis     RedisConfig               // Shares the limits of replicas when Address is set
+}
+
+// RouteRateLimit contains a rate limit of a single route
+type RouteRateLimit struct {
+	Method string // HTTP method, empty for any method
+	Path   string // Route pattern, e.g. /api/v1/scans/:id
+	Key    string // ip or user
+	RateLimitRule
+}
+
+// RedisConfig contains the connection settings of a Redis server
+type RedisConfig struct {
+	Address  string // host:port, empty to keep state in memory
+	Password string
+	DB       int
+}
+
+// RateLimitRule contains the parameters of a token bucket
+type RateLimitRule struct {
+	RequestsPerMinute float64
+	Burst             int
+}
+
+// EnrichmentConfig contains configuration of the scan result enrichment steps
+type EnrichmentConfig struct {
+	GeoIP GeoIPConfig
+	RDAP  RDAPConfig
+}
+
+// GeoIPConfig contains MaxMind GeoIP enrichment configuration
+type GeoIPConfig struct {
+	Enabled    bool
+	CityDBPath string // GeoLite2/GeoIP2 City or Country .mmdb file
+	ASNDBPath  string // GeoLite2 ASN or GeoIP2 ISP .mmdb file
+}
+
+// RDAPConfig contains RDAP (WHOIS) enrichment configuration
+type RDAPConfig struct {
+	Enabled    bool
+	BaseURL    string
+	Timeout    time.Duration
+	CacheTTL   time.Duration
+	MaxLookups int // Maximum number of lookups per scan result
+}
+
+// DiscoveryConfig contains configuration of the subdomain discovery stage of domain scans
+type DiscoveryConfig struct {
+	Enabled      bool
+	CTURL        string        // crt.sh compatible Certificate Transparency search service
+	Timeout      time.Duration // Timeout of each discovery method
+	MaxHosts     int           // Maximum number of hosts a domain expands into
+	WordlistPath string        // File with one subdomain name per line, empty for the built-in list
+	Concurrency  int           // Maximum number of concurrent DNS lookups
+}
+
+// AgentsConfig contains configuration of remote scanning agents connecting over gRPC
+type AgentsConfig struct {
+	Enabled          bool
+	Token            string        // Shared secret agents present when connecting
+	HeartbeatTimeout time.Duration // Time without heartbeat after which an agent is marked offline
+}
+
+// EnginesConfig contains configuration of the scan engines selectable besides nmap
+type EnginesConfig struct {
+	Masscan  MasscanConfig
+	Rustscan RustscanConfig
+	Zmap     ZmapConfig
+	Naabu    NaabuConfig
+	Hybrid   HybridConfig
+}
+
+// MasscanConfig contains configuration of the masscan engine
+type MasscanConfig struct {
+	Enabled bool
+	Path    string
+	Rate    int // Packets per second
+}
+
+// RustscanConfig contains configuration of the rustscan engine used by the fast scan mode
+type RustscanConfig struct {
+	Enabled   bool
+	Path      string
+	BatchSize int           // Ports probed at once
+	Timeout   time.Duration // Timeout of a single port probe
+}
+
+// ZmapConfig contains configuration of the zmap engine
+type ZmapConfig struct {
+	Enabled bool
+	Path    string
+	Rate    int // Packets per second, caps the bandwidth used by surveys
+}
+
+// HybridConfig contains configuration of hybrid scans, sweeping ports with a
+// fast engine before scanning the open ports with nmap
+type HybridConfig struct {
+	Enabled     bool
+	SweepEngine string // Enabled engine sweeping the ports
+}
+
+// NaabuConfig contains configuration of the naabu engine
+type NaabuConfig struct {
+	Enabled bool
+	Path    string
+	Rate    int // Packets per second
+}
+
+// SecretsConfig contains configuration of the providers secret references
+// (env:, file: and vault:) in secret settings are resolved with
+type SecretsConfig struct {
+	FileDir         string        // Directory of relative file: references, e.g. /run/secrets
+	RefreshInterval time.Duration // Interval secrets are re-read at to pick up rotation, 0 to disable
+	Vault           VaultConfig
+}
+
+// VaultConfig contains HashiCorp Vault connection configuration
+type VaultConfig struct {
+	Address   string // Empty to disable vault: references
+	Token     string
+	Namespace string
+}
+
+// NotificationsConfig contains configuration of the notification service client
+type NotificationsConfig struct {
+	Address               string        // gRPC address of the notification service, empty to disable notifications
+	Channels              []string      // Channels alerts are delivered over, empty for the defaults of the notification service
+	Timeout               time.Duration // Timeout of a notification request
+	PanicAlerts           bool          // Alert operators about panics recovered while serving requests
+	CertificateExpiryDays []int         // Days before expiry owners of certificates observed by scans are alerted, e.g. [30, 7, 1]
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -45,31 +46,261 @@ func LoadConfig() (*Config, error) {
 	config.Server.HTTP.Timeout = viper.GetDuration("server.http.timeout")
 	config.Server.HTTP.ReadTimeout = viper.GetDuration("server.http.read_timeout")
 	config.Server.HTTP.WriteTimeout = viper.GetDuration("server.http.write_timeout")
+	config.Server.HTTP.TLS.Enabled = viper.GetBool("server.http.tls.enabled")
+	config.Server.HTTP.TLS.CertFile = viper.GetString("server.http.tls.cert_file")
+	config.Server.HTTP.TLS.KeyFile = viper.GetString("server.http.tls.key_file")
+	config.Server.HTTP.TLS.Autocert.Enabled = viper.GetBool("server.http.tls.autocert.enabled")
+	config.Server.HTTP.TLS.Autocert.Domains = viper.GetStringSlice("server.http.tls.autocert.domains")
+	config.Server.HTTP.TLS.Autocert.Email = viper.GetString("server.http.tls.autocert.email")
+	config.Server.HTTP.TLS.Autocert.CacheDir = viper.GetString("server.http.tls.autocert.cache_dir")
+	config.Server.HTTP.TLS.Autocert.HTTPPort = viper.GetInt("server.http.tls.autocert.http_port")
+	viper.SetDefault("server.http.compression.enabled", true)
+	config.Server.HTTP.Compression.Enabled = viper.GetBool("server.http.compression.enabled")
+	config.Server.HTTP.Compression.Encodings = viper.GetStringSlice("server.http.compression.encodings")
+	config.Server.HTTP.Compression.MinSize = viper.GetInt("server.http.compression.min_size")
+	config.Server.HTTP.Compression.GzipLevel = viper.GetInt("server.http.compression.gzip_level")
+	config.Server.HTTP.Compression.BrotliLevel = viper.GetInt("server.http.compression.brotli_level")
+	config.Server.HTTP.BodyLogging.Enabled = viper.GetBool("server.http.body_logging.enabled")
+	config.Server.HTTP.BodyLogging.MaxSize = viper.GetInt("server.http.body_logging.max_size")
+	viper.SetDefault("server.http.security_headers.enabled", true)
+	viper.SetDefault("server.http.security_headers.content_type_options", "nosniff")
+	viper.SetDefault("server.http.security_headers.frame_options", "DENY")
+	viper.SetDefault("server.http.security_headers.content_security_policy", "default-src 'none'; frame-ancestors 'none'")
+	viper.SetDefault("server.http.security_headers.referrer_policy", "no-referrer")
+	viper.SetDefault("server.http.security_headers.hsts.enabled", true)
+	viper.SetDefault("server.http.security_headers.hsts.max_age", "8760h")
+	config.Server.HTTP.Security.Enabled = viper.GetBool("server.http.security_headers.enabled")
+	config.Server.HTTP.Security.ContentTypeOptions = viper.GetString("server.http.security_headers.content_type_options")
+	config.Server.HTTP.Security.FrameOptions = viper.GetString("server.http.security_headers.frame_options")
+	config.Server.HTTP.Security.ContentSecurityPolicy = viper.GetString("server.http.security_headers.content_security_policy")
+	config.Server.HTTP.Security.ReferrerPolicy = viper.GetString("server.http.security_headers.referrer_policy")
+	config.Server.HTTP.Security.HSTS.Enabled = viper.GetBool("server.http.security_headers.hsts.enabled")
+	config.Server.HTTP.Security.HSTS.MaxAge = viper.GetDuration("server.http.security_headers.hsts.max_age")
+	config.Server.HTTP.Security.HSTS.IncludeSubdomains = viper.GetBool("server.http.security_headers.hsts.include_subdomains")
+	config.Server.HTTP.Security.HSTS.Preload = viper.GetBool("server.http.security_headers.hsts.preload")
 
 	// gRPC Server configuration
 	config.Server.GRPC.Port = viper.GetInt("server.grpc.port")
 	config.Server.GRPC.Timeout = viper.GetDuration("server.grpc.timeout")
+	config.Server.DrainTimeout = viper.GetDuration("server.drain_timeout")
 
 	// Nmap configuration
 	config.Nmap.Path = viper.GetString("nmap.path")
 	config.Nmap.Timeout = viper.GetDuration("nmap.timeout")
 	config.Nmap.MaxConcurrentScans = viper.GetInt("nmap.max_concurrent_scans")
+	config.Nmap.QueueSize = viper.GetInt("nmap.queue_size")
+	config.Nmap.MaxTimeout = viper.GetDuration("nmap.max_timeout")
+	config.Nmap.MaxHosts = viper.GetInt("nmap.max_hosts")
+	config.Nmap.MaxRate = viper.GetInt("nmap.max_rate")
+	config.Nmap.MaxParallelism = viper.GetInt("nmap.max_parallelism")
+	viper.SetDefault("nmap.max_retries", -1)
+	config.Nmap.MaxRetries = viper.GetInt("nmap.max_retries")
+	config.Nmap.ShardSize = viper.GetInt("nmap.shard_size")
+	config.Nmap.ShardConcurrency = viper.GetInt("nmap.shard_concurrency")
+	config.Nmap.DryRun = viper.GetBool("nmap.dry_run")
+	config.Nmap.FixturesDir = viper.GetString("nmap.fixtures_dir")
+	config.Nmap.DryRunDelay = viper.GetDuration("nmap.dry_run_delay")
+	config.Nmap.EvasionEnabled = viper.GetBool("nmap.evasion_enabled")
+	config.Nmap.StateDir = viper.GetString("nmap.state_dir")
+	config.Nmap.DuplicateScans = viper.GetString("nmap.duplicate_scans")
+	viper.SetDefault("nmap.log_lines", 500)
+	config.Nmap.LogLines = viper.GetInt("nmap.log_lines")
+	viper.SetDefault("nmap.log_scans", 100)
+	config.Nmap.LogScans = viper.GetInt("nmap.log_scans")
+	config.Nmap.Resources.Nice = viper.GetInt("nmap.resources.nice")
+	config.Nmap.Resources.IOClass = viper.GetString("nmap.resources.io_class")
+	config.Nmap.Resources.CPUs = viper.GetFloat64("nmap.resources.cpus")
+	config.Nmap.Resources.MemoryMB = viper.GetInt("nmap.resources.memory_mb")
+	config.Nmap.Resources.MaxRuntime = viper.GetDuration("nmap.resources.max_runtime")
+	config.Nmap.Resources.CgroupParent = viper.GetString("nmap.resources.cgroup_parent")
+	config.Nmap.Backend = viper.GetString("nmap.backend")
+	config.Nmap.Docker.Path = viper.GetString("nmap.docker.path")
+	config.Nmap.Docker.Image = viper.GetString("nmap.docker.image")
+	config.Nmap.Docker.NmapPath = viper.GetString("nmap.docker.nmap_path")
+	config.Nmap.Docker.Network = viper.GetString("nmap.docker.network")
+	config.Nmap.Docker.CapAdd = viper.GetStringSlice("nmap.docker.cap_add")
+	config.Nmap.Docker.CapDrop = viper.GetStringSlice("nmap.docker.cap_drop")
+	config.Nmap.Kubernetes.Namespace = viper.GetString("nmap.kubernetes.namespace")
+	config.Nmap.Kubernetes.Image = viper.GetString("nmap.kubernetes.image")
+	config.Nmap.Kubernetes.NmapPath = viper.GetString("nmap.kubernetes.nmap_path")
+	config.Nmap.Kubernetes.NodeSelector = viper.GetStringMapString("nmap.kubernetes.node_selector")
+	config.Nmap.Kubernetes.Labels = viper.GetStringMapString("nmap.kubernetes.labels")
+	config.Nmap.Kubernetes.ServiceAccount = viper.GetString("nmap.kubernetes.service_account")
+	config.Nmap.Kubernetes.CapAdd = viper.GetStringSlice("nmap.kubernetes.cap_add")
+	config.Nmap.Kubernetes.CapDrop = viper.GetStringSlice("nmap.kubernetes.cap_drop")
+	viper.SetDefault("nmap.kubernetes.poll_interval", 2*time.Second)
+	config.Nmap.Kubernetes.PollInterval = viper.GetDuration("nmap.kubernetes.poll_interval")
 
 	// Logging configuration
 	config.Log.Level = viper.GetString("log.level")
 	config.Log.Format = viper.GetString("log.format")
 	config.Log.Output = viper.GetString("log.output")
+	config.Log.Sampling.Enabled = viper.GetBool("log.sampling.enabled")
+	config.Log.Sampling.Initial = viper.GetInt("log.sampling.initial")
+	config.Log.Sampling.Thereafter = viper.GetInt("log.sampling.thereafter")
+	config.Log.Sampling.Tick = viper.GetDuration("log.sampling.tick")
+	config.Log.Redaction.Targets = viper.GetBool("log.redaction.targets")
+	config.Log.Redaction.Fields = viper.GetStringSlice("log.redaction.fields")
+	config.Log.Access.Enabled = viper.GetBool("log.access.enabled")
+	config.Log.Access.Format = viper.GetString("log.access.format")
+	config.Log.Access.Output = viper.GetString("log.access.output")
 
 	// Storage configuration
 	config.Storage.Type = viper.GetString("storage.type")
 	config.Storage.RetentionPeriod = viper.GetDuration("storage.retention_period")
+	viper.SetDefault("storage.compress_results", true)
+	config.Storage.CompressResults = viper.GetBool("storage.compress_results")
+	userRetention, err := loadDurationMap("storage.retention_overrides.users")
+	if err != nil {
+		return nil, err
+	}
+	config.Storage.UserRetention = userRetention
+	tenantRetention, err := loadDurationMap("storage.retention_overrides.tenants")
+	if err != nil {
+		return nil, err
+	}
+	config.Storage.TenantRetention = tenantRetention
+	retentionRules, err := loadDurationMap("storage.retention_rules")
+	if err != nil {
+		return nil, err
+	}
+	config.Storage.RetentionRules = retentionRules
+	config.Storage.RetentionInterval = viper.GetDuration("storage.retention_interval")
+	config.Storage.RetentionDryRun = viper.GetBool("storage.retention_dry_run")
+
+	// Auth configuration
+	config.Auth.Enabled = viper.GetBool("auth.enabled")
+	config.Auth.Issuer = viper.GetString("auth.issuer")
+	config.Auth.Audience = viper.GetString("auth.audience")
+	config.Auth.JWKSURL = viper.GetString("auth.jwks_url")
+	config.Auth.Secret = viper.GetString("auth.secret")
+	config.Auth.JWKSRefreshInterval = viper.GetDuration("auth.jwks_refresh_interval")
+	config.Auth.AdminUsers = viper.GetStringSlice("auth.admin_users")
+	config.Auth.RolesClaim = viper.GetString("auth.roles_claim")
+	config.Auth.DefaultRole = viper.GetString("auth.default_role")
+	config.Auth.TenantClaim = viper.GetString("auth.tenant_claim")
+	config.Auth.OIDC.IssuerURL = viper.GetString("auth.oidc.issuer_url")
+	config.Auth.OIDC.ClientID = viper.GetString("auth.oidc.client_id")
+	config.Auth.OIDC.ClientSecret = viper.GetString("auth.oidc.client_secret")
+	config.Auth.OIDC.GroupsClaim = viper.GetString("auth.oidc.groups_claim")
+	config.Auth.OIDC.GroupRoles = viper.GetStringMapString("auth.oidc.group_roles")
+	config.Auth.OIDC.Introspection = viper.GetBool("auth.oidc.introspection")
+	config.Auth.OIDC.IntrospectionCacheTTL = viper.GetDuration("auth.oidc.introspection_cache_ttl")
+
+	// Policy configuration
+	viper.SetDefault("policy.require_allowlist", true)
+	config.Policy.RequireAllowlist = viper.GetBool("policy.require_allowlist")
+	config.Policy.Blocklist = viper.GetStringSlice("policy.blocklist")
+	viper.SetDefault("policy.option_rules", map[string]string{
+		"extra_options":   "admin",
+		"aggressive_scan": "admin",
+	})
+	config.Policy.OptionRules = viper.GetStringMapString("policy.option_rules")
+
+	// Rate limit configuration
+	config.RateLimit.Enabled = viper.GetBool("rate_limit.enabled")
+	config.RateLimit.PerIP = loadRateLimitRule("rate_limit.per_ip")
+	config.RateLimit.PerUser = loadRateLimitRule("rate_limit.per_user")
+	config.RateLimit.StartScan = loadRateLimitRule("rate_limit.start_scan")
+	config.RateLimit.Routes = make(map[string]RouteRateLimit)
+	for name := range viper.GetStringMap("rate_limit.routes") {
+		key := "rate_limit.routes." + name
+		config.RateLimit.Routes[name] = RouteRateLimit{
+			Method:        strings.ToUpper(viper.GetString(key + ".method")),
+			Path:          viper.GetString(key + ".path"),
+			Key:           viper.GetString(key + ".key"),
+			RateLimitRule: loadRateLimitRule(key),
+		}
+	}
+	config.RateLimit.Redis.Address = viper.GetString("rate_limit.redis.address")
+	config.RateLimit.Redis.Password = viper.GetString("rate_limit.redis.password")
+	config.RateLimit.Redis.DB = viper.GetInt("rate_limit.redis.db")
+
+	// Enrichment configuration
+	config.Enrichment.GeoIP.Enabled = viper.GetBool("enrichment.geoip.enabled")
+	config.Enrichment.GeoIP.CityDBPath = viper.GetString("enrichment.geoip.city_db_path")
+	config.Enrichment.GeoIP.ASNDBPath = viper.GetString("enrichment.geoip.asn_db_path")
+	config.Enrichment.RDAP.Enabled = viper.GetBool("enrichment.rdap.enabled")
+	config.Enrichment.RDAP.BaseURL = viper.GetString("enrichment.rdap.base_url")
+	config.Enrichment.RDAP.Timeout = viper.GetDuration("enrichment.rdap.timeout")
+	config.Enrichment.RDAP.CacheTTL = viper.GetDuration("enrichment.rdap.cache_ttl")
+	config.Enrichment.RDAP.MaxLookups = viper.GetInt("enrichment.rdap.max_lookups")
+
+	// Discovery configuration
+	config.Discovery.Enabled = viper.GetBool("discovery.enabled")
+	config.Discovery.CTURL = viper.GetString("discovery.ct_url")
+	config.Discovery.Timeout = viper.GetDuration("discovery.timeout")
+	config.Discovery.MaxHosts = viper.GetInt("discovery.max_hosts")
+	config.Discovery.WordlistPath = viper.GetString("discovery.wordlist_path")
+	config.Discovery.Concurrency = viper.GetInt("discovery.concurrency")
+
+	// Agents configuration
+	config.Agents.Enabled = viper.GetBool("agents.enabled")
+	config.Agents.Token = viper.GetString("agents.token")
+	config.Agents.HeartbeatTimeout = viper.GetDuration("agents.heartbeat_timeout")
+
+	// Engines configuration
+	config.Engines.Masscan.Enabled = viper.GetBool("engines.masscan.enabled")
+	config.Engines.Masscan.Path = viper.GetString("engines.masscan.path")
+	config.Engines.Masscan.Rate = viper.GetInt("engines.masscan.rate")
+	config.Engines.Rustscan.Enabled = viper.GetBool("engines.rustscan.enabled")
+	config.Engines.Rustscan.Path = viper.GetString("engines.rustscan.path")
+	config.Engines.Rustscan.BatchSize = viper.GetInt("engines.rustscan.batch_size")
+	config.Engines.Rustscan.Timeout = viper.GetDuration("engines.rustscan.timeout")
+	config.Engines.Zmap.Enabled = viper.GetBool("engines.zmap.enabled")
+	config.Engines.Zmap.Path = viper.GetString("engines.zmap.path")
+	config.Engines.Zmap.Rate = viper.GetInt("engines.zmap.rate")
+	config.Engines.Naabu.Enabled = viper.GetBool("engines.naabu.enabled")
+	config.Engines.Naabu.Path = viper.GetString("engines.naabu.path")
+	config.Engines.Naabu.Rate = viper.GetInt("engines.naabu.rate")
+	config.Engines.Hybrid.Enabled = viper.GetBool("engines.hybrid.enabled")
+	config.Engines.Hybrid.SweepEngine = viper.GetString("engines.hybrid.sweep_engine")
+
+	// Secrets configuration
+	config.Secrets.FileDir = viper.GetString("secrets.file_dir")
+	config.Secrets.RefreshInterval = viper.GetDuration("secrets.refresh_interval")
+	config.Secrets.Vault.Address = viper.GetString("secrets.vault.address")
+	config.Secrets.Vault.Token = viper.GetString("secrets.vault.token")
+	config.Secrets.Vault.Namespace = viper.GetString("secrets.vault.namespace")
+
+	// Notifications configuration
+	config.Notifications.Address = viper.GetString("notifications.address")
+	config.Notifications.Channels = viper.GetStringSlice("notifications.channels")
+	config.Notifications.Timeout = viper.GetDuration("notifications.timeout")
+	config.Notifications.PanicAlerts = viper.GetBool("notifications.panic_alerts")
+	config.Notifications.CertificateExpiryDays = viper.GetIntSlice("notifications.certificate_expiry_days")
 
 	// Set defaults if not provided
 	setDefaults(config)
 
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
+// loadDurationMap loads a map of positive durations from the given key
+func loadDurationMap(key string) (map[string]time.Duration, error) {
+	durations := make(map[string]time.Duration)
+	for name, value := range viper.GetStringMapString(key) {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid duration %q for %s.%s", value, key, name)
+		}
+		durations[name] = duration
+	}
+	return durations, nil
+}
+
+// loadRateLimitRule loads a rate limit rule from the given key
+func loadRateLimitRule(key string) RateLimitRule {
+	return RateLimitRule{
+		RequestsPerMinute: viper.GetFloat64(key + ".requests_per_minute"),
+		Burst:             viper.GetInt(key + ".burst"),
+	}
+}
+
 // setDefaults sets default values for configuration if not provided
 func setDefaults(config *Config) {
 	// App defaults
@@ -93,6 +324,27 @@ func setDefaults(config *Config) {
 	if config.Server.HTTP.WriteTimeout == 0 {
 		config.Server.HTTP.WriteTimeout = 15 * time.Second
 	}
+	if config.Server.HTTP.TLS.Autocert.CacheDir == "" {
+		config.Server.HTTP.TLS.Autocert.CacheDir = "./certs"
+	}
+	if config.Server.HTTP.TLS.Autocert.HTTPPort == 0 {
+		config.Server.HTTP.TLS.Autocert.HTTPPort = 80
+	}
+	if len(config.Server.HTTP.Compression.Encodings) == 0 {
+		config.Server.HTTP.Compression.Encodings = []string{"br", "gzip"}
+	}
+	if config.Server.HTTP.Compression.MinSize == 0 {
+		config.Server.HTTP.Compression.MinSize = 1024
+	}
+	if config.Server.HTTP.Compression.GzipLevel == 0 {
+		config.Server.HTTP.Compression.GzipLevel = 6
+	}
+	if config.Server.HTTP.Compression.BrotliLevel == 0 {
+		config.Server.HTTP.Compression.BrotliLevel = 4
+	}
+	if config.Server.HTTP.BodyLogging.MaxSize == 0 {
+		config.Server.HTTP.BodyLogging.MaxSize = 4096
+	}
 
 	// gRPC Server defaults
 	if config.Server.GRPC.Port == 0 {
@@ -101,6 +353,9 @@ func setDefaults(config *Config) {
 	if config.Server.GRPC.Timeout == 0 {
 		config.Server.GRPC.Timeout = 30 * time.Second
 	}
+	if config.Server.DrainTimeout == 0 {
+		config.Server.DrainTimeout = 5 * time.Minute
+	}
 
 	// Nmap defaults
 	if config.Nmap.Path == "" {
@@ -112,6 +367,15 @@ func setDefaults(config *Config) {
 	if config.Nmap.MaxConcurrentScans == 0 {
 		config.Nmap.MaxConcurrentScans = 5
 	}
+	if config.Nmap.ShardConcurrency == 0 {
+		config.Nmap.ShardConcurrency = 4
+	}
+	if config.Nmap.DuplicateScans == "" {
+		config.Nmap.DuplicateScans = "allow"
+	}
+	if config.Nmap.Backend == "" {
+		config.Nmap.Backend = "local"
+	}
 
 	// Logging defaults
 	if config.Log.Level == "" {
@@ -123,6 +387,21 @@ func setDefaults(config *Config) {
 	if config.Log.Output == "" {
 		config.Log.Output = "stdout"
 	}
+	if config.Log.Access.Format == "" {
+		config.Log.Access.Format = "json"
+	}
+	if config.Log.Access.Output == "" {
+		config.Log.Access.Output = "stdout"
+	}
+	if config.Log.Sampling.Initial == 0 {
+		config.Log.Sampling.Initial = 100
+	}
+	if config.Log.Sampling.Thereafter == 0 {
+		config.Log.Sampling.Thereafter = 100
+	}
+	if config.Log.Sampling.Tick == 0 {
+		config.Log.Sampling.Tick = time.Second
+	}
 
 	// Storage defaults
 	if config.Storage.Type == "" {
@@ -131,4 +410,120 @@ func setDefaults(config *Config) {
 	if config.Storage.RetentionPeriod == 0 {
 		config.Storage.RetentionPeriod = 168 * time.Hour // 7 days
 	}
+	if config.Storage.RetentionInterval == 0 {
+		config.Storage.RetentionInterval = time.Hour
+	}
+
+	// Auth defaults
+	if config.Auth.JWKSRefreshInterval == 0 {
+		config.Auth.JWKSRefreshInterval = time.Hour
+	}
+	if config.Auth.RolesClaim == "" {
+		config.Auth.RolesClaim = "roles"
+	}
+	if config.Auth.DefaultRole == "" {
+		config.Auth.DefaultRole = "viewer"
+	}
+	if config.Auth.TenantClaim == "" {
+		config.Auth.TenantClaim = "tenant"
+	}
+	if config.Auth.OIDC.GroupsClaim == "" {
+		config.Auth.OIDC.GroupsClaim = "groups"
+	}
+	if config.Auth.OIDC.IntrospectionCacheTTL == 0 {
+		config.Auth.OIDC.IntrospectionCacheTTL = 5 * time.Minute
+	}
+
+	// Rate limit defaults
+	if config.RateLimit.PerIP.RequestsPerMinute == 0 {
+		config.RateLimit.PerIP = RateLimitRule{RequestsPerMinute: 300, Burst: 50}
+	}
+	if config.RateLimit.PerUser.RequestsPerMinute == 0 {
+		config.RateLimit.PerUser = RateLimitRule{RequestsPerMinute: 120, Burst: 30}
+	}
+	if config.RateLimit.StartScan.RequestsPerMinute == 0 {
+		config.RateLimit.StartScan = RateLimitRule{RequestsPerMinute: 10, Burst: 3}
+	}
+	for name, route := range config.RateLimit.Routes {
+		if route.Key == "" {
+			route.Key = "ip"
+			config.RateLimit.Routes[name] = route
+		}
+	}
+
+	// Enrichment defaults
+	if config.Enrichment.RDAP.BaseURL == "" {
+		config.Enrichment.RDAP.BaseURL = "https://rdap.org"
+	}
+	if config.Enrichment.RDAP.Timeout == 0 {
+		config.Enrichment.RDAP.Timeout = 10 * time.Second
+	}
+	if config.Enrichment.RDAP.CacheTTL == 0 {
+		config.Enrichment.RDAP.CacheTTL = 24 * time.Hour
+	}
+	if config.Enrichment.RDAP.MaxLookups == 0 {
+		config.Enrichment.RDAP.MaxLookups = 64
+	}
+
+	// Discovery defaults
+	if config.Discovery.CTURL == "" {
+		config.Discovery.CTURL = "https://crt.sh"
+	}
+	if config.Discovery.Timeout == 0 {
+		config.Discovery.Timeout = 60 * time.Second
+	}
+	if config.Discovery.MaxHosts == 0 {
+		config.Discovery.MaxHosts = 256
+	}
+	if config.Discovery.Concurrency == 0 {
+		config.Discovery.Concurrency = 16
+	}
+
+	// Agent defaults
+	if config.Notifications.Timeout == 0 {
+		config.Notifications.Timeout = 10 * time.Second
+	}
+	if config.Agents.HeartbeatTimeout == 0 {
+		config.Agents.HeartbeatTimeout = 45 * time.Second
+	}
+
+	// Engine defaults
+	if config.Engines.Masscan.Path == "" {
+		config.Engines.Masscan.Path = "masscan"
+	}
+	if config.Engines.Masscan.Rate == 0 {
+		config.Engines.Masscan.Rate = 1000
+	}
+	if config.Engines.Rustscan.Path == "" {
+		config.Engines.Rustscan.Path = "rustscan"
+	}
+	if config.Engines.Rustscan.BatchSize == 0 {
+		config.Engines.Rustscan.BatchSize = 4500
+	}
+	if config.Engines.Rustscan.Timeout == 0 {
+		config.Engines.Rustscan.Timeout = 1500 * time.Millisecond
+	}
+	if config.Engines.Zmap.Path == "" {
+		config.Engines.Zmap.Path = "zmap"
+	}
+	if config.Engines.Zmap.Rate == 0 {
+		config.Engines.Zmap.Rate = 10000
+	}
+	if config.Engines.Naabu.Path == "" {
+		config.Engines.Naabu.Path = "naabu"
+	}
+	if config.Engines.Naabu.Rate == 0 {
+		config.Engines.Naabu.Rate = 1000
+	}
+	if config.Engines.Hybrid.SweepEngine == "" {
+		config.Engines.Hybrid.SweepEngine = "masscan"
+	}
+
+	// Secrets defaults
+	if config.Secrets.FileDir == "" {
+		config.Secrets.FileDir = "/run/secrets"
+	}
+	if config.Secrets.Vault.Token == "" {
+		config.Secrets.Vault.Token = os.Getenv("VAULT_TOKEN")
+	}
 }
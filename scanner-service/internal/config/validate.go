@@ -0,0 +1,251 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// StorageTypes lists the supported storage.type values
+var StorageTypes = []string{"memory"}
+
+// RetentionClasses lists the supported storage.retention_rules data classes
+var RetentionClasses = []string{"results", "raw_output"}
+
+// DuplicateScanPolicies lists the supported nmap.duplicate_scans values
+var DuplicateScanPolicies = []string{"allow", "reuse", "reject"}
+
+// NmapBackends lists the supported nmap.backend values
+var NmapBackends = []string{"local", "docker", "kubernetes"}
+
+// NmapIOClasses lists the supported nmap.resources.io_class values, empty to inherit the class of the service
+var NmapIOClasses = []string{"", "best-effort", "idle"}
+
+// CompressionEncodings lists the supported server.http.compression.encodings values
+var CompressionEncodings = []string{"br", "gzip"}
+
+// RateLimitKeys lists the supported rate_limit.routes.<name>.key values
+var RateLimitKeys = []string{"ip", "user"}
+
+// rateLimitMethods lists the HTTP methods of rate limited routes
+var rateLimitMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// logLevels lists the supported log.level values
+var logLevels = []string{"debug", "info", "warn", "error", "fatal"}
+
+// Validate checks the configuration after defaults were applied and reports every
+// invalid value or combination at once
+func (c *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	// Listeners
+	validPort := func(port int) bool { return port > 0 && port <= 65535 }
+	check(validPort(c.Server.HTTP.Port), "server.http.port %d is not a valid port", c.Server.HTTP.Port)
+	check(validPort(c.Server.GRPC.Port), "server.grpc.port %d is not a valid port", c.Server.GRPC.Port)
+	check(c.Server.HTTP.Port != c.Server.GRPC.Port, "server.http.port and server.grpc.port are both %d", c.Server.HTTP.Port)
+	if tls := c.Server.HTTP.TLS; tls.Enabled {
+		if tls.Autocert.Enabled {
+			check(len(tls.Autocert.Domains) > 0, "server.http.tls.autocert.domains must not be empty when autocert is enabled")
+			check(validPort(tls.Autocert.HTTPPort), "server.http.tls.autocert.http_port %d is not a valid port", tls.Autocert.HTTPPort)
+			check(tls.Autocert.HTTPPort != c.Server.HTTP.Port && tls.Autocert.HTTPPort != c.Server.GRPC.Port,
+				"server.http.tls.autocert.http_port %d collides with the HTTP or gRPC port", tls.Autocert.HTTPPort)
+		} else {
+			check(tls.CertFile != "" && tls.KeyFile != "", "server.http.tls.cert_file and key_file are required when TLS is enabled without autocert")
+		}
+	}
+
+	// Response compression
+	compression := c.Server.HTTP.Compression
+	for _, encoding := range compression.Encodings {
+		check(slices.Contains(CompressionEncodings, encoding), "unknown server.http.compression.encodings entry %q, supported: %s", encoding, strings.Join(CompressionEncodings, ", "))
+	}
+	check(compression.MinSize >= 0, "server.http.compression.min_size must not be negative, got %d", compression.MinSize)
+	check(compression.GzipLevel >= 1 && compression.GzipLevel <= 9, "server.http.compression.gzip_level must be between 1 and 9, got %d", compression.GzipLevel)
+	check(compression.BrotliLevel >= 0 && compression.BrotliLevel <= 11, "server.http.compression.brotli_level must be between 0 and 11, got %d", compression.BrotliLevel)
+	frameOptions := c.Server.HTTP.Security.FrameOptions
+	check(frameOptions == "" || frameOptions == "DENY" || frameOptions == "SAMEORIGIN", "unknown server.http.security_headers.frame_options %q, supported: DENY, SAMEORIGIN", frameOptions)
+	check(c.Server.HTTP.BodyLogging.MaxSize > 0, "server.http.body_logging.max_size must be positive, got %d", c.Server.HTTP.BodyLogging.MaxSize)
+
+	// Durations that must not be negative; zero values were replaced by defaults or disable a limit
+	for name, duration := range map[string]time.Duration{
+		"server.http.timeout":                       c.Server.HTTP.Timeout,
+		"server.http.read_timeout":                  c.Server.HTTP.ReadTimeout,
+		"server.http.write_timeout":                 c.Server.HTTP.WriteTimeout,
+		"server.grpc.timeout":                       c.Server.GRPC.Timeout,
+		"server.drain_timeout":                      c.Server.DrainTimeout,
+		"server.http.security_headers.hsts.max_age": c.Server.HTTP.Security.HSTS.MaxAge,
+		"nmap.timeout":                              c.Nmap.Timeout,
+		"nmap.max_timeout":                          c.Nmap.MaxTimeout,
+		"nmap.dry_run_delay":                        c.Nmap.DryRunDelay,
+		"nmap.resources.max_runtime":                c.Nmap.Resources.MaxRuntime,
+		"nmap.kubernetes.poll_interval":             c.Nmap.Kubernetes.PollInterval,
+		"storage.retention_period":                  c.Storage.RetentionPeriod,
+		"storage.retention_interval":                c.Storage.RetentionInterval,
+		"auth.jwks_refresh_interval":                c.Auth.JWKSRefreshInterval,
+		"auth.oidc.introspection_cache_ttl":         c.Auth.OIDC.IntrospectionCacheTTL,
+		"enrichment.rdap.timeout":                   c.Enrichment.RDAP.Timeout,
+		"enrichment.rdap.cache_ttl":                 c.Enrichment.RDAP.CacheTTL,
+		"discovery.timeout":                         c.Discovery.Timeout,
+		"agents.heartbeat_timeout":                  c.Agents.HeartbeatTimeout,
+		"engines.rustscan.timeout":                  c.Engines.Rustscan.Timeout,
+		"secrets.refresh_interval":                  c.Secrets.RefreshInterval,
+		"notifications.timeout":                     c.Notifications.Timeout,
+	} {
+		check(duration >= 0, "%s must not be negative, got %s", name, duration)
+	}
+
+	// Scan limits
+	check(c.Nmap.MaxConcurrentScans > 0, "nmap.max_concurrent_scans must be positive, got %d", c.Nmap.MaxConcurrentScans)
+	check(c.Nmap.MaxTimeout == 0 || c.Nmap.Timeout <= c.Nmap.MaxTimeout,
+		"nmap.timeout %s exceeds nmap.max_timeout %s", c.Nmap.Timeout, c.Nmap.MaxTimeout)
+	for name, value := range map[string]int{
+		"nmap.queue_size":          c.Nmap.QueueSize,
+		"nmap.max_hosts":           c.Nmap.MaxHosts,
+		"nmap.max_rate":            c.Nmap.MaxRate,
+		"nmap.max_parallelism":     c.Nmap.MaxParallelism,
+		"nmap.shard_size":          c.Nmap.ShardSize,
+		"nmap.log_lines":           c.Nmap.LogLines,
+		"nmap.log_scans":           c.Nmap.LogScans,
+		"nmap.resources.memory_mb": c.Nmap.Resources.MemoryMB,
+	} {
+		check(value >= 0, "%s must not be negative, got %d", name, value)
+	}
+	check(c.Nmap.ShardConcurrency > 0, "nmap.shard_concurrency must be positive, got %d", c.Nmap.ShardConcurrency)
+	check(slices.Contains(DuplicateScanPolicies, c.Nmap.DuplicateScans), "unknown nmap.duplicate_scans %q, supported: %s", c.Nmap.DuplicateScans, strings.Join(DuplicateScanPolicies, ", "))
+	check(c.Nmap.MaxRetries >= -1, "nmap.max_retries must be -1 (nmap default) or more, got %d", c.Nmap.MaxRetries)
+
+	// Resource controls of nmap
+	resources := c.Nmap.Resources
+	check(resources.Nice >= 0 && resources.Nice <= 19, "nmap.resources.nice must be between 0 and 19, got %d", resources.Nice)
+	check(slices.Contains(NmapIOClasses, resources.IOClass), "unknown nmap.resources.io_class %q, supported: best-effort, idle", resources.IOClass)
+	check(resources.CPUs >= 0, "nmap.resources.cpus must not be negative, got %g", resources.CPUs)
+
+	// Where nmap runs
+	check(slices.Contains(NmapBackends, c.Nmap.Backend), "unknown nmap.backend %q, supported: %s", c.Nmap.Backend, strings.Join(NmapBackends, ", "))
+	check(c.Nmap.Backend != "docker" || c.Nmap.Docker.Image != "", "nmap.docker.image is required when nmap.backend is docker")
+	check(c.Nmap.Backend != "kubernetes" || c.Nmap.Kubernetes.Image != "", "nmap.kubernetes.image is required when nmap.backend is kubernetes")
+
+	// Storage and logging
+	check(slices.Contains(StorageTypes, c.Storage.Type), "unknown storage.type %q, supported: %s", c.Storage.Type, strings.Join(StorageTypes, ", "))
+	for _, class := range slices.Sorted(maps.Keys(c.Storage.RetentionRules)) {
+		check(slices.Contains(RetentionClasses, class), "unknown storage.retention_rules class %q, supported: %s", class, strings.Join(RetentionClasses, ", "))
+	}
+	_, rawOutputRule := c.Storage.RetentionRules["raw_output"]
+	check(!rawOutputRule || c.Nmap.StateDir != "", "storage.retention_rules.raw_output needs nmap.state_dir")
+	check(slices.Contains(logLevels, c.Log.Level), "unknown log.level %q, supported: %s", c.Log.Level, strings.Join(logLevels, ", "))
+	check(c.Log.Format == "json" || c.Log.Format == "console", "unknown log.format %q, supported: json, console", c.Log.Format)
+	check(!c.Log.Sampling.Enabled || (c.Log.Sampling.Initial > 0 && c.Log.Sampling.Thereafter > 0 && c.Log.Sampling.Tick > 0),
+		"log.sampling needs a positive initial, thereafter and tick when enabled")
+	check(c.Log.Access.Format == "json" || c.Log.Access.Format == "console", "unknown log.access.format %q, supported: json, console", c.Log.Access.Format)
+
+	// Rate limits
+	for name, rule := range map[string]RateLimitRule{
+		"rate_limit.per_ip":     c.RateLimit.PerIP,
+		"rate_limit.per_user":   c.RateLimit.PerUser,
+		"rate_limit.start_scan": c.RateLimit.StartScan,
+	} {
+		check(rule.RequestsPerMinute > 0 && rule.Burst >= 0, "%s needs a positive requests_per_minute and a non-negative burst", name)
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.RateLimit.Routes)) {
+		route := c.RateLimit.Routes[name]
+		check(route.RequestsPerMinute > 0 && route.Burst >= 0, "rate_limit.routes.%s needs a positive requests_per_minute and a non-negative burst", name)
+		check(route.Method == "" || slices.Contains(rateLimitMethods, route.Method), "unknown rate_limit.routes.%s.method %q, supported: %s", name, route.Method, strings.Join(rateLimitMethods, ", "))
+		check(strings.HasPrefix(route.Path, "/"), "rate_limit.routes.%s.path must be a route pattern starting with /, got %q", name, route.Path)
+		check(slices.Contains(RateLimitKeys, route.Key), "unknown rate_limit.routes.%s.key %q, supported: %s", name, route.Key, strings.Join(RateLimitKeys, ", "))
+	}
+
+	// Features depending on other settings
+	check(!c.Agents.Enabled || c.Agents.Token != "", "agents.token is required when agents are enabled")
+	check(!c.Engines.Hybrid.Enabled || c.Engines.Hybrid.SweepEngine != "nmap", "engines.hybrid.sweep_engine must not be nmap")
+	check(c.Secrets.Vault.Address == "" || c.Secrets.Vault.Token != "", "secrets.vault.token or VAULT_TOKEN is required when secrets.vault.address is set")
+	check(!c.Notifications.PanicAlerts || c.Notifications.Address != "", "notifications.address is required when notifications.panic_alerts is enabled")
+	for _, days := range c.Notifications.CertificateExpiryDays {
+		check(days > 0, "notifications.certificate_expiry_days must be positive, got %d", days)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	slices.Sort(problems)
+	return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+}
+
+// Summary returns the effective values of the main settings as "key=value" entries
+// for logging at startup. Secrets are only reported as set or unset.
+func (c *Config) Summary() []string {
+	secret := func(value string) string {
+		if value == "" {
+			return "unset"
+		}
+		return "set"
+	}
+
+	return []string{
+		fmt.Sprintf("server.http.port=%d", c.Server.HTTP.Port),
+		fmt.Sprintf("server.http.tls.enabled=%t", c.Server.HTTP.TLS.Enabled),
+		fmt.Sprintf("server.http.tls.autocert.enabled=%t", c.Server.HTTP.TLS.Autocert.Enabled),
+		fmt.Sprintf("server.http.compression.enabled=%t", c.Server.HTTP.Compression.Enabled),
+		fmt.Sprintf("server.http.compression.encodings=%s", strings.Join(c.Server.HTTP.Compression.Encodings, ",")),
+		fmt.Sprintf("server.http.body_logging.enabled=%t", c.Server.HTTP.BodyLogging.Enabled),
+		fmt.Sprintf("server.http.security_headers.enabled=%t", c.Server.HTTP.Security.Enabled),
+		fmt.Sprintf("server.http.security_headers.hsts.enabled=%t", c.Server.HTTP.Security.HSTS.Enabled),
+		fmt.Sprintf("server.grpc.port=%d", c.Server.GRPC.Port),
+		fmt.Sprintf("server.drain_timeout=%s", c.Server.DrainTimeout),
+		fmt.Sprintf("nmap.path=%s", c.Nmap.Path),
+		fmt.Sprintf("nmap.timeout=%s", c.Nmap.Timeout),
+		fmt.Sprintf("nmap.max_timeout=%s", c.Nmap.MaxTimeout),
+		fmt.Sprintf("nmap.max_concurrent_scans=%d", c.Nmap.MaxConcurrentScans),
+		fmt.Sprintf("nmap.queue_size=%d", c.Nmap.QueueSize),
+		fmt.Sprintf("nmap.max_hosts=%d", c.Nmap.MaxHosts),
+		fmt.Sprintf("nmap.shard_size=%d", c.Nmap.ShardSize),
+		fmt.Sprintf("nmap.dry_run=%t", c.Nmap.DryRun),
+		fmt.Sprintf("nmap.evasion_enabled=%t", c.Nmap.EvasionEnabled),
+		fmt.Sprintf("nmap.state_dir=%s", c.Nmap.StateDir),
+		fmt.Sprintf("nmap.duplicate_scans=%s", c.Nmap.DuplicateScans),
+		fmt.Sprintf("nmap.log_lines=%d", c.Nmap.LogLines),
+		fmt.Sprintf("nmap.backend=%s", c.Nmap.Backend),
+		fmt.Sprintf("nmap.resources.nice=%d", c.Nmap.Resources.Nice),
+		fmt.Sprintf("nmap.resources.cpus=%g", c.Nmap.Resources.CPUs),
+		fmt.Sprintf("nmap.resources.memory_mb=%d", c.Nmap.Resources.MemoryMB),
+		fmt.Sprintf("nmap.resources.max_runtime=%s", c.Nmap.Resources.MaxRuntime),
+		fmt.Sprintf("log.level=%s", c.Log.Level),
+		fmt.Sprintf("log.format=%s", c.Log.Format),
+		fmt.Sprintf("log.sampling.enabled=%t", c.Log.Sampling.Enabled),
+		fmt.Sprintf("log.redaction.targets=%t", c.Log.Redaction.Targets),
+		fmt.Sprintf("log.access.enabled=%t", c.Log.Access.Enabled),
+		fmt.Sprintf("storage.type=%s", c.Storage.Type),
+		fmt.Sprintf("storage.retention_period=%s", c.Storage.RetentionPeriod),
+		fmt.Sprintf("storage.retention_overrides=%d", len(c.Storage.UserRetention)+len(c.Storage.TenantRetention)),
+		fmt.Sprintf("storage.compress_results=%t", c.Storage.CompressResults),
+		fmt.Sprintf("storage.retention_rules=%d", len(c.Storage.RetentionRules)),
+		fmt.Sprintf("storage.retention_dry_run=%t", c.Storage.RetentionDryRun),
+		fmt.Sprintf("auth.enabled=%t", c.Auth.Enabled),
+		fmt.Sprintf("auth.secret=%s", secret(c.Auth.Secret)),
+		fmt.Sprintf("auth.jwks_url=%s", c.Auth.JWKSURL),
+		fmt.Sprintf("auth.oidc.issuer_url=%s", c.Auth.OIDC.IssuerURL),
+		fmt.Sprintf("auth.default_role=%s", c.Auth.DefaultRole),
+		fmt.Sprintf("policy.require_allowlist=%t", c.Policy.RequireAllowlist),
+		fmt.Sprintf("rate_limit.enabled=%t", c.RateLimit.Enabled),
+		fmt.Sprintf("rate_limit.routes=%s", strings.Join(slices.Sorted(maps.Keys(c.RateLimit.Routes)), ",")),
+		fmt.Sprintf("rate_limit.redis.address=%s", c.RateLimit.Redis.Address),
+		fmt.Sprintf("discovery.enabled=%t", c.Discovery.Enabled),
+		fmt.Sprintf("agents.enabled=%t", c.Agents.Enabled),
+		fmt.Sprintf("agents.token=%s", secret(c.Agents.Token)),
+		fmt.Sprintf("secrets.refresh_interval=%s", c.Secrets.RefreshInterval),
+		fmt.Sprintf("secrets.vault.address=%s", c.Secrets.Vault.Address),
+		fmt.Sprintf("notifications.address=%s", c.Notifications.Address),
+		fmt.Sprintf("notifications.panic_alerts=%t", c.Notifications.PanicAlerts),
+		fmt.Sprintf("engines.masscan.enabled=%t", c.Engines.Masscan.Enabled),
+		fmt.Sprintf("engines.rustscan.enabled=%t", c.Engines.Rustscan.Enabled),
+		fmt.Sprintf("engines.zmap.enabled=%t", c.Engines.Zmap.Enabled),
+		fmt.Sprintf("engines.naabu.enabled=%t", c.Engines.Naabu.Enabled),
+		fmt.Sprintf("engines.hybrid.enabled=%t", c.Engines.Hybrid.Enabled),
+	}
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// defaultConfig returns a configuration with all defaults applied
+func defaultConfig() *Config {
+	config := &Config{}
+	setDefaults(config)
+	return config
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, defaultConfig().Validate())
+
+	tests := []struct {
+		name    string
+		modify  func(config *Config)
+		problem string
+	}{
+		{"port collision", func(c *Config) { c.Server.GRPC.Port = c.Server.HTTP.Port }, "server.http.port and server.grpc.port are both 8081"},
+		{"invalid port", func(c *Config) { c.Server.HTTP.Port = 70000 }, "server.http.port 70000 is not a valid port"},
+		{"negative timeout", func(c *Config) { c.Nmap.Timeout = -time.Second }, "nmap.timeout must not be negative"},
+		{"timeout above maximum", func(c *Config) { c.Nmap.MaxTimeout = time.Minute }, "nmap.timeout 5m0s exceeds nmap.max_timeout 1m0s"},
+		{"unknown storage type", func(c *Config) { c.Storage.Type = "postgres" }, `unknown storage.type "postgres", supported: memory`},
+		{"unknown log level", func(c *Config) { c.Log.Level = "verbose" }, `unknown log.level "verbose"`},
+		{"unknown access log format", func(c *Config) { c.Log.Access.Format = "combined" }, `unknown log.access.format "combined"`},
+		{"invalid log sampling", func(c *Config) { c.Log.Sampling.Enabled = true; c.Log.Sampling.Thereafter = -1 }, "log.sampling needs a positive initial"},
+		{"tls without certificate", func(c *Config) { c.Server.HTTP.TLS.Enabled = true }, "cert_file and key_file are required"},
+		{"panic alerts without notification service", func(c *Config) { c.Notifications.PanicAlerts = true }, "notifications.address is required"},
+		{"invalid certificate expiry days", func(c *Config) { c.Notifications.CertificateExpiryDays = []int{30, 0} }, "notifications.certificate_expiry_days must be positive, got 0"},
+		{"route rate limit without rate", func(c *Config) {
+			c.RateLimit.Routes = map[string]RouteRateLimit{"create_scan": {Method: "POST", Path: "/api/v1/scans", Key: "ip"}}
+		}, "rate_limit.routes.create_scan needs a positive requests_per_minute"},
+		{"unknown route rate limit key", func(c *Config) {
+			c.RateLimit.Routes = map[string]RouteRateLimit{"read_scan": {Path: "/api/v1/scans/:id", Key: "tenant", RateLimitRule: RateLimitRule{RequestsPerMinute: 100}}}
+		}, `unknown rate_limit.routes.read_scan.key "tenant"`},
+		{"agents without token", func(c *Config) { c.Agents.Enabled = true }, "agents.token is required"},
+		{"unknown compression encoding", func(c *Config) { c.Server.HTTP.Compression.Encodings = []string{"zstd"} }, `unknown server.http.compression.encodings entry "zstd"`},
+		{"unknown frame options", func(c *Config) { c.Server.HTTP.Security.FrameOptions = "ALLOW-FROM https://example.com" }, "unknown server.http.security_headers.frame_options"},
+		{"invalid body logging size", func(c *Config) { c.Server.HTTP.BodyLogging.MaxSize = -1 }, "body_logging.max_size must be positive"},
+		{"invalid gzip level", func(c *Config) { c.Server.HTTP.Compression.GzipLevel = 10 }, "gzip_level must be between 1 and 9"},
+		{"unknown duplicate scan policy", func(c *Config) { c.Nmap.DuplicateScans = "merge" }, `unknown nmap.duplicate_scans "merge"`},
+		{"invalid nice value", func(c *Config) { c.Nmap.Resources.Nice = 20 }, "nmap.resources.nice must be between 0 and 19"},
+		{"unknown io class", func(c *Config) { c.Nmap.Resources.IOClass = "realtime" }, `unknown nmap.resources.io_class "realtime"`},
+		{"unknown nmap backend", func(c *Config) { c.Nmap.Backend = "podman" }, `unknown nmap.backend "podman", supported: local, docker, kubernetes`},
+		{"docker backend without image", func(c *Config) { c.Nmap.Backend = "docker" }, "nmap.docker.image is required"},
+		{"kubernetes backend without image", func(c *Config) { c.Nmap.Backend = "kubernetes" }, "nmap.kubernetes.image is required"},
+		{"negative concurrency", func(c *Config) { c.Nmap.MaxConcurrentScans = -1 }, "nmap.max_concurrent_scans must be positive"},
+		{"negative queue size", func(c *Config) { c.Nmap.QueueSize = -1 }, "nmap.queue_size must not be negative"},
+		{"unknown retention class", func(c *Config) { c.Storage.RetentionRules = map[string]time.Duration{"summaries": time.Hour} }, `unknown storage.retention_rules class "summaries"`},
+		{"raw output retention without state dir", func(c *Config) {
+			c.Nmap.StateDir = ""
+			c.Storage.RetentionRules = map[string]time.Duration{"raw_output": time.Hour}
+		}, "storage.retention_rules.raw_output needs nmap.state_dir"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := defaultConfig()
+			test.modify(config)
+			assert.ErrorContains(t, config.Validate(), test.problem)
+		})
+	}
+
+	// All problems are reported at once
+	config := defaultConfig()
+	config.Storage.Type = "redis"
+	config.Server.GRPC.Port = config.Server.HTTP.Port
+	err := config.Validate()
+	assert.ErrorContains(t, err, "storage.type")
+	assert.ErrorContains(t, err, "server.grpc.port")
+}
+
+func TestSummaryHidesSecrets(t *testing.T) {
+	config := defaultConfig()
+	config.Auth.Secret = "s3cret"
+	config.Agents.Token = "agent-token"
+
+	summary := config.Summary()
+	assert.Contains(t, summary, "auth.secret=set")
+	assert.Contains(t, summary, "agents.token=set")
+	assert.Contains(t, summary, "server.http.port=8081")
+	for _, entry := range summary {
+		assert.NotContains(t, entry, "s3cret")
+		assert.NotContains(t, entry, "agent-token")
+	}
+}
@@ -0,0 +1,26 @@
+package adapters
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// loadAverage returns the one-minute load average of the host, or 0 where it is not available
+func loadAverage() float64 {
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0
+	}
+
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+	return load
+}
@@ -0,0 +1,249 @@
+package adapters
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/agent/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/grpcjson"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// connectStreamDesc describes the Connect stream of the agent service
+var connectStreamDesc = grpc.StreamDesc{
+	StreamName:    domain.ConnectStream,
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// ClientConfig contains the configuration of an agent
+type ClientConfig struct {
+	ServerAddress     string        // gRPC address of the scanner service
+	Token             string        // Shared agent token
+	Name              string        // Unique agent name
+	Version           string        // Agent build version
+	TLS               bool          // Connect with TLS using the system root certificates
+	ReconnectInterval time.Duration // Delay before reconnecting after a failure
+	HeartbeatInterval time.Duration // Interval between heartbeats
+	HostBatchSize     int           // Number of hosts per result message
+	Networks          []string      // CIDRs reachable from the agent, empty for any target
+	MaxJobs           int           // Maximum concurrent scans, 0 for no limit
+}
+
+// AgentClient connects to the scanner service and runs the scans it receives with a local scanner
+type AgentClient struct {
+	config  ClientConfig
+	scanner scandomain.ScanAdapter
+	logger  *logger.Logger
+}
+
+// NewAgentClient creates a new AgentClient
+func NewAgentClient(config ClientConfig, scanner scandomain.ScanAdapter, logger *logger.Logger) *AgentClient {
+	if config.ReconnectInterval <= 0 {
+		config.ReconnectInterval = 10 * time.Second
+	}
+	if config.HeartbeatInterval <= 0 {
+		config.HeartbeatInterval = 15 * time.Second
+	}
+	if config.HostBatchSize <= 0 {
+		config.HostBatchSize = 100
+	}
+
+	return &AgentClient{
+		config:  config,
+		scanner: scanner,
+		logger:  logger,
+	}
+}
+
+// Run keeps the agent connected to the scanner service until ctx is cancelled
+func (c *AgentClient) Run(ctx context.Context) error {
+	transportCredentials := insecure.NewCredentials()
+	if c.config.TLS {
+		transportCredentials = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	conn, err := grpc.NewClient(c.config.ServerAddress,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(grpcjson.Name)),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create gRPC client: %w", err)
+	}
+	defer conn.Close()
+
+	for {
+		err := c.serve(ctx, conn)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		c.logger.Warn("Disconnected from scanner service, reconnecting",
+			zap.String("server", c.config.ServerAddress),
+			zap.Duration("interval", c.config.ReconnectInterval),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.config.ReconnectInterval):
+		}
+	}
+}
+
+// serve registers the agent and runs the received jobs until the stream fails
+func (c *AgentClient) serve(ctx context.Context, conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, domain.TokenMetadataKey, c.config.Token)
+	stream, err := conn.NewStream(ctx, &connectStreamDesc, domain.ConnectMethod)
+	if err != nil {
+		return err
+	}
+
+	nmapVersion, _ := c.scanner.GetVersion()
+	if err := stream.SendMsg(&domain.AgentMessage{Register: &domain.Registration{
+		Name:        c.config.Name,
+		Version:     c.config.Version,
+		NmapVersion: nmapVersion,
+		Capabilities: domain.Capabilities{
+			RawSocket: utils.RawSocketCapable(),
+			Networks:  c.config.Networks,
+			MaxJobs:   c.config.MaxJobs,
+		},
+	}}); err != nil {
+		return err
+	}
+
+	var reply domain.ServerMessage
+	if err := stream.RecvMsg(&reply); err != nil {
+		return err
+	}
+	if reply.Registered == nil {
+		return fmt.Errorf("registration was not acknowledged")
+	}
+
+	c.logger.Info("Connected to scanner service",
+		zap.String("server", c.config.ServerAddress),
+		zap.String("agent_id", reply.Registered.AgentID),
+	)
+
+	sender := &streamSender{stream: stream}
+	go c.sendHeartbeats(ctx, sender)
+
+	jobs := make(map[string]context.CancelFunc)
+	var mu sync.Mutex
+
+	for {
+		var message domain.ServerMessage
+		if err := stream.RecvMsg(&message); err != nil {
+			return err
+		}
+
+		switch {
+		case message.Job != nil:
+			jobCtx, cancelJob := context.WithCancel(ctx)
+			mu.Lock()
+			jobs[message.Job.ID] = cancelJob
+			mu.Unlock()
+
+			go func(job domain.Job) {
+				defer func() {
+					mu.Lock()
+					delete(jobs, job.ID)
+					mu.Unlock()
+					cancelJob()
+				}()
+				c.runJob(jobCtx, sender, job)
+			}(*message.Job)
+
+		case message.Cancel != nil:
+			mu.Lock()
+			if cancelJob, ok := jobs[message.Cancel.JobID]; ok {
+				cancelJob()
+			}
+			mu.Unlock()
+		}
+	}
+}
+
+// sendHeartbeats reports the agent alive with its load until ctx is cancelled
+func (c *AgentClient) sendHeartbeats(ctx context.Context, sender *streamSender) {
+	ticker := time.NewTicker(c.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !sender.send(c.logger, &domain.AgentMessage{Heartbeat: &domain.Heartbeat{Load: loadAverage()}}) {
+				return
+			}
+		}
+	}
+}
+
+// runJob runs a scan and streams its hosts in batches followed by the result
+func (c *AgentClient) runJob(ctx context.Context, sender *streamSender, job domain.Job) {
+	log := c.logger.With(zap.String("job_id", job.ID))
+	log.Info("Running scan job", zap.String("target", job.Options.Target))
+
+	// The job is stopped by the server through a cancel message or by the timeout
+	if job.Options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Options.Timeout)
+		defer cancel()
+	}
+
+	result, err := c.scanner.ExecuteScan(ctx, job.Options)
+	if err != nil {
+		log.Warn("Scan job failed", zap.Error(err))
+		sender.send(log, &domain.AgentMessage{Done: &domain.JobDone{JobID: job.ID, Error: err.Error()}})
+		return
+	}
+
+	hosts := result.Hosts
+	for start := 0; start < len(hosts); start += c.config.HostBatchSize {
+		end := min(start+c.config.HostBatchSize, len(hosts))
+		if !sender.send(log, &domain.AgentMessage{Hosts: &domain.JobHosts{JobID: job.ID, Hosts: hosts[start:end]}}) {
+			return
+		}
+	}
+
+	summary := *result
+	summary.Hosts = nil
+	sender.send(log, &domain.AgentMessage{Done: &domain.JobDone{JobID: job.ID, Result: &summary}})
+
+	log.Info("Scan job completed", zap.Int("host_count", len(hosts)))
+}
+
+// streamSender serializes the messages sent by concurrent jobs on the stream
+type streamSender struct {
+	mu     sync.Mutex
+	stream grpc.ClientStream
+}
+
+// send sends a message and reports whether it succeeded
+func (s *streamSender) send(log *logger.Logger, message *domain.AgentMessage) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.stream.SendMsg(message); err != nil {
+		log.Error("Failed to send message", zap.Error(err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// Dispatcher is a ScanAdapter running scans that name an agent on that agent
+// and all other scans with the local adapter
+type Dispatcher struct {
+	local  scandomain.ScanAdapter
+	agents *AgentService
+}
+
+// NewDispatcher creates a new Dispatcher
+func NewDispatcher(local scandomain.ScanAdapter, agents *AgentService) *Dispatcher {
+	return &Dispatcher{
+		local:  local,
+		agents: agents,
+	}
+}
+
+// ExecuteScan runs the scan on the agent named in the options, or locally
+func (d *Dispatcher) ExecuteScan(ctx context.Context, options scandomain.ScanOptions) (*scandomain.ScanResult, error) {
+	if options.Agent == "" {
+		return d.local.ExecuteScan(ctx, options)
+	}
+	return d.agents.ExecuteScan(ctx, options)
+}
+
+// CheckAgent checks that the scan can run on the agent named in the options
+func (d *Dispatcher) CheckAgent(options scandomain.ScanOptions) error {
+	return d.agents.CheckAgent(options)
+}
+
+// CheckEngine checks the engine of the scan with the local adapter.
+// Agents only run nmap scans.
+func (d *Dispatcher) CheckEngine(options scandomain.ScanOptions) error {
+	if checker, ok := d.local.(scandomain.EngineChecker); ok {
+		return checker.CheckEngine(options)
+	}
+	if engine := options.ResolvedEngine(); engine != scandomain.ScanEngineNmap {
+		return errors.NewInvalidInput("scan engine "+string(engine)+" is not enabled", nil)
+	}
+	return nil
+}
+
+// RawSocketCapable reports whether the local scanner may open raw sockets
+func (d *Dispatcher) RawSocketCapable() bool {
+	if checker, ok := d.local.(scandomain.PrivilegeChecker); ok {
+		return checker.RawSocketCapable()
+	}
+	return true
+}
+
+// EngineCapabilities returns the engines of the local scanner.
+// Agents only run nmap scans.
+func (d *Dispatcher) EngineCapabilities() []scandomain.EngineCapabilities {
+	if reporter, ok := d.local.(scandomain.EngineReporter); ok {
+		return reporter.EngineCapabilities()
+	}
+	return nil
+}
+
+// ScriptCategories returns the NSE script categories of the local scanner
+func (d *Dispatcher) ScriptCategories() ([]string, error) {
+	if lister, ok := d.local.(scandomain.ScriptCategoryLister); ok {
+		return lister.ScriptCategories()
+	}
+	return nil, errors.NewUnavailable("the scanner does not list script categories", nil)
+}
+
+// CanResume reports whether the local scanner saved the progress of an interrupted scan.
+// Scans on agents cannot be resumed.
+func (d *Dispatcher) CanResume(options scandomain.ScanOptions) bool {
+	resumer, ok := d.local.(scandomain.ScanResumer)
+	return ok && options.Agent == "" && resumer.CanResume(options)
+}
+
+// ResumeScan resumes an interrupted scan with the local scanner
+func (d *Dispatcher) ResumeScan(ctx context.Context, options scandomain.ScanOptions) (*scandomain.ScanResult, error) {
+	resumer, ok := d.local.(scandomain.ScanResumer)
+	if !ok || options.Agent != "" {
+		return nil, errors.NewInvalidInput("the scanner cannot resume scans", nil)
+	}
+	return resumer.ResumeScan(ctx, options)
+}
+
+// GetVersion returns the version of the local scanner
+func (d *Dispatcher) GetVersion() (string, error) {
+	return d.local.GetVersion()
+}
+
+// IsAvailable reports whether the local scanner is available
+func (d *Dispatcher) IsAvailable() bool {
+	return d.local.IsAvailable()
+}
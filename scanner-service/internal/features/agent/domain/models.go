@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"time"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+)
+
+// gRPC service of the agent protocol. Messages are encoded with the grpcjson codec.
+const (
+	ServiceName   = "nmapui.agent.v1.AgentService"
+	ConnectStream = "Connect"
+	ConnectMethod = "/" + ServiceName + "/" + ConnectStream
+
+	// TokenMetadataKey is the gRPC metadata key carrying the shared agent token
+	TokenMetadataKey = "x-agent-token"
+)
+
+// AutoAgent is the agent option value routing a scan to any healthy agent able to run it
+const AutoAgent = "auto"
+
+// AgentStatus represents the connection state of an agent
+type AgentStatus string
+
+// Agent status constants
+const (
+	AgentStatusOnline  AgentStatus = "ONLINE"  // Connected and accepting jobs
+	AgentStatusOffline AgentStatus = "OFFLINE" // Disconnected
+)
+
+// Agent represents a remote scanner that runs scans from its own network segment
+type Agent struct {
+	ID             string       `json:"id"`              // Identifier of the current connection
+	Name           string       `json:"name"`            // Unique name chosen by the agent
+	Version        string       `json:"version"`         // Agent build version
+	NmapVersion    string       `json:"nmap_version"`    // Version of nmap on the agent host
+	Address        string       `json:"address"`         // Remote address of the connection
+	Status         AgentStatus  `json:"status"`          // Connection state
+	Capabilities   Capabilities `json:"capabilities"`    // What the agent can scan
+	Load           float64      `json:"load"`            // Load average of the agent host from the last heartbeat
+	ConnectedAt    time.Time    `json:"connected_at"`    // When the agent connected
+	LastHeartbeat  time.Time    `json:"last_heartbeat"`  // When the agent last reported being alive
+	DisconnectedAt *time.Time   `json:"disconnected_at"` // When the agent disconnected or went stale
+	ActiveJobs     int          `json:"active_jobs"`     // Number of scans running on the agent
+}
+
+// Capabilities describes what an agent can scan
+type Capabilities struct {
+	RawSocket bool     `json:"raw_socket"`         // Whether nmap can send raw packets (SYN, UDP and OS detection)
+	Networks  []string `json:"networks,omitempty"` // CIDRs reachable from the agent, empty for any target
+	MaxJobs   int      `json:"max_jobs,omitempty"` // Maximum concurrent scans, 0 for no limit
+}
+
+// AgentMessage is a message sent by an agent to the scanner service.
+// Exactly one field is set.
+type AgentMessage struct {
+	Register  *Registration `json:"register,omitempty"`  // First message of a connection
+	Heartbeat *Heartbeat    `json:"heartbeat,omitempty"` // Periodic liveness and load report
+	Hosts     *JobHosts     `json:"hosts,omitempty"`     // Part of the hosts of a finished job
+	Done      *JobDone      `json:"done,omitempty"`      // Completion of a job, sent after its hosts
+}
+
+// ServerMessage is a message sent by the scanner service to an agent.
+// Exactly one field is set.
+type ServerMessage struct {
+	Registered *Registered `json:"registered,omitempty"` // Reply to the registration
+	Job        *Job        `json:"job,omitempty"`        // Scan to run
+	Cancel     *JobCancel  `json:"cancel,omitempty"`     // Scan to stop
+}
+
+// Registration identifies an agent when it connects
+type Registration struct {
+	Name         string       `json:"name"`
+	Version      string       `json:"version"`
+	NmapVersion  string       `json:"nmap_version"`
+	Capabilities Capabilities `json:"capabilities"`
+}
+
+// Heartbeat reports that an agent is alive
+type Heartbeat struct {
+	Load float64 `json:"load"` // One-minute load average of the agent host
+}
+
+// Registered acknowledges a registration
+type Registered struct {
+	AgentID string `json:"agent_id"`
+}
+
+// Job is a scan run by an agent
+type Job struct {
+	ID      string                 `json:"id"`
+	Options scandomain.ScanOptions `json:"options"`
+}
+
+// JobCancel asks an agent to stop a job
+type JobCancel struct {
+	JobID string `json:"job_id"`
+}
+
+// JobHosts carries a batch of the hosts found by a job.
+// Results are streamed in batches to stay below the gRPC message size limit.
+type JobHosts struct {
+	JobID string            `json:"job_id"`
+	Hosts []scandomain.Host `json:"hosts"`
+}
+
+// JobDone reports the end of a job. The result is sent without its hosts.
+type JobDone struct {
+	JobID  string                 `json:"job_id"`
+	Result *scandomain.ScanResult `json:"result,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+}
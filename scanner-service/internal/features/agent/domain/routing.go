@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+)
+
+// selectLocked returns the session the scan should run on: the agent named in the
+// options or, for AutoAgent, the least busy healthy agent able to run it.
+// The caller must hold mu.
+func (s *AgentService) selectLocked(options scandomain.ScanOptions, now time.Time) (*Session, error) {
+	if options.Agent != AutoAgent {
+		session, ok := s.agents[options.Agent]
+		if !ok || !session.aliveLocked(now, s.heartbeatTimeout) {
+			return nil, errors.NewUnavailable("agent "+options.Agent+" is not connected", nil)
+		}
+		if err := session.canRun(options); err != nil {
+			return nil, err
+		}
+		if session.busyLocked() {
+			return nil, errors.NewUnavailable("agent "+options.Agent+" is running its maximum number of scans", nil)
+		}
+		return session, nil
+	}
+
+	var candidates []*Session
+	for _, session := range s.agents {
+		if session.aliveLocked(now, s.heartbeatTimeout) && !session.busyLocked() && session.canRun(options) == nil {
+			candidates = append(candidates, session)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.NewUnavailable("no healthy agent can scan "+options.Target, nil)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i].agent, candidates[j].agent
+		if a.ActiveJobs != b.ActiveJobs {
+			return a.ActiveJobs < b.ActiveJobs
+		}
+		if a.Load != b.Load {
+			return a.Load < b.Load
+		}
+		return a.Name < b.Name
+	})
+
+	return candidates[0], nil
+}
+
+// aliveLocked reports whether the agent is connected and sent a heartbeat recently.
+// The caller must hold service.mu.
+func (s *Session) aliveLocked(now time.Time, heartbeatTimeout time.Duration) bool {
+	return s.agent.Status == AgentStatusOnline && now.Sub(s.agent.LastHeartbeat) <= heartbeatTimeout
+}
+
+// busyLocked reports whether the agent runs its maximum number of scans.
+// The caller must hold service.mu.
+func (s *Session) busyLocked() bool {
+	return s.agent.Capabilities.MaxJobs > 0 && s.agent.ActiveJobs >= s.agent.Capabilities.MaxJobs
+}
+
+// canRun checks that the agent has the privileges and network reach the scan needs
+func (s *Session) canRun(options scandomain.ScanOptions) error {
+	if options.ResolvedEngine() != scandomain.ScanEngineNmap {
+		return errors.NewInvalidInput("agents only run nmap scans", nil)
+	}
+	if options.RequiresRawSocket() && !s.agent.Capabilities.RawSocket {
+		return errors.NewInvalidInput(fmt.Sprintf("agent %s cannot send raw packets required by the scan", s.agent.Name), nil)
+	}
+
+	if len(s.networks) == 0 {
+		return nil
+	}
+	for _, target := range utils.SplitTargets(options.Target) {
+		if !s.reaches(target) {
+			return errors.NewInvalidInput(fmt.Sprintf("target %s is outside the networks reachable from agent %s", target, s.agent.Name), nil)
+		}
+	}
+	return nil
+}
+
+// reaches reports whether a single target lies in the networks of the agent.
+// Host names are only scanned by agents that reach any target.
+func (s *Session) reaches(target string) bool {
+	start, end, _ := scandomain.ParseTargetRange(target)
+	if start == nil {
+		return false
+	}
+	for _, network := range s.networks {
+		if network.Contains(start) && network.Contains(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNetworks parses the networks reported by an agent
+func parseNetworks(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, errors.NewInvalidInput("invalid agent network "+cidr, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
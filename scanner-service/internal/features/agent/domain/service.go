@@ -0,0 +1,368 @@
+package domain
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// sendBufferSize is the number of messages queued for an agent before senders block
+const sendBufferSize = 16
+
+// AgentService keeps track of connected agents and runs scans on them
+type AgentService struct {
+	logger           *logger.Logger
+	heartbeatTimeout time.Duration // Time without heartbeat after which an agent is marked offline
+	mu               sync.Mutex
+	agents           map[string]*Session // Latest session by agent name
+	stop             chan struct{}
+}
+
+// NewAgentService creates a new AgentService
+func NewAgentService(logger *logger.Logger, heartbeatTimeout time.Duration) *AgentService {
+	return &AgentService{
+		logger:           logger,
+		heartbeatTimeout: heartbeatTimeout,
+		agents:           make(map[string]*Session),
+	}
+}
+
+// Session is the connection of a registered agent
+type Session struct {
+	service  *AgentService
+	agent    *Agent
+	networks []*net.IPNet // Parsed networks reachable from the agent
+	send     chan *ServerMessage
+	done     chan struct{}
+	jobs     map[string]*job // Jobs running on the agent by ID, guarded by service.mu
+}
+
+// job collects the streamed result of a scan running on an agent
+type job struct {
+	hosts []scandomain.Host
+	done  chan JobDone
+}
+
+// Connect registers the connection of an agent. A connection with the name of a
+// connected agent replaces the old one, which may not have noticed a network failure yet.
+func (s *AgentService) Connect(registration Registration, address string) (*Session, error) {
+	if registration.Name == "" {
+		return nil, errors.NewInvalidInput("agent name is required", nil)
+	}
+	if registration.Name == AutoAgent {
+		return nil, errors.NewInvalidInput("agent name "+AutoAgent+" is reserved", nil)
+	}
+
+	networks, err := parseNetworks(registration.Capabilities.Networks)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &Session{
+		service: s,
+		agent: &Agent{
+			ID:            uuid.New().String(),
+			Name:          registration.Name,
+			Version:       registration.Version,
+			NmapVersion:   registration.NmapVersion,
+			Address:       address,
+			Status:        AgentStatusOnline,
+			Capabilities:  registration.Capabilities,
+			ConnectedAt:   now,
+			LastHeartbeat: now,
+		},
+		networks: networks,
+		send:     make(chan *ServerMessage, sendBufferSize),
+		done:     make(chan struct{}),
+		jobs:     make(map[string]*job),
+	}
+
+	s.mu.Lock()
+	if old, ok := s.agents[registration.Name]; ok {
+		old.closeLocked()
+	}
+	s.agents[registration.Name] = session
+	s.mu.Unlock()
+
+	s.logger.Info("Agent connected",
+		zap.String("agent", registration.Name),
+		zap.String("agent_id", session.agent.ID),
+		zap.String("address", address),
+		zap.String("nmap_version", registration.NmapVersion),
+		zap.Bool("raw_socket", registration.Capabilities.RawSocket),
+		zap.Strings("networks", registration.Capabilities.Networks),
+	)
+
+	return session, nil
+}
+
+// ListAgents lists the known agents by name. The caller must have the viewer role.
+func (s *AgentService) ListAgents(ctx context.Context) ([]*Agent, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	agents := make([]*Agent, 0, len(s.agents))
+	for _, session := range s.agents {
+		agent := *session.agent
+		agents = append(agents, &agent)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].Name < agents[j].Name
+	})
+
+	return agents, nil
+}
+
+// CheckHealth reports the number of online and offline agents. Agents are not critical
+// because scans without an agent option run on the scanner service itself.
+func (s *AgentService) CheckHealth(ctx context.Context) scandomain.HealthCheck {
+	s.mu.Lock()
+	online, offline, activeJobs := 0, 0, 0
+	for _, session := range s.agents {
+		if session.agent.Status == AgentStatusOnline {
+			online++
+			activeJobs += session.agent.ActiveJobs
+		} else {
+			offline++
+		}
+	}
+	s.mu.Unlock()
+
+	check := scandomain.HealthCheck{
+		Name:   "agents",
+		Status: scandomain.CheckStatusUp,
+		Details: map[string]any{
+			"online":      online,
+			"offline":     offline,
+			"active_jobs": activeJobs,
+		},
+	}
+	if online == 0 && offline > 0 {
+		check.Status = scandomain.CheckStatusDegraded
+		check.Error = "no agent is online"
+	}
+
+	return check
+}
+
+// CheckAgent checks that the agent option of the scan names a healthy agent able to run it
+func (s *AgentService) CheckAgent(options scandomain.ScanOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.selectLocked(options, time.Now())
+	return err
+}
+
+// ExecuteScan runs a scan on the agent selected by its agent option and waits for its result.
+// Cancelling ctx asks the agent to stop the scan.
+func (s *AgentService) ExecuteScan(ctx context.Context, options scandomain.ScanOptions) (*scandomain.ScanResult, error) {
+	s.mu.Lock()
+	session, err := s.selectLocked(options, time.Now())
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	name := session.agent.Name
+
+	jobID := uuid.New().String()
+	j := &job{done: make(chan JobDone, 1)}
+	session.jobs[jobID] = j
+	session.agent.ActiveJobs++
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(session.jobs, jobID)
+		session.agent.ActiveJobs--
+		s.mu.Unlock()
+	}()
+
+	log := s.logger.WithContext(ctx).With(zap.String("agent", name), zap.String("job_id", jobID))
+	log.Info("Dispatching scan to agent", zap.String("target", options.Target))
+
+	// The agent runs the scan with its local scanner
+	options.Agent = ""
+	if err := session.enqueue(ctx, &ServerMessage{Job: &Job{ID: jobID, Options: options}}); err != nil {
+		return nil, err
+	}
+
+	select {
+	case done := <-j.done:
+		if done.Error != "" {
+			return nil, errors.NewInternal("agent scan failed: "+done.Error, nil)
+		}
+		if done.Result == nil {
+			return nil, errors.NewInternal("agent returned no result", nil)
+		}
+
+		// No more hosts arrive after the job is done
+		s.mu.Lock()
+		done.Result.Hosts = j.hosts
+		s.mu.Unlock()
+
+		log.Info("Agent scan completed", zap.Int("host_count", len(done.Result.Hosts)))
+		return done.Result, nil
+
+	case <-ctx.Done():
+		session.trySend(&ServerMessage{Cancel: &JobCancel{JobID: jobID}})
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, errors.NewTimeout("scan timed out", ctx.Err())
+		}
+		return nil, errors.NewTimeout("scan was cancelled", ctx.Err())
+
+	case <-session.done:
+		return nil, errors.NewUnavailable("agent "+name+" disconnected during the scan", nil)
+	}
+}
+
+// Agent returns a copy of the agent of the session
+func (s *Session) Agent() Agent {
+	s.service.mu.Lock()
+	defer s.service.mu.Unlock()
+	return *s.agent
+}
+
+// Outgoing returns the messages to send to the agent
+func (s *Session) Outgoing() <-chan *ServerMessage {
+	return s.send
+}
+
+// Done returns a channel that is closed when the session is closed
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Handle processes a message received from the agent
+func (s *Session) Handle(message *AgentMessage) error {
+	s.service.mu.Lock()
+	defer s.service.mu.Unlock()
+
+	switch {
+	case message.Heartbeat != nil:
+		s.agent.Load = message.Heartbeat.Load
+		s.agent.LastHeartbeat = time.Now()
+	case message.Hosts != nil:
+		if j, ok := s.jobs[message.Hosts.JobID]; ok {
+			j.hosts = append(j.hosts, message.Hosts.Hosts...)
+		}
+	case message.Done != nil:
+		if j, ok := s.jobs[message.Done.JobID]; ok {
+			select {
+			case j.done <- *message.Done:
+			default:
+			}
+		}
+	default:
+		return errors.NewInvalidInput("unexpected agent message", nil)
+	}
+
+	return nil
+}
+
+// Start starts marking agents that stopped sending heartbeats offline
+func (s *AgentService) Start() {
+	s.stop = make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(s.heartbeatTimeout / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case now := <-ticker.C:
+				s.closeStale(now)
+			}
+		}
+	}()
+}
+
+// Stop stops marking stale agents offline
+func (s *AgentService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+	}
+}
+
+// closeStale closes the sessions of agents without a heartbeat within the timeout.
+// Their scans fail and their connections are dropped, so they reconnect once reachable again.
+func (s *AgentService) closeStale(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, session := range s.agents {
+		if session.agent.Status == AgentStatusOnline && !session.aliveLocked(now, s.heartbeatTimeout) && session.closeLocked() {
+			s.logger.Warn("Agent missed its heartbeats, marked offline",
+				zap.String("agent", session.agent.Name),
+				zap.String("agent_id", session.agent.ID),
+				zap.Time("last_heartbeat", session.agent.LastHeartbeat),
+			)
+		}
+	}
+}
+
+// Close marks the agent offline and fails the scans running on it
+func (s *Session) Close() {
+	s.service.mu.Lock()
+	defer s.service.mu.Unlock()
+
+	if s.closeLocked() {
+		s.service.logger.Info("Agent disconnected",
+			zap.String("agent", s.agent.Name),
+			zap.String("agent_id", s.agent.ID),
+		)
+	}
+}
+
+// closeLocked closes the session if it is open and reports whether it did.
+// The caller must hold service.mu.
+func (s *Session) closeLocked() bool {
+	select {
+	case <-s.done:
+		return false
+	default:
+	}
+
+	now := time.Now()
+	s.agent.Status = AgentStatusOffline
+	s.agent.DisconnectedAt = &now
+	close(s.done)
+	return true
+}
+
+// enqueue queues a message for the agent
+func (s *Session) enqueue(ctx context.Context, message *ServerMessage) error {
+	select {
+	case s.send <- message:
+		return nil
+	case <-s.done:
+		return errors.NewUnavailable("agent "+s.agent.Name+" disconnected", nil)
+	case <-ctx.Done():
+		return errors.NewTimeout("scan was cancelled", ctx.Err())
+	}
+}
+
+// trySend queues a message for the agent unless the queue is full or the session closed
+func (s *Session) trySend(message *ServerMessage) {
+	select {
+	case <-s.done:
+	case s.send <- message:
+	default:
+	}
+}
@@ -0,0 +1,278 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/agent/domain"
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newAgentService() *domain.AgentService {
+	zapLogger, _ := zap.NewDevelopment()
+	return domain.NewAgentService(&logger.Logger{Logger: zapLogger}, time.Minute)
+}
+
+// receiveMessage waits for the next message sent to the agent
+func receiveMessage(t *testing.T, session *domain.Session) *domain.ServerMessage {
+	select {
+	case message := <-session.Outgoing():
+		return message
+	case <-time.After(time.Second):
+		t.Fatal("no message sent to the agent")
+		return nil
+	}
+}
+
+func TestExecuteScan_CollectsStreamedHosts(t *testing.T) {
+	service := newAgentService()
+	session, err := service.Connect(domain.Registration{Name: "dmz-1", NmapVersion: "7.94"}, "10.0.0.5:4242")
+	require.NoError(t, err)
+	require.NoError(t, service.CheckAgent(scandomain.ScanOptions{Target: "10.1.0.1", Agent: "dmz-1"}))
+
+	// Simulate the agent answering the job
+	go func() {
+		message := <-session.Outgoing()
+		job := message.Job
+		assert.Equal(t, "", job.Options.Agent)
+		assert.NoError(t, session.Handle(&domain.AgentMessage{Hosts: &domain.JobHosts{
+			JobID: job.ID,
+			Hosts: []scandomain.Host{{IP: "10.1.0.1"}},
+		}}))
+		assert.NoError(t, session.Handle(&domain.AgentMessage{Hosts: &domain.JobHosts{
+			JobID: job.ID,
+			Hosts: []scandomain.Host{{IP: "10.1.0.2"}},
+		}}))
+		assert.NoError(t, session.Handle(&domain.AgentMessage{Done: &domain.JobDone{
+			JobID:  job.ID,
+			Result: &scandomain.ScanResult{TotalHosts: 2},
+		}}))
+	}()
+
+	result, err := service.ExecuteScan(context.Background(), scandomain.ScanOptions{Target: "10.1.0.0/30", Agent: "dmz-1"})
+	require.NoError(t, err)
+	require.Len(t, result.Hosts, 2)
+	assert.Equal(t, "10.1.0.1", result.Hosts[0].IP)
+	assert.Equal(t, "10.1.0.2", result.Hosts[1].IP)
+	assert.Equal(t, 0, session.Agent().ActiveJobs)
+}
+
+func TestExecuteScan_AgentFailure(t *testing.T) {
+	service := newAgentService()
+	session, err := service.Connect(domain.Registration{Name: "dmz-1"}, "10.0.0.5:4242")
+	require.NoError(t, err)
+
+	go func() {
+		job := (<-session.Outgoing()).Job
+		assert.NoError(t, session.Handle(&domain.AgentMessage{Done: &domain.JobDone{JobID: job.ID, Error: "nmap not found"}}))
+	}()
+
+	_, err = service.ExecuteScan(context.Background(), scandomain.ScanOptions{Target: "10.1.0.1", Agent: "dmz-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nmap not found")
+}
+
+func TestExecuteScan_CancelSendsCancelMessage(t *testing.T) {
+	service := newAgentService()
+	session, err := service.Connect(domain.Registration{Name: "dmz-1"}, "10.0.0.5:4242")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := service.ExecuteScan(ctx, scandomain.ScanOptions{Target: "10.1.0.1", Agent: "dmz-1"})
+		errs <- err
+	}()
+
+	job := receiveMessage(t, session).Job
+	require.NotNil(t, job)
+	cancel()
+
+	err = <-errs
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrTimeout, scanErr.Type)
+
+	message := receiveMessage(t, session)
+	require.NotNil(t, message.Cancel)
+	assert.Equal(t, job.ID, message.Cancel.JobID)
+}
+
+func TestExecuteScan_Disconnect(t *testing.T) {
+	service := newAgentService()
+	session, err := service.Connect(domain.Registration{Name: "dmz-1"}, "10.0.0.5:4242")
+	require.NoError(t, err)
+
+	go func() {
+		<-session.Outgoing()
+		session.Close()
+	}()
+
+	_, err = service.ExecuteScan(context.Background(), scandomain.ScanOptions{Target: "10.1.0.1", Agent: "dmz-1"})
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrUnavailable, scanErr.Type)
+
+	// Disconnected agents stay listed as offline
+	ctx := authdomain.WithPrincipal(context.Background(), &authdomain.Principal{
+		UserID: "alice",
+		Roles:  []authdomain.Role{authdomain.RoleViewer},
+	})
+	agents, err := service.ListAgents(ctx)
+	require.NoError(t, err)
+	require.Len(t, agents, 1)
+	assert.Equal(t, domain.AgentStatusOffline, agents[0].Status)
+	assert.NotNil(t, agents[0].DisconnectedAt)
+	assert.Error(t, service.CheckAgent(scandomain.ScanOptions{Target: "10.1.0.1", Agent: "dmz-1"}))
+}
+
+func TestConnect_ReplacesSessionWithSameName(t *testing.T) {
+	service := newAgentService()
+	old, err := service.Connect(domain.Registration{Name: "dmz-1"}, "10.0.0.5:4242")
+	require.NoError(t, err)
+
+	_, err = service.Connect(domain.Registration{Name: "dmz-1"}, "10.0.0.5:4343")
+	require.NoError(t, err)
+
+	select {
+	case <-old.Done():
+	default:
+		t.Fatal("old session was not closed")
+	}
+	assert.NoError(t, service.CheckAgent(scandomain.ScanOptions{Target: "10.1.0.1", Agent: "dmz-1"}))
+}
+
+func TestExecuteScan_UnknownAgent(t *testing.T) {
+	service := newAgentService()
+
+	_, err := service.ExecuteScan(context.Background(), scandomain.ScanOptions{Target: "10.1.0.1", Agent: "missing"})
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrUnavailable, scanErr.Type)
+}
+
+func TestCheckAgent_Capabilities(t *testing.T) {
+	service := newAgentService()
+	_, err := service.Connect(domain.Registration{
+		Name:         "dmz-1",
+		Capabilities: domain.Capabilities{Networks: []string{"10.1.0.0/16"}},
+	}, "10.0.0.5:4242")
+	require.NoError(t, err)
+
+	assert.NoError(t, service.CheckAgent(scandomain.ScanOptions{Target: "10.1.2.0/24 10.1.3.1-20", Agent: "dmz-1"}))
+
+	// Outside the reachable networks
+	err = service.CheckAgent(scandomain.ScanOptions{Target: "10.2.0.1", Agent: "dmz-1"})
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrInvalidInput, scanErr.Type)
+
+	// Host names need an agent reaching any target
+	assert.Error(t, service.CheckAgent(scandomain.ScanOptions{Target: "example.com", Agent: "dmz-1"}))
+
+	// Raw packet scans need raw socket privileges
+	assert.Error(t, service.CheckAgent(scandomain.ScanOptions{Target: "10.1.0.1", Agent: "dmz-1", ScanType: scandomain.ScanTypeSYN}))
+	assert.Error(t, service.CheckAgent(scandomain.ScanOptions{Target: "10.1.0.1", Agent: "dmz-1", OSDetection: true}))
+	assert.NoError(t, service.CheckAgent(scandomain.ScanOptions{Target: "10.1.0.1", Agent: "dmz-1", ScanType: scandomain.ScanTypeConnect}))
+}
+
+func TestExecuteScan_AutoRoutesToLeastBusyMatchingAgent(t *testing.T) {
+	service := newAgentService()
+	unprivileged, err := service.Connect(domain.Registration{Name: "a-unprivileged"}, "10.0.0.4:4242")
+	require.NoError(t, err)
+	busy, err := service.Connect(domain.Registration{
+		Name:         "b-busy",
+		Capabilities: domain.Capabilities{RawSocket: true},
+	}, "10.0.0.5:4242")
+	require.NoError(t, err)
+	idle, err := service.Connect(domain.Registration{
+		Name:         "c-idle",
+		Capabilities: domain.Capabilities{RawSocket: true},
+	}, "10.0.0.6:4242")
+	require.NoError(t, err)
+	require.NoError(t, busy.Handle(&domain.AgentMessage{Heartbeat: &domain.Heartbeat{Load: 3.5}}))
+	require.NoError(t, idle.Handle(&domain.AgentMessage{Heartbeat: &domain.Heartbeat{Load: 0.2}}))
+
+	go func() {
+		job := receiveMessage(t, idle).Job
+		assert.NoError(t, idle.Handle(&domain.AgentMessage{Done: &domain.JobDone{JobID: job.ID, Result: &scandomain.ScanResult{}}}))
+	}()
+
+	_, err = service.ExecuteScan(context.Background(), scandomain.ScanOptions{
+		Target:   "10.1.0.1",
+		Agent:    domain.AutoAgent,
+		ScanType: scandomain.ScanTypeSYN,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, unprivileged.Outgoing())
+	assert.Empty(t, busy.Outgoing())
+}
+
+func TestExecuteScan_AutoWithoutMatchingAgent(t *testing.T) {
+	service := newAgentService()
+	_, err := service.Connect(domain.Registration{
+		Name:         "dmz-1",
+		Capabilities: domain.Capabilities{Networks: []string{"10.1.0.0/16"}},
+	}, "10.0.0.5:4242")
+	require.NoError(t, err)
+
+	_, err = service.ExecuteScan(context.Background(), scandomain.ScanOptions{Target: "192.168.1.1", Agent: domain.AutoAgent})
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrUnavailable, scanErr.Type)
+}
+
+func TestConnect_InvalidNetwork(t *testing.T) {
+	service := newAgentService()
+
+	_, err := service.Connect(domain.Registration{
+		Name:         "dmz-1",
+		Capabilities: domain.Capabilities{Networks: []string{"10.1.0.0/33"}},
+	}, "10.0.0.5:4242")
+	assert.Error(t, err)
+}
+
+func TestStaleAgentsMarkedOffline(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	service := domain.NewAgentService(&logger.Logger{Logger: zapLogger}, 30*time.Millisecond)
+	service.Start()
+	defer service.Stop()
+
+	session, err := service.Connect(domain.Registration{Name: "dmz-1"}, "10.0.0.5:4242")
+	require.NoError(t, err)
+
+	select {
+	case <-session.Done():
+	case <-time.After(time.Second):
+		t.Fatal("stale agent was not marked offline")
+	}
+	assert.Equal(t, domain.AgentStatusOffline, session.Agent().Status)
+}
+
+func TestCheckHealth_CountsAgents(t *testing.T) {
+	service := newAgentService()
+
+	check := service.CheckHealth(context.Background())
+	assert.Equal(t, scandomain.CheckStatusUp, check.Status)
+	assert.False(t, check.Critical)
+	assert.Equal(t, 0, check.Details["online"])
+
+	session, err := service.Connect(domain.Registration{Name: "dmz-1"}, "10.0.0.5:4242")
+	require.NoError(t, err)
+	check = service.CheckHealth(context.Background())
+	assert.Equal(t, scandomain.CheckStatusUp, check.Status)
+	assert.Equal(t, 1, check.Details["online"])
+
+	// Known agents that are all offline degrade the service
+	session.Close()
+	check = service.CheckHealth(context.Background())
+	assert.Equal(t, scandomain.CheckStatusDegraded, check.Status)
+	assert.Equal(t, 1, check.Details["offline"])
+}
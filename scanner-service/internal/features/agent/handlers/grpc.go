@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/agent/domain"
+	_ "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/grpcjson" // Agent messages are JSON encoded
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AgentGRPCHandler serves the agent protocol over gRPC
+type AgentGRPCHandler struct {
+	agentService *domain.AgentService
+	token        func() string
+	logger       *logger.Logger
+}
+
+// NewAgentGRPCHandler creates a new AgentGRPCHandler accepting agents presenting the token
+func NewAgentGRPCHandler(agentService *domain.AgentService, token string, logger *logger.Logger) *AgentGRPCHandler {
+	return &AgentGRPCHandler{
+		agentService: agentService,
+		token:        func() string { return token },
+		logger:       logger,
+	}
+}
+
+// SetTokenSource sets the function returning the current agent token, so that a rotated
+// token is accepted without restarting. Agents must reconnect with the new token.
+func (h *AgentGRPCHandler) SetTokenSource(token func() string) {
+	h.token = token
+}
+
+// agentServer is the server interface of the agent service
+type agentServer interface {
+	Connect(stream grpc.ServerStream) error
+}
+
+// agentServiceDesc describes the agent service. It is written by hand since
+// the messages are plain structs encoded with the JSON codec.
+var agentServiceDesc = grpc.ServiceDesc{
+	ServiceName: domain.ServiceName,
+	HandlerType: (*agentServer)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName: domain.ConnectStream,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return srv.(agentServer).Connect(stream)
+			},
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
+// Register registers the agent service on the gRPC server
+func (h *AgentGRPCHandler) Register(server *grpc.Server) {
+	server.RegisterService(&agentServiceDesc, h)
+}
+
+// Connect handles the connection of an agent: it registers the agent, sends it
+// jobs and passes the streamed results to the agent service until the agent disconnects
+func (h *AgentGRPCHandler) Connect(stream grpc.ServerStream) error {
+	ctx := stream.Context()
+
+	if !h.authenticate(ctx) {
+		return status.Error(codes.Unauthenticated, "invalid agent token")
+	}
+
+	var first domain.AgentMessage
+	if err := stream.RecvMsg(&first); err != nil {
+		return err
+	}
+	if first.Register == nil {
+		return status.Error(codes.InvalidArgument, "first message must be a registration")
+	}
+
+	address := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		address = p.Addr.String()
+	}
+
+	session, err := h.agentService.Connect(*first.Register, address)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer session.Close()
+
+	agent := session.Agent()
+	if err := stream.SendMsg(&domain.ServerMessage{Registered: &domain.Registered{AgentID: agent.ID}}); err != nil {
+		return err
+	}
+
+	// Receive results until the agent disconnects
+	received := make(chan error, 1)
+	go func() {
+		for {
+			var message domain.AgentMessage
+			if err := stream.RecvMsg(&message); err != nil {
+				received <- err
+				return
+			}
+			if err := session.Handle(&message); err != nil {
+				h.logger.Warn("Invalid agent message", zap.String("agent", agent.Name), zap.Error(err))
+			}
+		}
+	}()
+
+	for {
+		select {
+		case message := <-session.Outgoing():
+			if err := stream.SendMsg(message); err != nil {
+				return err
+			}
+		case err := <-received:
+			h.logger.Info("Agent stream closed", zap.String("agent", agent.Name), zap.Error(err))
+			return nil
+		case <-session.Done():
+			return status.Error(codes.Aborted, "agent session closed")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// authenticate checks the agent token in the stream metadata
+func (h *AgentGRPCHandler) authenticate(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(domain.TokenMetadataKey)
+	token := h.token()
+	return len(values) == 1 && token != "" && subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) == 1
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/agent/domain"
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// AgentHandler handles HTTP requests for scanning agents
+type AgentHandler struct {
+	agentService *domain.AgentService
+	logger       *logger.Logger
+}
+
+// NewAgentHandler creates a new AgentHandler
+func NewAgentHandler(agentService *domain.AgentService, logger *logger.Logger) *AgentHandler {
+	return &AgentHandler{
+		agentService: agentService,
+		logger:       logger,
+	}
+}
+
+// ListAgents handles the request to list the scanning agents
+func (h *AgentHandler) ListAgents(c *gin.Context) {
+	agents, err := h.agentService.ListAgents(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"agents": agents,
+		"count":  len(agents),
+	})
+}
+
+// RegisterRoutes registers the agent handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *AgentHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1", middleware...)
+
+	api.GET("/agents", authhandlers.RequireRole(authdomain.RoleViewer), h.ListAgents)
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// ruleProtocols lists the protocols a rule can be restricted to
+var ruleProtocols = []string{"tcp", "udp", "sctp"}
+
+// Rule represents an alert rule: ports outside its allowed ports that open on its
+// targets are reported over its notification channels, e.g. "notify the security
+// channel when any port other than 80 and 443 opens on 203.0.113.0/24". Rules are
+// checked against the results of all completed scans.
+type Rule struct {
+	ID           string     `json:"id"`                     // Unique identifier
+	Name         string     `json:"name"`                   // Display name
+	Target       string     `json:"target"`                 // Addresses, networks, ranges and host names watched by the rule
+	AllowedPorts []int      `json:"allowed_ports"`          // Ports that may be open without an alert, e.g. 80 and 443
+	Protocol     string     `json:"protocol,omitempty"`     // Only watch open ports of this protocol, empty for all
+	Channels     []string   `json:"channels"`               // Notification channels alerts are sent over, empty for the default channels
+	Disabled     bool       `json:"disabled"`               // Whether scans are not checked against the rule
+	OpenPorts    []OpenPort `json:"open_ports"`             // Ports currently open against the rule, by the latest scan of each host
+	TriggeredAt  *time.Time `json:"triggered_at,omitempty"` // When the rule last alerted
+	CreatedAt    time.Time  `json:"created_at"`             // When the rule was created
+	UpdatedAt    time.Time  `json:"updated_at"`             // When the rule was last changed
+	UpdatedBy    string     `json:"updated_by"`             // Admin who last changed the rule
+}
+
+// OpenPort represents an open port of a host that its rule does not allow
+type OpenPort struct {
+	Host       string    `json:"host"`        // IP address of the host
+	Port       int       `json:"port"`        // Port number
+	Protocol   string    `json:"protocol"`    // Protocol (tcp/udp)
+	Service    string    `json:"service"`     // Service name detected by nmap
+	ScanID     string    `json:"scan_id"`     // Scan that last found the port open
+	DetectedAt time.Time `json:"detected_at"` // When that scan completed
+}
+
+// Copy returns a copy of the rule that does not share its ports and channels
+func (r *Rule) Copy() *Rule {
+	ruleCopy := *r
+	ruleCopy.AllowedPorts = slices.Clone(r.AllowedPorts)
+	ruleCopy.Channels = slices.Clone(r.Channels)
+	ruleCopy.OpenPorts = slices.Clone(r.OpenPorts)
+	return &ruleCopy
+}
+
+// Validate checks the rule and normalizes its name, target, ports, protocol and channels
+func (r *Rule) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.NewInvalidField("name", "required", "name is required")
+	}
+
+	target, err := scandomain.NormalizeTarget(r.Target)
+	if err != nil {
+		return errors.WithField(err, "target", "format")
+	}
+	r.Target = target
+
+	for _, port := range r.AllowedPorts {
+		if port < 1 || port > 65535 {
+			return errors.NewInvalidField("allowed_ports", "port", fmt.Sprintf("invalid port: %d", port))
+		}
+	}
+	r.AllowedPorts = slices.Compact(slices.Sorted(slices.Values(r.AllowedPorts)))
+	if r.AllowedPorts == nil {
+		r.AllowedPorts = []int{}
+	}
+
+	r.Protocol = strings.ToLower(r.Protocol)
+	if r.Protocol != "" && !slices.Contains(ruleProtocols, r.Protocol) {
+		return errors.NewInvalidField("protocol", "oneof", "invalid protocol: "+r.Protocol)
+	}
+
+	channels := make([]string, 0, len(r.Channels))
+	for _, channel := range r.Channels {
+		channel = strings.TrimSpace(channel)
+		if channel == "" {
+			return errors.NewInvalidField("channels", "required", "channel names must not be empty")
+		}
+		if !slices.Contains(channels, channel) {
+			channels = append(channels, channel)
+		}
+	}
+	r.Channels = channels
+
+	return nil
+}
+
+// baseline returns the baseline checking hosts against the rule: the allowed ports
+// are the ports the baseline expects open
+func (r *Rule) baseline() scandomain.Baseline {
+	return scandomain.Baseline{
+		ID:        r.ID,
+		Name:      r.Name,
+		Target:    r.Target,
+		OpenPorts: r.AllowedPorts,
+		Protocol:  r.Protocol,
+	}
+}
+
+// key identifies the port of a host regardless of the scan that found it open
+func (p OpenPort) key() string {
+	return fmt.Sprintf("%s:%d/%s", p.Host, p.Port, p.Protocol)
+}
+
+// String describes the open port for alert messages
+func (p OpenPort) String() string {
+	return strings.TrimSpace("new open port " + p.key() + " " + p.Service)
+}
+
+// sortOpenPorts sorts open ports by host address, port and protocol
+func sortOpenPorts(ports []OpenPort) {
+	slices.SortStableFunc(ports, func(a, b OpenPort) int {
+		if a.Host != b.Host {
+			addrA, errA := netip.ParseAddr(a.Host)
+			addrB, errB := netip.ParseAddr(b.Host)
+			if errA == nil && errB == nil {
+				return addrA.Compare(addrB)
+			}
+			return strings.Compare(a.Host, b.Host)
+		}
+		return cmp.Or(cmp.Compare(a.Port, b.Port), strings.Compare(a.Protocol, b.Protocol))
+	})
+}
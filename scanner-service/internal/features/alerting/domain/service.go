@@ -0,0 +1,322 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	notificationdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxAlertPorts is the number of open ports listed in an alert message
+const maxAlertPorts = 50
+
+// RuleRepository defines the interface for alert rule storage
+type RuleRepository interface {
+	SaveRule(rule *Rule) error
+	GetRule(id string) (*Rule, error)
+	ListRules() ([]*Rule, error)
+	DeleteRule(id string) error
+}
+
+// RuleService manages alert rules and checks completed scans against them
+type RuleService struct {
+	repository    RuleRepository
+	notifier      notificationdomain.Notifier // Delivers alerts, nil to only log them
+	notifyTimeout time.Duration
+	logger        *logger.Logger
+	mu            sync.Mutex // Serializes rule changes and checks, which rewrite the open ports
+}
+
+// NewRuleService creates a new RuleService
+func NewRuleService(repository RuleRepository, logger *logger.Logger) *RuleService {
+	return &RuleService{
+		repository:    repository,
+		notifyTimeout: 10 * time.Second,
+		logger:        logger,
+	}
+}
+
+// SetNotifier sets the notifier alerts are delivered through, each within the timeout
+func (s *RuleService) SetNotifier(notifier notificationdomain.Notifier, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	s.notifier = notifier
+	s.notifyTimeout = timeout
+}
+
+// CreateRule stores a new rule. The caller must be an admin.
+func (s *RuleService) CreateRule(ctx context.Context, rule Rule) (*Rule, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule.ID = uuid.New().String()
+	rule.OpenPorts = []OpenPort{}
+	rule.TriggeredAt = nil
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+	rule.UpdatedBy = principal.UserID
+
+	if err := s.repository.SaveRule(&rule); err != nil {
+		return nil, errors.NewInternal("failed to save alert rule", err)
+	}
+
+	s.logger.Info("Alert rule created",
+		zap.String("rule_id", rule.ID),
+		zap.String("target", rule.Target),
+		zap.String("created_by", principal.UserID),
+	)
+
+	return &rule, nil
+}
+
+// UpdateRule replaces the name, target, ports, protocol and channels of a rule and
+// whether it is disabled. Its open ports are cleared, so the next scan of its hosts
+// alerts about every port open against it. The caller must be an admin.
+func (s *RuleService) UpdateRule(ctx context.Context, id string, rule Rule) (*Rule, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.repository.GetRule(id)
+	if err != nil {
+		return nil, errors.NewNotFound("alert rule not found", err)
+	}
+
+	rule.ID = existing.ID
+	rule.OpenPorts = []OpenPort{}
+	rule.TriggeredAt = existing.TriggeredAt
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+	rule.UpdatedBy = principal.UserID
+
+	if err := s.repository.SaveRule(&rule); err != nil {
+		return nil, errors.NewInternal("failed to save alert rule", err)
+	}
+
+	s.logger.Info("Alert rule updated",
+		zap.String("rule_id", id),
+		zap.String("updated_by", principal.UserID),
+	)
+
+	return &rule, nil
+}
+
+// GetRule gets a rule. The caller must have the viewer role.
+func (s *RuleService) GetRule(ctx context.Context, id string) (*Rule, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.repository.GetRule(id)
+	if err != nil {
+		return nil, errors.NewNotFound("alert rule not found", err)
+	}
+
+	return rule, nil
+}
+
+// ListRules lists all rules. The caller must have the viewer role.
+func (s *RuleService) ListRules(ctx context.Context) ([]*Rule, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repository.ListRules()
+	if err != nil {
+		return nil, errors.NewInternal("failed to list alert rules", err)
+	}
+
+	return rules, nil
+}
+
+// DeleteRule deletes a rule. The caller must be an admin.
+func (s *RuleService) DeleteRule(ctx context.Context, id string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.repository.GetRule(id); err != nil {
+		return errors.NewNotFound("alert rule not found", err)
+	}
+
+	if err := s.repository.DeleteRule(id); err != nil {
+		return errors.NewInternal("failed to delete alert rule", err)
+	}
+
+	s.logger.Info("Alert rule deleted",
+		zap.String("rule_id", id),
+		zap.String("deleted_by", principal.UserID),
+	)
+
+	return nil
+}
+
+// ScanCompleted checks the result of a completed scan against the enabled rules. The
+// ports open against a rule on the hosts the result found up replace their previous
+// open ports, and the ports that were not open before are alerted about.
+func (s *RuleService) ScanCompleted(ctx context.Context, scan *scandomain.Scan, result *scandomain.ScanResult) {
+	log := s.logger.WithContext(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules, err := s.repository.ListRules()
+	if err != nil {
+		log.Error("Failed to list alert rules",
+			zap.String("scan_id", scan.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	checkedAt := time.Now()
+	for _, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+
+		baseline := rule.baseline()
+		checked := make(map[string]bool)
+		var openPorts []OpenPort
+		for _, host := range result.Hosts {
+			if host.Status != "up" || !baseline.Covers(host) {
+				continue
+			}
+			checked[host.IP] = true
+			for _, violation := range baseline.Check(scan.ID, host, checkedAt) {
+				openPorts = append(openPorts, OpenPort{
+					Host:       violation.Host,
+					Port:       violation.Port,
+					Protocol:   violation.Protocol,
+					Service:    violation.Service,
+					ScanID:     violation.ScanID,
+					DetectedAt: violation.DetectedAt,
+				})
+			}
+		}
+		if len(checked) == 0 {
+			continue
+		}
+
+		// Only ports that were not open against the rule before are alerted about
+		previous := make(map[string]bool)
+		for _, port := range rule.OpenPorts {
+			previous[port.key()] = true
+		}
+		var opened []OpenPort
+		for _, port := range openPorts {
+			if !previous[port.key()] {
+				opened = append(opened, port)
+			}
+		}
+		sortOpenPorts(opened)
+
+		// Keep the open ports of hosts the scan did not find
+		for _, port := range rule.OpenPorts {
+			if !checked[port.Host] {
+				openPorts = append(openPorts, port)
+			}
+		}
+		sortOpenPorts(openPorts)
+
+		rule.OpenPorts = openPorts
+		if rule.OpenPorts == nil {
+			rule.OpenPorts = []OpenPort{}
+		}
+		if len(opened) > 0 {
+			rule.TriggeredAt = &checkedAt
+		}
+		if err := s.repository.SaveRule(rule); err != nil {
+			log.Error("Failed to save alert rule",
+				zap.String("scan_id", scan.ID),
+				zap.String("rule_id", rule.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if len(opened) > 0 {
+			s.alert(ctx, rule, scan, opened)
+		}
+	}
+}
+
+// alert sends an alert about the ports a scan found open against a rule in the background
+func (s *RuleService) alert(ctx context.Context, rule *Rule, scan *scandomain.Scan, opened []OpenPort) {
+	log := s.logger.WithContext(ctx).With(
+		zap.String("rule_id", rule.ID),
+		zap.String("scan_id", scan.ID),
+	)
+
+	lines := make([]string, 0, min(len(opened), maxAlertPorts)+1)
+	for _, port := range opened[:min(len(opened), maxAlertPorts)] {
+		lines = append(lines, port.String())
+	}
+	if len(opened) > maxAlertPorts {
+		lines = append(lines, fmt.Sprintf("and %d more", len(opened)-maxAlertPorts))
+	}
+
+	if s.notifier == nil {
+		log.Warn("Alert rule triggered", zap.Strings("open_ports", lines))
+		return
+	}
+
+	notification := notificationdomain.Notification{
+		ID:         fmt.Sprintf("alert-rule-%s-%s", rule.ID, scan.ID),
+		Type:       notificationdomain.EventTypeAlertRuleTriggered,
+		OccurredAt: time.Now().UTC(),
+		TenantID:   scan.TenantID,
+		ScanID:     scan.ID,
+		Subject:    fmt.Sprintf("Alert rule %s: %d new open ports", rule.Name, len(opened)),
+		Message:    strings.Join(lines, "\n"),
+		Attributes: map[string]string{
+			"rule_id":   rule.ID,
+			"rule_name": rule.Name,
+			"target":    rule.Target,
+			"user_id":   scan.UserID,
+			"result_id": scan.ResultID,
+		},
+		Channels: rule.Channels,
+	}
+
+	// The scan worker does not wait for the alert to be delivered
+	notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.notifyTimeout)
+	go func() {
+		defer cancel()
+		if err := s.notifier.Notify(notifyCtx, notification); err != nil {
+			log.Error("Failed to send alert",
+				zap.String("notification_id", notification.ID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
@@ -0,0 +1,171 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/alerting/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/alerting/repository"
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	notificationdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// channelNotifier passes notifications to a channel
+type channelNotifier chan notificationdomain.Notification
+
+func (n channelNotifier) Notify(ctx context.Context, notification notificationdomain.Notification) error {
+	n <- notification
+	return nil
+}
+
+func principalContext(userID string, role authdomain.Role) context.Context {
+	return authdomain.WithPrincipal(context.Background(), &authdomain.Principal{
+		UserID: userID,
+		Roles:  []authdomain.Role{role},
+	})
+}
+
+// completedScan returns a completed scan of alice and its result with the given hosts
+func completedScan(id string, hosts ...scandomain.Host) (*scandomain.Scan, *scandomain.ScanResult) {
+	scan := &scandomain.Scan{ID: id, UserID: "alice", TenantID: "acme", Status: scandomain.ScanStatusCompleted, ResultID: "result-" + id}
+	return scan, &scandomain.ScanResult{ID: scan.ResultID, ScanID: id, UserID: "alice", Hosts: hosts}
+}
+
+func TestCreateRule(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	service := domain.NewRuleService(repository.NewMemoryRuleRepository(log), log)
+	admin := principalContext("root", authdomain.RoleAdmin)
+	var serviceErr *errors.Error
+
+	// Only admins manage rules
+	_, err := service.CreateRule(principalContext("alice", authdomain.RoleOperator), domain.Rule{Name: "dmz", Target: "203.0.113.0/24"})
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, errors.ErrForbidden, serviceErr.Type)
+
+	for _, rule := range []domain.Rule{
+		{Name: " ", Target: "203.0.113.0/24"},
+		{Name: "dmz", Target: "not a target!"},
+		{Name: "dmz", Target: "203.0.113.0/24", AllowedPorts: []int{65536}},
+		{Name: "dmz", Target: "203.0.113.0/24", Protocol: "icmp"},
+		{Name: "dmz", Target: "203.0.113.0/24", Channels: []string{" "}},
+	} {
+		_, err := service.CreateRule(admin, rule)
+		require.ErrorAs(t, err, &serviceErr, rule)
+		assert.Equal(t, errors.ErrInvalidInput, serviceErr.Type, rule)
+	}
+
+	rule, err := service.CreateRule(admin, domain.Rule{
+		Name:         " dmz ",
+		Target:       "203.0.113.9/24",
+		AllowedPorts: []int{443, 80, 443},
+		Protocol:     "TCP",
+		Channels:     []string{"security ", "security"},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, rule.ID)
+	assert.Equal(t, "dmz", rule.Name)
+	assert.Equal(t, "203.0.113.0/24", rule.Target)
+	assert.Equal(t, []int{80, 443}, rule.AllowedPorts)
+	assert.Equal(t, "tcp", rule.Protocol)
+	assert.Equal(t, []string{"security"}, rule.Channels)
+	assert.Equal(t, "root", rule.UpdatedBy)
+	assert.Empty(t, rule.OpenPorts)
+
+	// Viewers can read rules
+	rules, err := service.ListRules(principalContext("alice", authdomain.RoleViewer))
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, rule.ID, rules[0].ID)
+
+	require.NoError(t, service.DeleteRule(admin, rule.ID))
+	_, err = service.GetRule(admin, rule.ID)
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, errors.ErrNotFound, serviceErr.Type)
+}
+
+func TestScanCompletedAlertsAboutNewOpenPorts(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	notifier := make(channelNotifier, 2)
+	service := domain.NewRuleService(repository.NewMemoryRuleRepository(log), log)
+	service.SetNotifier(notifier, time.Second)
+	admin := principalContext("root", authdomain.RoleAdmin)
+
+	rule, err := service.CreateRule(admin, domain.Rule{
+		Name:         "dmz",
+		Target:       "203.0.113.0/24",
+		AllowedPorts: []int{80, 443},
+		Channels:     []string{"security"},
+	})
+	require.NoError(t, err)
+	_, err = service.CreateRule(admin, domain.Rule{Name: "office", Target: "192.168.1.0/24", Disabled: true})
+	require.NoError(t, err)
+
+	web := scandomain.Host{IP: "203.0.113.10", Status: "up", Ports: []scandomain.Port{
+		{Port: 443, Protocol: "tcp", State: "open", Service: "https"},
+		{Port: 22, Protocol: "tcp", State: "open", Service: "ssh"},
+	}}
+	mail := scandomain.Host{IP: "203.0.113.2", Status: "up", Ports: []scandomain.Port{
+		{Port: 25, Protocol: "tcp", State: "open", Service: "smtp"},
+		{Port: 23, Protocol: "tcp", State: "closed"},
+	}}
+	office := scandomain.Host{IP: "192.168.1.5", Status: "up", Ports: []scandomain.Port{
+		{Port: 3389, Protocol: "tcp", State: "open", Service: "ms-wbt-server"},
+	}}
+
+	// The ports outside the allowed ports are alerted about over the channels of the rule
+	scan, result := completedScan("scan-1", web, mail, office)
+	service.ScanCompleted(context.Background(), scan, result)
+
+	notification := <-notifier
+	assert.Equal(t, "alert-rule-"+rule.ID+"-scan-1", notification.ID)
+	assert.Equal(t, notificationdomain.EventTypeAlertRuleTriggered, notification.Type)
+	assert.Equal(t, []string{"security"}, notification.Channels)
+	assert.Equal(t, "acme", notification.TenantID)
+	assert.Equal(t, "scan-1", notification.ScanID)
+	assert.Equal(t, "Alert rule dmz: 2 new open ports", notification.Subject)
+	assert.Equal(t, "new open port 203.0.113.2:25/tcp smtp\nnew open port 203.0.113.10:22/tcp ssh", notification.Message)
+	assert.Equal(t, "alice", notification.Attributes["user_id"])
+	assert.Equal(t, "result-scan-1", notification.Attributes["result_id"])
+
+	current, err := service.GetRule(admin, rule.ID)
+	require.NoError(t, err)
+	require.Len(t, current.OpenPorts, 2)
+	assert.Equal(t, "203.0.113.2", current.OpenPorts[0].Host)
+	assert.Equal(t, "scan-1", current.OpenPorts[1].ScanID)
+	require.NotNil(t, current.TriggeredAt)
+	triggeredAt := *current.TriggeredAt
+
+	// Ports that stay open are not alerted about again, and the open ports of hosts
+	// the scan did not find are kept
+	scan, result = completedScan("scan-2", web)
+	service.ScanCompleted(context.Background(), scan, result)
+	select {
+	case notification := <-notifier:
+		assert.Fail(t, "unexpected alert", notification.Subject)
+	case <-time.After(50 * time.Millisecond):
+	}
+	current, err = service.GetRule(admin, rule.ID)
+	require.NoError(t, err)
+	require.Len(t, current.OpenPorts, 2)
+	assert.Equal(t, "scan-2", current.OpenPorts[1].ScanID)
+	assert.Equal(t, triggeredAt, *current.TriggeredAt)
+
+	// A port that closed and opens again is alerted about
+	web.Ports = web.Ports[:1]
+	scan, result = completedScan("scan-3", web)
+	service.ScanCompleted(context.Background(), scan, result)
+	web.Ports = append(web.Ports, scandomain.Port{Port: 22, Protocol: "tcp", State: "open", Service: "ssh"})
+	scan, result = completedScan("scan-4", web)
+	service.ScanCompleted(context.Background(), scan, result)
+
+	notification = <-notifier
+	assert.Equal(t, "alert-rule-"+rule.ID+"-scan-4", notification.ID)
+	assert.Equal(t, "new open port 203.0.113.10:22/tcp ssh", notification.Message)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/alerting/domain"
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RuleHandler handles HTTP requests for alert rules
+type RuleHandler struct {
+	ruleService *domain.RuleService
+	logger      *logger.Logger
+}
+
+// NewRuleHandler creates a new RuleHandler
+func NewRuleHandler(ruleService *domain.RuleService, logger *logger.Logger) *RuleHandler {
+	return &RuleHandler{
+		ruleService: ruleService,
+		logger:      logger,
+	}
+}
+
+// RuleRequest represents the request body for creating or replacing an alert rule
+type RuleRequest struct {
+	Name         string   `json:"name" binding:"required"`
+	Target       string   `json:"target" binding:"required"`
+	AllowedPorts []int    `json:"allowed_ports"`
+	Protocol     string   `json:"protocol"`
+	Channels     []string `json:"channels"`
+	Disabled     bool     `json:"disabled"`
+}
+
+// rule returns the rule described by the request
+func (r RuleRequest) rule() domain.Rule {
+	return domain.Rule{
+		Name:         r.Name,
+		Target:       r.Target,
+		AllowedPorts: r.AllowedPorts,
+		Protocol:     r.Protocol,
+		Channels:     r.Channels,
+		Disabled:     r.Disabled,
+	}
+}
+
+// ListRules handles the request to list all alert rules
+func (h *RuleHandler) ListRules(c *gin.Context) {
+	rules, err := h.ruleService.ListRules(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"count": len(rules),
+	})
+}
+
+// GetRule handles the request to get an alert rule
+func (h *RuleHandler) GetRule(c *gin.Context) {
+	rule, err := h.ruleService.GetRule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// CreateRule handles the request to create an alert rule
+func (h *RuleHandler) CreateRule(c *gin.Context) {
+	var req RuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	rule, err := h.ruleService.CreateRule(c.Request.Context(), req.rule())
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to create alert rule",
+			zap.Error(err),
+			zap.String("target", req.Target),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule handles the request to replace an alert rule
+func (h *RuleHandler) UpdateRule(c *gin.Context) {
+	var req RuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	rule, err := h.ruleService.UpdateRule(c.Request.Context(), c.Param("id"), req.rule())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule handles the request to delete an alert rule
+func (h *RuleHandler) DeleteRule(c *gin.Context) {
+	if err := h.ruleService.DeleteRule(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Alert rule deleted",
+		"rule_id": c.Param("id"),
+	})
+}
+
+// RegisterRoutes registers the alert rule handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *RuleHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1/alert-rules", middleware...)
+	viewer := authhandlers.RequireRole(authdomain.RoleViewer)
+	admin := authhandlers.RequireRole(authdomain.RoleAdmin)
+
+	api.GET("", viewer, h.ListRules)
+	api.POST("", admin, h.CreateRule)
+	api.GET("/:id", viewer, h.GetRule)
+	api.PUT("/:id", admin, h.UpdateRule)
+	api.DELETE("/:id", admin, h.DeleteRule)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/alerting/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MemoryRuleRepository is an in-memory implementation of the RuleRepository interface
+type MemoryRuleRepository struct {
+	logger *logger.Logger
+	rules  map[string]*domain.Rule
+	mu     sync.RWMutex
+}
+
+// NewMemoryRuleRepository creates a new MemoryRuleRepository
+func NewMemoryRuleRepository(logger *logger.Logger) *MemoryRuleRepository {
+	return &MemoryRuleRepository{
+		logger: logger,
+		rules:  make(map[string]*domain.Rule),
+	}
+}
+
+// SaveRule creates or replaces a rule in the repository
+func (r *MemoryRuleRepository) SaveRule(rule *domain.Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rule.ID] = rule.Copy()
+
+	r.logger.Debug("Saved alert rule", zap.String("rule_id", rule.ID))
+
+	return nil
+}
+
+// GetRule gets a rule by ID from the repository
+func (r *MemoryRuleRepository) GetRule(id string) (*domain.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("alert rule with ID %s not found", id), nil)
+	}
+
+	return rule.Copy(), nil
+}
+
+// ListRules lists all rules from the repository by name
+func (r *MemoryRuleRepository) ListRules() ([]*domain.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]*domain.Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule.Copy())
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Name != rules[j].Name {
+			return rules[i].Name < rules[j].Name
+		}
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules, nil
+}
+
+// DeleteRule deletes a rule from the repository
+func (r *MemoryRuleRepository) DeleteRule(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[id]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("alert rule with ID %s not found", id), nil)
+	}
+
+	delete(r.rules, id)
+
+	r.logger.Debug("Deleted alert rule", zap.String("rule_id", id))
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package adapters
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// JWTValidatorConfig contains JWT validator configuration
+type JWTValidatorConfig struct {
+	Issuer          string
+	Audience        string
+	JWKSURL         string
+	Secret          string
+	RolesClaim      string
+	RefreshInterval time.Duration
+}
+
+// jwk represents a single JSON Web Key
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwkSet represents a JSON Web Key Set document
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// JWTValidator validates JWT bearer tokens signed with a shared secret or JWKS keys
+type JWTValidator struct {
+	config     JWTValidatorConfig
+	httpClient *http.Client
+	logger     *logger.Logger
+	keys       map[string]interface{}
+	fetchedAt  time.Time
+	secret     func() string // Current shared secret, set when the secret can be rotated
+	mu         sync.RWMutex
+}
+
+// NewJWTValidator creates a new JWTValidator
+func NewJWTValidator(config JWTValidatorConfig, logger *logger.Logger) (*JWTValidator, error) {
+	if config.Secret == "" && config.JWKSURL == "" {
+		return nil, fmt.Errorf("either a JWT secret or a JWKS URL must be configured")
+	}
+
+	if config.RefreshInterval == 0 {
+		config.RefreshInterval = time.Hour
+	}
+
+	return &JWTValidator{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+		keys:       make(map[string]interface{}),
+	}, nil
+}
+
+// SetSecretSource sets the function returning the current shared secret, so that a
+// rotated secret is used without restarting. It replaces the configured secret.
+func (v *JWTValidator) SetSecretSource(secret func() string) {
+	v.secret = secret
+}
+
+// ValidateToken parses and verifies a token and returns its principal
+func (v *JWTValidator) ValidateToken(ctx context.Context, token string) (*domain.Principal, error) {
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods(v.validMethods()),
+		jwt.WithExpirationRequired(),
+	}
+	if v.config.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(v.config.Issuer))
+	}
+	if v.config.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(v.config.Audience))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return v.keyFor(ctx, t)
+	}, parserOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil {
+		return nil, err
+	}
+
+	principal := &domain.Principal{
+		UserID: subject,
+		Method: domain.AuthMethodJWT,
+		Claims: claims,
+	}
+
+	if v.config.RolesClaim != "" {
+		for _, name := range stringsClaim(claims, v.config.RolesClaim) {
+			if role, ok := domain.ParseRole(name); ok {
+				principal.Roles = append(principal.Roles, role)
+			}
+		}
+	}
+
+	return principal, nil
+}
+
+// validMethods returns the signing algorithms accepted by the validator
+func (v *JWTValidator) validMethods() []string {
+	var methods []string
+	if v.config.Secret != "" {
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if v.config.JWKSURL != "" {
+		methods = append(methods, "RS256", "RS384", "RS512", "ES256", "ES384", "ES512")
+	}
+	return methods
+}
+
+// keyFor resolves the verification key for a token
+func (v *JWTValidator) keyFor(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if v.secret != nil {
+			return []byte(v.secret()), nil
+		}
+		return []byte(v.config.Secret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	stale := time.Since(v.fetchedAt) > v.config.RefreshInterval
+	v.mu.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	// Refresh keys on unknown kid (key rotation) or when the cache expired
+	if err := v.refreshKeys(ctx); err != nil {
+		if ok {
+			v.logger.Warn("Failed to refresh JWKS, using cached key", zap.Error(err))
+			return key, nil
+		}
+		return nil, err
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+
+	return nil, errors.NewUnauthorized(fmt.Sprintf("unknown signing key %q", kid), nil)
+}
+
+// refreshKeys fetches the JWKS document and replaces the cached keys
+func (v *JWTValidator) refreshKeys(ctx context.Context) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// Avoid hammering the JWKS endpoint with unknown kids
+	if time.Since(v.fetchedAt) < 30*time.Second {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.config.JWKSURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected JWKS status code: %d", resp.StatusCode)
+	}
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			v.logger.Warn("Skipping invalid JWKS key",
+				zap.String("kid", k.Kid),
+				zap.Error(err),
+			)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	v.keys = keys
+	v.fetchedAt = time.Now()
+
+	v.logger.Debug("Refreshed JWKS", zap.Int("key_count", len(keys)))
+
+	return nil
+}
+
+// publicKey converts a JWK to an RSA or ECDSA public key
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+	}
+}
+
+// decodeBigInt decodes a base64url encoded big-endian integer
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64url value: %w", err)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,107 @@
+package adapters_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/adapters"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newTestLogger() *logger.Logger {
+	zapLogger, _ := zap.NewDevelopment()
+	return &logger.Logger{Logger: zapLogger}
+}
+
+func TestValidateTokenWithSecret(t *testing.T) {
+	validator, err := adapters.NewJWTValidator(adapters.JWTValidatorConfig{
+		Issuer: "https://issuer.example.com",
+		Secret: "test-secret",
+	}, newTestLogger())
+	assert.NoError(t, err)
+
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+		assert.NoError(t, err)
+		return token
+	}
+
+	// Valid token
+	principal, err := validator.ValidateToken(context.Background(), sign(jwt.MapClaims{
+		"sub": "alice",
+		"iss": "https://issuer.example.com",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", principal.UserID)
+
+	// Expired token
+	_, err = validator.ValidateToken(context.Background(), sign(jwt.MapClaims{
+		"sub": "alice",
+		"iss": "https://issuer.example.com",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	}))
+	assert.Error(t, err)
+
+	// Wrong issuer
+	_, err = validator.ValidateToken(context.Background(), sign(jwt.MapClaims{
+		"sub": "alice",
+		"iss": "https://evil.example.com",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}))
+	assert.Error(t, err)
+}
+
+func TestValidateTokenWithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "key-1",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	validator, err := adapters.NewJWTValidator(adapters.JWTValidatorConfig{
+		JWKSURL: server.URL,
+	}, newTestLogger())
+	assert.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "bob",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+
+	principal, err := validator.ValidateToken(context.Background(), signed)
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", principal.UserID)
+
+	// HMAC tokens must be rejected when only JWKS is configured
+	hmacToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "bob",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("guess"))
+	_, err = validator.ValidateToken(context.Background(), hmacToken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,298 @@
+package adapters
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// OIDCValidatorConfig contains OIDC validator configuration
+type OIDCValidatorConfig struct {
+	IssuerURL             string
+	Audience              string
+	ClientID              string
+	ClientSecret          string
+	GroupsClaim           string
+	GroupRoles            map[string]string
+	Introspection         bool
+	IntrospectionCacheTTL time.Duration
+	JWKSRefreshInterval   time.Duration
+}
+
+// oidcDiscovery represents the relevant parts of an OpenID provider configuration document
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	JWKSURI               string `json:"jwks_uri"`
+	IntrospectionEndpoint string `json:"introspection_endpoint"`
+}
+
+// introspectionEntry is a cached token introspection result
+type introspectionEntry struct {
+	principal *domain.Principal
+	expiresAt time.Time
+}
+
+// OIDCValidator validates access tokens issued by an OpenID Connect provider,
+// either locally against the provider's JWKS or via token introspection
+type OIDCValidator struct {
+	config                OIDCValidatorConfig
+	httpClient            *http.Client
+	logger                *logger.Logger
+	jwtValidator          *JWTValidator
+	introspectionEndpoint string
+	cache                 map[string]introspectionEntry
+	clientSecret          func() string // Current client secret, set when the secret can be rotated
+	mu                    sync.Mutex
+}
+
+// NewOIDCValidator creates a new OIDCValidator using the provider's discovery document
+func NewOIDCValidator(ctx context.Context, config OIDCValidatorConfig, logger *logger.Logger) (*OIDCValidator, error) {
+	if config.IssuerURL == "" {
+		return nil, fmt.Errorf("OIDC issuer URL is required")
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+
+	v := &OIDCValidator{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		logger:     logger,
+		cache:      make(map[string]introspectionEntry),
+	}
+
+	discovery, err := v.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Introspection {
+		if discovery.IntrospectionEndpoint == "" {
+			return nil, fmt.Errorf("OIDC provider does not advertise an introspection endpoint")
+		}
+		if config.ClientID == "" {
+			return nil, fmt.Errorf("OIDC client ID is required for token introspection")
+		}
+		v.introspectionEndpoint = discovery.IntrospectionEndpoint
+	} else {
+		v.jwtValidator, err = NewJWTValidator(JWTValidatorConfig{
+			Issuer:          discovery.Issuer,
+			Audience:        config.Audience,
+			JWKSURL:         discovery.JWKSURI,
+			RefreshInterval: config.JWKSRefreshInterval,
+		}, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("OIDC provider configured",
+		zap.String("issuer", discovery.Issuer),
+		zap.Bool("introspection", config.Introspection),
+	)
+
+	return v, nil
+}
+
+// SetClientSecretSource sets the function returning the current client secret used for
+// token introspection, so that a rotated secret is used without restarting
+func (v *OIDCValidator) SetClientSecretSource(clientSecret func() string) {
+	v.clientSecret = clientSecret
+}
+
+// ValidateToken validates an access token and maps its groups to roles
+func (v *OIDCValidator) ValidateToken(ctx context.Context, token string) (*domain.Principal, error) {
+	var principal *domain.Principal
+	var err error
+
+	if v.introspectionEndpoint != "" {
+		principal, err = v.introspect(ctx, token)
+	} else {
+		principal, err = v.jwtValidator.ValidateToken(ctx, token)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	principal.Groups = stringsClaim(principal.Claims, v.config.GroupsClaim)
+	principal.Roles = v.mapRoles(principal.Groups)
+
+	return principal, nil
+}
+
+// discover fetches the provider's OpenID configuration document
+func (v *OIDCValidator) discover(ctx context.Context) (*oidcDiscovery, error) {
+	wellKnown := strings.TrimSuffix(v.config.IssuerURL, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected OIDC discovery status code: %d", resp.StatusCode)
+	}
+
+	var discovery oidcDiscovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery document: %w", err)
+	}
+
+	if discovery.Issuer == "" {
+		discovery.Issuer = v.config.IssuerURL
+	}
+
+	return &discovery, nil
+}
+
+// introspect validates a token with the provider's introspection endpoint (RFC 7662),
+// caching active results for the configured TTL
+func (v *OIDCValidator) introspect(ctx context.Context, token string) (*domain.Principal, error) {
+	sum := sha256.Sum256([]byte(token))
+	cacheKey := hex.EncodeToString(sum[:])
+	now := time.Now()
+
+	v.mu.Lock()
+	if entry, ok := v.cache[cacheKey]; ok && now.Before(entry.expiresAt) {
+		v.mu.Unlock()
+		principal := *entry.principal
+		return &principal, nil
+	}
+	v.mu.Unlock()
+
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.introspectionEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	clientSecret := v.config.ClientSecret
+	if v.clientSecret != nil {
+		clientSecret = v.clientSecret()
+	}
+	req.SetBasicAuth(v.config.ClientID, clientSecret)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to introspect token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected introspection status code: %d", resp.StatusCode)
+	}
+
+	claims := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("failed to decode introspection response: %w", err)
+	}
+
+	if active, _ := claims["active"].(bool); !active {
+		return nil, errors.NewUnauthorized("token is not active", nil)
+	}
+
+	subject, _ := claims["sub"].(string)
+	principal := &domain.Principal{
+		UserID: subject,
+		Method: domain.AuthMethodJWT,
+		Claims: claims,
+	}
+
+	// Never cache past the token's own expiry
+	expiresAt := now.Add(v.config.IntrospectionCacheTTL)
+	if exp, ok := claims["exp"].(float64); ok {
+		if tokenExpiry := time.Unix(int64(exp), 0); tokenExpiry.Before(expiresAt) {
+			expiresAt = tokenExpiry
+		}
+	}
+
+	if v.config.IntrospectionCacheTTL > 0 {
+		v.mu.Lock()
+		for key, entry := range v.cache {
+			if now.After(entry.expiresAt) {
+				delete(v.cache, key)
+			}
+		}
+		v.cache[cacheKey] = introspectionEntry{principal: principal, expiresAt: expiresAt}
+		v.mu.Unlock()
+	}
+
+	result := *principal
+	return &result, nil
+}
+
+// mapRoles maps identity provider groups to roles using the configured mapping
+func (v *OIDCValidator) mapRoles(groups []string) []domain.Role {
+	seen := make(map[domain.Role]bool)
+	var roles []domain.Role
+	for _, group := range groups {
+		name, ok := v.config.GroupRoles[strings.ToLower(strings.TrimPrefix(group, "/"))]
+		if !ok {
+			continue
+		}
+
+		role, ok := domain.ParseRole(name)
+		if !ok {
+			v.logger.Warn("Ignoring unknown role in group mapping",
+				zap.String("group", group),
+				zap.String("role", name),
+			)
+			continue
+		}
+
+		if !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// stringsClaim reads a string list claim, following dot-separated paths
+// such as Keycloak's "realm_access.roles"
+func stringsClaim(claims map[string]interface{}, path string) []string {
+	var value interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+
+	switch typed := value.(type) {
+	case []interface{}:
+		values := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	case string:
+		return strings.Fields(typed)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package adapters_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/adapters"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCIntrospectionWithGroupMapping(t *testing.T) {
+	introspections := 0
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"jwks_uri":               server.URL + "/certs",
+			"introspection_endpoint": server.URL + "/introspect",
+		})
+	})
+	mux.HandleFunc("/introspect", func(w http.ResponseWriter, r *http.Request) {
+		introspections++
+
+		clientID, clientSecret, _ := r.BasicAuth()
+		assert.Equal(t, "scanner", clientID)
+		assert.Equal(t, "s3cret", clientSecret)
+
+		if r.FormValue("token") != "good-token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"active": false})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"active": true,
+			"sub":    "carol",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"realm_access": map[string]interface{}{
+				"roles": []string{"nmap-admins", "offline_access"},
+			},
+		})
+	})
+
+	validator, err := adapters.NewOIDCValidator(context.Background(), adapters.OIDCValidatorConfig{
+		IssuerURL:             server.URL,
+		ClientID:              "scanner",
+		ClientSecret:          "s3cret",
+		GroupsClaim:           "realm_access.roles",
+		GroupRoles:            map[string]string{"nmap-admins": "admin"},
+		Introspection:         true,
+		IntrospectionCacheTTL: time.Minute,
+	}, newTestLogger())
+	assert.NoError(t, err)
+
+	principal, err := validator.ValidateToken(context.Background(), "good-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "carol", principal.UserID)
+	assert.Equal(t, []domain.Role{domain.RoleAdmin}, principal.Roles)
+
+	// Second validation is served from the cache
+	_, err = validator.ValidateToken(context.Background(), "good-token")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, introspections)
+
+	// Inactive tokens are rejected
+	_, err = validator.ValidateToken(context.Background(), "bad-token")
+	assert.Error(t, err)
+}
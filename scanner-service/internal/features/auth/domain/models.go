@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// AuthMethod represents how a principal was authenticated
+type AuthMethod string
+
+// Auth method constants
+const (
+	AuthMethodJWT    AuthMethod = "JWT"     // Bearer JWT access token
+	AuthMethodAPIKey AuthMethod = "API_KEY" // X-API-Key header
+)
+
+// Role represents a permission level.
+// Roles are hierarchical: admin implies advanced, advanced implies operator,
+// operator implies viewer.
+type Role string
+
+// Role constants
+const (
+	RoleViewer   Role = "viewer"   // Read own scans and results
+	RoleOperator Role = "operator" // Start and cancel own scans
+	RoleAdvanced Role = "advanced" // Use evasion options in red-team assessments
+	RoleAdmin    Role = "admin"    // Access all users' scans and manage policies
+)
+
+// roleRanks orders roles by privilege
+var roleRanks = map[Role]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdvanced: 3,
+	RoleAdmin:    4,
+}
+
+// ParseRole converts a string to a known Role
+func ParseRole(s string) (Role, bool) {
+	role := Role(strings.ToLower(strings.TrimSpace(s)))
+	_, ok := roleRanks[role]
+	return role, ok
+}
+
+// Principal represents an authenticated caller
+type Principal struct {
+	UserID   string                 `json:"user_id"`   // Subject of the credential
+	TenantID string                 `json:"tenant_id"` // Tenant (organization) of the caller
+	Method   AuthMethod             `json:"method"`    // How the caller was authenticated
+	Groups   []string               `json:"groups"`    // Identity provider groups
+	Roles    []Role                 `json:"roles"`     // Granted roles
+	Claims   map[string]interface{} `json:"-"`         // Raw token claims
+}
+
+// HasRole reports whether the principal has the given role or a more privileged one
+func (p *Principal) HasRole(role Role) bool {
+	for _, r := range p.Roles {
+		if roleRanks[r] >= roleRanks[role] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the principal has the admin role
+func (p *Principal) IsAdmin() bool {
+	return p.HasRole(RoleAdmin)
+}
+
+// CanAccess reports whether the principal may access a resource owned by ownerID
+func (p *Principal) CanAccess(ownerID string) bool {
+	return p.UserID == ownerID || p.IsAdmin()
+}
+
+// APIKey represents an API key issued to a user for machine clients
+type APIKey struct {
+	ID         string     `json:"id"`           // Unique identifier
+	UserID     string     `json:"user_id"`      // Owner of the key
+	Name       string     `json:"name"`         // Human readable name (e.g. "ci-pipeline")
+	Prefix     string     `json:"prefix"`       // First characters of the key, for identification
+	KeyHash    string     `json:"-"`            // SHA-256 hash of the key
+	CreatedAt  time.Time  `json:"created_at"`   // When the key was created
+	ExpiresAt  *time.Time `json:"expires_at"`   // When the key expires (nil = never)
+	LastUsedAt *time.Time `json:"last_used_at"` // When the key was last used
+	RevokedAt  *time.Time `json:"revoked_at"`   // When the key was revoked
+	Roles      []Role     `json:"roles"`        // Roles granted to the key
+}
+
+// IsActive reports whether the key can be used for authentication
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// principalContextKey is the context key for the authenticated principal
+type principalContextKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// Authorize returns the principal stored in ctx if it has the given role
+func Authorize(ctx context.Context, role Role) (*Principal, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, errors.NewUnauthorized("authentication required", nil)
+	}
+
+	if !principal.HasRole(role) {
+		return nil, errors.NewForbidden("role "+string(role)+" required", nil)
+	}
+
+	return principal, nil
+}
@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// apiKeyPrefix is prepended to every generated API key so leaked keys are easy to spot
+const apiKeyPrefix = "nsk_"
+
+// TokenValidator defines the interface for bearer token validation
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*Principal, error)
+}
+
+// APIKeyRepository defines the interface for API key repository
+type APIKeyRepository interface {
+	SaveAPIKey(key *APIKey) error
+	UpdateAPIKey(key *APIKey) error
+	GetAPIKeyByID(id string) (*APIKey, error)
+	GetAPIKeyByHash(hash string) (*APIKey, error)
+	ListAPIKeys(userID string) ([]*APIKey, error)
+}
+
+// PrincipalConfig contains role and tenant assignment configuration
+type PrincipalConfig struct {
+	AdminUsers  []string // Users that are always granted the admin role
+	DefaultRole Role     // Role granted to token principals without any role
+	TenantClaim string   // Token claim holding the tenant ID
+}
+
+// AuthService handles authentication of incoming requests
+type AuthService struct {
+	tokenValidator TokenValidator
+	apiKeys        APIKeyRepository
+	logger         *logger.Logger
+	adminUsers     map[string]bool
+	defaultRole    Role
+	tenantClaim    string
+}
+
+// NewAuthService creates a new AuthService.
+// tokenValidator may be nil when only API key authentication is configured.
+func NewAuthService(tokenValidator TokenValidator, apiKeys APIKeyRepository, config PrincipalConfig, logger *logger.Logger) *AuthService {
+	adminUsers := make(map[string]bool, len(config.AdminUsers))
+	for _, userID := range config.AdminUsers {
+		adminUsers[userID] = true
+	}
+
+	if config.DefaultRole == "" {
+		config.DefaultRole = RoleViewer
+	}
+
+	return &AuthService{
+		tokenValidator: tokenValidator,
+		apiKeys:        apiKeys,
+		logger:         logger,
+		adminUsers:     adminUsers,
+		defaultRole:    config.DefaultRole,
+		tenantClaim:    config.TenantClaim,
+	}
+}
+
+// AuthenticateToken validates a bearer token and returns its principal
+func (s *AuthService) AuthenticateToken(ctx context.Context, token string) (*Principal, error) {
+	if token == "" {
+		return nil, errors.NewUnauthorized("missing bearer token", nil)
+	}
+
+	if s.tokenValidator == nil {
+		return nil, errors.NewUnauthorized("bearer token authentication is not configured", nil)
+	}
+
+	principal, err := s.tokenValidator.ValidateToken(ctx, token)
+	if err != nil {
+		s.logger.Debug("Token validation failed", zap.Error(err))
+		return nil, errors.NewUnauthorized("invalid bearer token", err)
+	}
+
+	if principal.UserID == "" {
+		return nil, errors.NewUnauthorized("token has no subject", nil)
+	}
+
+	if s.adminUsers[principal.UserID] && !principal.IsAdmin() {
+		principal.Roles = append(principal.Roles, RoleAdmin)
+	}
+	if len(principal.Roles) == 0 {
+		principal.Roles = []Role{s.defaultRole}
+	}
+	if tenantID, ok := principal.Claims[s.tenantClaim].(string); ok && s.tenantClaim != "" {
+		principal.TenantID = tenantID
+	}
+
+	return principal, nil
+}
+
+// AuthenticateAPIKey validates an API key and returns the principal of its owner
+func (s *AuthService) AuthenticateAPIKey(ctx context.Context, key string) (*Principal, error) {
+	if key == "" {
+		return nil, errors.NewUnauthorized("missing API key", nil)
+	}
+
+	apiKey, err := s.apiKeys.GetAPIKeyByHash(hashAPIKey(key))
+	if err != nil {
+		return nil, errors.NewUnauthorized("invalid API key", nil)
+	}
+
+	now := time.Now()
+	if !apiKey.IsActive(now) {
+		return nil, errors.NewUnauthorized("API key is revoked or expired", nil)
+	}
+
+	apiKey.LastUsedAt = &now
+	if err := s.apiKeys.UpdateAPIKey(apiKey); err != nil {
+		s.logger.Warn("Failed to update API key usage",
+			zap.String("key_id", apiKey.ID),
+			zap.Error(err),
+		)
+	}
+
+	return &Principal{
+		UserID: apiKey.UserID,
+		Method: AuthMethodAPIKey,
+		Roles:  apiKey.Roles,
+		Claims: map[string]interface{}{"key_id": apiKey.ID},
+	}, nil
+}
+
+// CreateAPIKey issues a new API key for a user with the given roles (operator if none).
+// The plaintext key is only returned here and is never stored.
+func (s *AuthService) CreateAPIKey(userID, name string, ttl time.Duration, roles []Role) (*APIKey, string, error) {
+	if userID == "" {
+		return nil, "", errors.NewInvalidInput("user ID is required", nil)
+	}
+	if name == "" {
+		return nil, "", errors.NewInvalidInput("key name is required", nil)
+	}
+
+	if len(roles) == 0 {
+		roles = []Role{RoleOperator}
+	}
+
+	secret, err := utils.GenerateID(32)
+	if err != nil {
+		return nil, "", errors.NewInternal("failed to generate API key", err)
+	}
+	plaintext := apiKeyPrefix + secret
+
+	now := time.Now()
+	apiKey := &APIKey{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plaintext[:len(apiKeyPrefix)+8],
+		KeyHash:   hashAPIKey(plaintext),
+		CreatedAt: now,
+		Roles:     roles,
+	}
+	if ttl > 0 {
+		expiresAt := now.Add(ttl)
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	if err := s.apiKeys.SaveAPIKey(apiKey); err != nil {
+		return nil, "", errors.NewInternal("failed to save API key", err)
+	}
+
+	s.logger.Info("API key created",
+		zap.String("key_id", apiKey.ID),
+		zap.String("user_id", userID),
+		zap.String("name", name),
+	)
+
+	return apiKey, plaintext, nil
+}
+
+// ListAPIKeys lists the API keys of a user
+func (s *AuthService) ListAPIKeys(userID string) ([]*APIKey, error) {
+	keys, err := s.apiKeys.ListAPIKeys(userID)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list API keys", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key
+func (s *AuthService) RevokeAPIKey(id string) error {
+	apiKey, err := s.apiKeys.GetAPIKeyByID(id)
+	if err != nil {
+		return errors.NewNotFound("API key not found", err)
+	}
+
+	if apiKey.RevokedAt != nil {
+		return errors.NewInvalidInput("API key is already revoked", nil)
+	}
+
+	now := time.Now()
+	apiKey.RevokedAt = &now
+
+	if err := s.apiKeys.UpdateAPIKey(apiKey); err != nil {
+		return errors.NewInternal("failed to revoke API key", err)
+	}
+
+	s.logger.Info("API key revoked",
+		zap.String("key_id", apiKey.ID),
+		zap.String("user_id", apiKey.UserID),
+	)
+
+	return nil
+}
+
+// hashAPIKey returns the hex encoded SHA-256 hash of an API key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
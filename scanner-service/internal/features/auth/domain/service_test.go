@@ -0,0 +1,92 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/repository"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newTestService() *domain.AuthService {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	return domain.NewAuthService(nil, repository.NewMemoryAPIKeyRepository(log), domain.PrincipalConfig{}, log)
+}
+
+func TestAPIKeyLifecycle(t *testing.T) {
+	service := newTestService()
+
+	// Create key
+	apiKey, plaintext, err := service.CreateAPIKey("ci-user", "ci-pipeline", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-user", apiKey.UserID)
+	assert.True(t, len(plaintext) > len(apiKey.Prefix))
+	assert.Contains(t, plaintext, apiKey.Prefix)
+
+	// Authenticate with key
+	principal, err := service.AuthenticateAPIKey(context.Background(), plaintext)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-user", principal.UserID)
+	assert.Equal(t, domain.AuthMethodAPIKey, principal.Method)
+	assert.True(t, principal.HasRole(domain.RoleOperator))
+	assert.False(t, principal.IsAdmin())
+
+	// Unknown key
+	_, err = service.AuthenticateAPIKey(context.Background(), plaintext+"x")
+	assert.Error(t, err)
+
+	// Revoke key
+	assert.NoError(t, service.RevokeAPIKey(apiKey.ID))
+	_, err = service.AuthenticateAPIKey(context.Background(), plaintext)
+	assert.Error(t, err)
+
+	// Revoking twice fails
+	assert.Error(t, service.RevokeAPIKey(apiKey.ID))
+}
+
+func TestAPIKeyExpiry(t *testing.T) {
+	service := newTestService()
+
+	_, plaintext, err := service.CreateAPIKey("ci-user", "short-lived", time.Nanosecond, nil)
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond)
+
+	_, err = service.AuthenticateAPIKey(context.Background(), plaintext)
+	assert.Error(t, err)
+}
+
+func TestAuthenticateTokenWithoutValidator(t *testing.T) {
+	service := newTestService()
+
+	_, err := service.AuthenticateToken(context.Background(), "some-token")
+	assert.Error(t, err)
+}
+
+func TestRoleHierarchy(t *testing.T) {
+	admin := &domain.Principal{UserID: "root", Roles: []domain.Role{domain.RoleAdmin}}
+	viewer := &domain.Principal{UserID: "dave", Roles: []domain.Role{domain.RoleViewer}}
+
+	assert.True(t, admin.HasRole(domain.RoleViewer))
+	assert.True(t, admin.HasRole(domain.RoleOperator))
+	assert.True(t, admin.HasRole(domain.RoleAdvanced))
+	assert.True(t, admin.CanAccess("dave"))
+
+	assert.True(t, viewer.HasRole(domain.RoleViewer))
+	assert.False(t, viewer.HasRole(domain.RoleOperator))
+	assert.False(t, viewer.HasRole(domain.RoleAdvanced))
+	assert.True(t, viewer.CanAccess("dave"))
+	assert.False(t, viewer.CanAccess("root"))
+
+	ctx := domain.WithPrincipal(context.Background(), viewer)
+	_, err := domain.Authorize(ctx, domain.RoleOperator)
+	assert.Error(t, err)
+	_, err = domain.Authorize(context.Background(), domain.RoleViewer)
+	assert.Error(t, err)
+}
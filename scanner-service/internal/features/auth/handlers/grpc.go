@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryInterceptor returns a gRPC interceptor that rejects unauthenticated calls and
+// stores the authenticated user in the call context, like Middleware.
+// Calls are authenticated with either x-api-key metadata or a bearer token in the
+// authorization metadata.
+func (h *AuthHandler) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var principal *domain.Principal
+		var err error
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		if apiKey := firstValue(md, "x-api-key"); apiKey != "" {
+			principal, err = h.authService.AuthenticateAPIKey(ctx, apiKey)
+		} else {
+			token := bearerToken(firstValue(md, "authorization"))
+			principal, err = h.authService.AuthenticateToken(ctx, token)
+		}
+
+		if err != nil {
+			h.logger.Debug("Authentication failed",
+				zap.Error(err),
+				zap.String("method", info.FullMethod),
+			)
+			return nil, errors.NewUnauthorized("unauthorized", nil)
+		}
+
+		return handler(domain.WithPrincipal(ctx, principal), req)
+	}
+}
+
+// AnonymousUnaryInterceptor returns a gRPC interceptor used when authentication is
+// disabled. Every call is attributed to userID with the admin role.
+func AnonymousUnaryInterceptor(userID string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		principal := &domain.Principal{
+			UserID: userID,
+			Roles:  []domain.Role{domain.RoleAdmin},
+		}
+		return handler(domain.WithPrincipal(ctx, principal), req)
+	}
+}
+
+// firstValue returns the first value of a metadata key, or an empty string
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AuthHandler handles HTTP authentication and API key management
+type AuthHandler struct {
+	authService *domain.AuthService
+	logger      *logger.Logger
+}
+
+// NewAuthHandler creates a new AuthHandler
+func NewAuthHandler(authService *domain.AuthService, logger *logger.Logger) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+		logger:      logger,
+	}
+}
+
+// CreateAPIKeyRequest represents the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name       string   `json:"name" binding:"required"`
+	TTLSeconds int      `json:"ttl_seconds,omitempty"`
+	Roles      []string `json:"roles,omitempty"`
+}
+
+// Middleware returns a Gin middleware that rejects unauthenticated requests
+// and stores the authenticated user in the request context.
+// Requests are authenticated with either an X-API-Key header or a bearer token.
+func (h *AuthHandler) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var principal *domain.Principal
+		var err error
+
+		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+			principal, err = h.authService.AuthenticateAPIKey(c.Request.Context(), apiKey)
+		} else {
+			token := bearerToken(c.GetHeader("Authorization"))
+			principal, err = h.authService.AuthenticateToken(c.Request.Context(), token)
+		}
+
+		if err != nil {
+			h.logger.Debug("Authentication failed",
+				zap.Error(err),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("client_ip", c.ClientIP()),
+			)
+
+			c.Header("WWW-Authenticate", `Bearer realm="scanner-service"`)
+			c.Error(errors.NewUnauthorized("unauthorized", nil))
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", principal.UserID)
+		c.Request = c.Request.WithContext(domain.WithPrincipal(c.Request.Context(), principal))
+
+		c.Next()
+	}
+}
+
+// AnonymousMiddleware returns a Gin middleware used when authentication is disabled.
+// Every request is attributed to userID with the admin role.
+func AnonymousMiddleware(userID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := &domain.Principal{
+			UserID: userID,
+			Roles:  []domain.Role{domain.RoleAdmin},
+		}
+
+		c.Set("user_id", principal.UserID)
+		c.Request = c.Request.WithContext(domain.WithPrincipal(c.Request.Context(), principal))
+
+		c.Next()
+	}
+}
+
+// RequireRole returns a Gin middleware that only lets principals with the given role through
+func RequireRole(role domain.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, err := domain.Authorize(c.Request.Context(), role); err != nil {
+			c.Error(errors.NewForbidden(string(role)+" role required", err))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Me handles the request to describe the authenticated caller. The API gateway uses it
+// to validate the API keys of proxied requests.
+func (h *AuthHandler) Me(c *gin.Context) {
+	principal, ok := domain.PrincipalFromContext(c.Request.Context())
+	if !ok {
+		c.Error(errors.NewUnauthorized("unauthorized", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, principal)
+}
+
+// CreateAPIKey handles the request to create an API key for a user
+func (h *AuthHandler) CreateAPIKey(c *gin.Context) {
+	userID := c.Param("user_id")
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	var roles []domain.Role
+	for _, name := range req.Roles {
+		role, ok := domain.ParseRole(name)
+		if !ok {
+			c.Error(errors.NewInvalidInput("invalid request: unknown role "+name, nil))
+			return
+		}
+		roles = append(roles, role)
+	}
+
+	apiKey, plaintext, err := h.authService.CreateAPIKey(userID, req.Name, time.Duration(req.TTLSeconds)*time.Second, roles)
+	if err != nil {
+		h.logger.Error("Failed to create API key",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	// The plaintext key is only ever returned once
+	c.JSON(http.StatusCreated, gin.H{
+		"api_key": apiKey,
+		"key":     plaintext,
+	})
+}
+
+// ListAPIKeys handles the request to list the API keys of a user
+func (h *AuthHandler) ListAPIKeys(c *gin.Context) {
+	userID := c.Param("user_id")
+
+	keys, err := h.authService.ListAPIKeys(userID)
+	if err != nil {
+		h.logger.Error("Failed to list API keys",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_keys": keys,
+		"count":    len(keys),
+	})
+}
+
+// RevokeAPIKey handles the request to revoke an API key
+func (h *AuthHandler) RevokeAPIKey(c *gin.Context) {
+	keyID := c.Param("id")
+
+	if err := h.authService.RevokeAPIKey(keyID); err != nil {
+		h.logger.Error("Failed to revoke API key",
+			zap.Error(err),
+			zap.String("key_id", keyID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API key revoked",
+		"key_id":  keyID,
+	})
+}
+
+// RegisterRoutes registers the auth handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *AuthHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	router.Group("/api/v1/auth", middleware...).GET("/me", h.Me)
+
+	admin := router.Group("/api/v1/admin", middleware...)
+	admin.Use(RequireRole(domain.RoleAdmin))
+
+	// API key management endpoints
+	admin.POST("/users/:user_id/api-keys", h.CreateAPIKey)
+	admin.GET("/users/:user_id/api-keys", h.ListAPIKeys)
+	admin.DELETE("/api-keys/:id", h.RevokeAPIKey)
+}
+
+// bearerToken extracts the token from an Authorization header
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MemoryAPIKeyRepository is an in-memory implementation of the APIKeyRepository interface
+type MemoryAPIKeyRepository struct {
+	logger *logger.Logger
+	keys   map[string]*domain.APIKey
+	byHash map[string]string
+	mu     sync.RWMutex
+}
+
+// NewMemoryAPIKeyRepository creates a new MemoryAPIKeyRepository
+func NewMemoryAPIKeyRepository(logger *logger.Logger) *MemoryAPIKeyRepository {
+	return &MemoryAPIKeyRepository{
+		logger: logger,
+		keys:   make(map[string]*domain.APIKey),
+		byHash: make(map[string]string),
+	}
+}
+
+// SaveAPIKey saves an API key to the repository
+func (r *MemoryAPIKeyRepository) SaveAPIKey(key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byHash[key.KeyHash]; ok {
+		return errors.NewAlreadyExists("API key already exists", nil)
+	}
+
+	keyCopy := *key
+	r.keys[key.ID] = &keyCopy
+	r.byHash[key.KeyHash] = key.ID
+
+	r.logger.Debug("Saved API key",
+		zap.String("key_id", key.ID),
+		zap.String("user_id", key.UserID),
+	)
+
+	return nil
+}
+
+// UpdateAPIKey updates an API key in the repository
+func (r *MemoryAPIKeyRepository) UpdateAPIKey(key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[key.ID]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("API key with ID %s not found", key.ID), nil)
+	}
+
+	keyCopy := *key
+	r.keys[key.ID] = &keyCopy
+
+	return nil
+}
+
+// GetAPIKeyByID gets an API key by ID from the repository
+func (r *MemoryAPIKeyRepository) GetAPIKeyByID(id string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("API key with ID %s not found", id), nil)
+	}
+
+	keyCopy := *key
+	return &keyCopy, nil
+}
+
+// GetAPIKeyByHash gets an API key by the hash of its secret from the repository
+func (r *MemoryAPIKeyRepository) GetAPIKeyByHash(hash string) (*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byHash[hash]
+	if !ok {
+		return nil, errors.NewNotFound("API key not found", nil)
+	}
+
+	keyCopy := *r.keys[id]
+	return &keyCopy, nil
+}
+
+// ListAPIKeys lists the API keys of a user from the repository
+func (r *MemoryAPIKeyRepository) ListAPIKeys(userID string) ([]*domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]*domain.APIKey, 0)
+	for _, key := range r.keys {
+		if userID == "" || key.UserID == userID {
+			keyCopy := *key
+			keys = append(keys, &keyCopy)
+		}
+	}
+
+	return keys, nil
+}
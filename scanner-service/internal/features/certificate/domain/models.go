@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"cmp"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Certificate represents a TLS certificate observed by the scans of a user
+type Certificate struct {
+	UserID        string     `json:"user_id"`                // Owner of the scans that observed the certificate
+	TenantID      string     `json:"-"`                      // Tenant of the owner, for notifications
+	Fingerprint   string     `json:"fingerprint"`            // SHA-1 fingerprint, lowercase hex
+	Subject       string     `json:"subject"`                // Common name of the subject
+	Issuer        string     `json:"issuer"`                 // Common name or organization of the issuer
+	DNSNames      []string   `json:"dns_names"`              // Subject alternative DNS names
+	NotBefore     *time.Time `json:"not_before,omitempty"`   // Start of the validity period
+	NotAfter      time.Time  `json:"not_after"`              // When the certificate expires
+	ExpiresInDays int        `json:"expires_in_days"`        // Whole days until expiry, negative once expired
+	Endpoints     []Endpoint `json:"endpoints"`              // Ports currently serving the certificate
+	FirstSeenAt   time.Time  `json:"first_seen_at"`          // When a scan first observed the certificate
+	LastSeenAt    time.Time  `json:"last_seen_at"`           // When a scan last observed the certificate
+	AlertedDays   int        `json:"alerted_days,omitempty"` // Fewest days before expiry the owner was alerted at
+	AlertedAt     *time.Time `json:"alerted_at,omitempty"`   // When the owner was last alerted
+}
+
+// Endpoint represents a port a certificate was observed on
+type Endpoint struct {
+	Host       string    `json:"host"`         // IP address of the host
+	Port       int       `json:"port"`         // Port number
+	Protocol   string    `json:"protocol"`     // Protocol (tcp/udp)
+	ScanID     string    `json:"scan_id"`      // Scan that last observed the certificate on the port
+	LastSeenAt time.Time `json:"last_seen_at"` // When that scan completed
+}
+
+// Copy returns a copy of the certificate that does not share its names and endpoints
+func (c *Certificate) Copy() *Certificate {
+	certificateCopy := *c
+	certificateCopy.DNSNames = slices.Clone(c.DNSNames)
+	certificateCopy.Endpoints = slices.Clone(c.Endpoints)
+	return &certificateCopy
+}
+
+// daysLeft returns the whole days from now until the certificate expires, rounded
+// down, e.g. 0 on its last day and -1 on the day after it expired
+func (c *Certificate) daysLeft(now time.Time) int {
+	left := c.NotAfter.Sub(now)
+	days := int(left / (24 * time.Hour))
+	if left < 0 {
+		days--
+	}
+	return days
+}
+
+// same reports whether two endpoints are the same port of the same host
+func (e Endpoint) same(other Endpoint) bool {
+	return e.Host == other.Host && e.Port == other.Port && e.Protocol == other.Protocol
+}
+
+// sortEndpoints sorts endpoints by host address, port and protocol
+func sortEndpoints(endpoints []Endpoint) {
+	slices.SortFunc(endpoints, func(a, b Endpoint) int {
+		if a.Host != b.Host {
+			addrA, errA := netip.ParseAddr(a.Host)
+			addrB, errB := netip.ParseAddr(b.Host)
+			if errA == nil && errB == nil {
+				return addrA.Compare(addrB)
+			}
+			return strings.Compare(a.Host, b.Host)
+		}
+		return cmp.Or(cmp.Compare(a.Port, b.Port), strings.Compare(a.Protocol, b.Protocol))
+	})
+}
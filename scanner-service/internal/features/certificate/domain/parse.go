@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"cmp"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+)
+
+// sslCertScript is the ID of the NSE script reporting the certificate of a TLS service
+const sslCertScript = "ssl-cert"
+
+// validityLayouts are the time layouts of the validity period of ssl-cert, which
+// reports UTC times with or without a zone
+var validityLayouts = []string{time.RFC3339, "2006-01-02T15:04:05"}
+
+// ParseCertificate returns the certificate reported by an ssl-cert script result. The
+// PEM encoded certificate is preferred; without it the certificate is read from the
+// structured output of the script. It returns false for other scripts and for results
+// without a fingerprint or expiry date.
+func ParseCertificate(script scandomain.Script) (*Certificate, bool) {
+	if script.ID != sslCertScript {
+		return nil, false
+	}
+
+	if block, _ := pem.Decode([]byte(script.Data["pem"])); block != nil {
+		if parsed, err := x509.ParseCertificate(block.Bytes); err == nil {
+			return fromX509(parsed), true
+		}
+	}
+
+	fingerprint := strings.ToLower(strings.ReplaceAll(script.Data["sha1"], " ", ""))
+	notAfter, ok := parseValidity(script.Data["validity.notAfter"])
+	if fingerprint == "" || !ok {
+		return nil, false
+	}
+
+	certificate := &Certificate{
+		Fingerprint: fingerprint,
+		Subject:     script.Data["subject.commonName"],
+		Issuer:      cmp.Or(script.Data["issuer.commonName"], script.Data["issuer.organizationName"]),
+		DNSNames:    []string{},
+		NotAfter:    notAfter,
+	}
+	if notBefore, ok := parseValidity(script.Data["validity.notBefore"]); ok {
+		certificate.NotBefore = &notBefore
+	}
+
+	// Extensions are a list of name and value pairs
+	for i := 1; ; i++ {
+		prefix := "extensions." + strconv.Itoa(i) + "."
+		name, ok := script.Data[prefix+"name"]
+		if !ok {
+			break
+		}
+		if name != "X509v3 Subject Alternative Name" {
+			continue
+		}
+		for _, entry := range strings.Split(script.Data[prefix+"value"], ",") {
+			if dnsName, ok := strings.CutPrefix(strings.TrimSpace(entry), "DNS:"); ok && !slices.Contains(certificate.DNSNames, dnsName) {
+				certificate.DNSNames = append(certificate.DNSNames, dnsName)
+			}
+		}
+	}
+
+	return certificate, true
+}
+
+// fromX509 returns the certificate of a parsed X.509 certificate
+func fromX509(parsed *x509.Certificate) *Certificate {
+	sum := sha1.Sum(parsed.Raw)
+	notBefore := parsed.NotBefore.UTC()
+	dnsNames := slices.Clone(parsed.DNSNames)
+	if dnsNames == nil {
+		dnsNames = []string{}
+	}
+
+	issuer := parsed.Issuer.CommonName
+	if issuer == "" && len(parsed.Issuer.Organization) > 0 {
+		issuer = parsed.Issuer.Organization[0]
+	}
+
+	return &Certificate{
+		Fingerprint: hex.EncodeToString(sum[:]),
+		Subject:     parsed.Subject.CommonName,
+		Issuer:      issuer,
+		DNSNames:    dnsNames,
+		NotBefore:   &notBefore,
+		NotAfter:    parsed.NotAfter.UTC(),
+	}
+}
+
+// parseValidity parses a time of the validity period of an ssl-cert result
+func parseValidity(value string) (time.Time, bool) {
+	for _, layout := range validityLayouts {
+		if parsed, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return parsed.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/certificate/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// selfSigned returns a PEM encoded self-signed certificate for example.com expiring at
+// notAfter, and its SHA-1 fingerprint
+func selfSigned(t *testing.T, notAfter time.Time) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		Issuer:       pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "www.example.com"},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	sum := sha1.Sum(der)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), hex.EncodeToString(sum[:])
+}
+
+func TestParseCertificate(t *testing.T) {
+	notAfter := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	pemData, fingerprint := selfSigned(t, notAfter)
+
+	// The PEM encoded certificate is preferred
+	certificate, ok := domain.ParseCertificate(scandomain.Script{ID: "ssl-cert", Data: map[string]string{
+		"pem":                pemData,
+		"subject.commonName": "ignored",
+	}})
+	require.True(t, ok)
+	assert.Equal(t, fingerprint, certificate.Fingerprint)
+	assert.Equal(t, "example.com", certificate.Subject)
+	assert.Equal(t, "example.com", certificate.Issuer)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, certificate.DNSNames)
+	assert.Equal(t, notAfter, certificate.NotAfter)
+	require.NotNil(t, certificate.NotBefore)
+
+	// Without it the structured output of the script is used
+	certificate, ok = domain.ParseCertificate(scandomain.Script{ID: "ssl-cert", Data: map[string]string{
+		"subject.commonName":      "mail.example.com",
+		"issuer.organizationName": "Let's Encrypt",
+		"validity.notBefore":      "2026-08-01T00:00:00",
+		"validity.notAfter":       "2026-10-30T23:59:59+00:00",
+		"sha1":                    "AB12 CD34",
+		"extensions.1.name":       "X509v3 Key Usage",
+		"extensions.1.value":      "Digital Signature",
+		"extensions.2.name":       "X509v3 Subject Alternative Name",
+		"extensions.2.value":      "DNS:mail.example.com, IP Address:192.0.2.1, DNS:smtp.example.com",
+	}})
+	require.True(t, ok)
+	assert.Equal(t, "ab12cd34", certificate.Fingerprint)
+	assert.Equal(t, "mail.example.com", certificate.Subject)
+	assert.Equal(t, "Let's Encrypt", certificate.Issuer)
+	assert.Equal(t, []string{"mail.example.com", "smtp.example.com"}, certificate.DNSNames)
+	assert.Equal(t, time.Date(2026, 10, 30, 23, 59, 59, 0, time.UTC), certificate.NotAfter)
+	assert.Equal(t, time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), *certificate.NotBefore)
+
+	// Other scripts and results without an expiry date are ignored
+	for _, script := range []scandomain.Script{
+		{ID: "http-title", Data: map[string]string{"sha1": "ab12"}},
+		{ID: "ssl-cert", Data: map[string]string{"sha1": "ab12"}},
+		{ID: "ssl-cert", Data: map[string]string{"sha1": "ab12", "validity.notAfter": "soon"}},
+	} {
+		_, ok := domain.ParseCertificate(script)
+		assert.False(t, ok, script)
+	}
+}
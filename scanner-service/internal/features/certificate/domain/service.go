@@ -0,0 +1,341 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	notificationdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// expiryCheckInterval is how often certificates are checked for upcoming expiry
+const expiryCheckInterval = time.Hour
+
+// CertificateRepository defines the interface for certificate inventory storage
+type CertificateRepository interface {
+	SaveCertificate(certificate *Certificate) error
+	ListCertificates(userID string) ([]*Certificate, error)
+}
+
+// CertificateService maintains the inventory of certificates observed by completed
+// scans and alerts their owners before they expire
+type CertificateService struct {
+	repository    CertificateRepository
+	notifier      notificationdomain.Notifier // Delivers expiry alerts, nil to only log them
+	notifyTimeout time.Duration
+	alertDays     []int // Days before expiry owners are alerted, most first
+	logger        *logger.Logger
+	stop          chan struct{}
+	mu            sync.Mutex // Serializes inventory updates and expiry checks
+}
+
+// NewCertificateService creates a new CertificateService
+func NewCertificateService(repository CertificateRepository, logger *logger.Logger) *CertificateService {
+	return &CertificateService{
+		repository:    repository,
+		notifyTimeout: 10 * time.Second,
+		logger:        logger,
+	}
+}
+
+// SetNotifier sets the notifier expiry alerts are delivered through, each within the timeout
+func (s *CertificateService) SetNotifier(notifier notificationdomain.Notifier, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	s.notifier = notifier
+	s.notifyTimeout = timeout
+}
+
+// SetExpiryAlerts sets how many days before expiry the owners of certificates are
+// alerted, e.g. 30, 7 and 1 days. Owners are alerted once per number of days; no
+// alerts are sent by default.
+func (s *CertificateService) SetExpiryAlerts(days []int) {
+	s.alertDays = slices.Compact(slices.Sorted(slices.Values(days)))
+	slices.Reverse(s.alertDays)
+}
+
+// Start starts checking the inventory for certificates about to expire periodically
+func (s *CertificateService) Start() {
+	if len(s.alertDays) == 0 || s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(expiryCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.CheckExpiry(context.Background(), time.Now())
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic expiry checks
+func (s *CertificateService) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// ListCertificates lists the certificates observed by the scans of a user by expiry
+// date, earliest first. Only certificates expiring within expiresWithin days are
+// listed, unless it is 0. Only admins may list other users' certificates or the
+// certificates of all users (empty userID).
+func (s *CertificateService) ListCertificates(ctx context.Context, userID string, expiresWithin int) ([]*Certificate, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != principal.UserID && !principal.IsAdmin() {
+		return nil, errors.NewForbidden("cannot list certificates of other users", nil)
+	}
+	if expiresWithin < 0 {
+		return nil, errors.NewInvalidField("expires_within", "min", "expires_within must not be negative")
+	}
+
+	certificates, err := s.repository.ListCertificates(userID)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list certificates", err)
+	}
+
+	now := time.Now()
+	listed := make([]*Certificate, 0, len(certificates))
+	for _, certificate := range certificates {
+		certificate.ExpiresInDays = certificate.daysLeft(now)
+		if expiresWithin == 0 || certificate.ExpiresInDays < expiresWithin {
+			listed = append(listed, certificate)
+		}
+	}
+
+	return listed, nil
+}
+
+// ScanCompleted adds the certificates reported by the ssl-cert results of a completed
+// scan to the inventory of the scan owner. A port serving another certificate than
+// before is removed from the endpoints of the previous certificate. Certificates of
+// the scan that are about to expire are alerted about in the background.
+func (s *CertificateService) ScanCompleted(ctx context.Context, scan *scandomain.Scan, result *scandomain.ScanResult) {
+	log := s.logger.WithContext(ctx)
+	seenAt := time.Now()
+
+	observed := make(map[string]*Certificate)
+	var endpoints []Endpoint
+	for _, host := range result.Hosts {
+		for _, script := range host.Scripts {
+			certificate, ok := ParseCertificate(script)
+			if !ok || script.Port == 0 {
+				continue
+			}
+			endpoint := Endpoint{Host: host.IP, Port: script.Port, Protocol: script.Protocol, ScanID: scan.ID, LastSeenAt: seenAt}
+			endpoints = append(endpoints, endpoint)
+			if existing, ok := observed[certificate.Fingerprint]; ok {
+				certificate = existing
+			}
+			certificate.Endpoints = append(certificate.Endpoints, endpoint)
+			observed[certificate.Fingerprint] = certificate
+		}
+	}
+	if len(observed) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	certificates, err := s.repository.ListCertificates(scan.UserID)
+	if err != nil {
+		log.Error("Failed to list certificates",
+			zap.String("scan_id", scan.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	var updated []*Certificate
+	for _, certificate := range certificates {
+		found, ok := observed[certificate.Fingerprint]
+		if !ok {
+			// Ports of the scan serving other certificates no longer serve this one
+			kept := slices.DeleteFunc(slices.Clone(certificate.Endpoints), func(existing Endpoint) bool {
+				return slices.ContainsFunc(endpoints, existing.same)
+			})
+			if len(kept) == len(certificate.Endpoints) {
+				continue
+			}
+			certificate.Endpoints = kept
+			s.saveCertificate(ctx, certificate)
+			continue
+		}
+
+		for _, endpoint := range certificate.Endpoints {
+			if !slices.ContainsFunc(found.Endpoints, endpoint.same) && !slices.ContainsFunc(endpoints, endpoint.same) {
+				found.Endpoints = append(found.Endpoints, endpoint)
+			}
+		}
+		found.FirstSeenAt = certificate.FirstSeenAt
+		found.AlertedDays = certificate.AlertedDays
+		found.AlertedAt = certificate.AlertedAt
+		delete(observed, certificate.Fingerprint)
+		updated = append(updated, found)
+	}
+	for _, certificate := range observed {
+		certificate.FirstSeenAt = seenAt
+		updated = append(updated, certificate)
+	}
+
+	for _, certificate := range updated {
+		certificate.UserID = scan.UserID
+		certificate.TenantID = scan.TenantID
+		certificate.LastSeenAt = seenAt
+		sortEndpoints(certificate.Endpoints)
+		s.saveCertificate(ctx, certificate)
+	}
+
+	log.Info("Certificates observed",
+		zap.String("scan_id", scan.ID),
+		zap.Int("certificates", len(updated)),
+	)
+
+	notifications := s.expiring(ctx, updated, seenAt)
+	if len(notifications) > 0 {
+		go s.deliver(context.WithoutCancel(ctx), notifications)
+	}
+}
+
+// CheckExpiry alerts the owners of the certificates in the inventory that expire
+// within a number of days they were not alerted at yet
+func (s *CertificateService) CheckExpiry(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	certificates, err := s.repository.ListCertificates("")
+	if err != nil {
+		s.mu.Unlock()
+		s.logger.WithContext(ctx).Error("Failed to list certificates", zap.Error(err))
+		return
+	}
+	notifications := s.expiring(ctx, certificates, now)
+	s.mu.Unlock()
+
+	s.deliver(ctx, notifications)
+}
+
+// expiring returns the alerts about certificates still served on a port that expire
+// within fewer days than their owner was last alerted at, and records them. The
+// caller must hold s.mu.
+func (s *CertificateService) expiring(ctx context.Context, certificates []*Certificate, now time.Time) []notificationdomain.Notification {
+	var notifications []notificationdomain.Notification
+	for _, certificate := range certificates {
+		if len(certificate.Endpoints) == 0 {
+			continue
+		}
+
+		// The fewest days of the alerts the certificate is due
+		daysLeft := certificate.daysLeft(now)
+		due := 0
+		for _, days := range s.alertDays {
+			if daysLeft < days {
+				due = days
+			}
+		}
+		if due == 0 || (certificate.AlertedDays != 0 && certificate.AlertedDays <= due) {
+			continue
+		}
+
+		certificate.AlertedDays = due
+		certificate.AlertedAt = &now
+		s.saveCertificate(ctx, certificate)
+		notifications = append(notifications, expiryNotification(certificate, daysLeft, due, now))
+	}
+	return notifications
+}
+
+// expiryNotification returns the alert about a certificate expiring within days
+func expiryNotification(certificate *Certificate, daysLeft, days int, now time.Time) notificationdomain.Notification {
+	name := cmp.Or(certificate.Subject, certificate.Fingerprint)
+	subject := fmt.Sprintf("Certificate %s expires in %d days", name, daysLeft)
+	switch {
+	case now.After(certificate.NotAfter):
+		subject = fmt.Sprintf("Certificate %s has expired", name)
+	case daysLeft == 0:
+		subject = fmt.Sprintf("Certificate %s expires today", name)
+	case daysLeft == 1:
+		subject = fmt.Sprintf("Certificate %s expires in 1 day", name)
+	}
+
+	lines := []string{
+		"Expires: " + certificate.NotAfter.Format(time.RFC3339),
+		"Issuer: " + certificate.Issuer,
+	}
+	if len(certificate.DNSNames) > 0 {
+		lines = append(lines, "Names: "+strings.Join(certificate.DNSNames, ", "))
+	}
+	for _, endpoint := range certificate.Endpoints {
+		lines = append(lines, fmt.Sprintf("Served on %s:%d/%s", endpoint.Host, endpoint.Port, endpoint.Protocol))
+	}
+
+	return notificationdomain.Notification{
+		ID:         fmt.Sprintf("certificate-%s-%s-%dd", certificate.UserID, certificate.Fingerprint, days),
+		Type:       notificationdomain.EventTypeCertificateExpiring,
+		OccurredAt: now.UTC(),
+		UserID:     certificate.UserID,
+		TenantID:   certificate.TenantID,
+		Subject:    subject,
+		Message:    strings.Join(lines, "\n"),
+		Attributes: map[string]string{
+			"fingerprint": certificate.Fingerprint,
+			"subject":     certificate.Subject,
+			"issuer":      certificate.Issuer,
+			"not_after":   certificate.NotAfter.Format(time.RFC3339),
+			"days":        fmt.Sprint(days),
+		},
+	}
+}
+
+// deliver sends expiry alerts, each within the notification timeout
+func (s *CertificateService) deliver(ctx context.Context, notifications []notificationdomain.Notification) {
+	log := s.logger.WithContext(ctx)
+	for _, notification := range notifications {
+		if s.notifier == nil {
+			log.Warn("Certificate expires soon",
+				zap.String("user_id", notification.UserID),
+				zap.String("subject", notification.Subject),
+			)
+			continue
+		}
+
+		notifyCtx, cancel := context.WithTimeout(ctx, s.notifyTimeout)
+		if err := s.notifier.Notify(notifyCtx, notification); err != nil {
+			log.Error("Failed to send certificate expiry alert",
+				zap.String("notification_id", notification.ID),
+				zap.Error(err),
+			)
+		}
+		cancel()
+	}
+}
+
+// saveCertificate stores a certificate, logging failures
+func (s *CertificateService) saveCertificate(ctx context.Context, certificate *Certificate) {
+	if err := s.repository.SaveCertificate(certificate); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save certificate",
+			zap.String("fingerprint", certificate.Fingerprint),
+			zap.Error(err),
+		)
+	}
+}
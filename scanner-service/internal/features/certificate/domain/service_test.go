@@ -0,0 +1,181 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/certificate/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/certificate/repository"
+	notificationdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// channelNotifier passes notifications to a channel
+type channelNotifier chan notificationdomain.Notification
+
+func (n channelNotifier) Notify(ctx context.Context, notification notificationdomain.Notification) error {
+	n <- notification
+	return nil
+}
+
+func principalContext(userID string, role authdomain.Role) context.Context {
+	return authdomain.WithPrincipal(context.Background(), &authdomain.Principal{
+		UserID: userID,
+		Roles:  []authdomain.Role{role},
+	})
+}
+
+// sslCert returns the ssl-cert result of a certificate served on a port
+func sslCert(port int, fingerprint string, notAfter time.Time) scandomain.Script {
+	return scandomain.Script{ID: "ssl-cert", Port: port, Protocol: "tcp", Data: map[string]string{
+		"subject.commonName": fingerprint + ".example.com",
+		"issuer.commonName":  "Example CA",
+		"sha1":               fingerprint,
+		"validity.notAfter":  notAfter.Format(time.RFC3339),
+	}}
+}
+
+// completedScan returns a completed scan of alice and its result with the given hosts
+func completedScan(id string, hosts ...scandomain.Host) (*scandomain.Scan, *scandomain.ScanResult) {
+	scan := &scandomain.Scan{ID: id, UserID: "alice", TenantID: "acme", Status: scandomain.ScanStatusCompleted, ResultID: "result-" + id}
+	return scan, &scandomain.ScanResult{ID: scan.ResultID, ScanID: id, UserID: "alice", Hosts: hosts}
+}
+
+func TestScanCompletedMaintainsInventory(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	service := domain.NewCertificateService(repository.NewMemoryCertificateRepository(log), log)
+	alice := principalContext("alice", authdomain.RoleViewer)
+	notAfter := time.Now().Add(200 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	scan, result := completedScan("scan-1", scandomain.Host{IP: "203.0.113.10", Scripts: []scandomain.Script{
+		sslCert(443, "aa", notAfter),
+		sslCert(8443, "aa", notAfter),
+		sslCert(0, "cc", notAfter), // Host scripts are not served on a port
+		{ID: "http-title", Port: 80, Protocol: "tcp", Output: "Welcome"},
+	}})
+	service.ScanCompleted(context.Background(), scan, result)
+
+	certificates, err := service.ListCertificates(alice, "alice", 0)
+	require.NoError(t, err)
+	require.Len(t, certificates, 1)
+	certificate := certificates[0]
+	assert.Equal(t, "aa", certificate.Fingerprint)
+	assert.Equal(t, "aa.example.com", certificate.Subject)
+	assert.Equal(t, "Example CA", certificate.Issuer)
+	assert.Equal(t, notAfter, certificate.NotAfter)
+	assert.Equal(t, 199, certificate.ExpiresInDays)
+	require.Len(t, certificate.Endpoints, 2)
+	assert.Equal(t, 443, certificate.Endpoints[0].Port)
+	assert.Equal(t, "scan-1", certificate.Endpoints[1].ScanID)
+	firstSeenAt := certificate.FirstSeenAt
+
+	// A port serving another certificate is moved to it
+	scan, result = completedScan("scan-2", scandomain.Host{IP: "203.0.113.10", Scripts: []scandomain.Script{
+		sslCert(8443, "bb", notAfter.Add(-100*24*time.Hour)),
+	}})
+	service.ScanCompleted(context.Background(), scan, result)
+
+	certificates, err = service.ListCertificates(alice, "alice", 0)
+	require.NoError(t, err)
+	require.Len(t, certificates, 2)
+	assert.Equal(t, "bb", certificates[0].Fingerprint)
+	assert.Equal(t, []int{8443}, endpointPorts(certificates[0]))
+	assert.Equal(t, "aa", certificates[1].Fingerprint)
+	assert.Equal(t, []int{443}, endpointPorts(certificates[1]))
+	assert.Equal(t, firstSeenAt, certificates[1].FirstSeenAt)
+
+	// Certificates can be filtered by expiry
+	certificates, err = service.ListCertificates(alice, "alice", 150)
+	require.NoError(t, err)
+	require.Len(t, certificates, 1)
+	assert.Equal(t, "bb", certificates[0].Fingerprint)
+}
+
+func TestListCertificatesAuthorization(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	service := domain.NewCertificateService(repository.NewMemoryCertificateRepository(log), log)
+	var serviceErr *errors.Error
+
+	scan, result := completedScan("scan-1", scandomain.Host{IP: "203.0.113.10", Scripts: []scandomain.Script{
+		sslCert(443, "aa", time.Now().Add(90*24*time.Hour)),
+	}})
+	service.ScanCompleted(context.Background(), scan, result)
+
+	_, err := service.ListCertificates(principalContext("bob", authdomain.RoleViewer), "alice", 0)
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, errors.ErrForbidden, serviceErr.Type)
+
+	_, err = service.ListCertificates(principalContext("alice", authdomain.RoleViewer), "alice", -1)
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, errors.ErrInvalidInput, serviceErr.Type)
+
+	certificates, err := service.ListCertificates(principalContext("bob", authdomain.RoleViewer), "bob", 0)
+	require.NoError(t, err)
+	assert.Empty(t, certificates)
+
+	// Admins can list the certificates of all users
+	certificates, err = service.ListCertificates(principalContext("root", authdomain.RoleAdmin), "", 0)
+	require.NoError(t, err)
+	require.Len(t, certificates, 1)
+	assert.Equal(t, "alice", certificates[0].UserID)
+}
+
+func TestCheckExpiryAlertsOncePerThreshold(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	notifier := make(channelNotifier, 4)
+	service := domain.NewCertificateService(repository.NewMemoryCertificateRepository(log), log)
+	service.SetNotifier(notifier, time.Second)
+	service.SetExpiryAlerts([]int{1, 30, 7})
+
+	now := time.Now().UTC()
+	notAfter := now.Add(20*24*time.Hour + time.Hour)
+	scan, result := completedScan("scan-1", scandomain.Host{IP: "203.0.113.10", Scripts: []scandomain.Script{
+		sslCert(443, "aa", notAfter),
+		sslCert(8443, "bb", now.Add(90*24*time.Hour)),
+	}})
+
+	// Certificates expiring within the alert days are alerted about when observed
+	service.ScanCompleted(context.Background(), scan, result)
+	notification := <-notifier
+	assert.Equal(t, "certificate-alice-aa-30d", notification.ID)
+	assert.Equal(t, notificationdomain.EventTypeCertificateExpiring, notification.Type)
+	assert.Equal(t, "alice", notification.UserID)
+	assert.Equal(t, "acme", notification.TenantID)
+	assert.Equal(t, "Certificate aa.example.com expires in 20 days", notification.Subject)
+	assert.Contains(t, notification.Message, "Served on 203.0.113.10:443/tcp")
+	assert.Equal(t, "30", notification.Attributes["days"])
+
+	// The owner is not alerted again until the next threshold
+	service.CheckExpiry(context.Background(), now.Add(24*time.Hour))
+	assert.Empty(t, notifier)
+
+	service.CheckExpiry(context.Background(), now.Add(14*24*time.Hour))
+	notification = <-notifier
+	assert.Equal(t, "certificate-alice-aa-7d", notification.ID)
+	assert.Equal(t, "Certificate aa.example.com expires in 6 days", notification.Subject)
+
+	// Alerts skipped while the service was down are sent once, for the fewest days
+	service.CheckExpiry(context.Background(), now.Add(30*24*time.Hour))
+	notification = <-notifier
+	assert.Equal(t, "certificate-alice-aa-1d", notification.ID)
+	assert.Equal(t, "Certificate aa.example.com has expired", notification.Subject)
+	assert.Empty(t, notifier)
+
+	service.CheckExpiry(context.Background(), now.Add(31*24*time.Hour))
+	assert.Empty(t, notifier)
+}
+
+func endpointPorts(certificate *domain.Certificate) []int {
+	ports := make([]int, 0, len(certificate.Endpoints))
+	for _, endpoint := range certificate.Endpoints {
+		ports = append(ports, endpoint.Port)
+	}
+	return ports
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/certificate/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CertificateHandler handles HTTP requests for the certificate inventory
+type CertificateHandler struct {
+	certificateService *domain.CertificateService
+	logger             *logger.Logger
+}
+
+// NewCertificateHandler creates a new CertificateHandler
+func NewCertificateHandler(certificateService *domain.CertificateService, logger *logger.Logger) *CertificateHandler {
+	return &CertificateHandler{
+		certificateService: certificateService,
+		logger:             logger,
+	}
+}
+
+// ListCertificates handles the request to list the certificates observed by scans.
+// ?expires_within= limits the list to certificates expiring within a number of days.
+// Admins may list another user's certificates with ?user_id= or all certificates with ?all=true.
+func (h *CertificateHandler) ListCertificates(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if queryUserID := c.Query("user_id"); queryUserID != "" {
+		userID = queryUserID
+	}
+	if c.Query("all") == "true" {
+		userID = ""
+	}
+
+	var expiresWithin int
+	if value := c.Query("expires_within"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			c.Error(errors.NewInvalidField("expires_within", "type", "expires_within must be a number of days"))
+			return
+		}
+		expiresWithin = days
+	}
+
+	certificates, err := h.certificateService.ListCertificates(c.Request.Context(), userID, expiresWithin)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to list certificates",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"certificates": certificates,
+		"count":        len(certificates),
+	})
+}
+
+// RegisterRoutes registers the certificate handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *CertificateHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1/certificates", middleware...)
+
+	api.GET("", authhandlers.RequireRole(authdomain.RoleViewer), h.ListCertificates)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/certificate/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MemoryCertificateRepository is an in-memory implementation of the CertificateRepository interface
+type MemoryCertificateRepository struct {
+	logger       *logger.Logger
+	certificates map[string]*domain.Certificate // User ID and fingerprint -> certificate
+	mu           sync.RWMutex
+}
+
+// NewMemoryCertificateRepository creates a new MemoryCertificateRepository
+func NewMemoryCertificateRepository(logger *logger.Logger) *MemoryCertificateRepository {
+	return &MemoryCertificateRepository{
+		logger:       logger,
+		certificates: make(map[string]*domain.Certificate),
+	}
+}
+
+// SaveCertificate creates or replaces a certificate of a user in the repository
+func (r *MemoryCertificateRepository) SaveCertificate(certificate *domain.Certificate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.certificates[certificate.UserID+"/"+certificate.Fingerprint] = certificate.Copy()
+
+	r.logger.Debug("Saved certificate",
+		zap.String("fingerprint", certificate.Fingerprint),
+		zap.String("user_id", certificate.UserID),
+	)
+
+	return nil
+}
+
+// ListCertificates lists the certificates of a user, or of all users if userID is
+// empty, by expiry date
+func (r *MemoryCertificateRepository) ListCertificates(userID string) ([]*domain.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	certificates := make([]*domain.Certificate, 0)
+	for _, certificate := range r.certificates {
+		if userID == "" || certificate.UserID == userID {
+			certificates = append(certificates, certificate.Copy())
+		}
+	}
+
+	sort.Slice(certificates, func(i, j int) bool {
+		if !certificates[i].NotAfter.Equal(certificates[j].NotAfter) {
+			return certificates[i].NotAfter.Before(certificates[j].NotAfter)
+		}
+		if certificates[i].Fingerprint != certificates[j].Fingerprint {
+			return certificates[i].Fingerprint < certificates[j].Fingerprint
+		}
+		return certificates[i].UserID < certificates[j].UserID
+	})
+
+	return certificates, nil
+}
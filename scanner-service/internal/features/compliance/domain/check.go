@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// serviceProtocols lists the protocols service rules can match
+var serviceProtocols = []string{"tcp", "udp", "sctp"}
+
+// Validate checks the template and normalizes its networks, protocols and service names
+func (t *Template) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return errors.NewInvalidField("name", "required", "name is required")
+	}
+	if len(t.Segments) == 0 {
+		return errors.NewInvalidField("segments", "min", "at least one segment is required")
+	}
+
+	for i := range t.Segments {
+		segment := &t.Segments[i]
+		if strings.TrimSpace(segment.Name) == "" {
+			return errors.NewInvalidInput(fmt.Sprintf("segment %d: name is required", i+1), nil)
+		}
+		if len(segment.CIDRs) == 0 {
+			return errors.NewInvalidInput(fmt.Sprintf("segment %s: at least one CIDR is required", segment.Name), nil)
+		}
+		for j, cidr := range segment.CIDRs {
+			prefix, err := parsePrefix(cidr)
+			if err != nil {
+				return errors.NewInvalidInput(fmt.Sprintf("segment %s: invalid CIDR: %s", segment.Name, cidr), err)
+			}
+			segment.CIDRs[j] = prefix.String()
+		}
+		if len(segment.Allowed) == 0 && len(segment.Forbidden) == 0 {
+			return errors.NewInvalidInput(fmt.Sprintf("segment %s: allowed or forbidden services are required", segment.Name), nil)
+		}
+		for _, rules := range [][]ServiceRule{segment.Allowed, segment.Forbidden} {
+			for j := range rules {
+				if err := rules[j].validate(); err != nil {
+					return errors.NewInvalidInput(fmt.Sprintf("segment %s: %s", segment.Name, err), nil)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate checks the rule and normalizes its protocol and service name
+func (r *ServiceRule) validate() error {
+	r.Protocol = strings.ToLower(r.Protocol)
+	r.Service = strings.ToLower(strings.TrimSpace(r.Service))
+
+	if len(r.Ports) == 0 && r.Service == "" {
+		return fmt.Errorf("service rules need ports or a service name")
+	}
+	for _, port := range r.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid port: %d", port)
+		}
+	}
+	if r.Protocol != "" && !slices.Contains(serviceProtocols, r.Protocol) {
+		return fmt.Errorf("invalid protocol: %s", r.Protocol)
+	}
+	return nil
+}
+
+// matches reports whether the rule matches an open port
+func (r ServiceRule) matches(port scandomain.Port) bool {
+	return (len(r.Ports) == 0 || slices.Contains(r.Ports, port.Port)) &&
+		(r.Protocol == "" || strings.EqualFold(r.Protocol, port.Protocol)) &&
+		(r.Service == "" || strings.EqualFold(r.Service, port.Service))
+}
+
+// String describes the rule for findings without a reason
+func (r ServiceRule) String() string {
+	var parts []string
+	if r.Service != "" {
+		parts = append(parts, r.Service)
+	}
+	if len(r.Ports) > 0 {
+		ports := make([]string, len(r.Ports))
+		for i, port := range r.Ports {
+			ports[i] = fmt.Sprint(port)
+		}
+		parts = append(parts, "port "+strings.Join(ports, ","))
+	}
+	if r.Protocol != "" {
+		parts = append(parts, r.Protocol)
+	}
+	return strings.Join(parts, " ")
+}
+
+// contains reports whether the address is inside the segment
+func (s Segment) contains(addr netip.Addr) bool {
+	for _, cidr := range s.CIDRs {
+		if prefix, err := parsePrefix(cidr); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns the finding of an open port of a host in the segment
+func (s Segment) check(host string, port scandomain.Port) Finding {
+	finding := Finding{
+		Status:   CheckStatusPass,
+		Segment:  s.Name,
+		Host:     host,
+		Port:     port.Port,
+		Protocol: port.Protocol,
+		Service:  port.Service,
+	}
+
+	for _, rule := range s.Forbidden {
+		if rule.matches(port) {
+			finding.Status = CheckStatusFail
+			finding.Reason = cmp.Or(rule.Reason, "forbidden service: "+rule.String())
+			return finding
+		}
+	}
+
+	if len(s.Allowed) > 0 && !slices.ContainsFunc(s.Allowed, func(rule ServiceRule) bool { return rule.matches(port) }) {
+		finding.Status = CheckStatusFail
+		finding.Reason = "service not allowed in segment " + s.Name
+	}
+
+	return finding
+}
+
+// Check checks the open ports of the hosts of a scan result against the template.
+// Each open port of a host is checked in every segment containing the host.
+func (t *Template) Check(result *scandomain.ScanResult) *Report {
+	report := &Report{
+		TemplateID:   t.ID,
+		TemplateName: t.Name,
+		ResultID:     result.ID,
+		ScanID:       result.ScanID,
+		Status:       CheckStatusPass,
+		Findings:     []Finding{},
+		CheckedAt:    time.Now(),
+	}
+
+	for _, host := range result.Hosts {
+		addr, err := netip.ParseAddr(host.IP)
+		if err != nil {
+			report.UnscopedHosts++
+			continue
+		}
+
+		scoped := false
+		for _, segment := range t.Segments {
+			if !segment.contains(addr.Unmap()) {
+				continue
+			}
+			scoped = true
+
+			for _, port := range host.Ports {
+				if port.State != "open" {
+					continue
+				}
+				finding := segment.check(host.IP, port)
+				if finding.Status == CheckStatusFail {
+					report.Failed++
+				} else {
+					report.Passed++
+				}
+				report.Findings = append(report.Findings, finding)
+			}
+		}
+
+		if scoped {
+			report.HostsChecked++
+		} else {
+			report.UnscopedHosts++
+		}
+	}
+
+	if report.Failed > 0 {
+		report.Status = CheckStatusFail
+	}
+
+	slices.SortStableFunc(report.Findings, func(a, b Finding) int {
+		if a.Status != b.Status {
+			if a.Status == CheckStatusFail {
+				return -1
+			}
+			return 1
+		}
+		if a.Host != b.Host {
+			return netip.MustParseAddr(a.Host).Compare(netip.MustParseAddr(b.Host))
+		}
+		return cmp.Compare(a.Port, b.Port)
+	})
+
+	return report
+}
+
+// parsePrefix parses a CIDR or a single IP address into a network
+func parsePrefix(value string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
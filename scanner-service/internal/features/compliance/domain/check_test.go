@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/compliance/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pciTemplate allows only web and SSH in the cardholder data environment and forbids
+// cleartext protocols everywhere
+func pciTemplate() domain.Template {
+	return domain.Template{
+		Name: "PCI scope",
+		Segments: []domain.Segment{
+			{
+				Name:    "cde",
+				CIDRs:   []string{"10.1.0.0/16"},
+				Allowed: []domain.ServiceRule{{Ports: []int{443}, Protocol: "TCP"}, {Service: "SSH"}},
+			},
+			{
+				Name:      "corporate",
+				CIDRs:     []string{"10.0.0.0/8"},
+				Forbidden: []domain.ServiceRule{{Service: "telnet", Reason: "PCI DSS 2.2.7"}, {Ports: []int{21}}},
+			},
+		},
+	}
+}
+
+func TestTemplateCheck(t *testing.T) {
+	template := pciTemplate()
+	require.NoError(t, template.Validate())
+
+	result := &scandomain.ScanResult{ID: "result-1", ScanID: "scan-1", Hosts: []scandomain.Host{
+		{IP: "10.1.0.5", Ports: []scandomain.Port{
+			{Port: 443, Protocol: "tcp", State: "open", Service: "https"},
+			{Port: 22, Protocol: "tcp", State: "open", Service: "ssh"},
+			{Port: 3306, Protocol: "tcp", State: "open", Service: "mysql"},
+			{Port: 23, Protocol: "tcp", State: "filtered", Service: "telnet"},
+		}},
+		{IP: "10.2.0.7", Ports: []scandomain.Port{
+			{Port: 23, Protocol: "tcp", State: "open", Service: "telnet"},
+			{Port: 80, Protocol: "tcp", State: "open", Service: "http"},
+		}},
+		{IP: "192.168.1.1", Ports: []scandomain.Port{{Port: 23, Protocol: "tcp", State: "open", Service: "telnet"}}},
+	}}
+
+	report := template.Check(result)
+	assert.Equal(t, domain.CheckStatusFail, report.Status)
+	assert.Equal(t, 2, report.HostsChecked)
+	assert.Equal(t, 1, report.UnscopedHosts)
+	assert.Equal(t, 2, report.Failed)
+
+	// The CDE host is checked in both segments it belongs to
+	assert.Equal(t, 6, report.Passed)
+	require.Len(t, report.Findings, 8)
+	assert.Equal(t, domain.Finding{Status: domain.CheckStatusFail, Segment: "cde", Host: "10.1.0.5", Port: 3306, Protocol: "tcp", Service: "mysql",
+		Reason: "service not allowed in segment cde"}, report.Findings[0])
+	assert.Equal(t, domain.Finding{Status: domain.CheckStatusFail, Segment: "corporate", Host: "10.2.0.7", Port: 23, Protocol: "tcp", Service: "telnet",
+		Reason: "PCI DSS 2.2.7"}, report.Findings[1])
+
+	// A result without violations passes
+	report = template.Check(&scandomain.ScanResult{Hosts: []scandomain.Host{
+		{IP: "10.1.0.5", Ports: []scandomain.Port{{Port: 443, Protocol: "tcp", State: "open", Service: "https"}}},
+	}})
+	assert.Equal(t, domain.CheckStatusPass, report.Status)
+}
+
+func TestTemplateValidate(t *testing.T) {
+	template := pciTemplate()
+	template.Segments[0].CIDRs = []string{"10.1.2.3/16", "10.9.9.9"}
+	require.NoError(t, template.Validate())
+	assert.Equal(t, []string{"10.1.0.0/16", "10.9.9.9/32"}, template.Segments[0].CIDRs)
+	assert.Equal(t, "tcp", template.Segments[0].Allowed[0].Protocol)
+
+	tests := []struct {
+		name   string
+		modify func(template *domain.Template)
+	}{
+		{"missing name", func(template *domain.Template) { template.Name = " " }},
+		{"no segments", func(template *domain.Template) { template.Segments = nil }},
+		{"invalid cidr", func(template *domain.Template) { template.Segments[0].CIDRs = []string{"10.1.0.0/33"} }},
+		{"no rules", func(template *domain.Template) { template.Segments[0].Allowed = nil }},
+		{"empty rule", func(template *domain.Template) { template.Segments[1].Forbidden[0] = domain.ServiceRule{Reason: "any"} }},
+		{"invalid port", func(template *domain.Template) { template.Segments[1].Forbidden[1].Ports = []int{70000} }},
+		{"invalid protocol", func(template *domain.Template) { template.Segments[0].Allowed[0].Protocol = "icmp" }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			template := pciTemplate()
+			test.modify(&template)
+
+			var validationErr *errors.Error
+			require.ErrorAs(t, template.Validate(), &validationErr)
+			assert.Equal(t, errors.ErrInvalidInput, validationErr.Type)
+		})
+	}
+}
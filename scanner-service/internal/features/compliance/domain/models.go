@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+)
+
+// CheckStatus represents the outcome of a compliance check
+type CheckStatus string
+
+// Check status constants
+const (
+	CheckStatusPass CheckStatus = "pass" // The exposed service is permitted
+	CheckStatusFail CheckStatus = "fail" // The exposed service violates the template
+)
+
+// Template represents a compliance check template: the services the hosts of each
+// network segment may or must not expose, e.g. the PCI DSS cardholder data environment
+type Template struct {
+	ID          string    `json:"id"`                    // Unique identifier
+	Name        string    `json:"name"`                  // Display name
+	Description string    `json:"description,omitempty"` // Purpose of the template
+	Segments    []Segment `json:"segments"`              // Network segments checked by the template
+	CreatedAt   time.Time `json:"created_at"`            // When the template was created
+	UpdatedAt   time.Time `json:"updated_at"`            // When the template was last changed
+	UpdatedBy   string    `json:"updated_by"`            // Admin who last changed the template
+}
+
+// Segment represents a network segment and the services its hosts may expose.
+// A service is a violation if it matches a forbidden rule, or if the segment has
+// allowed rules and it matches none of them.
+type Segment struct {
+	Name      string        `json:"name"`                // Display name, e.g. "cardholder data environment"
+	CIDRs     []string      `json:"cidrs"`               // Networks of the segment
+	Allowed   []ServiceRule `json:"allowed,omitempty"`   // Services the hosts may expose, empty to allow all not forbidden
+	Forbidden []ServiceRule `json:"forbidden,omitempty"` // Services the hosts must not expose
+}
+
+// ServiceRule matches open ports by port number, protocol and service name.
+// Empty fields match any value.
+type ServiceRule struct {
+	Ports    []int  `json:"ports,omitempty"`    // Port numbers
+	Protocol string `json:"protocol,omitempty"` // tcp, udp or sctp
+	Service  string `json:"service,omitempty"`  // Service name detected by nmap, e.g. "telnet"
+	Reason   string `json:"reason,omitempty"`   // Requirement behind the rule, reported with violations
+}
+
+// Finding represents the check of an open port of a host in a segment
+type Finding struct {
+	Status   CheckStatus `json:"status"`           // Whether the port passed the check
+	Segment  string      `json:"segment"`          // Segment the host belongs to
+	Host     string      `json:"host"`             // IP address of the host
+	Port     int         `json:"port"`             // Port number
+	Protocol string      `json:"protocol"`         // Protocol (tcp/udp)
+	Service  string      `json:"service"`          // Service name detected by nmap
+	Reason   string      `json:"reason,omitempty"` // Why the port failed the check
+}
+
+// Report represents the outcome of checking a scan result against a template
+type Report struct {
+	TemplateID    string      `json:"template_id"`    // Template the result was checked against
+	TemplateName  string      `json:"template_name"`  // Name of the template
+	ResultID      string      `json:"result_id"`      // Checked scan result
+	ScanID        string      `json:"scan_id"`        // Scan of the checked result
+	Status        CheckStatus `json:"status"`         // fail if any finding failed
+	Passed        int         `json:"passed"`         // Number of passed findings
+	Failed        int         `json:"failed"`         // Number of failed findings
+	HostsChecked  int         `json:"hosts_checked"`  // Hosts inside at least one segment
+	UnscopedHosts int         `json:"unscoped_hosts"` // Hosts outside every segment, which are not checked
+	Findings      []Finding   `json:"findings"`       // Failed findings first, then by host and port
+	CheckedAt     time.Time   `json:"checked_at"`     // When the check ran
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TemplateRepository defines the interface for compliance template storage
+type TemplateRepository interface {
+	SaveTemplate(template *Template) error
+	GetTemplate(id string) (*Template, error)
+	ListTemplates() ([]*Template, error)
+	DeleteTemplate(id string) error
+}
+
+// ResultProvider gets the scan results checked against templates, with the
+// permissions of the caller
+type ResultProvider interface {
+	GetScanResult(ctx context.Context, id string) (*scandomain.ScanResult, error)
+}
+
+// ComplianceService manages compliance templates and checks scan results against them
+type ComplianceService struct {
+	repository TemplateRepository
+	results    ResultProvider
+	logger     *logger.Logger
+}
+
+// NewComplianceService creates a new ComplianceService
+func NewComplianceService(repository TemplateRepository, results ResultProvider, logger *logger.Logger) *ComplianceService {
+	return &ComplianceService{
+		repository: repository,
+		results:    results,
+		logger:     logger,
+	}
+}
+
+// CreateTemplate stores a new template. The caller must be an admin.
+func (s *ComplianceService) CreateTemplate(ctx context.Context, template Template) (*Template, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template.ID = uuid.New().String()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+	template.UpdatedBy = principal.UserID
+
+	if err := s.repository.SaveTemplate(&template); err != nil {
+		return nil, errors.NewInternal("failed to save compliance template", err)
+	}
+
+	s.logger.Info("Compliance template created",
+		zap.String("template_id", template.ID),
+		zap.String("name", template.Name),
+		zap.Int("segment_count", len(template.Segments)),
+		zap.String("created_by", principal.UserID),
+	)
+
+	return &template, nil
+}
+
+// UpdateTemplate replaces the name, description and segments of a template.
+// The caller must be an admin.
+func (s *ComplianceService) UpdateTemplate(ctx context.Context, id string, template Template) (*Template, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repository.GetTemplate(id)
+	if err != nil {
+		return nil, errors.NewNotFound("compliance template not found", err)
+	}
+
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	template.ID = existing.ID
+	template.CreatedAt = existing.CreatedAt
+	template.UpdatedAt = time.Now()
+	template.UpdatedBy = principal.UserID
+
+	if err := s.repository.SaveTemplate(&template); err != nil {
+		return nil, errors.NewInternal("failed to save compliance template", err)
+	}
+
+	s.logger.Info("Compliance template updated",
+		zap.String("template_id", id),
+		zap.String("updated_by", principal.UserID),
+	)
+
+	return &template, nil
+}
+
+// GetTemplate gets a template. The caller must have the viewer role.
+func (s *ComplianceService) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	template, err := s.repository.GetTemplate(id)
+	if err != nil {
+		return nil, errors.NewNotFound("compliance template not found", err)
+	}
+
+	return template, nil
+}
+
+// ListTemplates lists all templates. The caller must have the viewer role.
+func (s *ComplianceService) ListTemplates(ctx context.Context) ([]*Template, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.repository.ListTemplates()
+	if err != nil {
+		return nil, errors.NewInternal("failed to list compliance templates", err)
+	}
+
+	return templates, nil
+}
+
+// DeleteTemplate deletes a template. The caller must be an admin.
+func (s *ComplianceService) DeleteTemplate(ctx context.Context, id string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteTemplate(id); err != nil {
+		return errors.NewNotFound("compliance template not found", err)
+	}
+
+	s.logger.Info("Compliance template deleted",
+		zap.String("template_id", id),
+		zap.String("deleted_by", principal.UserID),
+	)
+
+	return nil
+}
+
+// CheckResult checks a scan result against a template. The caller must have the
+// viewer role and access to the result.
+func (s *ComplianceService) CheckResult(ctx context.Context, templateID, resultID string) (*Report, error) {
+	template, err := s.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.results.GetScanResult(ctx, resultID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := template.Check(result)
+
+	s.logger.Info("Compliance check completed",
+		zap.String("template_id", templateID),
+		zap.String("result_id", resultID),
+		zap.String("status", string(report.Status)),
+		zap.Int("failed", report.Failed),
+	)
+
+	return report, nil
+}
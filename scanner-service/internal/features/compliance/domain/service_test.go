@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/compliance/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/compliance/repository"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// resultStore provides the results of alice to alice
+type resultStore map[string]*scandomain.ScanResult
+
+func (s resultStore) GetScanResult(ctx context.Context, id string) (*scandomain.ScanResult, error) {
+	principal, _ := authdomain.PrincipalFromContext(ctx)
+	result, ok := s[id]
+	if !ok || !principal.CanAccess(result.UserID) {
+		return nil, errors.NewNotFound("scan result not found", nil)
+	}
+	return result, nil
+}
+
+func principalContext(userID string, role authdomain.Role) context.Context {
+	return authdomain.WithPrincipal(context.Background(), &authdomain.Principal{
+		UserID: userID,
+		Roles:  []authdomain.Role{role},
+	})
+}
+
+func TestCheckResult(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	results := resultStore{"result-1": {ID: "result-1", UserID: "alice", Hosts: []scandomain.Host{
+		{IP: "10.2.0.7", Ports: []scandomain.Port{{Port: 21, Protocol: "tcp", State: "open", Service: "ftp"}}},
+	}}}
+	service := domain.NewComplianceService(repository.NewMemoryTemplateRepository(log), results, log)
+	admin := principalContext("root", authdomain.RoleAdmin)
+	alice := principalContext("alice", authdomain.RoleViewer)
+
+	// Only admins manage templates
+	_, err := service.CreateTemplate(alice, pciTemplate())
+	var serviceErr *errors.Error
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, errors.ErrForbidden, serviceErr.Type)
+
+	template, err := service.CreateTemplate(admin, pciTemplate())
+	require.NoError(t, err)
+	assert.NotEmpty(t, template.ID)
+	assert.Equal(t, "root", template.UpdatedBy)
+
+	report, err := service.CheckResult(alice, template.ID, "result-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CheckStatusFail, report.Status)
+	assert.Equal(t, template.ID, report.TemplateID)
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, "forbidden service: port 21", report.Findings[0].Reason)
+
+	// Results of other users are not found
+	_, err = service.CheckResult(principalContext("bob", authdomain.RoleViewer), template.ID, "result-1")
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, errors.ErrNotFound, serviceErr.Type)
+
+	_, err = service.CheckResult(alice, "missing", "result-1")
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, errors.ErrNotFound, serviceErr.Type)
+
+	// Updating keeps the identity of the template
+	update := pciTemplate()
+	update.Name = "PCI scope 2024"
+	updated, err := service.UpdateTemplate(admin, template.ID, update)
+	require.NoError(t, err)
+	assert.Equal(t, template.ID, updated.ID)
+	assert.Equal(t, template.CreatedAt, updated.CreatedAt)
+
+	require.NoError(t, service.DeleteTemplate(admin, template.ID))
+	templates, err := service.ListTemplates(alice)
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/compliance/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ComplianceHandler handles HTTP requests for compliance templates and checks
+type ComplianceHandler struct {
+	complianceService *domain.ComplianceService
+	logger            *logger.Logger
+}
+
+// NewComplianceHandler creates a new ComplianceHandler
+func NewComplianceHandler(complianceService *domain.ComplianceService, logger *logger.Logger) *ComplianceHandler {
+	return &ComplianceHandler{
+		complianceService: complianceService,
+		logger:            logger,
+	}
+}
+
+// TemplateRequest represents the request body for creating or replacing a template
+type TemplateRequest struct {
+	Name        string           `json:"name" binding:"required"`
+	Description string           `json:"description"`
+	Segments    []domain.Segment `json:"segments" binding:"required"`
+}
+
+// CheckRequest represents the request body for checking a scan result against a template
+type CheckRequest struct {
+	ResultID string `json:"result_id" binding:"required"`
+}
+
+// template returns the template described by the request
+func (r TemplateRequest) template() domain.Template {
+	return domain.Template{Name: r.Name, Description: r.Description, Segments: r.Segments}
+}
+
+// ListTemplates handles the request to list all templates
+func (h *ComplianceHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.complianceService.ListTemplates(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"templates": templates,
+		"count":     len(templates),
+	})
+}
+
+// GetTemplate handles the request to get a template
+func (h *ComplianceHandler) GetTemplate(c *gin.Context) {
+	template, err := h.complianceService.GetTemplate(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// CreateTemplate handles the request to create a template
+func (h *ComplianceHandler) CreateTemplate(c *gin.Context) {
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	template, err := h.complianceService.CreateTemplate(c.Request.Context(), req.template())
+	if err != nil {
+		h.logger.Error("Failed to create compliance template", zap.Error(err))
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateTemplate handles the request to replace a template
+func (h *ComplianceHandler) UpdateTemplate(c *gin.Context) {
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	template, err := h.complianceService.UpdateTemplate(c.Request.Context(), c.Param("id"), req.template())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate handles the request to delete a template
+func (h *ComplianceHandler) DeleteTemplate(c *gin.Context) {
+	if err := h.complianceService.DeleteTemplate(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Compliance template deleted",
+	})
+}
+
+// CheckResult handles the request to check a scan result against a template
+func (h *ComplianceHandler) CheckResult(c *gin.Context) {
+	var req CheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	report, err := h.complianceService.CheckResult(c.Request.Context(), c.Param("id"), req.ResultID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// RegisterRoutes registers the compliance handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *ComplianceHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1/compliance", middleware...)
+	viewer := authhandlers.RequireRole(authdomain.RoleViewer)
+	admin := authhandlers.RequireRole(authdomain.RoleAdmin)
+
+	// Template endpoints
+	api.GET("/templates", viewer, h.ListTemplates)
+	api.POST("/templates", admin, h.CreateTemplate)
+	api.GET("/templates/:id", viewer, h.GetTemplate)
+	api.PUT("/templates/:id", admin, h.UpdateTemplate)
+	api.DELETE("/templates/:id", admin, h.DeleteTemplate)
+
+	// Check endpoints
+	api.POST("/templates/:id/check", viewer, h.CheckResult)
+}
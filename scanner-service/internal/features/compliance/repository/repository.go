@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/compliance/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MemoryTemplateRepository is an in-memory implementation of the TemplateRepository interface
+type MemoryTemplateRepository struct {
+	logger    *logger.Logger
+	templates map[string]*domain.Template
+	mu        sync.RWMutex
+}
+
+// NewMemoryTemplateRepository creates a new MemoryTemplateRepository
+func NewMemoryTemplateRepository(logger *logger.Logger) *MemoryTemplateRepository {
+	return &MemoryTemplateRepository{
+		logger:    logger,
+		templates: make(map[string]*domain.Template),
+	}
+}
+
+// SaveTemplate creates or replaces a template in the repository
+func (r *MemoryTemplateRepository) SaveTemplate(template *domain.Template) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.templates[template.ID] = copyTemplate(template)
+
+	r.logger.Debug("Saved compliance template", zap.String("template_id", template.ID))
+
+	return nil
+}
+
+// GetTemplate gets a template by ID from the repository
+func (r *MemoryTemplateRepository) GetTemplate(id string) (*domain.Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	template, ok := r.templates[id]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("compliance template with ID %s not found", id), nil)
+	}
+
+	return copyTemplate(template), nil
+}
+
+// ListTemplates lists all templates from the repository by name
+func (r *MemoryTemplateRepository) ListTemplates() ([]*domain.Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	templates := make([]*domain.Template, 0, len(r.templates))
+	for _, template := range r.templates {
+		templates = append(templates, copyTemplate(template))
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].ID < templates[j].ID
+	})
+
+	return templates, nil
+}
+
+// DeleteTemplate deletes a template from the repository
+func (r *MemoryTemplateRepository) DeleteTemplate(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.templates[id]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("compliance template with ID %s not found", id), nil)
+	}
+
+	delete(r.templates, id)
+
+	r.logger.Debug("Deleted compliance template", zap.String("template_id", id))
+
+	return nil
+}
+
+// copyTemplate returns a deep copy of a template
+func copyTemplate(template *domain.Template) *domain.Template {
+	templateCopy := *template
+	templateCopy.Segments = make([]domain.Segment, len(template.Segments))
+	for i, segment := range template.Segments {
+		segment.CIDRs = append([]string(nil), segment.CIDRs...)
+		segment.Allowed = copyRules(segment.Allowed)
+		segment.Forbidden = copyRules(segment.Forbidden)
+		templateCopy.Segments[i] = segment
+	}
+	return &templateCopy
+}
+
+// copyRules returns a deep copy of service rules
+func copyRules(rules []domain.ServiceRule) []domain.ServiceRule {
+	if rules == nil {
+		return nil
+	}
+	rulesCopy := make([]domain.ServiceRule, len(rules))
+	for i, rule := range rules {
+		rule.Ports = append([]int(nil), rule.Ports...)
+		rulesCopy[i] = rule
+	}
+	return rulesCopy
+}
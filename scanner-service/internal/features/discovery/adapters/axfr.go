@@ -0,0 +1,142 @@
+package adapters
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// zoneTransfer requests the zone of the domain from its authoritative name servers.
+// Most servers refuse transfers, so the first server allowing one is enough.
+func (d *DNSDiscoverer) zoneTransfer(ctx context.Context, found *candidates) error {
+	servers, err := d.resolver.LookupNS(ctx, found.domain)
+	if err != nil {
+		return fmt.Errorf("name server lookup failed: %w", err)
+	}
+	if len(servers) == 0 {
+		return fmt.Errorf("domain has no name servers")
+	}
+
+	var errs []error
+	for _, server := range servers {
+		host := strings.TrimSuffix(server.Host, ".")
+		if err := d.transfer(ctx, host, found); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", host, err))
+			continue
+		}
+		return nil
+	}
+
+	return errors.Join(errs...)
+}
+
+// transfer performs an AXFR over TCP against a single name server
+func (d *DNSDiscoverer) transfer(ctx context.Context, server string, found *candidates) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(server, d.axfrPort))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	name, err := dnsmessage.NewName(found.domain + ".")
+	if err != nil {
+		return err
+	}
+	query := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: uint16(rand.Uint32())},
+		Questions: []dnsmessage.Question{
+			{Name: name, Type: dnsmessage.TypeAXFR, Class: dnsmessage.ClassINET},
+		},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return err
+	}
+
+	// DNS over TCP prefixes every message with its length
+	request := binary.BigEndian.AppendUint16(nil, uint16(len(packed)))
+	if _, err := conn.Write(append(request, packed...)); err != nil {
+		return err
+	}
+
+	// The zone is framed by its SOA record at the start and the end of the transfer
+	soaRecords := 0
+	for soaRecords < 2 {
+		var length [2]byte
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return fmt.Errorf("transfer interrupted: %w", err)
+		}
+		data := make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, data); err != nil {
+			return fmt.Errorf("transfer interrupted: %w", err)
+		}
+
+		var parser dnsmessage.Parser
+		header, err := parser.Start(data)
+		if err != nil {
+			return err
+		}
+		if header.RCode != dnsmessage.RCodeSuccess {
+			return fmt.Errorf("transfer refused (%s)", header.RCode)
+		}
+		if err := parser.SkipAllQuestions(); err != nil {
+			return err
+		}
+
+		answers := 0
+		for {
+			answer, err := parser.AnswerHeader()
+			if err == dnsmessage.ErrSectionDone {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			answers++
+
+			owner := answer.Name.String()
+			switch answer.Type {
+			case dnsmessage.TypeSOA:
+				soaRecords++
+				err = parser.SkipAnswer()
+			case dnsmessage.TypeA:
+				var record dnsmessage.AResource
+				if record, err = parser.AResource(); err == nil {
+					found.add(owner, domain.DiscoveryZoneTransfer, net.IP(record.A[:]).String())
+				}
+			case dnsmessage.TypeAAAA:
+				var record dnsmessage.AAAAResource
+				if record, err = parser.AAAAResource(); err == nil {
+					found.add(owner, domain.DiscoveryZoneTransfer, net.IP(record.AAAA[:]).String())
+				}
+			case dnsmessage.TypeCNAME:
+				found.add(owner, domain.DiscoveryZoneTransfer)
+				err = parser.SkipAnswer()
+			default:
+				err = parser.SkipAnswer()
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if answers == 0 {
+			return fmt.Errorf("transfer refused (empty response)")
+		}
+	}
+
+	return nil
+}
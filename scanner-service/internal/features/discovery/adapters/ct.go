@@ -0,0 +1,72 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ctEntry is the subset of a crt.sh search result used here
+type ctEntry struct {
+	NameValue string `json:"name_value"` // Newline-separated names of the certificate
+}
+
+// ctClient searches Certificate Transparency logs through a crt.sh compatible service
+type ctClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// newCTClient creates a new ctClient
+func newCTClient(baseURL string, timeout time.Duration) *ctClient {
+	return &ctClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// search returns the names of the certificates logged for the domain and its subdomains
+func (c *ctClient) search(ctx context.Context, domainName string) ([]string, error) {
+	query := url.Values{}
+	query.Set("q", "%."+domainName)
+	query.Set("output", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("CT log search failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CT log search returned status %d", resp.StatusCode)
+	}
+
+	var entries []ctEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid CT log response: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, entry := range entries {
+		for _, name := range strings.Split(entry.NameValue, "\n") {
+			name = strings.TrimSpace(name)
+			if name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,303 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultWordlist contains the subdomain names tried by bruteforce discovery
+// when no wordlist is configured
+var defaultWordlist = []string{
+	"www", "mail", "smtp", "pop", "imap", "webmail", "mx", "ns1", "ns2", "dns",
+	"vpn", "remote", "gateway", "fw", "proxy", "api", "app", "dev", "test", "staging",
+	"beta", "demo", "admin", "portal", "intranet", "extranet", "git", "gitlab", "jenkins", "ci",
+	"jira", "wiki", "docs", "cdn", "static", "assets", "img", "media", "shop", "blog",
+	"ftp", "sftp", "files", "backup", "db", "sql", "mysql", "ldap", "sso", "auth",
+	"login", "m", "mobile", "monitor", "grafana", "kibana", "status", "support", "crm", "erp",
+}
+
+// Resolver resolves host names and name servers
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// DNSDiscoveryConfig contains target discovery configuration
+type DNSDiscoveryConfig struct {
+	CTURL       string        // Certificate Transparency search service compatible with crt.sh
+	Timeout     time.Duration // Timeout of each discovery method
+	MaxHosts    int           // Maximum number of hosts a domain expands into
+	Wordlist    []string      // Subdomain names tried by bruteforce, defaults to a built-in list
+	Concurrency int           // Maximum number of concurrent DNS lookups
+}
+
+// DNSDiscoverer expands a domain into the hosts found by zone transfer,
+// subdomain bruteforce and Certificate Transparency logs
+type DNSDiscoverer struct {
+	config   DNSDiscoveryConfig
+	resolver Resolver
+	ct       *ctClient
+	axfrPort string
+	logger   *logger.Logger
+}
+
+// NewDNSDiscoverer creates a new DNSDiscoverer.
+// A nil resolver uses the system resolver.
+func NewDNSDiscoverer(config DNSDiscoveryConfig, resolver Resolver, logger *logger.Logger) *DNSDiscoverer {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	if len(config.Wordlist) == 0 {
+		config.Wordlist = defaultWordlist
+	}
+	if config.Concurrency < 1 {
+		config.Concurrency = 1
+	}
+
+	return &DNSDiscoverer{
+		config:   config,
+		resolver: resolver,
+		ct:       newCTClient(config.CTURL, config.Timeout),
+		axfrPort: "53",
+		logger:   logger,
+	}
+}
+
+// candidates collects discovered names with their sources and known addresses
+type candidates struct {
+	domain string
+	hosts  map[string]*domain.DiscoveredHost
+	mu     sync.Mutex
+}
+
+// add records a name found by a method, ignoring names outside the domain
+func (c *candidates) add(name string, method domain.DiscoveryMethod, addresses ...string) {
+	name = strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(name, "*."), "."))
+	if name != c.domain && !strings.HasSuffix(name, "."+c.domain) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	host, ok := c.hosts[name]
+	if !ok {
+		host = &domain.DiscoveredHost{Name: name}
+		c.hosts[name] = host
+	}
+	if method != "" && !containsMethod(host.Sources, method) {
+		host.Sources = append(host.Sources, method)
+	}
+	for _, address := range addresses {
+		if !slices.Contains(host.Addresses, address) {
+			host.Addresses = append(host.Addresses, address)
+		}
+	}
+}
+
+// Discover runs the given methods against the domain and resolves the names found.
+// Failures of single methods are reported in the result; only cancellation fails discovery.
+func (d *DNSDiscoverer) Discover(ctx context.Context, domainName string, methods []domain.DiscoveryMethod) (*domain.DiscoveryResult, error) {
+	domainName = strings.ToLower(strings.TrimSuffix(domainName, "."))
+	found := &candidates{domain: domainName, hosts: make(map[string]*domain.DiscoveredHost)}
+	result := &domain.DiscoveryResult{Domain: domainName}
+
+	// The domain itself is scanned when it resolves
+	found.add(domainName, "")
+
+	for _, method := range methods {
+		methodCtx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+		var err error
+		switch method {
+		case domain.DiscoveryZoneTransfer:
+			err = d.zoneTransfer(methodCtx, found)
+		case domain.DiscoveryBruteforce:
+			err = d.bruteforce(methodCtx, found)
+		case domain.DiscoveryCT:
+			err = d.certificateTransparency(methodCtx, found)
+		default:
+			err = fmt.Errorf("unsupported method")
+		}
+		cancel()
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			d.logger.WithContext(ctx).Warn("Discovery method failed",
+				zap.String("domain", domainName),
+				zap.String("method", string(method)),
+				zap.Error(err),
+			)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", method, err))
+		}
+	}
+
+	resolveCtx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+	d.resolveAll(resolveCtx, found)
+	cancel()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	names := make([]string, 0, len(found.hosts))
+	for name, host := range found.hosts {
+		if len(host.Addresses) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if d.config.MaxHosts > 0 && len(result.Hosts) >= d.config.MaxHosts {
+			result.Errors = append(result.Errors, fmt.Sprintf("host limit of %d reached, %d hosts left out",
+				d.config.MaxHosts, len(names)-len(result.Hosts)))
+			break
+		}
+		result.Hosts = append(result.Hosts, *found.hosts[name])
+	}
+
+	return result, nil
+}
+
+// bruteforce resolves the wordlist names below the domain.
+// Names resolving only to the addresses of a wildcard record are ignored.
+func (d *DNSDiscoverer) bruteforce(ctx context.Context, found *candidates) error {
+	wildcard := make(map[string]bool)
+	probe := "nmap-ui-" + strings.ReplaceAll(uuid.New().String(), "-", "")[:12] + "." + found.domain
+	if addresses, err := d.lookup(ctx, probe); err == nil {
+		for _, address := range addresses {
+			wildcard[address] = true
+		}
+	}
+
+	d.forEach(ctx, d.config.Wordlist, func(word string) {
+		name := word + "." + found.domain
+		addresses, err := d.lookup(ctx, name)
+		if err != nil || len(addresses) == 0 {
+			return
+		}
+
+		for _, address := range addresses {
+			if !wildcard[address] {
+				found.add(name, domain.DiscoveryBruteforce, addresses...)
+				return
+			}
+		}
+	})
+
+	return ctx.Err()
+}
+
+// certificateTransparency adds the names of certificates logged for the domain
+func (d *DNSDiscoverer) certificateTransparency(ctx context.Context, found *candidates) error {
+	names, err := d.ct.search(ctx, found.domain)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		found.add(name, domain.DiscoveryCT)
+	}
+	return nil
+}
+
+// resolveAll resolves the names found without addresses
+func (d *DNSDiscoverer) resolveAll(ctx context.Context, found *candidates) {
+	var names []string
+	for name, host := range found.hosts {
+		if len(host.Addresses) == 0 {
+			names = append(names, name)
+		}
+	}
+
+	d.forEach(ctx, names, func(name string) {
+		addresses, err := d.lookup(ctx, name)
+		if err != nil {
+			return
+		}
+		found.add(name, "", addresses...)
+	})
+}
+
+// forEach calls fn for every item with bounded concurrency
+func (d *DNSDiscoverer) forEach(ctx context.Context, items []string, fn func(item string)) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, d.config.Concurrency)
+
+	for _, item := range items {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+
+		wg.Add(1)
+		go func(item string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fn(item)
+		}(item)
+	}
+
+	wg.Wait()
+}
+
+// lookup resolves a name to its IP addresses
+func (d *DNSDiscoverer) lookup(ctx context.Context, name string) ([]string, error) {
+	addrs, err := d.resolver.LookupIPAddr(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		addresses = append(addresses, addr.IP.String())
+	}
+	return addresses, nil
+}
+
+// containsMethod reports whether the slice contains the method
+func containsMethod(methods []domain.DiscoveryMethod, method domain.DiscoveryMethod) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadWordlist reads subdomain names from a file with one name per line.
+// Empty lines and lines starting with # are ignored.
+func LoadWordlist(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var words []string
+	for _, line := range strings.Split(string(data), "\n") {
+		word := strings.ToLower(strings.TrimSpace(line))
+		if word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("wordlist %s is empty", path)
+	}
+
+	return words, nil
+}
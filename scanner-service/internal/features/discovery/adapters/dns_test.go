@@ -0,0 +1,176 @@
+package adapters
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// fakeResolver resolves fixed names; every name server is the local host
+type fakeResolver struct {
+	addresses map[string][]string
+}
+
+func (f *fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	addresses, ok := f.addresses[host]
+	if !ok {
+		return nil, fmt.Errorf("no such host")
+	}
+
+	addrs := make([]net.IPAddr, 0, len(addresses))
+	for _, address := range addresses {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(address)})
+	}
+	return addrs, nil
+}
+
+func (f *fakeResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	return []*net.NS{{Host: "127.0.0.1."}}, nil
+}
+
+// serveZone answers a single AXFR request with a zone containing two hosts
+func serveZone(t *testing.T, listener net.Listener) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return
+	}
+	request := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return
+	}
+
+	var parser dnsmessage.Parser
+	header, err := parser.Start(request)
+	require.NoError(t, err)
+	question, err := parser.Question()
+	require.NoError(t, err)
+	assert.Equal(t, dnsmessage.TypeAXFR, question.Type)
+
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true})
+	builder.EnableCompression()
+	require.NoError(t, builder.StartQuestions())
+	require.NoError(t, builder.Question(question))
+	require.NoError(t, builder.StartAnswers())
+
+	soa := dnsmessage.SOAResource{
+		NS:   dnsmessage.MustNewName("ns1.example.com."),
+		MBox: dnsmessage.MustNewName("hostmaster.example.com."),
+	}
+	resource := func(name string, recordType dnsmessage.Type) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Type: recordType, Class: dnsmessage.ClassINET}
+	}
+	require.NoError(t, builder.SOAResource(resource("example.com.", dnsmessage.TypeSOA), soa))
+	require.NoError(t, builder.AResource(resource("vpn.example.com.", dnsmessage.TypeA), dnsmessage.AResource{A: [4]byte{203, 0, 113, 5}}))
+	require.NoError(t, builder.CNAMEResource(resource("intranet.example.com.", dnsmessage.TypeCNAME),
+		dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("vpn.example.com.")}))
+	require.NoError(t, builder.SOAResource(resource("example.com.", dnsmessage.TypeSOA), soa))
+
+	response, err := builder.Finish()
+	require.NoError(t, err)
+	conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(response))), response...))
+}
+
+func newTestDiscoverer(t *testing.T, ctURL string, resolver Resolver) *DNSDiscoverer {
+	zapLogger, _ := zap.NewDevelopment()
+	return NewDNSDiscoverer(DNSDiscoveryConfig{
+		CTURL:       ctURL,
+		Timeout:     5 * time.Second,
+		MaxHosts:    10,
+		Wordlist:    []string{"www", "mail", "dev"},
+		Concurrency: 2,
+	}, resolver, &logger.Logger{Logger: zapLogger})
+}
+
+func TestDiscover(t *testing.T) {
+	ct := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "%.example.com", r.URL.Query().Get("q"))
+		w.Write([]byte(`[
+			{"name_value": "example.com\nwww.example.com"},
+			{"name_value": "*.api.example.com"},
+			{"name_value": "example.org"}
+		]`))
+	}))
+	defer ct.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go serveZone(t, listener)
+
+	resolver := &fakeResolver{addresses: map[string][]string{
+		"example.com":          {"203.0.113.1"},
+		"www.example.com":      {"203.0.113.2"},
+		"mail.example.com":     {"203.0.113.3"},
+		"api.example.com":      {"203.0.113.4"},
+		"intranet.example.com": {"203.0.113.5"},
+		"example.org":          {"198.51.100.1"},
+	}}
+	discoverer := newTestDiscoverer(t, ct.URL, resolver)
+	_, discoverer.axfrPort, _ = net.SplitHostPort(listener.Addr().String())
+
+	result, err := discoverer.Discover(context.Background(), "Example.com.", []domain.DiscoveryMethod{
+		domain.DiscoveryZoneTransfer, domain.DiscoveryBruteforce, domain.DiscoveryCT,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, "example.com", result.Domain)
+
+	assert.Equal(t, []domain.DiscoveredHost{
+		{Name: "api.example.com", Addresses: []string{"203.0.113.4"}, Sources: []domain.DiscoveryMethod{domain.DiscoveryCT}},
+		{Name: "example.com", Addresses: []string{"203.0.113.1"}, Sources: []domain.DiscoveryMethod{domain.DiscoveryCT}},
+		{Name: "intranet.example.com", Addresses: []string{"203.0.113.5"}, Sources: []domain.DiscoveryMethod{domain.DiscoveryZoneTransfer}},
+		{Name: "mail.example.com", Addresses: []string{"203.0.113.3"}, Sources: []domain.DiscoveryMethod{domain.DiscoveryBruteforce}},
+		{Name: "vpn.example.com", Addresses: []string{"203.0.113.5"}, Sources: []domain.DiscoveryMethod{domain.DiscoveryZoneTransfer}},
+		{Name: "www.example.com", Addresses: []string{"203.0.113.2"}, Sources: []domain.DiscoveryMethod{domain.DiscoveryBruteforce, domain.DiscoveryCT}},
+	}, result.Hosts)
+}
+
+func TestDiscoverReportsFailures(t *testing.T) {
+	ct := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ct.Close()
+
+	// Every name resolves, so bruteforce hits are indistinguishable from a wildcard record
+	resolver := &fakeResolver{addresses: map[string][]string{}}
+	discoverer := newTestDiscoverer(t, ct.URL, resolver)
+	discoverer.resolver = wildcardResolver{resolver}
+
+	result, err := discoverer.Discover(context.Background(), "example.com", []domain.DiscoveryMethod{
+		domain.DiscoveryBruteforce, domain.DiscoveryCT,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0], "ct: CT log search returned status 502")
+
+	require.Len(t, result.Hosts, 1)
+	assert.Equal(t, "example.com", result.Hosts[0].Name)
+}
+
+// wildcardResolver resolves every name to the same address
+type wildcardResolver struct {
+	*fakeResolver
+}
+
+func (w wildcardResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return []net.IPAddr{{IP: net.ParseIP("203.0.113.99")}}, nil
+}
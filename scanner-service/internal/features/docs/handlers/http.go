@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	apiv1 "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/api/http/v1"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIPage renders Swagger UI for the specification served next to it
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Scanner Service API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// DocsHandler serves the OpenAPI specification and Swagger UI
+type DocsHandler struct {
+	specJSON []byte
+	logger   *logger.Logger
+}
+
+// NewDocsHandler creates a new DocsHandler
+func NewDocsHandler(logger *logger.Logger) (*DocsHandler, error) {
+	specJSON, err := apiv1.SpecJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DocsHandler{
+		specJSON: specJSON,
+		logger:   logger,
+	}, nil
+}
+
+// GetSpecJSON handles the request to get the OpenAPI specification in JSON format
+func (h *DocsHandler) GetSpecJSON(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", h.specJSON)
+}
+
+// GetSpecYAML handles the request to get the OpenAPI specification in YAML format
+func (h *DocsHandler) GetSpecYAML(c *gin.Context) {
+	c.Data(http.StatusOK, "application/yaml", apiv1.SpecYAML())
+}
+
+// swaggerUIPolicy is the Content-Security-Policy of the Swagger UI page, allowing the
+// assets of swagger-ui-dist and requests to the specification and API
+const swaggerUIPolicy = "default-src 'none'; script-src https://unpkg.com 'unsafe-inline'; " +
+	"style-src https://unpkg.com 'unsafe-inline'; img-src 'self' data: https://unpkg.com; " +
+	"connect-src 'self'; frame-ancestors 'none'"
+
+// GetSwaggerUI handles the request to get the Swagger UI page
+func (h *DocsHandler) GetSwaggerUI(c *gin.Context) {
+	c.Header("Content-Security-Policy", swaggerUIPolicy)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
+
+// RegisterRoutes registers the documentation routes to the router.
+// The routes are public; the given middleware should not require authentication.
+func (h *DocsHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1", middleware...)
+
+	api.GET("/openapi.json", h.GetSpecJSON)
+	api.GET("/openapi.yaml", h.GetSpecYAML)
+	api.GET("/docs", h.GetSwaggerUI)
+}
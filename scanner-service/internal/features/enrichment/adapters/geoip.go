@@ -0,0 +1,166 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/oschwald/geoip2-golang"
+	"go.uber.org/zap"
+)
+
+// geoLookup fills in the fields of a GeoInfo it has data for
+type geoLookup func(ip net.IP, geo *domain.GeoInfo) error
+
+// GeoIPConfig contains the MaxMind database paths of the GeoIP enricher
+type GeoIPConfig struct {
+	CityDBPath string // GeoLite2/GeoIP2 City or Country database
+	ASNDBPath  string // GeoLite2 ASN or GeoIP2 ISP database
+}
+
+// GeoIPEnricher attaches country, city and autonomous system data from MaxMind databases
+// to the public hosts of scan results
+type GeoIPEnricher struct {
+	readers []*geoip2.Reader
+	lookups []geoLookup
+	logger  *logger.Logger
+}
+
+// NewGeoIPEnricher opens the configured MaxMind databases.
+// At least one database path must be set.
+func NewGeoIPEnricher(config GeoIPConfig, logger *logger.Logger) (*GeoIPEnricher, error) {
+	e := &GeoIPEnricher{logger: logger}
+
+	if config.CityDBPath != "" {
+		reader, err := geoip2.Open(config.CityDBPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open GeoIP database %s: %w", config.CityDBPath, err)
+		}
+		e.readers = append(e.readers, reader)
+		e.lookups = append(e.lookups, locationLookup(reader))
+	}
+
+	if config.ASNDBPath != "" {
+		reader, err := geoip2.Open(config.ASNDBPath)
+		if err != nil {
+			e.Close()
+			return nil, fmt.Errorf("failed to open GeoIP database %s: %w", config.ASNDBPath, err)
+		}
+		e.readers = append(e.readers, reader)
+		e.lookups = append(e.lookups, networkLookup(reader))
+	}
+
+	if len(e.lookups) == 0 {
+		return nil, fmt.Errorf("no GeoIP database configured")
+	}
+
+	for _, reader := range e.readers {
+		logger.Info("GeoIP database loaded",
+			zap.String("type", reader.Metadata().DatabaseType),
+			zap.Uint("build_epoch", reader.Metadata().BuildEpoch),
+		)
+	}
+
+	return e, nil
+}
+
+// Name returns the name of the enricher
+func (e *GeoIPEnricher) Name() string {
+	return "geoip"
+}
+
+// Enrich sets the Geo field of the public hosts of the result.
+// Private, loopback and other non-routable addresses are skipped.
+func (e *GeoIPEnricher) Enrich(ctx context.Context, result *domain.ScanResult) error {
+	for i := range result.Hosts {
+		host := &result.Hosts[i]
+
+		ip := net.ParseIP(host.IP)
+		if ip == nil || !isPublicIP(ip) {
+			continue
+		}
+
+		geo := &domain.GeoInfo{}
+		for _, lookup := range e.lookups {
+			if err := lookup(ip, geo); err != nil {
+				return fmt.Errorf("lookup of %s failed: %w", host.IP, err)
+			}
+		}
+
+		if *geo != (domain.GeoInfo{}) {
+			host.Geo = geo
+		}
+	}
+
+	return nil
+}
+
+// Close closes the MaxMind databases
+func (e *GeoIPEnricher) Close() {
+	for _, reader := range e.readers {
+		reader.Close()
+	}
+}
+
+// locationLookup returns a lookup of country and city data, supporting City and Country databases
+func locationLookup(reader *geoip2.Reader) geoLookup {
+	if strings.Contains(reader.Metadata().DatabaseType, "City") {
+		return func(ip net.IP, geo *domain.GeoInfo) error {
+			record, err := reader.City(ip)
+			if err != nil {
+				return err
+			}
+			geo.CountryCode = record.Country.IsoCode
+			geo.Country = record.Country.Names["en"]
+			geo.City = record.City.Names["en"]
+			geo.Latitude = record.Location.Latitude
+			geo.Longitude = record.Location.Longitude
+			return nil
+		}
+	}
+
+	return func(ip net.IP, geo *domain.GeoInfo) error {
+		record, err := reader.Country(ip)
+		if err != nil {
+			return err
+		}
+		geo.CountryCode = record.Country.IsoCode
+		geo.Country = record.Country.Names["en"]
+		return nil
+	}
+}
+
+// networkLookup returns a lookup of autonomous system data, supporting ASN and ISP databases
+func networkLookup(reader *geoip2.Reader) geoLookup {
+	if strings.Contains(reader.Metadata().DatabaseType, "ISP") {
+		return func(ip net.IP, geo *domain.GeoInfo) error {
+			record, err := reader.ISP(ip)
+			if err != nil {
+				return err
+			}
+			geo.ASN = record.AutonomousSystemNumber
+			geo.ASOrganization = record.AutonomousSystemOrganization
+			geo.ISP = record.ISP
+			return nil
+		}
+	}
+
+	return func(ip net.IP, geo *domain.GeoInfo) error {
+		record, err := reader.ASN(ip)
+		if err != nil {
+			return err
+		}
+		geo.ASN = record.AutonomousSystemNumber
+		geo.ASOrganization = record.AutonomousSystemOrganization
+		return nil
+	}
+}
+
+// isPublicIP reports whether the address is globally routable
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsUnspecified() && !ip.IsMulticast() && !ip.IsInterfaceLocalMulticast()
+}
@@ -0,0 +1,42 @@
+package adapters
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoIPEnricher(t *testing.T) {
+	var looked []string
+	enricher := &GeoIPEnricher{
+		lookups: []geoLookup{
+			func(ip net.IP, geo *domain.GeoInfo) error {
+				looked = append(looked, ip.String())
+				geo.CountryCode = "US"
+				return nil
+			},
+			func(ip net.IP, geo *domain.GeoInfo) error {
+				geo.ASN = 15169
+				return nil
+			},
+		},
+	}
+
+	result := &domain.ScanResult{Hosts: []domain.Host{
+		{IP: "8.8.8.8"},
+		{IP: "10.0.0.1"},
+		{IP: "127.0.0.1"},
+		{IP: "fe80::1"},
+	}}
+
+	require.NoError(t, enricher.Enrich(context.Background(), result))
+
+	// Only public addresses are looked up
+	assert.Equal(t, []string{"8.8.8.8"}, looked)
+	assert.Equal(t, &domain.GeoInfo{CountryCode: "US", ASN: 15169}, result.Hosts[0].Geo)
+	assert.Nil(t, result.Hosts[1].Geo)
+}
@@ -0,0 +1,292 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// RDAPConfig contains RDAP client configuration
+type RDAPConfig struct {
+	BaseURL  string        // RDAP service, e.g. https://rdap.org which redirects to the responsible registry
+	Timeout  time.Duration // Timeout of a single lookup
+	CacheTTL time.Duration // How long netblock records are cached
+}
+
+// rdapNetwork is the subset of an RDAP IP network object used here
+type rdapNetwork struct {
+	Handle       string       `json:"handle"`
+	Name         string       `json:"name"`
+	StartAddress string       `json:"startAddress"`
+	EndAddress   string       `json:"endAddress"`
+	Country      string       `json:"country"`
+	Entities     []rdapEntity `json:"entities"`
+	Links        []struct {
+		Rel  string `json:"rel"`
+		Href string `json:"href"`
+	} `json:"links"`
+}
+
+// rdapEntity is the subset of an RDAP entity object used here
+type rdapEntity struct {
+	Roles      []string          `json:"roles"`
+	VCardArray []json.RawMessage `json:"vcardArray"`
+	Entities   []rdapEntity      `json:"entities"`
+}
+
+// cachedNetwork is a netblock record with its address range
+type cachedNetwork struct {
+	owner     *domain.NetworkOwner
+	start     net.IP
+	end       net.IP
+	expiresAt time.Time
+}
+
+// RDAPClient looks up the registered owners of IP addresses over RDAP.
+// Records are cached per netblock, so hosts of the same network cause a single lookup.
+type RDAPClient struct {
+	baseURL    string
+	httpClient *http.Client
+	cacheTTL   time.Duration
+	cache      []cachedNetwork
+	mu         sync.Mutex
+	logger     *logger.Logger
+}
+
+// NewRDAPClient creates a new RDAPClient
+func NewRDAPClient(config RDAPConfig, logger *logger.Logger) *RDAPClient {
+	return &RDAPClient{
+		baseURL:    strings.TrimSuffix(config.BaseURL, "/"),
+		httpClient: &http.Client{Timeout: config.Timeout},
+		cacheTTL:   config.CacheTTL,
+		logger:     logger,
+	}
+}
+
+// IsPublicIP reports whether the address is globally routable and can be looked up
+func IsPublicIP(ip net.IP) bool {
+	return isPublicIP(ip)
+}
+
+// Lookup returns the registered owner of the netblock containing the address
+func (c *RDAPClient) Lookup(ctx context.Context, ip net.IP) (*domain.NetworkOwner, error) {
+	if owner := c.cached(ip); owner != nil {
+		return owner, nil
+	}
+
+	url := c.baseURL + "/ip/" + ip.String()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("RDAP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("RDAP request for %s returned status %d", ip, resp.StatusCode)
+	}
+
+	var network rdapNetwork
+	if err := json.NewDecoder(resp.Body).Decode(&network); err != nil {
+		return nil, fmt.Errorf("invalid RDAP response: %w", err)
+	}
+
+	owner := &domain.NetworkOwner{
+		Handle:       network.Handle,
+		Name:         network.Name,
+		StartAddress: network.StartAddress,
+		EndAddress:   network.EndAddress,
+		Country:      network.Country,
+		Source:       resp.Request.URL.String(),
+	}
+	for _, link := range network.Links {
+		if link.Rel == "self" {
+			owner.Source = link.Href
+		}
+	}
+	collectEntities(owner, network.Entities)
+
+	c.store(owner)
+
+	return owner, nil
+}
+
+// cached returns the cached record of the netblock containing the address
+func (c *RDAPClient) cached(ip net.IP) *domain.NetworkOwner {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	entries := c.cache[:0]
+	var owner *domain.NetworkOwner
+	for _, entry := range c.cache {
+		if now.After(entry.expiresAt) {
+			continue
+		}
+		entries = append(entries, entry)
+		if owner == nil && inRange(ip, entry.start, entry.end) {
+			ownerCopy := *entry.owner
+			owner = &ownerCopy
+		}
+	}
+	c.cache = entries
+
+	return owner
+}
+
+// store caches a netblock record if its address range is known
+func (c *RDAPClient) store(owner *domain.NetworkOwner) {
+	start, end := net.ParseIP(owner.StartAddress), net.ParseIP(owner.EndAddress)
+	if start == nil || end == nil || c.cacheTTL <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache = append(c.cache, cachedNetwork{
+		owner:     owner,
+		start:     start,
+		end:       end,
+		expiresAt: time.Now().Add(c.cacheTTL),
+	})
+}
+
+// inRange reports whether ip lies between start and end inclusive
+func inRange(ip, start, end net.IP) bool {
+	ip, start, end = ip.To16(), start.To16(), end.To16()
+	return bytes.Compare(ip, start) >= 0 && bytes.Compare(ip, end) <= 0
+}
+
+// collectEntities sets the registrant organization and abuse contacts from RDAP entities
+func collectEntities(owner *domain.NetworkOwner, entities []rdapEntity) {
+	for _, entity := range entities {
+		name, emails := parseVCard(entity.VCardArray)
+
+		for _, role := range entity.Roles {
+			switch role {
+			case "registrant":
+				if owner.Organization == "" {
+					owner.Organization = name
+				}
+			case "abuse":
+				for _, email := range emails {
+					if !slices.Contains(owner.AbuseEmails, email) {
+						owner.AbuseEmails = append(owner.AbuseEmails, email)
+					}
+				}
+			}
+		}
+
+		// Registries such as ARIN nest the abuse contact in the registrant entity
+		collectEntities(owner, entity.Entities)
+	}
+}
+
+// parseVCard returns the formatted name and e-mail addresses of a jCard
+// (["vcard", [["fn", {}, "text", "Example Inc."], ["email", {}, "text", "abuse@example.com"]]])
+func parseVCard(vcard []json.RawMessage) (string, []string) {
+	if len(vcard) < 2 {
+		return "", nil
+	}
+
+	var properties [][]interface{}
+	if err := json.Unmarshal(vcard[1], &properties); err != nil {
+		return "", nil
+	}
+
+	var name string
+	var emails []string
+	for _, property := range properties {
+		if len(property) < 4 {
+			continue
+		}
+		key, _ := property[0].(string)
+		value, _ := property[3].(string)
+		switch key {
+		case "fn":
+			name = value
+		case "email":
+			if value != "" {
+				emails = append(emails, value)
+			}
+		}
+	}
+
+	return name, emails
+}
+
+// RDAPEnricher attaches netblock owners and abuse contacts to the public hosts of scan results
+type RDAPEnricher struct {
+	client     *RDAPClient
+	maxLookups int
+	logger     *logger.Logger
+}
+
+// NewRDAPEnricher creates a new RDAPEnricher
+func NewRDAPEnricher(client *RDAPClient, maxLookups int, logger *logger.Logger) *RDAPEnricher {
+	return &RDAPEnricher{
+		client:     client,
+		maxLookups: maxLookups,
+		logger:     logger,
+	}
+}
+
+// Name returns the name of the enricher
+func (e *RDAPEnricher) Name() string {
+	return "rdap"
+}
+
+// Enrich sets the Owner field of the public hosts of the result.
+// Private and other non-routable addresses are skipped. Failed lookups are
+// reported after all hosts have been processed.
+func (e *RDAPEnricher) Enrich(ctx context.Context, result *domain.ScanResult) error {
+	var errs []error
+	lookups := 0
+
+	for i := range result.Hosts {
+		host := &result.Hosts[i]
+
+		ip := net.ParseIP(host.IP)
+		if ip == nil || !isPublicIP(ip) {
+			continue
+		}
+
+		if e.client.cached(ip) == nil {
+			if lookups >= e.maxLookups {
+				e.logger.Warn("RDAP lookup limit reached, remaining hosts are not enriched",
+					zap.String("scan_id", result.ScanID),
+					zap.Int("max_lookups", e.maxLookups),
+				)
+				break
+			}
+			lookups++
+		}
+
+		owner, err := e.client.Lookup(ctx, ip)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		host.Owner = owner
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package adapters
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const rdapResponse = `{
+  "objectClassName": "ip network",
+  "handle": "NET-8-8-8-0-2",
+  "name": "GOGL",
+  "startAddress": "8.8.8.0",
+  "endAddress": "8.8.8.255",
+  "country": "US",
+  "links": [{"rel": "self", "href": "https://rdap.arin.net/registry/ip/8.8.8.0"}],
+  "entities": [{
+    "roles": ["registrant"],
+    "vcardArray": ["vcard", [["version", {}, "text", "4.0"], ["fn", {}, "text", "Google LLC"]]],
+    "entities": [{
+      "roles": ["abuse"],
+      "vcardArray": ["vcard", [["fn", {}, "text", "Abuse"], ["email", {}, "text", "network-abuse@google.com"]]]
+    }]
+  }]
+}`
+
+func TestRDAPEnricher(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/ip/8.8.8.8", r.URL.Path)
+		w.Header().Set("Content-Type", "application/rdap+json")
+		w.Write([]byte(rdapResponse))
+	}))
+	defer server.Close()
+
+	log := &logger.Logger{Logger: zap.NewNop()}
+	client := NewRDAPClient(RDAPConfig{BaseURL: server.URL, Timeout: time.Second, CacheTTL: time.Hour}, log)
+	enricher := NewRDAPEnricher(client, 10, log)
+
+	result := &domain.ScanResult{Hosts: []domain.Host{
+		{IP: "8.8.8.8"},
+		{IP: "192.168.1.1"},
+	}}
+	require.NoError(t, enricher.Enrich(context.Background(), result))
+
+	assert.Equal(t, &domain.NetworkOwner{
+		Handle:       "NET-8-8-8-0-2",
+		Name:         "GOGL",
+		StartAddress: "8.8.8.0",
+		EndAddress:   "8.8.8.255",
+		Country:      "US",
+		Organization: "Google LLC",
+		AbuseEmails:  []string{"network-abuse@google.com"},
+		Source:       "https://rdap.arin.net/registry/ip/8.8.8.0",
+	}, result.Hosts[0].Owner)
+	assert.Nil(t, result.Hosts[1].Owner)
+
+	// Addresses of a cached netblock are not looked up again
+	owner, err := client.Lookup(context.Background(), net.ParseIP("8.8.8.4"))
+	require.NoError(t, err)
+	assert.Equal(t, "NET-8-8-8-0-2", owner.Handle)
+	assert.Equal(t, 1, requests)
+}
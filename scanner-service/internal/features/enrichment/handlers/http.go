@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/enrichment/adapters"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// EnrichmentHandler handles HTTP requests for on-demand enrichment lookups
+type EnrichmentHandler struct {
+	rdapClient *adapters.RDAPClient
+	logger     *logger.Logger
+}
+
+// NewEnrichmentHandler creates a new EnrichmentHandler
+func NewEnrichmentHandler(rdapClient *adapters.RDAPClient, logger *logger.Logger) *EnrichmentHandler {
+	return &EnrichmentHandler{
+		rdapClient: rdapClient,
+		logger:     logger,
+	}
+}
+
+// LookupOwner handles the request to look up the registered owner of a public IP address,
+// so users can confirm ownership before scanning it
+func (h *EnrichmentHandler) LookupOwner(c *gin.Context) {
+	ip := net.ParseIP(c.Param("ip"))
+	if ip == nil {
+		c.Error(errors.NewInvalidInput("invalid IP address", nil))
+		return
+	}
+	if !adapters.IsPublicIP(ip) {
+		c.Error(errors.NewInvalidInput("RDAP lookups are only available for public IP addresses", nil))
+		return
+	}
+
+	owner, err := h.rdapClient.Lookup(c.Request.Context(), ip)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Warn("RDAP lookup failed",
+			zap.String("ip", ip.String()),
+			zap.Error(err),
+		)
+
+		c.Error(errors.NewUpstream("RDAP lookup failed", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, owner)
+}
+
+// RegisterRoutes registers the enrichment handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *EnrichmentHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1", middleware...)
+	api.Use(authhandlers.RequireRole(authdomain.RoleViewer))
+
+	api.GET("/rdap/:ip", h.LookupOwner)
+}
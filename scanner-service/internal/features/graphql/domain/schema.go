@@ -0,0 +1,335 @@
+package domain
+
+import (
+	"context"
+	"strings"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/graphql-go/graphql"
+)
+
+// ScanQueryService defines the scan operations available to GraphQL queries.
+// Permission checks are applied by the implementation.
+type ScanQueryService interface {
+	GetScan(ctx context.Context, id string) (*scandomain.Scan, error)
+	ListScans(ctx context.Context, filter scandomain.ScanFilter, sort scandomain.ScanSort, page scandomain.PageRequest) (*scandomain.ScanPage, error)
+	GetScanResult(ctx context.Context, id string) (*scandomain.ScanResult, error)
+}
+
+// maxListLimit is the maximum number of scans returned by a single scans query
+const maxListLimit = 100
+
+// NewSchema creates the GraphQL schema over scans and scan results
+func NewSchema(scanService ScanQueryService) (graphql.Schema, error) {
+	scriptType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Script",
+		Description: "NSE script output",
+		Fields: graphql.Fields{
+			"id":     &graphql.Field{Type: graphql.String},
+			"output": &graphql.Field{Type: graphql.String},
+			"data": &graphql.Field{
+				Type: graphql.NewList(graphql.NewObject(graphql.ObjectConfig{
+					Name: "ScriptData",
+					Fields: graphql.Fields{
+						"key":   &graphql.Field{Type: graphql.String},
+						"value": &graphql.Field{Type: graphql.String},
+					},
+				})),
+				Description: "Structured script output as key/value pairs",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					script := p.Source.(scandomain.Script)
+					data := make([]map[string]string, 0, len(script.Data))
+					for key, value := range script.Data {
+						data = append(data, map[string]string{"key": key, "value": value})
+					}
+					return data, nil
+				},
+			},
+		},
+	})
+
+	portType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Port",
+		Fields: graphql.Fields{
+			"port":      &graphql.Field{Type: graphql.Int},
+			"protocol":  &graphql.Field{Type: graphql.String},
+			"state":     &graphql.Field{Type: graphql.String},
+			"service":   &graphql.Field{Type: graphql.String},
+			"product":   &graphql.Field{Type: graphql.String},
+			"version":   &graphql.Field{Type: graphql.String},
+			"extraInfo": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	hostType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Host",
+		Fields: graphql.Fields{
+			"ip":        &graphql.Field{Type: graphql.String},
+			"hostnames": &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"status":    &graphql.Field{Type: graphql.String},
+			"os":        &graphql.Field{Type: graphql.String},
+			"ports": &graphql.Field{
+				Type:        graphql.NewList(portType),
+				Description: "Ports of the host, optionally filtered by number and state",
+				Args: graphql.FieldConfigArgument{
+					"port":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"state": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					host := p.Source.(scandomain.Host)
+					port, _ := p.Args["port"].(int)
+					state, _ := p.Args["state"].(string)
+
+					ports := make([]scandomain.Port, 0, len(host.Ports))
+					for _, candidate := range host.Ports {
+						if (port == 0 || candidate.Port == port) && (state == "" || candidate.State == state) {
+							ports = append(ports, candidate)
+						}
+					}
+					return ports, nil
+				},
+			},
+			"scripts": &graphql.Field{
+				Type:        graphql.NewList(scriptType),
+				Description: "Script results of the host, optionally filtered by script ID",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					host := p.Source.(scandomain.Host)
+					id, _ := p.Args["id"].(string)
+
+					scripts := make([]scandomain.Script, 0, len(host.Scripts))
+					for _, script := range host.Scripts {
+						if id == "" || script.ID == id {
+							scripts = append(scripts, script)
+						}
+					}
+					return scripts, nil
+				},
+			},
+		},
+	})
+
+	resultType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ScanResult",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.ID},
+			"scanId":     &graphql.Field{Type: graphql.ID},
+			"userId":     &graphql.Field{Type: graphql.String},
+			"startTime":  &graphql.Field{Type: graphql.DateTime},
+			"endTime":    &graphql.Field{Type: graphql.DateTime},
+			"duration":   &graphql.Field{Type: graphql.Float},
+			"command":    &graphql.Field{Type: graphql.String},
+			"summary":    &graphql.Field{Type: graphql.String},
+			"totalHosts": &graphql.Field{Type: graphql.Int},
+			"upHosts":    &graphql.Field{Type: graphql.Int},
+			"hosts": &graphql.Field{
+				Type:        graphql.NewList(hostType),
+				Description: "Hosts of the result, optionally filtered by status, open port or service",
+				Args: graphql.FieldConfigArgument{
+					"status":   &graphql.ArgumentConfig{Type: graphql.String},
+					"openPort": &graphql.ArgumentConfig{Type: graphql.Int},
+					"service":  &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					result := p.Source.(*scandomain.ScanResult)
+					status, _ := p.Args["status"].(string)
+					openPort, _ := p.Args["openPort"].(int)
+					service, _ := p.Args["service"].(string)
+
+					hosts := make([]scandomain.Host, 0, len(result.Hosts))
+					for _, host := range result.Hosts {
+						if status != "" && host.Status != status {
+							continue
+						}
+						if (openPort != 0 || service != "") && !hasOpenPort(host, openPort, service) {
+							continue
+						}
+						hosts = append(hosts, host)
+					}
+					return hosts, nil
+				},
+			},
+		},
+	})
+
+	optionsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ScanOptions",
+		Fields: graphql.Fields{
+			"target":           &graphql.Field{Type: graphql.String},
+			"ports":            &graphql.Field{Type: graphql.String},
+			"scanType":         &graphql.Field{Type: graphql.String},
+			"timingTemplate":   &graphql.Field{Type: graphql.Int},
+			"serviceDetection": &graphql.Field{Type: graphql.Boolean},
+			"osDetection":      &graphql.Field{Type: graphql.Boolean},
+			"scriptScan":       &graphql.Field{Type: graphql.Boolean},
+			"extraOptions":     &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"timeoutSeconds": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(scandomain.ScanOptions).Timeout.Seconds()), nil
+				},
+			},
+			"minRate":        &graphql.Field{Type: graphql.Int},
+			"maxRate":        &graphql.Field{Type: graphql.Int},
+			"maxParallelism": &graphql.Field{Type: graphql.Int},
+			"maxRetries": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if retries := p.Source.(scandomain.ScanOptions).MaxRetries; retries != nil {
+						return *retries, nil
+					}
+					return nil, nil
+				},
+			},
+			"decoys":     &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"sourcePort": &graphql.Field{Type: graphql.Int},
+			"fragment":   &graphql.Field{Type: graphql.Boolean},
+			"dataLength": &graphql.Field{Type: graphql.Int},
+			"hostTimeoutSeconds": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(scandomain.ScanOptions).HostTimeout.Seconds()), nil
+				},
+			},
+		},
+	})
+
+	scanType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Scan",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.ID},
+			"userId":      &graphql.Field{Type: graphql.String},
+			"options":     &graphql.Field{Type: optionsType},
+			"status":      &graphql.Field{Type: graphql.String},
+			"progress":    &graphql.Field{Type: graphql.Float},
+			"createdAt":   &graphql.Field{Type: graphql.DateTime},
+			"startedAt":   &graphql.Field{Type: graphql.DateTime},
+			"completedAt": &graphql.Field{Type: graphql.DateTime},
+			"error":       &graphql.Field{Type: graphql.String},
+			"resultId":    &graphql.Field{Type: graphql.ID},
+			"requestId":   &graphql.Field{Type: graphql.String},
+			"result": &graphql.Field{
+				Type:        resultType,
+				Description: "Result of the scan, null until the scan has completed",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					scan := p.Source.(*scandomain.Scan)
+					if scan.ResultID == "" {
+						return nil, nil
+					}
+					return scanService.GetScanResult(p.Context, scan.ResultID)
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"scan": &graphql.Field{
+				Type: scanType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return scanService.GetScan(p.Context, p.Args["id"].(string))
+				},
+			},
+			"scans": &graphql.Field{
+				Type:        graphql.NewList(scanType),
+				Description: "Scans of the caller, or of userId when the caller is an admin",
+				Args: graphql.FieldConfigArgument{
+					"userId": &graphql.ArgumentConfig{Type: graphql.String},
+					"status": &graphql.ArgumentConfig{Type: graphql.String},
+					"target": &graphql.ArgumentConfig{Type: graphql.String, Description: "Substring of the scan target"},
+					"tags":   &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String), Description: "Tags the scans must all have"},
+					"sort":   &graphql.ArgumentConfig{Type: graphql.String, Description: "created_at, completed_at, duration or status"},
+					"order":  &graphql.ArgumentConfig{Type: graphql.String, Description: "asc or desc"},
+					"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+					"after":  &graphql.ArgumentConfig{Type: graphql.String, Description: "Cursor returned by the REST listing"},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter := scandomain.ScanFilter{UserID: callerID(p.Context)}
+					if userID, _ := p.Args["userId"].(string); userID != "" {
+						filter.UserID = userID
+					}
+					if status, _ := p.Args["status"].(string); status != "" {
+						parsed, ok := scandomain.ParseScanStatus(status)
+						if !ok {
+							return nil, errors.NewInvalidInput("invalid status: "+status, nil)
+						}
+						filter.Statuses = []scandomain.ScanStatus{parsed}
+					}
+					filter.Target, _ = p.Args["target"].(string)
+					if tags, ok := p.Args["tags"].([]interface{}); ok {
+						for _, tag := range tags {
+							if tag, ok := tag.(string); ok {
+								filter.Tags = append(filter.Tags, tag)
+							}
+						}
+					}
+
+					field, _ := p.Args["sort"].(string)
+					order, _ := p.Args["order"].(string)
+					sort, err := scandomain.ParseScanSort(field, order)
+					if err != nil {
+						return nil, err
+					}
+
+					cursor, _ := p.Args["after"].(string)
+					limit := p.Args["limit"].(int)
+					if limit < 1 || limit > maxListLimit {
+						limit = maxListLimit
+					}
+
+					page, err := scanService.ListScans(p.Context, filter, sort, scandomain.PageRequest{
+						Limit:  limit,
+						Offset: p.Args["offset"].(int),
+						Cursor: cursor,
+					})
+					if err != nil {
+						return nil, err
+					}
+					return page.Scans, nil
+				},
+			},
+			"result": &graphql.Field{
+				Type: resultType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return scanService.GetScanResult(p.Context, p.Args["id"].(string))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// hasOpenPort reports whether a host has an open port matching the number and service.
+// Zero values match any port.
+func hasOpenPort(host scandomain.Host, port int, service string) bool {
+	for _, candidate := range host.Ports {
+		if candidate.State != "open" {
+			continue
+		}
+		if (port == 0 || candidate.Port == port) && (service == "" || strings.EqualFold(candidate.Service, service)) {
+			return true
+		}
+	}
+	return false
+}
+
+// callerID returns the user ID of the principal in the context
+func callerID(ctx context.Context) string {
+	if principal, ok := authdomain.PrincipalFromContext(ctx); ok {
+		return principal.UserID
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/graphql/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeScanService serves fixed scans and results
+type fakeScanService struct {
+	scans   map[string]*scandomain.Scan
+	results map[string]*scandomain.ScanResult
+}
+
+func (f *fakeScanService) GetScan(ctx context.Context, id string) (*scandomain.Scan, error) {
+	if scan, ok := f.scans[id]; ok {
+		return scan, nil
+	}
+	return nil, errors.NewNotFound("scan not found", nil)
+}
+
+func (f *fakeScanService) ListScans(ctx context.Context, filter scandomain.ScanFilter, sort scandomain.ScanSort, page scandomain.PageRequest) (*scandomain.ScanPage, error) {
+	result := &scandomain.ScanPage{}
+	for _, scan := range f.scans {
+		if filter.Matches(scan) {
+			result.Scans = append(result.Scans, scan)
+		}
+	}
+	result.TotalCount = len(result.Scans)
+	return result, nil
+}
+
+func (f *fakeScanService) GetScanResult(ctx context.Context, id string) (*scandomain.ScanResult, error) {
+	if result, ok := f.results[id]; ok {
+		return result, nil
+	}
+	return nil, errors.NewNotFound("scan result not found", nil)
+}
+
+func newTestSchema(t *testing.T) graphql.Schema {
+	service := &fakeScanService{
+		scans: map[string]*scandomain.Scan{
+			"scan-1": {
+				ID:     "scan-1",
+				UserID: "alice",
+				Options: scandomain.ScanOptions{
+					Target:         "10.0.0.0/30",
+					ScanType:       scandomain.ScanTypeSYN,
+					TimingTemplate: scandomain.TimingNormal,
+					Timeout:        5 * time.Minute,
+				},
+				Status:    scandomain.ScanStatusCompleted,
+				CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				ResultID:  "result-1",
+			},
+		},
+		results: map[string]*scandomain.ScanResult{
+			"result-1": {
+				ID:     "result-1",
+				ScanID: "scan-1",
+				Hosts: []scandomain.Host{
+					{
+						IP:     "10.0.0.1",
+						Status: "up",
+						Ports: []scandomain.Port{
+							{Port: 22, Protocol: "tcp", State: "open", Service: "ssh"},
+							{Port: 443, Protocol: "tcp", State: "open", Service: "https"},
+						},
+						Scripts: []scandomain.Script{
+							{ID: "ssl-cert", Output: "CN=example.com", Data: map[string]string{"subject": "CN=example.com"}},
+							{ID: "ssh-hostkey", Output: "2048 aa:bb"},
+						},
+					},
+					{
+						IP:     "10.0.0.2",
+						Status: "up",
+						Ports: []scandomain.Port{
+							{Port: 443, Protocol: "tcp", State: "closed", Service: "https"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	schema, err := domain.NewSchema(service)
+	require.NoError(t, err)
+	return schema
+}
+
+func doQuery(schema graphql.Schema, query string) *graphql.Result {
+	ctx := authdomain.WithPrincipal(context.Background(), &authdomain.Principal{
+		UserID: "alice",
+		Roles:  []authdomain.Role{authdomain.RoleViewer},
+	})
+	return graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: ctx})
+}
+
+func TestNestedResultQuery(t *testing.T) {
+	schema := newTestSchema(t)
+
+	result := doQuery(schema, `{
+		scan(id: "scan-1") {
+			status
+			options { target scanType timingTemplate timeoutSeconds }
+			result {
+				hosts(openPort: 443) {
+					ip
+					ports(port: 443) { port service }
+					scripts(id: "ssl-cert") { id data { key value } }
+				}
+			}
+		}
+	}`)
+	require.Empty(t, result.Errors)
+
+	scan := result.Data.(map[string]interface{})["scan"].(map[string]interface{})
+	assert.Equal(t, "COMPLETED", scan["status"])
+
+	options := scan["options"].(map[string]interface{})
+	assert.Equal(t, "10.0.0.0/30", options["target"])
+	assert.Equal(t, 300, options["timeoutSeconds"])
+
+	hosts := scan["result"].(map[string]interface{})["hosts"].([]interface{})
+	require.Len(t, hosts, 1)
+
+	host := hosts[0].(map[string]interface{})
+	assert.Equal(t, "10.0.0.1", host["ip"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"port": 443, "service": "https"}}, host["ports"])
+
+	scripts := host["scripts"].([]interface{})
+	require.Len(t, scripts, 1)
+	assert.Equal(t, []interface{}{map[string]interface{}{"key": "subject", "value": "CN=example.com"}},
+		scripts[0].(map[string]interface{})["data"])
+}
+
+func TestScansQuery(t *testing.T) {
+	schema := newTestSchema(t)
+
+	result := doQuery(schema, `{ scans(status: "completed") { id resultId } }`)
+	require.Empty(t, result.Errors)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "scan-1", "resultId": "result-1"},
+	}, result.Data.(map[string]interface{})["scans"])
+
+	result = doQuery(schema, `{ scans(status: "running") { id } }`)
+	require.Empty(t, result.Errors)
+	assert.Empty(t, result.Data.(map[string]interface{})["scans"])
+
+	result = doQuery(schema, `{ scans(status: "unknown") { id } }`)
+	assert.Len(t, result.Errors, 1)
+}
+
+func TestQueryErrors(t *testing.T) {
+	schema := newTestSchema(t)
+
+	result := doQuery(schema, `{ result(id: "missing") { id } }`)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "scan result not found")
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+)
+
+// GraphQLHandler handles GraphQL queries over HTTP
+type GraphQLHandler struct {
+	schema graphql.Schema
+	logger *logger.Logger
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler
+func NewGraphQLHandler(schema graphql.Schema, logger *logger.Logger) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema: schema,
+		logger: logger,
+	}
+}
+
+// QueryRequest represents a GraphQL request
+type QueryRequest struct {
+	Query         string                 `json:"query" form:"query" binding:"required"`
+	OperationName string                 `json:"operationName,omitempty" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Query handles a GraphQL query sent as a JSON body (POST) or query parameters (GET)
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req QueryRequest
+	var err error
+	if c.Request.Method == http.MethodGet {
+		err = c.ShouldBindQuery(&req)
+	} else {
+		err = c.ShouldBindJSON(&req)
+	}
+	if err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        c.Request.Context(),
+	})
+
+	if result.HasErrors() {
+		h.logger.WithContext(c.Request.Context()).Debug("GraphQL query returned errors",
+			zap.Int("errors", len(result.Errors)),
+			zap.String("user_id", c.GetString("user_id")),
+		)
+	}
+
+	// Following GraphQL over HTTP conventions, errors are reported in the body
+	c.JSON(http.StatusOK, result)
+}
+
+// RegisterRoutes registers the GraphQL handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *GraphQLHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1", middleware...)
+	api.Use(authhandlers.RequireRole(authdomain.RoleViewer))
+
+	api.GET("/graphql", h.Query)
+	api.POST("/graphql", h.Query)
+}
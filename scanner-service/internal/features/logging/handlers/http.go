@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// LogLevelHandler handles HTTP requests to inspect and change the log level at runtime
+type LogLevelHandler struct {
+	logger *logger.Logger
+}
+
+// NewLogLevelHandler creates a new LogLevelHandler for the level of logger and all
+// loggers derived from it
+func NewLogLevelHandler(logger *logger.Logger) *LogLevelHandler {
+	return &LogLevelHandler{
+		logger: logger,
+	}
+}
+
+// LogLevelRequest represents the request body for changing the log level
+type LogLevelRequest struct {
+	Level string `json:"level" binding:"required"`
+}
+
+// GetLogLevel handles the request to get the current log level
+func (h *LogLevelHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"level": h.logger.Level(),
+	})
+}
+
+// SetLogLevel handles the request to change the log level without a restart
+func (h *LogLevelHandler) SetLogLevel(c *gin.Context) {
+	var req LogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	previous := h.logger.Level()
+	if err := h.logger.SetLevel(req.Level); err != nil {
+		c.Error(errors.NewInvalidField("level", "oneof", err.Error()))
+		return
+	}
+
+	// Logged as a warning so the change is recorded at any level
+	h.logger.WithContext(c.Request.Context()).Warn("Log level changed",
+		zap.String("previous_level", previous),
+		zap.String("level", req.Level),
+		zap.String("user_id", c.GetString("user_id")),
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"level": h.logger.Level(),
+	})
+}
+
+// RegisterRoutes registers the log level handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *LogLevelHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	admin := router.Group("/api/v1/admin", middleware...)
+	admin.Use(authhandlers.RequireRole(authdomain.RoleAdmin))
+
+	admin.GET("/log-level", h.GetLogLevel)
+	admin.PUT("/log-level", h.SetLogLevel)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	workflowdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/domain"
+)
+
+// ChangeType represents a kind of change between two scans of a monitor
+type ChangeType = scandomain.ResultChangeType
+
+// Change type constants
+const (
+	ChangeTypeHostAdded      = scandomain.ResultChangeHostAdded
+	ChangeTypeHostRemoved    = scandomain.ResultChangeHostRemoved
+	ChangeTypePortOpened     = scandomain.ResultChangePortOpened
+	ChangeTypePortClosed     = scandomain.ResultChangePortClosed
+	ChangeTypeServiceChanged = scandomain.ResultChangeServiceChanged
+)
+
+// ChangeTypes lists the change types in the order changes of a host are reported
+var ChangeTypes = scandomain.ResultChangeTypes
+
+// DefaultAlertChanges are the change types alerted about by monitors that do not set their own
+var DefaultAlertChanges = []ChangeType{ChangeTypeHostAdded, ChangeTypePortOpened, ChangeTypeServiceChanged}
+
+// Change represents a difference between the previous and the latest scan of a monitor
+type Change = scandomain.ResultChange
+
+// RunStatus represents the status of a monitor run
+type RunStatus string
+
+// Run status constants
+const (
+	RunStatusRunning   RunStatus = "running"   // The scan of the run is running
+	RunStatusBaseline  RunStatus = "baseline"  // The run completed without a previous scan to compare against
+	RunStatusUnchanged RunStatus = "unchanged" // The run completed and found no changes
+	RunStatusChanged   RunStatus = "changed"   // The run completed and found changes
+	RunStatusFailed    RunStatus = "failed"    // The scan of the run failed
+)
+
+// Monitor represents a recurring scan of a set of targets that alerts about the
+// changes between consecutive scans instead of every result
+type Monitor struct {
+	ID           string                  `json:"id"`                       // Unique identifier
+	UserID       string                  `json:"user_id"`                  // Owner of the monitor
+	Name         string                  `json:"name"`                     // Display name
+	Target       string                  `json:"target"`                   // Targets scanned by every run
+	Scan         workflowdomain.StepScan `json:"scan"`                     // Options of the scans
+	Schedule     string                  `json:"schedule"`                 // Cron expression of the runs
+	AlertOn      []ChangeType            `json:"alert_on"`                 // Change types that generate alerts
+	Paused       bool                    `json:"paused"`                   // Whether scheduled runs are skipped
+	Owner        *authdomain.Principal   `json:"-"`                        // Principal runs are executed as
+	LastRunID    string                  `json:"last_run_id,omitempty"`    // Most recent run
+	LastRunAt    *time.Time              `json:"last_run_at,omitempty"`    // When the most recent run started
+	LastResultID string                  `json:"last_result_id,omitempty"` // Result the next run is compared against
+	CreatedAt    time.Time               `json:"created_at"`               // When the monitor was created
+	UpdatedAt    time.Time               `json:"updated_at"`               // When the monitor was last updated
+}
+
+// Copy returns a copy of the monitor that does not share its alert change types
+func (m *Monitor) Copy() *Monitor {
+	monitorCopy := *m
+	monitorCopy.AlertOn = slices.Clone(m.AlertOn)
+	return &monitorCopy
+}
+
+// Run represents one scan of a monitor and the changes it found
+type Run struct {
+	ID          string                `json:"id"`                     // Unique identifier
+	MonitorID   string                `json:"monitor_id"`             // Monitor that was run
+	UserID      string                `json:"user_id"`                // Owner of the monitor
+	Status      RunStatus             `json:"status"`                 // Current status
+	ScanID      string                `json:"scan_id,omitempty"`      // Scan of the run
+	ScanStatus  scandomain.ScanStatus `json:"scan_status,omitempty"`  // Status of the scan
+	ResultID    string                `json:"result_id,omitempty"`    // Result of the scan
+	PreviousID  string                `json:"previous_id,omitempty"`  // Result the scan was compared against
+	Changes     []Change              `json:"changes"`                // Changes since the previous result, by host and port
+	Alerted     int                   `json:"alerted"`                // Number of changes alerted about
+	Error       string                `json:"error,omitempty"`        // Error message if failed
+	StartedAt   time.Time             `json:"started_at"`             // When the run started
+	CompletedAt *time.Time            `json:"completed_at,omitempty"` // When the run completed
+}
+
+// Copy returns a copy of the run that does not share its changes
+func (r *Run) Copy() *Run {
+	runCopy := *r
+	runCopy.Changes = slices.Clone(r.Changes)
+	return &runCopy
+}
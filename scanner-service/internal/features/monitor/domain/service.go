@@ -0,0 +1,587 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	notificationdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// MaxRuns is the number of runs kept per monitor, older runs are dropped
+const MaxRuns = 50
+
+// maxAlertChanges is the number of changes listed in an alert message
+const maxAlertChanges = 50
+
+// MonitorRepository defines the interface for monitor storage
+type MonitorRepository interface {
+	SaveMonitor(monitor *Monitor) error
+	GetMonitorByID(id string) (*Monitor, error)
+	ListMonitors(userID string) ([]*Monitor, error)
+	DeleteMonitor(id string) error
+	SaveRun(run *Run) error
+	ListRuns(monitorID string) ([]*Run, error)
+}
+
+// ScanRunner checks and runs the scans of monitors, and gets the results they are compared against
+type ScanRunner interface {
+	CheckScan(ctx context.Context, options scandomain.ScanOptions) error
+	RunScan(ctx context.Context, scan *scandomain.Scan) (*scandomain.ScanResult, error)
+	GetScanResult(ctx context.Context, id string) (*scandomain.ScanResult, error)
+}
+
+// MonitorService runs monitors on their schedules and alerts about the changes they find
+type MonitorService struct {
+	repository    MonitorRepository
+	scanRunner    ScanRunner
+	notifier      notificationdomain.Notifier // Delivers change alerts, nil to only log them
+	notifyTimeout time.Duration
+	logger        *logger.Logger
+	scheduler     *cron.Cron
+	mu            sync.Mutex
+	monitorMu     sync.Mutex                    // Serializes reading and saving stored monitors
+	entries       map[string]cron.EntryID       // Schedule entries by monitor ID
+	activeRuns    map[string]string             // Active run ID by monitor ID
+	runCancels    map[string]context.CancelFunc // Cancel functions by run ID
+}
+
+// NewMonitorService creates a new MonitorService
+func NewMonitorService(repository MonitorRepository, scanRunner ScanRunner, logger *logger.Logger) *MonitorService {
+	return &MonitorService{
+		repository: repository,
+		scanRunner: scanRunner,
+		logger:     logger,
+		scheduler:  cron.New(),
+		entries:    make(map[string]cron.EntryID),
+		activeRuns: make(map[string]string),
+		runCancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// SetNotifier sets the notifier change alerts are delivered through, each within the timeout
+func (s *MonitorService) SetNotifier(notifier notificationdomain.Notifier, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	s.notifier = notifier
+	s.notifyTimeout = timeout
+}
+
+// Start starts running monitors on their schedules
+func (s *MonitorService) Start() {
+	s.scheduler.Start()
+}
+
+// Stop stops scheduling monitors and cancels the active runs.
+// The returned context is done when the running schedule jobs have returned.
+func (s *MonitorService) Stop() context.Context {
+	ctx := s.scheduler.Stop()
+
+	s.mu.Lock()
+	for _, cancel := range s.runCancels {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	return ctx
+}
+
+// CreateMonitor stores a monitor after checking its scans against the caller's policies,
+// and schedules it. The first run records the result later runs are compared against.
+// The caller must have the operator role.
+func (s *MonitorService) CreateMonitor(ctx context.Context, userID string, monitor Monitor) (*Monitor, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkMonitor(ctx, &monitor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	monitor.ID = uuid.New().String()
+	monitor.UserID = userID
+	monitor.Owner = principal
+	monitor.LastRunID = ""
+	monitor.LastRunAt = nil
+	monitor.LastResultID = ""
+	monitor.CreatedAt = now
+	monitor.UpdatedAt = now
+
+	if err := s.repository.SaveMonitor(&monitor); err != nil {
+		return nil, errors.NewInternal("failed to save monitor", err)
+	}
+
+	if err := s.schedule(&monitor); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Monitor created",
+		zap.String("monitor_id", monitor.ID),
+		zap.String("user_id", userID),
+		zap.String("target", monitor.Target),
+		zap.String("schedule", monitor.Schedule),
+	)
+
+	return &monitor, nil
+}
+
+// UpdateMonitor replaces the name, target, scan options, schedule and alerted change
+// types of a monitor, and whether it is paused. Runs are executed as the caller from now
+// on. Changing the target or scan options makes the next run record a new result to
+// compare against. The caller must have the operator role and own the monitor, or be an admin.
+func (s *MonitorService) UpdateMonitor(ctx context.Context, id string, monitor Monitor) (*Monitor, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	s.monitorMu.Lock()
+	defer s.monitorMu.Unlock()
+
+	existing, err := s.repository.GetMonitorByID(id)
+	if err != nil || !principal.CanAccess(existing.UserID) {
+		return nil, errors.NewNotFound("monitor not found", err)
+	}
+
+	if err := s.checkMonitor(ctx, &monitor); err != nil {
+		return nil, err
+	}
+
+	monitor.ID = existing.ID
+	monitor.UserID = existing.UserID
+	monitor.Owner = principal
+	monitor.LastRunID = existing.LastRunID
+	monitor.LastRunAt = existing.LastRunAt
+	monitor.LastResultID = existing.LastResultID
+	if monitor.Target != existing.Target || !reflect.DeepEqual(monitor.Scan, existing.Scan) {
+		monitor.LastResultID = ""
+	}
+	monitor.CreatedAt = existing.CreatedAt
+	monitor.UpdatedAt = time.Now()
+
+	if err := s.repository.SaveMonitor(&monitor); err != nil {
+		return nil, errors.NewInternal("failed to save monitor", err)
+	}
+
+	if err := s.schedule(&monitor); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("Monitor updated",
+		zap.String("monitor_id", id),
+		zap.String("updated_by", principal.UserID),
+	)
+
+	return &monitor, nil
+}
+
+// GetMonitor gets a monitor by ID.
+// Monitors owned by other users are reported as not found unless the caller is an admin.
+func (s *MonitorService) GetMonitor(ctx context.Context, id string) (*Monitor, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor, err := s.repository.GetMonitorByID(id)
+	if err != nil || !principal.CanAccess(monitor.UserID) {
+		return nil, errors.NewNotFound("monitor not found", err)
+	}
+
+	return monitor, nil
+}
+
+// ListMonitors lists the monitors of a user by name.
+// Only admins may list other users' monitors or all monitors (empty userID).
+func (s *MonitorService) ListMonitors(ctx context.Context, userID string) ([]*Monitor, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != principal.UserID && !principal.IsAdmin() {
+		return nil, errors.NewForbidden("cannot list monitors of other users", nil)
+	}
+
+	monitors, err := s.repository.ListMonitors(userID)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list monitors", err)
+	}
+
+	return monitors, nil
+}
+
+// DeleteMonitor unschedules and deletes a monitor and cancels its active run.
+// The caller must have the operator role and own the monitor, or be an admin.
+func (s *MonitorService) DeleteMonitor(ctx context.Context, id string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
+	s.monitorMu.Lock()
+	defer s.monitorMu.Unlock()
+
+	monitor, err := s.repository.GetMonitorByID(id)
+	if err != nil || !principal.CanAccess(monitor.UserID) {
+		return errors.NewNotFound("monitor not found", err)
+	}
+
+	s.mu.Lock()
+	if entry, ok := s.entries[id]; ok {
+		s.scheduler.Remove(entry)
+		delete(s.entries, id)
+	}
+	if cancel, ok := s.runCancels[s.activeRuns[id]]; ok {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	if err := s.repository.DeleteMonitor(id); err != nil {
+		return errors.NewInternal("failed to delete monitor", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Monitor deleted",
+		zap.String("monitor_id", id),
+		zap.String("deleted_by", principal.UserID),
+	)
+
+	return nil
+}
+
+// ListRuns lists the recent runs of a monitor, newest first. The caller must have the
+// viewer role and own the monitor, or be an admin.
+func (s *MonitorService) ListRuns(ctx context.Context, id string) ([]*Run, error) {
+	if _, err := s.GetMonitor(ctx, id); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.repository.ListRuns(id)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list monitor runs", err)
+	}
+
+	return runs, nil
+}
+
+// RunNow starts a run of a monitor as its owner, exactly like the schedule would,
+// even if the monitor is paused.
+// The caller must have the operator role and own the monitor, or be an admin.
+func (s *MonitorService) RunNow(ctx context.Context, id string) (*Run, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor, err := s.repository.GetMonitorByID(id)
+	if err != nil || !principal.CanAccess(monitor.UserID) {
+		return nil, errors.NewNotFound("monitor not found", err)
+	}
+
+	return s.startRun(ctx, monitor)
+}
+
+// checkMonitor validates a monitor, normalizes its name, target and alerted change
+// types, and checks its scans against the caller's policies
+func (s *MonitorService) checkMonitor(ctx context.Context, monitor *Monitor) error {
+	monitor.Name = strings.TrimSpace(monitor.Name)
+	if monitor.Name == "" {
+		return errors.NewInvalidField("name", "required", "name is required")
+	}
+
+	target, err := scandomain.NormalizeTarget(monitor.Target)
+	if err != nil {
+		return errors.WithField(err, "target", "format")
+	}
+	monitor.Target = target
+
+	if _, err := cron.ParseStandard(monitor.Schedule); err != nil {
+		return errors.NewInvalidField("schedule", "cron", "invalid schedule: "+err.Error())
+	}
+
+	if len(monitor.AlertOn) == 0 {
+		monitor.AlertOn = slices.Clone(DefaultAlertChanges)
+	}
+	for _, changeType := range monitor.AlertOn {
+		if !slices.Contains(ChangeTypes, changeType) {
+			return errors.NewInvalidField("alert_on", "oneof", "unknown change type: "+string(changeType))
+		}
+	}
+	slices.SortFunc(monitor.AlertOn, func(a, b ChangeType) int {
+		return slices.Index(ChangeTypes, a) - slices.Index(ChangeTypes, b)
+	})
+	monitor.AlertOn = slices.Compact(monitor.AlertOn)
+
+	return s.scanRunner.CheckScan(ctx, monitor.Scan.Options(monitor.Target))
+}
+
+// schedule replaces the schedule entry of a monitor with its current schedule
+func (s *MonitorService) schedule(monitor *Monitor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[monitor.ID]; ok {
+		s.scheduler.Remove(entry)
+		delete(s.entries, monitor.ID)
+	}
+
+	monitorID := monitor.ID
+	entry, err := s.scheduler.AddFunc(monitor.Schedule, func() {
+		s.runScheduled(monitorID)
+	})
+	if err != nil {
+		return errors.NewInvalidInput("invalid schedule: "+err.Error(), err)
+	}
+	s.entries[monitor.ID] = entry
+
+	return nil
+}
+
+// runScheduled starts a scheduled run of a monitor.
+// The run is skipped if the monitor is paused or the previous run is still active.
+func (s *MonitorService) runScheduled(monitorID string) {
+	log := s.logger.With(zap.String("monitor_id", monitorID))
+
+	monitor, err := s.repository.GetMonitorByID(monitorID)
+	if err != nil {
+		log.Error("Failed to get scheduled monitor", zap.Error(err))
+		return
+	}
+
+	if monitor.Paused {
+		log.Info("Scheduled monitor run skipped", zap.String("reason", "monitor is paused"))
+		return
+	}
+
+	run, err := s.startRun(context.Background(), monitor)
+	if err != nil {
+		log.Warn("Scheduled monitor run skipped", zap.String("reason", err.Error()))
+		return
+	}
+
+	log.Info("Scheduled monitor run started", zap.String("run_id", run.ID))
+}
+
+// startRun starts a run of a monitor in the background as its owner
+func (s *MonitorService) startRun(ctx context.Context, monitor *Monitor) (*Run, error) {
+	run := &Run{
+		ID:        uuid.New().String(),
+		MonitorID: monitor.ID,
+		UserID:    monitor.UserID,
+		Status:    RunStatusRunning,
+		Changes:   []Change{},
+		StartedAt: time.Now(),
+	}
+
+	// Run the scan detached from the cancellation of the request
+	runCtx, cancel := context.WithCancel(authdomain.WithPrincipal(context.WithoutCancel(ctx), monitor.Owner))
+
+	s.mu.Lock()
+	if activeRunID, ok := s.activeRuns[monitor.ID]; ok {
+		s.mu.Unlock()
+		cancel()
+		return nil, errors.NewAlreadyExists("monitor run "+activeRunID+" is still active", nil)
+	}
+	s.activeRuns[monitor.ID] = run.ID
+	s.runCancels[run.ID] = cancel
+	s.mu.Unlock()
+
+	if err := s.repository.SaveRun(run); err != nil {
+		s.finishRun(monitor.ID, run.ID)
+		return nil, errors.NewInternal("failed to save monitor run", err)
+	}
+
+	// Only record the run on the stored monitor, which may have been updated since
+	// monitor was read
+	s.monitorMu.Lock()
+	current, err := s.repository.GetMonitorByID(monitor.ID)
+	if err == nil {
+		current.LastRunID = run.ID
+		current.LastRunAt = &run.StartedAt
+		err = s.repository.SaveMonitor(current)
+	}
+	s.monitorMu.Unlock()
+	if err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update monitor",
+			zap.String("monitor_id", monitor.ID),
+			zap.Error(err),
+		)
+	}
+
+	go s.executeRun(runCtx, monitor.Copy(), run.Copy())
+
+	return run, nil
+}
+
+// executeRun runs the scan of a monitor run, compares its result against the previous
+// result of the monitor and alerts about the changes of the alerted types
+func (s *MonitorService) executeRun(ctx context.Context, monitor *Monitor, run *Run) {
+	defer s.finishRun(monitor.ID, run.ID)
+	log := s.logger.WithContext(ctx).With(
+		zap.String("monitor_id", monitor.ID),
+		zap.String("run_id", run.ID),
+	)
+
+	scan := &scandomain.Scan{
+		UserID:  monitor.UserID,
+		Options: monitor.Scan.Options(monitor.Target),
+	}
+	result, err := s.scanRunner.RunScan(ctx, scan)
+	run.ScanID = scan.ID
+	run.ScanStatus = scan.Status
+	completedAt := time.Now()
+	run.CompletedAt = &completedAt
+	if err != nil {
+		log.Error("Monitor scan failed", zap.Error(err))
+		run.Status = RunStatusFailed
+		run.Error = err.Error()
+		s.saveRun(ctx, run)
+		return
+	}
+	run.ResultID = result.ID
+
+	var previous *scandomain.ScanResult
+	if monitor.LastResultID != "" {
+		previous, err = s.scanRunner.GetScanResult(ctx, monitor.LastResultID)
+		if err != nil {
+			// The previous result expired or was deleted, this result replaces it
+			log.Warn("Failed to get previous monitor result",
+				zap.String("result_id", monitor.LastResultID),
+				zap.Error(err),
+			)
+			previous = nil
+		}
+	}
+
+	if previous == nil {
+		run.Status = RunStatusBaseline
+	} else {
+		run.PreviousID = previous.ID
+		run.Changes = scandomain.DiffResults(previous, result)
+		run.Status = RunStatusUnchanged
+		if len(run.Changes) > 0 {
+			run.Status = RunStatusChanged
+		}
+
+		var alerted []Change
+		for _, change := range run.Changes {
+			if slices.Contains(monitor.AlertOn, change.Type) {
+				alerted = append(alerted, change)
+			}
+		}
+		run.Alerted = len(alerted)
+		if len(alerted) > 0 {
+			s.alert(ctx, monitor, run, alerted)
+		}
+	}
+	s.saveRun(ctx, run)
+
+	log.Info("Monitor run completed",
+		zap.String("status", string(run.Status)),
+		zap.Int("changes", len(run.Changes)),
+		zap.Int("alerted", run.Alerted),
+	)
+
+	// Compare the next run against this result, unless the monitor was changed or
+	// deleted while the scan ran
+	s.monitorMu.Lock()
+	defer s.monitorMu.Unlock()
+
+	current, err := s.repository.GetMonitorByID(monitor.ID)
+	if err != nil || !current.UpdatedAt.Equal(monitor.UpdatedAt) {
+		return
+	}
+	current.LastResultID = result.ID
+	if err := s.repository.SaveMonitor(current); err != nil {
+		log.Error("Failed to update monitor", zap.Error(err))
+	}
+}
+
+// alert notifies the owner of a monitor about the changes a run found
+func (s *MonitorService) alert(ctx context.Context, monitor *Monitor, run *Run, changes []Change) {
+	log := s.logger.WithContext(ctx).With(
+		zap.String("monitor_id", monitor.ID),
+		zap.String("run_id", run.ID),
+	)
+
+	lines := make([]string, 0, min(len(changes), maxAlertChanges)+1)
+	for _, change := range changes[:min(len(changes), maxAlertChanges)] {
+		lines = append(lines, change.String())
+	}
+	if len(changes) > maxAlertChanges {
+		lines = append(lines, fmt.Sprintf("and %d more", len(changes)-maxAlertChanges))
+	}
+
+	if s.notifier == nil {
+		log.Warn("Monitor found changes", zap.Strings("changes", lines))
+		return
+	}
+
+	notification := notificationdomain.Notification{
+		ID:         "monitor-" + run.ID,
+		Type:       notificationdomain.EventTypeMonitorChanges,
+		OccurredAt: time.Now().UTC(),
+		UserID:     monitor.UserID,
+		ScanID:     run.ScanID,
+		Subject:    fmt.Sprintf("Monitor %s found %d changes", monitor.Name, len(changes)),
+		Message:    strings.Join(lines, "\n"),
+		Attributes: map[string]string{
+			"monitor_id":         monitor.ID,
+			"run_id":             run.ID,
+			"target":             monitor.Target,
+			"result_id":          run.ResultID,
+			"previous_result_id": run.PreviousID,
+		},
+	}
+	if monitor.Owner != nil {
+		notification.TenantID = monitor.Owner.TenantID
+	}
+
+	// The alert is delivered even if the run is cancelled meanwhile
+	notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.notifyTimeout)
+	defer cancel()
+	if err := s.notifier.Notify(notifyCtx, notification); err != nil {
+		log.Error("Failed to send monitor alert",
+			zap.String("notification_id", notification.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// saveRun stores the state of a run, logging failures
+func (s *MonitorService) saveRun(ctx context.Context, run *Run) {
+	if err := s.repository.SaveRun(run); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to save monitor run",
+			zap.String("run_id", run.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// finishRun removes a run from the active runs of its monitor
+func (s *MonitorService) finishRun(monitorID, runID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cancel, ok := s.runCancels[runID]; ok {
+		cancel()
+		delete(s.runCancels, runID)
+	}
+	if s.activeRuns[monitorID] == runID {
+		delete(s.activeRuns, monitorID)
+	}
+}
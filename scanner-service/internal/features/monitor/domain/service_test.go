@@ -0,0 +1,229 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/monitor/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/monitor/repository"
+	notificationdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeScanRunner returns the given hosts for consecutive scans and keeps the results.
+// Scans wait for gate to be closed if it is set.
+type fakeScanRunner struct {
+	mu      sync.Mutex
+	scans   [][]scandomain.Host
+	results map[string]*scandomain.ScanResult
+	gate    chan struct{}
+}
+
+func (f *fakeScanRunner) CheckScan(ctx context.Context, options scandomain.ScanOptions) error {
+	return nil
+}
+
+func (f *fakeScanRunner) RunScan(ctx context.Context, scan *scandomain.Scan) (*scandomain.ScanResult, error) {
+	if f.gate != nil {
+		<-f.gate
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	scan.ID = fmt.Sprintf("scan-%d", len(f.results)+1)
+	scan.Status = scandomain.ScanStatusCompleted
+	result := &scandomain.ScanResult{ID: "result-" + scan.ID, ScanID: scan.ID, UserID: scan.UserID, Hosts: f.scans[0]}
+	f.scans = f.scans[1:]
+	f.results[result.ID] = result
+	return result, nil
+}
+
+func (f *fakeScanRunner) GetScanResult(ctx context.Context, id string) (*scandomain.ScanResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	result, ok := f.results[id]
+	if !ok {
+		return nil, errors.NewNotFound("scan result not found", nil)
+	}
+	return result, nil
+}
+
+// fakeNotifier records the notifications it delivers
+type fakeNotifier struct {
+	mu            sync.Mutex
+	notifications []notificationdomain.Notification
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, notification notificationdomain.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func principalContext(userID string, role authdomain.Role) context.Context {
+	return authdomain.WithPrincipal(context.Background(), &authdomain.Principal{
+		UserID: userID,
+		Roles:  []authdomain.Role{role},
+	})
+}
+
+// runMonitor runs a monitor now and waits for the run to complete
+func runMonitor(t *testing.T, service *domain.MonitorService, ctx context.Context, id string) *domain.Run {
+	started, err := service.RunNow(ctx, id)
+	require.NoError(t, err)
+
+	var run *domain.Run
+	require.Eventually(t, func() bool {
+		runs, err := service.ListRuns(ctx, id)
+		require.NoError(t, err)
+		run = runs[0]
+		return run.ID == started.ID && run.Status != domain.RunStatusRunning
+	}, time.Second, 10*time.Millisecond)
+	return run
+}
+
+func TestCreateMonitor(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	service := domain.NewMonitorService(repository.NewMemoryMonitorRepository(log), &fakeScanRunner{}, log)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	var monitorErr *errors.Error
+
+	for _, monitor := range []domain.Monitor{
+		{Name: "", Target: "10.0.0.0/24", Schedule: "@hourly"},
+		{Name: "dmz", Target: "not a target!", Schedule: "@hourly"},
+		{Name: "dmz", Target: "10.0.0.0/24", Schedule: "every day"},
+		{Name: "dmz", Target: "10.0.0.0/24", Schedule: "@hourly", AlertOn: []domain.ChangeType{"port_flapped"}},
+	} {
+		_, err := service.CreateMonitor(ctx, "alice", monitor)
+		require.ErrorAs(t, err, &monitorErr, monitor)
+		assert.Equal(t, errors.ErrInvalidInput, monitorErr.Type, monitor)
+	}
+
+	monitor, err := service.CreateMonitor(ctx, "alice", domain.Monitor{Name: " dmz ", Target: "10.0.0.7/24", Schedule: "0 * * * *"})
+	require.NoError(t, err)
+	assert.Equal(t, "dmz", monitor.Name)
+	assert.Equal(t, "10.0.0.0/24", monitor.Target)
+	assert.Equal(t, domain.DefaultAlertChanges, monitor.AlertOn)
+
+	// Monitors of other users are reported as not found
+	_, err = service.GetMonitor(principalContext("bob", authdomain.RoleViewer), monitor.ID)
+	require.ErrorAs(t, err, &monitorErr)
+	assert.Equal(t, errors.ErrNotFound, monitorErr.Type)
+	_, err = service.RunNow(principalContext("bob", authdomain.RoleOperator), monitor.ID)
+	require.ErrorAs(t, err, &monitorErr)
+	assert.Equal(t, errors.ErrNotFound, monitorErr.Type)
+}
+
+func TestMonitorAlertsAboutChanges(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	web := scandomain.Host{IP: "10.0.0.1", Status: "up", Ports: []scandomain.Port{
+		{Port: 443, Protocol: "tcp", State: "open", Service: "https", Product: "nginx", Version: "1.24.0"},
+	}}
+	upgraded := scandomain.Host{IP: "10.0.0.1", Status: "up", Ports: []scandomain.Port{
+		{Port: 443, Protocol: "tcp", State: "open", Service: "https", Product: "nginx", Version: "1.26.1"},
+	}}
+	runner := &fakeScanRunner{
+		scans: [][]scandomain.Host{
+			{web},
+			{web},
+			{web, {IP: "10.0.0.9", Status: "up", Ports: []scandomain.Port{{Port: 23, Protocol: "tcp", State: "open", Service: "telnet"}}}},
+			{upgraded},
+		},
+		results: make(map[string]*scandomain.ScanResult),
+	}
+	notifier := &fakeNotifier{}
+	service := domain.NewMonitorService(repository.NewMemoryMonitorRepository(log), runner, log)
+	service.SetNotifier(notifier, time.Second)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	monitor, err := service.CreateMonitor(ctx, "alice", domain.Monitor{Name: "dmz", Target: "10.0.0.0/28", Schedule: "@daily"})
+	require.NoError(t, err)
+
+	// The first run records the result later runs are compared against
+	run := runMonitor(t, service, ctx, monitor.ID)
+	assert.Equal(t, domain.RunStatusBaseline, run.Status)
+	assert.Equal(t, "scan-1", run.ScanID)
+
+	// Unchanged results generate no alert
+	run = runMonitor(t, service, ctx, monitor.ID)
+	assert.Equal(t, domain.RunStatusUnchanged, run.Status)
+	assert.Equal(t, "result-scan-1", run.PreviousID)
+	assert.Empty(t, run.Changes)
+	assert.Empty(t, notifier.notifications)
+
+	// A new host with an open port is alerted about
+	run = runMonitor(t, service, ctx, monitor.ID)
+	assert.Equal(t, domain.RunStatusChanged, run.Status)
+	assert.Len(t, run.Changes, 2)
+	assert.Equal(t, 2, run.Alerted)
+	require.Len(t, notifier.notifications, 1)
+	notification := notifier.notifications[0]
+	assert.Equal(t, "monitor-"+run.ID, notification.ID)
+	assert.Equal(t, notificationdomain.EventTypeMonitorChanges, notification.Type)
+	assert.Equal(t, "alice", notification.UserID)
+	assert.Equal(t, "scan-3", notification.ScanID)
+	assert.Equal(t, "new host 10.0.0.9\nnew open port 10.0.0.9:23/tcp telnet", notification.Message)
+	assert.Equal(t, monitor.ID, notification.Attributes["monitor_id"])
+
+	// Removed hosts are recorded but not alerted about by default, version changes are
+	run = runMonitor(t, service, ctx, monitor.ID)
+	assert.Equal(t, []domain.ChangeType{domain.ChangeTypeServiceChanged, domain.ChangeTypeHostRemoved},
+		[]domain.ChangeType{run.Changes[0].Type, run.Changes[1].Type})
+	assert.Equal(t, 1, run.Alerted)
+	require.Len(t, notifier.notifications, 2)
+	assert.Contains(t, notifier.notifications[1].Message, `changed from "https nginx 1.24.0" to "https nginx 1.26.1"`)
+
+	runs, err := service.ListRuns(ctx, monitor.ID)
+	require.NoError(t, err)
+	assert.Len(t, runs, 4)
+}
+
+func TestMonitorUpdatedDuringRun(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	runner := &fakeScanRunner{
+		scans:   [][]scandomain.Host{{{IP: "10.0.0.1", Status: "up"}}},
+		results: make(map[string]*scandomain.ScanResult),
+		gate:    make(chan struct{}),
+	}
+	service := domain.NewMonitorService(repository.NewMemoryMonitorRepository(log), runner, log)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	monitor, err := service.CreateMonitor(ctx, "alice", domain.Monitor{Name: "dmz", Target: "10.0.0.0/24", Schedule: "@hourly"})
+	require.NoError(t, err)
+
+	started, err := service.RunNow(ctx, monitor.ID)
+	require.NoError(t, err)
+
+	// The update made while the scan runs is kept, and the run is still recorded
+	_, err = service.UpdateMonitor(ctx, monitor.ID, domain.Monitor{Name: "web", Target: "10.0.0.0/24", Schedule: "@daily"})
+	require.NoError(t, err)
+	close(runner.gate)
+
+	require.Eventually(t, func() bool {
+		runs, err := service.ListRuns(ctx, monitor.ID)
+		require.NoError(t, err)
+		return runs[0].Status != domain.RunStatusRunning
+	}, time.Second, 10*time.Millisecond)
+
+	current, err := service.GetMonitor(ctx, monitor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "web", current.Name)
+	assert.Equal(t, "@daily", current.Schedule)
+	assert.Equal(t, started.ID, current.LastRunID)
+
+	// The result of a run started before the update is not compared against
+	assert.Empty(t, current.LastResultID)
+}
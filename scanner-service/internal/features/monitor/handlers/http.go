@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"net/http"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/monitor/domain"
+	workflowdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// MonitorHandler handles HTTP requests for monitors
+type MonitorHandler struct {
+	monitorService *domain.MonitorService
+	logger         *logger.Logger
+}
+
+// NewMonitorHandler creates a new MonitorHandler
+func NewMonitorHandler(monitorService *domain.MonitorService, logger *logger.Logger) *MonitorHandler {
+	return &MonitorHandler{
+		monitorService: monitorService,
+		logger:         logger,
+	}
+}
+
+// MonitorRequest represents the request body for creating or replacing a monitor
+type MonitorRequest struct {
+	Name     string                  `json:"name" binding:"required"`
+	Target   string                  `json:"target" binding:"required"`
+	Scan     workflowdomain.StepScan `json:"scan"`
+	Schedule string                  `json:"schedule" binding:"required"`
+	AlertOn  []domain.ChangeType     `json:"alert_on"`
+	Paused   bool                    `json:"paused"`
+}
+
+// monitor returns the monitor described by the request
+func (r MonitorRequest) monitor() domain.Monitor {
+	return domain.Monitor{
+		Name:     r.Name,
+		Target:   r.Target,
+		Scan:     r.Scan,
+		Schedule: r.Schedule,
+		AlertOn:  r.AlertOn,
+		Paused:   r.Paused,
+	}
+}
+
+// CreateMonitor handles the request to create a monitor
+func (h *MonitorHandler) CreateMonitor(c *gin.Context) {
+	var req MonitorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	monitor, err := h.monitorService.CreateMonitor(c.Request.Context(), c.GetString("user_id"), req.monitor())
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to create monitor",
+			zap.Error(err),
+			zap.String("target", req.Target),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, monitor)
+}
+
+// UpdateMonitor handles the request to replace a monitor
+func (h *MonitorHandler) UpdateMonitor(c *gin.Context) {
+	var req MonitorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	monitor, err := h.monitorService.UpdateMonitor(c.Request.Context(), c.Param("id"), req.monitor())
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to update monitor",
+			zap.Error(err),
+			zap.String("monitor_id", c.Param("id")),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, monitor)
+}
+
+// GetMonitor handles the request to get a monitor
+func (h *MonitorHandler) GetMonitor(c *gin.Context) {
+	monitor, err := h.monitorService.GetMonitor(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, monitor)
+}
+
+// ListMonitors handles the request to list monitors.
+// Admins may list another user's monitors with ?user_id= or all monitors with ?all=true.
+func (h *MonitorHandler) ListMonitors(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if queryUserID := c.Query("user_id"); queryUserID != "" {
+		userID = queryUserID
+	}
+	if c.Query("all") == "true" {
+		userID = ""
+	}
+
+	monitors, err := h.monitorService.ListMonitors(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to list monitors",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"monitors": monitors,
+		"count":    len(monitors),
+	})
+}
+
+// DeleteMonitor handles the request to delete a monitor
+func (h *MonitorHandler) DeleteMonitor(c *gin.Context) {
+	monitorID := c.Param("id")
+
+	if err := h.monitorService.DeleteMonitor(c.Request.Context(), monitorID); err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to delete monitor",
+			zap.Error(err),
+			zap.String("monitor_id", monitorID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Monitor deleted",
+		"monitor_id": monitorID,
+	})
+}
+
+// RunNow handles the request to start a run of a monitor now
+func (h *MonitorHandler) RunNow(c *gin.Context) {
+	run, err := h.monitorService.RunNow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to run monitor",
+			zap.Error(err),
+			zap.String("monitor_id", c.Param("id")),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, run)
+}
+
+// ListRuns handles the request to list the recent runs of a monitor and their changes
+func (h *MonitorHandler) ListRuns(c *gin.Context) {
+	runs, err := h.monitorService.ListRuns(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"runs":  runs,
+		"count": len(runs),
+	})
+}
+
+// RegisterRoutes registers the monitor handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *MonitorHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1/monitors", middleware...)
+
+	viewer := authhandlers.RequireRole(authdomain.RoleViewer)
+	operator := authhandlers.RequireRole(authdomain.RoleOperator)
+
+	api.POST("", operator, h.CreateMonitor)
+	api.GET("", viewer, h.ListMonitors)
+	api.GET("/:id", viewer, h.GetMonitor)
+	api.PUT("/:id", operator, h.UpdateMonitor)
+	api.DELETE("/:id", operator, h.DeleteMonitor)
+
+	api.POST("/:id/runs", operator, h.RunNow)
+	api.GET("/:id/runs", viewer, h.ListRuns)
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/monitor/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MemoryMonitorRepository is an in-memory implementation of the MonitorRepository interface
+type MemoryMonitorRepository struct {
+	logger   *logger.Logger
+	monitors map[string]*domain.Monitor
+	runs     map[string][]*domain.Run // Monitor ID -> runs, oldest first
+	mu       sync.RWMutex
+}
+
+// NewMemoryMonitorRepository creates a new MemoryMonitorRepository
+func NewMemoryMonitorRepository(logger *logger.Logger) *MemoryMonitorRepository {
+	return &MemoryMonitorRepository{
+		logger:   logger,
+		monitors: make(map[string]*domain.Monitor),
+		runs:     make(map[string][]*domain.Run),
+	}
+}
+
+// SaveMonitor saves a new or changed monitor to the repository
+func (r *MemoryMonitorRepository) SaveMonitor(monitor *domain.Monitor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.monitors[monitor.ID] = monitor.Copy()
+
+	r.logger.Debug("Saved monitor",
+		zap.String("monitor_id", monitor.ID),
+		zap.String("user_id", monitor.UserID),
+	)
+
+	return nil
+}
+
+// GetMonitorByID gets a monitor by ID from the repository
+func (r *MemoryMonitorRepository) GetMonitorByID(id string) (*domain.Monitor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	monitor, ok := r.monitors[id]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("monitor with ID %s not found", id), nil)
+	}
+
+	return monitor.Copy(), nil
+}
+
+// ListMonitors lists the monitors of a user, or of all users if userID is empty, by name
+func (r *MemoryMonitorRepository) ListMonitors(userID string) ([]*domain.Monitor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	monitors := make([]*domain.Monitor, 0)
+	for _, monitor := range r.monitors {
+		if userID == "" || monitor.UserID == userID {
+			monitors = append(monitors, monitor.Copy())
+		}
+	}
+
+	sort.Slice(monitors, func(i, j int) bool {
+		if monitors[i].Name != monitors[j].Name {
+			return monitors[i].Name < monitors[j].Name
+		}
+		return monitors[i].ID < monitors[j].ID
+	})
+
+	return monitors, nil
+}
+
+// DeleteMonitor deletes a monitor and its runs from the repository
+func (r *MemoryMonitorRepository) DeleteMonitor(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.monitors[id]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("monitor with ID %s not found", id), nil)
+	}
+
+	delete(r.monitors, id)
+	delete(r.runs, id)
+
+	return nil
+}
+
+// SaveRun saves a new or changed run of a monitor. Only the newest domain.MaxRuns
+// runs of a monitor are kept.
+func (r *MemoryMonitorRepository) SaveRun(run *domain.Run) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	runs := r.runs[run.MonitorID]
+	for i, existing := range runs {
+		if existing.ID == run.ID {
+			runs[i] = run.Copy()
+			return nil
+		}
+	}
+
+	runs = append(runs, run.Copy())
+	if len(runs) > domain.MaxRuns {
+		runs = runs[len(runs)-domain.MaxRuns:]
+	}
+	r.runs[run.MonitorID] = runs
+
+	return nil
+}
+
+// ListRuns lists the runs of a monitor, newest first
+func (r *MemoryMonitorRepository) ListRuns(monitorID string) ([]*domain.Run, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	runs := make([]*domain.Run, 0, len(r.runs[monitorID]))
+	for i := len(r.runs[monitorID]) - 1; i >= 0; i-- {
+		runs = append(runs, r.runs[monitorID][i].Copy())
+	}
+
+	return runs, nil
+}
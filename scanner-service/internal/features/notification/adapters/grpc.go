@@ -0,0 +1,81 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	notificationv1 "github.com/furkansarikaya/nmap-ui-microservices/api/proto/notification/v1"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventTypes maps event types to their proto enum values
+var eventTypes = map[domain.EventType]notificationv1.EventType{
+	domain.EventTypeScanCompleted:        notificationv1.EventType_EVENT_TYPE_SCAN_COMPLETED,
+	domain.EventTypeScanFailed:           notificationv1.EventType_EVENT_TYPE_SCAN_FAILED,
+	domain.EventTypeScanCancelled:        notificationv1.EventType_EVENT_TYPE_SCAN_CANCELLED,
+	domain.EventTypeWorkflowRunCompleted: notificationv1.EventType_EVENT_TYPE_WORKFLOW_RUN_COMPLETED,
+	domain.EventTypeWorkflowRunFailed:    notificationv1.EventType_EVENT_TYPE_WORKFLOW_RUN_FAILED,
+	domain.EventTypeScheduledRunSkipped:  notificationv1.EventType_EVENT_TYPE_SCHEDULED_RUN_SKIPPED,
+	domain.EventTypeServicePanic:         notificationv1.EventType_EVENT_TYPE_SERVICE_PANIC,
+	domain.EventTypeMonitorChanges:       notificationv1.EventType_EVENT_TYPE_MONITOR_CHANGES,
+	domain.EventTypeAlertRuleTriggered:   notificationv1.EventType_EVENT_TYPE_ALERT_RULE_TRIGGERED,
+	domain.EventTypeCertificateExpiring:  notificationv1.EventType_EVENT_TYPE_CERTIFICATE_EXPIRING,
+}
+
+// GRPCNotifier delivers notifications through the NotificationService of api/proto
+type GRPCNotifier struct {
+	conn     *grpc.ClientConn
+	client   notificationv1.NotificationServiceClient
+	channels []string
+}
+
+// NewGRPCNotifier creates a notifier sending to the notification service at address over
+// the given channels, or the channels the notification service prefers if empty
+func NewGRPCNotifier(address string, channels []string) (*GRPCNotifier, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification service client: %w", err)
+	}
+	return &GRPCNotifier{
+		conn:     conn,
+		client:   notificationv1.NewNotificationServiceClient(conn),
+		channels: channels,
+	}, nil
+}
+
+// Notify delivers a notification over its own channels, or the channels of the notifier
+func (n *GRPCNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	channels := n.channels
+	if len(notification.Channels) > 0 {
+		channels = notification.Channels
+	}
+
+	_, err := n.client.Send(ctx, &notificationv1.SendRequest{
+		Notification: &notificationv1.Notification{
+			Id:         notification.ID,
+			Type:       eventTypes[notification.Type],
+			OccurredAt: timestamppb.New(notification.OccurredAt),
+			UserId:     notification.UserID,
+			TenantId:   notification.TenantID,
+			ScanId:     notification.ScanID,
+			WorkflowId: notification.WorkflowID,
+			RunId:      notification.RunID,
+			Subject:    notification.Subject,
+			Message:    notification.Message,
+			Attributes: notification.Attributes,
+		},
+		Channels: channels,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the notification service
+func (n *GRPCNotifier) Close() error {
+	return n.conn.Close()
+}
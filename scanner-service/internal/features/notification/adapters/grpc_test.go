@@ -0,0 +1,71 @@
+package adapters
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	notificationv1 "github.com/furkansarikaya/nmap-ui-microservices/api/proto/notification/v1"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeNotificationServer records the requests it receives
+type fakeNotificationServer struct {
+	notificationv1.UnimplementedNotificationServiceServer
+	requests []*notificationv1.SendRequest
+}
+
+func (s *fakeNotificationServer) Send(ctx context.Context, req *notificationv1.SendRequest) (*notificationv1.SendResponse, error) {
+	s.requests = append(s.requests, req)
+	return &notificationv1.SendResponse{Delivered: req.Channels}, nil
+}
+
+func TestGRPCNotifier(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	fake := &fakeNotificationServer{}
+	server := grpc.NewServer()
+	notificationv1.RegisterNotificationServiceServer(server, fake)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	notifier, err := NewGRPCNotifier(lis.Addr().String(), []string{"slack"})
+	require.NoError(t, err)
+	defer notifier.Close()
+
+	occurredAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	err = notifier.Notify(context.Background(), domain.Notification{
+		ID:         "panic-1",
+		Type:       domain.EventTypeServicePanic,
+		OccurredAt: occurredAt,
+		Subject:    "scanner-service recovered from a panic",
+		Message:    "boom",
+		Attributes: map[string]string{"request_id": "req-1"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, fake.requests, 1)
+	req := fake.requests[0]
+	assert.Equal(t, []string{"slack"}, req.Channels)
+	assert.Equal(t, "panic-1", req.Notification.Id)
+	assert.Equal(t, notificationv1.EventType_EVENT_TYPE_SERVICE_PANIC, req.Notification.Type)
+	assert.Equal(t, occurredAt, req.Notification.OccurredAt.AsTime())
+	assert.Equal(t, "boom", req.Notification.Message)
+	assert.Equal(t, "req-1", req.Notification.Attributes["request_id"])
+
+	// Channels of the notification replace the channels of the notifier
+	err = notifier.Notify(context.Background(), domain.Notification{
+		ID:       "alert-rule-1",
+		Type:     domain.EventTypeAlertRuleTriggered,
+		Channels: []string{"security"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, fake.requests, 2)
+	assert.Equal(t, []string{"security"}, fake.requests[1].Channels)
+	assert.Equal(t, notificationv1.EventType_EVENT_TYPE_ALERT_RULE_TRIGGERED, fake.requests[1].Notification.Type)
+}
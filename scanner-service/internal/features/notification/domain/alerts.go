@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Panic describes a panic recovered while serving a request
+type Panic struct {
+	Value     string
+	Stack     string
+	Method    string // HTTP method or full gRPC method
+	Path      string // HTTP path, empty for gRPC requests
+	RequestID string
+}
+
+// maxStackLength is the length the stack of a panic alert is cut at
+const maxStackLength = 4096
+
+// AlertService alerts operators about failures of the service through a notifier
+type AlertService struct {
+	notifier Notifier
+	service  string
+	timeout  time.Duration
+	logger   *logger.Logger
+}
+
+// NewAlertService creates a new AlertService sending alerts about the named service
+func NewAlertService(notifier Notifier, service string, timeout time.Duration, logger *logger.Logger) *AlertService {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &AlertService{
+		notifier: notifier,
+		service:  service,
+		timeout:  timeout,
+		logger:   logger,
+	}
+}
+
+// AlertPanic sends an alert about a recovered panic in the background. Alerts about the
+// same panic are delivered once per hour, so a failing route does not flood operators.
+func (s *AlertService) AlertPanic(ctx context.Context, p Panic) {
+	now := time.Now().UTC()
+	location := p.Method + " " + p.Path
+	if p.Path == "" {
+		location = p.Method
+	}
+	sum := sha256.Sum256([]byte(location + "\n" + p.Value))
+
+	stack := p.Stack
+	if len(stack) > maxStackLength {
+		stack = stack[:maxStackLength]
+	}
+
+	notification := Notification{
+		ID:         fmt.Sprintf("panic-%s-%s", hex.EncodeToString(sum[:8]), now.Truncate(time.Hour).Format("2006010215")),
+		Type:       EventTypeServicePanic,
+		OccurredAt: now,
+		Subject:    fmt.Sprintf("%s recovered from a panic in %s", s.service, location),
+		Message:    p.Value,
+		Attributes: map[string]string{
+			"service":    s.service,
+			"method":     p.Method,
+			"path":       p.Path,
+			"request_id": p.RequestID,
+			"stack":      stack,
+		},
+	}
+
+	// The alert outlives the failed request
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+	go func() {
+		defer cancel()
+		if err := s.notifier.Notify(ctx, notification); err != nil {
+			s.logger.WithContext(ctx).Error("Failed to send panic alert",
+				zap.String("notification_id", notification.ID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
@@ -0,0 +1,83 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/notification/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// channelNotifier passes notifications to a channel
+type channelNotifier struct {
+	notifications chan domain.Notification
+	err           error
+}
+
+func (n *channelNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	n.notifications <- notification
+	return n.err
+}
+
+// received returns the next notification sent to the notifier
+func (n *channelNotifier) received(t *testing.T) domain.Notification {
+	t.Helper()
+	select {
+	case notification := <-n.notifications:
+		return notification
+	case <-time.After(time.Second):
+		require.FailNow(t, "no notification sent")
+		return domain.Notification{}
+	}
+}
+
+func TestAlertPanic(t *testing.T) {
+	notifier := &channelNotifier{notifications: make(chan domain.Notification, 3)}
+	service := domain.NewAlertService(notifier, "scanner-service", time.Second, &logger.Logger{Logger: zap.NewNop()})
+
+	// The alert is sent even though the request has ended
+	ctx, cancel := context.WithCancel(context.Background())
+	panicked := domain.Panic{
+		Value:     "assignment to entry in nil map",
+		Stack:     strings.Repeat("goroutine 1 [running]:\n", 500),
+		Method:    "GET",
+		Path:      "/api/v1/scans/scan-1",
+		RequestID: "req-1",
+	}
+	service.AlertPanic(ctx, panicked)
+	cancel()
+
+	notification := notifier.received(t)
+	assert.Equal(t, domain.EventTypeServicePanic, notification.Type)
+	assert.Equal(t, "scanner-service recovered from a panic in GET /api/v1/scans/scan-1", notification.Subject)
+	assert.Equal(t, "assignment to entry in nil map", notification.Message)
+	assert.Equal(t, "req-1", notification.Attributes["request_id"])
+	assert.LessOrEqual(t, len(notification.Attributes["stack"]), 4096)
+	assert.True(t, strings.HasPrefix(notification.ID, "panic-"))
+
+	// The same panic has the same ID within the hour, so it is delivered once
+	panicked.RequestID = "req-2"
+	service.AlertPanic(context.Background(), panicked)
+	assert.Equal(t, notification.ID, notifier.received(t).ID)
+
+	// Other panics are delivered separately
+	service.AlertPanic(context.Background(), domain.Panic{Value: "index out of range", Method: "/nmapui.scanner.v1.ScannerService/GetScan"})
+	other := notifier.received(t)
+	assert.NotEqual(t, notification.ID, other.ID)
+	assert.Equal(t, "scanner-service recovered from a panic in /nmapui.scanner.v1.ScannerService/GetScan", other.Subject)
+}
+
+func TestAlertPanicNotifierFailure(t *testing.T) {
+	notifier := &channelNotifier{notifications: make(chan domain.Notification, 1), err: errors.New("connection refused")}
+	service := domain.NewAlertService(notifier, "scanner-service", time.Second, &logger.Logger{Logger: zap.NewNop()})
+
+	// Failures to deliver the alert are only logged
+	service.AlertPanic(context.Background(), domain.Panic{Value: "boom", Method: "GET", Path: "/"})
+	notifier.received(t)
+}
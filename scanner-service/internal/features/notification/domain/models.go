@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EventType is the event a notification is about
+type EventType string
+
+// Event type constants
+const (
+	EventTypeScanCompleted        EventType = "scan_completed"
+	EventTypeScanFailed           EventType = "scan_failed"
+	EventTypeScanCancelled        EventType = "scan_cancelled"
+	EventTypeWorkflowRunCompleted EventType = "workflow_run_completed"
+	EventTypeWorkflowRunFailed    EventType = "workflow_run_failed"
+	EventTypeScheduledRunSkipped  EventType = "scheduled_run_skipped"
+	EventTypeServicePanic         EventType = "service_panic"
+	EventTypeMonitorChanges       EventType = "monitor_changes"
+	EventTypeAlertRuleTriggered   EventType = "alert_rule_triggered"
+	EventTypeCertificateExpiring  EventType = "certificate_expiring"
+)
+
+// Notification is a notification about an event
+type Notification struct {
+	ID         string // Notifications with the same ID are delivered once
+	Type       EventType
+	OccurredAt time.Time
+	UserID     string // User notified, empty for operator alerts
+	TenantID   string
+	ScanID     string
+	WorkflowID string
+	RunID      string
+	Subject    string // Short summary, e.g. an e-mail subject
+	Message    string
+	Attributes map[string]string // Additional values of the event
+	Channels   []string          // Channels to deliver over, empty for the notifier's channels
+}
+
+// Notifier delivers notifications
+type Notifier interface {
+	// Notify delivers a notification
+	Notify(ctx context.Context, notification Notification) error
+}
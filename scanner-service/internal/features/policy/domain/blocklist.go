@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+	"go.uber.org/zap"
+)
+
+// Blocklist aliases that expand to predefined address sets
+const (
+	BlocklistAliasReserved = "reserved" // IANA special-purpose and documentation ranges
+	BlocklistAliasPublic   = "public"   // Everything outside private, loopback and link-local space
+)
+
+// reservedNetworks are the networks the "reserved" alias expands to
+var reservedNetworks = []string{
+	"0.0.0.0/8",
+	"192.0.0.0/24",
+	"192.0.2.0/24",
+	"198.18.0.0/15",
+	"198.51.100.0/24",
+	"203.0.113.0/24",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"2001:db8::/32",
+	"ff00::/8",
+}
+
+// privateNetworks are the only networks allowed when the "public" alias is set
+var privateNetworks = []string{
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"fc00::/7",
+	"fe80::/10",
+	"::1/128",
+}
+
+// resolveTimeout bounds the hostname lookups of a single check
+const resolveTimeout = 5 * time.Second
+
+// Resolver resolves hostnames to IP addresses
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Blocklist rejects targets that overlap forbidden networks.
+// It applies to every user, including admins.
+type Blocklist struct {
+	networks    []*net.IPNet
+	private     []*net.IPNet
+	blockPublic bool
+	resolver    Resolver
+	logger      *logger.Logger
+}
+
+// NewBlocklist creates a new Blocklist from CIDRs, single addresses and aliases.
+// A nil resolver uses the system resolver.
+func NewBlocklist(entries []string, resolver Resolver, logger *logger.Logger) (*Blocklist, error) {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	blocklist := &Blocklist{
+		private:  mustParseNetworks(privateNetworks),
+		resolver: resolver,
+		logger:   logger,
+	}
+
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch entry {
+		case "":
+			continue
+		case BlocklistAliasReserved:
+			blocklist.networks = append(blocklist.networks, mustParseNetworks(reservedNetworks)...)
+		case BlocklistAliasPublic:
+			blocklist.blockPublic = true
+		default:
+			network, err := parseNetwork(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid blocklist entry %q: %w", entry, err)
+			}
+			blocklist.networks = append(blocklist.networks, network)
+		}
+	}
+
+	return blocklist, nil
+}
+
+// IsEmpty reports whether the blocklist blocks nothing
+func (b *Blocklist) IsEmpty() bool {
+	return len(b.networks) == 0 && !b.blockPublic
+}
+
+// CheckTarget checks that no target in the specification overlaps a blocked network.
+// Hostnames are resolved and every resolved address is checked.
+func (b *Blocklist) CheckTarget(ctx context.Context, target string) error {
+	if b.IsEmpty() {
+		return nil
+	}
+
+	for _, item := range utils.SplitTargets(target) {
+		ranges, err := b.resolve(ctx, item)
+		if err != nil {
+			return errors.NewInvalidInput(fmt.Sprintf("could not resolve target %s", item), err)
+		}
+
+		for _, r := range ranges {
+			if b.blocked(r[0], r[1]) {
+				b.logger.Warn("Blocked target rejected",
+					zap.String("target", item),
+					zap.String("address", r[0].String()),
+				)
+				return errors.NewForbidden(fmt.Sprintf("target %s is in a blocked network", item), nil)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolve turns a single target into address ranges, resolving hostnames if needed
+func (b *Blocklist) resolve(ctx context.Context, target string) ([][2]net.IP, error) {
+	start, end, isAddress := scandomain.ParseTargetRange(target)
+	if isAddress {
+		if start == nil {
+			return nil, fmt.Errorf("unsupported address format")
+		}
+		return [][2]net.IP{{start, end}}, nil
+	}
+
+	// nmap accepts "hostname/prefix", which scans the network around the resolved address
+	host, prefix := target, -1
+	if slash := strings.LastIndex(target, "/"); slash > 0 {
+		bits, err := strconv.Atoi(target[slash+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix length")
+		}
+		host, prefix = target[:slash], bits
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	addrs, err := b.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no addresses found")
+	}
+
+	ranges := make([][2]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		if prefix < 0 {
+			ranges = append(ranges, [2]net.IP{addr.IP, addr.IP})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(fmt.Sprintf("%s/%d", addr.IP, prefix))
+		if err != nil {
+			return nil, err
+		}
+		first, last, _ := scandomain.ParseTargetRange(network.String())
+		ranges = append(ranges, [2]net.IP{first, last})
+	}
+
+	return ranges, nil
+}
+
+// blocked reports whether the address range from start to end overlaps a blocked network
+func (b *Blocklist) blocked(start, end net.IP) bool {
+	for _, network := range b.networks {
+		if overlaps(network, start, end) {
+			return true
+		}
+	}
+
+	if b.blockPublic {
+		for _, network := range b.private {
+			if network.Contains(start) && network.Contains(end) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// overlaps reports whether a network and the address range from start to end share any address
+func overlaps(network *net.IPNet, start, end net.IP) bool {
+	if network.Contains(start) || network.Contains(end) {
+		return true
+	}
+
+	// The range may fully contain the network
+	first := network.IP
+	return compareIP(start, first) <= 0 && compareIP(first, end) <= 0
+}
+
+// compareIP compares two addresses of the same family
+func compareIP(a, b net.IP) int {
+	if a4, b4 := a.To4(), b.To4(); a4 != nil && b4 != nil {
+		a, b = a4, b4
+	} else {
+		a, b = a.To16(), b.To16()
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// mustParseNetworks parses a static list of CIDRs
+func mustParseNetworks(cidrs []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
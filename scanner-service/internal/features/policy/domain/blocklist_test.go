@@ -0,0 +1,94 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/policy/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// staticResolver resolves hostnames from a fixed table
+type staticResolver map[string][]string
+
+func (r staticResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, fmt.Errorf("no such host: %s", host)
+	}
+
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func newTestBlocklist(t *testing.T, entries ...string) *domain.Blocklist {
+	zapLogger, _ := zap.NewDevelopment()
+	resolver := staticResolver{
+		"internal.example": {"10.1.2.3"},
+		"mixed.example":    {"192.168.1.1", "10.1.0.9"},
+		"safe.example":     {"192.168.1.1"},
+		"public.example":   {"8.8.8.8"},
+	}
+
+	blocklist, err := domain.NewBlocklist(entries, resolver, &logger.Logger{Logger: zapLogger})
+	assert.NoError(t, err)
+	return blocklist
+}
+
+func TestBlocklistCheckTarget(t *testing.T) {
+	blocklist := newTestBlocklist(t, "10.1.0.0/16", "reserved")
+	ctx := context.Background()
+
+	allowedTargets := []string{
+		"10.2.0.1",
+		"10.0.0.0/16",
+		"192.168.1.0/24",
+		"safe.example",
+		"10.2.0.1-254",
+	}
+	for _, target := range allowedTargets {
+		assert.NoError(t, blocklist.CheckTarget(ctx, target), target)
+	}
+
+	blockedTargets := []string{
+		"10.1.5.5",
+		"10.0.0.0/8",
+		"10.1.255.250-255",
+		"internal.example",
+		"mixed.example",
+		"safe.example/8",
+		"224.0.0.1",
+		"192.168.1.1, 203.0.113.10",
+	}
+	for _, target := range blockedTargets {
+		assert.Error(t, blocklist.CheckTarget(ctx, target), target)
+	}
+
+	// Unresolvable hostnames cannot be verified
+	assert.Error(t, blocklist.CheckTarget(ctx, "unknown.example"))
+}
+
+func TestBlocklistPublic(t *testing.T) {
+	blocklist := newTestBlocklist(t, "public")
+	ctx := context.Background()
+
+	assert.NoError(t, blocklist.CheckTarget(ctx, "192.168.0.0/16"))
+	assert.NoError(t, blocklist.CheckTarget(ctx, "safe.example"))
+	assert.Error(t, blocklist.CheckTarget(ctx, "8.8.8.8"))
+	assert.Error(t, blocklist.CheckTarget(ctx, "public.example"))
+	assert.Error(t, blocklist.CheckTarget(ctx, "192.168.0.0/15"))
+}
+
+func TestNewBlocklistInvalidEntry(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+
+	_, err := domain.NewBlocklist([]string{"not-a-network"}, nil, &logger.Logger{Logger: zapLogger})
+	assert.Error(t, err)
+}
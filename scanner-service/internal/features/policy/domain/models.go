@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+)
+
+// SubjectType represents the kind of subject a policy applies to
+type SubjectType string
+
+// Subject type constants
+const (
+	SubjectTypeUser   SubjectType = "user"   // Policy applies to a single user
+	SubjectTypeTenant SubjectType = "tenant" // Policy applies to every user of a tenant
+)
+
+// TargetAllowlist represents the networks and domains a subject is permitted to scan
+type TargetAllowlist struct {
+	SubjectType SubjectType `json:"subject_type"` // Kind of subject
+	SubjectID   string      `json:"subject_id"`   // User or tenant ID
+	CIDRs       []string    `json:"cidrs"`        // Permitted networks (e.g. "10.0.0.0/8")
+	Domains     []string    `json:"domains"`      // Permitted domains, including subdomains
+	UpdatedAt   time.Time   `json:"updated_at"`   // When the allowlist was last changed
+	UpdatedBy   string      `json:"updated_by"`   // Admin who last changed the allowlist
+}
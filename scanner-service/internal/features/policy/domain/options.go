@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ScanOption identifies a scan option that can be restricted to a role
+type ScanOption string
+
+// Scan option constants
+const (
+	OptionExtraOptions     ScanOption = "extra_options"     // Raw command-line options
+	OptionOSDetection      ScanOption = "os_detection"      // -O
+	OptionServiceDetection ScanOption = "service_detection" // -sV
+	OptionScriptScan       ScanOption = "script_scan"       // -sC
+	OptionSYNScan          ScanOption = "syn_scan"          // -sS
+	OptionUDPScan          ScanOption = "udp_scan"          // -sU
+	OptionAggressiveScan   ScanOption = "aggressive_scan"   // -A
+	OptionTimingAggressive ScanOption = "timing_aggressive" // -T4
+	OptionTimingInsane     ScanOption = "timing_insane"     // -T5
+	OptionDiscovery        ScanOption = "discovery"         // Subdomain discovery before scanning
+)
+
+// knownOptions lists every option a rule may refer to
+var knownOptions = map[ScanOption]bool{
+	OptionExtraOptions:     true,
+	OptionOSDetection:      true,
+	OptionServiceDetection: true,
+	OptionScriptScan:       true,
+	OptionSYNScan:          true,
+	OptionUDPScan:          true,
+	OptionAggressiveScan:   true,
+	OptionTimingAggressive: true,
+	OptionTimingInsane:     true,
+	OptionDiscovery:        true,
+}
+
+// OptionPolicy restricts which scan options each role may use
+type OptionPolicy struct {
+	rules  map[ScanOption]authdomain.Role
+	logger *logger.Logger
+}
+
+// NewOptionPolicy creates a new OptionPolicy from a map of option name to minimum role
+func NewOptionPolicy(rules map[string]string, logger *logger.Logger) (*OptionPolicy, error) {
+	policy := &OptionPolicy{
+		rules:  make(map[ScanOption]authdomain.Role, len(rules)),
+		logger: logger,
+	}
+
+	for name, roleName := range rules {
+		option := ScanOption(strings.ToLower(strings.TrimSpace(name)))
+		if !knownOptions[option] {
+			return nil, fmt.Errorf("unknown scan option %q", name)
+		}
+
+		role, ok := authdomain.ParseRole(roleName)
+		if !ok {
+			return nil, fmt.Errorf("invalid role %q for scan option %q", roleName, name)
+		}
+
+		policy.rules[option] = role
+	}
+
+	return policy, nil
+}
+
+// Rules returns the minimum role of every restricted option
+func (p *OptionPolicy) Rules() map[ScanOption]authdomain.Role {
+	rules := make(map[ScanOption]authdomain.Role, len(p.rules))
+	for option, role := range p.rules {
+		rules[option] = role
+	}
+	return rules
+}
+
+// AuthorizeOptions checks that the caller's role permits every option used by the scan
+func (p *OptionPolicy) AuthorizeOptions(ctx context.Context, options scandomain.ScanOptions) error {
+	principal, ok := authdomain.PrincipalFromContext(ctx)
+	if !ok {
+		return errors.NewUnauthorized("authentication required", nil)
+	}
+
+	for _, option := range usedOptions(options) {
+		role, restricted := p.rules[option]
+		if !restricted || principal.HasRole(role) {
+			continue
+		}
+
+		p.logger.Warn("Restricted scan option rejected",
+			zap.String("user_id", principal.UserID),
+			zap.String("option", string(option)),
+			zap.String("required_role", string(role)),
+		)
+		return errors.NewForbidden(fmt.Sprintf("scan option %s requires the %s role", option, role), nil)
+	}
+
+	return nil
+}
+
+// usedOptions returns the restrictable options enabled by the scan options, in a stable order
+func usedOptions(options scandomain.ScanOptions) []ScanOption {
+	used := make(map[ScanOption]bool)
+
+	if len(options.ExtraOptions) > 0 {
+		used[OptionExtraOptions] = true
+	}
+	if options.OSDetection {
+		used[OptionOSDetection] = true
+	}
+	if len(options.Discovery) > 0 {
+		used[OptionDiscovery] = true
+	}
+	if options.ServiceDetection {
+		used[OptionServiceDetection] = true
+	}
+	if options.ScriptScan {
+		used[OptionScriptScan] = true
+	}
+
+	switch options.ScanType {
+	case scandomain.ScanTypeSYN:
+		used[OptionSYNScan] = true
+	case scandomain.ScanTypeUDP:
+		used[OptionUDPScan] = true
+	case scandomain.ScanTypeVersion:
+		used[OptionServiceDetection] = true
+	case scandomain.ScanTypeScript:
+		used[OptionScriptScan] = true
+	case scandomain.ScanTypeAll:
+		used[OptionAggressiveScan] = true
+		used[OptionOSDetection] = true
+		used[OptionServiceDetection] = true
+		used[OptionScriptScan] = true
+	}
+
+	switch options.TimingTemplate {
+	case scandomain.TimingAggressive:
+		used[OptionTimingAggressive] = true
+	case scandomain.TimingInsane:
+		used[OptionTimingInsane] = true
+	}
+
+	result := make([]ScanOption, 0, len(used))
+	for option := range used {
+		result = append(result, option)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return result
+}
@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"testing"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/policy/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestOptionPolicy(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	policy, err := domain.NewOptionPolicy(map[string]string{
+		"extra_options": "admin",
+		"timing_insane": "operator",
+		"os_detection":  "admin",
+	}, &logger.Logger{Logger: zapLogger})
+	assert.NoError(t, err)
+
+	operatorCtx := principalContext("alice", "", authdomain.RoleOperator)
+	adminCtx := principalContext("root", "", authdomain.RoleAdmin)
+
+	// Unrestricted and operator options
+	assert.NoError(t, policy.AuthorizeOptions(operatorCtx, scandomain.ScanOptions{
+		Target:         "10.0.0.1",
+		TimingTemplate: scandomain.TimingInsane,
+	}))
+
+	// Admin-only options
+	restricted := []scandomain.ScanOptions{
+		{Target: "10.0.0.1", ExtraOptions: []string{"--script-args=x"}},
+		{Target: "10.0.0.1", OSDetection: true},
+		{Target: "10.0.0.1", ScanType: scandomain.ScanTypeAll},
+	}
+	for _, options := range restricted {
+		err := policy.AuthorizeOptions(operatorCtx, options)
+		assert.Error(t, err)
+		assert.Equal(t, errors.ErrForbidden, err.(*errors.Error).Type)
+
+		assert.NoError(t, policy.AuthorizeOptions(adminCtx, options))
+	}
+}
+
+func TestNewOptionPolicyInvalidRules(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	_, err := domain.NewOptionPolicy(map[string]string{"unknown": "admin"}, log)
+	assert.Error(t, err)
+
+	_, err = domain.NewOptionPolicy(map[string]string{"os_detection": "root"}, log)
+	assert.Error(t, err)
+}
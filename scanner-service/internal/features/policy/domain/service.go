@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+	"go.uber.org/zap"
+)
+
+// PolicyRepository defines the interface for policy repository
+type PolicyRepository interface {
+	SaveAllowlist(allowlist *TargetAllowlist) error
+	GetAllowlist(subjectType SubjectType, subjectID string) (*TargetAllowlist, error)
+	ListAllowlists() ([]*TargetAllowlist, error)
+	DeleteAllowlist(subjectType SubjectType, subjectID string) error
+}
+
+// PolicyService handles scan policies
+type PolicyService struct {
+	repository       PolicyRepository
+	logger           *logger.Logger
+	requireAllowlist bool
+}
+
+// NewPolicyService creates a new PolicyService.
+// When requireAllowlist is set, non-admin users without any allowlist cannot scan.
+func NewPolicyService(repository PolicyRepository, logger *logger.Logger, requireAllowlist bool) *PolicyService {
+	return &PolicyService{
+		repository:       repository,
+		logger:           logger,
+		requireAllowlist: requireAllowlist,
+	}
+}
+
+// SetAllowlist creates or replaces the allowlist of a subject
+func (s *PolicyService) SetAllowlist(ctx context.Context, subjectType SubjectType, subjectID string, cidrs, domains []string) (*TargetAllowlist, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if subjectType != SubjectTypeUser && subjectType != SubjectTypeTenant {
+		return nil, errors.NewInvalidInput(fmt.Sprintf("invalid subject type: %s", subjectType), nil)
+	}
+	if subjectID == "" {
+		return nil, errors.NewInvalidInput("subject ID is required", nil)
+	}
+
+	allowlist := &TargetAllowlist{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		CIDRs:       make([]string, 0, len(cidrs)),
+		Domains:     make([]string, 0, len(domains)),
+		UpdatedAt:   time.Now(),
+		UpdatedBy:   principal.UserID,
+	}
+
+	for _, cidr := range cidrs {
+		network, err := parseNetwork(cidr)
+		if err != nil {
+			return nil, errors.NewInvalidInput(fmt.Sprintf("invalid CIDR: %s", cidr), err)
+		}
+		allowlist.CIDRs = append(allowlist.CIDRs, network.String())
+	}
+
+	for _, domain := range domains {
+		normalized := normalizeDomain(domain)
+		if normalized == "" || strings.ContainsAny(normalized, " /*") {
+			return nil, errors.NewInvalidInput(fmt.Sprintf("invalid domain: %s", domain), nil)
+		}
+		allowlist.Domains = append(allowlist.Domains, normalized)
+	}
+
+	if err := s.repository.SaveAllowlist(allowlist); err != nil {
+		return nil, errors.NewInternal("failed to save allowlist", err)
+	}
+
+	s.logger.Info("Target allowlist updated",
+		zap.String("subject_type", string(subjectType)),
+		zap.String("subject_id", subjectID),
+		zap.Int("cidr_count", len(allowlist.CIDRs)),
+		zap.Int("domain_count", len(allowlist.Domains)),
+		zap.String("updated_by", principal.UserID),
+	)
+
+	return allowlist, nil
+}
+
+// GetAllowlist gets the allowlist of a subject
+func (s *PolicyService) GetAllowlist(ctx context.Context, subjectType SubjectType, subjectID string) (*TargetAllowlist, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	allowlist, err := s.repository.GetAllowlist(subjectType, subjectID)
+	if err != nil {
+		return nil, errors.NewNotFound("allowlist not found", err)
+	}
+
+	return allowlist, nil
+}
+
+// ListAllowlists lists all allowlists
+func (s *PolicyService) ListAllowlists(ctx context.Context) ([]*TargetAllowlist, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	allowlists, err := s.repository.ListAllowlists()
+	if err != nil {
+		return nil, errors.NewInternal("failed to list allowlists", err)
+	}
+
+	return allowlists, nil
+}
+
+// DeleteAllowlist deletes the allowlist of a subject
+func (s *PolicyService) DeleteAllowlist(ctx context.Context, subjectType SubjectType, subjectID string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteAllowlist(subjectType, subjectID); err != nil {
+		return errors.NewNotFound("allowlist not found", err)
+	}
+
+	s.logger.Info("Target allowlist deleted",
+		zap.String("subject_type", string(subjectType)),
+		zap.String("subject_id", subjectID),
+		zap.String("deleted_by", principal.UserID),
+	)
+
+	return nil
+}
+
+// AuthorizeTarget checks that every target in the specification is covered by
+// the allowlists of the calling user or their tenant. Admins are not restricted.
+func (s *PolicyService) AuthorizeTarget(ctx context.Context, target string) error {
+	principal, ok := authdomain.PrincipalFromContext(ctx)
+	if !ok {
+		return errors.NewUnauthorized("authentication required", nil)
+	}
+
+	if principal.IsAdmin() {
+		return nil
+	}
+
+	var allowlists []*TargetAllowlist
+	if allowlist, err := s.repository.GetAllowlist(SubjectTypeUser, principal.UserID); err == nil {
+		allowlists = append(allowlists, allowlist)
+	}
+	if principal.TenantID != "" {
+		if allowlist, err := s.repository.GetAllowlist(SubjectTypeTenant, principal.TenantID); err == nil {
+			allowlists = append(allowlists, allowlist)
+		}
+	}
+
+	if len(allowlists) == 0 {
+		if s.requireAllowlist {
+			return errors.NewForbidden("no target allowlist is configured for this user", nil)
+		}
+		return nil
+	}
+
+	for _, item := range utils.SplitTargets(target) {
+		if !allowed(item, allowlists) {
+			s.logger.Warn("Target outside allowlist rejected",
+				zap.String("user_id", principal.UserID),
+				zap.String("tenant_id", principal.TenantID),
+				zap.String("target", item),
+			)
+			return errors.NewForbidden(fmt.Sprintf("target %s is outside the permitted scope", item), nil)
+		}
+	}
+
+	return nil
+}
+
+// allowed reports whether a single target is covered by any of the allowlists
+func allowed(target string, allowlists []*TargetAllowlist) bool {
+	start, end, isAddress := scandomain.ParseTargetRange(target)
+
+	for _, allowlist := range allowlists {
+		if isAddress {
+			for _, cidr := range allowlist.CIDRs {
+				_, network, err := net.ParseCIDR(cidr)
+				if err == nil && network.Contains(start) && network.Contains(end) {
+					return true
+				}
+			}
+			continue
+		}
+
+		host := normalizeDomain(target)
+		for _, domain := range allowlist.Domains {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parseNetwork parses a CIDR or single IP address into a network
+func parseNetwork(value string) (*net.IPNet, error) {
+	if ip := net.ParseIP(value); ip != nil {
+		bits := 128
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	return network, err
+}
+
+// normalizeDomain lowercases a domain and strips wildcard prefixes and trailing dots
+func normalizeDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	domain = strings.TrimPrefix(domain, "*.")
+	return strings.TrimSuffix(domain, ".")
+}
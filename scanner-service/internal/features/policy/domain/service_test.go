@@ -0,0 +1,79 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/policy/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/policy/repository"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newTestService(requireAllowlist bool) *domain.PolicyService {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	return domain.NewPolicyService(repository.NewMemoryPolicyRepository(log), log, requireAllowlist)
+}
+
+func principalContext(userID, tenantID string, role authdomain.Role) context.Context {
+	return authdomain.WithPrincipal(context.Background(), &authdomain.Principal{
+		UserID:   userID,
+		TenantID: tenantID,
+		Roles:    []authdomain.Role{role},
+	})
+}
+
+func TestAuthorizeTarget(t *testing.T) {
+	service := newTestService(true)
+	adminCtx := principalContext("root", "", authdomain.RoleAdmin)
+
+	_, err := service.SetAllowlist(adminCtx, domain.SubjectTypeTenant, "acme", []string{"10.10.0.0/16", "192.168.1.5"}, []string{"*.acme.example"})
+	assert.NoError(t, err)
+
+	ctx := principalContext("alice", "acme", authdomain.RoleOperator)
+
+	allowedTargets := []string{
+		"10.10.1.1",
+		"10.10.4.0/24",
+		"10.10.0.1-20",
+		"192.168.1.5",
+		"acme.example",
+		"www.acme.example",
+		"10.10.1.1, 10.10.2.2",
+	}
+	for _, target := range allowedTargets {
+		assert.NoError(t, service.AuthorizeTarget(ctx, target), target)
+	}
+
+	deniedTargets := []string{
+		"10.11.0.1",
+		"10.0.0.0/8",
+		"192.168.1.6",
+		"evilacme.example",
+		"10.10.*.1",
+		"10.10.1.1,8.8.8.8",
+	}
+	for _, target := range deniedTargets {
+		assert.Error(t, service.AuthorizeTarget(ctx, target), target)
+	}
+
+	// Admins are not restricted
+	assert.NoError(t, service.AuthorizeTarget(adminCtx, "8.8.8.8"))
+
+	// Users without an allowlist are denied
+	assert.Error(t, service.AuthorizeTarget(principalContext("bob", "", authdomain.RoleOperator), "10.10.1.1"))
+}
+
+func TestSetAllowlistRequiresAdmin(t *testing.T) {
+	service := newTestService(true)
+
+	_, err := service.SetAllowlist(principalContext("alice", "", authdomain.RoleOperator), domain.SubjectTypeUser, "alice", []string{"0.0.0.0/0"}, nil)
+	assert.Error(t, err)
+
+	_, err = service.SetAllowlist(principalContext("root", "", authdomain.RoleAdmin), domain.SubjectTypeUser, "alice", []string{"not-a-cidr"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/policy/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PolicyHandler handles HTTP requests for scan policies
+type PolicyHandler struct {
+	policyService *domain.PolicyService
+	logger        *logger.Logger
+}
+
+// NewPolicyHandler creates a new PolicyHandler
+func NewPolicyHandler(policyService *domain.PolicyService, logger *logger.Logger) *PolicyHandler {
+	return &PolicyHandler{
+		policyService: policyService,
+		logger:        logger,
+	}
+}
+
+// SetAllowlistRequest represents the request body for setting an allowlist
+type SetAllowlistRequest struct {
+	CIDRs   []string `json:"cidrs"`
+	Domains []string `json:"domains"`
+}
+
+// ListAllowlists handles the request to list all allowlists
+func (h *PolicyHandler) ListAllowlists(c *gin.Context) {
+	allowlists, err := h.policyService.ListAllowlists(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list allowlists", zap.Error(err))
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"allowlists": allowlists,
+		"count":      len(allowlists),
+	})
+}
+
+// GetAllowlist handles the request to get the allowlist of a subject
+func (h *PolicyHandler) GetAllowlist(c *gin.Context) {
+	subjectType := domain.SubjectType(c.Param("subject_type"))
+	subjectID := c.Param("subject_id")
+
+	allowlist, err := h.policyService.GetAllowlist(c.Request.Context(), subjectType, subjectID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, allowlist)
+}
+
+// SetAllowlist handles the request to create or replace the allowlist of a subject
+func (h *PolicyHandler) SetAllowlist(c *gin.Context) {
+	subjectType := domain.SubjectType(c.Param("subject_type"))
+	subjectID := c.Param("subject_id")
+
+	var req SetAllowlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	allowlist, err := h.policyService.SetAllowlist(c.Request.Context(), subjectType, subjectID, req.CIDRs, req.Domains)
+	if err != nil {
+		h.logger.Error("Failed to set allowlist",
+			zap.Error(err),
+			zap.String("subject_type", string(subjectType)),
+			zap.String("subject_id", subjectID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, allowlist)
+}
+
+// DeleteAllowlist handles the request to delete the allowlist of a subject
+func (h *PolicyHandler) DeleteAllowlist(c *gin.Context) {
+	subjectType := domain.SubjectType(c.Param("subject_type"))
+	subjectID := c.Param("subject_id")
+
+	if err := h.policyService.DeleteAllowlist(c.Request.Context(), subjectType, subjectID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Allowlist deleted",
+	})
+}
+
+// RegisterRoutes registers the policy handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *PolicyHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	admin := router.Group("/api/v1/admin/policies", middleware...)
+	admin.Use(authhandlers.RequireRole(authdomain.RoleAdmin))
+
+	// Target allowlist endpoints
+	admin.GET("/allowlists", h.ListAllowlists)
+	admin.GET("/allowlists/:subject_type/:subject_id", h.GetAllowlist)
+	admin.PUT("/allowlists/:subject_type/:subject_id", h.SetAllowlist)
+	admin.DELETE("/allowlists/:subject_type/:subject_id", h.DeleteAllowlist)
+}
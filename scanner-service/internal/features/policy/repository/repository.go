@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/policy/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MemoryPolicyRepository is an in-memory implementation of the PolicyRepository interface
+type MemoryPolicyRepository struct {
+	logger     *logger.Logger
+	allowlists map[string]*domain.TargetAllowlist
+	mu         sync.RWMutex
+}
+
+// NewMemoryPolicyRepository creates a new MemoryPolicyRepository
+func NewMemoryPolicyRepository(logger *logger.Logger) *MemoryPolicyRepository {
+	return &MemoryPolicyRepository{
+		logger:     logger,
+		allowlists: make(map[string]*domain.TargetAllowlist),
+	}
+}
+
+// SaveAllowlist creates or replaces an allowlist in the repository
+func (r *MemoryPolicyRepository) SaveAllowlist(allowlist *domain.TargetAllowlist) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	allowlistCopy := copyAllowlist(allowlist)
+	r.allowlists[allowlistKey(allowlist.SubjectType, allowlist.SubjectID)] = allowlistCopy
+
+	r.logger.Debug("Saved allowlist",
+		zap.String("subject_type", string(allowlist.SubjectType)),
+		zap.String("subject_id", allowlist.SubjectID),
+	)
+
+	return nil
+}
+
+// GetAllowlist gets the allowlist of a subject from the repository
+func (r *MemoryPolicyRepository) GetAllowlist(subjectType domain.SubjectType, subjectID string) (*domain.TargetAllowlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	allowlist, ok := r.allowlists[allowlistKey(subjectType, subjectID)]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("allowlist for %s %s not found", subjectType, subjectID), nil)
+	}
+
+	return copyAllowlist(allowlist), nil
+}
+
+// ListAllowlists lists all allowlists from the repository
+func (r *MemoryPolicyRepository) ListAllowlists() ([]*domain.TargetAllowlist, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	allowlists := make([]*domain.TargetAllowlist, 0, len(r.allowlists))
+	for _, allowlist := range r.allowlists {
+		allowlists = append(allowlists, copyAllowlist(allowlist))
+	}
+
+	return allowlists, nil
+}
+
+// DeleteAllowlist deletes the allowlist of a subject from the repository
+func (r *MemoryPolicyRepository) DeleteAllowlist(subjectType domain.SubjectType, subjectID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := allowlistKey(subjectType, subjectID)
+	if _, ok := r.allowlists[key]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("allowlist for %s %s not found", subjectType, subjectID), nil)
+	}
+
+	delete(r.allowlists, key)
+
+	r.logger.Debug("Deleted allowlist",
+		zap.String("subject_type", string(subjectType)),
+		zap.String("subject_id", subjectID),
+	)
+
+	return nil
+}
+
+// allowlistKey returns the map key of a subject's allowlist
+func allowlistKey(subjectType domain.SubjectType, subjectID string) string {
+	return string(subjectType) + "/" + subjectID
+}
+
+// copyAllowlist returns a deep copy of an allowlist
+func copyAllowlist(allowlist *domain.TargetAllowlist) *domain.TargetAllowlist {
+	allowlistCopy := *allowlist
+	allowlistCopy.CIDRs = append([]string(nil), allowlist.CIDRs...)
+	allowlistCopy.Domains = append([]string(nil), allowlist.Domains...)
+	return &allowlistCopy
+}
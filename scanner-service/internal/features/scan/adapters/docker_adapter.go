@@ -0,0 +1,178 @@
+package adapters
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ContainerOptions configure the containers nmap runs in
+type ContainerOptions struct {
+	DockerPath string   // docker CLI, from PATH by default
+	Image      string   // Image providing nmap, pinned to a tag for reproducible nmap versions
+	NmapPath   string   // nmap binary in the image, from PATH by default
+	Network    string   // Network mode of the containers, e.g. host or bridge
+	CapAdd     []string // Capabilities added to the containers, e.g. NET_RAW and NET_ADMIN
+	CapDrop    []string // Capabilities dropped from the containers, e.g. ALL
+}
+
+// DockerAdapter runs each nmap scan in a dedicated container removed after the scan,
+// isolating nmap and its privileges from the service. Output files are bind mounted
+// into the container at their paths, so results are read as from a local nmap.
+type DockerAdapter struct {
+	*NmapAdapter
+	options ContainerOptions
+	limits  ResourceLimits // CPU and memory limits of the containers
+}
+
+// NewDockerAdapter creates a new DockerAdapter
+func NewDockerAdapter(options ContainerOptions, logger *logger.Logger) *DockerAdapter {
+	if options.DockerPath == "" {
+		options.DockerPath = "docker"
+	}
+	if options.NmapPath == "" {
+		options.NmapPath = "nmap"
+	}
+
+	adapter := &DockerAdapter{
+		NmapAdapter: NewNmapAdapter(options.NmapPath, logger),
+		options:     options,
+	}
+	adapter.NmapAdapter.launcher = adapter.launch
+	return adapter
+}
+
+// SetResourceLimits limits the CPU and memory of the containers and the runtime of
+// scans. Priorities do not apply to containers and are skipped.
+func (a *DockerAdapter) SetResourceLimits(limits ResourceLimits) {
+	if limits.Nice != 0 || limits.IOClass != "" {
+		a.logger.Warn("Priority limits of nmap are not supported in containers")
+	}
+	a.limits = limits
+
+	// The runtime limit kills the docker client, the container is removed after it
+	a.NmapAdapter.limits = ResourceLimits{MaxRuntime: limits.MaxRuntime}
+}
+
+// launch returns the docker command running nmap in a new container, and a function
+// removing the container if the docker client exited without removing it
+func (a *DockerAdapter) launch(args []string, outputs []string) (string, []string, func()) {
+	name := "nmap-" + uuid.NewString()
+	runArgs := append(a.runArgs(name), a.mounts(outputs)...)
+	runArgs = append(runArgs, "--entrypoint", a.options.NmapPath, a.options.Image)
+	runArgs = append(runArgs, args...)
+
+	remove := func() {
+		if err := exec.Command(a.options.DockerPath, "rm", "--force", name).Run(); err != nil {
+			a.logger.Debug("Failed to remove nmap container", zap.String("container", name), zap.Error(err))
+		}
+	}
+	return a.options.DockerPath, runArgs, remove
+}
+
+// runArgs returns the docker run arguments of a container with the configured
+// network, capabilities and limits
+func (a *DockerAdapter) runArgs(name string) []string {
+	// The init process forwards the interrupt of a stopped scan to nmap
+	args := []string{"run", "--rm", "--init"}
+	if name != "" {
+		args = append(args, "--name", name)
+	}
+	if a.options.Network != "" {
+		args = append(args, "--network", a.options.Network)
+	}
+	for _, capability := range a.options.CapDrop {
+		args = append(args, "--cap-drop", capability)
+	}
+	for _, capability := range a.options.CapAdd {
+		args = append(args, "--cap-add", capability)
+	}
+	if a.limits.CPUs > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(a.limits.CPUs, 'f', -1, 64))
+	}
+	if a.limits.MemoryBytes > 0 {
+		memory := strconv.FormatInt(a.limits.MemoryBytes, 10)
+		args = append(args, "--memory", memory, "--memory-swap", memory)
+	}
+	return args
+}
+
+// mounts returns the bind mounts of the output files of nmap. Existing files are
+// mounted themselves, files nmap creates with their directory.
+func (a *DockerAdapter) mounts(outputs []string) []string {
+	var paths, args []string
+	for _, output := range outputs {
+		if output == "" {
+			continue
+		}
+		path := output
+		if _, err := os.Stat(output); err != nil {
+			path = filepath.Dir(output)
+		}
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+			args = append(args, "--volume", path+":"+path)
+		}
+	}
+	return args
+}
+
+// GetVersion returns the version of nmap in the image
+func (a *DockerAdapter) GetVersion() (string, error) {
+	args := append(a.runArgs(""), "--entrypoint", a.options.NmapPath, a.options.Image, "--version")
+	cmd := exec.Command(a.options.DockerPath, args...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.NewUnavailable("failed to get nmap version of image "+a.options.Image, err)
+	}
+	return strings.Split(out.String(), "\n")[0], nil
+}
+
+// IsAvailable checks if nmap can run in a container of the image
+func (a *DockerAdapter) IsAvailable() bool {
+	_, err := a.GetVersion()
+	return err == nil
+}
+
+// RawSocketCapable reports whether nmap may open raw sockets in the containers
+func (a *DockerAdapter) RawSocketCapable() bool {
+	return containerRawSocketCapable(a.options.CapAdd, a.options.CapDrop)
+}
+
+// containerRawSocketCapable reports whether a container with the capabilities may open
+// raw sockets, which needs the NET_RAW capability that container runtimes grant unless
+// it is dropped
+func containerRawSocketCapable(capAdd, capDrop []string) bool {
+	has := func(capabilities []string, capability string) bool {
+		return slices.ContainsFunc(capabilities, func(c string) bool {
+			c = strings.TrimPrefix(strings.ToUpper(c), "CAP_")
+			return c == capability || c == "ALL"
+		})
+	}
+	return has(capAdd, "NET_RAW") || !has(capDrop, "NET_RAW")
+}
+
+// ScriptCategories returns the categories of the NSE scripts installed in the image
+func (a *DockerAdapter) ScriptCategories() ([]string, error) {
+	paths := []string{"/usr/share/nmap/scripts/script.db", "/usr/local/share/nmap/scripts/script.db"}
+	args := append(a.runArgs(""), "--entrypoint", "cat", a.options.Image)
+	for _, path := range paths {
+		data, err := exec.Command(a.options.DockerPath, append(args, path)...).Output()
+		if err != nil {
+			continue
+		}
+		return parseScriptDB(data), nil
+	}
+	return nil, errors.NewUnavailable("nmap script database not found in image "+a.options.Image, nil)
+}
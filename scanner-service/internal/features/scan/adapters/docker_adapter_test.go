@@ -0,0 +1,85 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeDocker is a script standing in for the docker CLI that records its arguments
+// and runs the entrypoint of the container with the arguments after the image
+const fakeDocker = `#!/bin/sh
+echo "$@" >> "$(dirname "$0")/docker.log"
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--entrypoint" ]; then entrypoint="$2"; shift 3; exec "$entrypoint" "$@"; fi
+  shift
+done
+`
+
+// completeNmap is a script standing in for nmap that writes a scanned host to its XML output
+const completeNmap = `#!/bin/sh
+while [ $# -gt 0 ]; do
+  if [ "$1" = "-oX" ]; then xml="$2"; fi
+  shift
+done
+printf '<?xml version="1.0"?><nmaprun><host><status state="up"/><address addr="10.0.0.1" addrtype="ipv4"/></host><runstats><hosts up="1" down="0" total="1"/></runstats></nmaprun>' > "$xml"
+`
+
+func TestDockerExecuteScan(t *testing.T) {
+	dir := t.TempDir()
+	dockerPath := filepath.Join(dir, "docker")
+	nmapPath := filepath.Join(dir, "nmap")
+	require.NoError(t, os.WriteFile(dockerPath, []byte(fakeDocker), 0o700))
+	require.NoError(t, os.WriteFile(nmapPath, []byte(completeNmap), 0o700))
+
+	adapter := NewDockerAdapter(ContainerOptions{
+		DockerPath: dockerPath,
+		Image:      "nmap:7.95",
+		NmapPath:   nmapPath,
+		Network:    "host",
+		CapAdd:     []string{"NET_RAW"},
+		CapDrop:    []string{"ALL"},
+	}, &logger.Logger{Logger: zap.NewNop()})
+	adapter.SetResourceLimits(ResourceLimits{CPUs: 0.5, MemoryBytes: 256 << 20})
+
+	result, err := adapter.ExecuteScan(context.Background(), domain.ScanOptions{Target: "10.0.0.1", TimingTemplate: domain.TimingNormal})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.UpHosts)
+	require.Len(t, result.Hosts, 1)
+	assert.Equal(t, "10.0.0.1", result.Hosts[0].IP)
+
+	// nmap ran in a container with the configured settings and its output file mounted
+	log, err := os.ReadFile(filepath.Join(dir, "docker.log"))
+	require.NoError(t, err)
+	command := strings.TrimSpace(string(log))
+	assert.True(t, strings.HasPrefix(command, "run --rm --init --name nmap-"))
+	assert.Contains(t, command, "--network host --cap-drop ALL --cap-add NET_RAW --cpus 0.5 --memory 268435456 --memory-swap 268435456 --volume ")
+	assert.Contains(t, command, "--entrypoint "+nmapPath+" nmap:7.95 10.0.0.1 -T3 -oX ")
+}
+
+func TestDockerRawSocketCapable(t *testing.T) {
+	tests := []struct {
+		name    string
+		options ContainerOptions
+		capable bool
+	}{
+		{"default capabilities", ContainerOptions{}, true},
+		{"all capabilities dropped", ContainerOptions{CapDrop: []string{"ALL"}}, false},
+		{"raw sockets dropped", ContainerOptions{CapDrop: []string{"cap_net_raw"}}, false},
+		{"raw sockets added back", ContainerOptions{CapDrop: []string{"ALL"}, CapAdd: []string{"NET_RAW"}}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adapter := NewDockerAdapter(test.options, nil)
+			assert.Equal(t, test.capable, adapter.RawSocketCapable())
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultFixture is the fixture returned for targets without their own fixture
+const defaultFixture = "default"
+
+// maxSyntheticHosts limits the hosts generated for a target without fixtures
+const maxSyntheticHosts = 16
+
+// DryRunAdapter is a scan adapter returning canned results without running nmap,
+// for development and demos on machines without nmap or network access
+type DryRunAdapter struct {
+	fixtures map[string]domain.ScanResult
+	delay    time.Duration // Simulated scan duration
+	logger   *logger.Logger
+}
+
+// NewDryRunAdapter creates a new DryRunAdapter with the JSON scan results of the fixtures
+// directory. A fixture named after the target ("10.0.0.0_24.json" for 10.0.0.0/24) is returned
+// for that target, default.json for other targets. Without fixtures, results are generated.
+func NewDryRunAdapter(fixturesDir string, delay time.Duration, logger *logger.Logger) (*DryRunAdapter, error) {
+	adapter := &DryRunAdapter{
+		fixtures: make(map[string]domain.ScanResult),
+		delay:    delay,
+		logger:   logger,
+	}
+	if fixturesDir == "" {
+		return adapter, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(fixturesDir, "*.json"))
+	if err != nil {
+		return nil, errors.NewInternal("failed to list scan fixtures", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.NewInternal("failed to read scan fixture "+file, err)
+		}
+		var result domain.ScanResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, errors.NewInvalidInput("invalid scan fixture "+file+": "+err.Error(), err)
+		}
+		adapter.fixtures[strings.TrimSuffix(filepath.Base(file), ".json")] = result
+	}
+
+	return adapter, nil
+}
+
+// ExecuteScan returns the fixture of the target after the simulated scan duration
+func (a *DryRunAdapter) ExecuteScan(ctx context.Context, scanOptions domain.ScanOptions) (*domain.ScanResult, error) {
+	startTime := time.Now()
+
+	a.logger.Info("Executing dry-run scan",
+		zap.String("target", scanOptions.Target),
+	)
+
+	// Simulate the phases of the scan, each taking an equal share of the delay
+	phases := dryRunPhases(scanOptions)
+	step := a.delay / time.Duration(len(phases))
+	report, _ := domain.ProgressReporterFromContext(ctx)
+	for _, phase := range phases {
+		if report != nil {
+			report(domain.ScanProgress{Phase: phase, Remaining: step})
+		}
+
+		select {
+		case <-time.After(step):
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, errors.NewTimeout("scan timed out", ctx.Err())
+			}
+			return nil, errors.NewTimeout("scan was cancelled", ctx.Err())
+		}
+	}
+
+	var result domain.ScanResult
+	if fixture, ok := a.fixtures[fixtureName(scanOptions.Target)]; ok {
+		result = fixture
+	} else if fixture, ok := a.fixtures[defaultFixture]; ok {
+		result = fixture
+	} else {
+		result = syntheticResult(scanOptions)
+	}
+
+	// Copy the hosts so that callers do not change the fixture
+	result.Hosts = append([]domain.Host(nil), result.Hosts...)
+	if result.Hosts == nil {
+		result.Hosts = make([]domain.Host, 0)
+	}
+
+	endTime := time.Now()
+	result.ID = uuid.New().String()
+	result.StartTime = startTime
+	result.EndTime = endTime
+	result.Duration = endTime.Sub(startTime).Seconds()
+	result.Command = "dry-run " + scanOptions.Target
+	result.UpHosts = len(result.Hosts)
+	if result.TotalHosts < result.UpHosts {
+		result.TotalHosts = result.UpHosts
+	}
+	result.Summary = fmt.Sprintf("Dry run done: %d IP addresses (%d hosts up) scanned in %.2f seconds",
+		result.TotalHosts, result.UpHosts, result.Duration)
+
+	// Write the summary to the scan log like nmap, so the log is not empty in demo mode
+	if scanLog, ok := domain.ScanLogFromContext(ctx); ok {
+		scanLog.Append(domain.LogStreamStdout, result.Summary)
+	}
+
+	return &result, nil
+}
+
+// dryRunPhases returns the phases nmap would run for the scan options
+func dryRunPhases(options domain.ScanOptions) []domain.ScanPhase {
+	phases := []domain.ScanPhase{domain.ScanPhaseDiscovery}
+	if options.ScanType == domain.ScanTypePing {
+		return phases
+	}
+	phases = append(phases, domain.ScanPhasePortScan)
+
+	all := options.ScanType == domain.ScanTypeAll
+	if all || options.ScanType == domain.ScanTypeVersion || options.ServiceDetection || options.OSDetection {
+		phases = append(phases, domain.ScanPhaseServiceDetection)
+	}
+	if all || options.ScanType == domain.ScanTypeScript || options.ScriptScan {
+		phases = append(phases, domain.ScanPhaseScripts)
+	}
+	return phases
+}
+
+// fixtureName returns the fixture file name of a target without extension
+func fixtureName(target string) string {
+	return strings.NewReplacer("/", "_", " ", "_", ",", "_").Replace(strings.TrimSpace(target))
+}
+
+// syntheticResult generates a result with a few common services on each target.
+// Host names and networks are given documentation addresses (192.0.2.0/24).
+func syntheticResult(options domain.ScanOptions) domain.ScanResult {
+	result := domain.ScanResult{TotalHosts: countAddresses(options.Target)}
+
+	for i, item := range strings.Fields(strings.ReplaceAll(options.Target, ",", " ")) {
+		if i == maxSyntheticHosts {
+			break
+		}
+
+		host := newDiscoveredHost(item)
+		if net.ParseIP(item) == nil {
+			host.IP = fmt.Sprintf("192.0.2.%d", i+1)
+			if _, ok := countTarget(item); !ok {
+				host.Hostnames = append(host.Hostnames, item)
+			}
+		}
+		if options.ScanType != domain.ScanTypePing {
+			host.Ports = []domain.Port{
+				{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH", Version: "9.6p1"},
+				{Port: 80, Protocol: "tcp", State: "open", Service: "http", Product: "nginx", Version: "1.24.0"},
+				{Port: 443, Protocol: "tcp", State: "open", Service: "https", Product: "nginx", Version: "1.24.0"},
+			}
+		}
+		if options.OSDetection {
+			host.OS = "Linux 5.X"
+		}
+		result.Hosts = append(result.Hosts, *host)
+	}
+
+	return result
+}
+
+// GetVersion returns the version reported in dry-run mode
+func (a *DryRunAdapter) GetVersion() (string, error) {
+	return "Nmap dry-run mode", nil
+}
+
+// ScriptCategories returns the script categories of nmap, without reading its script database
+func (a *DryRunAdapter) ScriptCategories() ([]string, error) {
+	return nmapScriptCategories, nil
+}
+
+// IsAvailable reports that dry-run scans can always run
+func (a *DryRunAdapter) IsAvailable() bool {
+	return true
+}
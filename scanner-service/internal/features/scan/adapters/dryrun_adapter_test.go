@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDryRunAdapter(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "10.0.0.0_24.json"),
+		[]byte(`{"total_hosts": 256, "hosts": [{"ip": "10.0.0.5", "status": "up"}]}`), 0o600))
+
+	adapter, err := NewDryRunAdapter(dir, 0, &logger.Logger{Logger: zap.NewNop()})
+	require.NoError(t, err)
+
+	// Targets with a fixture return it
+	result, err := adapter.ExecuteScan(context.Background(), domain.ScanOptions{Target: "10.0.0.0/24"})
+	require.NoError(t, err)
+	assert.Equal(t, 256, result.TotalHosts)
+	assert.Equal(t, 1, result.UpHosts)
+	assert.Equal(t, "10.0.0.5", result.Hosts[0].IP)
+
+	// Other targets get generated results
+	result, err = adapter.ExecuteScan(context.Background(), domain.ScanOptions{Target: "10.1.0.1 example.com"})
+	require.NoError(t, err)
+	require.Len(t, result.Hosts, 2)
+	assert.Equal(t, "10.1.0.1", result.Hosts[0].IP)
+	assert.Equal(t, []string{"example.com"}, result.Hosts[1].Hostnames)
+	assert.NotEmpty(t, result.Hosts[0].Ports)
+
+	// Fixtures must be valid scan results
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "default.json"), []byte(`[]`), 0o600))
+	_, err = NewDryRunAdapter(dir, 0, &logger.Logger{Logger: zap.NewNop()})
+	assert.Error(t, err)
+}
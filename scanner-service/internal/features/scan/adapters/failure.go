@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// scannerFailure is a common way a scanner fails, recognized by its standard error output
+type scannerFailure struct {
+	pattern *regexp.Regexp
+	newErr  func(message string, err error) *errors.Error
+	message string
+	hint    string
+}
+
+// scannerFailures are the failures users can remedy themselves, in the order they are checked
+var scannerFailures = []scannerFailure{
+	{
+		pattern: regexp.MustCompile(`(?i)port specifications? (are|is) illegal|ports? specified must be between|found no matches for the service mask|invalid port`),
+		newErr:  errors.NewInvalidPortSpec,
+		message: "the scanner rejected the ports of the scan",
+		hint:    "Give ports between 1 and 65535 as a comma-separated list of ports and ranges, e.g. 22,80,8000-8100.",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)could not find interface|unable to find (appropriate )?interface|no such device`),
+		newErr:  errors.NewInterfaceNotFound,
+		message: "the network interface of the scan does not exist on the scanner host",
+		hint:    "List the interfaces of the scanner host with nmap --iflist and pass one of them with -e, or drop -e to let the scanner choose.",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)requires root privileges|operation not permitted|couldn't open a raw socket|don't have permission to capture`),
+		newErr:  errors.NewPermissionDenied,
+		message: "the scanner lacks the privileges for raw sockets",
+		hint:    "Run the scanner as root or grant it the CAP_NET_RAW and CAP_NET_ADMIN capabilities, or use a connect scan, which needs no privileges.",
+	},
+	{
+		pattern: regexp.MustCompile(`Failed to resolve "[^"]*"`),
+		newErr:  errors.NewUnresolvableHost,
+		message: "a target host name does not resolve",
+		hint:    "Check the spelling of the host name and that the DNS servers of the scanner host resolve it, or scan its IP address.",
+	},
+}
+
+// classifyFailure returns the error of a scanner that failed with err, telling the user
+// how to remedy the failure if its standard error output shows a common one
+func classifyFailure(name, stderr string, err error) *errors.Error {
+	for _, failure := range scannerFailures {
+		if failure.pattern.MatchString(stderr) {
+			appErr := failure.newErr(failure.message, err)
+			appErr.Hint = failure.hint
+			return appErr
+		}
+	}
+	return errors.NewInternal(name+" scan failed", err)
+}
+
+// noTargetsResolved reports whether nmap exited without scanning because none of the
+// targets resolved, which it does not treat as a failure
+func noTargetsResolved(stderr string) bool {
+	return strings.Contains(stderr, "Failed to resolve") && strings.Contains(stderr, "No targets were specified")
+}
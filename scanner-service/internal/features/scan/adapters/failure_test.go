@@ -0,0 +1,54 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		stderr  string
+		errType errors.Type
+	}{
+		{"raw sockets", "You requested a scan type which requires root privileges.\nQUITTING!\n", errors.ErrPermissionDenied},
+		{"masscan", "FAIL: permission denied\n [hint] need to sudo or run as root\nsendto: Operation not permitted\n", errors.ErrPermissionDenied},
+		{"unresolvable", "Failed to resolve \"nohost.invalid\".\nWARNING: No targets were specified, so 0 hosts scanned.\n", errors.ErrUnresolvableHost},
+		{"interface", "Could not find interface eth9 which was specified by -e\nQUITTING!\n", errors.ErrInterfaceNotFound},
+		{"ports", "Ports specified must be between 0 and 65535 inclusive\nQUITTING!\n", errors.ErrInvalidPortSpec},
+		{"port range", "Error #485: Your port specifications are illegal.  Example of proper form: \"-100,200-1024,T:3000-4000,U:60000-\"\nQUITTING!\n", errors.ErrInvalidPortSpec},
+		{"other", "Segmentation fault\n", errors.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyFailure("nmap", tt.stderr, nil)
+			assert.Equal(t, tt.errType, err.Type)
+			if tt.errType == errors.ErrInternal {
+				assert.Equal(t, "nmap scan failed", err.Message)
+				assert.Empty(t, err.Hint)
+			} else {
+				assert.NotEmpty(t, err.Hint)
+			}
+		})
+	}
+}
+
+func TestRunNmapFailsWithoutResolvedTargets(t *testing.T) {
+	// nmap exits successfully when none of the targets resolve
+	nmapPath := filepath.Join(t.TempDir(), "nmap")
+	script := "#!/bin/sh\necho 'Failed to resolve \"nohost.invalid\".' >&2\necho 'WARNING: No targets were specified, so 0 hosts scanned.' >&2\n"
+	require.NoError(t, os.WriteFile(nmapPath, []byte(script), 0o700))
+
+	adapter := NewNmapAdapter(nmapPath, nil)
+	err := adapter.runNmap(context.Background(), []string{"nohost.invalid"}, nil)
+
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrUnresolvableHost, scanErr.Type)
+}
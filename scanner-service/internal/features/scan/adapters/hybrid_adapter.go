@@ -0,0 +1,155 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// HybridAdapter runs a scan in two phases: a fast engine sweeps the ports of the
+// targets, then nmap runs service detection and default scripts on the open ports only
+type HybridAdapter struct {
+	sweepEngine domain.ScanEngine
+	sweep       domain.ScanAdapter
+	nmap        domain.ScanAdapter
+	logger      *logger.Logger
+}
+
+// NewHybridAdapter creates a new HybridAdapter sweeping ports with the given engine
+func NewHybridAdapter(sweepEngine domain.ScanEngine, sweep, nmap domain.ScanAdapter, logger *logger.Logger) *HybridAdapter {
+	return &HybridAdapter{
+		sweepEngine: sweepEngine,
+		sweep:       sweep,
+		nmap:        nmap,
+		logger:      logger,
+	}
+}
+
+// Capabilities returns the scan options supported by hybrid scans: the scan types
+// and targets of the sweep engine, and every nmap option for the second phase
+func (a *HybridAdapter) Capabilities() Capabilities {
+	capabilities := AllCapabilities
+	if reporter, ok := a.sweep.(CapabilityReporter); ok {
+		sweep := reporter.Capabilities()
+		capabilities.ScanTypes = sweep.ScanTypes
+		capabilities.HostNames = sweep.HostNames
+	}
+	return capabilities
+}
+
+// ValidateOptions checks the options of the sweep with the sweep engine
+func (a *HybridAdapter) ValidateOptions(options domain.ScanOptions) error {
+	if validator, ok := a.sweep.(OptionValidator); ok {
+		return validator.ValidateOptions(a.sweepOptions(options))
+	}
+	return nil
+}
+
+// sweepOptions returns the options of the port sweep, leaving the
+// service details to nmap
+func (a *HybridAdapter) sweepOptions(options domain.ScanOptions) domain.ScanOptions {
+	sweep := options
+	sweep.Engine = a.sweepEngine
+	sweep.Mode = ""
+	sweep.ServiceDetection = false
+	sweep.OSDetection = false
+	sweep.ScriptScan = false
+	sweep.ExtraOptions = nil
+	sweep.HostTimeout = 0
+	sweep.MinRate = 0
+	sweep.MaxRate = 0
+	sweep.MaxParallelism = 0
+	sweep.MaxRetries = nil
+	sweep.Decoys = nil
+	sweep.SourcePort = 0
+	sweep.Fragment = false
+	sweep.DataLength = 0
+	return sweep
+}
+
+// ExecuteScan sweeps the ports of the targets and scans the open ports with nmap
+func (a *HybridAdapter) ExecuteScan(ctx context.Context, scanOptions domain.ScanOptions) (*domain.ScanResult, error) {
+	startTime := time.Now()
+
+	a.logger.Info("Executing hybrid scan sweep",
+		zap.String("target", scanOptions.Target),
+		zap.String("engine", string(a.sweepEngine)),
+	)
+
+	sweepResult, err := a.sweep.ExecuteScan(ctx, a.sweepOptions(scanOptions))
+	if err != nil {
+		return nil, err
+	}
+
+	openPorts := make(map[string][]int)
+	for _, host := range sweepResult.Hosts {
+		for _, port := range host.Ports {
+			if port.State == "open" {
+				openPorts[host.IP] = append(openPorts[host.IP], port.Port)
+			}
+		}
+	}
+
+	// Nothing to pass to nmap
+	if len(openPorts) == 0 {
+		endTime := time.Now()
+		result := &domain.ScanResult{
+			ID:         uuid.New().String(),
+			StartTime:  startTime,
+			EndTime:    endTime,
+			Duration:   endTime.Sub(startTime).Seconds(),
+			Command:    sweepResult.Command,
+			TotalHosts: sweepResult.TotalHosts,
+			Hosts:      make([]domain.Host, 0),
+		}
+		result.Summary = fmt.Sprintf("hybrid scan done: %d IP addresses (0 hosts with open ports) scanned in %.2f seconds",
+			result.TotalHosts, result.Duration)
+		return result, nil
+	}
+
+	nmapOptions := serviceScanOptions(scanOptions, openPorts)
+	nmapOptions.ScriptScan = true
+
+	a.logger.Info("Executing hybrid scan service detection",
+		zap.String("target", nmapOptions.Target),
+		zap.String("ports", nmapOptions.Ports),
+	)
+
+	result, err := a.nmap.ExecuteScan(ctx, nmapOptions)
+	if err != nil {
+		// A partial result of a stopped scan is returned with the error
+		return result, err
+	}
+
+	endTime := time.Now()
+	result.StartTime = startTime
+	result.EndTime = endTime
+	result.Duration = endTime.Sub(startTime).Seconds()
+	result.Command = sweepResult.Command + "\n" + result.Command
+	result.TotalHosts = sweepResult.TotalHosts
+	result.Summary = fmt.Sprintf("hybrid scan done: %d IP addresses (%d hosts up) scanned in %.2f seconds",
+		result.TotalHosts, result.UpHosts, result.Duration)
+
+	a.logger.Info("Hybrid scan completed",
+		zap.String("target", scanOptions.Target),
+		zap.Int("up_hosts", result.UpHosts),
+		zap.Float64("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// GetVersion returns the version of the sweep engine
+func (a *HybridAdapter) GetVersion() (string, error) {
+	return a.sweep.GetVersion()
+}
+
+// IsAvailable checks if the sweep engine and nmap are available
+func (a *HybridAdapter) IsAvailable() bool {
+	return a.sweep.IsAvailable() && a.nmap.IsAvailable()
+}
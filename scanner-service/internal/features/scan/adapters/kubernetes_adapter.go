@@ -0,0 +1,412 @@
+package adapters
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// kubernetesOutputDir is the volume of the scan pods nmap writes its XML output to
+	kubernetesOutputDir = "/scan"
+	// kubernetesXMLMarker separates the output of nmap from its XML output in the pod log
+	kubernetesXMLMarker = "<<<nmap-xml-output>>>"
+	// kubernetesJobTTL is how long finished Jobs are kept if the service fails to delete them
+	kubernetesJobTTL = 10 * time.Minute
+	// kubernetesVersionRetry is how long a failed nmap version check is not repeated
+	kubernetesVersionRetry = time.Minute
+)
+
+// kubernetesScanScript runs nmap, given as $0, with the arguments and prints its XML
+// output after the marker, keeping the exit code of nmap
+const kubernetesScanScript = `"$0" "$@"; status=$?; echo; echo '` + kubernetesXMLMarker + `'; cat ` + kubernetesOutputDir + `/nmap.xml 2>/dev/null; exit $status`
+
+// KubernetesOptions configure the Jobs nmap scans run in
+type KubernetesOptions struct {
+	Namespace      string            // Namespace of the Jobs, the namespace of the service if empty
+	Image          string            // Image providing nmap, pinned to a tag for reproducible nmap versions
+	NmapPath       string            // nmap binary in the image, from PATH by default
+	NodeSelector   map[string]string // Labels of the nodes scans may run on
+	Labels         map[string]string // Labels of the scan pods, e.g. selected by their network policy
+	ServiceAccount string            // Service account of the scan pods, the default account if empty
+	CapAdd         []string          // Capabilities added to the nmap container, e.g. NET_RAW and NET_ADMIN
+	CapDrop        []string          // Capabilities dropped from the nmap container, e.g. ALL
+	PollInterval   time.Duration     // How often the status of a running Job is checked
+}
+
+// KubernetesAdapter runs each nmap scan in a Kubernetes Job, so that the service itself
+// runs unprivileged. The Job is watched until it finished, the XML output of nmap is
+// collected from the log of its pod and the Job is deleted.
+type KubernetesAdapter struct {
+	nmap    *NmapAdapter // Builds the nmap arguments and converts the results
+	options KubernetesOptions
+	limits  ResourceLimits // CPU, memory and runtime limits of the scan pods
+	client  *kubernetesClient
+	logger  *logger.Logger
+
+	versionMutex   sync.Mutex
+	version        string    // nmap version of the image, once known
+	versionChecked time.Time // Time of the last failed version check
+}
+
+// NewKubernetesAdapter creates a new KubernetesAdapter with the service account of the
+// pod the service runs in
+func NewKubernetesAdapter(options KubernetesOptions, logger *logger.Logger) (*KubernetesAdapter, error) {
+	client, namespace, err := inClusterClient()
+	if err != nil {
+		return nil, err
+	}
+	if options.Namespace == "" {
+		options.Namespace = namespace
+	}
+	return newKubernetesAdapter(options, client, logger), nil
+}
+
+// newKubernetesAdapter creates a new KubernetesAdapter calling the API with client
+func newKubernetesAdapter(options KubernetesOptions, client *kubernetesClient, logger *logger.Logger) *KubernetesAdapter {
+	if options.NmapPath == "" {
+		options.NmapPath = "nmap"
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = 2 * time.Second
+	}
+
+	return &KubernetesAdapter{
+		nmap:    NewNmapAdapter(options.NmapPath, logger),
+		options: options,
+		client:  client,
+		logger:  logger,
+	}
+}
+
+// SetDefaultMaxRetries sets the probe retransmissions of scans not setting them,
+// -1 to leave them to nmap
+func (a *KubernetesAdapter) SetDefaultMaxRetries(retries int) {
+	a.nmap.SetDefaultMaxRetries(retries)
+}
+
+// SetResourceLimits limits the CPU and memory of the scan pods and the runtime of the
+// Jobs. Priorities do not apply to pods and are skipped.
+func (a *KubernetesAdapter) SetResourceLimits(limits ResourceLimits) {
+	if limits.Nice != 0 || limits.IOClass != "" {
+		a.logger.Warn("Priority limits of nmap are not supported in Kubernetes Jobs")
+	}
+	a.limits = limits
+}
+
+// ValidateOptions checks the extra options of an nmap scan against the allowlist
+func (a *KubernetesAdapter) ValidateOptions(options domain.ScanOptions) error {
+	return a.nmap.ValidateOptions(options)
+}
+
+// ExecuteScan executes an nmap scan in a Kubernetes Job
+func (a *KubernetesAdapter) ExecuteScan(ctx context.Context, scanOptions domain.ScanOptions) (*domain.ScanResult, error) {
+	if err := a.ValidateOptions(scanOptions); err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	args := a.nmap.buildCommandArgs(scanOptions)
+	args = append(args, "-oX", kubernetesOutputDir+"/nmap.xml")
+
+	a.logger.Info("Executing nmap scan in a Kubernetes Job",
+		zap.String("target", scanOptions.Target),
+		zap.Strings("args", args),
+	)
+
+	// The log of the pod is only read once nmap exited
+	reportPhase(ctx, domain.ScanPhasePortScan)
+	output, err := a.runJob(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	_, xmlData, _ := strings.Cut(output, kubernetesXMLMarker+"\n")
+	var nmapXML NmapXML
+	if err := xml.Unmarshal([]byte(xmlData), &nmapXML); err != nil {
+		return nil, errors.NewInternal("failed to parse nmap output", err)
+	}
+
+	result := a.nmap.convertToDomainModel(nmapXML, startTime)
+	result.ID = uuid.New().String()
+	result.Command = a.options.NmapPath + " " + strings.Join(args, " ")
+
+	a.logger.Info("Nmap scan completed",
+		zap.String("target", scanOptions.Target),
+		zap.Int("total_hosts", result.TotalHosts),
+		zap.Int("up_hosts", result.UpHosts),
+		zap.Int("host_count", len(result.Hosts)),
+		zap.Float64("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// kubernetesJobStatus is the status of a Job
+type kubernetesJobStatus struct {
+	Status struct {
+		Conditions []struct {
+			Type    string `json:"type"`
+			Status  string `json:"status"`
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+// kubernetesPodList is a list of pods with the exit codes of their containers
+type kubernetesPodList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Status struct {
+			ContainerStatuses []struct {
+				Name  string `json:"name"`
+				State struct {
+					Terminated *struct {
+						ExitCode int `json:"exitCode"`
+					} `json:"terminated"`
+				} `json:"state"`
+			} `json:"containerStatuses"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// runJob runs nmap with the arguments in a Job until it finished or the context is
+// done, and returns the log of its pod. The Job is deleted afterwards.
+func (a *KubernetesAdapter) runJob(ctx context.Context, args []string) (string, error) {
+	name := "nmap-" + uuid.NewString()
+	jobs := "/apis/batch/v1/namespaces/" + a.options.Namespace + "/jobs"
+	if _, err := a.client.request(ctx, http.MethodPost, jobs, a.jobManifest(name, args)); err != nil {
+		return "", errors.NewUnavailable("failed to create the Kubernetes Job of the scan", err)
+	}
+	defer a.deleteJob(name)
+
+	// Wait for the Job to finish
+	var failed bool
+	var reason, message string
+	for finished := false; !finished; {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.Canceled {
+				return "", errors.NewTimeout("scan was cancelled", ctx.Err())
+			}
+			return "", errors.NewTimeout("scan timed out", ctx.Err())
+		case <-time.After(a.options.PollInterval):
+		}
+
+		var job kubernetesJobStatus
+		if err := a.client.get(ctx, jobs+"/"+name, &job); err != nil {
+			a.logger.Warn("Failed to get the status of the scan Job", zap.String("job", name), zap.Error(err))
+			continue
+		}
+		for _, condition := range job.Status.Conditions {
+			if condition.Status != "True" {
+				continue
+			}
+			switch condition.Type {
+			case "Complete":
+				finished = true
+			case "Failed":
+				finished, failed = true, true
+				reason, message = condition.Reason, condition.Message
+			}
+		}
+	}
+
+	// Collect the output of nmap from the log of the pod
+	output, exitCode, err := a.podOutput(ctx, name)
+	if err != nil {
+		return "", errors.NewUnavailable("failed to read the output of the scan Job", err)
+	}
+	nmapOutput, _, _ := strings.Cut(output, kubernetesXMLMarker)
+	if scanLog, ok := domain.ScanLogFromContext(ctx); ok {
+		stdoutLog := scanLog.Writer(domain.LogStreamStdout)
+		io.WriteString(stdoutLog, nmapOutput)
+		stdoutLog.Close()
+	}
+
+	command := "job/" + name + ": " + a.options.NmapPath + " " + strings.Join(args, " ")
+	if failed {
+		if reason == "DeadlineExceeded" {
+			timeoutErr := errors.NewTimeout(fmt.Sprintf("nmap exceeded its maximum runtime of %s and was killed", a.limits.MaxRuntime), nil)
+			return "", domain.NewScannerError(command, exitCode, nmapOutput, timeoutErr)
+		}
+
+		a.logger.Error("Nmap scan failed",
+			zap.String("job", name),
+			zap.String("reason", reason),
+			zap.String("output", nmapOutput),
+		)
+
+		err := fmt.Errorf("job %s failed: %s", name, message)
+		return "", domain.NewScannerError(command, exitCode, nmapOutput, classifyFailure("nmap", nmapOutput, err))
+	}
+	if noTargetsResolved(nmapOutput) {
+		return "", domain.NewScannerError(command, exitCode, nmapOutput, classifyFailure("nmap", nmapOutput, nil))
+	}
+
+	return output, nil
+}
+
+// podOutput returns the log of the pod of a Job and the exit code of nmap, -1 if unknown
+func (a *KubernetesAdapter) podOutput(ctx context.Context, job string) (string, int, error) {
+	pods := "/api/v1/namespaces/" + a.options.Namespace + "/pods"
+	var list kubernetesPodList
+	if err := a.client.get(ctx, pods+"?labelSelector="+url.QueryEscape("job-name="+job), &list); err != nil {
+		return "", -1, err
+	}
+	if len(list.Items) == 0 {
+		return "", -1, fmt.Errorf("job %s has no pod", job)
+	}
+
+	pod := list.Items[0]
+	exitCode := -1
+	for _, container := range pod.Status.ContainerStatuses {
+		if container.Name == "nmap" && container.State.Terminated != nil {
+			exitCode = container.State.Terminated.ExitCode
+		}
+	}
+
+	log, err := a.client.request(ctx, http.MethodGet, pods+"/"+pod.Metadata.Name+"/log?container=nmap", nil)
+	if err != nil {
+		return "", exitCode, err
+	}
+	return string(log), exitCode, nil
+}
+
+// deleteJob deletes a Job with its pod
+func (a *KubernetesAdapter) deleteJob(name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := "/apis/batch/v1/namespaces/" + a.options.Namespace + "/jobs/" + name + "?propagationPolicy=Background"
+	if _, err := a.client.request(ctx, http.MethodDelete, path, nil); err != nil {
+		a.logger.Warn("Failed to delete the scan Job", zap.String("job", name), zap.Error(err))
+	}
+}
+
+// jobManifest returns the Job running nmap with the arguments
+func (a *KubernetesAdapter) jobManifest(name string, args []string) map[string]any {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "nmap-scan",
+		"app.kubernetes.io/managed-by": "scanner-service",
+	}
+	for key, value := range a.options.Labels {
+		labels[key] = value
+	}
+
+	container := map[string]any{
+		"name":    "nmap",
+		"image":   a.options.Image,
+		"command": append([]string{"sh", "-c", kubernetesScanScript, a.options.NmapPath}, args...),
+		"securityContext": map[string]any{
+			"capabilities": map[string]any{"add": a.options.CapAdd, "drop": a.options.CapDrop},
+		},
+		"volumeMounts": []map[string]any{{"name": "output", "mountPath": kubernetesOutputDir}},
+	}
+	limits := map[string]string{}
+	if a.limits.CPUs > 0 {
+		limits["cpu"] = strconv.FormatFloat(a.limits.CPUs, 'f', -1, 64)
+	}
+	if a.limits.MemoryBytes > 0 {
+		limits["memory"] = strconv.FormatInt(a.limits.MemoryBytes, 10)
+	}
+	if len(limits) > 0 {
+		container["resources"] = map[string]any{"limits": limits}
+	}
+
+	podSpec := map[string]any{
+		"restartPolicy":                "Never",
+		"automountServiceAccountToken": false,
+		"containers":                   []map[string]any{container},
+		"volumes":                      []map[string]any{{"name": "output", "emptyDir": map[string]any{}}},
+	}
+	if len(a.options.NodeSelector) > 0 {
+		podSpec["nodeSelector"] = a.options.NodeSelector
+	}
+	if a.options.ServiceAccount != "" {
+		podSpec["serviceAccountName"] = a.options.ServiceAccount
+	}
+
+	jobSpec := map[string]any{
+		"backoffLimit":            0,
+		"ttlSecondsAfterFinished": int(kubernetesJobTTL.Seconds()),
+		"template": map[string]any{
+			"metadata": map[string]any{"labels": labels},
+			"spec":     podSpec,
+		},
+	}
+	if a.limits.MaxRuntime > 0 {
+		jobSpec["activeDeadlineSeconds"] = max(int(a.limits.MaxRuntime.Seconds()), 1)
+	}
+
+	return map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata":   map[string]any{"name": name, "labels": labels},
+		"spec":       jobSpec,
+	}
+}
+
+// GetVersion returns the version of nmap in the image, running a Job once to find it
+func (a *KubernetesAdapter) GetVersion() (string, error) {
+	a.versionMutex.Lock()
+	defer a.versionMutex.Unlock()
+
+	if a.version != "" {
+		return a.version, nil
+	}
+	if time.Since(a.versionChecked) < kubernetesVersionRetry {
+		return "", errors.NewUnavailable("failed to get nmap version of image "+a.options.Image, nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	output, err := a.runJob(ctx, []string{"--version"})
+	if err != nil {
+		a.versionChecked = time.Now()
+		return "", errors.NewUnavailable("failed to get nmap version of image "+a.options.Image, err)
+	}
+	a.version = strings.Split(strings.TrimSpace(output), "\n")[0]
+	return a.version, nil
+}
+
+// IsAvailable checks if the service may run Jobs in the namespace. The nmap version of
+// the image is checked when it is first needed, as it takes a Job.
+func (a *KubernetesAdapter) IsAvailable() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var jobs map[string]any
+	err := a.client.get(ctx, "/apis/batch/v1/namespaces/"+a.options.Namespace+"/jobs?limit=1", &jobs)
+	if err != nil {
+		a.logger.Error("Kubernetes Jobs are not available", zap.Error(err))
+	}
+	return err == nil
+}
+
+// RawSocketCapable reports whether nmap may open raw sockets in the scan pods
+func (a *KubernetesAdapter) RawSocketCapable() bool {
+	return containerRawSocketCapable(a.options.CapAdd, a.options.CapDrop)
+}
+
+// ScriptCategories returns the NSE script categories nmap ships scripts in, as the
+// script database of the image is not read
+func (a *KubernetesAdapter) ScriptCategories() ([]string, error) {
+	return nmapScriptCategories, nil
+}
@@ -0,0 +1,134 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeKubernetes is a Kubernetes API running Jobs that finish with a condition and
+// the log of their pod
+type fakeKubernetes struct {
+	condition string // Condition of finished Jobs, Complete or Failed
+	reason    string // Reason of the condition
+	exitCode  int    // Exit code of the nmap container
+	log       string // Log of the pods
+
+	mutex   sync.Mutex
+	job     map[string]any // Created Job
+	deleted []string       // Deleted Jobs
+}
+
+func (k *fakeKubernetes) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	const jobs = "/apis/batch/v1/namespaces/scans/jobs"
+	const pods = "/api/v1/namespaces/scans/pods"
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == jobs:
+		json.NewDecoder(r.Body).Decode(&k.job)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("{}"))
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, jobs+"/"):
+		json.NewEncoder(w).Encode(map[string]any{"status": map[string]any{"conditions": []map[string]string{
+			{"type": k.condition, "status": "True", "reason": k.reason, "message": "Job has reached the specified backoff limit"},
+		}}})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, jobs+"/"):
+		k.deleted = append(k.deleted, strings.TrimPrefix(r.URL.Path, jobs+"/"))
+		w.Write([]byte("{}"))
+	case r.Method == http.MethodGet && r.URL.Path == pods:
+		json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{{
+			"metadata": map[string]string{"name": "nmap-pod"},
+			"status": map[string]any{"containerStatuses": []map[string]any{
+				{"name": "nmap", "state": map[string]any{"terminated": map[string]int{"exitCode": k.exitCode}}},
+			}},
+		}}})
+	case r.Method == http.MethodGet && r.URL.Path == pods+"/nmap-pod/log":
+		io.WriteString(w, k.log)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	}
+}
+
+// newFakeKubernetesAdapter returns an adapter running Jobs in the namespace scans of a fake API
+func newFakeKubernetesAdapter(t *testing.T, api *fakeKubernetes, options KubernetesOptions) *KubernetesAdapter {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	options.Namespace = "scans"
+	options.PollInterval = time.Millisecond
+	client := &kubernetesClient{server: server.URL, httpClient: server.Client()}
+	return newKubernetesAdapter(options, client, &logger.Logger{Logger: zap.NewNop()})
+}
+
+func TestKubernetesExecuteScan(t *testing.T) {
+	api := &fakeKubernetes{
+		condition: "Complete",
+		log: "Starting Nmap 7.95\nNmap done: 1 IP address (1 host up)\n\n" + kubernetesXMLMarker + "\n" +
+			`<?xml version="1.0"?><nmaprun><host><status state="up"/><address addr="10.0.0.1" addrtype="ipv4"/></host><runstats><hosts up="1" down="0" total="1"/></runstats></nmaprun>`,
+	}
+	adapter := newFakeKubernetesAdapter(t, api, KubernetesOptions{
+		Image:        "nmap:7.95",
+		NodeSelector: map[string]string{"scanner": "true"},
+		Labels:       map[string]string{"network-policy": "nmap-scan"},
+		CapAdd:       []string{"NET_RAW"},
+	})
+	adapter.SetResourceLimits(ResourceLimits{CPUs: 0.5, MaxRuntime: time.Hour})
+
+	result, err := adapter.ExecuteScan(context.Background(), domain.ScanOptions{Target: "10.0.0.1", TimingTemplate: domain.TimingNormal})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.UpHosts)
+	require.Len(t, result.Hosts, 1)
+	assert.Equal(t, "10.0.0.1", result.Hosts[0].IP)
+	assert.Equal(t, "nmap 10.0.0.1 -T3 -oX /scan/nmap.xml", result.Command)
+
+	// The Job ran nmap with the configured settings and was deleted afterwards
+	spec := api.job["spec"].(map[string]any)
+	assert.Equal(t, float64(3600), spec["activeDeadlineSeconds"])
+	template := spec["template"].(map[string]any)
+	assert.Equal(t, "nmap-scan", template["metadata"].(map[string]any)["labels"].(map[string]any)["network-policy"])
+	podSpec := template["spec"].(map[string]any)
+	assert.Equal(t, map[string]any{"scanner": "true"}, podSpec["nodeSelector"])
+	container := podSpec["containers"].([]any)[0].(map[string]any)
+	assert.Equal(t, "nmap:7.95", container["image"])
+	assert.Equal(t, []any{"sh", "-c", kubernetesScanScript, "nmap", "10.0.0.1", "-T3", "-oX", "/scan/nmap.xml"}, container["command"])
+	assert.Equal(t, map[string]any{"cpu": "0.5"}, container["resources"].(map[string]any)["limits"])
+
+	name := api.job["metadata"].(map[string]any)["name"].(string)
+	assert.Equal(t, []string{name}, api.deleted)
+}
+
+func TestKubernetesExecuteScanRecordsFailure(t *testing.T) {
+	api := &fakeKubernetes{
+		condition: "Failed",
+		reason:    "BackoffLimitExceeded",
+		exitCode:  1,
+		log:       "You requested a scan type which requires root privileges.\nQUITTING!\n\n" + kubernetesXMLMarker + "\n",
+	}
+	adapter := newFakeKubernetesAdapter(t, api, KubernetesOptions{Image: "nmap:7.95"})
+
+	result, err := adapter.ExecuteScan(context.Background(), domain.ScanOptions{Target: "10.0.0.1", ScanType: domain.ScanTypeSYN})
+	assert.Nil(t, result)
+
+	var scannerErr *domain.ScannerError
+	require.ErrorAs(t, err, &scannerErr)
+	assert.Equal(t, errors.ErrPermissionDenied, scannerErr.Failure.Type)
+	assert.Equal(t, 1, scannerErr.Failure.ExitCode)
+	assert.True(t, strings.HasPrefix(scannerErr.Failure.Command, "job/nmap-"))
+	assert.Len(t, api.deleted, 1)
+}
@@ -0,0 +1,123 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// serviceAccountDir is where Kubernetes mounts the service account of a pod
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// kubernetesClient calls the Kubernetes API over HTTP with a service account token
+type kubernetesClient struct {
+	server     string // Address of the API server, e.g. https://10.0.0.1:443
+	tokenFile  string // File of the token, read for every request as it is rotated
+	httpClient *http.Client
+}
+
+// inClusterClient creates a client with the service account of the pod the service
+// runs in, and returns the namespace of the pod
+func inClusterClient() (*kubernetesClient, string, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, "", fmt.Errorf("not running in a Kubernetes cluster, KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not set")
+	}
+
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read the cluster CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, "", fmt.Errorf("invalid cluster CA in %s", serviceAccountDir)
+	}
+	namespace, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read the namespace of the pod: %w", err)
+	}
+
+	client := &kubernetesClient{
+		server:    "https://" + net.JoinHostPort(host, port),
+		tokenFile: filepath.Join(serviceAccountDir, "token"),
+		httpClient: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		},
+	}
+	return client, strings.TrimSpace(string(namespace)), nil
+}
+
+// kubernetesStatus is the error body of the Kubernetes API
+type kubernetesStatus struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
+
+// request calls the API and returns the response body. Bodies are sent as JSON.
+func (c *kubernetesClient) request(ctx context.Context, method, path string, body any) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.server+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.tokenFile != "" {
+		token, err := os.ReadFile(c.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		var status kubernetesStatus
+		if json.Unmarshal(data, &status) == nil && status.Message != "" {
+			return nil, fmt.Errorf("kubernetes API returned status %d for %s %s: %s", resp.StatusCode, method, path, status.Message)
+		}
+		return nil, fmt.Errorf("kubernetes API returned status %d for %s %s", resp.StatusCode, method, path)
+	}
+	return data, nil
+}
+
+// get calls the API with GET and decodes the JSON response into out
+func (c *kubernetesClient) get(ctx context.Context, path string, out any) error {
+	data, err := c.request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid kubernetes API response for %s: %w", path, err)
+	}
+	return nil
+}
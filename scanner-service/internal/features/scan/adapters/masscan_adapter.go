@@ -0,0 +1,207 @@
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultMasscanPorts are scanned when a masscan scan does not specify ports,
+// since masscan has no default port list
+const defaultMasscanPorts = "1-1000"
+
+// MasscanAdapter is an adapter for masscan, which finds open ports over large
+// address ranges much faster than nmap but reports no service details
+type MasscanAdapter struct {
+	masscanPath string
+	rate        int // Packets per second
+	logger      *logger.Logger
+}
+
+// NewMasscanAdapter creates a new MasscanAdapter sending at most rate packets per second
+func NewMasscanAdapter(masscanPath string, rate int, logger *logger.Logger) *MasscanAdapter {
+	if masscanPath == "" {
+		masscanPath = "masscan" // Use PATH by default
+	}
+	if rate <= 0 {
+		rate = 1000
+	}
+
+	return &MasscanAdapter{
+		masscanPath: masscanPath,
+		rate:        rate,
+		logger:      logger,
+	}
+}
+
+// Capabilities returns the scan options supported by masscan
+func (a *MasscanAdapter) Capabilities() Capabilities {
+	return Capabilities{ScanTypes: []domain.ScanType{domain.ScanTypeSYN, domain.ScanTypeUDP}}
+}
+
+// ExecuteScan executes a masscan scan with the given options
+func (a *MasscanAdapter) ExecuteScan(ctx context.Context, scanOptions domain.ScanOptions) (*domain.ScanResult, error) {
+	startTime := time.Now()
+	args := a.buildCommandArgs(scanOptions)
+
+	a.logger.Info("Executing masscan scan",
+		zap.String("target", scanOptions.Target),
+		zap.Strings("args", args),
+	)
+
+	// Create a temporary file for list output
+	tmpFile, err := os.CreateTemp("", "masscan-scan-*.txt")
+	if err != nil {
+		return nil, errors.NewInternal("failed to create temporary file", err)
+	}
+	tmpFileName := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(tmpFileName)
+
+	args = append(args, "-oL", tmpFileName)
+
+	if _, err := runScanner(ctx, a.logger, "masscan", a.masscanPath, args); err != nil {
+		return nil, err
+	}
+
+	output, err := os.ReadFile(tmpFileName)
+	if err != nil {
+		return nil, errors.NewInternal("failed to read masscan output", err)
+	}
+
+	hosts, err := parseMasscanList(output)
+	if err != nil {
+		return nil, errors.NewInternal("failed to parse masscan output", err)
+	}
+
+	endTime := time.Now()
+	result := &domain.ScanResult{
+		ID:         uuid.New().String(),
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Duration:   endTime.Sub(startTime).Seconds(),
+		Command:    a.masscanPath + " " + strings.Join(args, " "),
+		TotalHosts: countAddresses(scanOptions.Target),
+		UpHosts:    len(hosts),
+		Hosts:      hosts,
+	}
+	result.Summary = fmt.Sprintf("masscan done: %d IP addresses (%d hosts up) scanned in %.2f seconds",
+		result.TotalHosts, result.UpHosts, result.Duration)
+
+	a.logger.Info("Masscan scan completed",
+		zap.String("target", scanOptions.Target),
+		zap.Int("up_hosts", result.UpHosts),
+		zap.Float64("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// buildCommandArgs builds masscan command arguments from scan options
+func (a *MasscanAdapter) buildCommandArgs(options domain.ScanOptions) []string {
+	targets, _ := addressTargets("masscan", options.Target)
+	args := append([]string{}, targets...)
+
+	ports := options.Ports
+	if ports == "" {
+		ports = defaultMasscanPorts
+	}
+
+	// UDP ports are prefixed with U:
+	if options.ScanType == domain.ScanTypeUDP {
+		specs := strings.Split(ports, ",")
+		for i, spec := range specs {
+			specs[i] = "U:" + strings.TrimSpace(spec)
+		}
+		ports = strings.Join(specs, ",")
+	}
+
+	args = append(args, "-p", ports, "--rate", strconv.Itoa(a.rate))
+	return args
+}
+
+// parseMasscanList parses the list output of masscan ("open tcp 80 10.0.0.1 1700000000")
+// into hosts with their open ports, ordered by address and port
+func parseMasscanList(output []byte) ([]domain.Host, error) {
+	hostsByIP := make(map[string]*domain.Host)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || strings.HasPrefix(fields[0], "#") || fields[0] != "open" {
+			continue
+		}
+
+		port, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", fields[2])
+		}
+
+		ip := fields[3]
+		host, ok := hostsByIP[ip]
+		if !ok {
+			host = newDiscoveredHost(ip)
+			hostsByIP[ip] = host
+		}
+		host.Ports = append(host.Ports, domain.Port{Port: port, Protocol: fields[1], State: "open"})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sortedHosts(hostsByIP), nil
+}
+
+// newDiscoveredHost creates an up host found by a port discovery engine
+func newDiscoveredHost(ip string) *domain.Host {
+	return &domain.Host{
+		IP:        ip,
+		Status:    "up",
+		Hostnames: make([]string, 0),
+		Ports:     make([]domain.Port, 0),
+		Scripts:   make([]domain.Script, 0),
+	}
+}
+
+// sortedHosts returns the hosts ordered by address, with their ports ordered by number
+func sortedHosts(hostsByIP map[string]*domain.Host) []domain.Host {
+	hosts := make([]domain.Host, 0, len(hostsByIP))
+	for _, host := range hostsByIP {
+		sort.Slice(host.Ports, func(i, j int) bool {
+			if host.Ports[i].Port != host.Ports[j].Port {
+				return host.Ports[i].Port < host.Ports[j].Port
+			}
+			return host.Ports[i].Protocol < host.Ports[j].Protocol
+		})
+		hosts = append(hosts, *host)
+	}
+
+	sort.Slice(hosts, func(i, j int) bool {
+		return bytes.Compare(net.ParseIP(hosts[i].IP).To16(), net.ParseIP(hosts[j].IP).To16()) < 0
+	})
+	return hosts
+}
+
+// GetVersion returns the masscan version
+func (a *MasscanAdapter) GetVersion() (string, error) {
+	return scannerVersion("masscan", a.masscanPath, "--version")
+}
+
+// IsAvailable checks if masscan is available
+func (a *MasscanAdapter) IsAvailable() bool {
+	_, err := a.GetVersion()
+	return err == nil
+}
@@ -0,0 +1,184 @@
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// NaabuAdapter is an adapter for projectdiscovery/naabu, a port discovery engine
+// for networks where nmap SYN scans are too slow or blocked
+type NaabuAdapter struct {
+	naabuPath string
+	rate      int // Packets per second
+	logger    *logger.Logger
+}
+
+// naabuResult is a line of the JSON output of naabu
+type naabuResult struct {
+	Host     string `json:"host"`
+	IP       string `json:"ip"`
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// NewNaabuAdapter creates a new NaabuAdapter sending at most rate packets per second
+func NewNaabuAdapter(naabuPath string, rate int, logger *logger.Logger) *NaabuAdapter {
+	if naabuPath == "" {
+		naabuPath = "naabu" // Use PATH by default
+	}
+	if rate <= 0 {
+		rate = 1000
+	}
+
+	return &NaabuAdapter{
+		naabuPath: naabuPath,
+		rate:      rate,
+		logger:    logger,
+	}
+}
+
+// Capabilities returns the scan options supported by naabu
+func (a *NaabuAdapter) Capabilities() Capabilities {
+	return Capabilities{
+		ScanTypes: []domain.ScanType{domain.ScanTypeSYN, domain.ScanTypeConnect},
+		HostNames: true,
+	}
+}
+
+// ExecuteScan executes a naabu scan with the given options
+func (a *NaabuAdapter) ExecuteScan(ctx context.Context, scanOptions domain.ScanOptions) (*domain.ScanResult, error) {
+	startTime := time.Now()
+	args := a.buildCommandArgs(scanOptions)
+
+	a.logger.Info("Executing naabu scan",
+		zap.String("target", scanOptions.Target),
+		zap.Strings("args", args),
+	)
+
+	output, err := runScanner(ctx, a.logger, "naabu", a.naabuPath, args)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := parseNaabuJSON(output)
+	if err != nil {
+		return nil, errors.NewInternal("failed to parse naabu output", err)
+	}
+
+	endTime := time.Now()
+	result := &domain.ScanResult{
+		ID:         uuid.New().String(),
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Duration:   endTime.Sub(startTime).Seconds(),
+		Command:    a.naabuPath + " " + strings.Join(args, " "),
+		TotalHosts: countAddresses(scanOptions.Target),
+		UpHosts:    len(hosts),
+		Hosts:      hosts,
+	}
+	result.Summary = fmt.Sprintf("naabu done: %d IP addresses (%d hosts up) scanned in %.2f seconds",
+		result.TotalHosts, result.UpHosts, result.Duration)
+
+	a.logger.Info("Naabu scan completed",
+		zap.String("target", scanOptions.Target),
+		zap.Int("up_hosts", result.UpHosts),
+		zap.Float64("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// buildCommandArgs builds naabu command arguments from scan options.
+// Without ports naabu scans its top 100 ports.
+func (a *NaabuAdapter) buildCommandArgs(options domain.ScanOptions) []string {
+	targets := strings.Fields(strings.ReplaceAll(options.Target, ",", " "))
+	args := []string{"-host", strings.Join(targets, ","), "-json", "-silent"}
+
+	if options.Ports != "" {
+		args = append(args, "-p", options.Ports)
+	}
+
+	// Connect scans do not need raw socket privileges
+	if options.ScanType == domain.ScanTypeConnect {
+		args = append(args, "-s", "c")
+	} else {
+		args = append(args, "-s", "s")
+	}
+
+	args = append(args, "-rate", strconv.Itoa(a.rate))
+	return args
+}
+
+// parseNaabuJSON parses the JSON lines output of naabu into hosts with their open ports,
+// ordered by address and port. Ports reported again for another name of a host are
+// listed once.
+func parseNaabuJSON(output []byte) ([]domain.Host, error) {
+	hostsByIP := make(map[string]*domain.Host)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+
+		var found naabuResult
+		if err := json.Unmarshal(line, &found); err != nil {
+			return nil, err
+		}
+		ip := found.IP
+		if ip == "" {
+			ip = found.Host
+		}
+		if ip == "" || found.Port == 0 {
+			continue
+		}
+
+		host, ok := hostsByIP[ip]
+		if !ok {
+			host = newDiscoveredHost(ip)
+			hostsByIP[ip] = host
+		}
+		if found.Host != "" && found.Host != ip && !slices.Contains(host.Hostnames, found.Host) {
+			host.Hostnames = append(host.Hostnames, found.Host)
+		}
+
+		protocol := found.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		port := domain.Port{Port: found.Port, Protocol: protocol, State: "open"}
+		if !slices.Contains(host.Ports, port) {
+			host.Ports = append(host.Ports, port)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sortedHosts(hostsByIP), nil
+}
+
+// GetVersion returns the naabu version
+func (a *NaabuAdapter) GetVersion() (string, error) {
+	return scannerVersion("naabu", a.naabuPath, "-version")
+}
+
+// IsAvailable checks if naabu is available
+func (a *NaabuAdapter) IsAvailable() bool {
+	_, err := a.GetVersion()
+	return err == nil
+}
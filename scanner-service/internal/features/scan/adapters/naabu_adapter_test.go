@@ -0,0 +1,122 @@
+package adapters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNaabuBuildCommandArgs(t *testing.T) {
+	adapter := NewNaabuAdapter("", 0, nil)
+
+	tests := []struct {
+		name    string
+		options domain.ScanOptions
+		args    string
+	}{
+		{
+			name:    "defaults",
+			options: domain.ScanOptions{Target: "10.0.0.1"},
+			args:    "-host 10.0.0.1 -json -silent -s s -rate 1000",
+		},
+		{
+			name:    "targets and ports",
+			options: domain.ScanOptions{Target: "10.0.0.0/24, example.com 10.0.1.1", Ports: "22,80-90"},
+			args:    "-host 10.0.0.0/24,example.com,10.0.1.1 -json -silent -p 22,80-90 -s s -rate 1000",
+		},
+		{
+			name:    "connect scan",
+			options: domain.ScanOptions{Target: "10.0.0.1", ScanType: domain.ScanTypeConnect},
+			args:    "-host 10.0.0.1 -json -silent -s c -rate 1000",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.args, strings.Join(adapter.buildCommandArgs(test.options), " "))
+		})
+	}
+
+	assert.Equal(t, "naabu", adapter.naabuPath)
+	assert.Contains(t, strings.Join(NewNaabuAdapter("/opt/naabu", 250, nil).buildCommandArgs(domain.ScanOptions{Target: "10.0.0.1"}), " "), "-rate 250")
+}
+
+func TestParseNaabuJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		hosts  []domain.Host
+		err    bool
+	}{
+		{
+			name:   "empty output",
+			output: "",
+			hosts:  []domain.Host{},
+		},
+		{
+			name: "hosts ordered by address and port",
+			output: `{"ip":"10.0.0.2","port":443,"protocol":"tcp"}
+{"ip":"10.0.0.1","port":80}
+{"host":"www.example.com","ip":"10.0.0.2","port":22,"protocol":"tcp"}
+`,
+			hosts: []domain.Host{
+				{IP: "10.0.0.1", Status: "up", Hostnames: []string{}, Scripts: []domain.Script{}, Ports: []domain.Port{
+					{Port: 80, Protocol: "tcp", State: "open"},
+				}},
+				{IP: "10.0.0.2", Status: "up", Hostnames: []string{"www.example.com"}, Scripts: []domain.Script{}, Ports: []domain.Port{
+					{Port: 22, Protocol: "tcp", State: "open"},
+					{Port: 443, Protocol: "tcp", State: "open"},
+				}},
+			},
+		},
+		{
+			name: "duplicate host and port",
+			output: `{"host":"example.com","ip":"10.0.0.1","port":443}
+{"host":"www.example.com","ip":"10.0.0.1","port":443}
+{"host":"example.com","ip":"10.0.0.1","port":443}
+`,
+			hosts: []domain.Host{
+				{IP: "10.0.0.1", Status: "up", Hostnames: []string{"example.com", "www.example.com"}, Scripts: []domain.Script{}, Ports: []domain.Port{
+					{Port: 443, Protocol: "tcp", State: "open"},
+				}},
+			},
+		},
+		{
+			name: "banner, blank lines and incomplete results are skipped",
+			output: `naabu v2.3.0 started
+
+{"ip":"10.0.0.1"}
+{"port":22}
+{"host":"10.0.0.3","port":8080}
+`,
+			hosts: []domain.Host{
+				{IP: "10.0.0.3", Status: "up", Hostnames: []string{}, Scripts: []domain.Script{}, Ports: []domain.Port{
+					{Port: 8080, Protocol: "tcp", State: "open"},
+				}},
+			},
+		},
+		{
+			name:   "malformed line",
+			output: "{\"ip\":\"10.0.0.1\",\"port\":22}\n{\"ip\":\"10.0.0.1\",\"port\":\n",
+			err:    true,
+		},
+		{
+			name:   "mistyped field",
+			output: `{"ip":"10.0.0.1","port":"ssh"}`,
+			err:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hosts, err := parseNaabuJSON([]byte(test.output))
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.hosts, hosts)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strconv"
@@ -14,77 +15,18 @@ import (
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 // NmapXML represents the nmap XML output structure
 type NmapXML struct {
-	XMLName xml.Name `xml:"nmaprun"`
-	Args    string   `xml:"args,attr"`
-	Start   int64    `xml:"start,attr"`
-	Version string   `xml:"version,attr"`
-	Hosts   []struct {
-		StartTime int64 `xml:"starttime,attr"`
-		EndTime   int64 `xml:"endtime,attr"`
-		Status    struct {
-			State string `xml:"state,attr"`
-		} `xml:"status"`
-		Addresses []struct {
-			Addr     string `xml:"addr,attr"`
-			AddrType string `xml:"addrtype,attr"`
-			Vendor   string `xml:"vendor,attr,omitempty"`
-		} `xml:"address"`
-		Hostnames struct {
-			Hostnames []struct {
-				Name string `xml:"name,attr"`
-				Type string `xml:"type,attr"`
-			} `xml:"hostname"`
-		} `xml:"hostnames"`
-		Ports struct {
-			Ports []struct {
-				Protocol string `xml:"protocol,attr"`
-				PortID   int    `xml:"portid,attr"`
-				State    struct {
-					State  string `xml:"state,attr"`
-					Reason string `xml:"reason,attr"`
-				} `xml:"state"`
-				Service struct {
-					Name       string `xml:"name,attr"`
-					Product    string `xml:"product,attr,omitempty"`
-					Version    string `xml:"version,attr,omitempty"`
-					ExtraInfo  string `xml:"extrainfo,attr,omitempty"`
-					Method     string `xml:"method,attr"`
-					Conf       string `xml:"conf,attr"`
-					DeviceType string `xml:"devicetype,attr,omitempty"`
-				} `xml:"service"`
-				Scripts []struct {
-					ID     string `xml:"id,attr"`
-					Output string `xml:"output,attr"`
-				} `xml:"script"`
-			} `xml:"port"`
-		} `xml:"ports"`
-		OS struct {
-			Matches []struct {
-				Name     string `xml:"name,attr"`
-				Accuracy string `xml:"accuracy,attr"`
-			} `xml:"osmatch"`
-		} `xml:"os"`
-		Uptime struct {
-			Seconds  string `xml:"seconds,attr"`
-			LastBoot string `xml:"lastboot,attr,omitempty"`
-		} `xml:"uptime"`
-		Distance struct {
-			Value string `xml:"value,attr"`
-		} `xml:"distance"`
-		TCPSequence struct {
-			Index      string `xml:"index,attr"`
-			Difficulty string `xml:"difficulty,attr"`
-		} `xml:"tcpsequence"`
-		IPIDSequence struct {
-			Class string `xml:"class,attr"`
-		} `xml:"ipidsequence"`
-	} `xml:"host"`
+	XMLName  xml.Name   `xml:"nmaprun"`
+	Args     string     `xml:"args,attr"`
+	Start    int64      `xml:"start,attr"`
+	Version  string     `xml:"version,attr"`
+	Hosts    []nmapHost `xml:"host"`
 	RunStats struct {
 		Finished struct {
 			Time    int64   `xml:"time,attr"`
@@ -101,12 +43,104 @@ type NmapXML struct {
 	} `xml:"runstats"`
 }
 
+// nmapHost represents a host element of the nmap XML output
+type nmapHost struct {
+	StartTime int64 `xml:"starttime,attr"`
+	EndTime   int64 `xml:"endtime,attr"`
+	Status    struct {
+		State string `xml:"state,attr"`
+	} `xml:"status"`
+	Addresses []struct {
+		Addr     string `xml:"addr,attr"`
+		AddrType string `xml:"addrtype,attr"`
+		Vendor   string `xml:"vendor,attr,omitempty"`
+	} `xml:"address"`
+	Hostnames struct {
+		Hostnames []struct {
+			Name string `xml:"name,attr"`
+			Type string `xml:"type,attr"`
+		} `xml:"hostname"`
+	} `xml:"hostnames"`
+	Ports struct {
+		Ports []struct {
+			Protocol string `xml:"protocol,attr"`
+			PortID   int    `xml:"portid,attr"`
+			State    struct {
+				State  string `xml:"state,attr"`
+				Reason string `xml:"reason,attr"`
+			} `xml:"state"`
+			Service struct {
+				Name       string `xml:"name,attr"`
+				Product    string `xml:"product,attr,omitempty"`
+				Version    string `xml:"version,attr,omitempty"`
+				ExtraInfo  string `xml:"extrainfo,attr,omitempty"`
+				Method     string `xml:"method,attr"`
+				Conf       string `xml:"conf,attr"`
+				DeviceType string `xml:"devicetype,attr,omitempty"`
+			} `xml:"service"`
+			Scripts []nmapScript `xml:"script"`
+		} `xml:"port"`
+	} `xml:"ports"`
+	OS struct {
+		Matches []struct {
+			Name     string `xml:"name,attr"`
+			Accuracy string `xml:"accuracy,attr"`
+		} `xml:"osmatch"`
+	} `xml:"os"`
+	Uptime struct {
+		Seconds  string `xml:"seconds,attr"`
+		LastBoot string `xml:"lastboot,attr,omitempty"`
+	} `xml:"uptime"`
+	Distance struct {
+		Value string `xml:"value,attr"`
+	} `xml:"distance"`
+	TCPSequence struct {
+		Index      string `xml:"index,attr"`
+		Difficulty string `xml:"difficulty,attr"`
+	} `xml:"tcpsequence"`
+	IPIDSequence struct {
+		Class string `xml:"class,attr"`
+	} `xml:"ipidsequence"`
+}
+
+// nmapScript represents a script element of the nmap XML output with its structured output
+type nmapScript struct {
+	ID       string      `xml:"id,attr"`
+	Output   string      `xml:"output,attr"`
+	Elements []nmapElem  `xml:"elem"`
+	Tables   []nmapTable `xml:"table"`
+}
+
+// nmapTable represents a table of the structured output of a script. Tables without a
+// key are list items.
+type nmapTable struct {
+	Key      string      `xml:"key,attr"`
+	Elements []nmapElem  `xml:"elem"`
+	Tables   []nmapTable `xml:"table"`
+}
+
+// nmapElem represents a value of the structured output of a script
+type nmapElem struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// nmapInterruptDelay is how long an interrupted nmap may take to write its output before it is killed
+const nmapInterruptDelay = 5 * time.Second
+
 // NmapAdapter is an adapter for nmap
 type NmapAdapter struct {
-	nmapPath string
-	logger   *logger.Logger
+	nmapPath          string
+	defaultMaxRetries int            // Retransmissions of scans not setting them, -1 for the nmap default
+	limits            ResourceLimits // Resource controls nmap runs under
+	launcher          nmapLauncher   // Runs nmap somewhere else than in the service, nil to run it locally
+	logger            *logger.Logger
 }
 
+// nmapLauncher returns the command running nmap with the arguments, given the paths of
+// the output files nmap writes, and a function cleaning up after a failed run
+type nmapLauncher func(args []string, outputs []string) (string, []string, func())
+
 // NewNmapAdapter creates a new NmapAdapter
 func NewNmapAdapter(nmapPath string, logger *logger.Logger) *NmapAdapter {
 	if nmapPath == "" {
@@ -114,13 +148,30 @@ func NewNmapAdapter(nmapPath string, logger *logger.Logger) *NmapAdapter {
 	}
 
 	return &NmapAdapter{
-		nmapPath: nmapPath,
-		logger:   logger,
+		nmapPath:          nmapPath,
+		defaultMaxRetries: -1,
+		logger:            logger,
 	}
 }
 
+// SetDefaultMaxRetries sets the probe retransmissions of scans not setting them,
+// -1 to leave them to nmap
+func (a *NmapAdapter) SetDefaultMaxRetries(retries int) {
+	a.defaultMaxRetries = retries
+}
+
+// ValidateOptions checks the extra options of an nmap scan against the allowlist
+func (a *NmapAdapter) ValidateOptions(options domain.ScanOptions) error {
+	return validateExtraOptions(options.ExtraOptions)
+}
+
 // ExecuteScan executes an nmap scan with the given options
 func (a *NmapAdapter) ExecuteScan(ctx context.Context, scanOptions domain.ScanOptions) (*domain.ScanResult, error) {
+	// Extra options are passed to nmap as they are
+	if err := a.ValidateOptions(scanOptions); err != nil {
+		return nil, err
+	}
+
 	startTime := time.Now()
 
 	// Build nmap command
@@ -131,48 +182,41 @@ func (a *NmapAdapter) ExecuteScan(ctx context.Context, scanOptions domain.ScanOp
 		zap.Strings("args", args),
 	)
 
-	// Create a temporary file for XML output
-	tmpFile, err := os.CreateTemp("", "nmap-scan-*.xml")
+	// Create a file for XML output, next to the state file of resumable scans so
+	// that a resumed nmap finds the output files of the interrupted run
+	xmlFileName, err := xmlOutputFile(scanOptions.StateFile)
 	if err != nil {
-		return nil, errors.NewInternal("failed to create temporary file", err)
+		return nil, err
 	}
-	tmpFileName := tmpFile.Name()
-	tmpFile.Close()
-	defer os.Remove(tmpFileName)
+	defer os.Remove(xmlFileName)
 
-	// Add XML output to args
-	args = append(args, "-oX", tmpFileName)
-
-	// Create command
-	cmd := exec.CommandContext(ctx, a.nmapPath, args...)
-
-	// Capture stdout and stderr
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	// Run command
-	if err := cmd.Run(); err != nil {
-		// Check for context cancellation
-		if ctx.Err() == context.Canceled {
-			return nil, errors.NewTimeout("scan was cancelled", ctx.Err())
-		}
+	// Add XML output to args, and grepable output recording the progress of resumable scans.
+	// nmap prints its progress periodically for the phase and completion estimate.
+	args = append(args, "-oX", xmlFileName, "--stats-every", nmapStatsInterval)
+	if scanOptions.StateFile != "" {
+		args = append(args, "-oG", scanOptions.StateFile)
+	}
 
-		// Check for context timeout
-		if ctx.Err() == context.DeadlineExceeded {
-			return nil, errors.NewTimeout("scan timed out", ctx.Err())
+	// Run command, reporting the hosts nmap completes while it runs
+	stopStream := startHostStream(ctx, xmlFileName)
+	err = a.runNmap(ctx, args, []string{xmlFileName, scanOptions.StateFile})
+	stopStream()
+	if err != nil {
+		// Keep the hosts nmap completed before the scan timed out or was cancelled
+		if ctx.Err() != nil {
+			if result := a.partialResult(xmlFileName, startTime); result != nil {
+				result.Command = a.nmapPath + " " + strings.Join(args, " ")
+				return result, err
+			}
 		}
-
-		a.logger.Error("Nmap scan failed",
-			zap.Error(err),
-			zap.String("stderr", stderr.String()),
-		)
-
-		return nil, errors.NewInternal("nmap scan failed", err)
+		return nil, err
+	}
+	if scanOptions.StateFile != "" {
+		os.Remove(scanOptions.StateFile)
 	}
 
 	// Read XML output
-	xmlData, err := os.ReadFile(tmpFileName)
+	xmlData, err := os.ReadFile(xmlFileName)
 	if err != nil {
 		return nil, errors.NewInternal("failed to read nmap output", err)
 	}
@@ -201,15 +245,124 @@ func (a *NmapAdapter) ExecuteScan(ctx context.Context, scanOptions domain.ScanOp
 	return result, nil
 }
 
+// partialResult returns the hosts nmap wrote to the XML output file before it was
+// stopped, or nil if it completed none
+func (a *NmapAdapter) partialResult(xmlFileName string, startTime time.Time) *domain.ScanResult {
+	hosts, err := readHosts(xmlFileName)
+	if err != nil || len(hosts) == 0 {
+		return nil
+	}
+
+	endTime := time.Now()
+	result := &domain.ScanResult{
+		ID:         uuid.New().String(),
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Duration:   endTime.Sub(startTime).Seconds(),
+		TotalHosts: len(hosts),
+		UpHosts:    len(hosts),
+		Hosts:      hosts,
+		Partial:    true,
+	}
+	result.Summary = fmt.Sprintf("Nmap stopped: %d hosts up completed in %.2f seconds", result.UpHosts, result.Duration)
+
+	a.logger.Info("Nmap scan stopped with partial result",
+		zap.Int("up_hosts", result.UpHosts),
+		zap.Float64("duration", result.Duration),
+	)
+
+	return result
+}
+
+// runNmap runs nmap with the arguments until it exits or the context is done.
+// nmap is interrupted rather than killed so that it flushes its output files, the
+// paths of which are given as outputs; empty paths are ignored.
+func (a *NmapAdapter) runNmap(ctx context.Context, args []string, outputs []string) error {
+	// Create command
+	path, cleanup := a.nmapPath, func() {}
+	if a.launcher != nil {
+		path, args, cleanup = a.launcher(args, outputs)
+	}
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = nmapInterruptDelay
+
+	// Capture stdout and stderr, in the log of the scan as well
+	var stdout, stderr bytes.Buffer
+	flush := captureOutput(ctx, cmd, &stdout, &stderr)
+	if report, ok := domain.ProgressReporterFromContext(ctx); ok {
+		cmd.Stdout = io.MultiWriter(cmd.Stdout, newNmapProgressWriter(report))
+	}
+
+	// Run nmap under the resource limits
+	process, err := startLimited(cmd, a.limits)
+	if err == nil {
+		err = process.wait()
+	}
+	flush()
+	if err != nil {
+		cleanup()
+
+		// Check for context cancellation
+		if ctx.Err() == context.Canceled {
+			return errors.NewTimeout("scan was cancelled", ctx.Err())
+		}
+
+		// Check for context timeout
+		if ctx.Err() == context.DeadlineExceeded {
+			return errors.NewTimeout("scan timed out", ctx.Err())
+		}
+
+		// Check for the resource limits
+		if process != nil && process.killed.Load() {
+			message := fmt.Sprintf("nmap exceeded its maximum runtime of %s and was killed", a.limits.MaxRuntime)
+			return scannerError(cmd, stderr.String(), errors.NewTimeout(message, err))
+		}
+		if process != nil && process.oom {
+			memoryErr := errors.NewInternal("nmap exceeded its memory limit and was killed", err)
+			return scannerError(cmd, stderr.String(), errors.WithHint(memoryErr, "Scan fewer hosts at once or raise the memory limit of nmap."))
+		}
+
+		a.logger.Error("Nmap scan failed",
+			zap.Error(err),
+			zap.String("stderr", stderr.String()),
+		)
+
+		return scannerError(cmd, stderr.String(), classifyFailure("nmap", stderr.String(), err))
+	}
+	if noTargetsResolved(stderr.String()) {
+		return scannerError(cmd, stderr.String(), classifyFailure("nmap", stderr.String(), nil))
+	}
+
+	return nil
+}
+
+// xmlOutputFile returns the XML output file of a scan: a temporary file, or a file
+// next to the state file of resumable scans
+func xmlOutputFile(stateFile string) (string, error) {
+	if stateFile != "" {
+		return stateFile + ".xml", nil
+	}
+
+	tmpFile, err := os.CreateTemp("", "nmap-scan-*.xml")
+	if err != nil {
+		return "", errors.NewInternal("failed to create temporary file", err)
+	}
+	tmpFile.Close()
+	return tmpFile.Name(), nil
+}
+
 // buildCommandArgs builds nmap command arguments from scan options
 func (a *NmapAdapter) buildCommandArgs(options domain.ScanOptions) []string {
 	var args []string
 
-	// Add target
-	args = append(args, options.Target)
+	// Add targets, the discovery stage passes several space-separated addresses
+	args = append(args, strings.Fields(options.Target)...)
 
-	// Add ports
-	if options.Ports != "" {
+	// Add ports, a ping scan does not scan ports
+	if options.Ports != "" && options.ScanType != domain.ScanTypePing {
 		args = append(args, "-p", options.Ports)
 	}
 
@@ -227,6 +380,8 @@ func (a *NmapAdapter) buildCommandArgs(options domain.ScanOptions) []string {
 		args = append(args, "-sC")
 	case domain.ScanTypeAll:
 		args = append(args, "-A")
+	case domain.ScanTypePing:
+		args = append(args, "-sn")
 	}
 
 	// Add timing template
@@ -249,6 +404,45 @@ func (a *NmapAdapter) buildCommandArgs(options domain.ScanOptions) []string {
 		args = append(args, "-sC")
 	}
 
+	// Add host timeout
+	if options.HostTimeout > 0 {
+		args = append(args, "--host-timeout", strconv.FormatInt(options.HostTimeout.Milliseconds(), 10)+"ms")
+	}
+
+	// Add packet rate and parallelism
+	if options.MinRate > 0 {
+		args = append(args, "--min-rate", strconv.Itoa(options.MinRate))
+	}
+	if options.MaxRate > 0 {
+		args = append(args, "--max-rate", strconv.Itoa(options.MaxRate))
+	}
+	if options.MaxParallelism > 0 {
+		args = append(args, "--max-parallelism", strconv.Itoa(options.MaxParallelism))
+	}
+
+	// Add retransmissions
+	retries := a.defaultMaxRetries
+	if options.MaxRetries != nil {
+		retries = *options.MaxRetries
+	}
+	if retries >= 0 {
+		args = append(args, "--max-retries", strconv.Itoa(retries))
+	}
+
+	// Add evasion options
+	if len(options.Decoys) > 0 {
+		args = append(args, "-D", strings.Join(options.Decoys, ","))
+	}
+	if options.SourcePort > 0 {
+		args = append(args, "-g", strconv.Itoa(options.SourcePort))
+	}
+	if options.Fragment {
+		args = append(args, "-f")
+	}
+	if options.DataLength > 0 {
+		args = append(args, "--data-length", strconv.Itoa(options.DataLength))
+	}
+
 	// Add extra options
 	args = append(args, options.ExtraOptions...)
 
@@ -271,91 +465,122 @@ func (a *NmapAdapter) convertToDomainModel(nmapXML NmapXML, startTime time.Time)
 
 	// Process hosts
 	for _, xmlHost := range nmapXML.Hosts {
-		// Skip hosts that are down
-		if xmlHost.Status.State != "up" {
-			continue
+		if host, ok := convertHost(xmlHost); ok {
+			result.Hosts = append(result.Hosts, host)
 		}
+	}
 
-		host := domain.Host{
-			Status:    xmlHost.Status.State,
-			Hostnames: make([]string, 0),
-			Ports:     make([]domain.Port, 0),
-			Scripts:   make([]domain.Script, 0),
-			Metadata:  domain.HostMetadata{},
-		}
+	return result
+}
 
-		// Get IP address
-		for _, addr := range xmlHost.Addresses {
-			if addr.AddrType == "ipv4" {
-				host.IP = addr.Addr
-				break
-			}
-		}
+// convertHost converts a host of the nmap XML output to domain.Host. It returns
+// false for hosts that are down.
+func convertHost(xmlHost nmapHost) (domain.Host, bool) {
+	// Skip hosts that are down
+	if xmlHost.Status.State != "up" {
+		return domain.Host{}, false
+	}
 
-		// Get hostnames
-		for _, hostname := range xmlHost.Hostnames.Hostnames {
-			host.Hostnames = append(host.Hostnames, hostname.Name)
-		}
+	host := domain.Host{
+		Status:    xmlHost.Status.State,
+		Hostnames: make([]string, 0),
+		Ports:     make([]domain.Port, 0),
+		Scripts:   make([]domain.Script, 0),
+		Metadata:  domain.HostMetadata{},
+	}
 
-		// Get OS
-		if len(xmlHost.OS.Matches) > 0 {
-			host.OS = xmlHost.OS.Matches[0].Name
+	// Get IP address
+	for _, addr := range xmlHost.Addresses {
+		if addr.AddrType == "ipv4" {
+			host.IP = addr.Addr
+			break
 		}
+	}
 
-		// Get ports
-		for _, xmlPort := range xmlHost.Ports.Ports {
-			port := domain.Port{
-				Port:      xmlPort.PortID,
-				Protocol:  xmlPort.Protocol,
-				State:     xmlPort.State.State,
-				Service:   xmlPort.Service.Name,
-				Product:   xmlPort.Service.Product,
-				Version:   xmlPort.Service.Version,
-				ExtraInfo: xmlPort.Service.ExtraInfo,
-			}
+	// Get hostnames
+	for _, hostname := range xmlHost.Hostnames.Hostnames {
+		host.Hostnames = append(host.Hostnames, hostname.Name)
+	}
 
-			// Get script results
-			for _, xmlScript := range xmlPort.Scripts {
-				script := domain.Script{
-					ID:     xmlScript.ID,
-					Output: xmlScript.Output,
-					Data:   make(map[string]string),
-				}
+	// Get OS
+	if len(xmlHost.OS.Matches) > 0 {
+		host.OS = xmlHost.OS.Matches[0].Name
+	}
 
-				host.Scripts = append(host.Scripts, script)
+	// Get ports
+	for _, xmlPort := range xmlHost.Ports.Ports {
+		port := domain.Port{
+			Port:      xmlPort.PortID,
+			Protocol:  xmlPort.Protocol,
+			State:     xmlPort.State.State,
+			Service:   xmlPort.Service.Name,
+			Product:   xmlPort.Service.Product,
+			Version:   xmlPort.Service.Version,
+			ExtraInfo: xmlPort.Service.ExtraInfo,
+		}
+
+		// Get script results
+		for _, xmlScript := range xmlPort.Scripts {
+			script := domain.Script{
+				ID:       xmlScript.ID,
+				Output:   xmlScript.Output,
+				Data:     make(map[string]string),
+				Port:     xmlPort.PortID,
+				Protocol: xmlPort.Protocol,
 			}
+			flattenScriptData(script.Data, "", xmlScript.Elements, xmlScript.Tables)
 
-			host.Ports = append(host.Ports, port)
+			host.Scripts = append(host.Scripts, script)
 		}
 
-		// Get metadata
-		if xmlHost.Distance.Value != "" {
-			distance, _ := strconv.Atoi(xmlHost.Distance.Value)
-			host.Metadata.Distance = distance
-		}
+		host.Ports = append(host.Ports, port)
+	}
 
-		if xmlHost.Uptime.Seconds != "" {
-			uptime, _ := strconv.ParseFloat(xmlHost.Uptime.Seconds, 64)
-			host.Metadata.UpTime = uptime
-		}
+	// Get metadata
+	if xmlHost.Distance.Value != "" {
+		distance, _ := strconv.Atoi(xmlHost.Distance.Value)
+		host.Metadata.Distance = distance
+	}
 
-		if xmlHost.Uptime.LastBoot != "" {
-			// Parse last boot time if available
-			host.Metadata.LastBoot, _ = time.Parse("2006-01-02 15:04:05", xmlHost.Uptime.LastBoot)
-		}
+	if xmlHost.Uptime.Seconds != "" {
+		uptime, _ := strconv.ParseFloat(xmlHost.Uptime.Seconds, 64)
+		host.Metadata.UpTime = uptime
+	}
 
-		if xmlHost.TCPSequence.Difficulty != "" {
-			host.Metadata.TCPSequence = xmlHost.TCPSequence.Difficulty
-		}
+	if xmlHost.Uptime.LastBoot != "" {
+		// Parse last boot time if available
+		host.Metadata.LastBoot, _ = time.Parse("2006-01-02 15:04:05", xmlHost.Uptime.LastBoot)
+	}
 
-		if xmlHost.IPIDSequence.Class != "" {
-			host.Metadata.IPIDSequence = xmlHost.IPIDSequence.Class
-		}
+	if xmlHost.TCPSequence.Difficulty != "" {
+		host.Metadata.TCPSequence = xmlHost.TCPSequence.Difficulty
+	}
 
-		result.Hosts = append(result.Hosts, host)
+	if xmlHost.IPIDSequence.Class != "" {
+		host.Metadata.IPIDSequence = xmlHost.IPIDSequence.Class
 	}
 
-	return result
+	return host, true
+}
+
+// flattenScriptData adds the values of the structured output of a script to data, keyed
+// by their path of table keys, e.g. "validity.notAfter". List items are keyed by their
+// position, starting at 1.
+func flattenScriptData(data map[string]string, prefix string, elements []nmapElem, tables []nmapTable) {
+	for i, elem := range elements {
+		key := elem.Key
+		if key == "" {
+			key = strconv.Itoa(i + 1)
+		}
+		data[prefix+key] = strings.TrimSpace(elem.Value)
+	}
+	for i, table := range tables {
+		key := table.Key
+		if key == "" {
+			key = strconv.Itoa(i + 1)
+		}
+		flattenScriptData(data, prefix+key+".", table.Elements, table.Tables)
+	}
 }
 
 // GetVersion returns the nmap version
@@ -372,6 +597,11 @@ func (a *NmapAdapter) GetVersion() (string, error) {
 	return version, nil
 }
 
+// RawSocketCapable reports whether nmap may open raw sockets
+func (a *NmapAdapter) RawSocketCapable() bool {
+	return utils.RawSocketCapable()
+}
+
 // IsAvailable checks if nmap is available
 func (a *NmapAdapter) IsAvailable() bool {
 	_, err := a.GetVersion()
@@ -0,0 +1,145 @@
+package adapters
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNmapBuildCommandArgs(t *testing.T) {
+	adapter := NewNmapAdapter("nmap", nil)
+	retries := 3
+
+	args := strings.Join(adapter.buildCommandArgs(domain.ScanOptions{
+		Target:         "10.0.0.0/24",
+		Ports:          "22,80",
+		ScanType:       domain.ScanTypeSYN,
+		TimingTemplate: domain.TimingNormal,
+		HostTimeout:    90 * time.Second,
+		MaxRate:        100,
+		MaxRetries:     &retries,
+		Decoys:         []string{"10.0.0.7", "ME"},
+		Fragment:       true,
+	}), " ")
+	assert.Equal(t, "10.0.0.0/24 -p 22,80 -sS -T3 --host-timeout 90000ms --max-rate 100 --max-retries 3 -D 10.0.0.7,ME -f", args)
+
+	// The default retries apply to scans not setting them
+	options := domain.ScanOptions{Target: "10.0.0.1", TimingTemplate: domain.TimingNormal}
+	assert.NotContains(t, adapter.buildCommandArgs(options), "--max-retries")
+
+	adapter.SetDefaultMaxRetries(0)
+	assert.Equal(t, "10.0.0.1 -T3 --max-retries 0", strings.Join(adapter.buildCommandArgs(options), " "))
+	assert.Contains(t, strings.Join(adapter.buildCommandArgs(domain.ScanOptions{Target: "10.0.0.1", MaxRetries: &retries}), " "), "--max-retries 3")
+}
+
+// fakeNmap is a script standing in for nmap that writes a host to its XML output and
+// waits to be interrupted, like nmap scanning a large network
+const fakeNmap = `#!/bin/sh
+while [ $# -gt 0 ]; do
+  if [ "$1" = "-oX" ]; then xml="$2"; fi
+  shift
+done
+printf '<?xml version="1.0"?><nmaprun><host><status state="up"/><address addr="10.0.0.1" addrtype="ipv4"/></host><host><status' > "$xml"
+sleep 10 > /dev/null &
+trap 'kill $!; exit 130' INT
+wait
+`
+
+func TestNmapExecuteScanReturnsPartialResult(t *testing.T) {
+	nmapPath := filepath.Join(t.TempDir(), "nmap")
+	require.NoError(t, os.WriteFile(nmapPath, []byte(fakeNmap), 0o700))
+	adapter := NewNmapAdapter(nmapPath, &logger.Logger{Logger: zap.NewNop()})
+
+	// The hosts nmap completed before the scan timed out are returned with the error
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	result, err := adapter.ExecuteScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24"})
+
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrTimeout, scanErr.Type)
+	require.NotNil(t, result)
+	assert.True(t, result.Partial)
+	assert.Equal(t, 1, result.UpHosts)
+	require.Len(t, result.Hosts, 1)
+	assert.Equal(t, "10.0.0.1", result.Hosts[0].IP)
+}
+
+// failingNmap is a script standing in for nmap that rejects its options
+const failingNmap = `#!/bin/sh
+echo "Starting Nmap"
+echo "You requested a scan type which requires root privileges." >&2
+echo "QUITTING!" >&2
+exit 1
+`
+
+func TestNmapExecuteScanRecordsFailure(t *testing.T) {
+	nmapPath := filepath.Join(t.TempDir(), "nmap")
+	require.NoError(t, os.WriteFile(nmapPath, []byte(failingNmap), 0o700))
+	adapter := NewNmapAdapter(nmapPath, &logger.Logger{Logger: zap.NewNop()})
+
+	result, err := adapter.ExecuteScan(context.Background(), domain.ScanOptions{Target: "10.0.0.1", ScanType: domain.ScanTypeSYN})
+	assert.Nil(t, result)
+
+	// The error tells how to remedy the failure, and carries how nmap failed
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrPermissionDenied, scanErr.Type)
+	assert.NotEmpty(t, scanErr.Hint)
+
+	var scannerErr *domain.ScannerError
+	require.ErrorAs(t, err, &scannerErr)
+	assert.Equal(t, errors.ErrPermissionDenied, scannerErr.Failure.Type)
+	assert.Equal(t, 1, scannerErr.Failure.ExitCode)
+	assert.Equal(t, "You requested a scan type which requires root privileges.\nQUITTING!\n", scannerErr.Failure.Stderr)
+	assert.True(t, strings.HasPrefix(scannerErr.Failure.Command, nmapPath+" 10.0.0.1 -sS"))
+}
+
+// sslCertHost is a host of the nmap XML output with the structured output of ssl-cert
+const sslCertHost = `<host>
+<status state="up"/>
+<address addr="93.184.216.34" addrtype="ipv4"/>
+<ports><port protocol="tcp" portid="443"><state state="open"/><service name="https"/>
+<script id="ssl-cert" output="Subject: commonName=example.com">
+<table key="subject"><elem key="commonName">example.com</elem></table>
+<table key="extensions">
+<table><elem key="name">X509v3 Subject Alternative Name</elem><elem key="value">DNS:example.com, DNS:www.example.com</elem></table>
+</table>
+<table key="validity"><elem key="notBefore">2025-01-01T00:00:00</elem><elem key="notAfter">2026-01-01T23:59:59</elem></table>
+<elem key="sha1">0123456789abcdef0123456789abcdef01234567</elem>
+</script>
+</port></ports>
+</host>`
+
+func TestConvertHostParsesScriptData(t *testing.T) {
+	var xmlHost nmapHost
+	require.NoError(t, xml.Unmarshal([]byte(sslCertHost), &xmlHost))
+
+	host, ok := convertHost(xmlHost)
+	require.True(t, ok)
+	require.Len(t, host.Scripts, 1)
+
+	script := host.Scripts[0]
+	assert.Equal(t, "ssl-cert", script.ID)
+	assert.Equal(t, 443, script.Port)
+	assert.Equal(t, "tcp", script.Protocol)
+	assert.Equal(t, map[string]string{
+		"subject.commonName": "example.com",
+		"extensions.1.name":  "X509v3 Subject Alternative Name",
+		"extensions.1.value": "DNS:example.com, DNS:www.example.com",
+		"validity.notBefore": "2025-01-01T00:00:00",
+		"validity.notAfter":  "2026-01-01T23:59:59",
+		"sha1":               "0123456789abcdef0123456789abcdef01234567",
+	}, script.Data)
+}
@@ -0,0 +1,160 @@
+package adapters
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+)
+
+// valueValidator checks the value of an nmap option
+type valueValidator func(value string) bool
+
+var (
+	// nmapTimePattern matches nmap times such as 500ms, 30s, 5m or 1h
+	nmapTimePattern = regexp.MustCompile(`^[0-9]{1,6}(ms|s|m|h)?$`)
+	// scriptListPattern matches comma-separated script names and categories, never paths or wildcards
+	scriptListPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*(,[a-z0-9][a-z0-9_-]*)*$`)
+)
+
+// restrictedScriptCategories are the script categories that attack, crash or flood the
+// targets rather than inspect them, and "all", which includes them
+var restrictedScriptCategories = map[string]bool{
+	"all": true, "brute": true, "dos": true, "exploit": true,
+	"fuzzer": true, "intrusive": true, "vuln": true,
+}
+
+// flagOptions are the nmap options without a value allowed in ExtraOptions
+var flagOptions = map[string]bool{
+	"-Pn": true, "-n": true, "-R": true, "-F": true, "-r": true, "-6": true,
+	"-v": true, "-vv": true, "-sC": true, "-sV": true, "-O": true,
+	"-PE": true, "-PP": true, "-PM": true, "-PR": true,
+	"--open": true, "--reason": true, "--traceroute": true, "--system-dns": true,
+	"--version-light": true, "--version-all": true, "--osscan-limit": true, "--osscan-guess": true,
+}
+
+// valueOptions are the nmap options with a value allowed in ExtraOptions, with the
+// check of their value. The value follows as the next option or after "=".
+var valueOptions = map[string]valueValidator{
+	"--top-ports":           intBetween(1, 65535),
+	"--max-retries":         intBetween(0, 50),
+	"--version-intensity":   intBetween(0, 9),
+	"--min-rate":            intBetween(1, 1000000),
+	"--max-rate":            intBetween(1, 1000000),
+	"--min-parallelism":     intBetween(1, 1024),
+	"--max-parallelism":     intBetween(1, 1024),
+	"--min-hostgroup":       intBetween(1, 4096),
+	"--max-hostgroup":       intBetween(1, 4096),
+	"--host-timeout":        nmapTime,
+	"--scan-delay":          nmapTime,
+	"--max-scan-delay":      nmapTime,
+	"--min-rtt-timeout":     nmapTime,
+	"--max-rtt-timeout":     nmapTime,
+	"--initial-rtt-timeout": nmapTime,
+	"--exclude-ports":       portSpec,
+	"--exclude":             hostList,
+	"--dns-servers":         ipList,
+	"--script":              scriptList,
+}
+
+// portProbeOptions are the host discovery options taking an optional port list
+// attached to the option, like -PS22,80
+var portProbeOptions = []string{"-PS", "-PA", "-PU", "-PY"}
+
+// validateExtraOptions checks the extra options of a scan against the allowlist of
+// nmap options, so that clients cannot write files, read local files or run scripts
+// from arbitrary paths
+func validateExtraOptions(extraOptions []string) error {
+	for i := 0; i < len(extraOptions); i++ {
+		option := extraOptions[i]
+
+		if flagOptions[option] {
+			continue
+		}
+		if isPortProbeOption(option) {
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(option, "=")
+		validator, ok := valueOptions[name]
+		if !ok {
+			return errors.NewInvalidInput("nmap option "+name+" is not allowed", nil)
+		}
+		if !hasValue {
+			if i+1 == len(extraOptions) {
+				return errors.NewInvalidInput("nmap option "+name+" needs a value", nil)
+			}
+			i++
+			value = extraOptions[i]
+		}
+		if !validator(value) {
+			return errors.NewInvalidInput("invalid value for nmap option "+name+": "+value, nil)
+		}
+	}
+	return nil
+}
+
+// isPortProbeOption reports whether an option is a host discovery probe with an optional port list
+func isPortProbeOption(option string) bool {
+	for _, prefix := range portProbeOptions {
+		if ports, ok := strings.CutPrefix(option, prefix); ok {
+			return ports == "" || portSpec(ports)
+		}
+	}
+	return false
+}
+
+// intBetween returns a validator for integers between min and max
+func intBetween(min, max int) valueValidator {
+	return func(value string) bool {
+		n, err := strconv.Atoi(value)
+		return err == nil && n >= min && n <= max
+	}
+}
+
+// nmapTime validates nmap times
+func nmapTime(value string) bool {
+	return nmapTimePattern.MatchString(value)
+}
+
+// portSpec validates port lists and ranges
+func portSpec(value string) bool {
+	_, err := utils.PortRangeToSlice(value)
+	return err == nil
+}
+
+// scriptList validates script names and categories, rejecting the restricted categories
+func scriptList(value string) bool {
+	if !scriptListPattern.MatchString(value) {
+		return false
+	}
+	for _, script := range strings.Split(value, ",") {
+		if restrictedScriptCategories[script] {
+			return false
+		}
+	}
+	return true
+}
+
+// hostList validates comma-separated addresses, networks and host names
+func hostList(value string) bool {
+	for _, host := range strings.Split(value, ",") {
+		if _, ok := countTarget(host); !ok && !utils.ValidateHostname(host) {
+			return false
+		}
+	}
+	return true
+}
+
+// ipList validates comma-separated IP addresses
+func ipList(value string) bool {
+	for _, ip := range strings.Split(value, ",") {
+		if net.ParseIP(ip) == nil {
+			return false
+		}
+	}
+	return true
+}
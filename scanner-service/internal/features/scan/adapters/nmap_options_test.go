@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExtraOptions(t *testing.T) {
+	allowed := [][]string{
+		nil,
+		{"-Pn", "--open", "--reason"},
+		{"--top-ports", "100"},
+		{"--max-retries=2", "--host-timeout", "30s"},
+		{"--script", "http-title,ssl-cert", "-PS22,443"},
+		{"--script=default,safe,discovery"},
+		{"--exclude", "10.0.0.1,10.0.1.0/24,gw.example.com"},
+		{"--dns-servers=1.1.1.1,8.8.8.8"},
+	}
+	for _, options := range allowed {
+		assert.NoError(t, validateExtraOptions(options), "%v", options)
+	}
+
+	rejected := [][]string{
+		{"-oN", "/etc/cron.d/x"},
+		{"-oX=/tmp/out.xml"},
+		{"-iL", "/etc/passwd"},
+		{"--script", "/tmp/evil.nse"},
+		{"--script=../../evil"},
+		{"--script", "smb-vuln*"},
+		{"--script", "*"},
+		{"--script=all"},
+		{"--script", "http-title,vuln"},
+		{"--script", "exploit"},
+		{"--script", "brute"},
+		{"--script", "dos"},
+		{"--script", "intrusive"},
+		{"--script", "fuzzer"},
+		{"--script", "not safe"},
+		{"--script-args", "http-fetch.destination=/etc"},
+		{"--datadir", "/tmp"},
+		{"--top-ports", "-oN"},
+		{"--max-retries"},
+		{"--host-timeout", "30s;rm"},
+		{"--exclude", "10.0.0.1,$(id)"},
+		{"-PS22,http"},
+	}
+	for _, options := range rejected {
+		assert.Error(t, validateExtraOptions(options), "%v", options)
+	}
+}
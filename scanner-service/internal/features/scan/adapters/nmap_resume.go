@@ -0,0 +1,151 @@
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// greppablePortPattern matches a port of nmap grepable output:
+// port/state/protocol/owner/service/rpc info/version/
+var greppablePortPattern = regexp.MustCompile(`(\d+)/([a-z|]+)/([a-z]+)/[^/]*/([^/]*)/[^/]*/([^/]*)/`)
+
+// CanResume reports whether an interrupted scan left a state file to resume from
+func (a *NmapAdapter) CanResume(options domain.ScanOptions) bool {
+	if options.StateFile == "" {
+		return false
+	}
+	info, err := os.Stat(options.StateFile)
+	return err == nil && info.Size() > 0
+}
+
+// ResumeScan continues an interrupted scan from its state file with nmap --resume.
+// nmap repeats the recorded command for the hosts not scanned yet and appends them to
+// the state file, so the result is read from the grepable output of both runs, which
+// has no script output.
+func (a *NmapAdapter) ResumeScan(ctx context.Context, scanOptions domain.ScanOptions) (*domain.ScanResult, error) {
+	if !a.CanResume(scanOptions) {
+		return nil, errors.NewInvalidInput("scan has no saved progress to resume", nil)
+	}
+
+	startTime := time.Now()
+	args := []string{"--resume", scanOptions.StateFile}
+
+	a.logger.Info("Resuming nmap scan",
+		zap.String("target", scanOptions.Target),
+		zap.String("state_file", scanOptions.StateFile),
+	)
+
+	// The resumed nmap appends to the XML output file of the interrupted run, which is not read
+	defer os.Remove(scanOptions.StateFile + ".xml")
+
+	if err := a.runNmap(ctx, args, []string{scanOptions.StateFile, scanOptions.StateFile + ".xml"}); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(scanOptions.StateFile)
+	if err != nil {
+		return nil, errors.NewInternal("failed to read nmap output", err)
+	}
+	os.Remove(scanOptions.StateFile)
+
+	endTime := time.Now()
+	hosts := parseNmapGreppable(data)
+	result := &domain.ScanResult{
+		ID:         uuid.New().String(),
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Duration:   endTime.Sub(startTime).Seconds(),
+		Command:    a.nmapPath + " " + strings.Join(args, " "),
+		TotalHosts: max(countAddresses(scanOptions.Target), len(hosts)),
+		UpHosts:    len(hosts),
+		Hosts:      hosts,
+	}
+	result.Summary = fmt.Sprintf("Nmap done: %d IP addresses (%d hosts up), resumed scan finished in %.2f seconds",
+		result.TotalHosts, result.UpHosts, result.Duration)
+
+	a.logger.Info("Nmap scan resumed",
+		zap.String("target", scanOptions.Target),
+		zap.Int("total_hosts", result.TotalHosts),
+		zap.Int("up_hosts", result.UpHosts),
+		zap.Float64("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// parseNmapGreppable parses the hosts that are up from nmap grepable output,
+// merging the lines of each host in the order the hosts were scanned
+func parseNmapGreppable(data []byte) []domain.Host {
+	hostsByIP := make(map[string]*domain.Host)
+	var order []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		address, ok := strings.CutPrefix(fields[0], "Host: ")
+		if !ok {
+			continue // Comment
+		}
+		ip, hostname, _ := strings.Cut(address, " ")
+		hostname = strings.Trim(hostname, "()")
+
+		var status, ports, osName string
+		for _, field := range fields[1:] {
+			name, value, _ := strings.Cut(field, ": ")
+			switch name {
+			case "Status":
+				status = strings.ToLower(value)
+			case "Ports":
+				ports = value
+			case "OS":
+				osName = value
+			}
+		}
+		if status != "" && status != "up" {
+			continue
+		}
+
+		host, seen := hostsByIP[ip]
+		if !seen {
+			host = newDiscoveredHost(ip)
+			hostsByIP[ip] = host
+			order = append(order, ip)
+		}
+		if hostname != "" && !slices.Contains(host.Hostnames, hostname) {
+			host.Hostnames = append(host.Hostnames, hostname)
+		}
+		if osName != "" {
+			host.OS = osName
+		}
+		for _, match := range greppablePortPattern.FindAllStringSubmatch(ports, -1) {
+			port, _ := strconv.Atoi(match[1])
+			host.Ports = append(host.Ports, domain.Port{
+				Port:     port,
+				Protocol: match[3],
+				State:    match[2],
+				Service:  strings.ReplaceAll(match[4], "|", "/"), // nmap replaces slashes by "|"
+				Product:  strings.ReplaceAll(match[5], "|", "/"),
+			})
+		}
+	}
+
+	hosts := make([]domain.Host, 0, len(order))
+	for _, ip := range order {
+		hosts = append(hosts, *hostsByIP[ip])
+	}
+	return hosts
+}
@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const greppableOutput = `# Nmap 7.94 scan initiated Mon Mar  3 10:00:00 2025 as: nmap -sV -oG state.gnmap 10.0.0.0/24
+Host: 10.0.0.1 (router.lan)	Status: Up
+Host: 10.0.0.1 (router.lan)	Ports: 22/open/tcp//ssh//OpenSSH 9.6p1 Ubuntu 3ubuntu13 (Ubuntu Linux; protocol 2.0)/, 80/open/tcp//http//nginx 1.24.0 (Ubuntu)/	Ignored State: closed (998)
+Host: 10.0.0.2 ()	Status: Down
+# Nmap 7.94 scan initiated Mon Mar  3 10:30:00 2025 as: nmap -sV -oG state.gnmap 10.0.0.0/24
+Host: 10.0.0.7 ()	Status: Up
+Host: 10.0.0.7 ()	Ports: 53/open|filtered/udp//domain///, 443/open/tcp//ssl|https//nginx/	OS: Linux 5.X
+# Nmap done at Mon Mar  3 10:31:00 2025 -- 256 IP addresses (2 hosts up) scanned in 60.00 seconds
+`
+
+func TestParseNmapGreppable(t *testing.T) {
+	hosts := parseNmapGreppable([]byte(greppableOutput))
+	require.Len(t, hosts, 2)
+
+	assert.Equal(t, "10.0.0.1", hosts[0].IP)
+	assert.Equal(t, []string{"router.lan"}, hosts[0].Hostnames)
+	assert.Equal(t, []domain.Port{
+		{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH 9.6p1 Ubuntu 3ubuntu13 (Ubuntu Linux; protocol 2.0)"},
+		{Port: 80, Protocol: "tcp", State: "open", Service: "http", Product: "nginx 1.24.0 (Ubuntu)"},
+	}, hosts[0].Ports)
+
+	assert.Equal(t, "10.0.0.7", hosts[1].IP)
+	assert.Empty(t, hosts[1].Hostnames)
+	assert.Equal(t, "Linux 5.X", hosts[1].OS)
+	require.Len(t, hosts[1].Ports, 2)
+	assert.Equal(t, "open|filtered", hosts[1].Ports[0].State)
+	assert.Equal(t, "ssl/https", hosts[1].Ports[1].Service)
+}
+
+func TestNmapCanResume(t *testing.T) {
+	adapter := NewNmapAdapter("nmap", nil)
+	stateFile := filepath.Join(t.TempDir(), "scan.gnmap")
+
+	assert.False(t, adapter.CanResume(domain.ScanOptions{}))
+	assert.False(t, adapter.CanResume(domain.ScanOptions{StateFile: stateFile}))
+
+	require.NoError(t, os.WriteFile(stateFile, []byte(greppableOutput), 0o600))
+	assert.True(t, adapter.CanResume(domain.ScanOptions{StateFile: stateFile}))
+}
@@ -0,0 +1,65 @@
+package adapters
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// nmapScriptCategories are the NSE script categories nmap ships scripts in
+var nmapScriptCategories = []string{
+	"auth", "broadcast", "brute", "default", "discovery", "dos", "exploit",
+	"external", "fuzzer", "intrusive", "malware", "safe", "version", "vuln",
+}
+
+var (
+	// scriptDBCategoriesPattern matches the categories of a script.db entry
+	scriptDBCategoriesPattern = regexp.MustCompile(`categories = \{([^}]*)\}`)
+	// scriptDBCategoryPattern matches a category in the categories of a script.db entry
+	scriptDBCategoryPattern = regexp.MustCompile(`"([^"]+)"`)
+)
+
+// ScriptCategories returns the categories of the NSE scripts installed with nmap,
+// read from its script database
+func (a *NmapAdapter) ScriptCategories() ([]string, error) {
+	for _, path := range scriptDBPaths(a.nmapPath) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		return parseScriptDB(data), nil
+	}
+	return nil, errors.NewUnavailable("nmap script database not found", nil)
+}
+
+// scriptDBPaths returns where the script database of nmap may be: in NMAPDIR, next to
+// the nmap binary, then in the default install locations
+func scriptDBPaths(nmapPath string) []string {
+	var paths []string
+	if dir := os.Getenv("NMAPDIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "scripts", "script.db"))
+	}
+	if path, err := exec.LookPath(nmapPath); err == nil {
+		prefix := filepath.Dir(filepath.Dir(path))
+		paths = append(paths, filepath.Join(prefix, "share", "nmap", "scripts", "script.db"))
+	}
+	return append(paths, "/usr/share/nmap/scripts/script.db", "/usr/local/share/nmap/scripts/script.db")
+}
+
+// parseScriptDB returns the sorted categories of the scripts of a script database
+func parseScriptDB(data []byte) []string {
+	categories := make([]string, 0)
+	for _, match := range scriptDBCategoriesPattern.FindAllSubmatch(data, -1) {
+		for _, category := range scriptDBCategoryPattern.FindAllSubmatch(match[1], -1) {
+			if name := string(category[1]); !slices.Contains(categories, name) {
+				categories = append(categories, name)
+			}
+		}
+	}
+	slices.Sort(categories)
+	return categories
+}
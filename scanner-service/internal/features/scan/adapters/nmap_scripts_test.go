@@ -0,0 +1,26 @@
+package adapters
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testScriptDB = `Entry { filename = "http-title.nse", categories = { "default", "discovery", "safe", } }
+Entry { filename = "ssh-brute.nse", categories = { "brute", "intrusive", } }
+Entry { filename = "vulners.nse", categories = { "external", "safe", "vuln", } }
+`
+
+func TestNmapScriptCategories(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "scripts"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scripts", "script.db"), []byte(testScriptDB), 0o600))
+	t.Setenv("NMAPDIR", dir)
+
+	categories, err := NewNmapAdapter("nmap", nil).ScriptCategories()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"brute", "default", "discovery", "external", "intrusive", "safe", "vuln"}, categories)
+}
@@ -0,0 +1,126 @@
+package adapters
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"os"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+)
+
+// xmlPollInterval is how often the XML output file of a running nmap is checked for new hosts
+const xmlPollInterval = time.Second
+
+// startHostStream streams the hosts of the XML output file to the host reporter ctx
+// carries, if any. The returned function waits for the hosts nmap wrote before it exited.
+func startHostStream(ctx context.Context, path string) func() {
+	report, ok := domain.HostReporterFromContext(ctx)
+	if !ok {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		streamHosts(path, report, done)
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// streamHosts reports the hosts nmap writes to its XML output file as it completes them,
+// until done is closed and the file is read to its end
+func streamHosts(path string, report domain.HostReporter, done <-chan struct{}) {
+	reader := &tailReader{path: path, done: done}
+	defer reader.Close()
+
+	decodeHosts(reader, report)
+}
+
+// readHosts returns the hosts of an XML output file nmap may have stopped writing
+// in the middle of a host
+func readHosts(path string) ([]domain.Host, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hosts := make([]domain.Host, 0)
+	decodeHosts(file, func(host domain.Host) {
+		hosts = append(hosts, host)
+	})
+	return hosts, nil
+}
+
+// decodeHosts reports the complete host elements of nmap XML output. Hosts that are
+// down are skipped.
+func decodeHosts(reader io.Reader, report domain.HostReporter) {
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// The end of the output, or output cut off by an interrupted nmap
+			return
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "host" {
+			continue
+		}
+		var xmlHost nmapHost
+		if err := decoder.DecodeElement(&xmlHost, &start); err != nil {
+			return
+		}
+		if host, ok := convertHost(xmlHost); ok {
+			report(host)
+		}
+	}
+}
+
+// tailReader reads a file while another process writes it, like tail -f. At the end of
+// the file, it waits for more data until done is closed.
+type tailReader struct {
+	path string
+	file *os.File
+	done <-chan struct{}
+}
+
+func (r *tailReader) Read(p []byte) (int, error) {
+	for {
+		// The file is created by nmap once it starts
+		if r.file == nil {
+			if file, err := os.Open(r.path); err == nil {
+				r.file = file
+			}
+		}
+		if r.file != nil {
+			n, err := r.file.Read(p)
+			if n > 0 || err != io.EOF {
+				return n, err
+			}
+		}
+
+		select {
+		case <-r.done:
+			// Read what was written since the last read before giving up
+			if r.file == nil {
+				return 0, io.EOF
+			}
+			return r.file.Read(p)
+		case <-time.After(xmlPollInterval):
+		}
+	}
+}
+
+func (r *tailReader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
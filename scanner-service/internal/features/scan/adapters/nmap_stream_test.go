@@ -0,0 +1,58 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartHostStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scan.xml")
+
+	var mu sync.Mutex
+	var ips []string
+	reported := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), ips...)
+	}
+	ctx := domain.WithHostReporter(context.Background(), func(host domain.Host) {
+		mu.Lock()
+		ips = append(ips, host.IP)
+		mu.Unlock()
+	})
+
+	// Streaming starts before nmap created the file
+	stop := startHostStream(ctx, path)
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	write := func(data string) {
+		_, err := file.WriteString(data)
+		require.NoError(t, err)
+	}
+
+	// Hosts are reported once their element is complete
+	write(`<?xml version="1.0"?><!DOCTYPE nmaprun><nmaprun args="nmap">` +
+		`<host><status state="up"/><address addr="10.0.0.1" addrtype="ipv4"/>` +
+		`<ports><port protocol="tcp" portid="22"><state state="open"/><service name="ssh"/></port></ports></host>` +
+		`<host><status state="up"/><address addr="10.0.0.2" `)
+	assert.Eventually(t, func() bool { return len(reported()) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// Hosts that are down are skipped, hosts written before nmap exited are reported
+	write(`addrtype="ipv4"/></host><host><status state="down"/><address addr="10.0.0.3" addrtype="ipv4"/></host>` +
+		`<host><status state="up"/><address addr="10.0.0.4" addrtype="ipv4"/></host>`)
+	stop()
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.4"}, reported())
+
+	// Without a reporter nothing is streamed
+	startHostStream(context.Background(), path)()
+}
@@ -0,0 +1,98 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// runScanner runs a scanner binary and returns its standard output.
+// Cancellation and expiry of ctx are reported as timeout errors.
+func runScanner(ctx context.Context, log *logger.Logger, name, path string, args []string) ([]byte, error) {
+	// The port scanners run by runScanner do not report their progress
+	reportPhase(ctx, domain.ScanPhasePortScan)
+
+	cmd := exec.CommandContext(ctx, path, args...)
+
+	var stdout, stderr bytes.Buffer
+	flush := captureOutput(ctx, cmd, &stdout, &stderr)
+
+	err := cmd.Run()
+	flush()
+	if err != nil {
+		if ctx.Err() == context.Canceled {
+			return nil, errors.NewTimeout("scan was cancelled", ctx.Err())
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, errors.NewTimeout("scan timed out", ctx.Err())
+		}
+
+		log.Error(name+" scan failed",
+			zap.Error(err),
+			zap.String("stderr", stderr.String()),
+		)
+
+		return nil, scannerError(cmd, stderr.String(), classifyFailure(name, stderr.String(), err))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// scannerError returns the error of a scanner process that failed with err, recording
+// its command line, exit code and standard error output
+func scannerError(cmd *exec.Cmd, stderr string, err error) error {
+	exitCode := -1
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+	return domain.NewScannerError(cmd.String(), exitCode, stderr, err)
+}
+
+// captureOutput sets the standard output and error of cmd, and copies them to the log
+// of the running scan if ctx carries one. The returned function flushes the log once
+// the process exited.
+func captureOutput(ctx context.Context, cmd *exec.Cmd, stdout, stderr io.Writer) func() {
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	scanLog, ok := domain.ScanLogFromContext(ctx)
+	if !ok {
+		return func() {}
+	}
+	stdoutLog := scanLog.Writer(domain.LogStreamStdout)
+	stderrLog := scanLog.Writer(domain.LogStreamStderr)
+	cmd.Stdout = io.MultiWriter(stdout, stdoutLog)
+	cmd.Stderr = io.MultiWriter(stderr, stderrLog)
+	return func() {
+		stdoutLog.Close()
+		stderrLog.Close()
+	}
+}
+
+// scannerVersion runs a scanner binary with the version flag and returns
+// the first output line mentioning the version
+func scannerVersion(name, path, flag string) (string, error) {
+	cmd := exec.Command(path, flag)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.NewUnavailable("failed to get "+name+" version", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	for _, line := range lines {
+		if strings.Contains(strings.ToLower(line), "version") {
+			return strings.TrimSpace(line), nil
+		}
+	}
+	return strings.TrimSpace(lines[0]), nil
+}
@@ -0,0 +1,37 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRunScannerCapturesOutput(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	scanLog := domain.NewScanLog(10)
+	ctx := domain.WithScanLog(context.Background(), scanLog)
+
+	output, err := runScanner(ctx, log, "sh", "sh", []string{"-c", "echo 'open 10.0.0.1:22'; echo 'rate limited' >&2; printf 'done'"})
+	require.NoError(t, err)
+	assert.Equal(t, "open 10.0.0.1:22\ndone", string(output))
+
+	// Both streams are copied to the scan log, including the last line without newline.
+	// The streams are read concurrently, so only the order within a stream is kept.
+	lines, _ := scanLog.Lines()
+	streams := map[domain.LogStream][]string{}
+	for _, line := range lines {
+		streams[line.Stream] = append(streams[line.Stream], line.Text)
+	}
+	assert.Equal(t, []string{"open 10.0.0.1:22", "done"}, streams[domain.LogStreamStdout])
+	assert.Equal(t, []string{"rate limited"}, streams[domain.LogStreamStderr])
+
+	// Scans without a log only return the output
+	output, err = runScanner(context.Background(), log, "sh", "sh", []string{"-c", "echo ok"})
+	require.NoError(t, err)
+	assert.Equal(t, "ok\n", string(output))
+}
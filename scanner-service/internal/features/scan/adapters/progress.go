@@ -0,0 +1,123 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+)
+
+// nmapStatsInterval is how often nmap prints the progress of running scans
+const nmapStatsInterval = "5s"
+
+// maxProgressLineLength is the length of partial output lines kept until their newline.
+// Progress lines are short, so longer lines are dropped.
+const maxProgressLineLength = 512
+
+var (
+	// nmapTimingPattern matches the progress lines of nmap, e.g.
+	// "SYN Stealth Scan Timing: About 45.23% done; ETC: 14:05 (0:00:15 remaining)"
+	nmapTimingPattern = regexp.MustCompile(`^(.+?) Timing: About ([\d.]+)% done(?:; ETC: \S+ \((\d+):(\d+):(\d+) remaining\))?`)
+
+	// nmapTaskPattern matches the lines of nmap starting a task in verbose mode and the
+	// statistics lines naming the running task
+	nmapTaskPattern = regexp.MustCompile(`^Initiating (.+)$|undergoing (.+)$`)
+)
+
+// reportPhase reports the phase of the running scan if ctx carries a progress reporter
+func reportPhase(ctx context.Context, phase domain.ScanPhase) {
+	if report, ok := domain.ProgressReporterFromContext(ctx); ok {
+		report(domain.ScanProgress{Phase: phase})
+	}
+}
+
+// nmapPhase returns the scan phase of an nmap task, or an empty phase for tasks
+// outside the phases, e.g. traceroute
+func nmapPhase(task string) domain.ScanPhase {
+	task = strings.ToLower(task)
+	switch {
+	case strings.Contains(task, "ping"), strings.Contains(task, "dns resolution"):
+		return domain.ScanPhaseDiscovery
+	case strings.Contains(task, "service scan"), strings.Contains(task, "os detection"):
+		return domain.ScanPhaseServiceDetection
+	case strings.HasPrefix(task, "nse"), strings.Contains(task, "script"):
+		return domain.ScanPhaseScripts
+	case strings.Contains(task, " scan"):
+		return domain.ScanPhasePortScan
+	}
+	return ""
+}
+
+// parseNmapProgress parses a line of nmap output into the progress it reports
+func parseNmapProgress(line string) (domain.ScanProgress, bool) {
+	line = strings.TrimSpace(line)
+
+	if match := nmapTimingPattern.FindStringSubmatch(line); match != nil {
+		phase := nmapPhase(match[1])
+		if phase == "" {
+			return domain.ScanProgress{}, false
+		}
+		progress := domain.ScanProgress{Phase: phase}
+		progress.Percent, _ = strconv.ParseFloat(match[2], 64)
+		if match[3] != "" {
+			hours, _ := strconv.Atoi(match[3])
+			minutes, _ := strconv.Atoi(match[4])
+			seconds, _ := strconv.Atoi(match[5])
+			progress.Remaining = time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		}
+		return progress, true
+	}
+
+	if match := nmapTaskPattern.FindStringSubmatch(line); match != nil {
+		if phase := nmapPhase(match[1] + match[2]); phase != "" {
+			return domain.ScanProgress{Phase: phase}, true
+		}
+	}
+	return domain.ScanProgress{}, false
+}
+
+// nmapProgressWriter parses the standard output of nmap and reports its progress.
+// Task lines only report a change of phase, so they do not reset the estimate of the
+// timing lines following them.
+type nmapProgressWriter struct {
+	report  domain.ProgressReporter
+	phase   domain.ScanPhase
+	partial []byte
+}
+
+func newNmapProgressWriter(report domain.ProgressReporter) *nmapProgressWriter {
+	return &nmapProgressWriter{report: report}
+}
+
+func (w *nmapProgressWriter) Write(p []byte) (int, error) {
+	data := append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		w.line(string(data[:i]))
+		data = data[i+1:]
+	}
+	if len(data) > maxProgressLineLength {
+		data = nil
+	}
+	w.partial = append(w.partial[:0], data...)
+	return len(p), nil
+}
+
+func (w *nmapProgressWriter) line(line string) {
+	progress, ok := parseNmapProgress(line)
+	if !ok {
+		return
+	}
+	if progress.Percent == 0 && progress.Remaining == 0 && progress.Phase == w.phase {
+		return
+	}
+	w.phase = progress.Phase
+	w.report(progress)
+}
@@ -0,0 +1,66 @@
+package adapters
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNmapProgress(t *testing.T) {
+	tests := []struct {
+		line     string
+		progress domain.ScanProgress
+		ok       bool
+	}{
+		{"Initiating Ping Scan at 14:02", domain.ScanProgress{Phase: domain.ScanPhaseDiscovery}, true},
+		{"Initiating Parallel DNS resolution of 1 host. at 14:02", domain.ScanProgress{Phase: domain.ScanPhaseDiscovery}, true},
+		{"Initiating SYN Stealth Scan at 14:02", domain.ScanProgress{Phase: domain.ScanPhasePortScan}, true},
+		{"Stats: 0:00:10 elapsed; 0 hosts completed (1 up), 1 undergoing Service Scan", domain.ScanProgress{Phase: domain.ScanPhaseServiceDetection}, true},
+		{"Initiating OS detection (try #1) against 10.0.0.1", domain.ScanProgress{Phase: domain.ScanPhaseServiceDetection}, true},
+		{"Initiating NSE at 14:03", domain.ScanProgress{Phase: domain.ScanPhaseScripts}, true},
+		{
+			"SYN Stealth Scan Timing: About 45.23% done; ETC: 14:05 (0:01:15 remaining)",
+			domain.ScanProgress{Phase: domain.ScanPhasePortScan, Percent: 45.23, Remaining: 75 * time.Second},
+			true,
+		},
+		{
+			"NSE Timing: About 93.75% done; ETC: 14:05 (1:00:02 remaining)",
+			domain.ScanProgress{Phase: domain.ScanPhaseScripts, Percent: 93.75, Remaining: time.Hour + 2*time.Second},
+			true,
+		},
+		{"Service scan Timing: About 0.00% done", domain.ScanProgress{Phase: domain.ScanPhaseServiceDetection}, true},
+		{"Initiating Traceroute at 14:04", domain.ScanProgress{}, false},
+		{"Nmap scan report for 10.0.0.1", domain.ScanProgress{}, false},
+		{"22/tcp open  ssh", domain.ScanProgress{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			progress, ok := parseNmapProgress(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.progress, progress)
+		})
+	}
+}
+
+func TestNmapProgressWriter(t *testing.T) {
+	var reports []domain.ScanProgress
+	writer := newNmapProgressWriter(func(progress domain.ScanProgress) {
+		reports = append(reports, progress)
+	})
+
+	// Lines may be split across writes. The statistics line repeating the phase does not
+	// reset the estimate of the previous timing line.
+	io.WriteString(writer, "Initiating SYN Stealth Scan at 14:02\nSYN Stealth Scan Timing: About 50.00% done; ")
+	io.WriteString(writer, "ETC: 14:05 (0:00:30 remaining)\nStats: 0:00:10 elapsed; 1 undergoing SYN Stealth Scan\n")
+	io.WriteString(writer, "Initiating Service scan at 14:03\n")
+
+	assert.Equal(t, []domain.ScanProgress{
+		{Phase: domain.ScanPhasePortScan},
+		{Phase: domain.ScanPhasePortScan, Percent: 50, Remaining: 30 * time.Second},
+		{Phase: domain.ScanPhaseServiceDetection},
+	}, reports)
+}
@@ -0,0 +1,228 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// Capabilities describes the scan options an engine supports
+type Capabilities struct {
+	ScanTypes        []domain.ScanType // Supported scan types besides the engine default, nil for all
+	ServiceDetection bool
+	OSDetection      bool
+	Scripts          bool
+	ExtraOptions     bool
+	HostTimeout      bool // Hosts not scanned within the host timeout are skipped
+	RateControl      bool // Packet rate and parallelism can be set per scan
+	Retries          bool // Probe retransmissions can be set per scan
+	Evasion          bool // Decoys, source port, fragmentation and padding
+	HostNames        bool // Targets may be host names, not only addresses
+}
+
+// AllCapabilities are the capabilities of engines supporting every scan option, like nmap
+var AllCapabilities = Capabilities{
+	ServiceDetection: true,
+	OSDetection:      true,
+	Scripts:          true,
+	ExtraOptions:     true,
+	HostTimeout:      true,
+	RateControl:      true,
+	Retries:          true,
+	Evasion:          true,
+	HostNames:        true,
+}
+
+// CapabilityReporter is implemented by engine adapters supporting only some scan options
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// OptionValidator is implemented by engine adapters with checks beyond their capabilities
+type OptionValidator interface {
+	ValidateOptions(options domain.ScanOptions) error
+}
+
+// registeredEngine is an engine adapter with its capabilities
+type registeredEngine struct {
+	adapter      domain.ScanAdapter
+	capabilities Capabilities
+}
+
+// EngineRegistry runs each scan with the engine selected by its options.
+// New engines are added by registering their adapter.
+type EngineRegistry struct {
+	engines map[domain.ScanEngine]registeredEngine
+}
+
+// NewEngineRegistry creates a new EngineRegistry running scans without an engine with nmap
+func NewEngineRegistry(nmap domain.ScanAdapter) *EngineRegistry {
+	registry := &EngineRegistry{
+		engines: make(map[domain.ScanEngine]registeredEngine),
+	}
+	registry.Register(domain.ScanEngineNmap, nmap)
+	return registry
+}
+
+// Register makes an engine selectable by scans. Adapters not reporting
+// their capabilities are assumed to support every scan option.
+func (r *EngineRegistry) Register(engine domain.ScanEngine, adapter domain.ScanAdapter) {
+	capabilities := AllCapabilities
+	if reporter, ok := adapter.(CapabilityReporter); ok {
+		capabilities = reporter.Capabilities()
+	}
+	r.engines[engine] = registeredEngine{adapter: adapter, capabilities: capabilities}
+}
+
+// Lookup returns the adapter of a registered engine
+func (r *EngineRegistry) Lookup(engine domain.ScanEngine) (domain.ScanAdapter, bool) {
+	registered, ok := r.engines[engine]
+	return registered.adapter, ok
+}
+
+// engine returns the registered engine running the scan
+func (r *EngineRegistry) engine(options domain.ScanOptions) (registeredEngine, error) {
+	name := options.ResolvedEngine()
+	engine, ok := r.engines[name]
+	if !ok {
+		return registeredEngine{}, errors.NewInvalidInput("scan engine "+string(name)+" is not enabled", nil)
+	}
+	return engine, nil
+}
+
+// CheckEngine checks that the engine of the scan is enabled and supports its options
+func (r *EngineRegistry) CheckEngine(options domain.ScanOptions) error {
+	engine, err := r.engine(options)
+	if err != nil {
+		return err
+	}
+	if err := checkCapabilities(options.ResolvedEngine(), engine.capabilities, options); err != nil {
+		return err
+	}
+	if validator, ok := engine.adapter.(OptionValidator); ok {
+		return validator.ValidateOptions(options)
+	}
+	return nil
+}
+
+// ExecuteScan executes the scan with its engine
+func (r *EngineRegistry) ExecuteScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	if err := r.CheckEngine(options); err != nil {
+		return nil, err
+	}
+	engine, _ := r.engine(options)
+	return engine.adapter.ExecuteScan(ctx, options)
+}
+
+// GetVersion returns the nmap version
+func (r *EngineRegistry) GetVersion() (string, error) {
+	return r.engines[domain.ScanEngineNmap].adapter.GetVersion()
+}
+
+// IsAvailable checks if nmap is available
+func (r *EngineRegistry) IsAvailable() bool {
+	return r.engines[domain.ScanEngineNmap].adapter.IsAvailable()
+}
+
+// RawSocketCapable reports whether nmap may open raw sockets
+func (r *EngineRegistry) RawSocketCapable() bool {
+	if checker, ok := r.engines[domain.ScanEngineNmap].adapter.(domain.PrivilegeChecker); ok {
+		return checker.RawSocketCapable()
+	}
+	return true
+}
+
+// EngineCapabilities returns the capabilities of the registered engines, ordered by name
+func (r *EngineRegistry) EngineCapabilities() []domain.EngineCapabilities {
+	names := make([]domain.ScanEngine, 0, len(r.engines))
+	for name := range r.engines {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	engines := make([]domain.EngineCapabilities, len(names))
+	for i, name := range names {
+		capabilities := r.engines[name].capabilities
+		scanTypes := capabilities.ScanTypes
+		if scanTypes == nil {
+			scanTypes = domain.ScanTypes
+		}
+		engines[i] = domain.EngineCapabilities{
+			Engine:           name,
+			ScanTypes:        scanTypes,
+			ServiceDetection: capabilities.ServiceDetection,
+			OSDetection:      capabilities.OSDetection,
+			Scripts:          capabilities.Scripts,
+			ExtraOptions:     capabilities.ExtraOptions,
+			HostTimeout:      capabilities.HostTimeout,
+			RateControl:      capabilities.RateControl,
+			Retries:          capabilities.Retries,
+			Evasion:          capabilities.Evasion,
+			HostNames:        capabilities.HostNames,
+		}
+	}
+	return engines
+}
+
+// ScriptCategories returns the NSE script categories of nmap
+func (r *EngineRegistry) ScriptCategories() ([]string, error) {
+	if lister, ok := r.engines[domain.ScanEngineNmap].adapter.(domain.ScriptCategoryLister); ok {
+		return lister.ScriptCategories()
+	}
+	return nil, errors.NewUnavailable("the scanner does not list script categories", nil)
+}
+
+// checkCapabilities checks that an engine with the capabilities supports the scan options
+func checkCapabilities(engine domain.ScanEngine, capabilities Capabilities, options domain.ScanOptions) error {
+	if options.ScanType != "" && capabilities.ScanTypes != nil && !slices.Contains(capabilities.ScanTypes, options.ScanType) {
+		return errors.NewInvalidInput(fmt.Sprintf("%s does not support %s scans", engine, options.ScanType), nil)
+	}
+	if options.ServiceDetection && !capabilities.ServiceDetection {
+		return errors.NewInvalidInput(fmt.Sprintf("%s does not support service detection", engine), nil)
+	}
+	if options.OSDetection && !capabilities.OSDetection {
+		return errors.NewInvalidInput(fmt.Sprintf("%s does not support OS detection", engine), nil)
+	}
+	if options.ScriptScan && !capabilities.Scripts {
+		return errors.NewInvalidInput(fmt.Sprintf("%s does not support scripts", engine), nil)
+	}
+	if len(options.ExtraOptions) > 0 && !capabilities.ExtraOptions {
+		return errors.NewInvalidInput(fmt.Sprintf("%s does not support extra options", engine), nil)
+	}
+	if options.HostTimeout > 0 && !capabilities.HostTimeout {
+		return errors.NewInvalidInput(fmt.Sprintf("%s does not support host timeouts", engine), nil)
+	}
+	if (options.MinRate > 0 || options.MaxRate > 0 || options.MaxParallelism > 0) && !capabilities.RateControl {
+		return errors.NewInvalidInput(fmt.Sprintf("%s does not support packet rate options, its rate is set by the server", engine), nil)
+	}
+	if options.MaxRetries != nil && !capabilities.Retries {
+		return errors.NewInvalidInput(fmt.Sprintf("%s does not support setting retries", engine), nil)
+	}
+	if options.UsesEvasion() && !capabilities.Evasion {
+		return errors.NewInvalidInput(fmt.Sprintf("%s does not support evasion options", engine), nil)
+	}
+	if !capabilities.HostNames {
+		if _, err := addressTargets(string(engine), options.Target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CanResume reports whether nmap saved the progress of an interrupted scan
+func (r *EngineRegistry) CanResume(options domain.ScanOptions) bool {
+	resumer, ok := r.engines[domain.ScanEngineNmap].adapter.(domain.ScanResumer)
+	return ok && resumer.CanResume(options)
+}
+
+// ResumeScan resumes an interrupted scan with nmap
+func (r *EngineRegistry) ResumeScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	resumer, ok := r.engines[domain.ScanEngineNmap].adapter.(domain.ScanResumer)
+	if !ok {
+		return nil, errors.NewInvalidInput("the scanner cannot resume scans", nil)
+	}
+	return resumer.ResumeScan(ctx, options)
+}
@@ -0,0 +1,110 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeEngine is a scan adapter recording the scans it runs
+type fakeEngine struct {
+	name    string
+	hosts   []domain.Host
+	targets []string
+	scans   []domain.ScanOptions
+}
+
+func (e *fakeEngine) ExecuteScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	e.targets = append(e.targets, options.Target)
+	e.scans = append(e.scans, options)
+	return &domain.ScanResult{Command: e.name, TotalHosts: 256, UpHosts: len(e.hosts), Hosts: e.hosts}, nil
+}
+
+func (e *fakeEngine) GetVersion() (string, error) { return e.name, nil }
+
+func (e *fakeEngine) IsAvailable() bool { return true }
+
+func TestEngineRegistry(t *testing.T) {
+	nmap := &fakeEngine{name: "nmap"}
+	masscan := NewMasscanAdapter("masscan", 1000, nil)
+	registry := NewEngineRegistry(nmap)
+	registry.Register(domain.ScanEngineMasscan, masscan)
+
+	// Scans without an engine run with nmap
+	result, err := registry.ExecuteScan(context.Background(), domain.ScanOptions{Target: "example.com", OSDetection: true})
+	require.NoError(t, err)
+	assert.Equal(t, "nmap", result.Command)
+	assert.Equal(t, []string{"example.com"}, nmap.targets)
+
+	// Options are checked against the capabilities of the engine
+	assert.NoError(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.0/8", Engine: domain.ScanEngineMasscan, ScanType: domain.ScanTypeUDP}))
+	assert.Error(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.0/8", Engine: domain.ScanEngineMasscan, ScanType: domain.ScanTypeConnect}))
+	assert.Error(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.0/8", Engine: domain.ScanEngineMasscan, ServiceDetection: true}))
+	assert.Error(t, registry.CheckEngine(domain.ScanOptions{Target: "example.com", Engine: domain.ScanEngineMasscan}))
+	assert.Error(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.0/8", Engine: domain.ScanEngineMasscan, HostTimeout: time.Minute}))
+	assert.NoError(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.0/8", HostTimeout: time.Minute}))
+	assert.Error(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.0/8", Engine: domain.ScanEngineMasscan, MaxRate: 100}))
+
+	// Engines not registered cannot be selected
+	assert.Error(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.1", Engine: domain.ScanEngineZmap}))
+	assert.Error(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.1", Mode: domain.ScanModeFast}))
+}
+
+func TestZmapValidateOptions(t *testing.T) {
+	registry := NewEngineRegistry(&fakeEngine{name: "nmap"})
+	registry.Register(domain.ScanEngineZmap, NewZmapAdapter("zmap", 0, nil))
+
+	assert.NoError(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.0/8", Engine: domain.ScanEngineZmap, Ports: "443"}))
+	assert.Error(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.0/8", Engine: domain.ScanEngineZmap, Ports: "80,443"}))
+	assert.Error(t, registry.CheckEngine(domain.ScanOptions{Target: "10.0.0.1-10.0.0.9", Engine: domain.ScanEngineZmap, Ports: "80"}))
+}
+
+func TestHybridAdapter(t *testing.T) {
+	sweep := &fakeEngine{name: "masscan", hosts: []domain.Host{
+		{IP: "10.0.0.9", Ports: []domain.Port{{Port: 443, State: "open"}}},
+		{IP: "10.0.0.2", Ports: []domain.Port{{Port: 22, State: "open"}, {Port: 80, State: "open"}}},
+	}}
+	nmap := &fakeEngine{name: "nmap", hosts: []domain.Host{{IP: "10.0.0.2"}, {IP: "10.0.0.9"}}}
+	hybrid := NewHybridAdapter(domain.ScanEngineMasscan, sweep, nmap, &logger.Logger{Logger: zap.NewNop()})
+
+	result, err := hybrid.ExecuteScan(context.Background(), domain.ScanOptions{
+		Target: "10.0.0.0/24", Engine: domain.ScanEngineHybrid, OSDetection: true,
+	})
+	require.NoError(t, err)
+
+	// The sweep only finds ports
+	require.Len(t, sweep.scans, 1)
+	assert.Equal(t, domain.ScanEngineMasscan, sweep.scans[0].Engine)
+	assert.False(t, sweep.scans[0].OSDetection)
+
+	// Nmap scans the open ports of the hosts found
+	require.Len(t, nmap.scans, 1)
+	assert.Equal(t, "10.0.0.2 10.0.0.9", nmap.scans[0].Target)
+	assert.Equal(t, "22,80,443", nmap.scans[0].Ports)
+	assert.True(t, nmap.scans[0].ServiceDetection)
+	assert.True(t, nmap.scans[0].ScriptScan)
+	assert.True(t, nmap.scans[0].OSDetection)
+
+	assert.Equal(t, 256, result.TotalHosts)
+	assert.Equal(t, "masscan\nnmap", result.Command)
+}
+
+func TestEngineCapabilities(t *testing.T) {
+	registry := NewEngineRegistry(&fakeEngine{name: "nmap"})
+	registry.Register(domain.ScanEngineMasscan, NewMasscanAdapter("masscan", 1000, nil))
+
+	engines := registry.EngineCapabilities()
+	require.Len(t, engines, 2)
+	assert.Equal(t, domain.ScanEngineMasscan, engines[0].Engine)
+	assert.Equal(t, []domain.ScanType{domain.ScanTypeSYN, domain.ScanTypeUDP}, engines[0].ScanTypes)
+	assert.False(t, engines[0].ServiceDetection)
+	assert.Equal(t, domain.ScanEngineNmap, engines[1].Engine)
+	assert.Equal(t, domain.ScanTypes, engines[1].ScanTypes)
+	assert.True(t, engines[1].Scripts)
+}
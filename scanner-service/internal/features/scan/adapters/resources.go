@@ -0,0 +1,111 @@
+package adapters
+
+import (
+	"os/exec"
+	"sync/atomic"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// I/O scheduling classes of nmap processes
+const (
+	IOClassBestEffort = "best-effort"
+	IOClassIdle       = "idle"
+)
+
+// ResourceLimits are the resource controls nmap processes run under, so a runaway scan
+// cannot starve the service or the host. Zero values disable a control.
+type ResourceLimits struct {
+	Nice         int           // Scheduling priority adjustment, 1 (slightly lower) to 19 (lowest)
+	IOClass      string        // I/O scheduling class, best-effort or idle, empty to keep the class of the service
+	CPUs         float64       // CPU cores a scan may use, enforced with cgroups
+	MemoryBytes  int64         // Memory a scan may use, enforced with cgroups or else as an address space limit
+	MaxRuntime   time.Duration // Wall-clock time after which nmap is killed, even if it ignores the interrupt of a stopped scan
+	CgroupParent string        // cgroup v2 directory the cgroups of scans are created in, empty to disable cgroups
+}
+
+// SetResourceLimits sets the resource controls nmap processes run under. Controls the
+// host does not support are logged and skipped.
+func (a *NmapAdapter) SetResourceLimits(limits ResourceLimits) {
+	if !processLimitsSupported && (limits.Nice != 0 || limits.IOClass != "" || limits.MemoryBytes > 0) {
+		a.logger.Warn("Priority and memory limits of nmap are not supported on this platform")
+	}
+	if limits.CgroupParent != "" {
+		if err := checkCgroupParent(limits.CgroupParent); err != nil {
+			a.logger.Warn("cgroups unavailable, nmap runs without CPU limit and with an address space limit instead of a memory limit",
+				zap.String("cgroup_parent", limits.CgroupParent),
+				zap.Error(err),
+			)
+			limits.CgroupParent = ""
+		}
+	}
+	if limits.CPUs > 0 && limits.CgroupParent == "" {
+		a.logger.Warn("The CPU limit of nmap needs cgroups and is not enforced")
+	}
+	a.limits = limits
+}
+
+// limitedProcess is a process running under resource limits
+type limitedProcess struct {
+	cmd      *exec.Cmd
+	cgroup   string      // cgroup of the process, empty if it runs without
+	deadline *time.Timer // Kills the process once it exceeded its maximum runtime
+	killed   atomic.Bool // Whether the process was killed for exceeding its maximum runtime
+	oom      bool        // Whether the process was killed for exceeding its memory limit
+}
+
+// startLimited starts cmd under the resource limits
+func startLimited(cmd *exec.Cmd, limits ResourceLimits) (*limitedProcess, error) {
+	process := &limitedProcess{cmd: cmd}
+
+	// The process starts in its cgroup, so it never runs without the limits
+	if limits.CgroupParent != "" && (limits.CPUs > 0 || limits.MemoryBytes > 0) {
+		cgroup, release, err := createCgroup(cmd, limits)
+		if err != nil {
+			return nil, errors.NewInternal("failed to create the cgroup of the scanner", err)
+		}
+		defer release()
+		process.cgroup = cgroup
+	}
+
+	if err := cmd.Start(); err != nil {
+		process.removeCgroup()
+		return nil, err
+	}
+	if err := limitProcess(cmd.Process.Pid, limits, process.cgroup != ""); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		process.removeCgroup()
+		return nil, errors.NewInternal("failed to limit the resources of the scanner", err)
+	}
+
+	if limits.MaxRuntime > 0 {
+		process.deadline = time.AfterFunc(limits.MaxRuntime, func() {
+			process.killed.Store(true)
+			cmd.Process.Kill()
+		})
+	}
+	return process, nil
+}
+
+// wait waits for the process to exit and releases its cgroup
+func (p *limitedProcess) wait() error {
+	err := p.cmd.Wait()
+	if p.deadline != nil {
+		p.deadline.Stop()
+	}
+	p.removeCgroup()
+	return err
+}
+
+// removeCgroup removes the cgroup of the exited process, recording whether the process
+// exceeded its memory limit
+func (p *limitedProcess) removeCgroup() {
+	if p.cgroup == "" {
+		return
+	}
+	p.oom = removeCgroup(p.cgroup)
+	p.cgroup = ""
+}
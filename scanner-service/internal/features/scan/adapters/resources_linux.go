@@ -0,0 +1,134 @@
+package adapters
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/google/uuid"
+	"golang.org/x/sys/unix"
+)
+
+// processLimitsSupported reports whether priorities and memory limits can be applied
+const processLimitsSupported = true
+
+// cpuPeriod is the cgroup CPU accounting period in microseconds
+const cpuPeriod = 100000
+
+// I/O priority constants of ioprio_set(2)
+const (
+	ioprioWhoProcess    = 1
+	ioprioClassShift    = 13
+	ioprioClassBestEff  = 2
+	ioprioClassIdle     = 3
+	ioprioLowestBestEff = 7
+)
+
+// checkCgroupParent checks that cgroups with CPU and memory limits can be created in a
+// cgroup v2 directory, enabling the controllers for its children if needed
+func checkCgroupParent(parent string) error {
+	controllers, err := os.ReadFile(filepath.Join(parent, "cgroup.controllers"))
+	if err != nil {
+		return fmt.Errorf("%s is not a cgroup v2 directory: %w", parent, err)
+	}
+	for _, controller := range []string{"cpu", "memory"} {
+		if !slices.Contains(strings.Fields(string(controllers)), controller) {
+			return fmt.Errorf("the %s controller is not available in %s", controller, parent)
+		}
+	}
+
+	control := filepath.Join(parent, "cgroup.subtree_control")
+	if err := os.WriteFile(control, []byte("+cpu +memory"), 0o644); err != nil {
+		return fmt.Errorf("failed to enable the cpu and memory controllers in %s: %w", parent, err)
+	}
+	return nil
+}
+
+// createCgroup creates a cgroup with the CPU and memory limits and makes cmd start in it.
+// The returned function releases the cgroup handle once the process started.
+func createCgroup(cmd *exec.Cmd, limits ResourceLimits) (string, func(), error) {
+	dir := filepath.Join(limits.CgroupParent, "nmap-"+uuid.NewString())
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return "", nil, err
+	}
+
+	if limits.CPUs > 0 {
+		quota := int(limits.CPUs * cpuPeriod)
+		if err := os.WriteFile(filepath.Join(dir, "cpu.max"), []byte(fmt.Sprintf("%d %d", quota, cpuPeriod)), 0o644); err != nil {
+			os.Remove(dir)
+			return "", nil, err
+		}
+	}
+	if limits.MemoryBytes > 0 {
+		memory := []byte(strconv.FormatInt(limits.MemoryBytes, 10))
+		if err := os.WriteFile(filepath.Join(dir, "memory.max"), memory, 0o644); err != nil {
+			os.Remove(dir)
+			return "", nil, err
+		}
+		// Without swap, the limit is the memory the process may use
+		os.WriteFile(filepath.Join(dir, "memory.swap.max"), []byte("0"), 0o644)
+	}
+
+	handle, err := os.Open(dir)
+	if err != nil {
+		os.Remove(dir)
+		return "", nil, err
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(handle.Fd())
+	return dir, func() { handle.Close() }, nil
+}
+
+// removeCgroup removes the cgroup of an exited process and reports whether the process
+// was killed for exceeding the memory limit
+func removeCgroup(dir string) bool {
+	oom := false
+	if events, err := os.ReadFile(filepath.Join(dir, "memory.events")); err == nil {
+		for _, line := range strings.Split(string(events), "\n") {
+			if count, ok := strings.CutPrefix(line, "oom_kill "); ok && count != "0" {
+				oom = true
+			}
+		}
+	}
+	os.Remove(dir)
+	return oom
+}
+
+// limitProcess lowers the CPU and I/O priority of a started process, and limits its
+// address space if its memory is not limited by a cgroup
+func limitProcess(pid int, limits ResourceLimits, cgroup bool) error {
+	if limits.Nice != 0 {
+		if err := unix.Setpriority(unix.PRIO_PROCESS, pid, limits.Nice); err != nil {
+			return fmt.Errorf("failed to set nice value: %w", err)
+		}
+	}
+
+	var ioprio int
+	switch limits.IOClass {
+	case IOClassBestEffort:
+		ioprio = ioprioClassBestEff<<ioprioClassShift | ioprioLowestBestEff
+	case IOClassIdle:
+		ioprio = ioprioClassIdle << ioprioClassShift
+	}
+	if ioprio != 0 {
+		if _, _, errno := unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(pid), uintptr(ioprio)); errno != 0 {
+			return fmt.Errorf("failed to set I/O class: %w", errno)
+		}
+	}
+
+	if limits.MemoryBytes > 0 && !cgroup {
+		limit := &unix.Rlimit{Cur: uint64(limits.MemoryBytes), Max: uint64(limits.MemoryBytes)}
+		if err := unix.Prlimit(pid, unix.RLIMIT_AS, limit, nil); err != nil {
+			return fmt.Errorf("failed to limit address space: %w", err)
+		}
+	}
+	return nil
+}
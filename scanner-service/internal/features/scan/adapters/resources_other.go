@@ -0,0 +1,34 @@
+//go:build !linux
+
+package adapters
+
+import (
+	stderrors "errors"
+	"os/exec"
+)
+
+// processLimitsSupported reports whether priorities and memory limits can be applied
+const processLimitsSupported = false
+
+// errNoCgroups is returned where cgroups are not available
+var errNoCgroups = stderrors.New("cgroups are only available on Linux")
+
+// checkCgroupParent reports that cgroups are not available
+func checkCgroupParent(parent string) error {
+	return errNoCgroups
+}
+
+// createCgroup reports that cgroups are not available
+func createCgroup(cmd *exec.Cmd, limits ResourceLimits) (string, func(), error) {
+	return "", nil, errNoCgroups
+}
+
+// removeCgroup does nothing, there are no cgroups
+func removeCgroup(dir string) bool {
+	return false
+}
+
+// limitProcess does nothing, priorities and memory limits are not supported
+func limitProcess(pid int, limits ResourceLimits, cgroup bool) error {
+	return nil
+}
@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStartLimitedLowersPriority(t *testing.T) {
+	if !processLimitsSupported {
+		t.Skip("process limits are not supported on this platform")
+	}
+
+	// The priority is lowered right after the start, before the process does its work
+	var stdout bytes.Buffer
+	cmd := exec.Command("sh", "-c", "sleep 0.2; nice")
+	cmd.Stdout = &stdout
+	process, err := startLimited(cmd, ResourceLimits{Nice: 5})
+	require.NoError(t, err)
+	require.NoError(t, process.wait())
+	assert.Equal(t, "5\n", stdout.String())
+}
+
+// stubbornNmap is a script standing in for nmap that ignores interrupts
+const stubbornNmap = `#!/bin/sh
+trap '' INT
+sleep 10 > /dev/null 2>&1 &
+wait
+`
+
+func TestNmapExecuteScanKillsAfterMaxRuntime(t *testing.T) {
+	nmapPath := filepath.Join(t.TempDir(), "nmap")
+	require.NoError(t, os.WriteFile(nmapPath, []byte(stubbornNmap), 0o700))
+	adapter := NewNmapAdapter(nmapPath, &logger.Logger{Logger: zap.NewNop()})
+	adapter.SetResourceLimits(ResourceLimits{MaxRuntime: 300 * time.Millisecond})
+
+	// nmap is killed once it exceeded its maximum runtime, although the scan has no timeout
+	start := time.Now()
+	_, err := adapter.ExecuteScan(context.Background(), domain.ScanOptions{Target: "10.0.0.1"})
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrTimeout, scanErr.Type)
+	assert.Contains(t, scanErr.Message, "maximum runtime of 300ms")
+
+	var scannerErr *domain.ScannerError
+	require.ErrorAs(t, err, &scannerErr)
+	assert.Equal(t, -1, scannerErr.Failure.ExitCode)
+}
@@ -0,0 +1,238 @@
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RustscanAdapter is an adapter for rustscan, which finds the open ports of the targets
+// and then runs nmap with service detection on those ports only
+type RustscanAdapter struct {
+	rustscanPath string
+	batchSize    int // Ports probed at once
+	timeout      time.Duration
+	nmap         domain.ScanAdapter
+	logger       *logger.Logger
+}
+
+// NewRustscanAdapter creates a new RustscanAdapter passing the open ports it finds to nmap
+func NewRustscanAdapter(rustscanPath string, batchSize int, timeout time.Duration, nmap domain.ScanAdapter, logger *logger.Logger) *RustscanAdapter {
+	if rustscanPath == "" {
+		rustscanPath = "rustscan" // Use PATH by default
+	}
+	if batchSize <= 0 {
+		batchSize = 4500
+	}
+	if timeout <= 0 {
+		timeout = 1500 * time.Millisecond
+	}
+
+	return &RustscanAdapter{
+		rustscanPath: rustscanPath,
+		batchSize:    batchSize,
+		timeout:      timeout,
+		nmap:         nmap,
+		logger:       logger,
+	}
+}
+
+// Capabilities returns the scan options supported by rustscan. Nmap scans the
+// ports found by rustscan, so only port scans over TCP are excluded.
+func (a *RustscanAdapter) Capabilities() Capabilities {
+	capabilities := AllCapabilities
+	capabilities.ScanTypes = []domain.ScanType{
+		domain.ScanTypeSYN, domain.ScanTypeConnect, domain.ScanTypeVersion, domain.ScanTypeScript, domain.ScanTypeAll,
+	}
+	return capabilities
+}
+
+// ExecuteScan finds the open ports of the targets with rustscan and runs
+// nmap service detection on them
+func (a *RustscanAdapter) ExecuteScan(ctx context.Context, scanOptions domain.ScanOptions) (*domain.ScanResult, error) {
+	startTime := time.Now()
+	args, err := a.buildCommandArgs(scanOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.Info("Executing rustscan port discovery",
+		zap.String("target", scanOptions.Target),
+		zap.Strings("args", args),
+	)
+
+	output, err := runScanner(ctx, a.logger, "rustscan", a.rustscanPath, args)
+	if err != nil {
+		return nil, err
+	}
+
+	openPorts, err := parseRustscanGreppable(output)
+	if err != nil {
+		return nil, errors.NewInternal("failed to parse rustscan output", err)
+	}
+	command := a.rustscanPath + " " + strings.Join(args, " ")
+
+	// Nothing to pass to nmap
+	if len(openPorts) == 0 {
+		endTime := time.Now()
+		result := &domain.ScanResult{
+			ID:         uuid.New().String(),
+			StartTime:  startTime,
+			EndTime:    endTime,
+			Duration:   endTime.Sub(startTime).Seconds(),
+			Command:    command,
+			TotalHosts: countAddresses(scanOptions.Target),
+			Hosts:      make([]domain.Host, 0),
+		}
+		result.Summary = fmt.Sprintf("rustscan done: %d IP addresses (0 hosts with open ports) scanned in %.2f seconds",
+			result.TotalHosts, result.Duration)
+		return result, nil
+	}
+
+	result, err := a.nmap.ExecuteScan(ctx, serviceScanOptions(scanOptions, openPorts))
+	if err != nil {
+		// A partial result of a stopped scan is returned with the error
+		return result, err
+	}
+
+	endTime := time.Now()
+	result.StartTime = startTime
+	result.EndTime = endTime
+	result.Duration = endTime.Sub(startTime).Seconds()
+	result.Command = command + "\n" + result.Command
+	result.TotalHosts = countAddresses(scanOptions.Target)
+	result.Summary = fmt.Sprintf("rustscan and nmap done: %d IP addresses (%d hosts up) scanned in %.2f seconds",
+		result.TotalHosts, result.UpHosts, result.Duration)
+
+	a.logger.Info("Rustscan scan completed",
+		zap.String("target", scanOptions.Target),
+		zap.Int("up_hosts", result.UpHosts),
+		zap.Float64("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// buildCommandArgs builds rustscan command arguments from scan options.
+// Without ports rustscan probes all 65535 ports.
+func (a *RustscanAdapter) buildCommandArgs(options domain.ScanOptions) ([]string, error) {
+	targets := strings.Fields(strings.ReplaceAll(options.Target, ",", " "))
+	args := []string{"-a", strings.Join(targets, ","), "-g"}
+
+	if options.Ports != "" {
+		if start, end, ok := strings.Cut(options.Ports, "-"); ok && !strings.Contains(options.Ports, ",") {
+			args = append(args, "-r", strings.TrimSpace(start)+"-"+strings.TrimSpace(end))
+		} else {
+			ports, err := utils.PortRangeToSlice(options.Ports)
+			if err != nil {
+				return nil, errors.NewInvalidInput("invalid ports: "+err.Error(), err)
+			}
+			list := make([]string, len(ports))
+			for i, port := range ports {
+				list[i] = strconv.Itoa(port)
+			}
+			args = append(args, "-p", strings.Join(list, ","))
+		}
+	}
+
+	args = append(args,
+		"-b", strconv.Itoa(a.batchSize),
+		"-t", strconv.FormatInt(a.timeout.Milliseconds(), 10),
+	)
+	return args, nil
+}
+
+// serviceScanOptions returns the nmap options scanning the open ports found by a port
+// discovery engine with service detection
+func serviceScanOptions(options domain.ScanOptions, openPorts map[string][]int) domain.ScanOptions {
+	ips := make([]string, 0, len(openPorts))
+	portSet := make(map[int]bool)
+	for ip, ports := range openPorts {
+		ips = append(ips, ip)
+		for _, port := range ports {
+			portSet[port] = true
+		}
+	}
+	sort.Slice(ips, func(i, j int) bool {
+		return bytes.Compare(net.ParseIP(ips[i]).To16(), net.ParseIP(ips[j]).To16()) < 0
+	})
+
+	ports := make([]int, 0, len(portSet))
+	for port := range portSet {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	portList := make([]string, len(ports))
+	for i, port := range ports {
+		portList[i] = strconv.Itoa(port)
+	}
+
+	nmapOptions := options
+	nmapOptions.Engine = ""
+	nmapOptions.Mode = ""
+	nmapOptions.Target = strings.Join(ips, " ")
+	nmapOptions.Ports = strings.Join(portList, ",")
+	nmapOptions.ServiceDetection = true
+	// The hosts are known to be up
+	nmapOptions.ExtraOptions = append(append([]string{}, options.ExtraOptions...), "-Pn")
+	return nmapOptions
+}
+
+// parseRustscanGreppable parses the greppable output of rustscan ("10.0.0.1 -> [22,80]")
+// into the open ports of each address
+func parseRustscanGreppable(output []byte) (map[string][]int, error) {
+	openPorts := make(map[string][]int)
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		ip, list, ok := strings.Cut(scanner.Text(), " -> ")
+		if !ok {
+			continue
+		}
+		ip = strings.TrimSpace(ip)
+		if net.ParseIP(ip) == nil {
+			continue
+		}
+
+		list = strings.Trim(strings.TrimSpace(list), "[]")
+		for _, field := range strings.Split(list, ",") {
+			if field = strings.TrimSpace(field); field == "" {
+				continue
+			}
+			port, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %q", field)
+			}
+			openPorts[ip] = append(openPorts[ip], port)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return openPorts, nil
+}
+
+// GetVersion returns the rustscan version
+func (a *RustscanAdapter) GetVersion() (string, error) {
+	return scannerVersion("rustscan", a.rustscanPath, "--version")
+}
+
+// IsAvailable checks if rustscan and nmap are available
+func (a *RustscanAdapter) IsAvailable() bool {
+	_, err := a.GetVersion()
+	return err == nil && a.nmap.IsAvailable()
+}
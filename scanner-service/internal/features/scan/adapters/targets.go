@@ -0,0 +1,71 @@
+package adapters
+
+import (
+	"math/big"
+	"net"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// maxCountedAddresses caps the address count of a target, e.g. for IPv6 networks
+const maxCountedAddresses = 1 << 40
+
+// addressTargets checks that every item of a target is an IP address, a CIDR
+// or an address range ("10.0.0.1-10.0.0.50") and returns the items.
+// Engines that send raw packets cannot resolve host names.
+func addressTargets(engine, target string) ([]string, error) {
+	items := strings.Fields(strings.ReplaceAll(target, ",", " "))
+	for _, item := range items {
+		if _, ok := countTarget(item); !ok {
+			return nil, errors.NewInvalidInput(engine+" only scans IP addresses, networks and address ranges, not "+item, nil)
+		}
+	}
+	return items, nil
+}
+
+// countAddresses returns the number of addresses covered by a target
+func countAddresses(target string) int {
+	total := 0
+	for _, item := range strings.Fields(strings.ReplaceAll(target, ",", " ")) {
+		count, ok := countTarget(item)
+		if !ok {
+			count = 1 // Host name
+		}
+		total = min(total+count, maxCountedAddresses)
+	}
+	return total
+}
+
+// countTarget returns the number of addresses of a single IP, CIDR or address range
+func countTarget(item string) (int, bool) {
+	if net.ParseIP(item) != nil {
+		return 1, true
+	}
+
+	if _, network, err := net.ParseCIDR(item); err == nil {
+		ones, bits := network.Mask.Size()
+		if bits-ones >= 40 {
+			return maxCountedAddresses, true
+		}
+		return 1 << (bits - ones), true
+	}
+
+	if dash := strings.Index(item, "-"); dash > 0 {
+		first, last := net.ParseIP(item[:dash]), net.ParseIP(item[dash+1:])
+		if first == nil || last == nil || (first.To4() == nil) != (last.To4() == nil) {
+			return 0, false
+		}
+		count := new(big.Int).Sub(new(big.Int).SetBytes(last.To16()), new(big.Int).SetBytes(first.To16()))
+		if count.Sign() < 0 {
+			return 0, false
+		}
+		count.Add(count, big.NewInt(1))
+		if !count.IsInt64() || count.Int64() > maxCountedAddresses {
+			return maxCountedAddresses, true
+		}
+		return int(count.Int64()), true
+	}
+
+	return 0, false
+}
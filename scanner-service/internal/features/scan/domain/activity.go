@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// MaxActivityEvents is the maximum number of events returned by an activity feed
+const MaxActivityEvents = 100
+
+// ActivityType represents the kind of a scan lifecycle event
+type ActivityType string
+
+// Activity type constants
+const (
+	ActivityScanStarted   ActivityType = "scan_started"
+	ActivityScanCompleted ActivityType = "scan_completed"
+	ActivityScanFailed    ActivityType = "scan_failed"
+	ActivityScanCancelled ActivityType = "scan_cancelled"
+)
+
+// Activity represents a scan lifecycle event in the activity feed of a user
+type Activity struct {
+	Type       ActivityType `json:"type"`            // Kind of the event
+	OccurredAt time.Time    `json:"occurred_at"`     // When the event occurred
+	ScanID     string       `json:"scan_id"`         // Scan the event belongs to
+	Target     string       `json:"target"`          // Target of the scan
+	Error      string       `json:"error,omitempty"` // Why the scan failed, for failed scans
+}
+
+// GetUserActivity returns the latest scan lifecycle events of a user, newest first.
+// Events are derived from the stored scans, leaving out the scans in the trash.
+// The caller must be the user or an admin.
+func (s *ScanService) GetUserActivity(ctx context.Context, userID string, limit int) ([]Activity, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != principal.UserID && !principal.IsAdmin() {
+		return nil, errors.NewForbidden("cannot view the activity of other users", nil)
+	}
+	if limit < 1 || limit > MaxActivityEvents {
+		return nil, errors.NewInvalidInput("limit must be between 1 and 100", nil)
+	}
+
+	// The start of a finished scan precedes its end, so only the scans that finished
+	// last and the running scans can have events among the latest ones
+	finished, err := s.repository.ListScans(ScanFilter{
+		UserID:   userID,
+		Statuses: []ScanStatus{ScanStatusCompleted, ScanStatusFailed, ScanStatusCancelled},
+	}, ScanSort{Field: ScanSortCompletedAt}, PageRequest{Limit: limit})
+	if err != nil {
+		return nil, errors.NewInternal("failed to list scans", err)
+	}
+	running, err := s.repository.ListScans(ScanFilter{
+		UserID:   userID,
+		Statuses: []ScanStatus{ScanStatusRunning},
+	}, ScanSort{}, PageRequest{Limit: limit})
+	if err != nil {
+		return nil, errors.NewInternal("failed to list scans", err)
+	}
+
+	var events []Activity
+	for _, scan := range append(finished.Scans, running.Scans...) {
+		events = append(events, scanActivity(scan)...)
+	}
+
+	slices.SortStableFunc(events, func(a, b Activity) int {
+		return b.OccurredAt.Compare(a.OccurredAt)
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+// scanActivity returns the lifecycle events of a scan, oldest first
+func scanActivity(scan *Scan) []Activity {
+	var events []Activity
+	event := func(kind ActivityType, at time.Time) Activity {
+		return Activity{Type: kind, OccurredAt: at, ScanID: scan.ID, Target: scan.Options.Target}
+	}
+
+	if scan.StartedAt != nil {
+		events = append(events, event(ActivityScanStarted, *scan.StartedAt))
+	}
+	if scan.CompletedAt == nil {
+		return events
+	}
+
+	switch scan.Status {
+	case ScanStatusCompleted:
+		events = append(events, event(ActivityScanCompleted, *scan.CompletedAt))
+	case ScanStatusFailed:
+		failed := event(ActivityScanFailed, *scan.CompletedAt)
+		failed.Error = scan.Error
+		events = append(events, failed)
+	case ScanStatusCancelled:
+		events = append(events, event(ActivityScanCancelled, *scan.CompletedAt))
+	}
+
+	return events
+}
@@ -0,0 +1,67 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetUserActivity(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	service := domain.NewScanService(new(MockScanAdapter), repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleViewer)
+
+	base := time.Now().Add(-time.Hour)
+	at := func(minutes int) *time.Time {
+		t := base.Add(time.Duration(minutes) * time.Minute)
+		return &t
+	}
+	// The failed scan started before the completed scan and finished after it
+	finished := []*domain.Scan{
+		{ID: "failed", Status: domain.ScanStatusFailed, StartedAt: at(0), CompletedAt: at(30), Error: "host unreachable"},
+		{ID: "completed", Status: domain.ScanStatusCompleted, StartedAt: at(10), CompletedAt: at(20)},
+		{ID: "cancelled", Status: domain.ScanStatusCancelled, CompletedAt: at(5)},
+	}
+	running := []*domain.Scan{{ID: "running", Status: domain.ScanStatusRunning, StartedAt: at(25)}}
+	repository.On("ListScans", mock.MatchedBy(func(f domain.ScanFilter) bool {
+		return f.UserID == "alice" && len(f.Statuses) == 3
+	}), domain.ScanSort{Field: domain.ScanSortCompletedAt}, mock.Anything).Return(&domain.ScanPage{Scans: finished}, nil)
+	repository.On("ListScans", domain.ScanFilter{UserID: "alice", Statuses: []domain.ScanStatus{domain.ScanStatusRunning}},
+		domain.ScanSort{}, mock.Anything).Return(&domain.ScanPage{Scans: running}, nil)
+
+	events, err := service.GetUserActivity(ctx, "alice", 10)
+	require.NoError(t, err)
+
+	var feed []string
+	for _, event := range events {
+		feed = append(feed, event.ScanID+" "+string(event.Type))
+	}
+	assert.Equal(t, []string{
+		"failed scan_failed",
+		"running scan_started",
+		"completed scan_completed",
+		"completed scan_started",
+		"cancelled scan_cancelled",
+		"failed scan_started",
+	}, feed)
+	assert.Equal(t, "host unreachable", events[0].Error)
+
+	events, err = service.GetUserActivity(ctx, "alice", 2)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	// Only admins see the activity of other users
+	_, err = service.GetUserActivity(ctx, "bob", 10)
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrForbidden, scanErr.Type)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ScanStats represents the runtime state of the scan service
+type ScanStats struct {
+	ActiveScans        int     `json:"active_scans"`         // Pending and running scans
+	PendingScans       int     `json:"pending_scans"`        // Scans waiting to start (queue depth)
+	RunningScans       int     `json:"running_scans"`        // Scans currently executing
+	MaxConcurrentScans int     `json:"max_concurrent_scans"` // Current concurrency limit
+	Scans              []*Scan `json:"scans"`                // Pending and running scans
+}
+
+// GetScanStats returns the queue depth and the active scans.
+// The caller must be an admin.
+func (s *ScanService) GetScanStats(ctx context.Context) (*ScanStats, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	active := s.pool.activeScans()
+	workers, _ := s.pool.capacity()
+
+	stats := &ScanStats{
+		ActiveScans:        len(active),
+		MaxConcurrentScans: workers,
+		Scans:              active,
+	}
+
+	for _, scan := range active {
+		switch scan.Status {
+		case ScanStatusPending:
+			stats.PendingScans++
+		case ScanStatusRunning:
+			stats.RunningScans++
+		}
+	}
+
+	return stats, nil
+}
+
+// SetMaxConcurrentScans changes the number of workers running scans at runtime.
+// Running scans are not affected when the limit is lowered, surplus workers retire
+// once their scan finished.
+// The caller must be an admin.
+func (s *ScanService) SetMaxConcurrentScans(ctx context.Context, maxConcurrentScans int) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if maxConcurrentScans < 1 {
+		return errors.NewInvalidInput("max concurrent scans must be at least 1", nil)
+	}
+
+	previous, _ := s.pool.capacity()
+	s.pool.resize(maxConcurrentScans)
+
+	s.logger.Info("Max concurrent scans changed",
+		zap.Int("previous", previous),
+		zap.Int("current", maxConcurrentScans),
+		zap.String("changed_by", principal.UserID),
+	)
+
+	return nil
+}
+
+// PurgeScanResults deletes the results of scans that ended before olderThan ago, or
+// all results when all is set. The caller must be an admin.
+func (s *ScanService) PurgeScanResults(ctx context.Context, olderThan time.Duration, all bool) (int, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case all && olderThan != 0:
+		return 0, errors.NewInvalidField("older_than", "excluded_with", "older_than cannot be combined with all")
+	case !all && olderThan <= 0:
+		return 0, errors.NewInvalidField("older_than", "required", "older_than must be positive, or all must be set to purge all results")
+	}
+
+	purged, err := s.repository.PurgeScanResults(time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, errors.NewInternal("failed to purge scan results", err)
+	}
+
+	s.logger.Info("Scan results purged",
+		zap.Int("count", purged),
+		zap.Duration("older_than", olderThan),
+		zap.Bool("all", all),
+		zap.String("purged_by", principal.UserID),
+	)
+
+	return purged, nil
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// addressPattern matches MAC addresses, IPv6 addresses and IPv4 addresses in text.
+// Matches are validated before they are replaced, so version numbers and times are kept.
+var addressPattern = regexp.MustCompile(
+	`(?:[0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}` +
+		`|(?:[0-9A-Fa-f]{0,4}:){2,7}(?:\d{1,3}(?:\.\d{1,3}){3}|[0-9A-Fa-f]{0,4})` +
+		`|\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+
+// macPattern matches a whole MAC address
+var macPattern = regexp.MustCompile(`^(?:[0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}$`)
+
+// anonymizer replaces IP addresses, hostnames and MAC addresses with pseudonyms.
+// The same value always gets the same pseudonym, so hosts can still be told apart.
+//
+// IPv4 addresses are mapped into the reserved 240.0.0.0/4 range, IPv6 addresses into
+// the 2001:db8::/32 documentation range, hostnames to host-N.invalid and MAC addresses
+// to locally administered addresses. Hostnames are only recognized in text when they
+// are a hostname of a host of the result.
+type anonymizer struct {
+	ips       map[netip.Addr]string
+	hostnames map[string]string
+	macs      map[string]string
+}
+
+// AnonymizeResult returns a copy of the result with its IP addresses, hostnames and
+// MAC addresses replaced by pseudonyms that are consistent within the result.
+// The geolocation and netblock owner of hosts are dropped as they identify the
+// network of the host.
+func AnonymizeResult(result *ScanResult) *ScanResult {
+	a := &anonymizer{
+		ips:       make(map[netip.Addr]string),
+		hostnames: make(map[string]string),
+		macs:      make(map[string]string),
+	}
+
+	// Pseudonyms are assigned in the order of the hosts before any text is replaced
+	for _, host := range result.Hosts {
+		a.ip(host.IP)
+		for _, hostname := range host.Hostnames {
+			a.hostname(hostname)
+		}
+	}
+	hostnames := a.hostnamePattern()
+
+	anonymized := *result
+	anonymized.Command = a.text(anonymized.Command, hostnames)
+	anonymized.Summary = a.text(anonymized.Summary, hostnames)
+	anonymized.Hosts = make([]Host, len(result.Hosts))
+	for i, host := range result.Hosts {
+		host.IP = a.ip(host.IP)
+		host.Hostnames = slices.Clone(host.Hostnames)
+		for j, hostname := range host.Hostnames {
+			host.Hostnames[j] = a.hostname(hostname)
+		}
+		host.Geo = nil
+		host.Owner = nil
+
+		host.Ports = slices.Clone(host.Ports)
+		for j := range host.Ports {
+			host.Ports[j].ExtraInfo = a.text(host.Ports[j].ExtraInfo, hostnames)
+		}
+		host.Scripts = slices.Clone(host.Scripts)
+		for j, script := range host.Scripts {
+			host.Scripts[j].Output = a.text(script.Output, hostnames)
+			if script.Data != nil {
+				host.Scripts[j].Data = make(map[string]string, len(script.Data))
+				for key, value := range script.Data {
+					host.Scripts[j].Data[key] = a.text(value, hostnames)
+				}
+			}
+		}
+		host.Notes = slices.Clone(host.Notes)
+		for j := range host.Notes {
+			host.Notes[j].Text = a.text(host.Notes[j].Text, hostnames)
+		}
+
+		anonymized.Hosts[i] = host
+	}
+
+	return &anonymized
+}
+
+// text replaces the IP addresses, MAC addresses and the hostnames matched by the
+// pattern in a value. The pattern may be nil.
+func (a *anonymizer) text(value string, hostnames *regexp.Regexp) string {
+	if value == "" {
+		return value
+	}
+	if hostnames != nil {
+		value = hostnames.ReplaceAllStringFunc(value, a.hostname)
+	}
+	return addressPattern.ReplaceAllStringFunc(value, func(match string) string {
+		if macPattern.MatchString(match) {
+			return a.mac(match)
+		}
+		if _, err := netip.ParseAddr(match); err != nil {
+			return match
+		}
+		return a.ip(match)
+	})
+}
+
+// hostnamePattern returns a pattern matching the known hostnames as whole words,
+// or nil if there are none
+func (a *anonymizer) hostnamePattern() *regexp.Regexp {
+	if len(a.hostnames) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(a.hostnames))
+	for name := range a.hostnames {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	// Longer names first, so a hostname is not replaced by its parent domain
+	slices.SortFunc(names, func(x, y string) int { return len(y) - len(x) })
+
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(names, "|") + `)\b`)
+}
+
+// ip returns the pseudonym of an IP address. Values that are not IP addresses are
+// returned as is, as are loopback and unspecified addresses which identify no host.
+func (a *anonymizer) ip(value string) string {
+	addr, err := netip.ParseAddr(value)
+	if err != nil || addr.IsLoopback() || addr.IsUnspecified() {
+		return value
+	}
+	addr = addr.Unmap()
+
+	if pseudonym, ok := a.ips[addr]; ok {
+		return pseudonym
+	}
+
+	n := uint32(len(a.ips) + 1)
+	var pseudonym netip.Addr
+	if addr.Is4() {
+		var bytes [4]byte
+		binary.BigEndian.PutUint32(bytes[:], 240<<24|n&0x0fffffff)
+		pseudonym = netip.AddrFrom4(bytes)
+	} else {
+		bytes := [16]byte{0x20, 0x01, 0x0d, 0xb8}
+		binary.BigEndian.PutUint32(bytes[12:], n)
+		pseudonym = netip.AddrFrom16(bytes)
+	}
+
+	a.ips[addr] = pseudonym.String()
+	return a.ips[addr]
+}
+
+// hostname returns the pseudonym of a hostname, ignoring its case
+func (a *anonymizer) hostname(value string) string {
+	name := strings.ToLower(strings.TrimSuffix(value, "."))
+	if name == "" {
+		return value
+	}
+	if pseudonym, ok := a.hostnames[name]; ok {
+		return pseudonym
+	}
+
+	a.hostnames[name] = fmt.Sprintf("host-%d.invalid", len(a.hostnames)+1)
+	return a.hostnames[name]
+}
+
+// mac returns the pseudonym of a MAC address, ignoring its case and separators
+func (a *anonymizer) mac(value string) string {
+	key := strings.ToLower(strings.ReplaceAll(value, "-", ":"))
+	if pseudonym, ok := a.macs[key]; ok {
+		return pseudonym
+	}
+
+	n := len(a.macs) + 1
+	a.macs[key] = fmt.Sprintf("02:00:00:%02x:%02x:%02x", n>>16&0xff, n>>8&0xff, n&0xff)
+	return a.macs[key]
+}
@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnonymizeResult(t *testing.T) {
+	result := &domain.ScanResult{
+		ID:      "result-1",
+		Command: "nmap -sV 10.0.0.0/24",
+		Hosts: []domain.Host{
+			{
+				IP:        "10.0.0.5",
+				Hostnames: []string{"db.corp.local"},
+				Ports:     []domain.Port{{Port: 22, Product: "OpenSSH", Version: "8.9.1.2", ExtraInfo: "Ubuntu"}},
+				Scripts: []domain.Script{{
+					ID:     "nbstat",
+					Output: "NetBIOS MAC: 00:1A:2B:3C:4D:5E, seen by DB.corp.local via 10.0.0.1 at 12:30:45",
+					Data:   map[string]string{"mac": "00-1a-2b-3c-4d-5e"},
+				}},
+				Owner: &domain.NetworkOwner{Handle: "NET-10"},
+			},
+			{
+				IP:        "fe80::1",
+				Hostnames: []string{"web.corp.local"},
+				Notes:     []domain.Note{{Text: "talks to db.corp.local at 10.0.0.5 and ::1"}},
+			},
+		},
+	}
+
+	anonymized := domain.AnonymizeResult(result)
+
+	db, web := anonymized.Hosts[0], anonymized.Hosts[1]
+	assert.Equal(t, "240.0.0.1", db.IP)
+	assert.Equal(t, []string{"host-1.invalid"}, db.Hostnames)
+	assert.Equal(t, "2001:db8::2", web.IP)
+	assert.Equal(t, []string{"host-2.invalid"}, web.Hostnames)
+	assert.Nil(t, db.Owner)
+
+	// Values are replaced consistently wherever they appear in the result
+	assert.Equal(t, "nmap -sV 240.0.0.3/24", anonymized.Command)
+	require.Len(t, db.Scripts, 1)
+	assert.Equal(t, "NetBIOS MAC: 02:00:00:00:00:01, seen by host-1.invalid via 240.0.0.4 at 12:30:45", db.Scripts[0].Output)
+	assert.Equal(t, "02:00:00:00:00:01", db.Scripts[0].Data["mac"])
+	assert.Equal(t, "talks to host-1.invalid at 240.0.0.1 and ::1", web.Notes[0].Text)
+	assert.Equal(t, "8.9.1.2", db.Ports[0].Version)
+
+	// The original result is left unchanged
+	assert.Equal(t, "10.0.0.5", result.Hosts[0].IP)
+	assert.Equal(t, "db.corp.local", result.Hosts[0].Hostnames[0])
+	assert.Equal(t, "00-1a-2b-3c-4d-5e", result.Hosts[0].Scripts[0].Data["mac"])
+	assert.NotNil(t, result.Hosts[0].Owner)
+}
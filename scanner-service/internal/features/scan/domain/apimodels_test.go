@@ -0,0 +1,126 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/shared-lib/apimodels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertRoundTrip checks that value survives decoding into and encoding from the shared
+// API model, so fields added to the service are added to the shared models as well
+func assertRoundTrip[T any](t *testing.T, value any) {
+	t.Helper()
+
+	encoded, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	var model T
+	require.NoError(t, json.Unmarshal(encoded, &model))
+	reencoded, err := json.Marshal(model)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(encoded), string(reencoded))
+}
+
+func TestAPIModelsMatchScan(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	started, completed, deleted := now.Add(time.Second), now.Add(time.Minute), now.Add(time.Hour)
+	retries := 2
+
+	assertRoundTrip[apimodels.Scan](t, domain.Scan{
+		ID:       "scan-1",
+		UserID:   "user-1",
+		TenantID: "tenant-1",
+		Options: domain.ScanOptions{
+			Target:           "example.com",
+			Ports:            "22,80",
+			ScanType:         domain.ScanTypeConnect,
+			TimingTemplate:   domain.TimingAggressive,
+			ServiceDetection: true,
+			OSDetection:      true,
+			ScriptScan:       true,
+			ExtraOptions:     []string{"--reason"},
+			Timeout:          5 * time.Minute,
+			HostTimeout:      time.Minute,
+			MinRate:          10,
+			MaxRate:          100,
+			MaxParallelism:   5,
+			MaxRetries:       &retries,
+			Decoys:           []string{"10.0.0.9"},
+			SourcePort:       53,
+			Fragment:         true,
+			DataLength:       16,
+			Discovery:        []domain.DiscoveryMethod{domain.DiscoveryCT},
+			Agent:            "agent-1",
+			Engine:           domain.ScanEngineHybrid,
+			Mode:             domain.ScanModeFast,
+		},
+		Status:              domain.ScanStatusFailed,
+		Progress:            40,
+		CurrentPhase:        domain.ScanPhaseServiceDetection,
+		EstimatedCompletion: &completed,
+		CreatedAt:           now,
+		StartedAt:           &started,
+		CompletedAt:         &completed,
+		Error:               "error",
+		Failure:             &domain.ScanFailure{Type: errors.ErrPermissionDenied, Hint: "hint", Command: "nmap -sS 10.0.0.1", ExitCode: 1, Stderr: "QUITTING!"},
+		ResultID:            "result-1",
+		RequestID:           "request-1",
+		Discovery: &domain.DiscoveryResult{
+			Domain:  "example.com",
+			Hosts:   []domain.DiscoveredHost{{Name: "www.example.com", Addresses: []string{"10.0.0.1"}, Sources: []domain.DiscoveryMethod{domain.DiscoveryCT}}},
+			Targets: []string{"10.0.0.1"},
+			Skipped: []string{"10.0.0.2"},
+			Errors:  []string{"zone transfer refused"},
+		},
+		PipelineID:    "pipeline-1",
+		WorkflowRunID: "run-1",
+		ParentID:      "scan-0",
+		ShardCount:    2,
+		Resumable:     true,
+		DeletedAt:     &deleted,
+		Notes:         []domain.Note{{ID: "note-1", UserID: "user-1", Text: "note", CreatedAt: now}},
+	})
+}
+
+func TestAPIModelsMatchScanResult(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assertRoundTrip[apimodels.ScanResult](t, domain.ScanResult{
+		ID:         "result-1",
+		ScanID:     "scan-1",
+		UserID:     "user-1",
+		StartTime:  now,
+		EndTime:    now.Add(time.Minute),
+		Duration:   60,
+		Command:    "nmap -sT example.com",
+		Summary:    "1 host up",
+		TotalHosts: 1,
+		UpHosts:    1,
+		Partial:    true,
+		Hosts: []domain.Host{{
+			IP:        "10.0.0.1",
+			Hostnames: []string{"www.example.com"},
+			Status:    "up",
+			OS:        "Linux",
+			Ports:     []domain.Port{{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH", Version: "9.6", ExtraInfo: "protocol 2.0"}},
+			Scripts:   []domain.Script{{ID: "ssh-hostkey", Output: "key", Data: map[string]string{"type": "ed25519"}}},
+			Metadata:  domain.HostMetadata{Distance: 2, UpTime: 3600, LastBoot: now, TCPSequence: "random", IPIDSequence: "all zeros"},
+			Geo: &domain.GeoInfo{
+				CountryCode: "DE", Country: "Germany", City: "Berlin", Latitude: 52.5, Longitude: 13.4,
+				ASN: 64500, ASOrganization: "Example AS", ISP: "Example ISP",
+			},
+			Owner: &domain.NetworkOwner{
+				Handle: "NET-1", Name: "EXAMPLE", StartAddress: "10.0.0.0", EndAddress: "10.0.0.255", Country: "DE",
+				Organization: "Example", AbuseEmails: []string{"abuse@example.com"}, Source: "https://rdap.example.com/ip/10.0.0.1",
+			},
+			Notes: []domain.Note{{ID: "note-1", UserID: "user-1", Text: "note", CreatedAt: now}},
+		}},
+	})
+}
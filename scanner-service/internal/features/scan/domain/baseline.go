@@ -0,0 +1,406 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// baselineProtocols lists the protocols a baseline can be restricted to
+var baselineProtocols = []string{"tcp", "udp", "sctp"}
+
+// Baseline represents the expected state of a target or a group of targets: the
+// only ports their hosts should have open. Every other open port found by a
+// completed scan of the owner is a violation.
+type Baseline struct {
+	ID         string              `json:"id"`                   // Unique identifier
+	UserID     string              `json:"user_id"`              // User who owns the baseline
+	Name       string              `json:"name"`                 // Display name
+	Target     string              `json:"target"`               // Addresses, networks, ranges and host names the baseline applies to
+	OpenPorts  []int               `json:"open_ports"`           // Ports expected open, e.g. 22 and 443
+	Protocol   string              `json:"protocol,omitempty"`   // Only check open ports of this protocol, empty for all
+	Violations []BaselineViolation `json:"violations"`           // Current violations, by the latest scan of each host
+	CheckedAt  *time.Time          `json:"checked_at,omitempty"` // When a scan result was last compared against the baseline
+	CreatedAt  time.Time           `json:"created_at"`           // When the baseline was created
+	UpdatedAt  time.Time           `json:"updated_at"`           // When the baseline was last changed
+}
+
+// BaselineViolation represents an open port of a host that its baseline does not expect
+type BaselineViolation struct {
+	BaselineID   string    `json:"baseline_id"`   // Violated baseline
+	BaselineName string    `json:"baseline_name"` // Name of the baseline
+	ScanID       string    `json:"scan_id"`       // Scan that found the port open
+	Host         string    `json:"host"`          // IP address of the host
+	Port         int       `json:"port"`          // Port number
+	Protocol     string    `json:"protocol"`      // Protocol (tcp/udp)
+	Service      string    `json:"service"`       // Service name detected by nmap
+	DetectedAt   time.Time `json:"detected_at"`   // When the scan completed
+}
+
+// Copy returns a copy of the baseline that does not share its ports and violations
+func (b *Baseline) Copy() *Baseline {
+	baselineCopy := *b
+	baselineCopy.OpenPorts = slices.Clone(b.OpenPorts)
+	baselineCopy.Violations = slices.Clone(b.Violations)
+	return &baselineCopy
+}
+
+// validate checks the baseline and normalizes its name, target and protocol
+func (b *Baseline) validate() error {
+	b.Name = strings.TrimSpace(b.Name)
+	if b.Name == "" {
+		return errors.NewInvalidField("name", "required", "name is required")
+	}
+
+	target, err := NormalizeTarget(b.Target)
+	if err != nil {
+		return errors.WithField(err, "target", "format")
+	}
+	b.Target = target
+
+	for _, port := range b.OpenPorts {
+		if port < 1 || port > 65535 {
+			return errors.NewInvalidField("open_ports", "port", fmt.Sprintf("invalid port: %d", port))
+		}
+	}
+	b.OpenPorts = slices.Compact(slices.Sorted(slices.Values(b.OpenPorts)))
+	if b.OpenPorts == nil {
+		b.OpenPorts = []int{}
+	}
+
+	b.Protocol = strings.ToLower(b.Protocol)
+	if b.Protocol != "" && !slices.Contains(baselineProtocols, b.Protocol) {
+		return errors.NewInvalidField("protocol", "oneof", "invalid protocol: "+b.Protocol)
+	}
+
+	return nil
+}
+
+// Covers reports whether a host is one of the targets of the baseline
+func (b *Baseline) Covers(host Host) bool {
+	ip := net.ParseIP(host.IP)
+	for _, item := range utils.SplitTargets(b.Target) {
+		if ip != nil && targetItemContains(item, ip) {
+			return true
+		}
+		for _, hostname := range host.Hostnames {
+			if strings.EqualFold(strings.TrimSuffix(hostname, "."), item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check returns the violations of a host covered by the baseline: its open ports
+// of the baseline protocol that the baseline does not expect
+func (b *Baseline) Check(scanID string, host Host, detectedAt time.Time) []BaselineViolation {
+	var violations []BaselineViolation
+	for _, port := range host.Ports {
+		if port.State != "open" || slices.Contains(b.OpenPorts, port.Port) {
+			continue
+		}
+		if b.Protocol != "" && !strings.EqualFold(b.Protocol, port.Protocol) {
+			continue
+		}
+		violations = append(violations, BaselineViolation{
+			BaselineID:   b.ID,
+			BaselineName: b.Name,
+			ScanID:       scanID,
+			Host:         host.IP,
+			Port:         port.Port,
+			Protocol:     port.Protocol,
+			Service:      port.Service,
+			DetectedAt:   detectedAt,
+		})
+	}
+	return violations
+}
+
+// targetItemContains reports whether a normalized target item (address, network or
+// address range) contains an IP address
+func targetItemContains(item string, ip net.IP) bool {
+	if itemIP := net.ParseIP(item); itemIP != nil {
+		return itemIP.Equal(ip)
+	}
+	if _, network, err := net.ParseCIDR(item); err == nil {
+		return network.Contains(ip)
+	}
+
+	dash := strings.Index(item, "-")
+	if dash <= 0 {
+		return false
+	}
+	start := net.ParseIP(item[:dash])
+	if start == nil {
+		return false
+	}
+	end := net.ParseIP(item[dash+1:])
+	if end == nil {
+		// Range up to a last octet
+		end = net.ParseIP(item[:strings.LastIndex(item[:dash], ".")+1] + item[dash+1:])
+		if end == nil {
+			return false
+		}
+	}
+	if (start.To4() == nil) != (ip.To4() == nil) {
+		return false
+	}
+	return compareIP(start, ip) <= 0 && compareIP(ip, end) <= 0
+}
+
+// compareIP compares two IP addresses of the same family
+func compareIP(a, b net.IP) int {
+	return slices.Compare(a.To16(), b.To16())
+}
+
+// sortViolations sorts violations by host address, port and protocol
+func sortViolations(violations []BaselineViolation) {
+	slices.SortStableFunc(violations, func(a, b BaselineViolation) int {
+		if a.Host != b.Host {
+			if c := compareIP(net.ParseIP(a.Host), net.ParseIP(b.Host)); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Host, b.Host)
+		}
+		return cmp.Or(cmp.Compare(a.Port, b.Port), strings.Compare(a.Protocol, b.Protocol))
+	})
+}
+
+// CreateBaseline stores a new baseline of a user. The caller must have the operator role.
+func (s *ScanService) CreateBaseline(ctx context.Context, userID string, baseline Baseline) (*Baseline, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	if err := baseline.validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	baseline.ID = uuid.New().String()
+	baseline.UserID = userID
+	baseline.Violations = []BaselineViolation{}
+	baseline.CheckedAt = nil
+	baseline.CreatedAt = now
+	baseline.UpdatedAt = now
+
+	if err := s.repository.SaveBaseline(&baseline); err != nil {
+		return nil, errors.NewInternal("failed to save baseline", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Baseline created",
+		zap.String("baseline_id", baseline.ID),
+		zap.String("user_id", userID),
+		zap.String("target", baseline.Target),
+	)
+
+	return &baseline, nil
+}
+
+// UpdateBaseline replaces the name, target, ports and protocol of a baseline. Its
+// violations are cleared until the next scan of its hosts.
+// The caller must have the operator role and own the baseline, or be an admin.
+func (s *ScanService) UpdateBaseline(ctx context.Context, id string, baseline Baseline) (*Baseline, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := baseline.validate(); err != nil {
+		return nil, err
+	}
+
+	s.baselineMu.Lock()
+	defer s.baselineMu.Unlock()
+
+	existing, err := s.repository.GetBaselineByID(id)
+	if err != nil || !principal.CanAccess(existing.UserID) {
+		return nil, errors.NewNotFound("baseline not found", err)
+	}
+
+	baseline.ID = existing.ID
+	baseline.UserID = existing.UserID
+	baseline.Violations = []BaselineViolation{}
+	baseline.CheckedAt = nil
+	baseline.CreatedAt = existing.CreatedAt
+	baseline.UpdatedAt = time.Now()
+
+	if err := s.repository.SaveBaseline(&baseline); err != nil {
+		return nil, errors.NewInternal("failed to save baseline", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Baseline updated",
+		zap.String("baseline_id", id),
+		zap.String("updated_by", principal.UserID),
+	)
+
+	return &baseline, nil
+}
+
+// GetBaseline gets a baseline by ID.
+// Baselines owned by other users are reported as not found unless the caller is an admin.
+func (s *ScanService) GetBaseline(ctx context.Context, id string) (*Baseline, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	baseline, err := s.repository.GetBaselineByID(id)
+	if err != nil || !principal.CanAccess(baseline.UserID) {
+		return nil, errors.NewNotFound("baseline not found", err)
+	}
+
+	return baseline, nil
+}
+
+// ListBaselines lists the baselines of a user by name.
+// Only admins may list other users' baselines or all baselines (empty userID).
+func (s *ScanService) ListBaselines(ctx context.Context, userID string) ([]*Baseline, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != principal.UserID && !principal.IsAdmin() {
+		return nil, errors.NewForbidden("cannot list baselines of other users", nil)
+	}
+
+	baselines, err := s.repository.ListBaselines(userID)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list baselines", err)
+	}
+
+	return baselines, nil
+}
+
+// DeleteBaseline deletes a baseline.
+// The caller must have the operator role and own the baseline, or be an admin.
+func (s *ScanService) DeleteBaseline(ctx context.Context, id string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
+	s.baselineMu.Lock()
+	defer s.baselineMu.Unlock()
+
+	baseline, err := s.repository.GetBaselineByID(id)
+	if err != nil || !principal.CanAccess(baseline.UserID) {
+		return errors.NewNotFound("baseline not found", err)
+	}
+
+	if err := s.repository.DeleteBaseline(id); err != nil {
+		return errors.NewInternal("failed to delete baseline", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Baseline deleted",
+		zap.String("baseline_id", id),
+		zap.String("deleted_by", principal.UserID),
+	)
+
+	return nil
+}
+
+// ListBaselineViolations lists the current violations of the baselines of a user,
+// by host and port. Only admins may list the violations of other users' baselines
+// or of all baselines (empty userID).
+func (s *ScanService) ListBaselineViolations(ctx context.Context, userID string) ([]BaselineViolation, error) {
+	baselines, err := s.ListBaselines(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := []BaselineViolation{}
+	for _, baseline := range baselines {
+		violations = append(violations, baseline.Violations...)
+	}
+	sortViolations(violations)
+
+	return violations, nil
+}
+
+// checkBaselines compares the result of a completed scan against the baselines of
+// the scan owner. The violations of the hosts the result found up replace their
+// previous violations of each baseline, and are recorded on the scan.
+func (s *ScanService) checkBaselines(ctx context.Context, scan *Scan, result *ScanResult) {
+	log := s.logger.WithContext(ctx)
+
+	s.baselineMu.Lock()
+	defer s.baselineMu.Unlock()
+
+	baselines, err := s.repository.ListBaselines(scan.UserID)
+	if err != nil {
+		log.Error("Failed to list baselines",
+			zap.String("scan_id", scan.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	checkedAt := time.Now()
+	var scanViolations []BaselineViolation
+	for _, baseline := range baselines {
+		checked := make(map[string]bool)
+		var violations []BaselineViolation
+		for _, host := range result.Hosts {
+			if host.Status != "up" || !baseline.Covers(host) {
+				continue
+			}
+			checked[host.IP] = true
+			violations = append(violations, baseline.Check(scan.ID, host, checkedAt)...)
+		}
+		if len(checked) == 0 {
+			continue
+		}
+
+		// Keep the violations of hosts the scan did not find
+		for _, violation := range baseline.Violations {
+			if !checked[violation.Host] {
+				violations = append(violations, violation)
+			}
+		}
+		sortViolations(violations)
+
+		baseline.Violations = violations
+		if baseline.Violations == nil {
+			baseline.Violations = []BaselineViolation{}
+		}
+		baseline.CheckedAt = &checkedAt
+		if err := s.repository.SaveBaseline(baseline); err != nil {
+			log.Error("Failed to save baseline",
+				zap.String("scan_id", scan.ID),
+				zap.String("baseline_id", baseline.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		for _, violation := range violations {
+			if violation.ScanID == scan.ID {
+				scanViolations = append(scanViolations, violation)
+			}
+		}
+	}
+
+	if len(scanViolations) > 0 {
+		sortViolations(scanViolations)
+		log.Warn("Scan found ports open against baselines",
+			zap.String("scan_id", scan.ID),
+			zap.Int("violations", len(scanViolations)),
+		)
+	}
+	s.mu.Lock()
+	scan.BaselineViolations = scanViolations
+	s.mu.Unlock()
+}
@@ -0,0 +1,176 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateBaseline(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	repository.On("SaveBaseline", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(new(MockScanAdapter), repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	var scanErr *errors.Error
+
+	// Viewers cannot define baselines
+	_, err := service.CreateBaseline(principalContext("alice", authdomain.RoleViewer), "alice", domain.Baseline{Name: "web", Target: "10.0.0.0/24"})
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrForbidden, scanErr.Type)
+
+	for _, baseline := range []domain.Baseline{
+		{Name: " ", Target: "10.0.0.0/24"},
+		{Name: "web", Target: "not a target!"},
+		{Name: "web", Target: "10.0.0.0/24", OpenPorts: []int{0}},
+		{Name: "web", Target: "10.0.0.0/24", Protocol: "icmp"},
+	} {
+		_, err := service.CreateBaseline(ctx, "alice", baseline)
+		require.ErrorAs(t, err, &scanErr, baseline)
+		assert.Equal(t, errors.ErrInvalidInput, scanErr.Type, baseline)
+	}
+
+	baseline, err := service.CreateBaseline(ctx, "alice", domain.Baseline{
+		Name:      " web ",
+		Target:    "10.0.0.5/24, WWW.example.com",
+		OpenPorts: []int{443, 22, 443},
+		Protocol:  "TCP",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, baseline.ID)
+	assert.Equal(t, "alice", baseline.UserID)
+	assert.Equal(t, "web", baseline.Name)
+	assert.Equal(t, "10.0.0.0/24 www.example.com", baseline.Target)
+	assert.Equal(t, []int{22, 443}, baseline.OpenPorts)
+	assert.Equal(t, "tcp", baseline.Protocol)
+	assert.Empty(t, baseline.Violations)
+
+	// Baselines of other users are reported as not found
+	repository.On("GetBaselineByID", baseline.ID).Return(baseline, nil)
+	_, err = service.GetBaseline(principalContext("bob", authdomain.RoleViewer), baseline.ID)
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+	err = service.DeleteBaseline(principalContext("bob", authdomain.RoleOperator), baseline.ID)
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+}
+
+func TestScanRecordsBaselineViolations(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	// The web servers may only expose SSH and HTTPS; 10.0.0.9 was not scanned again
+	earlier := domain.BaselineViolation{BaselineID: "baseline-1", ScanID: "scan-0", Host: "10.0.0.9", Port: 21, Protocol: "tcp"}
+	stale := domain.BaselineViolation{BaselineID: "baseline-1", ScanID: "scan-0", Host: "10.0.0.1", Port: 8080, Protocol: "tcp"}
+	web := &domain.Baseline{
+		ID:         "baseline-1",
+		UserID:     "alice",
+		Name:       "web",
+		Target:     "10.0.0.0/28 db.example.com",
+		OpenPorts:  []int{22, 443},
+		Protocol:   "tcp",
+		Violations: []domain.BaselineViolation{stale, earlier},
+	}
+	unrelated := &domain.Baseline{ID: "baseline-2", UserID: "alice", Name: "office", Target: "192.168.1.0/24", OpenPorts: []int{}}
+	repository.On("ListBaselines", "alice").Return([]*domain.Baseline{unrelated, web}, nil)
+	var saved []*domain.Baseline
+	repository.On("SaveBaseline", mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(0).(*domain.Baseline))
+	}).Return(nil)
+
+	adapter := new(MockScanAdapter)
+	adapter.On("ExecuteScan", mock.Anything, mock.Anything).Return(&domain.ScanResult{
+		ID: "result-1",
+		Hosts: []domain.Host{
+			{IP: "10.0.0.1", Status: "up", Ports: []domain.Port{
+				{Port: 22, Protocol: "tcp", State: "open", Service: "ssh"},
+				{Port: 443, Protocol: "tcp", State: "open", Service: "https"},
+				{Port: 53, Protocol: "udp", State: "open", Service: "domain"},
+			}},
+			{IP: "172.16.0.4", Hostnames: []string{"DB.example.com."}, Status: "up", Ports: []domain.Port{
+				{Port: 3306, Protocol: "tcp", State: "open", Service: "mysql"},
+				{Port: 23, Protocol: "tcp", State: "filtered"},
+			}},
+			{IP: "10.0.0.2", Status: "up", Ports: []domain.Port{
+				{Port: 80, Protocol: "tcp", State: "open", Service: "http"},
+			}},
+			{IP: "10.0.0.3", Status: "down"},
+		},
+	}, nil)
+
+	service := domain.NewScanService(adapter, repository, log, 10)
+	listener := completionListener(make(chan string, 1))
+	service.AddCompletionListener(listener)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.0/28 db.example.com", Timeout: time.Minute})
+	require.NoError(t, err)
+	repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+
+	var current *domain.Scan
+	require.Eventually(t, func() bool {
+		current, err = service.GetScan(ctx, scan.ID)
+		return err == nil && current.Status == domain.ScanStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+
+	// The scan records the unexpected open TCP ports of the covered hosts
+	require.Len(t, current.BaselineViolations, 2)
+	assert.Equal(t, "10.0.0.2", current.BaselineViolations[0].Host)
+	assert.Equal(t, 80, current.BaselineViolations[0].Port)
+	assert.Equal(t, "web", current.BaselineViolations[0].BaselineName)
+	assert.Equal(t, scan.ID, current.BaselineViolations[0].ScanID)
+	assert.Equal(t, "172.16.0.4", current.BaselineViolations[1].Host)
+	assert.Equal(t, "mysql", current.BaselineViolations[1].Service)
+
+	summary := service.CreateScanSummary(current, nil)
+	assert.Len(t, summary.BaselineViolations, 2)
+
+	// Only the baseline covering hosts of the result is updated. The violations of
+	// scanned hosts are replaced, those of other hosts are kept.
+	require.Len(t, saved, 1)
+	assert.Equal(t, "baseline-1", saved[0].ID)
+	assert.NotNil(t, saved[0].CheckedAt)
+	require.Len(t, saved[0].Violations, 3)
+	assert.Equal(t, "10.0.0.2", saved[0].Violations[0].Host)
+	assert.Equal(t, earlier, saved[0].Violations[1])
+	assert.Equal(t, "172.16.0.4", saved[0].Violations[2].Host)
+
+	// The violations endpoint lists the current violations of all baselines
+	violations, err := service.ListBaselineViolations(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, saved[0].Violations, violations)
+
+	_, err = service.ListBaselineViolations(ctx, "")
+	assert.Error(t, err)
+
+	// Completion listeners see the checked scan and its result
+	select {
+	case completed := <-listener:
+		assert.Equal(t, scan.ID+" result-1 2", completed)
+	case <-time.After(time.Second):
+		require.FailNow(t, "completion listener not called")
+	}
+}
+
+// completionListener reports the ID, result ID and number of baseline violations of
+// completed scans
+type completionListener chan string
+
+func (l completionListener) ScanCompleted(ctx context.Context, scan *domain.Scan, result *domain.ScanResult) {
+	l <- fmt.Sprintf("%s %s %d", scan.ID, result.ID, len(scan.BaselineViolations))
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// ScanTypes are all scan types, in the order clients offer them
+var ScanTypes = []ScanType{
+	ScanTypeConnect, ScanTypeSYN, ScanTypeUDP, ScanTypeVersion, ScanTypeScript, ScanTypeAll, ScanTypePing,
+}
+
+// EngineCapabilities describes the scan options an engine supports
+type EngineCapabilities struct {
+	Engine           ScanEngine `json:"engine"`
+	ScanTypes        []ScanType `json:"scan_types"`        // Scan types the engine runs
+	ServiceDetection bool       `json:"service_detection"` // Service and version detection
+	OSDetection      bool       `json:"os_detection"`      // OS detection
+	Scripts          bool       `json:"scripts"`           // NSE script scans
+	ExtraOptions     bool       `json:"extra_options"`     // Extra command-line options
+	HostTimeout      bool       `json:"host_timeout"`      // Hosts not scanned within the host timeout are skipped
+	RateControl      bool       `json:"rate_control"`      // Packet rate and parallelism can be set per scan
+	Retries          bool       `json:"retries"`           // Probe retransmissions can be set per scan
+	Evasion          bool       `json:"evasion"`           // Decoys, source port, fragmentation and padding
+	HostNames        bool       `json:"host_names"`        // Targets may be host names, not only addresses
+}
+
+// Capabilities describes the scan features the scanner supports, so clients can offer
+// only the options that work
+type Capabilities struct {
+	NmapAvailable    bool                 `json:"nmap_available"`         // Whether nmap can be run
+	NmapVersion      string               `json:"nmap_version,omitempty"` // Version of nmap, if available
+	Privileged       bool                 `json:"privileged"`             // Whether nmap may open raw sockets, needed by SYN and UDP scans, OS detection and evasion options
+	OSDetection      bool                 `json:"os_detection"`           // Whether OS detection can run
+	ScanTypes        []ScanType           `json:"scan_types"`             // Scan types that can run with the privileges of nmap
+	Engines          []EngineCapabilities `json:"engines"`                // Enabled engines
+	ScriptCategories []string             `json:"script_categories"`      // NSE script categories of the installed scripts
+}
+
+// EngineReporter is implemented by scan adapters that run scans with several engines
+type EngineReporter interface {
+	EngineCapabilities() []EngineCapabilities
+}
+
+// ScriptCategoryLister is implemented by scan adapters that know the NSE script
+// categories of the installed scripts
+type ScriptCategoryLister interface {
+	ScriptCategories() ([]string, error)
+}
+
+// GetCapabilities returns the scan features the scanner supports
+func (s *ScanService) GetCapabilities(ctx context.Context) *Capabilities {
+	capabilities := &Capabilities{
+		Privileged:       true,
+		ScanTypes:        make([]ScanType, 0, len(ScanTypes)),
+		ScriptCategories: make([]string, 0),
+	}
+
+	if version, err := s.GetNmapVersion(); err == nil {
+		capabilities.NmapAvailable = true
+		capabilities.NmapVersion = version
+	}
+	if checker, ok := s.adapter.(PrivilegeChecker); ok {
+		capabilities.Privileged = checker.RawSocketCapable()
+	}
+	capabilities.OSDetection = capabilities.Privileged
+
+	// Scans needing raw sockets fail without the privileges
+	for _, scanType := range ScanTypes {
+		if capabilities.Privileged || !(ScanOptions{ScanType: scanType}).RequiresRawSocket() {
+			capabilities.ScanTypes = append(capabilities.ScanTypes, scanType)
+		}
+	}
+
+	// Adapters not reporting their engines run nmap with every scan option
+	if reporter, ok := s.adapter.(EngineReporter); ok {
+		capabilities.Engines = reporter.EngineCapabilities()
+	}
+	if len(capabilities.Engines) == 0 {
+		capabilities.Engines = []EngineCapabilities{{
+			Engine:           ScanEngineNmap,
+			ScanTypes:        ScanTypes,
+			ServiceDetection: true,
+			OSDetection:      true,
+			Scripts:          true,
+			ExtraOptions:     true,
+			HostTimeout:      true,
+			RateControl:      true,
+			Retries:          true,
+			Evasion:          true,
+			HostNames:        true,
+		}}
+	}
+
+	if lister, ok := s.adapter.(ScriptCategoryLister); ok {
+		categories, err := lister.ScriptCategories()
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("Failed to list NSE script categories", zap.Error(err))
+		} else {
+			capabilities.ScriptCategories = categories
+		}
+	}
+
+	return capabilities
+}
@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+
+	// Adapters reporting nothing run every nmap scan type
+	adapter := new(MockScanAdapter)
+	adapter.On("GetVersion").Return("Nmap version 7.94", nil)
+	capabilities := domain.NewScanService(adapter, new(MockScanRepository), log, 10).GetCapabilities(context.Background())
+	assert.True(t, capabilities.NmapAvailable)
+	assert.Equal(t, "Nmap version 7.94", capabilities.NmapVersion)
+	assert.True(t, capabilities.Privileged)
+	assert.True(t, capabilities.OSDetection)
+	assert.Equal(t, domain.ScanTypes, capabilities.ScanTypes)
+	assert.Len(t, capabilities.Engines, 1)
+	assert.Equal(t, domain.ScanEngineNmap, capabilities.Engines[0].Engine)
+	assert.Empty(t, capabilities.ScriptCategories)
+
+	// Without raw sockets, scans needing them are not offered
+	unprivileged := new(unprivilegedScanAdapter)
+	unprivileged.On("GetVersion").Return("Nmap version 7.94", nil)
+	capabilities = domain.NewScanService(unprivileged, new(MockScanRepository), log, 10).GetCapabilities(context.Background())
+	assert.False(t, capabilities.Privileged)
+	assert.False(t, capabilities.OSDetection)
+	assert.Equal(t, []domain.ScanType{domain.ScanTypeConnect, domain.ScanTypeVersion, domain.ScanTypeScript, domain.ScanTypePing}, capabilities.ScanTypes)
+}
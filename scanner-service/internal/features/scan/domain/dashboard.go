@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// MaxSurfaceEntries is the maximum number of entries returned per attack surface ranking
+const MaxSurfaceEntries = 100
+
+// SurfaceCount represents a service or product with the number of hosts exposing it
+type SurfaceCount struct {
+	Name  string `json:"name"`  // Service or product name
+	Hosts int    `json:"hosts"` // Number of distinct hosts
+}
+
+// PortCount represents an open port with the number of hosts exposing it
+type PortCount struct {
+	Port     int    `json:"port"`     // Port number
+	Protocol string `json:"protocol"` // Protocol (tcp/udp)
+	Service  string `json:"service"`  // Most common service name on the port
+	Hosts    int    `json:"hosts"`    // Number of distinct hosts
+}
+
+// AttackSurface represents the open services, products and ports across stored results
+type AttackSurface struct {
+	Results     int            `json:"results"`      // Number of aggregated results
+	Hosts       int            `json:"hosts"`        // Distinct hosts with at least one open port
+	OpenPorts   int            `json:"open_ports"`   // Distinct host/port pairs that are open
+	TopServices []SurfaceCount `json:"top_services"` // Most exposed services
+	TopProducts []SurfaceCount `json:"top_products"` // Most exposed products
+	TopPorts    []PortCount    `json:"top_ports"`    // Most exposed ports
+}
+
+// SurfaceAggregator accumulates scan results into an AttackSurface.
+// Hosts are identified by IP, so a host seen in several results is counted once.
+type SurfaceAggregator struct {
+	results  int
+	hosts    map[string]bool
+	open     map[string]bool
+	services map[string]map[string]bool
+	products map[string]map[string]bool
+	ports    map[string]*portAggregate
+}
+
+// portAggregate tracks the hosts and service names seen on one port
+type portAggregate struct {
+	port     int
+	protocol string
+	hosts    map[string]bool
+	services map[string]int
+}
+
+// NewSurfaceAggregator creates an empty SurfaceAggregator
+func NewSurfaceAggregator() *SurfaceAggregator {
+	return &SurfaceAggregator{
+		hosts:    make(map[string]bool),
+		open:     make(map[string]bool),
+		services: make(map[string]map[string]bool),
+		products: make(map[string]map[string]bool),
+		ports:    make(map[string]*portAggregate),
+	}
+}
+
+// Add adds the open ports of a scan result
+func (a *SurfaceAggregator) Add(result *ScanResult) {
+	a.results++
+
+	for _, host := range result.Hosts {
+		for _, port := range host.Ports {
+			if port.State != "open" {
+				continue
+			}
+
+			key := fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+			a.hosts[host.IP] = true
+			a.open[host.IP+" "+key] = true
+
+			if port.Service != "" {
+				addHost(a.services, port.Service, host.IP)
+			}
+			if port.Product != "" {
+				addHost(a.products, port.Product, host.IP)
+			}
+
+			agg, ok := a.ports[key]
+			if !ok {
+				agg = &portAggregate{
+					port:     port.Port,
+					protocol: port.Protocol,
+					hosts:    make(map[string]bool),
+					services: make(map[string]int),
+				}
+				a.ports[key] = agg
+			}
+			agg.hosts[host.IP] = true
+			if port.Service != "" {
+				agg.services[port.Service]++
+			}
+		}
+	}
+}
+
+// Surface returns the aggregated attack surface with at most limit entries per ranking
+func (a *SurfaceAggregator) Surface(limit int) *AttackSurface {
+	surface := &AttackSurface{
+		Results:     a.results,
+		Hosts:       len(a.hosts),
+		OpenPorts:   len(a.open),
+		TopServices: topCounts(a.services, limit),
+		TopProducts: topCounts(a.products, limit),
+		TopPorts:    make([]PortCount, 0, len(a.ports)),
+	}
+
+	for _, agg := range a.ports {
+		surface.TopPorts = append(surface.TopPorts, PortCount{
+			Port:     agg.port,
+			Protocol: agg.protocol,
+			Service:  mostCommon(agg.services),
+			Hosts:    len(agg.hosts),
+		})
+	}
+	sort.Slice(surface.TopPorts, func(i, j int) bool {
+		a, b := surface.TopPorts[i], surface.TopPorts[j]
+		if a.Hosts != b.Hosts {
+			return a.Hosts > b.Hosts
+		}
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		return a.Protocol < b.Protocol
+	})
+	if len(surface.TopPorts) > limit {
+		surface.TopPorts = surface.TopPorts[:limit]
+	}
+
+	return surface
+}
+
+// addHost records that a host exposes the named service or product
+func addHost(counts map[string]map[string]bool, name, ip string) {
+	if counts[name] == nil {
+		counts[name] = make(map[string]bool)
+	}
+	counts[name][ip] = true
+}
+
+// topCounts returns the names exposed by the most hosts
+func topCounts(counts map[string]map[string]bool, limit int) []SurfaceCount {
+	top := make([]SurfaceCount, 0, len(counts))
+	for name, hosts := range counts {
+		top = append(top, SurfaceCount{Name: name, Hosts: len(hosts)})
+	}
+
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Hosts != top[j].Hosts {
+			return top[i].Hosts > top[j].Hosts
+		}
+		return top[i].Name < top[j].Name
+	})
+
+	if len(top) > limit {
+		top = top[:limit]
+	}
+	return top
+}
+
+// mostCommon returns the most frequent name, preferring the alphabetically first on ties
+func mostCommon(counts map[string]int) string {
+	var best string
+	for name, count := range counts {
+		if best == "" || count > counts[best] || (count == counts[best] && name < best) {
+			best = name
+		}
+	}
+	return best
+}
+
+// GetAttackSurface aggregates the open services, products and ports of stored results.
+// Only admins may aggregate other users' results or all results (empty userID).
+func (s *ScanService) GetAttackSurface(ctx context.Context, userID string, limit int) (*AttackSurface, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != principal.UserID && !principal.IsAdmin() {
+		return nil, errors.NewForbidden("cannot view the attack surface of other users", nil)
+	}
+
+	if limit < 1 || limit > MaxSurfaceEntries {
+		return nil, errors.NewInvalidInput(fmt.Sprintf("limit must be between 1 and %d", MaxSurfaceEntries), nil)
+	}
+
+	surface, err := s.repository.AggregateSurface(userID, limit)
+	if err != nil {
+		return nil, errors.NewInternal("failed to aggregate scan results", err)
+	}
+
+	return surface, nil
+}
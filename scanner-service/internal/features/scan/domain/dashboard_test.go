@@ -0,0 +1,72 @@
+package domain_test
+
+import (
+	"testing"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSurfaceAggregator(t *testing.T) {
+	aggregator := domain.NewSurfaceAggregator()
+	aggregator.Add(&domain.ScanResult{Hosts: []domain.Host{
+		{IP: "10.0.0.1", Ports: []domain.Port{
+			{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH"},
+			{Port: 80, Protocol: "tcp", State: "open", Service: "http", Product: "nginx"},
+		}},
+		{IP: "10.0.0.2", Ports: []domain.Port{
+			{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH"},
+			{Port: 443, Protocol: "tcp", State: "closed", Service: "https"},
+		}},
+	}})
+	// The same host in a later result is counted once
+	aggregator.Add(&domain.ScanResult{Hosts: []domain.Host{
+		{IP: "10.0.0.1", Ports: []domain.Port{
+			{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH"},
+		}},
+	}})
+
+	surface := aggregator.Surface(10)
+	assert.Equal(t, 2, surface.Results)
+	assert.Equal(t, 2, surface.Hosts)
+	assert.Equal(t, 3, surface.OpenPorts)
+	assert.Equal(t, []domain.SurfaceCount{{Name: "ssh", Hosts: 2}, {Name: "http", Hosts: 1}}, surface.TopServices)
+	assert.Equal(t, []domain.SurfaceCount{{Name: "OpenSSH", Hosts: 2}, {Name: "nginx", Hosts: 1}}, surface.TopProducts)
+	assert.Equal(t, []domain.PortCount{
+		{Port: 22, Protocol: "tcp", Service: "ssh", Hosts: 2},
+		{Port: 80, Protocol: "tcp", Service: "http", Hosts: 1},
+	}, surface.TopPorts)
+
+	limited := aggregator.Surface(1)
+	assert.Len(t, limited.TopServices, 1)
+	assert.Len(t, limited.TopPorts, 1)
+}
+
+func TestGetAttackSurface(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	mockRepository := new(MockScanRepository)
+	service := domain.NewScanService(new(MockScanAdapter), mockRepository, log, 10)
+
+	expected := &domain.AttackSurface{Results: 1}
+	mockRepository.On("AggregateSurface", "alice", 10).Return(expected, nil)
+	mockRepository.On("AggregateSurface", "", 10).Return(expected, nil)
+
+	surface, err := service.GetAttackSurface(principalContext("alice", authdomain.RoleViewer), "alice", 10)
+	require.NoError(t, err)
+	assert.Equal(t, expected, surface)
+
+	_, err = service.GetAttackSurface(principalContext("alice", authdomain.RoleViewer), "", 10)
+	assert.Error(t, err)
+
+	_, err = service.GetAttackSurface(principalContext("admin", authdomain.RoleAdmin), "", 10)
+	assert.NoError(t, err)
+
+	_, err = service.GetAttackSurface(principalContext("alice", authdomain.RoleViewer), "alice", 0)
+	assert.Error(t, err)
+}
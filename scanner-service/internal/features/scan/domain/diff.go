@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// ResultChangeType represents a kind of difference between two scan results
+type ResultChangeType string
+
+// Result change type constants
+const (
+	ResultChangeHostAdded      ResultChangeType = "host_added"      // A host was found up that was not before
+	ResultChangeHostRemoved    ResultChangeType = "host_removed"    // A host found up before was not found
+	ResultChangePortOpened     ResultChangeType = "port_opened"     // A port of a host was found open that was not before
+	ResultChangePortClosed     ResultChangeType = "port_closed"     // A port open before was not found open
+	ResultChangeServiceChanged ResultChangeType = "service_changed" // The service, product or version of an open port changed
+)
+
+// ResultChangeTypes lists the change types in the order changes of a host are reported
+var ResultChangeTypes = []ResultChangeType{ResultChangeHostAdded, ResultChangeHostRemoved, ResultChangePortOpened, ResultChangePortClosed, ResultChangeServiceChanged}
+
+// ResultChange represents a difference between two scan results
+type ResultChange struct {
+	Type     ResultChangeType `json:"type"`               // Kind of change
+	Host     string           `json:"host"`               // IP address of the host
+	Port     int              `json:"port,omitempty"`     // Port number, absent for host changes
+	Protocol string           `json:"protocol,omitempty"` // Protocol (tcp/udp), absent for host changes
+	Before   string           `json:"before,omitempty"`   // Service, product and version before the change
+	After    string           `json:"after,omitempty"`    // Service, product and version after the change
+}
+
+// portKey identifies an open port of a host
+type portKey struct {
+	port     int
+	protocol string
+}
+
+// DiffResults returns the changes from one scan result to another, by host, port and
+// change type. Only hosts found up and their open ports are compared.
+func DiffResults(before, after *ScanResult) []ResultChange {
+	oldHosts, newHosts := openServices(before), openServices(after)
+
+	var changes []ResultChange
+	for ip, oldPorts := range oldHosts {
+		newPorts, ok := newHosts[ip]
+		if !ok {
+			changes = append(changes, ResultChange{Type: ResultChangeHostRemoved, Host: ip})
+			continue
+		}
+		for key, service := range oldPorts {
+			if _, ok := newPorts[key]; !ok {
+				changes = append(changes, ResultChange{Type: ResultChangePortClosed, Host: ip, Port: key.port, Protocol: key.protocol, Before: service})
+			}
+		}
+	}
+	for ip, newPorts := range newHosts {
+		oldPorts, ok := oldHosts[ip]
+		if !ok {
+			changes = append(changes, ResultChange{Type: ResultChangeHostAdded, Host: ip})
+		}
+		for key, service := range newPorts {
+			oldService, open := oldPorts[key]
+			switch {
+			case !open:
+				changes = append(changes, ResultChange{Type: ResultChangePortOpened, Host: ip, Port: key.port, Protocol: key.protocol, After: service})
+			case oldService != service:
+				changes = append(changes, ResultChange{Type: ResultChangeServiceChanged, Host: ip, Port: key.port, Protocol: key.protocol, Before: oldService, After: service})
+			}
+		}
+	}
+
+	// Host changes come before the port changes of the host
+	slices.SortFunc(changes, func(a, b ResultChange) int {
+		if a.Host != b.Host {
+			return compareHosts(a.Host, b.Host)
+		}
+		return cmp.Or(
+			cmp.Compare(a.Port, b.Port),
+			strings.Compare(a.Protocol, b.Protocol),
+			cmp.Compare(slices.Index(ResultChangeTypes, a.Type), slices.Index(ResultChangeTypes, b.Type)),
+		)
+	})
+	return changes
+}
+
+// openServices maps the IP of every host of a result found up to its open ports and
+// their service descriptions
+func openServices(result *ScanResult) map[string]map[portKey]string {
+	services := make(map[string]map[portKey]string)
+	for _, host := range result.Hosts {
+		if host.Status != "up" {
+			continue
+		}
+		ports := make(map[portKey]string)
+		for _, port := range host.Ports {
+			if port.State != "open" {
+				continue
+			}
+			ports[portKey{port.Port, port.Protocol}] = strings.Join(strings.Fields(strings.Join([]string{port.Service, port.Product, port.Version}, " ")), " ")
+		}
+		services[host.IP] = ports
+	}
+	return services
+}
+
+// compareHosts orders IP addresses numerically, and other values after them as text
+func compareHosts(a, b string) int {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	switch {
+	case errA == nil && errB == nil:
+		return addrA.Compare(addrB)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// String describes the change for alert messages
+func (c ResultChange) String() string {
+	port := fmt.Sprintf("%s:%d/%s", c.Host, c.Port, c.Protocol)
+	switch c.Type {
+	case ResultChangeHostAdded:
+		return "new host " + c.Host
+	case ResultChangeHostRemoved:
+		return "host " + c.Host + " no longer found"
+	case ResultChangePortOpened:
+		return strings.TrimSpace("new open port " + port + " " + c.After)
+	case ResultChangePortClosed:
+		return "port " + port + " no longer open"
+	case ResultChangeServiceChanged:
+		return fmt.Sprintf("service of %s changed from %q to %q", port, c.Before, c.After)
+	}
+	return string(c.Type) + " " + c.Host
+}
@@ -0,0 +1,52 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffResults(t *testing.T) {
+	before := &domain.ScanResult{Hosts: []domain.Host{
+		{IP: "10.0.0.10", Status: "up", Ports: []domain.Port{
+			{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH", Version: "8.9"},
+			{Port: 80, Protocol: "tcp", State: "open", Service: "http"},
+			{Port: 443, Protocol: "tcp", State: "closed"},
+		}},
+		{IP: "10.0.0.2", Status: "up", Ports: []domain.Port{
+			{Port: 53, Protocol: "udp", State: "open", Service: "domain"},
+		}},
+		{IP: "10.0.0.3", Status: "down"},
+	}}
+	after := &domain.ScanResult{Hosts: []domain.Host{
+		{IP: "10.0.0.3", Status: "up", Ports: []domain.Port{
+			{Port: 3389, Protocol: "tcp", State: "open", Service: "ms-wbt-server"},
+		}},
+		{IP: "10.0.0.10", Status: "up", Ports: []domain.Port{
+			{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH", Version: "9.6"},
+			{Port: 80, Protocol: "tcp", State: "filtered", Service: "http"},
+			{Port: 443, Protocol: "tcp", State: "open", Service: "https"},
+		}},
+	}}
+
+	// Changes are ordered by host address, port and change type
+	assert.Equal(t, []domain.ResultChange{
+		{Type: domain.ResultChangeHostRemoved, Host: "10.0.0.2"},
+		{Type: domain.ResultChangeHostAdded, Host: "10.0.0.3"},
+		{Type: domain.ResultChangePortOpened, Host: "10.0.0.3", Port: 3389, Protocol: "tcp", After: "ms-wbt-server"},
+		{Type: domain.ResultChangeServiceChanged, Host: "10.0.0.10", Port: 22, Protocol: "tcp", Before: "ssh OpenSSH 8.9", After: "ssh OpenSSH 9.6"},
+		{Type: domain.ResultChangePortClosed, Host: "10.0.0.10", Port: 80, Protocol: "tcp", Before: "http"},
+		{Type: domain.ResultChangePortOpened, Host: "10.0.0.10", Port: 443, Protocol: "tcp", After: "https"},
+	}, domain.DiffResults(before, after))
+
+	assert.Empty(t, domain.DiffResults(after, after))
+}
+
+func TestResultChangeString(t *testing.T) {
+	assert.Equal(t, "new host 10.0.0.3", domain.ResultChange{Type: domain.ResultChangeHostAdded, Host: "10.0.0.3"}.String())
+	assert.Equal(t, "new open port 10.0.0.3:3389/tcp ms-wbt-server",
+		domain.ResultChange{Type: domain.ResultChangePortOpened, Host: "10.0.0.3", Port: 3389, Protocol: "tcp", After: "ms-wbt-server"}.String())
+	assert.Equal(t, `service of 10.0.0.10:22/tcp changed from "ssh OpenSSH 8.9" to "ssh OpenSSH 9.6"`,
+		domain.ResultChange{Type: domain.ResultChangeServiceChanged, Host: "10.0.0.10", Port: 22, Protocol: "tcp", Before: "ssh OpenSSH 8.9", After: "ssh OpenSSH 9.6"}.String())
+}
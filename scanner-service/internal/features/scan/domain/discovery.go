@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+	"go.uber.org/zap"
+)
+
+// DiscoveryMethod represents a way of finding the hosts of a domain
+type DiscoveryMethod string
+
+// Discovery method constants
+const (
+	DiscoveryZoneTransfer DiscoveryMethod = "zone_transfer" // AXFR against the authoritative name servers
+	DiscoveryBruteforce   DiscoveryMethod = "bruteforce"    // Resolution of common subdomain names
+	DiscoveryCT           DiscoveryMethod = "ct"            // Certificate Transparency log search
+)
+
+// DiscoveredHost represents a host name found by target discovery
+type DiscoveredHost struct {
+	Name      string            `json:"name"`              // Fully qualified host name
+	Addresses []string          `json:"addresses"`         // Resolved IP addresses
+	Sources   []DiscoveryMethod `json:"sources,omitempty"` // Methods that found the name, empty for the domain itself
+}
+
+// DiscoveryResult represents the outcome of the discovery stage of a scan
+type DiscoveryResult struct {
+	Domain  string           `json:"domain"`            // Domain that was expanded
+	Hosts   []DiscoveredHost `json:"hosts"`             // Host names found
+	Targets []string         `json:"targets"`           // Addresses passed to the scan
+	Skipped []string         `json:"skipped,omitempty"` // Addresses left out by the blocklist or target scope
+	Errors  []string         `json:"errors,omitempty"`  // Failures of individual methods
+}
+
+// TargetDiscoverer defines the interface for expanding a domain into concrete hosts
+type TargetDiscoverer interface {
+	Discover(ctx context.Context, domainName string, methods []DiscoveryMethod) (*DiscoveryResult, error)
+}
+
+// ParseDiscoveryMethod parses a discovery method case-insensitively
+func ParseDiscoveryMethod(value string) (DiscoveryMethod, bool) {
+	method := DiscoveryMethod(strings.ToLower(strings.TrimSpace(value)))
+	switch method {
+	case DiscoveryZoneTransfer, DiscoveryBruteforce, DiscoveryCT:
+		return method, true
+	}
+	return "", false
+}
+
+// IsDomainName reports whether the target is a single DNS domain name rather than
+// an address, network or list of targets
+func IsDomainName(target string) bool {
+	name := strings.TrimSuffix(target, ".")
+	return strings.Contains(name, ".") && net.ParseIP(name) == nil && utils.ValidateHostname(name)
+}
+
+// validateDiscovery checks the discovery options of a scan
+func (s *ScanService) validateDiscovery(options ScanOptions) error {
+	if len(options.Discovery) == 0 {
+		return nil
+	}
+
+	if s.discoverer == nil {
+		return errors.NewInvalidInput("target discovery is not enabled", nil)
+	}
+	if !IsDomainName(options.Target) {
+		return errors.NewInvalidInput("target discovery requires a single domain name as target", nil)
+	}
+	for _, method := range options.Discovery {
+		if _, ok := ParseDiscoveryMethod(string(method)); !ok {
+			return errors.NewInvalidInput(fmt.Sprintf("unknown discovery method %q", method), nil)
+		}
+	}
+
+	return nil
+}
+
+// discoverTargets runs the discovery stage of a scan and returns the scan options
+// with the target replaced by the discovered addresses.
+// Addresses rejected by the blocklist or the caller's target scope are skipped.
+func (s *ScanService) discoverTargets(ctx context.Context, scan *Scan) (ScanOptions, error) {
+	options := scan.Options
+	log := s.logger.WithContext(ctx)
+
+	log.Info("Starting target discovery",
+		zap.String("scan_id", scan.ID),
+		zap.String("domain", options.Target),
+	)
+
+	result, err := s.discoverer.Discover(ctx, options.Target, options.Discovery)
+	if err != nil {
+		return options, fmt.Errorf("target discovery failed: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, host := range result.Hosts {
+		for _, address := range host.Addresses {
+			if seen[address] {
+				continue
+			}
+			seen[address] = true
+
+			if err := s.checkDiscoveredTarget(ctx, address); err != nil {
+				log.Warn("Discovered target skipped",
+					zap.String("scan_id", scan.ID),
+					zap.String("host", host.Name),
+					zap.String("address", address),
+					zap.Error(err),
+				)
+				result.Skipped = append(result.Skipped, address)
+				continue
+			}
+			result.Targets = append(result.Targets, address)
+		}
+	}
+
+	s.mu.Lock()
+	scan.Discovery = result
+	s.mu.Unlock()
+
+	log.Info("Target discovery completed",
+		zap.String("scan_id", scan.ID),
+		zap.Int("hosts", len(result.Hosts)),
+		zap.Int("targets", len(result.Targets)),
+		zap.Int("skipped", len(result.Skipped)),
+	)
+
+	if len(result.Targets) == 0 {
+		return options, fmt.Errorf("target discovery found no hosts to scan")
+	}
+
+	options.Target = strings.Join(result.Targets, " ")
+	return options, nil
+}
+
+// checkDiscoveredTarget applies the checks of the scan target to a discovered address
+func (s *ScanService) checkDiscoveredTarget(ctx context.Context, address string) error {
+	if s.targetBlocklist != nil {
+		if err := s.targetBlocklist.CheckTarget(ctx, address); err != nil {
+			return err
+		}
+	}
+	if s.targetAuthorizer != nil {
+		if err := s.targetAuthorizer.AuthorizeTarget(ctx, address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
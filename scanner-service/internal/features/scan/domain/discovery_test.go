@@ -0,0 +1,133 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeDiscoverer returns fixed hosts for every domain
+type fakeDiscoverer struct {
+	hosts []domain.DiscoveredHost
+}
+
+func (f *fakeDiscoverer) Discover(ctx context.Context, domainName string, methods []domain.DiscoveryMethod) (*domain.DiscoveryResult, error) {
+	return &domain.DiscoveryResult{Domain: domainName, Hosts: f.hosts}, nil
+}
+
+// fakeBlocklist blocks a single target
+type fakeBlocklist struct {
+	blocked string
+}
+
+func (f *fakeBlocklist) CheckTarget(ctx context.Context, target string) error {
+	if target == f.blocked {
+		return errors.New("blocked")
+	}
+	return nil
+}
+
+func TestIsDomainName(t *testing.T) {
+	for target, expected := range map[string]bool{
+		"example.com":          true,
+		"dev.example.co.uk.":   true,
+		"_sip.example.com":     true,
+		"localhost":            false,
+		"192.168.1.1":          false,
+		"10.0.1":               false,
+		"192.168.1.0/24":       false,
+		"example.com 10.0.0.1": false,
+		"-bad.example.com":     false,
+		"":                     false,
+	} {
+		assert.Equal(t, expected, domain.IsDomainName(target), target)
+	}
+}
+
+func TestDiscoveryValidation(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	service := domain.NewScanService(new(MockScanAdapter), new(MockScanRepository), log, 10)
+
+	_, err := service.StartScan(ctx, "alice", domain.ScanOptions{
+		Target:    "example.com",
+		Discovery: []domain.DiscoveryMethod{domain.DiscoveryCT},
+	})
+	assert.ErrorContains(t, err, "not enabled")
+
+	service.SetTargetDiscoverer(&fakeDiscoverer{})
+
+	_, err = service.StartScan(ctx, "alice", domain.ScanOptions{
+		Target:    "192.168.1.0/24",
+		Discovery: []domain.DiscoveryMethod{domain.DiscoveryCT},
+	})
+	assert.ErrorContains(t, err, "single domain name")
+
+	_, err = service.StartScan(ctx, "alice", domain.ScanOptions{
+		Target:    "example.com",
+		Discovery: []domain.DiscoveryMethod{"whois"},
+	})
+	assert.ErrorContains(t, err, "unknown discovery method")
+}
+
+func TestScanWithDiscovery(t *testing.T) {
+	mockAdapter := new(MockScanAdapter)
+	mockRepository := new(MockScanRepository)
+
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	service := domain.NewScanService(mockAdapter, mockRepository, log, 10)
+	service.SetTargetBlocklist(&fakeBlocklist{blocked: "10.0.0.1"})
+	service.SetTargetDiscoverer(&fakeDiscoverer{hosts: []domain.DiscoveredHost{
+		{Name: "example.com", Addresses: []string{"203.0.113.10"}},
+		{Name: "www.example.com", Addresses: []string{"203.0.113.10", "203.0.113.11"}, Sources: []domain.DiscoveryMethod{domain.DiscoveryCT}},
+		{Name: "internal.example.com", Addresses: []string{"10.0.0.1"}, Sources: []domain.DiscoveryMethod{domain.DiscoveryBruteforce}},
+	}})
+
+	executed := make(chan domain.ScanOptions, 1)
+	mockRepository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	mockRepository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+	mockAdapter.On("ExecuteScan", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { executed <- args.Get(1).(domain.ScanOptions) }).
+		Return(nil, errors.New("not executed"))
+
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{
+		Target:    "example.com",
+		Timeout:   time.Minute,
+		Discovery: []domain.DiscoveryMethod{domain.DiscoveryCT, domain.DiscoveryBruteforce},
+	})
+	require.NoError(t, err)
+	mockRepository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+
+	select {
+	case options := <-executed:
+		assert.Equal(t, "203.0.113.10 203.0.113.11", options.Target)
+	case <-time.After(5 * time.Second):
+		t.Fatal("scan was not executed")
+	}
+
+	// The stored options keep the requested domain
+	var current *domain.Scan
+	require.Eventually(t, func() bool {
+		current, err = service.GetScan(ctx, scan.ID)
+		return err == nil && current.Status == domain.ScanStatusFailed
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "example.com", current.Options.Target)
+	require.NotNil(t, current.Discovery)
+	assert.Equal(t, []string{"203.0.113.10", "203.0.113.11"}, current.Discovery.Targets)
+	assert.Equal(t, []string{"10.0.0.1"}, current.Discovery.Skipped)
+}
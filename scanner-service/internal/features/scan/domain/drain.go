@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// drainPollInterval is how often Drain checks whether the active scans finished
+	drainPollInterval = 100 * time.Millisecond
+
+	// drainAbortTimeout is how long Drain waits for aborted scans to record their final state
+	drainAbortTimeout = 5 * time.Second
+
+	// shutdownAbortReason is recorded as the error of scans aborted by Drain
+	shutdownAbortReason = "aborted by service shutdown"
+)
+
+// errDraining is returned when a scan is started while the service shuts down
+var errDraining = errors.NewUnavailable("service is shutting down, no new scans are accepted", nil)
+
+// Draining reports whether the service stopped accepting new scans
+func (s *ScanService) Draining() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.draining
+}
+
+// Drain stops accepting new scans and waits until the queued and running scans finished
+// or ctx is done. Scans still active then are cancelled with the shutdown recorded as
+// their error; scans with saved progress stay resumable. The workers stop afterwards.
+// Drain returns the number of aborted scans.
+func (s *ScanService) Drain(ctx context.Context) int {
+	s.mu.Lock()
+	s.draining = true
+	s.mu.Unlock()
+
+	s.logger.Info("Draining active scans", zap.Int("active_scans", len(s.pool.activeScans())))
+
+	s.waitFor(ctx, s.pool.idle)
+
+	// Stop the workers once the aborted scans recorded whether they can be resumed
+	abortCtx, cancel := context.WithTimeout(context.Background(), drainAbortTimeout)
+	defer cancel()
+	defer s.pool.wait(abortCtx)
+	defer s.pool.close()
+
+	remaining := s.pool.activeScans()
+	if len(remaining) == 0 {
+		return 0
+	}
+
+	for _, scan := range remaining {
+		s.logger.Warn("Aborting scan for shutdown", zap.String("scan_id", scan.ID))
+		if err := s.abortScan(scan, shutdownAbortReason); err != nil {
+			s.logger.Error("Failed to abort scan",
+				zap.String("scan_id", scan.ID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return len(remaining)
+}
+
+// waitFor polls done until it returns true or ctx is done
+func (s *ScanService) waitFor(ctx context.Context, done func() bool) {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if done() {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
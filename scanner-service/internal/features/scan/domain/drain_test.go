@@ -0,0 +1,95 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// blockingScanAdapter is a scan adapter whose scans run until they are released or cancelled
+type blockingScanAdapter struct {
+	MockScanAdapter
+	started chan struct{}
+	release chan struct{}
+}
+
+func (a *blockingScanAdapter) ExecuteScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	a.started <- struct{}{}
+	select {
+	case <-a.release:
+		return &domain.ScanResult{ID: "result-" + options.Target}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestDrain(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	adapter := &blockingScanAdapter{started: make(chan struct{}, 1), release: make(chan struct{})}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+	repository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(adapter, repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.1", Timeout: time.Minute})
+	require.NoError(t, err)
+	repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+
+	// Scans finishing within the drain period complete normally
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(adapter.release)
+	}()
+	assert.Equal(t, 0, service.Drain(context.Background()))
+	assert.True(t, service.Draining())
+	current, err := service.GetScan(ctx, scan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScanStatusCompleted, current.Status)
+
+	// No new scans are accepted while draining
+	_, err = service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.2", Timeout: time.Minute})
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrUnavailable, scanErr.Type)
+}
+
+func TestDrainAbortsRemainingScans(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	adapter := &blockingScanAdapter{started: make(chan struct{}, 1), release: make(chan struct{})}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+
+	service := domain.NewScanService(adapter, repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.1", Timeout: time.Minute})
+	require.NoError(t, err)
+	repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+	<-adapter.started
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, 1, service.Drain(drainCtx))
+
+	current, err := service.GetScan(ctx, scan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScanStatusCancelled, current.Status)
+	assert.Equal(t, "aborted by service shutdown", current.Error)
+	assert.NotNil(t, current.CompletedAt)
+}
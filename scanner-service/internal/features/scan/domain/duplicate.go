@@ -0,0 +1,68 @@
+package domain
+
+import "reflect"
+
+// DuplicateScanPolicy decides what StartScan does when an identical scan of the user is
+// already pending or running
+type DuplicateScanPolicy string
+
+// Duplicate scan policies
+const (
+	DuplicateScansAllow  DuplicateScanPolicy = "allow"  // Start the duplicate as a separate scan
+	DuplicateScansReuse  DuplicateScanPolicy = "reuse"  // Return the running scan instead
+	DuplicateScansReject DuplicateScanPolicy = "reject" // Fail with a conflict naming the running scan
+)
+
+// DuplicateScanPolicies lists the supported duplicate scan policies
+var DuplicateScanPolicies = []DuplicateScanPolicy{DuplicateScansAllow, DuplicateScansReuse, DuplicateScansReject}
+
+// SetDuplicateScanPolicy sets how scans identical to a running scan of the same user
+// are handled. Pipeline stages and workflow steps are always started.
+func (s *ScanService) SetDuplicateScanPolicy(policy DuplicateScanPolicy) {
+	s.duplicateScans = policy
+}
+
+// duplicateOf returns a copy of the active scan started by the same user with the same
+// options as scan, or nil. The caller must hold s.mu.
+func (s *ScanService) duplicateOf(scan *Scan) *Scan {
+	if s.duplicateScans == "" || s.duplicateScans == DuplicateScansAllow || !scan.standalone() {
+		return nil
+	}
+
+	for _, active := range s.pool.liveScans() {
+		if active.UserID == scan.UserID && active.standalone() && !active.Status.Terminal() &&
+			sameScanOptions(active.Options, scan.Options) {
+			duplicate := *active
+			return &duplicate
+		}
+	}
+	return nil
+}
+
+// standalone reports whether the scan was started on its own rather than as a shard,
+// pipeline stage or workflow step
+func (s *Scan) standalone() bool {
+	return s.ParentID == "" && s.PipelineID == "" && s.WorkflowRunID == ""
+}
+
+// sameScanOptions reports whether two scans would run the same way. Empty and missing
+// lists are equal, and the state file set by the service and the tags are ignored.
+func sameScanOptions(a, b ScanOptions) bool {
+	canonical := func(options ScanOptions) ScanOptions {
+		options.StateFile = ""
+		options.Tags = nil
+		options.ExtraOptions = nilIfEmpty(options.ExtraOptions)
+		options.Decoys = nilIfEmpty(options.Decoys)
+		options.Discovery = nilIfEmpty(options.Discovery)
+		return options
+	}
+	return reflect.DeepEqual(canonical(a), canonical(b))
+}
+
+// nilIfEmpty returns nil for an empty slice
+func nilIfEmpty[S ~[]E, E any](values S) S {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDuplicateScans(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	adapter := &blockingScanAdapter{started: make(chan struct{}, 4), release: make(chan struct{})}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	repository.On("UpdateScan", mock.Anything).Return(nil)
+	repository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(adapter, repository, log, 10)
+	service.SetDuplicateScanPolicy(domain.DuplicateScansReuse)
+	alice := principalContext("alice", authdomain.RoleOperator)
+	bob := principalContext("bob", authdomain.RoleOperator)
+	options := domain.ScanOptions{Target: "10.0.0.1", Ports: "22,80", Timeout: time.Minute, ExtraOptions: []string{}}
+
+	scan, err := service.StartScan(alice, "alice", options)
+	require.NoError(t, err)
+	<-adapter.started
+
+	// Identical scans of the same user are coalesced, empty and missing lists are equal
+	options.ExtraOptions = nil
+	duplicate, err := service.StartScan(alice, "alice", options)
+	require.NoError(t, err)
+	assert.Equal(t, scan.ID, duplicate.ID)
+
+	// Other users and other options start new scans
+	other, err := service.StartScan(bob, "bob", options)
+	require.NoError(t, err)
+	assert.NotEqual(t, scan.ID, other.ID)
+	<-adapter.started
+
+	options.Ports = "443"
+	other, err = service.StartScan(alice, "alice", options)
+	require.NoError(t, err)
+	assert.NotEqual(t, scan.ID, other.ID)
+	<-adapter.started
+
+	// Duplicates can be rejected with the ID of the running scan
+	service.SetDuplicateScanPolicy(domain.DuplicateScansReject)
+	_, err = service.StartScan(alice, "alice", options)
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrAlreadyExists, scanErr.Type)
+	assert.Contains(t, scanErr.Message, other.ID)
+
+	close(adapter.release)
+	assert.Equal(t, 0, service.Drain(context.Background()))
+}
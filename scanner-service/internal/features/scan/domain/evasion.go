@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// Evasion option limits
+const (
+	MaxDecoys     = 16   // Decoys of a scan at most
+	MaxDataLength = 1400 // Bytes appended to a probe at most, keeping probes within a common MTU
+)
+
+// SetEvasionEnabled allows scans to use evasion options. Evasion is disabled by default
+// and, when enabled, restricted to callers with the advanced role.
+func (s *ScanService) SetEvasionEnabled(enabled bool) {
+	s.evasionEnabled = enabled
+}
+
+// validateEvasion checks the evasion options of a scan and the caller's permission to use them
+func (s *ScanService) validateEvasion(ctx context.Context, options ScanOptions) error {
+	if !options.UsesEvasion() {
+		return nil
+	}
+
+	if !s.evasionEnabled {
+		return errors.NewInvalidInput("evasion options are not enabled", nil)
+	}
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleAdvanced); err != nil {
+		return err
+	}
+
+	if len(options.Decoys) > MaxDecoys {
+		return errors.NewInvalidField("decoys", "max", "a scan may use at most "+strconv.Itoa(MaxDecoys)+" decoys")
+	}
+	for i, decoy := range options.Decoys {
+		if !validDecoy(decoy) {
+			return errors.NewInvalidField("decoys["+strconv.Itoa(i)+"]", "ip", "invalid decoy "+strconv.Quote(decoy)+": expected an IP address, ME or RND")
+		}
+	}
+	if options.SourcePort < 0 || options.SourcePort > 65535 {
+		return errors.NewInvalidField("source_port", "range", "source port must be between 1 and 65535")
+	}
+	if options.DataLength < 0 || options.DataLength > MaxDataLength {
+		return errors.NewInvalidField("data_length", "range", "data length must be between 1 and "+strconv.Itoa(MaxDataLength))
+	}
+
+	return nil
+}
+
+// validDecoy reports whether a decoy is an IP address, the scanner itself (ME)
+// or a random address (RND)
+func validDecoy(decoy string) bool {
+	switch strings.ToUpper(decoy) {
+	case "ME", "RND":
+		return true
+	}
+	return net.ParseIP(decoy) != nil
+}
@@ -0,0 +1,46 @@
+package domain_test
+
+import (
+	"testing"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestValidateEvasion(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	operator := principalContext("test-user", authdomain.RoleOperator)
+	advanced := principalContext("red-team", authdomain.RoleAdvanced)
+	options := domain.ScanOptions{Target: "10.0.0.1", Decoys: []string{"10.0.0.7", "ME", "rnd"}, SourcePort: 53, Fragment: true, DataLength: 24}
+
+	// Evasion is disabled by default
+	service := domain.NewScanService(new(MockScanAdapter), new(MockScanRepository), log, 10)
+	assert.Error(t, service.CheckScan(advanced, options))
+
+	service.SetEvasionEnabled(true)
+	assert.NoError(t, service.CheckScan(advanced, options))
+	assert.NoError(t, service.CheckScan(operator, domain.ScanOptions{Target: "10.0.0.1"}))
+
+	// Only the advanced role may use evasion options
+	err := service.CheckScan(operator, options)
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrForbidden, scanErr.Type)
+
+	// Invalid options are attributed to their fields
+	err = service.CheckScan(advanced, domain.ScanOptions{Target: "10.0.0.1", Decoys: []string{"ME", "decoy.example.com"}})
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrInvalidInput, scanErr.Type)
+	require.Len(t, scanErr.Fields, 1)
+	assert.Equal(t, "decoys[1]", scanErr.Fields[0].Field)
+	assert.Equal(t, "ip", scanErr.Fields[0].Constraint)
+
+	assert.Error(t, service.CheckScan(advanced, domain.ScanOptions{Target: "10.0.0.1", SourcePort: 70000}))
+	assert.Error(t, service.CheckScan(advanced, domain.ScanOptions{Target: "10.0.0.1", DataLength: 9000}))
+}
@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// ExportFormat represents a document format scan results are exported in
+type ExportFormat string
+
+// Export format constants
+const (
+	ExportFormatJSON ExportFormat = "json" // The result as returned by the API
+	ExportFormatXML  ExportFormat = "xml"  // Hosts, ports and script output as XML
+	ExportFormatHTML ExportFormat = "html" // Human-readable report
+)
+
+// ExportFormats lists the supported export formats
+var ExportFormats = []ExportFormat{ExportFormatJSON, ExportFormatXML, ExportFormatHTML}
+
+// ParseExportFormat parses an export format case-insensitively
+func ParseExportFormat(value string) (ExportFormat, error) {
+	format := ExportFormat(strings.ToLower(value))
+	if !slices.Contains(ExportFormats, format) {
+		return "", errors.NewInvalidField("format", "oneof", fmt.Sprintf("format must be one of %v", ExportFormats))
+	}
+	return format, nil
+}
+
+// ContentType returns the media type of documents in the format
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportFormatXML:
+		return "application/xml; charset=utf-8"
+	case ExportFormatHTML:
+		return "text/html; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// ExportResult writes a scan result as a document in the format
+func ExportResult(w io.Writer, result *ScanResult, format ExportFormat) error {
+	switch format {
+	case ExportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case ExportFormatXML:
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		encoder := xml.NewEncoder(w)
+		encoder.Indent("", "  ")
+		if err := encoder.Encode(newXMLResult(result)); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	case ExportFormatHTML:
+		return htmlReport.Execute(w, result)
+	default:
+		return fmt.Errorf("unknown export format %s", format)
+	}
+}
+
+// xmlResult is the XML document of a scan result
+type xmlResult struct {
+	XMLName    xml.Name  `xml:"scan_result"`
+	ID         string    `xml:"id,attr"`
+	ScanID     string    `xml:"scan_id,attr"`
+	StartTime  string    `xml:"start_time"`
+	EndTime    string    `xml:"end_time"`
+	Duration   float64   `xml:"duration"`
+	Command    string    `xml:"command,omitempty"`
+	Summary    string    `xml:"summary,omitempty"`
+	TotalHosts int       `xml:"total_hosts"`
+	UpHosts    int       `xml:"up_hosts"`
+	Hosts      []xmlHost `xml:"hosts>host"`
+}
+
+// xmlHost is a host in the XML document of a scan result
+type xmlHost struct {
+	IP        string      `xml:"ip,attr"`
+	Status    string      `xml:"status,attr"`
+	Hostnames []string    `xml:"hostnames>hostname"`
+	OS        string      `xml:"os,omitempty"`
+	Ports     []xmlPort   `xml:"ports>port"`
+	Scripts   []xmlScript `xml:"scripts>script"`
+}
+
+// xmlPort is a port in the XML document of a scan result
+type xmlPort struct {
+	Port      int    `xml:"number,attr"`
+	Protocol  string `xml:"protocol,attr"`
+	State     string `xml:"state,attr"`
+	Service   string `xml:"service,omitempty"`
+	Product   string `xml:"product,omitempty"`
+	Version   string `xml:"version,omitempty"`
+	ExtraInfo string `xml:"extra_info,omitempty"`
+}
+
+// xmlScript is a script result in the XML document of a scan result
+type xmlScript struct {
+	ID     string       `xml:"id,attr"`
+	Output string       `xml:"output"`
+	Data   []xmlElement `xml:"elem"`
+}
+
+// xmlElement is an entry of the structured data of a script result
+type xmlElement struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// newXMLResult converts a scan result to its XML document
+func newXMLResult(result *ScanResult) xmlResult {
+	doc := xmlResult{
+		ID:         result.ID,
+		ScanID:     result.ScanID,
+		StartTime:  result.StartTime.UTC().Format(time.RFC3339),
+		EndTime:    result.EndTime.UTC().Format(time.RFC3339),
+		Duration:   result.Duration,
+		Command:    result.Command,
+		Summary:    result.Summary,
+		TotalHosts: result.TotalHosts,
+		UpHosts:    result.UpHosts,
+	}
+
+	for _, host := range result.Hosts {
+		xh := xmlHost{IP: host.IP, Status: host.Status, Hostnames: host.Hostnames, OS: host.OS}
+		for _, port := range host.Ports {
+			xh.Ports = append(xh.Ports, xmlPort(port))
+		}
+		for _, script := range host.Scripts {
+			xs := xmlScript{ID: script.ID, Output: script.Output}
+			// Map order is random, the document is not
+			keys := make([]string, 0, len(script.Data))
+			for key := range script.Data {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			for _, key := range keys {
+				xs.Data = append(xs.Data, xmlElement{Key: key, Value: script.Data[key]})
+			}
+			xh.Scripts = append(xh.Scripts, xs)
+		}
+		doc.Hosts = append(doc.Hosts, xh)
+	}
+	return doc
+}
+
+// htmlReport is the template of the HTML report of a scan result
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Scan result {{.ID}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+pre { margin: 0; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Scan result {{.ID}}</h1>
+<table>
+<tr><th>Scan</th><td>{{.ScanID}}</td></tr>
+<tr><th>Started</th><td>{{.StartTime.UTC.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+<tr><th>Ended</th><td>{{.EndTime.UTC.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+<tr><th>Duration</th><td>{{printf "%.2f" .Duration}} seconds</td></tr>
+<tr><th>Hosts up</th><td>{{.UpHosts}} of {{.TotalHosts}}</td></tr>
+{{- if .Command}}
+<tr><th>Command</th><td><code>{{.Command}}</code></td></tr>
+{{- end}}
+{{- if .Summary}}
+<tr><th>Summary</th><td>{{.Summary}}</td></tr>
+{{- end}}
+</table>
+{{- range .Hosts}}
+<h2>{{.IP}}{{range .Hostnames}} ({{.}}){{end}}</h2>
+<p>Status: {{.Status}}{{if .OS}}, OS: {{.OS}}{{end}}</p>
+{{- if .Ports}}
+<table>
+<tr><th>Port</th><th>State</th><th>Service</th><th>Product</th><th>Version</th></tr>
+{{- range .Ports}}
+<tr><td>{{.Port}}/{{.Protocol}}</td><td>{{.State}}</td><td>{{.Service}}</td><td>{{.Product}}</td><td>{{.Version}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No open ports found</p>
+{{- end}}
+{{- if .Scripts}}
+<table>
+<tr><th>Script</th><th>Output</th></tr>
+{{- range .Scripts}}
+<tr><td>{{.ID}}</td><td><pre>{{.Output}}</pre></td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
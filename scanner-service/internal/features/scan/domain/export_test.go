@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := domain.ParseExportFormat("XML")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportFormatXML, format)
+
+	_, err = domain.ParseExportFormat("pdf")
+	var exportErr *errors.Error
+	require.ErrorAs(t, err, &exportErr)
+	assert.Equal(t, errors.ErrInvalidInput, exportErr.Type)
+}
+
+func TestExportResult(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	result := &domain.ScanResult{
+		ID:         "result-1",
+		ScanID:     "scan-1",
+		StartTime:  start,
+		EndTime:    start.Add(time.Minute),
+		Duration:   60,
+		TotalHosts: 1,
+		UpHosts:    1,
+		Hosts: []domain.Host{{
+			IP:        "10.0.0.1",
+			Hostnames: []string{"web.example.com"},
+			Status:    "up",
+			Ports:     []domain.Port{{Port: 443, Protocol: "tcp", State: "open", Service: "https", Product: "nginx"}},
+			Scripts: []domain.Script{{
+				ID:     "http-title",
+				Output: "<script>alert(1)</script>",
+				Data:   map[string]string{"title": "Welcome", "redirect": "/login"},
+			}},
+		}},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, domain.ExportResult(&buf, result, domain.ExportFormatJSON))
+
+		var decoded domain.ScanResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, *result, decoded)
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, domain.ExportResult(&buf, result, domain.ExportFormatXML))
+
+		var decoded struct {
+			ID    string `xml:"id,attr"`
+			Hosts []struct {
+				IP    string `xml:"ip,attr"`
+				Ports []struct {
+					Number  int    `xml:"number,attr"`
+					Service string `xml:"service"`
+				} `xml:"ports>port"`
+				Scripts []struct {
+					Data []struct {
+						Key string `xml:"key,attr"`
+					} `xml:"elem"`
+				} `xml:"scripts>script"`
+			} `xml:"hosts>host"`
+		}
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, "result-1", decoded.ID)
+		require.Len(t, decoded.Hosts, 1)
+		assert.Equal(t, "10.0.0.1", decoded.Hosts[0].IP)
+		assert.Equal(t, 443, decoded.Hosts[0].Ports[0].Number)
+		assert.Equal(t, "https", decoded.Hosts[0].Ports[0].Service)
+		// Script data is ordered by key
+		assert.Equal(t, "redirect", decoded.Hosts[0].Scripts[0].Data[0].Key)
+		assert.Equal(t, "title", decoded.Hosts[0].Scripts[0].Data[1].Key)
+	})
+
+	t.Run("html", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, domain.ExportResult(&buf, result, domain.ExportFormatHTML))
+
+		html := buf.String()
+		assert.Contains(t, html, "<h2>10.0.0.1 (web.example.com)</h2>")
+		assert.Contains(t, html, "<td>443/tcp</td>")
+		// Script output is escaped
+		assert.Contains(t, html, "&lt;script&gt;alert(1)&lt;/script&gt;")
+		assert.NotContains(t, html, "<script>")
+	})
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	stderrors "errors"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// MaxFailureStderr is how many bytes of the standard error of a failed scanner process
+// are kept, the end of the output being the most telling
+const MaxFailureStderr = 8 * 1024
+
+// ScanFailure describes the scanner process of a failed scan
+type ScanFailure struct {
+	Type     errors.Type `json:"type"`             // Kind of failure, INTERNAL unless the scanner output shows a common one
+	Hint     string      `json:"hint,omitempty"`   // How the user can remedy the failure
+	Command  string      `json:"command"`          // Command line the scanner was run with
+	ExitCode int         `json:"exit_code"`        // Exit code, -1 if the process did not exit by itself
+	Stderr   string      `json:"stderr,omitempty"` // End of the standard error output
+}
+
+// ScannerError is the error of a scanner process that failed. Scan adapters return it so
+// the scan records how the process failed.
+type ScannerError struct {
+	Failure ScanFailure
+	Err     error
+}
+
+// NewScannerError returns the error of a failed scanner process, keeping the end of its
+// standard error output. The failure takes the type and hint of err.
+func NewScannerError(command string, exitCode int, stderr string, err error) *ScannerError {
+	if len(stderr) > MaxFailureStderr {
+		stderr = stderr[len(stderr)-MaxFailureStderr:]
+	}
+	appErr := errors.From(err)
+	return &ScannerError{
+		Failure: ScanFailure{
+			Type:     appErr.Type,
+			Hint:     appErr.Hint,
+			Command:  command,
+			ExitCode: exitCode,
+			Stderr:   stderr,
+		},
+		Err: err,
+	}
+}
+
+func (e *ScannerError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ScannerError) Unwrap() error {
+	return e.Err
+}
+
+// failureFromError returns how the scanner process of a failed scan failed, if err tells
+func failureFromError(err error) *ScanFailure {
+	var scannerErr *ScannerError
+	if !stderrors.As(err, &scannerErr) {
+		return nil
+	}
+	failure := scannerErr.Failure
+	return &failure
+}
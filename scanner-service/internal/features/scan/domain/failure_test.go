@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFailedScanRecordsFailure(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+
+	adapter := new(MockScanAdapter)
+	cause := errors.WithHint(errors.NewPermissionDenied("the scanner lacks the privileges for raw sockets", nil), "use a connect scan")
+	scanErr := domain.NewScannerError("nmap 10.0.0.1 -sS", 1, "QUITTING!\n", cause)
+	adapter.On("ExecuteScan", mock.Anything, mock.Anything).Return(nil, scanErr)
+
+	service := domain.NewScanService(adapter, repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.1", Timeout: time.Minute})
+	require.NoError(t, err)
+	repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+
+	assert.Eventually(t, func() bool {
+		current, err := service.GetScan(ctx, scan.ID)
+		return err == nil && current.Status == domain.ScanStatusFailed
+	}, time.Second, 10*time.Millisecond)
+
+	current, _ := service.GetScan(ctx, scan.ID)
+	assert.Equal(t, "PERMISSION_DENIED: the scanner lacks the privileges for raw sockets", current.Error)
+	require.NotNil(t, current.Failure)
+	assert.Equal(t, domain.ScanFailure{
+		Type:     errors.ErrPermissionDenied,
+		Hint:     "use a connect scan",
+		Command:  "nmap 10.0.0.1 -sS",
+		ExitCode: 1,
+		Stderr:   "QUITTING!\n",
+	}, *current.Failure)
+}
+
+func TestNewScannerErrorKeepsEndOfStderr(t *testing.T) {
+	stderr := strings.Repeat("a", domain.MaxFailureStderr) + "QUITTING!"
+	err := domain.NewScannerError("nmap", 1, stderr, errors.NewInternal("nmap scan failed", nil))
+
+	assert.Len(t, err.Failure.Stderr, domain.MaxFailureStderr)
+	assert.True(t, strings.HasSuffix(err.Failure.Stderr, "QUITTING!"))
+	assert.Equal(t, "INTERNAL: nmap scan failed", err.Error())
+}
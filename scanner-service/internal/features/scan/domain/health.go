@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// healthCheckTimeout bounds how long a single dependency check may take
+const healthCheckTimeout = 5 * time.Second
+
+// Health statuses of the service
+const (
+	HealthStatusHealthy   = "healthy"   // All dependencies are up
+	HealthStatusDegraded  = "degraded"  // An optional dependency is down or degraded
+	HealthStatusUnhealthy = "unhealthy" // A critical dependency is down
+	HealthStatusDraining  = "draining"  // The service shuts down and accepts no new scans
+)
+
+// Check statuses of a dependency
+const (
+	CheckStatusUp       = "up"
+	CheckStatusDegraded = "degraded"
+	CheckStatusDown     = "down"
+)
+
+// HealthCheck is the result of checking one dependency
+type HealthCheck struct {
+	Name      string         `json:"name"`              // Name of the dependency
+	Status    string         `json:"status"`            // up, degraded or down
+	Critical  bool           `json:"critical"`          // Whether the service is unhealthy while the dependency is down
+	LatencyMS float64        `json:"latency_ms"`        // Duration of the check in milliseconds
+	Details   map[string]any `json:"details,omitempty"` // Dependency specific information, e.g. versions or counts
+	Error     string         `json:"error,omitempty"`   // Why the dependency is not up
+}
+
+// HealthChecker is implemented by dependencies reporting their health.
+// Name, Status, Critical, Details and Error are taken from the returned check.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) HealthCheck
+}
+
+// BuildInfo describes the running build of the service
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"build_date,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// HealthReport is the health of the service and its dependencies
+type HealthReport struct {
+	Status        string        `json:"status"`
+	Checks        []HealthCheck `json:"checks"`
+	StartedAt     time.Time     `json:"started_at"`
+	UptimeSeconds int64         `json:"uptime_seconds"`
+	ActiveScans   int           `json:"active_scans"`
+	Build         BuildInfo     `json:"build"`
+	Timestamp     time.Time     `json:"timestamp"`
+}
+
+// Check returns the check of the named dependency
+func (r *HealthReport) Check(name string) (HealthCheck, bool) {
+	for _, check := range r.Checks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+	return HealthCheck{}, false
+}
+
+// SetBuildInfo sets the build metadata reported by CheckHealth
+func (s *ScanService) SetBuildInfo(build BuildInfo) {
+	if build.GoVersion == "" {
+		build.GoVersion = runtime.Version()
+	}
+	s.build = build
+}
+
+// AddHealthChecker adds a dependency reported by CheckHealth
+func (s *ScanService) AddHealthChecker(checker HealthChecker) {
+	s.healthCheckers = append(s.healthCheckers, checker)
+}
+
+// CheckHealth checks nmap, the scan storage and the registered dependencies in parallel.
+// The service is unhealthy while a critical dependency is down and degraded while any
+// other dependency is not up.
+func (s *ScanService) CheckHealth(ctx context.Context) *HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	checkers := append([]HealthChecker{
+		HealthCheckerFunc(s.checkNmap),
+		HealthCheckerFunc(s.checkStorage),
+	}, s.healthCheckers...)
+
+	checks := make([]HealthCheck, len(checkers))
+	var wg sync.WaitGroup
+	for i, checker := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			check := checker.CheckHealth(ctx)
+			check.LatencyMS = float64(time.Since(start).Microseconds()) / 1000
+			checks[i] = check
+		}()
+	}
+	wg.Wait()
+
+	activeScans := len(s.pool.activeScans())
+
+	now := time.Now()
+	report := &HealthReport{
+		Status:        HealthStatusHealthy,
+		Checks:        checks,
+		StartedAt:     s.startedAt,
+		UptimeSeconds: int64(now.Sub(s.startedAt).Seconds()),
+		ActiveScans:   activeScans,
+		Build:         s.build,
+		Timestamp:     now,
+	}
+	for _, check := range checks {
+		switch {
+		case check.Status == CheckStatusDown && check.Critical:
+			report.Status = HealthStatusUnhealthy
+		case check.Status != CheckStatusUp && report.Status == HealthStatusHealthy:
+			report.Status = HealthStatusDegraded
+		}
+	}
+	if s.Draining() {
+		report.Status = HealthStatusDraining
+	}
+
+	return report
+}
+
+// HealthCheckerFunc adapts a function to the HealthChecker interface
+type HealthCheckerFunc func(ctx context.Context) HealthCheck
+
+// CheckHealth calls f
+func (f HealthCheckerFunc) CheckHealth(ctx context.Context) HealthCheck {
+	return f(ctx)
+}
+
+// checkNmap checks that the nmap binary can be run and reports its version
+func (s *ScanService) checkNmap(ctx context.Context) HealthCheck {
+	check := HealthCheck{Name: "nmap", Status: CheckStatusUp, Critical: true}
+
+	if err := s.ValidateNmap(); err != nil {
+		check.Status = CheckStatusDown
+		check.Error = err.Error()
+		return check
+	}
+	version, err := s.GetNmapVersion()
+	if err != nil {
+		check.Status = CheckStatusDown
+		check.Error = err.Error()
+		return check
+	}
+	check.Details = map[string]any{"version": version}
+
+	return check
+}
+
+// checkStorage checks that scans can be read from the repository
+func (s *ScanService) checkStorage(ctx context.Context) HealthCheck {
+	check := HealthCheck{Name: "storage", Status: CheckStatusUp, Critical: true}
+
+	page, err := s.repository.ListScans(ScanFilter{}, ScanSort{}, PageRequest{Limit: 1})
+	if err != nil {
+		check.Status = CheckStatusDown
+		check.Error = err.Error()
+		return check
+	}
+	check.Details = map[string]any{"scans": page.TotalCount}
+
+	return check
+}
@@ -0,0 +1,61 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCheckHealth(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	adapter := new(MockScanAdapter)
+	adapter.On("IsAvailable").Return(true)
+	adapter.On("GetVersion").Return("Nmap version 7.94", nil)
+	repository := new(MockScanRepository)
+	repository.On("ListScans", domain.ScanFilter{}, domain.ScanSort{}, domain.PageRequest{Limit: 1}).
+		Return(&domain.ScanPage{TotalCount: 3}, nil).Once()
+
+	service := domain.NewScanService(adapter, repository, log, 10)
+	service.SetBuildInfo(domain.BuildInfo{Version: "1.2.3", Commit: "abc123"})
+
+	report := service.CheckHealth(context.Background())
+	assert.Equal(t, domain.HealthStatusHealthy, report.Status)
+	assert.Equal(t, "1.2.3", report.Build.Version)
+	assert.NotEmpty(t, report.Build.GoVersion)
+	require.Len(t, report.Checks, 2)
+
+	nmap, ok := report.Check("nmap")
+	require.True(t, ok)
+	assert.Equal(t, domain.CheckStatusUp, nmap.Status)
+	assert.Equal(t, "Nmap version 7.94", nmap.Details["version"])
+
+	storage, ok := report.Check("storage")
+	require.True(t, ok)
+	assert.Equal(t, domain.CheckStatusUp, storage.Status)
+	assert.Equal(t, 3, storage.Details["scans"])
+
+	// Optional dependencies that are not up degrade the service
+	service.AddHealthChecker(domain.HealthCheckerFunc(func(ctx context.Context) domain.HealthCheck {
+		return domain.HealthCheck{Name: "agents", Status: domain.CheckStatusDegraded, Error: "no agent is online"}
+	}))
+	repository.On("ListScans", domain.ScanFilter{}, domain.ScanSort{}, domain.PageRequest{Limit: 1}).
+		Return(&domain.ScanPage{TotalCount: 3}, nil).Once()
+	report = service.CheckHealth(context.Background())
+	assert.Equal(t, domain.HealthStatusDegraded, report.Status)
+	assert.Len(t, report.Checks, 3)
+
+	// Critical dependencies that are down make the service unhealthy
+	repository.On("ListScans", domain.ScanFilter{}, domain.ScanSort{}, domain.PageRequest{Limit: 1}).
+		Return(nil, errors.New("connection refused")).Once()
+	report = service.CheckHealth(context.Background())
+	assert.Equal(t, domain.HealthStatusUnhealthy, report.Status)
+	storage, _ = report.Check("storage")
+	assert.Equal(t, domain.CheckStatusDown, storage.Status)
+	assert.Equal(t, "connection refused", storage.Error)
+}
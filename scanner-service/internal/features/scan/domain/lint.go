@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+)
+
+// LintSeverity represents the severity of a lint finding
+type LintSeverity string
+
+// Lint severity constants
+const (
+	LintError   LintSeverity = "error"   // The scan would be rejected or fail
+	LintWarning LintSeverity = "warning" // The scan would run but likely not as intended
+)
+
+// LintFinding represents a problem found in scan options
+type LintFinding struct {
+	Severity LintSeverity `json:"severity"`        // Severity
+	Field    string       `json:"field,omitempty"` // Request field the finding is about, empty for the whole request
+	Message  string       `json:"message"`         // Description of the problem
+}
+
+// LintReport represents the problems found in scan options before submission
+type LintReport struct {
+	Valid    bool          `json:"valid"`    // Whether the scan would be accepted
+	Findings []LintFinding `json:"findings"` // Errors and warnings
+}
+
+// PrivilegeChecker is implemented by scan adapters that know whether nmap may open raw sockets
+type PrivilegeChecker interface {
+	RawSocketCapable() bool
+}
+
+// LintScan checks scan options for conflicts without starting a scan. Errors are
+// problems that would reject the scan, warnings are options that likely do not
+// work as intended.
+func (s *ScanService) LintScan(ctx context.Context, options ScanOptions) *LintReport {
+	report := &LintReport{Findings: make([]LintFinding, 0)}
+	add := func(severity LintSeverity, field, message string) {
+		report.Findings = append(report.Findings, LintFinding{Severity: severity, Field: field, Message: message})
+	}
+
+	// Field checks not covered by CheckScan
+	switch options.ScanType {
+	case "", ScanTypeSYN, ScanTypeConnect, ScanTypeUDP, ScanTypeVersion, ScanTypeScript, ScanTypeAll, ScanTypePing:
+	default:
+		add(LintError, "scan_type", fmt.Sprintf("unknown scan type %s", options.ScanType))
+	}
+	if options.Ports != "" {
+		if _, err := utils.PortRangeToSlice(options.Ports); err != nil {
+			add(LintError, "ports", "invalid port specification: "+err.Error())
+		}
+	}
+	if options.TimingTemplate < TimingParanoid || options.TimingTemplate > TimingInsane {
+		add(LintWarning, "timing_template", "timing template must be between 0 and 5, normal timing is used")
+	}
+
+	// Validation, option policy and target scope
+	if err := s.CheckScan(ctx, options); err != nil {
+		add(LintError, "", err.Error())
+	}
+
+	// Conflicting options
+	if options.ScanType == ScanTypePing {
+		if options.Ports != "" {
+			add(LintWarning, "ports", "ping scans do not scan ports, the ports are ignored")
+		}
+		if options.ServiceDetection || options.ScriptScan {
+			add(LintWarning, "scan_type", "ping scans find no open ports for service detection or scripts")
+		}
+	}
+	if options.ScanType == ScanTypeUDP && options.ScriptScan {
+		add(LintWarning, "script_scan", "most default scripts need TCP ports and do not run in UDP scans")
+	}
+	if options.ScanType == ScanTypeUDP && options.Ports == "" {
+		add(LintWarning, "ports", "UDP scans of the default ports are slow, consider listing the ports to scan")
+	}
+	if options.ScanType == ScanTypeAll && (options.ServiceDetection || options.OSDetection || options.ScriptScan) {
+		add(LintWarning, "scan_type", "aggressive scans already include service detection, OS detection and scripts")
+	}
+	if options.HostTimeout > 0 && hasExtraOption(options.ExtraOptions, "--host-timeout") {
+		add(LintWarning, "host_timeout", "the host timeout is also set in the extra options, which take precedence")
+	}
+	if (options.MinRate > 0 && hasExtraOption(options.ExtraOptions, "--min-rate")) ||
+		(options.MaxRate > 0 && hasExtraOption(options.ExtraOptions, "--max-rate")) ||
+		(options.MaxParallelism > 0 && hasExtraOption(options.ExtraOptions, "--max-parallelism")) ||
+		(options.MaxRetries != nil && hasExtraOption(options.ExtraOptions, "--max-retries")) {
+		add(LintWarning, "extra_options", "packet rate or retry options are also set in the extra options, which take precedence")
+	}
+	if options.ScanType == ScanTypeConnect && (len(options.Decoys) > 0 || options.Fragment) {
+		add(LintWarning, "scan_type", "connect scans use the system network stack and ignore decoys and fragmentation")
+	}
+	if options.TimingTemplate == TimingInsane {
+		add(LintWarning, "timing_template", "insane timing may miss open ports on slow networks")
+	}
+
+	// Privileges of the local scanner, agents are checked when routing the scan
+	if options.Agent == "" && options.ResolvedEngine() == ScanEngineNmap && options.RequiresRawSocket() {
+		if checker, ok := s.adapter.(PrivilegeChecker); ok && !checker.RawSocketCapable() {
+			add(LintError, "scan_type", "the scanner lacks raw socket privileges required for SYN and UDP scans and OS detection")
+		}
+	}
+
+	report.Valid = true
+	for _, finding := range report.Findings {
+		if finding.Severity == LintError {
+			report.Valid = false
+		}
+	}
+	return report
+}
+
+// hasExtraOption reports whether an nmap option is set in the extra options, with or without "="
+func hasExtraOption(extraOptions []string, name string) bool {
+	for _, option := range extraOptions {
+		if option == name || strings.HasPrefix(option, name+"=") {
+			return true
+		}
+	}
+	return false
+}
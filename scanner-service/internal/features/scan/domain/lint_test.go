@@ -0,0 +1,59 @@
+package domain_test
+
+import (
+	"testing"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// unprivilegedScanAdapter is a MockScanAdapter that cannot open raw sockets
+type unprivilegedScanAdapter struct {
+	MockScanAdapter
+}
+
+func (a *unprivilegedScanAdapter) RawSocketCapable() bool {
+	return false
+}
+
+// findingFields returns the fields of the findings with the severity
+func findingFields(report *domain.LintReport, severity domain.LintSeverity) []string {
+	var fields []string
+	for _, finding := range report.Findings {
+		if finding.Severity == severity {
+			fields = append(fields, finding.Field)
+		}
+	}
+	return fields
+}
+
+func TestLintScan(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	ctx := principalContext("test-user", authdomain.RoleOperator)
+	service := domain.NewScanService(new(unprivilegedScanAdapter), new(MockScanRepository), log, 10)
+
+	// Valid options
+	report := service.LintScan(ctx, domain.ScanOptions{Target: "10.0.0.1", ScanType: domain.ScanTypeConnect, Ports: "22,80"})
+	assert.True(t, report.Valid)
+	assert.Empty(t, report.Findings)
+
+	// Invalid port specification and missing target
+	report = service.LintScan(ctx, domain.ScanOptions{Ports: "22-http"})
+	assert.False(t, report.Valid)
+	assert.ElementsMatch(t, []string{"ports", ""}, findingFields(report, domain.LintError))
+
+	// Scripts in UDP scans need TCP, and UDP needs raw sockets
+	report = service.LintScan(ctx, domain.ScanOptions{Target: "10.0.0.1", ScanType: domain.ScanTypeUDP, Ports: "53", ScriptScan: true})
+	assert.False(t, report.Valid)
+	assert.Equal(t, []string{"scan_type"}, findingFields(report, domain.LintError))
+	assert.Equal(t, []string{"script_scan"}, findingFields(report, domain.LintWarning))
+
+	// Warnings do not make the options invalid
+	report = service.LintScan(ctx, domain.ScanOptions{Target: "10.0.0.1", ScanType: domain.ScanTypePing, Ports: "80"})
+	assert.True(t, report.Valid)
+	assert.Equal(t, []string{"ports"}, findingFields(report, domain.LintWarning))
+}
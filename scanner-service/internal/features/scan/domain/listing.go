@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// ScanFilter represents the criteria a scan must match to be listed.
+// Zero values match any scan.
+type ScanFilter struct {
+	UserID        string       // Owner of the scan, empty for all users
+	Statuses      []ScanStatus // Statuses the scan may have
+	Target        string       // Case-insensitive substring of the scan target
+	CreatedAfter  time.Time    // Scans created at or after this time
+	CreatedBefore time.Time    // Scans created before this time
+	Tags          []string     // Tags the scan must all have
+	ParentID      string       // Scan the listed scans are shards of, empty for top-level scans
+	Deleted       bool         // List the scans in the trash instead of the other scans
+}
+
+// Matches reports whether the scan matches the filter
+func (f ScanFilter) Matches(scan *Scan) bool {
+	if f.UserID != "" && scan.UserID != f.UserID {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, scan.Status) {
+		return false
+	}
+	if scan.ParentID != f.ParentID {
+		return false
+	}
+	if (scan.DeletedAt != nil) != f.Deleted {
+		return false
+	}
+	if f.Target != "" && !strings.Contains(strings.ToLower(scan.Options.Target), strings.ToLower(f.Target)) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && scan.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !scan.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !slices.Contains(scan.Options.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxScanTags is the maximum number of tags of a scan
+const maxScanTags = 20
+
+// scanTagPattern matches valid scan tags, e.g. "prod", "team:network" or "project/web-1"
+var scanTagPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:/-]{0,63}$`)
+
+// validateTags checks the tags of a scan
+func validateTags(tags []string) error {
+	if len(tags) > maxScanTags {
+		return errors.NewInvalidField("tags", "max", fmt.Sprintf("a scan may have at most %d tags", maxScanTags))
+	}
+	for _, tag := range tags {
+		if !scanTagPattern.MatchString(tag) {
+			return errors.NewInvalidField("tags", "format", "invalid tag "+strconv.Quote(tag)+": tags are up to 64 letters, digits and . _ : / - characters")
+		}
+	}
+	return nil
+}
+
+// ScanSortField represents a field scan listings can be sorted by
+type ScanSortField string
+
+// Scan sort field constants
+const (
+	ScanSortCreatedAt   ScanSortField = "created_at"
+	ScanSortCompletedAt ScanSortField = "completed_at"
+	ScanSortDuration    ScanSortField = "duration"
+	ScanSortStatus      ScanSortField = "status"
+)
+
+// ScanSort represents the order of a scan listing.
+// The zero value orders by creation time, newest first.
+type ScanSort struct {
+	Field     ScanSortField `json:"f,omitempty"` // Field to sort by, defaults to created_at
+	Ascending bool          `json:"a,omitempty"` // Sort in ascending instead of descending order
+}
+
+// ParseScanSort parses the sort field and order ("asc" or "desc") of a listing request
+func ParseScanSort(field, order string) (ScanSort, error) {
+	var sort ScanSort
+
+	switch ScanSortField(strings.ToLower(field)) {
+	case "", ScanSortCreatedAt:
+		sort.Field = ScanSortCreatedAt
+	case ScanSortCompletedAt:
+		sort.Field = ScanSortCompletedAt
+	case ScanSortDuration:
+		sort.Field = ScanSortDuration
+	case ScanSortStatus:
+		sort.Field = ScanSortStatus
+	default:
+		return sort, errors.NewInvalidInput("invalid sort field: "+field, nil)
+	}
+
+	switch strings.ToLower(order) {
+	case "", "desc":
+	case "asc":
+		sort.Ascending = true
+	default:
+		return sort, errors.NewInvalidInput("invalid sort order: "+order, nil)
+	}
+
+	return sort, nil
+}
+
+// Compare returns a negative number if scan a comes before scan b in the listing and a
+// positive number if it comes after. Scans with equal sort values are ordered by creation
+// time (newest first) and ID, so the order is total and stable across pages. Scans that
+// have not completed come last when sorting by completion time, in either direction.
+func (s ScanSort) Compare(a, b *Scan) int {
+	var order int
+	switch s.Field {
+	case ScanSortCompletedAt:
+		switch {
+		case a.CompletedAt == nil && b.CompletedAt != nil:
+			return 1
+		case a.CompletedAt != nil && b.CompletedAt == nil:
+			return -1
+		case a.CompletedAt != nil:
+			order = a.CompletedAt.Compare(*b.CompletedAt)
+		}
+	case ScanSortDuration:
+		order = cmp.Compare(a.Duration(), b.Duration())
+	case ScanSortStatus:
+		order = cmp.Compare(a.Status, b.Status)
+	default:
+		order = a.CreatedAt.Compare(b.CreatedAt)
+	}
+
+	if order != 0 {
+		if !s.Ascending {
+			order = -order
+		}
+		return order
+	}
+
+	if order := b.CreatedAt.Compare(a.CreatedAt); order != 0 {
+		return order
+	}
+	return cmp.Compare(b.ID, a.ID)
+}
+
+// Less reports whether scan a comes before scan b in the listing
+func (s ScanSort) Less(a, b *Scan) bool {
+	return s.Compare(a, b) < 0
+}
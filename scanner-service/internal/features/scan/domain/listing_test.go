@@ -0,0 +1,133 @@
+package domain_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestScanFilter(t *testing.T) {
+	now := time.Now()
+	scan := &domain.Scan{
+		UserID:    "alice",
+		Status:    domain.ScanStatusCompleted,
+		Options:   domain.ScanOptions{Target: "Example.com", Tags: []string{"prod", "team:network"}},
+		CreatedAt: now,
+	}
+
+	assert.True(t, domain.ScanFilter{}.Matches(scan))
+	assert.True(t, domain.ScanFilter{UserID: "alice", Statuses: []domain.ScanStatus{domain.ScanStatusCompleted}, Target: "example"}.Matches(scan))
+	assert.False(t, domain.ScanFilter{UserID: "bob"}.Matches(scan))
+	assert.False(t, domain.ScanFilter{Statuses: []domain.ScanStatus{domain.ScanStatusFailed}}.Matches(scan))
+	assert.True(t, domain.ScanFilter{Statuses: []domain.ScanStatus{domain.ScanStatusFailed, domain.ScanStatusCompleted}}.Matches(scan))
+	assert.False(t, domain.ScanFilter{Target: "example.org"}.Matches(scan))
+
+	// Scans must have all tags of the filter
+	assert.True(t, domain.ScanFilter{Tags: []string{"team:network", "prod"}}.Matches(scan))
+	assert.False(t, domain.ScanFilter{Tags: []string{"prod", "staging"}}.Matches(scan))
+
+	// The created range includes its start and excludes its end
+	assert.True(t, domain.ScanFilter{CreatedAfter: now, CreatedBefore: now.Add(time.Second)}.Matches(scan))
+	assert.False(t, domain.ScanFilter{CreatedBefore: now}.Matches(scan))
+	assert.False(t, domain.ScanFilter{CreatedAfter: now.Add(time.Second)}.Matches(scan))
+
+	// Scans in the trash are only listed when asked for
+	assert.False(t, domain.ScanFilter{Deleted: true}.Matches(scan))
+	scan.DeletedAt = &now
+	assert.False(t, domain.ScanFilter{}.Matches(scan))
+	assert.True(t, domain.ScanFilter{Deleted: true}.Matches(scan))
+}
+
+func TestScanSort(t *testing.T) {
+	now := time.Now()
+	completed := func(d time.Duration) *time.Time {
+		end := now.Add(d)
+		return &end
+	}
+
+	scans := []*domain.Scan{
+		{ID: "a", Status: domain.ScanStatusRunning, CreatedAt: now, StartedAt: &now},
+		{ID: "b", Status: domain.ScanStatusCompleted, CreatedAt: now.Add(time.Minute), StartedAt: &now, CompletedAt: completed(time.Minute)},
+		{ID: "c", Status: domain.ScanStatusFailed, CreatedAt: now.Add(2 * time.Minute), StartedAt: &now, CompletedAt: completed(time.Second)},
+	}
+
+	ids := func(order domain.ScanSort) []string {
+		sorted := append([]*domain.Scan(nil), scans...)
+		sort.Slice(sorted, func(i, j int) bool { return order.Less(sorted[i], sorted[j]) })
+		return []string{sorted[0].ID, sorted[1].ID, sorted[2].ID}
+	}
+
+	assert.Equal(t, []string{"c", "b", "a"}, ids(domain.ScanSort{}))
+	assert.Equal(t, []string{"a", "b", "c"}, ids(domain.ScanSort{Field: domain.ScanSortCreatedAt, Ascending: true}))
+	assert.Equal(t, []string{"b", "c", "a"}, ids(domain.ScanSort{Field: domain.ScanSortDuration}))
+	assert.Equal(t, []string{"b", "c", "a"}, ids(domain.ScanSort{Field: domain.ScanSortStatus, Ascending: true}))
+
+	// Scans that have not completed come last in either direction
+	assert.Equal(t, []string{"b", "c", "a"}, ids(domain.ScanSort{Field: domain.ScanSortCompletedAt}))
+	assert.Equal(t, []string{"c", "b", "a"}, ids(domain.ScanSort{Field: domain.ScanSortCompletedAt, Ascending: true}))
+
+	parsed, err := domain.ParseScanSort("duration", "asc")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScanSort{Field: domain.ScanSortDuration, Ascending: true}, parsed)
+
+	_, err = domain.ParseScanSort("target", "")
+	assert.Error(t, err)
+	_, err = domain.ParseScanSort("", "sideways")
+	assert.Error(t, err)
+}
+
+func TestCheckScanTags(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	ctx := principalContext("test-user", authdomain.RoleOperator)
+
+	service := domain.NewScanService(new(MockScanAdapter), new(MockScanRepository), log, 10)
+
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", Tags: []string{"prod", "team:network", "project/web-1"}}))
+
+	err := service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", Tags: []string{"two words"}})
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	require.Len(t, scanErr.Fields, 1)
+	assert.Equal(t, "tags", scanErr.Fields[0].Field)
+	assert.Equal(t, "format", scanErr.Fields[0].Constraint)
+
+	tooMany := make([]string, 21)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag-%d", i)
+	}
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", Tags: tooMany}))
+}
+
+func TestCountScans(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	service := domain.NewScanService(new(MockScanAdapter), repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleViewer)
+
+	filter := domain.ScanFilter{UserID: "alice", Statuses: []domain.ScanStatus{domain.ScanStatusRunning}}
+	repository.On("CountScans", filter).Return(3, nil)
+
+	count, err := service.CountScans(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// Counting follows the permissions and checks of listings
+	_, err = service.CountScans(ctx, domain.ScanFilter{})
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrForbidden, scanErr.Type)
+
+	now := time.Now()
+	_, err = service.CountScans(ctx, domain.ScanFilter{UserID: "alice", CreatedAfter: now, CreatedBefore: now})
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrInvalidInput, scanErr.Type)
+}
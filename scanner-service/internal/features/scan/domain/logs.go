@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// LogStream is the output stream of a scanner process a log line was written to
+type LogStream string
+
+// Log stream constants
+const (
+	LogStreamStdout LogStream = "stdout"
+	LogStreamStderr LogStream = "stderr"
+)
+
+// maxLogLineLength is the length log lines are cut at, so a scanner writing without
+// newlines cannot grow a line without bounds
+const maxLogLineLength = 4096
+
+// ScanLogLine is a line a scanner process wrote while running a scan
+type ScanLogLine struct {
+	Time   time.Time `json:"time"`   // When the line was written
+	Stream LogStream `json:"stream"` // Stream the line was written to
+	Text   string    `json:"text"`   // Line without the trailing newline
+}
+
+// ScanLogs are the most recent lines the scanner processes of a scan wrote
+type ScanLogs struct {
+	ScanID  string        `json:"scan_id"`
+	Lines   []ScanLogLine `json:"lines"`
+	Dropped int           `json:"dropped"` // Number of older lines dropped from the buffer
+}
+
+// ScanLog is a ring buffer of the output lines of the scanner processes of a scan.
+// It is safe for concurrent use, e.g. by the processes of parallel child scans.
+type ScanLog struct {
+	mu       sync.Mutex
+	lines    []ScanLogLine
+	next     int // Index the next line is written to once the buffer is full
+	dropped  int
+	maxLines int
+}
+
+// NewScanLog creates a scan log keeping the last maxLines lines
+func NewScanLog(maxLines int) *ScanLog {
+	return &ScanLog{maxLines: max(maxLines, 1)}
+}
+
+// Append adds a line to the log, dropping the oldest line when the log is full
+func (l *ScanLog) Append(stream LogStream, text string) {
+	if len(text) > maxLogLineLength {
+		text = text[:maxLogLineLength]
+	}
+	line := ScanLogLine{Time: time.Now(), Stream: stream, Text: text}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.lines) < l.maxLines {
+		l.lines = append(l.lines, line)
+		return
+	}
+	l.lines[l.next] = line
+	l.next = (l.next + 1) % l.maxLines
+	l.dropped++
+}
+
+// Lines returns the lines of the log, oldest first, and the number of dropped lines
+func (l *ScanLog) Lines() ([]ScanLogLine, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lines := make([]ScanLogLine, 0, len(l.lines))
+	lines = append(lines, l.lines[l.next:]...)
+	lines = append(lines, l.lines[:l.next]...)
+	return lines, l.dropped
+}
+
+// Writer returns a writer appending the lines written to it to the log. Close
+// flushes a final line without a trailing newline.
+func (l *ScanLog) Writer(stream LogStream) io.WriteCloser {
+	return &scanLogWriter{log: l, stream: stream}
+}
+
+// scanLogWriter splits the output of a process into log lines
+type scanLogWriter struct {
+	log     *ScanLog
+	stream  LogStream
+	partial []byte
+}
+
+func (w *scanLogWriter) Write(p []byte) (int, error) {
+	data := append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		w.log.Append(w.stream, string(bytes.TrimSuffix(data[:i], []byte("\r"))))
+		data = data[i+1:]
+	}
+	if len(data) > maxLogLineLength {
+		w.log.Append(w.stream, string(data))
+		data = nil
+	}
+	w.partial = append(w.partial[:0], data...)
+	return len(p), nil
+}
+
+func (w *scanLogWriter) Close() error {
+	if len(w.partial) > 0 {
+		w.log.Append(w.stream, string(w.partial))
+		w.partial = nil
+	}
+	return nil
+}
+
+// scanLogContextKey is the context key of the log of the running scan
+type scanLogContextKey struct{}
+
+// WithScanLog returns a copy of ctx carrying the log scan adapters write the output
+// of scanner processes to
+func WithScanLog(ctx context.Context, log *ScanLog) context.Context {
+	return context.WithValue(ctx, scanLogContextKey{}, log)
+}
+
+// ScanLogFromContext returns the log of the running scan carried by ctx, if any
+func ScanLogFromContext(ctx context.Context) (*ScanLog, bool) {
+	log, ok := ctx.Value(scanLogContextKey{}).(*ScanLog)
+	return log, ok
+}
+
+// scanLogStore keeps the logs of the most recent scans
+type scanLogStore struct {
+	mu       sync.Mutex
+	logs     map[string]*list.Element
+	order    *list.List // Scan IDs, oldest first
+	maxLines int        // Lines kept per scan, 0 disables capture
+	maxScans int        // Scans whose logs are kept
+}
+
+type scanLogEntry struct {
+	scanID string
+	log    *ScanLog
+}
+
+// create creates the log of a scan, replacing a previous log of the scan, and evicts
+// the log of the oldest scan if too many are kept. It returns nil if capture is disabled.
+func (s *scanLogStore) create(scanID string) *ScanLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.maxLines <= 0 || s.maxScans <= 0 {
+		return nil
+	}
+	if s.logs == nil {
+		s.logs = make(map[string]*list.Element)
+		s.order = list.New()
+	}
+
+	if elem, ok := s.logs[scanID]; ok {
+		s.order.Remove(elem)
+	}
+	log := NewScanLog(s.maxLines)
+	s.logs[scanID] = s.order.PushBack(&scanLogEntry{scanID: scanID, log: log})
+
+	for s.order.Len() > s.maxScans {
+		oldest := s.order.Front()
+		s.order.Remove(oldest)
+		delete(s.logs, oldest.Value.(*scanLogEntry).scanID)
+	}
+	return log
+}
+
+// get returns the log of a scan
+func (s *scanLogStore) get(scanID string) (*ScanLog, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.logs[scanID]
+	if !ok {
+		return nil, false
+	}
+	return elem.Value.(*scanLogEntry).log, true
+}
+
+// SetLogCapture sets how many output lines of the scanner processes are kept per scan
+// and for how many of the most recent scans. Zero lines disables capture.
+func (s *ScanService) SetLogCapture(maxLines, maxScans int) {
+	s.scanLogs.mu.Lock()
+	s.scanLogs.maxLines = maxLines
+	s.scanLogs.maxScans = maxScans
+	s.scanLogs.mu.Unlock()
+}
+
+// GetScanLogs returns the output the scanner processes of a scan wrote so far.
+// Logs are kept in memory for the most recent scans only.
+func (s *ScanService) GetScanLogs(ctx context.Context, scanID string) (*ScanLogs, error) {
+	// Check that the scan exists and the caller may view it
+	if _, err := s.GetScan(ctx, scanID); err != nil {
+		return nil, err
+	}
+
+	logs := &ScanLogs{ScanID: scanID, Lines: make([]ScanLogLine, 0)}
+	if log, ok := s.scanLogs.get(scanID); ok {
+		logs.Lines, logs.Dropped = log.Lines()
+	}
+	return logs, nil
+}
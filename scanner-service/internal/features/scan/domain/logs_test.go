@@ -0,0 +1,137 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// texts returns the text of the log lines
+func texts(lines []domain.ScanLogLine) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = line.Text
+	}
+	return result
+}
+
+func TestScanLogKeepsMostRecentLines(t *testing.T) {
+	log := domain.NewScanLog(3)
+	for i := 1; i <= 5; i++ {
+		log.Append(domain.LogStreamStdout, fmt.Sprintf("line %d", i))
+	}
+
+	lines, dropped := log.Lines()
+	assert.Equal(t, []string{"line 3", "line 4", "line 5"}, texts(lines))
+	assert.Equal(t, 2, dropped)
+}
+
+func TestScanLogWriterSplitsLines(t *testing.T) {
+	log := domain.NewScanLog(10)
+	stdout := log.Writer(domain.LogStreamStdout)
+	stderr := log.Writer(domain.LogStreamStderr)
+
+	// Lines may be split across writes and end with CRLF
+	io.WriteString(stdout, "Starting Nmap 7.94\nNmap scan rep")
+	io.WriteString(stderr, "Failed to resolve \"nohost\".\r\n")
+	io.WriteString(stdout, "ort for 10.0.0.1\nNmap done")
+	stdout.Close()
+
+	lines, dropped := log.Lines()
+	assert.Equal(t, []string{"Starting Nmap 7.94", "Failed to resolve \"nohost\".", "Nmap scan report for 10.0.0.1", "Nmap done"}, texts(lines))
+	assert.Equal(t, domain.LogStreamStderr, lines[1].Stream)
+	assert.Equal(t, domain.LogStreamStdout, lines[3].Stream)
+	assert.Zero(t, dropped)
+
+	// Output without newlines is cut into lines of bounded length
+	long := domain.NewScanLog(10)
+	writer := long.Writer(domain.LogStreamStdout)
+	io.WriteString(writer, strings.Repeat("x", 10000))
+	writer.Close()
+	lines, _ = long.Lines()
+	require.NotEmpty(t, lines)
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line.Text), 4096)
+	}
+}
+
+// loggingScanAdapter is a scan adapter writing to the log of the scan and failing it
+type loggingScanAdapter struct {
+	MockScanAdapter
+}
+
+func (a *loggingScanAdapter) ExecuteScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	if scanLog, ok := domain.ScanLogFromContext(ctx); ok {
+		scanLog.Append(domain.LogStreamStderr, "Failed to resolve \""+options.Target+"\".")
+	}
+	return nil, fmt.Errorf("nmap scan failed")
+}
+
+func TestGetScanLogs(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+
+	service := domain.NewScanService(&loggingScanAdapter{}, repository, log, 10)
+	service.SetLogCapture(100, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "nohost.invalid", Timeout: time.Minute})
+	require.NoError(t, err)
+	repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+	assert.Eventually(t, func() bool {
+		current, err := service.GetScan(ctx, scan.ID)
+		return err == nil && current.Status == domain.ScanStatusFailed
+	}, time.Second, 10*time.Millisecond)
+
+	logs, err := service.GetScanLogs(ctx, scan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, scan.ID, logs.ScanID)
+	require.Len(t, logs.Lines, 1)
+	assert.Equal(t, domain.LogStreamStderr, logs.Lines[0].Stream)
+	assert.Equal(t, "Failed to resolve \"nohost.invalid\".", logs.Lines[0].Text)
+
+	// Other users cannot read the logs
+	_, err = service.GetScanLogs(principalContext("bob", authdomain.RoleViewer), scan.ID)
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+}
+
+func TestGetScanLogsDisabled(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+
+	// Capture is disabled unless configured
+	service := domain.NewScanService(&loggingScanAdapter{}, repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.1", Timeout: time.Minute})
+	require.NoError(t, err)
+	repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+	assert.Eventually(t, func() bool {
+		current, err := service.GetScan(ctx, scan.ID)
+		return err == nil && current.Status == domain.ScanStatusFailed
+	}, time.Second, 10*time.Millisecond)
+
+	logs, err := service.GetScanLogs(ctx, scan.ID)
+	require.NoError(t, err)
+	assert.Empty(t, logs.Lines)
+}
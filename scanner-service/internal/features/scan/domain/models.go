@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
@@ -27,8 +28,55 @@ const (
 	ScanTypeVersion ScanType = "VERSION" // -sV: Version detection
 	ScanTypeScript  ScanType = "SCRIPT"  // -sC: Script scan
 	ScanTypeAll     ScanType = "ALL"     // -A: Aggressive scan (-sV -sC -O)
+	ScanTypePing    ScanType = "PING"    // -sn: Host discovery only, no port scan
 )
 
+// ScanEngine represents the scanner running a scan
+type ScanEngine string
+
+// Scan engine constants
+const (
+	ScanEngineNmap     ScanEngine = "nmap"     // Default engine
+	ScanEngineMasscan  ScanEngine = "masscan"  // Asynchronous port discovery over large ranges
+	ScanEngineRustscan ScanEngine = "rustscan" // Fast port discovery followed by nmap service detection
+	ScanEngineZmap     ScanEngine = "zmap"     // Single-port surveys of very large ranges
+	ScanEngineNaabu    ScanEngine = "naabu"    // Port discovery where nmap SYN scans are slow or blocked
+	ScanEngineHybrid   ScanEngine = "hybrid"   // Fast port sweep followed by nmap service and script scans
+)
+
+// ScanMode represents a preset selecting how a scan is run
+type ScanMode string
+
+// Scan mode constants
+const (
+	ScanModeFast ScanMode = "fast" // Rustscan port discovery, then nmap on the open ports only
+)
+
+// ResolvedEngine returns the engine running the scan, resolving the scan mode
+func (o ScanOptions) ResolvedEngine() ScanEngine {
+	if o.Engine != "" {
+		return o.Engine
+	}
+	if o.Mode == ScanModeFast {
+		return ScanEngineRustscan
+	}
+	return ScanEngineNmap
+}
+
+// RequiresRawSocket reports whether nmap needs raw socket privileges for the scan
+func (o ScanOptions) RequiresRawSocket() bool {
+	switch o.ScanType {
+	case ScanTypeSYN, ScanTypeUDP, ScanTypeAll:
+		return true
+	}
+	return o.OSDetection || o.UsesEvasion()
+}
+
+// UsesEvasion reports whether the scan uses options evading firewalls and intrusion detection
+func (o ScanOptions) UsesEvasion() bool {
+	return len(o.Decoys) > 0 || o.SourcePort > 0 || o.Fragment || o.DataLength > 0
+}
+
 // TimingTemplate represents the timing template for a scan
 type TimingTemplate int
 
@@ -44,40 +92,119 @@ const (
 
 // ScanOptions represents the options for a scan
 type ScanOptions struct {
-	Target           string         `json:"target"`            // Target host(s) or network
-	Ports            string         `json:"ports"`             // Port specification (e.g., "22,80,443" or "1-1000")
-	ScanType         ScanType       `json:"scan_type"`         // Type of scan
-	TimingTemplate   TimingTemplate `json:"timing_template"`   // Timing template
-	ServiceDetection bool           `json:"service_detection"` // Enable service/version detection
-	OSDetection      bool           `json:"os_detection"`      // Enable OS detection
-	ScriptScan       bool           `json:"script_scan"`       // Enable script scanning
-	ExtraOptions     []string       `json:"extra_options"`     // Extra command-line options
-	Timeout          time.Duration  `json:"timeout"`           // Scan timeout
+	Target           string            `json:"target"`                    // Target host(s) or network
+	Ports            string            `json:"ports"`                     // Port specification (e.g., "22,80,443" or "1-1000")
+	ScanType         ScanType          `json:"scan_type"`                 // Type of scan
+	TimingTemplate   TimingTemplate    `json:"timing_template"`           // Timing template
+	ServiceDetection bool              `json:"service_detection"`         // Enable service/version detection
+	OSDetection      bool              `json:"os_detection"`              // Enable OS detection
+	ScriptScan       bool              `json:"script_scan"`               // Enable script scanning
+	ExtraOptions     []string          `json:"extra_options"`             // Extra command-line options
+	Timeout          time.Duration     `json:"timeout"`                   // Scan timeout
+	HostTimeout      time.Duration     `json:"host_timeout,omitempty"`    // Time spent on a single host before it is skipped, 0 for no limit
+	MinRate          int               `json:"min_rate,omitempty"`        // Packets per second sent at least, 0 for the timing template default
+	MaxRate          int               `json:"max_rate,omitempty"`        // Packets per second sent at most, 0 for no limit
+	MaxParallelism   int               `json:"max_parallelism,omitempty"` // Probes outstanding at most, 0 for the timing template default
+	MaxRetries       *int              `json:"max_retries,omitempty"`     // Probe retransmissions at most, nil for the server default
+	StateFile        string            `json:"-"`                         // File nmap records its progress in, set by the service for resumable scans
+	Decoys           []string          `json:"decoys,omitempty"`          // Decoy addresses the probes appear to come from as well (-D)
+	SourcePort       int               `json:"source_port,omitempty"`     // Source port of the probes (-g), 0 for random ports
+	Fragment         bool              `json:"fragment,omitempty"`        // Split probes into small IP fragments (-f)
+	DataLength       int               `json:"data_length,omitempty"`     // Random bytes appended to the probes (--data-length)
+	Discovery        []DiscoveryMethod `json:"discovery,omitempty"`       // Methods expanding a domain target into hosts before scanning
+	Agent            string            `json:"agent,omitempty"`           // Agent running the scan from its network, empty for the local scanner
+	Engine           ScanEngine        `json:"engine,omitempty"`          // Scanner running the scan, empty for nmap
+	Mode             ScanMode          `json:"mode,omitempty"`            // Preset selecting the engine, empty for a normal scan
+	Tags             []string          `json:"tags,omitempty"`            // Labels grouping the scan in listings, e.g. a project or an environment
 }
 
 // Scan represents a scan job
 type Scan struct {
-	ID          string      `json:"id"`           // Unique identifier
-	UserID      string      `json:"user_id"`      // User who initiated the scan
-	Options     ScanOptions `json:"options"`      // Scan options
-	Status      ScanStatus  `json:"status"`       // Current status
-	Progress    float64     `json:"progress"`     // Progress percentage (0-100)
-	CreatedAt   time.Time   `json:"created_at"`   // When the scan was created
-	StartedAt   *time.Time  `json:"started_at"`   // When the scan started
-	CompletedAt *time.Time  `json:"completed_at"` // When the scan completed
-	Error       string      `json:"error"`        // Error message if failed
-	ResultID    string      `json:"result_id"`    // Reference to scan result
+	ID                  string              `json:"id"`                             // Unique identifier
+	UserID              string              `json:"user_id"`                        // User who initiated the scan
+	TenantID            string              `json:"tenant_id,omitempty"`            // Tenant (organization) of the user
+	Options             ScanOptions         `json:"options"`                        // Scan options
+	Status              ScanStatus          `json:"status"`                         // Current status
+	Progress            float64             `json:"progress"`                       // Progress percentage (0-100)
+	CurrentPhase        ScanPhase           `json:"current_phase,omitempty"`        // Phase of the running scan, or the phase a failed scan stopped in
+	EstimatedCompletion *time.Time          `json:"estimated_completion,omitempty"` // When the current phase is estimated to complete, from the scanner progress output
+	CreatedAt           time.Time           `json:"created_at"`                     // When the scan was created
+	StartedAt           *time.Time          `json:"started_at"`                     // When the scan started
+	CompletedAt         *time.Time          `json:"completed_at"`                   // When the scan completed
+	Error               string              `json:"error"`                          // Error message if failed
+	Failure             *ScanFailure        `json:"failure,omitempty"`              // How the scanner process of the failed scan failed, if it did
+	ResultID            string              `json:"result_id"`                      // Reference to scan result
+	RequestID           string              `json:"request_id"`                     // ID of the API request that started the scan
+	Discovery           *DiscoveryResult    `json:"discovery,omitempty"`            // Outcome of the discovery stage, if requested
+	PipelineID          string              `json:"pipeline_id,omitempty"`          // Pipeline the scan is a stage of
+	WorkflowRunID       string              `json:"workflow_run_id,omitempty"`      // Workflow run the scan is a step of
+	ParentID            string              `json:"parent_id,omitempty"`            // Scan this scan is a shard of
+	ShardCount          int                 `json:"shard_count,omitempty"`          // Number of shards the scan was split into
+	Resumable           bool                `json:"resumable,omitempty"`            // Whether the progress of the failed or cancelled scan was saved
+	DeletedAt           *time.Time          `json:"deleted_at,omitempty"`           // When the scan was moved to the trash
+	Notes               []Note              `json:"notes,omitempty"`                // Notes users attached to the scan
+	BaselineViolations  []BaselineViolation `json:"baseline_violations,omitempty"`  // Open ports the baselines of the user do not expect
+}
+
+// Duration returns how long the scan ran, or zero if it has not completed
+func (s *Scan) Duration() time.Duration {
+	if s.StartedAt == nil || s.CompletedAt == nil {
+		return 0
+	}
+	return s.CompletedAt.Sub(*s.StartedAt)
+}
+
+// ParseScanStatus parses a scan status case-insensitively
+func ParseScanStatus(value string) (ScanStatus, bool) {
+	status := ScanStatus(strings.ToUpper(value))
+	switch status {
+	case ScanStatusPending, ScanStatusRunning, ScanStatusCompleted, ScanStatusFailed, ScanStatusCancelled:
+		return status, true
+	}
+	return "", false
+}
+
+// Terminal reports whether a scan in the status has stopped running
+func (s ScanStatus) Terminal() bool {
+	return s == ScanStatusCompleted || s == ScanStatusFailed || s == ScanStatusCancelled
 }
 
 // Host represents a host from a scan result
 type Host struct {
-	IP        string       `json:"ip"`        // IP address
-	Hostnames []string     `json:"hostnames"` // Hostnames
-	Status    string       `json:"status"`    // Host status (up/down)
-	OS        string       `json:"os"`        // Operating system
-	Ports     []Port       `json:"ports"`     // Open ports
-	Scripts   []Script     `json:"scripts"`   // Script results
-	Metadata  HostMetadata `json:"metadata"`  // Additional metadata
+	IP        string        `json:"ip"`              // IP address
+	Hostnames []string      `json:"hostnames"`       // Hostnames
+	Status    string        `json:"status"`          // Host status (up/down)
+	OS        string        `json:"os"`              // Operating system
+	Ports     []Port        `json:"ports"`           // Open ports
+	Scripts   []Script      `json:"scripts"`         // Script results
+	Metadata  HostMetadata  `json:"metadata"`        // Additional metadata
+	Geo       *GeoInfo      `json:"geo,omitempty"`   // Geolocation and autonomous system, set by GeoIP enrichment
+	Owner     *NetworkOwner `json:"owner,omitempty"` // Registered owner of the netblock, set by RDAP enrichment
+	Notes     []Note        `json:"notes,omitempty"` // Annotations users attached to the host
+}
+
+// NetworkOwner represents the registration data of the netblock containing a host
+type NetworkOwner struct {
+	Handle       string   `json:"handle"`                 // Registry handle of the netblock
+	Name         string   `json:"name,omitempty"`         // Netblock name
+	StartAddress string   `json:"start_address"`          // First address of the netblock
+	EndAddress   string   `json:"end_address"`            // Last address of the netblock
+	Country      string   `json:"country,omitempty"`      // Registration country
+	Organization string   `json:"organization,omitempty"` // Registrant organization
+	AbuseEmails  []string `json:"abuse_emails,omitempty"` // Abuse contact e-mail addresses
+	Source       string   `json:"source,omitempty"`       // URL of the RDAP record
+}
+
+// GeoInfo represents the geolocation and autonomous system of a host
+type GeoInfo struct {
+	CountryCode    string  `json:"country_code,omitempty"`    // ISO 3166-1 alpha-2 country code
+	Country        string  `json:"country,omitempty"`         // Country name (English)
+	City           string  `json:"city,omitempty"`            // City name (English)
+	Latitude       float64 `json:"latitude,omitempty"`        // Approximate latitude
+	Longitude      float64 `json:"longitude,omitempty"`       // Approximate longitude
+	ASN            uint    `json:"asn,omitempty"`             // Autonomous system number
+	ASOrganization string  `json:"as_organization,omitempty"` // Autonomous system organization
+	ISP            string  `json:"isp,omitempty"`             // Internet service provider
 }
 
 // Port represents a port from a scan result
@@ -93,9 +220,11 @@ type Port struct {
 
 // Script represents a script result from a scan
 type Script struct {
-	ID     string            `json:"id"`     // Script ID
-	Output string            `json:"output"` // Script output
-	Data   map[string]string `json:"data"`   // Structured data
+	ID       string            `json:"id"`                 // Script ID
+	Output   string            `json:"output"`             // Script output
+	Data     map[string]string `json:"data"`               // Structured data, keyed by path, e.g. "validity.notAfter"
+	Port     int               `json:"port,omitempty"`     // Port the script ran against, 0 for host scripts
+	Protocol string            `json:"protocol,omitempty"` // Protocol of the port
 }
 
 // HostMetadata contains additional information about a host
@@ -109,31 +238,33 @@ type HostMetadata struct {
 
 // ScanResult represents the result of a scan
 type ScanResult struct {
-	ID         string    `json:"id"`          // Unique identifier
-	ScanID     string    `json:"scan_id"`     // Reference to scan
-	UserID     string    `json:"user_id"`     // User who initiated the scan
-	StartTime  time.Time `json:"start_time"`  // When the scan started
-	EndTime    time.Time `json:"end_time"`    // When the scan ended
-	Duration   float64   `json:"duration"`    // Duration in seconds
-	Command    string    `json:"command"`     // Command that was run
-	Summary    string    `json:"summary"`     // Scan summary
-	TotalHosts int       `json:"total_hosts"` // Total hosts scanned
-	UpHosts    int       `json:"up_hosts"`    // Hosts that were up
-	Hosts      []Host    `json:"hosts"`       // Host results
+	ID         string    `json:"id"`                // Unique identifier
+	ScanID     string    `json:"scan_id"`           // Reference to scan
+	UserID     string    `json:"user_id"`           // User who initiated the scan
+	StartTime  time.Time `json:"start_time"`        // When the scan started
+	EndTime    time.Time `json:"end_time"`          // When the scan ended
+	Duration   float64   `json:"duration"`          // Duration in seconds
+	Command    string    `json:"command"`           // Command that was run
+	Summary    string    `json:"summary"`           // Scan summary
+	TotalHosts int       `json:"total_hosts"`       // Total hosts scanned
+	UpHosts    int       `json:"up_hosts"`          // Hosts that were up
+	Hosts      []Host    `json:"hosts"`             // Host results
+	Partial    bool      `json:"partial,omitempty"` // Whether the scan timed out or was cancelled before it completed all hosts
 }
 
 // ScanSummary represents a summary of a scan
 type ScanSummary struct {
-	ID         string     `json:"id"`          // Unique identifier
-	UserID     string     `json:"user_id"`     // User who initiated the scan
-	Target     string     `json:"target"`      // Target that was scanned
-	Status     ScanStatus `json:"status"`      // Current status
-	StartTime  *time.Time `json:"start_time"`  // When the scan started
-	EndTime    *time.Time `json:"end_time"`    // When the scan ended
-	Duration   float64    `json:"duration"`    // Duration in seconds
-	TotalHosts int        `json:"total_hosts"` // Total hosts scanned
-	UpHosts    int        `json:"up_hosts"`    // Hosts that were up
-	OpenPorts  int        `json:"open_ports"`  // Total open ports found
-	VulnCount  int        `json:"vuln_count"`  // Number of vulnerabilities found
-	HasResults bool       `json:"has_results"` // Whether the scan has results
+	ID                 string              `json:"id"`                            // Unique identifier
+	UserID             string              `json:"user_id"`                       // User who initiated the scan
+	Target             string              `json:"target"`                        // Target that was scanned
+	Status             ScanStatus          `json:"status"`                        // Current status
+	StartTime          *time.Time          `json:"start_time"`                    // When the scan started
+	EndTime            *time.Time          `json:"end_time"`                      // When the scan ended
+	Duration           float64             `json:"duration"`                      // Duration in seconds
+	TotalHosts         int                 `json:"total_hosts"`                   // Total hosts scanned
+	UpHosts            int                 `json:"up_hosts"`                      // Hosts that were up
+	OpenPorts          int                 `json:"open_ports"`                    // Total open ports found
+	VulnCount          int                 `json:"vuln_count"`                    // Number of vulnerabilities found
+	HasResults         bool                `json:"has_results"`                   // Whether the scan has results
+	BaselineViolations []BaselineViolation `json:"baseline_violations,omitempty"` // Open ports the baselines of the user do not expect
 }
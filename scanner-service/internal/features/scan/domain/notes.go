@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/google/uuid"
+)
+
+// MaxNoteLength is the maximum length of the text of a note in bytes
+const MaxNoteLength = 4096
+
+// Note represents a free-text note a user attached to a scan or host, e.g. "known false positive"
+type Note struct {
+	ID        string    `json:"id"`         // Unique identifier
+	UserID    string    `json:"user_id"`    // User who wrote the note
+	Text      string    `json:"text"`       // Text of the note
+	CreatedAt time.Time `json:"created_at"` // When the note was written
+}
+
+// newNote validates the text of a note and creates it for the principal
+func newNote(principal *authdomain.Principal, text string) (Note, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Note{}, errors.NewInvalidInput("note text is required", nil)
+	}
+	if len(text) > MaxNoteLength {
+		return Note{}, errors.NewInvalidInput("note text must be at most "+strconv.Itoa(MaxNoteLength)+" bytes", nil)
+	}
+
+	return Note{
+		ID:        uuid.New().String(),
+		UserID:    principal.UserID,
+		Text:      text,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// removeNote returns the notes without the note with the given ID.
+// Only the author of the note or an admin may remove it.
+func removeNote(principal *authdomain.Principal, notes []Note, noteID string) ([]Note, error) {
+	i := slices.IndexFunc(notes, func(note Note) bool { return note.ID == noteID })
+	if i < 0 {
+		return nil, errors.NewNotFound("note not found", nil)
+	}
+	if !principal.CanAccess(notes[i].UserID) {
+		return nil, errors.NewForbidden("only the author of a note or an admin may delete it", nil)
+	}
+	return slices.Delete(slices.Clone(notes), i, i+1), nil
+}
+
+// AddScanNote attaches a note to a scan.
+// The caller must have the operator role and own the scan, or be an admin.
+func (s *ScanService) AddScanNote(ctx context.Context, scanID, text string) (*Note, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := newNote(principal, text)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.updateScanNotes(principal, scanID, func(notes []Note) ([]Note, error) {
+		return append(slices.Clip(notes), note), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// DeleteScanNote deletes a note of a scan.
+// The caller must have the operator role, own the scan and have written the note, or be an admin.
+func (s *ScanService) DeleteScanNote(ctx context.Context, scanID, noteID string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
+	return s.updateScanNotes(principal, scanID, func(notes []Note) ([]Note, error) {
+		return removeNote(principal, notes, noteID)
+	})
+}
+
+// updateScanNotes replaces the notes of a scan with the result of update
+func (s *ScanService) updateScanNotes(principal *authdomain.Principal, scanID string, update func([]Note) ([]Note, error)) error {
+	s.notesMu.Lock()
+	defer s.notesMu.Unlock()
+
+	scan, err := s.getScan(scanID)
+	if err != nil {
+		return err
+	}
+
+	if !principal.CanAccess(scan.UserID) {
+		return errors.NewNotFound("scan not found", nil)
+	}
+
+	// Active scans are changed in place, so that the worker running the scan keeps the notes
+	s.mu.Lock()
+	if active, ok := s.pool.liveScan(scan.ID); ok {
+		scan = active
+	}
+	notes, err := update(scan.Notes)
+	if err == nil {
+		scan.Notes = notes
+	}
+	updated := *scan
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.UpdateScan(&updated); err != nil {
+		return errors.NewInternal("failed to update scan", err)
+	}
+
+	return nil
+}
+
+// AddHostNote attaches an annotation to a host of a scan result.
+// The caller must have the operator role and own the result, or be an admin.
+func (s *ScanService) AddHostNote(ctx context.Context, resultID, hostIP, text string) (*Note, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := newNote(principal, text)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.updateHostNotes(principal, resultID, hostIP, func(notes []Note) ([]Note, error) {
+		return append(slices.Clip(notes), note), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// DeleteHostNote deletes an annotation of a host of a scan result.
+// The caller must have the operator role, own the result and have written the note, or be an admin.
+func (s *ScanService) DeleteHostNote(ctx context.Context, resultID, hostIP, noteID string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
+	return s.updateHostNotes(principal, resultID, hostIP, func(notes []Note) ([]Note, error) {
+		return removeNote(principal, notes, noteID)
+	})
+}
+
+// updateHostNotes replaces the notes of a host of a scan result with the result of update
+func (s *ScanService) updateHostNotes(principal *authdomain.Principal, resultID, hostIP string, update func([]Note) ([]Note, error)) error {
+	s.notesMu.Lock()
+	defer s.notesMu.Unlock()
+
+	result, err := s.repository.GetScanResultByID(resultID)
+	if err != nil {
+		return errors.NewNotFound("scan result not found", err)
+	}
+
+	if !principal.CanAccess(result.UserID) {
+		return errors.NewNotFound("scan result not found", nil)
+	}
+
+	i := slices.IndexFunc(result.Hosts, func(host Host) bool { return host.IP == hostIP })
+	if i < 0 {
+		return errors.NewNotFound("host "+hostIP+" not found in scan result", nil)
+	}
+
+	notes, err := update(result.Hosts[i].Notes)
+	if err != nil {
+		return err
+	}
+
+	// The hosts are shared with the stored result until it is saved
+	result.Hosts = slices.Clone(result.Hosts)
+	result.Hosts[i].Notes = notes
+	if err := s.repository.SaveScanResult(result); err != nil {
+		return errors.NewInternal("failed to save scan result", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestScanNotes(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	scan := &domain.Scan{ID: "scan-1", UserID: "alice", Status: domain.ScanStatusCompleted}
+	repository.On("GetScanByID", "scan-1").Return(scan, nil)
+	repository.On("UpdateScan", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(new(MockScanAdapter), repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	var scanErr *errors.Error
+
+	// Empty and oversized notes are rejected
+	_, err := service.AddScanNote(ctx, "scan-1", "  ")
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrInvalidInput, scanErr.Type)
+	_, err = service.AddScanNote(ctx, "scan-1", strings.Repeat("a", domain.MaxNoteLength+1))
+	assert.Error(t, err)
+
+	// Scans of other users are reported as not found
+	_, err = service.AddScanNote(principalContext("bob", authdomain.RoleOperator), "scan-1", "mine")
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+
+	note, err := service.AddScanNote(ctx, "scan-1", " retest after patch window ")
+	require.NoError(t, err)
+	assert.Equal(t, "retest after patch window", note.Text)
+	assert.Equal(t, "alice", note.UserID)
+	require.Len(t, scan.Notes, 1)
+
+	// Admins may delete notes of other users, which operators may not
+	scan.UserID = "bob"
+	err = service.DeleteScanNote(principalContext("bob", authdomain.RoleOperator), "scan-1", note.ID)
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrForbidden, scanErr.Type)
+	require.NoError(t, service.DeleteScanNote(principalContext("admin", authdomain.RoleAdmin), "scan-1", note.ID))
+	assert.Empty(t, scan.Notes)
+
+	err = service.DeleteScanNote(principalContext("admin", authdomain.RoleAdmin), "scan-1", note.ID)
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+}
+
+func TestHostNotes(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	stored := &domain.ScanResult{
+		ID:     "result-1",
+		UserID: "alice",
+		Hosts:  []domain.Host{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}},
+	}
+	result := *stored
+	repository.On("GetScanResultByID", "result-1").Return(&result, nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(new(MockScanAdapter), repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	// Hosts missing from the result are reported as not found
+	_, err := service.AddHostNote(ctx, "result-1", "10.0.0.3", "owned by team X")
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+
+	note, err := service.AddHostNote(ctx, "result-1", "10.0.0.2", "known false positive")
+	require.NoError(t, err)
+
+	saved := repository.Calls[len(repository.Calls)-1].Arguments.Get(0).(*domain.ScanResult)
+	require.Len(t, saved.Hosts[1].Notes, 1)
+	assert.Equal(t, *note, saved.Hosts[1].Notes[0])
+	assert.Empty(t, saved.Hosts[0].Notes)
+
+	// The hosts read from the repository are left unchanged
+	assert.Empty(t, stored.Hosts[1].Notes)
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// PageRequest represents a request for one page of a listing.
+// Listings have a total order (see ScanSort.Less), so a cursor identifies a stable
+// position even when scans are added while a client is paging.
+type PageRequest struct {
+	Limit  int    // Maximum number of items to return
+	Offset int    // Number of items to skip, ignored when Cursor is set
+	Cursor string // Opaque cursor returned as NextCursor of the previous page
+}
+
+// ScanPage represents one page of a scan listing
+type ScanPage struct {
+	Scans      []*Scan `json:"scans"`                 // Scans of the page
+	TotalCount int     `json:"total_count"`           // Number of scans matching the listing
+	HasMore    bool    `json:"has_more"`              // Whether more scans follow this page
+	NextCursor string  `json:"next_cursor,omitempty"` // Cursor of the next page, set when HasMore is true
+}
+
+// ScanCursor represents a position in a scan listing.
+// It holds the sort keys of the last scan of the previous page.
+type ScanCursor struct {
+	Sort        ScanSort      `json:"s"`            // Order of the listing the cursor belongs to
+	CreatedAt   time.Time     `json:"c"`            // Creation time of the scan
+	ID          string        `json:"id"`           // ID of the scan
+	Status      ScanStatus    `json:"st,omitempty"` // Status of the scan
+	Duration    time.Duration `json:"d,omitempty"`  // Duration of the scan
+	CompletedAt *time.Time    `json:"ca,omitempty"` // Completion time of the scan, nil if it has not completed
+}
+
+// NewScanCursor creates a cursor positioned after the given scan in a listing with the given order
+func NewScanCursor(scan *Scan, sort ScanSort) ScanCursor {
+	return ScanCursor{
+		Sort:        sort,
+		CreatedAt:   scan.CreatedAt,
+		ID:          scan.ID,
+		Status:      scan.Status,
+		Duration:    scan.Duration(),
+		CompletedAt: scan.CompletedAt,
+	}
+}
+
+// Encode returns the opaque string form of the cursor
+func (c ScanCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// After reports whether the scan comes after the cursor position in listing order
+func (c ScanCursor) After(scan *Scan) bool {
+	// Rebuild the sort keys of the scan the cursor points at
+	position := &Scan{
+		ID:          c.ID,
+		CreatedAt:   c.CreatedAt,
+		Status:      c.Status,
+		CompletedAt: c.CompletedAt,
+	}
+	if c.CompletedAt != nil {
+		startedAt := c.CompletedAt.Add(-c.Duration)
+		position.StartedAt = &startedAt
+	}
+	return c.Sort.Less(position, scan)
+}
+
+// DecodeScanCursor parses a cursor returned by Encode.
+// The cursor must belong to a listing with the given order.
+func DecodeScanCursor(cursor string, sort ScanSort) (ScanCursor, error) {
+	var c ScanCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return c, errors.NewInvalidInput("invalid cursor", err)
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return c, errors.NewInvalidInput("invalid cursor", err)
+	}
+	if normalizeSort(c.Sort) != normalizeSort(sort) {
+		return c, errors.NewInvalidInput("cursor does not match the sort order", nil)
+	}
+
+	return c, nil
+}
+
+// normalizeSort replaces the default sort field with its explicit value
+func normalizeSort(sort ScanSort) ScanSort {
+	if sort.Field == "" {
+		sort.Field = ScanSortCreatedAt
+	}
+	return sort
+}
@@ -0,0 +1,105 @@
+package domain_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestScanCursor(t *testing.T) {
+	now := time.Now()
+	scans := []*domain.Scan{
+		{ID: "a", CreatedAt: now},
+		{ID: "b", CreatedAt: now},
+		{ID: "c", CreatedAt: now.Add(-time.Minute)},
+		{ID: "d", CreatedAt: now.Add(time.Minute)},
+	}
+
+	// Newest first, ties broken by ID
+	sort.Slice(scans, func(i, j int) bool { return domain.ScanSort{}.Less(scans[i], scans[j]) })
+	assert.Equal(t, []string{"d", "b", "a", "c"}, []string{scans[0].ID, scans[1].ID, scans[2].ID, scans[3].ID})
+
+	cursor, err := domain.DecodeScanCursor(domain.NewScanCursor(scans[1], domain.ScanSort{}).Encode(), domain.ScanSort{})
+	require.NoError(t, err)
+	assert.Equal(t, "b", cursor.ID)
+	assert.True(t, cursor.CreatedAt.Equal(now))
+
+	assert.False(t, cursor.After(scans[0]))
+	assert.False(t, cursor.After(scans[1]))
+	assert.True(t, cursor.After(scans[2]))
+	assert.True(t, cursor.After(scans[3]))
+
+	_, err = domain.DecodeScanCursor("not-a-cursor", domain.ScanSort{})
+	assert.Error(t, err)
+
+	// Cursors are bound to the order of the listing they were created for
+	_, err = domain.DecodeScanCursor(cursor.Encode(), domain.ScanSort{Field: domain.ScanSortStatus})
+	assert.Error(t, err)
+}
+
+func TestScanCursorByCompletionTime(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		value := now.Add(d)
+		return &value
+	}
+	scans := []*domain.Scan{
+		{ID: "a", CreatedAt: now, StartedAt: at(0), CompletedAt: at(time.Minute)},
+		{ID: "b", CreatedAt: now, StartedAt: at(0), CompletedAt: at(time.Second)},
+		{ID: "c", CreatedAt: now, StartedAt: at(0)},
+		{ID: "d", CreatedAt: now.Add(time.Minute)},
+	}
+	order := domain.ScanSort{Field: domain.ScanSortCompletedAt, Ascending: true}
+	sort.Slice(scans, func(i, j int) bool { return order.Less(scans[i], scans[j]) })
+	assert.Equal(t, []string{"b", "a", "d", "c"}, []string{scans[0].ID, scans[1].ID, scans[2].ID, scans[3].ID})
+
+	// Cursors resume after completed and running scans alike
+	cursor, err := domain.DecodeScanCursor(domain.NewScanCursor(scans[1], order).Encode(), order)
+	require.NoError(t, err)
+	assert.False(t, cursor.After(scans[0]))
+	assert.False(t, cursor.After(scans[1]))
+	assert.True(t, cursor.After(scans[2]))
+	assert.True(t, cursor.After(scans[3]))
+
+	cursor, err = domain.DecodeScanCursor(domain.NewScanCursor(scans[2], order).Encode(), order)
+	require.NoError(t, err)
+	assert.False(t, cursor.After(scans[1]))
+	assert.False(t, cursor.After(scans[2]))
+	assert.True(t, cursor.After(scans[3]))
+}
+
+func TestListScansValidatesPage(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	mockRepository := new(MockScanRepository)
+	service := domain.NewScanService(new(MockScanAdapter), mockRepository, log, 10)
+	ctx := principalContext("user", authdomain.RoleViewer)
+
+	filter := domain.ScanFilter{UserID: "user"}
+
+	_, err := service.ListScans(ctx, filter, domain.ScanSort{}, domain.PageRequest{Limit: 10, Cursor: "%%%"})
+	assert.Error(t, err)
+
+	_, err = service.ListScans(ctx, filter, domain.ScanSort{}, domain.PageRequest{})
+	assert.Error(t, err)
+
+	now := time.Now()
+	_, err = service.ListScans(ctx, domain.ScanFilter{UserID: "user", CreatedAfter: now, CreatedBefore: now.Add(-time.Hour)},
+		domain.ScanSort{}, domain.PageRequest{Limit: 1})
+	assert.Error(t, err)
+
+	page := &domain.ScanPage{Scans: []*domain.Scan{{ID: "scan"}}, TotalCount: 3, HasMore: true, NextCursor: "next"}
+	mockRepository.On("ListScans", filter, domain.ScanSort{}, domain.PageRequest{Limit: 1}).Return(page, nil)
+
+	result, err := service.ListScans(ctx, filter, domain.ScanSort{}, domain.PageRequest{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, page, result)
+}
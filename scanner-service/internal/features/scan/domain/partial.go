@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// PartialResult is the hosts a scan found so far
+type PartialResult struct {
+	ScanID   string     `json:"scan_id"`
+	Status   ScanStatus `json:"status"`
+	Complete bool       `json:"complete"` // Whether the hosts are those of the result of the completed scan
+	Hosts    []Host     `json:"hosts"`
+}
+
+// HostReporter receives the hosts of a running scan as the scanner completes them
+type HostReporter func(host Host)
+
+// hostContextKey is the context key of the host reporter of the running scan
+type hostContextKey struct{}
+
+// WithHostReporter returns a copy of ctx carrying the reporter scan adapters report the
+// hosts of the running scan to
+func WithHostReporter(ctx context.Context, report HostReporter) context.Context {
+	return context.WithValue(ctx, hostContextKey{}, report)
+}
+
+// HostReporterFromContext returns the host reporter of the running scan carried by ctx, if any
+func HostReporterFromContext(ctx context.Context) (HostReporter, bool) {
+	report, ok := ctx.Value(hostContextKey{}).(HostReporter)
+	return report, ok
+}
+
+// hostReporter returns a reporter storing the hosts of a running scan in the repository.
+// reported is set once a host was stored.
+func (s *ScanService) hostReporter(ctx context.Context, scan *Scan, reported *atomic.Bool) HostReporter {
+	return func(host Host) {
+		if err := s.repository.AppendPartialHosts(scan.ID, []Host{host}); err != nil {
+			s.logger.WithContext(ctx).Error("Failed to store partial scan host",
+				zap.String("scan_id", scan.ID),
+				zap.Error(err),
+			)
+			return
+		}
+		reported.Store(true)
+	}
+}
+
+// GetPartialResult returns the hosts a scan found so far. Scans with a result, including
+// the partial result of a stopped scan, return the hosts of their result.
+func (s *ScanService) GetPartialResult(ctx context.Context, scanID string) (*PartialResult, error) {
+	scan, err := s.GetScan(ctx, scanID)
+	if err != nil {
+		return nil, err
+	}
+
+	partial := &PartialResult{ScanID: scan.ID, Status: scan.Status}
+	if scan.ResultID != "" {
+		result, err := s.repository.GetScanResultByID(scan.ResultID)
+		if err != nil {
+			return nil, errors.NewNotFound("scan result not found", err)
+		}
+		partial.Complete = !result.Partial
+		partial.Hosts = result.Hosts
+		return partial, nil
+	}
+
+	partial.Hosts, err = s.repository.GetPartialHosts(scan.ID)
+	if err != nil {
+		return nil, errors.NewInternal("failed to get partial scan hosts", err)
+	}
+	if partial.Hosts == nil {
+		partial.Hosts = make([]Host, 0)
+	}
+	return partial, nil
+}
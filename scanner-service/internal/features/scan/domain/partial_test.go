@@ -0,0 +1,159 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// streamingScanAdapter reports a host of the scan and completes it once released
+type streamingScanAdapter struct {
+	MockScanAdapter
+	reported chan struct{}
+	release  chan struct{}
+}
+
+func (a *streamingScanAdapter) ExecuteScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	if report, ok := domain.HostReporterFromContext(ctx); ok {
+		report(domain.Host{IP: "10.0.0.1", Status: "up"})
+	}
+	close(a.reported)
+	<-a.release
+	return &domain.ScanResult{ID: "result-1", Hosts: []domain.Host{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}}}, nil
+}
+
+func TestGetPartialResult(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+	repository.On("AppendPartialHosts", mock.Anything, []domain.Host{{IP: "10.0.0.1", Status: "up"}}).Return(nil)
+
+	adapter := &streamingScanAdapter{reported: make(chan struct{}), release: make(chan struct{})}
+	service := domain.NewScanService(adapter, repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.0/30", Timeout: time.Minute})
+	require.NoError(t, err)
+	repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+	repository.On("GetPartialHosts", scan.ID).Return([]domain.Host{{IP: "10.0.0.1", Status: "up"}}, nil)
+
+	// The running scan returns the hosts reported so far
+	<-adapter.reported
+	partial, err := service.GetPartialResult(ctx, scan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScanStatusRunning, partial.Status)
+	assert.False(t, partial.Complete)
+	require.Len(t, partial.Hosts, 1)
+	assert.Equal(t, "10.0.0.1", partial.Hosts[0].IP)
+
+	// The completed scan replaces them with its result
+	repository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+	repository.On("DeletePartialHosts", scan.ID).Return(nil)
+	repository.On("GetScanResultByID", "result-1").Return(&domain.ScanResult{ID: "result-1", Hosts: []domain.Host{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}}}, nil)
+	close(adapter.release)
+	assert.Eventually(t, func() bool {
+		current, err := service.GetScan(ctx, scan.ID)
+		return err == nil && current.Status == domain.ScanStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+	repository.AssertCalled(t, "DeletePartialHosts", scan.ID)
+
+	partial, err = service.GetPartialResult(ctx, scan.ID)
+	require.NoError(t, err)
+	assert.True(t, partial.Complete)
+	assert.Len(t, partial.Hosts, 2)
+
+	// Other users cannot read the hosts
+	_, err = service.GetPartialResult(principalContext("bob", authdomain.RoleViewer), scan.ID)
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+}
+
+// stoppedScanAdapter runs until the scan is stopped and returns the hosts completed so far
+type stoppedScanAdapter struct {
+	MockScanAdapter
+	started chan struct{}
+}
+
+func (a *stoppedScanAdapter) ExecuteScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	close(a.started)
+	<-ctx.Done()
+	result := &domain.ScanResult{ID: "partial-1", UpHosts: 1, Hosts: []domain.Host{{IP: "10.0.0.1"}}, Partial: true}
+	return result, errors.NewTimeout("scan timed out", ctx.Err())
+}
+
+func TestStoppedScanKeepsPartialResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		cancel  bool
+		status  domain.ScanStatus
+	}{
+		{"timeout", 50 * time.Millisecond, false, domain.ScanStatusFailed},
+		{"cancel", time.Minute, true, domain.ScanStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &logger.Logger{Logger: zap.NewNop()}
+			repository := new(MockScanRepository)
+			repository.On("SaveScan", mock.Anything).Return(nil)
+			scans := newScanStore()
+			repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+			saved := make(chan *domain.ScanResult, 1)
+			repository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+			repository.On("SaveScanResult", mock.Anything).Run(func(args mock.Arguments) {
+				saved <- args.Get(0).(*domain.ScanResult)
+			}).Return(nil)
+
+			adapter := &stoppedScanAdapter{started: make(chan struct{})}
+			service := domain.NewScanService(adapter, repository, log, 10)
+			ctx := principalContext("alice", authdomain.RoleOperator)
+
+			scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.0/24", Timeout: tt.timeout})
+			require.NoError(t, err)
+			repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+			<-adapter.started
+			if tt.cancel {
+				require.NoError(t, service.CancelScan(ctx, scan.ID))
+			}
+
+			// The partial result is stored for the stopped scan
+			var result *domain.ScanResult
+			select {
+			case result = <-saved:
+			case <-time.After(time.Second):
+				t.Fatal("partial result not saved")
+			}
+			assert.True(t, result.Partial)
+			assert.Equal(t, scan.ID, result.ScanID)
+			assert.Equal(t, "alice", result.UserID)
+
+			assert.Eventually(t, func() bool {
+				current, err := service.GetScan(ctx, scan.ID)
+				return err == nil && current.ResultID == "partial-1"
+			}, time.Second, 10*time.Millisecond)
+			current, _ := service.GetScan(ctx, scan.ID)
+			assert.Equal(t, tt.status, current.Status)
+
+			// The partial result is not reported as complete
+			repository.On("GetScanResultByID", "partial-1").Return(result, nil)
+			partial, err := service.GetPartialResult(ctx, scan.ID)
+			require.NoError(t, err)
+			assert.False(t, partial.Complete)
+			assert.Len(t, partial.Hosts, 1)
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MaxPipelineStages is the maximum number of stages of a pipeline
+const MaxPipelineStages = 10
+
+// ScanStatusSkipped is the status of a pipeline stage that did not run
+// because the previous stage failed or passed on no hosts
+const ScanStatusSkipped ScanStatus = "SKIPPED"
+
+// StageHostFilter selects the hosts of the previous stage result that a stage scans.
+// Only hosts that are up are passed on.
+type StageHostFilter struct {
+	OpenPorts []int    `json:"open_ports,omitempty" yaml:"open_ports,omitempty"` // Only hosts with at least one of these ports open
+	Services  []string `json:"services,omitempty" yaml:"services,omitempty"`     // Only hosts with at least one of these services open
+}
+
+// Matches reports whether the host is passed on to the stage
+func (f StageHostFilter) Matches(host Host) bool {
+	if host.Status != "up" {
+		return false
+	}
+	if len(f.OpenPorts) == 0 && len(f.Services) == 0 {
+		return true
+	}
+
+	for _, port := range host.Ports {
+		if port.State != "open" {
+			continue
+		}
+		for _, wanted := range f.OpenPorts {
+			if port.Port == wanted {
+				return true
+			}
+		}
+		for _, wanted := range f.Services {
+			if strings.EqualFold(port.Service, wanted) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PipelineStage represents one scan of a pipeline and its state
+type PipelineStage struct {
+	Name        string          `json:"name"`                   // Stage name
+	Options     ScanOptions     `json:"options"`                // Scan options, the target is set by the pipeline
+	Hosts       StageHostFilter `json:"hosts"`                  // Hosts of the previous stage to scan, ignored for the first stage
+	Status      ScanStatus      `json:"status"`                 // Current status
+	ScanID      string          `json:"scan_id,omitempty"`      // Scan started for the stage
+	HostCount   int             `json:"host_count"`             // Number of hosts passed to the stage
+	Error       string          `json:"error,omitempty"`        // Error message if failed
+	StartedAt   *time.Time      `json:"started_at,omitempty"`   // When the stage started
+	CompletedAt *time.Time      `json:"completed_at,omitempty"` // When the stage completed
+}
+
+// Pipeline represents a chain of scans where each stage scans hosts found by the previous one
+type Pipeline struct {
+	ID          string          `json:"id"`           // Unique identifier
+	UserID      string          `json:"user_id"`      // User who started the pipeline
+	Name        string          `json:"name"`         // Pipeline name
+	Target      string          `json:"target"`       // Target of the first stage
+	Stages      []PipelineStage `json:"stages"`       // Stages in execution order
+	Status      ScanStatus      `json:"status"`       // Current status
+	CreatedAt   time.Time       `json:"created_at"`   // When the pipeline was created
+	StartedAt   *time.Time      `json:"started_at"`   // When the pipeline started
+	CompletedAt *time.Time      `json:"completed_at"` // When the pipeline completed
+	Error       string          `json:"error"`        // Error message if failed
+	RequestID   string          `json:"request_id"`   // ID of the API request that started the pipeline
+}
+
+// Copy returns a copy of the pipeline that does not share its stages
+func (p *Pipeline) Copy() *Pipeline {
+	pipelineCopy := *p
+	pipelineCopy.Stages = append([]PipelineStage(nil), p.Stages...)
+	return &pipelineCopy
+}
+
+// StartPipeline validates the stages and runs them one after another in the background.
+// The caller must have the operator role.
+func (s *ScanService) StartPipeline(ctx context.Context, userID, name, target string, stages []PipelineStage) (*Pipeline, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	target, err := NormalizeTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) == 0 || len(stages) > MaxPipelineStages {
+		return nil, errors.NewInvalidInput(fmt.Sprintf("a pipeline must have between 1 and %d stages", MaxPipelineStages), nil)
+	}
+
+	// Later stages scan a subset of the hosts of the first one,
+	// so every stage is checked against the pipeline target
+	pipelineStages := make([]PipelineStage, len(stages))
+	for i, stage := range stages {
+		stage.Options.Target = target
+		if i > 0 && len(stage.Options.Discovery) > 0 {
+			return nil, errors.NewInvalidInput("target discovery is only supported in the first stage", nil)
+		}
+		if err := s.CheckScan(ctx, stage.Options); err != nil {
+			return nil, err
+		}
+
+		if stage.Name == "" {
+			stage.Name = fmt.Sprintf("stage-%d", i+1)
+		}
+		pipelineStages[i] = PipelineStage{
+			Name:    stage.Name,
+			Options: stage.Options,
+			Hosts:   stage.Hosts,
+			Status:  ScanStatusPending,
+		}
+	}
+
+	pipeline := &Pipeline{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Target:    target,
+		Stages:    pipelineStages,
+		Status:    ScanStatusPending,
+		CreatedAt: time.Now(),
+		RequestID: requestid.FromContext(ctx),
+	}
+
+	if err := s.repository.SavePipeline(pipeline); err != nil {
+		return nil, errors.NewInternal("failed to save pipeline", err)
+	}
+
+	// Run the pipeline detached from the cancellation of the request
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.mu.Lock()
+	s.pipelineCancels[pipeline.ID] = cancel
+	s.mu.Unlock()
+
+	go s.runPipeline(ctx, pipeline.Copy())
+
+	return pipeline, nil
+}
+
+// GetPipeline gets a pipeline by ID.
+// Pipelines owned by other users are reported as not found unless the caller is an admin.
+func (s *ScanService) GetPipeline(ctx context.Context, id string) (*Pipeline, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline, err := s.repository.GetPipelineByID(id)
+	if err != nil || !principal.CanAccess(pipeline.UserID) {
+		return nil, errors.NewNotFound("pipeline not found", err)
+	}
+
+	return pipeline, nil
+}
+
+// ListPipelines lists the pipelines of a user, newest first.
+// Only admins may list other users' pipelines or all pipelines (empty userID).
+func (s *ScanService) ListPipelines(ctx context.Context, userID string) ([]*Pipeline, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != principal.UserID && !principal.IsAdmin() {
+		return nil, errors.NewForbidden("cannot list pipelines of other users", nil)
+	}
+
+	pipelines, err := s.repository.ListPipelines(userID)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list pipelines", err)
+	}
+
+	return pipelines, nil
+}
+
+// CancelPipeline cancels a running pipeline and the scan of its current stage.
+// The caller must have the operator role and own the pipeline, or be an admin.
+func (s *ScanService) CancelPipeline(ctx context.Context, id string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
+	pipeline, err := s.repository.GetPipelineByID(id)
+	if err != nil || !principal.CanAccess(pipeline.UserID) {
+		return errors.NewNotFound("pipeline not found", err)
+	}
+
+	s.mu.Lock()
+	cancel, running := s.pipelineCancels[id]
+	s.mu.Unlock()
+
+	if !running {
+		return errors.NewInvalidInput("pipeline is not running or pending", nil)
+	}
+
+	// Cancelling the pipeline context also cancels the scan of the current stage
+	cancel()
+
+	return nil
+}
+
+// runPipeline runs the stages of a pipeline, feeding the hosts found by each stage to the next
+func (s *ScanService) runPipeline(ctx context.Context, pipeline *Pipeline) {
+	log := s.logger.WithContext(ctx)
+
+	defer func() {
+		s.mu.Lock()
+		if cancel, ok := s.pipelineCancels[pipeline.ID]; ok {
+			cancel()
+			delete(s.pipelineCancels, pipeline.ID)
+		}
+		s.mu.Unlock()
+	}()
+
+	now := time.Now()
+	pipeline.Status = ScanStatusRunning
+	pipeline.StartedAt = &now
+	s.updatePipeline(ctx, pipeline)
+
+	log.Info("Starting pipeline",
+		zap.String("pipeline_id", pipeline.ID),
+		zap.String("target", pipeline.Target),
+		zap.Int("stages", len(pipeline.Stages)),
+	)
+
+	target := pipeline.Target
+	status := ScanStatusCompleted
+	for i := range pipeline.Stages {
+		stage := &pipeline.Stages[i]
+
+		if status != ScanStatusCompleted || target == "" {
+			stage.Status = ScanStatusSkipped
+			continue
+		}
+
+		result, err := s.runPipelineStage(ctx, pipeline, stage, target)
+		if err != nil {
+			status = stage.Status
+			if ctx.Err() != nil {
+				status = ScanStatusCancelled
+			}
+			pipeline.Error = fmt.Sprintf("stage %s: %v", stage.Name, err)
+			s.updatePipeline(ctx, pipeline)
+			continue
+		}
+
+		// Select the hosts scanned by the next stage
+		target = ""
+		if i+1 < len(pipeline.Stages) {
+			target = nextStageTarget(result, pipeline.Stages[i+1].Hosts)
+		}
+		s.updatePipeline(ctx, pipeline)
+	}
+
+	completedAt := time.Now()
+	pipeline.Status = status
+	pipeline.CompletedAt = &completedAt
+	s.updatePipeline(ctx, pipeline)
+
+	log.Info("Pipeline finished",
+		zap.String("pipeline_id", pipeline.ID),
+		zap.String("status", string(status)),
+	)
+}
+
+// runPipelineStage runs the scan of a stage and returns its result
+func (s *ScanService) runPipelineStage(ctx context.Context, pipeline *Pipeline, stage *PipelineStage, target string) (*ScanResult, error) {
+	options := stage.Options
+	options.Target = target
+	stage.HostCount = len(strings.Fields(target))
+
+	now := time.Now()
+	stage.Status = ScanStatusRunning
+	stage.StartedAt = &now
+	s.updatePipeline(ctx, pipeline)
+
+	scan := &Scan{UserID: pipeline.UserID, Options: options, PipelineID: pipeline.ID}
+	result, err := s.RunScan(ctx, scan)
+
+	completedAt := time.Now()
+	stage.CompletedAt = &completedAt
+	stage.ScanID = scan.ID
+
+	s.mu.Lock()
+	stage.Status = scan.Status
+	s.mu.Unlock()
+
+	if err != nil {
+		switch {
+		case ctx.Err() != nil:
+			stage.Status = ScanStatusCancelled
+		case stage.Status != ScanStatusCancelled:
+			stage.Status = ScanStatusFailed
+		}
+		stage.Error = err.Error()
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// updatePipeline stores the state of a running pipeline, logging failures
+func (s *ScanService) updatePipeline(ctx context.Context, pipeline *Pipeline) {
+	if err := s.repository.UpdatePipeline(pipeline); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update pipeline",
+			zap.String("pipeline_id", pipeline.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// nextStageTarget returns the addresses of the result hosts matching the filter
+func nextStageTarget(result *ScanResult, filter StageHostFilter) string {
+	var addresses []string
+	for _, host := range result.Hosts {
+		if filter.Matches(host) {
+			addresses = append(addresses, host.IP)
+		}
+	}
+	return strings.Join(addresses, " ")
+}
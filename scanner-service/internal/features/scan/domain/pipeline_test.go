@@ -0,0 +1,157 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func upHost(ip string, openPorts ...int) domain.Host {
+	host := domain.Host{IP: ip, Status: "up"}
+	for _, port := range openPorts {
+		host.Ports = append(host.Ports, domain.Port{Port: port, Protocol: "tcp", State: "open"})
+	}
+	return host
+}
+
+func TestStageHostFilter(t *testing.T) {
+	assert.True(t, domain.StageHostFilter{}.Matches(upHost("10.0.0.1")))
+	assert.False(t, domain.StageHostFilter{}.Matches(domain.Host{IP: "10.0.0.1", Status: "down"}))
+	assert.True(t, domain.StageHostFilter{OpenPorts: []int{139, 445}}.Matches(upHost("10.0.0.1", 22, 445)))
+	assert.False(t, domain.StageHostFilter{OpenPorts: []int{445}}.Matches(upHost("10.0.0.1", 22)))
+
+	smb := upHost("10.0.0.1", 8445)
+	smb.Ports[0].Service = "microsoft-ds"
+	assert.True(t, domain.StageHostFilter{OpenPorts: []int{445}, Services: []string{"Microsoft-DS"}}.Matches(smb))
+}
+
+func TestRunPipeline(t *testing.T) {
+	mockAdapter := new(MockScanAdapter)
+	mockRepository := new(MockScanRepository)
+
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	service := domain.NewScanService(mockAdapter, mockRepository, log, 10)
+
+	results := map[string]*domain.ScanResult{
+		"10.0.0.0/29":       {ID: "r1", Hosts: []domain.Host{upHost("10.0.0.1"), upHost("10.0.0.2")}},
+		"10.0.0.1 10.0.0.2": {ID: "r2", Hosts: []domain.Host{upHost("10.0.0.1", 445), upHost("10.0.0.2", 80)}},
+		"10.0.0.1":          {ID: "r3", Hosts: []domain.Host{upHost("10.0.0.1", 445)}},
+	}
+
+	finished := make(chan domain.Pipeline, 1)
+	mockRepository.On("SavePipeline", mock.Anything).Return(nil)
+	mockRepository.On("UpdatePipeline", mock.Anything).Run(func(args mock.Arguments) {
+		pipeline := args.Get(0).(*domain.Pipeline)
+		if pipeline.CompletedAt != nil {
+			finished <- *pipeline.Copy()
+		}
+	}).Return(nil)
+	mockRepository.On("SaveScan", mock.Anything).Return(nil)
+	mockRepository.On("UpdateScan", mock.Anything).Return(nil)
+	mockRepository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	mockRepository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	// Each stage is executed with the hosts selected from the previous result
+	var targets []string
+	for target, result := range results {
+		mockRepository.On("GetScanResultByID", result.ID).Return(result, nil)
+		mockAdapter.On("ExecuteScan", mock.Anything, mock.MatchedBy(func(options domain.ScanOptions) bool {
+			return options.Target == target
+		})).Run(func(args mock.Arguments) {
+			targets = append(targets, args.Get(1).(domain.ScanOptions).Target)
+		}).Return(result, nil)
+	}
+
+	pipeline, err := service.StartPipeline(principalContext("alice", authdomain.RoleOperator), "alice", "smb", "10.0.0.0/29", []domain.PipelineStage{
+		{Name: "discovery", Options: domain.ScanOptions{ScanType: domain.ScanTypePing, Timeout: time.Minute}},
+		{Name: "tcp", Options: domain.ScanOptions{ScanType: domain.ScanTypeConnect, Ports: "1-65535", Timeout: time.Minute}},
+		{Name: "smb", Options: domain.ScanOptions{ScriptScan: true, Ports: "445", Timeout: time.Minute}, Hosts: domain.StageHostFilter{OpenPorts: []int{445}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScanStatusPending, pipeline.Status)
+
+	select {
+	case result := <-finished:
+		assert.Equal(t, domain.ScanStatusCompleted, result.Status)
+		assert.Equal(t, []string{"10.0.0.0/29", "10.0.0.1 10.0.0.2", "10.0.0.1"}, targets)
+		for _, stage := range result.Stages {
+			assert.Equal(t, domain.ScanStatusCompleted, stage.Status, stage.Name)
+			assert.NotEmpty(t, stage.ScanID)
+		}
+		assert.Equal(t, 1, result.Stages[2].HostCount)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pipeline did not finish")
+	}
+}
+
+func TestPipelineSkipsStagesWithoutHosts(t *testing.T) {
+	mockAdapter := new(MockScanAdapter)
+	mockRepository := new(MockScanRepository)
+
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	service := domain.NewScanService(mockAdapter, mockRepository, log, 10)
+
+	result := &domain.ScanResult{ID: "r1", Hosts: []domain.Host{upHost("10.0.0.1", 22)}}
+	finished := make(chan domain.Pipeline, 1)
+	mockRepository.On("SavePipeline", mock.Anything).Return(nil)
+	mockRepository.On("UpdatePipeline", mock.Anything).Run(func(args mock.Arguments) {
+		pipeline := args.Get(0).(*domain.Pipeline)
+		if pipeline.CompletedAt != nil {
+			finished <- *pipeline.Copy()
+		}
+	}).Return(nil)
+	mockRepository.On("SaveScan", mock.Anything).Return(nil)
+	mockRepository.On("UpdateScan", mock.Anything).Return(nil)
+	mockRepository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	mockRepository.On("SaveScanResult", mock.Anything).Return(nil)
+	mockRepository.On("GetScanResultByID", "r1").Return(result, nil)
+	mockAdapter.On("ExecuteScan", mock.Anything, mock.Anything).Return(result, nil).Once()
+
+	_, err := service.StartPipeline(principalContext("alice", authdomain.RoleOperator), "alice", "", "10.0.0.1", []domain.PipelineStage{
+		{Options: domain.ScanOptions{Timeout: time.Minute}},
+		{Options: domain.ScanOptions{ScriptScan: true, Timeout: time.Minute}, Hosts: domain.StageHostFilter{OpenPorts: []int{445}}},
+	})
+	require.NoError(t, err)
+
+	select {
+	case pipeline := <-finished:
+		assert.Equal(t, domain.ScanStatusCompleted, pipeline.Status)
+		assert.Equal(t, "stage-1", pipeline.Stages[0].Name)
+		assert.Equal(t, domain.ScanStatusCompleted, pipeline.Stages[0].Status)
+		assert.Equal(t, domain.ScanStatusSkipped, pipeline.Stages[1].Status)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pipeline did not finish")
+	}
+	mockAdapter.AssertExpectations(t)
+}
+
+func TestStartPipelineValidation(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	service := domain.NewScanService(new(MockScanAdapter), new(MockScanRepository), log, 10)
+	stage := domain.PipelineStage{Options: domain.ScanOptions{Timeout: time.Minute}}
+
+	_, err := service.StartPipeline(principalContext("alice", authdomain.RoleViewer), "alice", "", "10.0.0.1", []domain.PipelineStage{stage})
+	assert.Error(t, err)
+
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	_, err = service.StartPipeline(ctx, "alice", "", "10.0.0.1", nil)
+	assert.ErrorContains(t, err, "between 1 and")
+
+	_, err = service.StartPipeline(ctx, "alice", "", "10.0.0.1", make([]domain.PipelineStage, domain.MaxPipelineStages+1))
+	assert.ErrorContains(t, err, "between 1 and")
+
+	discoveryStage := domain.PipelineStage{Options: domain.ScanOptions{Discovery: []domain.DiscoveryMethod{domain.DiscoveryCT}}}
+	_, err = service.StartPipeline(ctx, "alice", "", "example.com", []domain.PipelineStage{stage, discoveryStage})
+	assert.ErrorContains(t, err, "only supported in the first stage")
+}
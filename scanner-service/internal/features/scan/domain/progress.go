@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ScanPhase represents the stage a running scan is in
+type ScanPhase string
+
+// Scan phase constants
+const (
+	ScanPhaseDiscovery        ScanPhase = "discovery"         // Host discovery and DNS resolution
+	ScanPhasePortScan         ScanPhase = "port_scan"         // Probing the ports of the hosts up
+	ScanPhaseServiceDetection ScanPhase = "service_detection" // Service, version and OS detection
+	ScanPhaseScripts          ScanPhase = "scripts"           // NSE scripts
+)
+
+// ScanProgress is the progress a scan adapter reports while a scan is running
+type ScanProgress struct {
+	Phase     ScanPhase
+	Percent   float64       // Progress of the phase (0-100)
+	Remaining time.Duration // Estimated time until the phase completes, 0 if unknown
+}
+
+// ProgressReporter receives the progress of a running scan. Adapters call it from the
+// goroutines reading the output of scanner processes.
+type ProgressReporter func(progress ScanProgress)
+
+// progressContextKey is the context key of the progress reporter of the running scan
+type progressContextKey struct{}
+
+// WithProgressReporter returns a copy of ctx carrying the reporter scan adapters report
+// the progress of the running scan to
+func WithProgressReporter(ctx context.Context, report ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressContextKey{}, report)
+}
+
+// ProgressReporterFromContext returns the progress reporter of the running scan carried
+// by ctx, if any
+func ProgressReporterFromContext(ctx context.Context) (ProgressReporter, bool) {
+	report, ok := ctx.Value(progressContextKey{}).(ProgressReporter)
+	return report, ok
+}
+
+// progressReporter returns a reporter recording the phase and estimated completion
+// of a running scan
+func (s *ScanService) progressReporter(scan *Scan) ProgressReporter {
+	return func(progress ScanProgress) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if scan.Status != ScanStatusRunning {
+			return
+		}
+		scan.CurrentPhase = progress.Phase
+		scan.EstimatedCompletion = nil
+		if progress.Remaining > 0 {
+			eta := time.Now().Add(progress.Remaining).Truncate(time.Second)
+			scan.EstimatedCompletion = &eta
+		}
+	}
+}
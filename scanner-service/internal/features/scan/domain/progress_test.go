@@ -0,0 +1,67 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// progressScanAdapter reports a phase of the scan and completes it once released
+type progressScanAdapter struct {
+	MockScanAdapter
+	release chan struct{}
+}
+
+func (a *progressScanAdapter) ExecuteScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	if report, ok := domain.ProgressReporterFromContext(ctx); ok {
+		report(domain.ScanProgress{Phase: domain.ScanPhaseServiceDetection, Percent: 40, Remaining: time.Hour})
+	}
+	<-a.release
+	return &domain.ScanResult{ID: "result-1", Hosts: []domain.Host{}}, nil
+}
+
+func TestScanReportsPhaseAndEstimatedCompletion(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+	repository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	adapter := &progressScanAdapter{release: make(chan struct{})}
+	service := domain.NewScanService(adapter, repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	started := time.Now()
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.1", Timeout: time.Minute})
+	require.NoError(t, err)
+	repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+
+	// The running scan has the reported phase and completion estimate
+	var current *domain.Scan
+	require.Eventually(t, func() bool {
+		current, err = service.GetScan(ctx, scan.ID)
+		return err == nil && current.CurrentPhase != ""
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, domain.ScanPhaseServiceDetection, current.CurrentPhase)
+	require.NotNil(t, current.EstimatedCompletion)
+	assert.WithinDuration(t, started.Add(time.Hour), *current.EstimatedCompletion, 5*time.Second)
+
+	// Completed scans have neither
+	close(adapter.release)
+	require.Eventually(t, func() bool {
+		current, err = service.GetScan(ctx, scan.ID)
+		return err == nil && current.Status == domain.ScanStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+	assert.Empty(t, current.CurrentPhase)
+	assert.Nil(t, current.EstimatedCompletion)
+}
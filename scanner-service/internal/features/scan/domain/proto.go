@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"time"
+
+	scannerv1 "github.com/furkansarikaya/nmap-ui-microservices/api/proto/scanner/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ScanResultToProto converts a scan result into its protobuf message, a far more
+// compact encoding than the JSON document for gRPC transport and event payloads
+func ScanResultToProto(result *ScanResult) *scannerv1.ScanResult {
+	msg := &scannerv1.ScanResult{
+		Id:         result.ID,
+		ScanId:     result.ScanID,
+		UserId:     result.UserID,
+		StartTime:  timeToProto(result.StartTime),
+		EndTime:    timeToProto(result.EndTime),
+		Duration:   result.Duration,
+		Command:    result.Command,
+		Summary:    result.Summary,
+		TotalHosts: int32(result.TotalHosts),
+		UpHosts:    int32(result.UpHosts),
+		Hosts:      make([]*scannerv1.Host, len(result.Hosts)),
+		Partial:    result.Partial,
+	}
+	for i := range result.Hosts {
+		msg.Hosts[i] = HostToProto(&result.Hosts[i])
+	}
+	return msg
+}
+
+// ScanResultFromProto converts a protobuf scan result into the scan result
+func ScanResultFromProto(msg *scannerv1.ScanResult) *ScanResult {
+	result := &ScanResult{
+		ID:         msg.GetId(),
+		ScanID:     msg.GetScanId(),
+		UserID:     msg.GetUserId(),
+		StartTime:  timeFromProto(msg.GetStartTime()),
+		EndTime:    timeFromProto(msg.GetEndTime()),
+		Duration:   msg.GetDuration(),
+		Command:    msg.GetCommand(),
+		Summary:    msg.GetSummary(),
+		TotalHosts: int(msg.GetTotalHosts()),
+		UpHosts:    int(msg.GetUpHosts()),
+		Hosts:      make([]Host, len(msg.GetHosts())),
+		Partial:    msg.GetPartial(),
+	}
+	for i, host := range msg.GetHosts() {
+		result.Hosts[i] = HostFromProto(host)
+	}
+	return result
+}
+
+// HostToProto converts a host of a scan result into its protobuf message
+func HostToProto(host *Host) *scannerv1.Host {
+	msg := &scannerv1.Host{
+		Ip:        host.IP,
+		Hostnames: host.Hostnames,
+		Status:    host.Status,
+		Os:        host.OS,
+		Ports:     make([]*scannerv1.Port, len(host.Ports)),
+		Scripts:   make([]*scannerv1.Script, len(host.Scripts)),
+		Metadata: &scannerv1.HostMetadata{
+			Distance:     int32(host.Metadata.Distance),
+			Uptime:       host.Metadata.UpTime,
+			LastBoot:     timeToProto(host.Metadata.LastBoot),
+			TcpSequence:  host.Metadata.TCPSequence,
+			IpIdSequence: host.Metadata.IPIDSequence,
+		},
+	}
+	for i, port := range host.Ports {
+		msg.Ports[i] = &scannerv1.Port{
+			Port:      int32(port.Port),
+			Protocol:  port.Protocol,
+			State:     port.State,
+			Service:   port.Service,
+			Product:   port.Product,
+			Version:   port.Version,
+			ExtraInfo: port.ExtraInfo,
+		}
+	}
+	for i, script := range host.Scripts {
+		msg.Scripts[i] = &scannerv1.Script{
+			Id:       script.ID,
+			Output:   script.Output,
+			Data:     script.Data,
+			Port:     int32(script.Port),
+			Protocol: script.Protocol,
+		}
+	}
+	if geo := host.Geo; geo != nil {
+		msg.Geo = &scannerv1.GeoInfo{
+			CountryCode:    geo.CountryCode,
+			Country:        geo.Country,
+			City:           geo.City,
+			Latitude:       geo.Latitude,
+			Longitude:      geo.Longitude,
+			Asn:            uint32(geo.ASN),
+			AsOrganization: geo.ASOrganization,
+			Isp:            geo.ISP,
+		}
+	}
+	if owner := host.Owner; owner != nil {
+		msg.Owner = &scannerv1.NetworkOwner{
+			Handle:       owner.Handle,
+			Name:         owner.Name,
+			StartAddress: owner.StartAddress,
+			EndAddress:   owner.EndAddress,
+			Country:      owner.Country,
+			Organization: owner.Organization,
+			AbuseEmails:  owner.AbuseEmails,
+			Source:       owner.Source,
+		}
+	}
+	for _, note := range host.Notes {
+		msg.Notes = append(msg.Notes, &scannerv1.Note{
+			Id:        note.ID,
+			UserId:    note.UserID,
+			Text:      note.Text,
+			CreatedAt: timeToProto(note.CreatedAt),
+		})
+	}
+	return msg
+}
+
+// HostFromProto converts a protobuf host into the host of a scan result
+func HostFromProto(msg *scannerv1.Host) Host {
+	metadata := msg.GetMetadata()
+	host := Host{
+		IP:        msg.GetIp(),
+		Hostnames: msg.GetHostnames(),
+		Status:    msg.GetStatus(),
+		OS:        msg.GetOs(),
+		Ports:     make([]Port, len(msg.GetPorts())),
+		Scripts:   make([]Script, len(msg.GetScripts())),
+		Metadata: HostMetadata{
+			Distance:     int(metadata.GetDistance()),
+			UpTime:       metadata.GetUptime(),
+			LastBoot:     timeFromProto(metadata.GetLastBoot()),
+			TCPSequence:  metadata.GetTcpSequence(),
+			IPIDSequence: metadata.GetIpIdSequence(),
+		},
+	}
+	for i, port := range msg.GetPorts() {
+		host.Ports[i] = Port{
+			Port:      int(port.GetPort()),
+			Protocol:  port.GetProtocol(),
+			State:     port.GetState(),
+			Service:   port.GetService(),
+			Product:   port.GetProduct(),
+			Version:   port.GetVersion(),
+			ExtraInfo: port.GetExtraInfo(),
+		}
+	}
+	for i, script := range msg.GetScripts() {
+		host.Scripts[i] = Script{
+			ID:       script.GetId(),
+			Output:   script.GetOutput(),
+			Data:     script.GetData(),
+			Port:     int(script.GetPort()),
+			Protocol: script.GetProtocol(),
+		}
+	}
+	if geo := msg.GetGeo(); geo != nil {
+		host.Geo = &GeoInfo{
+			CountryCode:    geo.GetCountryCode(),
+			Country:        geo.GetCountry(),
+			City:           geo.GetCity(),
+			Latitude:       geo.GetLatitude(),
+			Longitude:      geo.GetLongitude(),
+			ASN:            uint(geo.GetAsn()),
+			ASOrganization: geo.GetAsOrganization(),
+			ISP:            geo.GetIsp(),
+		}
+	}
+	if owner := msg.GetOwner(); owner != nil {
+		host.Owner = &NetworkOwner{
+			Handle:       owner.GetHandle(),
+			Name:         owner.GetName(),
+			StartAddress: owner.GetStartAddress(),
+			EndAddress:   owner.GetEndAddress(),
+			Country:      owner.GetCountry(),
+			Organization: owner.GetOrganization(),
+			AbuseEmails:  owner.GetAbuseEmails(),
+			Source:       owner.GetSource(),
+		}
+	}
+	for _, note := range msg.GetNotes() {
+		host.Notes = append(host.Notes, Note{
+			ID:        note.GetId(),
+			UserID:    note.GetUserId(),
+			Text:      note.GetText(),
+			CreatedAt: timeFromProto(note.GetCreatedAt()),
+		})
+	}
+	return host
+}
+
+// timeToProto converts a time into a protobuf timestamp, nil for the zero time
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeFromProto converts a protobuf timestamp into a time, the zero time if unset
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	scannerv1 "github.com/furkansarikaya/nmap-ui-microservices/api/proto/scanner/v1"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestScanResultProtoRoundTrip(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	result := &domain.ScanResult{
+		ID:         "result-1",
+		ScanID:     "scan-1",
+		UserID:     "user-1",
+		StartTime:  start,
+		EndTime:    start.Add(42 * time.Second),
+		Duration:   42,
+		Command:    "nmap -sT -p 22,443 example.com",
+		Summary:    "Nmap done: 2 IP addresses (1 host up)",
+		TotalHosts: 2,
+		UpHosts:    1,
+		Partial:    true,
+		Hosts: []domain.Host{
+			{
+				IP:        "93.184.216.34",
+				Hostnames: []string{"example.com"},
+				Status:    "up",
+				OS:        "Linux 5.X",
+				Ports: []domain.Port{
+					{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH", Version: "9.6", ExtraInfo: "protocol 2.0"},
+					{Port: 443, Protocol: "tcp", State: "filtered"},
+				},
+				Scripts: []domain.Script{
+					{ID: "ssl-cert", Output: "Subject: commonName=example.com", Data: map[string]string{"subject.commonName": "example.com"}, Port: 443, Protocol: "tcp"},
+				},
+				Metadata: domain.HostMetadata{
+					Distance:     12,
+					UpTime:       3600,
+					LastBoot:     start.Add(-time.Hour),
+					TCPSequence:  "Good luck!",
+					IPIDSequence: "All zeros",
+				},
+				Geo: &domain.GeoInfo{
+					CountryCode:    "US",
+					Country:        "United States",
+					City:           "Norwell",
+					Latitude:       42.1508,
+					Longitude:      -70.8228,
+					ASN:            15133,
+					ASOrganization: "Edgecast Inc.",
+				},
+				Owner: &domain.NetworkOwner{
+					Handle:       "NET-93-184-216-0-1",
+					StartAddress: "93.184.216.0",
+					EndAddress:   "93.184.216.255",
+					AbuseEmails:  []string{"abuse@example.com"},
+				},
+				Notes: []domain.Note{
+					{ID: "note-1", UserID: "user-1", Text: "Owned by the web team", CreatedAt: start.Add(time.Minute)},
+				},
+			},
+			{IP: "93.184.216.35", Status: "down"},
+		},
+	}
+
+	// Round trip through the wire format, as a gRPC client or event consumer would
+	encoded, err := proto.Marshal(domain.ScanResultToProto(result))
+	require.NoError(t, err)
+	msg := &scannerv1.ScanResult{}
+	require.NoError(t, proto.Unmarshal(encoded, msg))
+
+	decoded := domain.ScanResultFromProto(msg)
+
+	// Empty slices of the down host come back empty rather than nil
+	result.Hosts[1].Ports = []domain.Port{}
+	result.Hosts[1].Scripts = []domain.Script{}
+	assert.Equal(t, result, decoded)
+}
+
+func TestScanResultProtoUnsetTimes(t *testing.T) {
+	msg := domain.ScanResultToProto(&domain.ScanResult{
+		ID:    "result-1",
+		Hosts: []domain.Host{{IP: "10.0.0.1"}},
+	})
+
+	assert.Nil(t, msg.GetStartTime())
+	assert.Nil(t, msg.GetEndTime())
+	assert.Nil(t, msg.GetHosts()[0].GetMetadata().GetLastBoot())
+
+	decoded := domain.ScanResultFromProto(msg)
+	assert.True(t, decoded.StartTime.IsZero())
+	assert.True(t, decoded.Hosts[0].Metadata.LastBoot.IsZero())
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"context"
+	"os"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// DeleteScanResult deletes a scan result on demand, e.g. for a data erasure request.
+// The scan the result belongs to is kept without a result.
+// The caller must have the operator role and own the result, or be an admin.
+func (s *ScanService) DeleteScanResult(ctx context.Context, id string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.repository.GetScanResultByID(id)
+	if err != nil {
+		return errors.NewNotFound("scan result not found", err)
+	}
+
+	if !principal.CanAccess(result.UserID) {
+		return errors.NewNotFound("scan result not found", nil)
+	}
+
+	if err := s.repository.DeleteScanResult(id); err != nil {
+		return errors.NewInternal("failed to delete scan result", err)
+	}
+
+	s.logger.Info("Scan result deleted",
+		zap.String("result_id", id),
+		zap.String("scan_id", result.ScanID),
+		zap.String("deleted_by", principal.UserID),
+	)
+
+	return nil
+}
+
+// PurgeScan permanently deletes a scan in the trash together with its result, its shard
+// scans and their results, and the progress saved for resuming it.
+// The caller must have the operator role and own the scan, or be an admin.
+func (s *ScanService) PurgeScan(ctx context.Context, id string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
+	scan, err := s.getScan(id)
+	if err != nil {
+		return err
+	}
+
+	if !principal.CanAccess(scan.UserID) {
+		return errors.NewNotFound("scan not found", nil)
+	}
+
+	if scan.DeletedAt == nil {
+		return errors.NewInvalidInput("only scans in the trash can be purged, delete the scan first", nil)
+	}
+
+	// Shards are purged before their parent so that a failure leaves the parent in place
+	if scan.ShardCount > 0 {
+		shards, err := s.repository.ListScans(ScanFilter{ParentID: scan.ID}, ScanSort{}, PageRequest{Limit: scan.ShardCount})
+		if err != nil {
+			return errors.NewInternal("failed to list shard scans", err)
+		}
+		for _, shard := range shards.Scans {
+			if err := s.purgeScan(shard); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.purgeScan(scan); err != nil {
+		return err
+	}
+
+	s.logger.Info("Scan purged",
+		zap.String("scan_id", id),
+		zap.Int("shard_count", scan.ShardCount),
+		zap.String("purged_by", principal.UserID),
+	)
+
+	return nil
+}
+
+// purgeScan deletes a single scan, its result and its state file
+func (s *ScanService) purgeScan(scan *Scan) error {
+	// The result may already have been deleted by a purge of old results
+	if scan.ResultID != "" {
+		if _, err := s.repository.GetScanResultByID(scan.ResultID); err == nil {
+			if err := s.repository.DeleteScanResult(scan.ResultID); err != nil {
+				return errors.NewInternal("failed to delete scan result", err)
+			}
+		}
+	}
+
+	if s.stateDir != "" {
+		if err := os.Remove(s.stateFile(scan)); err != nil && !os.IsNotExist(err) {
+			s.logger.Warn("Failed to remove scan state file",
+				zap.String("scan_id", scan.ID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if err := s.repository.DeleteScan(scan.ID); err != nil {
+		return errors.NewInternal("failed to delete scan", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDeleteScanResult(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	repository.On("GetScanResultByID", "result-1").Return(&domain.ScanResult{ID: "result-1", ScanID: "scan-1", UserID: "alice"}, nil)
+	repository.On("DeleteScanResult", "result-1").Return(nil)
+
+	service := domain.NewScanService(new(MockScanAdapter), repository, log, 10)
+
+	// Viewers cannot delete results
+	err := service.DeleteScanResult(principalContext("alice", authdomain.RoleViewer), "result-1")
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrForbidden, scanErr.Type)
+
+	// Results of other users are reported as not found
+	err = service.DeleteScanResult(principalContext("bob", authdomain.RoleOperator), "result-1")
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+	repository.AssertNotCalled(t, "DeleteScanResult", "result-1")
+
+	require.NoError(t, service.DeleteScanResult(principalContext("alice", authdomain.RoleOperator), "result-1"))
+	repository.AssertCalled(t, "DeleteScanResult", "result-1")
+}
+
+func TestPurgeScan(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+
+	deletedAt := time.Now()
+	scan := &domain.Scan{ID: "scan-1", UserID: "alice", Status: domain.ScanStatusCompleted, ResultID: "result-1", ShardCount: 2, DeletedAt: &deletedAt}
+	shards := []*domain.Scan{
+		{ID: "shard-1", UserID: "alice", Status: domain.ScanStatusCompleted, ResultID: "shard-result-1", ParentID: "scan-1"},
+		{ID: "shard-2", UserID: "alice", Status: domain.ScanStatusCompleted, ResultID: "shard-result-2", ParentID: "scan-1"},
+	}
+	repository.On("GetScanByID", "scan-1").Return(scan, nil)
+	repository.On("GetScanByID", "scan-2").Return(&domain.Scan{ID: "scan-2", UserID: "alice", Status: domain.ScanStatusCompleted}, nil)
+	repository.On("ListScans", domain.ScanFilter{ParentID: "scan-1"}, domain.ScanSort{}, domain.PageRequest{Limit: 2}).
+		Return(&domain.ScanPage{Scans: shards, TotalCount: 2}, nil)
+	for _, id := range []string{"result-1", "shard-result-1"} {
+		repository.On("GetScanResultByID", id).Return(&domain.ScanResult{ID: id}, nil)
+		repository.On("DeleteScanResult", id).Return(nil)
+	}
+	// The result of the second shard was already purged
+	repository.On("GetScanResultByID", "shard-result-2").Return(nil, errors.NewNotFound("scan result not found", nil))
+	for _, id := range []string{"scan-1", "shard-1", "shard-2"} {
+		repository.On("DeleteScan", id).Return(nil)
+	}
+
+	service := domain.NewScanService(new(MockScanAdapter), repository, log, 10)
+
+	// Scans of other users are reported as not found
+	err := service.PurgeScan(principalContext("bob", authdomain.RoleOperator), "scan-1")
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+
+	// Scans must be moved to the trash first
+	err = service.PurgeScan(principalContext("alice", authdomain.RoleOperator), "scan-2")
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrInvalidInput, scanErr.Type)
+	repository.AssertNotCalled(t, "DeleteScan", "scan-2")
+
+	require.NoError(t, service.PurgeScan(principalContext("alice", authdomain.RoleOperator), "scan-1"))
+	for _, id := range []string{"scan-1", "shard-1", "shard-2"} {
+		repository.AssertCalled(t, "DeleteScan", id)
+	}
+	repository.AssertCalled(t, "DeleteScanResult", "result-1")
+	repository.AssertCalled(t, "DeleteScanResult", "shard-result-1")
+	repository.AssertNotCalled(t, "DeleteScanResult", "shard-result-2")
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+)
+
+// ScanPriorityNormal is the priority scans are queued with. Scans are started in
+// submission order, the queue depth is reported per priority for dashboards and alerts
+// to keep working once scans can be prioritized.
+const ScanPriorityNormal = "normal"
+
+// QueueStats represents the state of the scan queue and of the workers running scans
+type QueueStats struct {
+	QueuedScans       int            `json:"queued_scans"`        // Scans waiting for a worker
+	OldestWaitSeconds float64        `json:"oldest_wait_seconds"` // How long the longest waiting scan waits, 0 if none waits
+	DepthByPriority   map[string]int `json:"depth_by_priority"`   // Scans waiting for a worker by priority
+	QueueCapacity     int            `json:"queue_capacity"`      // Scans that may wait beyond one per worker
+	Workers           int            `json:"workers"`             // Workers running scans
+	BusyWorkers       int            `json:"busy_workers"`        // Workers running a scan
+	WorkerUtilization float64        `json:"worker_utilization"`  // Share of busy workers, from 0 to 1
+	Timestamp         time.Time      `json:"timestamp"`
+}
+
+// GetQueueStats returns the state of the scan queue and of the workers.
+// The caller must be an admin.
+func (s *ScanService) GetQueueStats(ctx context.Context) (*QueueStats, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.QueueStats(), nil
+}
+
+// QueueStats returns the state of the scan queue and of the workers without
+// authorization, for the metrics endpoint
+func (s *ScanService) QueueStats() *QueueStats {
+	state := s.pool.state()
+	now := time.Now()
+
+	stats := &QueueStats{
+		QueuedScans:     len(state.waiting),
+		DepthByPriority: map[string]int{ScanPriorityNormal: len(state.waiting)},
+		QueueCapacity:   state.queueSize,
+		Workers:         state.size,
+		BusyWorkers:     state.running,
+		Timestamp:       now,
+	}
+	if len(state.waiting) > 0 {
+		stats.OldestWaitSeconds = now.Sub(state.waiting[0]).Seconds()
+	}
+	if state.size > 0 {
+		// Workers retiring after a resize may still run scans
+		stats.WorkerUtilization = min(float64(state.running)/float64(state.size), 1)
+	}
+	return stats
+}
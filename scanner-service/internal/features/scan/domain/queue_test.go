@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGetQueueStats(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	adapter := &blockingScanAdapter{started: make(chan struct{}, 2), release: make(chan struct{})}
+	defer close(adapter.release)
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	repository.On("UpdateScan", mock.Anything).Return(nil)
+	repository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(adapter, repository, log, 2)
+	service.SetScanQueueSize(3)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	admin := principalContext("admin", authdomain.RoleAdmin)
+
+	// Only admins see the queue
+	_, err := service.GetQueueStats(ctx)
+	assert.Error(t, err)
+
+	stats, err := service.GetQueueStats(admin)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.QueuedScans)
+	assert.Zero(t, stats.OldestWaitSeconds)
+	assert.Zero(t, stats.WorkerUtilization)
+
+	// Both workers run a scan, the third scan waits
+	for _, target := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		_, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: target, Timeout: time.Minute})
+		require.NoError(t, err)
+	}
+	<-adapter.started
+	<-adapter.started
+
+	stats, err = service.GetQueueStats(admin)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.QueuedScans)
+	assert.Equal(t, map[string]int{domain.ScanPriorityNormal: 1}, stats.DepthByPriority)
+	assert.Greater(t, stats.OldestWaitSeconds, 0.0)
+	assert.Equal(t, 3, stats.QueueCapacity)
+	assert.Equal(t, 2, stats.Workers)
+	assert.Equal(t, 2, stats.BusyWorkers)
+	assert.Equal(t, 1.0, stats.WorkerUtilization)
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// MaxRetryLimit is the highest number of probe retransmissions a scan may request
+const MaxRetryLimit = 50
+
+// SetRateLimits sets the highest packet rate and parallelism a scan may request,
+// in its options or its extra options. Zero disables a limit.
+func (s *ScanService) SetRateLimits(maxRate, maxParallelism int) {
+	s.maxRate = maxRate
+	s.maxParallelism = maxParallelism
+}
+
+// validateRateOptions checks the packet rate, parallelism and retries of a scan against
+// each other and the configured ceilings
+func (s *ScanService) validateRateOptions(options ScanOptions) error {
+	switch {
+	case options.MinRate < 0:
+		return errors.NewInvalidField("min_rate", "min", "packet rates and parallelism must not be negative")
+	case options.MaxRate < 0:
+		return errors.NewInvalidField("max_rate", "min", "packet rates and parallelism must not be negative")
+	case options.MaxParallelism < 0:
+		return errors.NewInvalidField("max_parallelism", "min", "packet rates and parallelism must not be negative")
+	}
+	if options.MinRate > 0 && options.MaxRate > 0 && options.MinRate > options.MaxRate {
+		return errors.NewInvalidField("min_rate", "max_rate", fmt.Sprintf("minimum rate %d exceeds the maximum rate %d", options.MinRate, options.MaxRate))
+	}
+	if options.MaxRetries != nil && (*options.MaxRetries < 0 || *options.MaxRetries > MaxRetryLimit) {
+		return errors.NewInvalidField("max_retries", "range", fmt.Sprintf("max retries must be between 0 and %d", MaxRetryLimit))
+	}
+
+	// Extra options must not get around the ceilings
+	limits := []struct {
+		name    string
+		field   string
+		value   int
+		ceiling int
+	}{
+		{"--min-rate", "min_rate", options.MinRate, s.maxRate},
+		{"--max-rate", "max_rate", options.MaxRate, s.maxRate},
+		{"--max-parallelism", "max_parallelism", options.MaxParallelism, s.maxParallelism},
+		{"--min-parallelism", "extra_options", 0, s.maxParallelism},
+	}
+	for _, limit := range limits {
+		if limit.ceiling <= 0 {
+			continue
+		}
+		value := limit.value
+		if extra, ok := extraOptionValue(options.ExtraOptions, limit.name); ok {
+			n, err := strconv.Atoi(extra)
+			if err != nil {
+				return errors.NewInvalidField("extra_options", "type", "invalid value for nmap option "+limit.name+": "+extra)
+			}
+			value = max(value, n)
+		}
+		if value > limit.ceiling {
+			field := limit.field
+			if value != limit.value {
+				field = "extra_options"
+			}
+			return errors.NewInvalidField(field, "max", fmt.Sprintf("%s %d exceeds the maximum of %d", strings.TrimPrefix(limit.name, "--"), value, limit.ceiling))
+		}
+	}
+
+	return nil
+}
+
+// extraOptionValue returns the value of an nmap option in the extra options, given
+// after "=" or as the next option
+func extraOptionValue(extraOptions []string, name string) (string, bool) {
+	for i, option := range extraOptions {
+		if value, ok := strings.CutPrefix(option, name+"="); ok {
+			return value, true
+		}
+		if option == name && i+1 < len(extraOptions) {
+			return extraOptions[i+1], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"context"
+	"path/filepath"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// ScanResumer is implemented by scan adapters that can continue an interrupted scan
+// from the state file recorded while it ran
+type ScanResumer interface {
+	CanResume(options ScanOptions) bool
+	ResumeScan(ctx context.Context, options ScanOptions) (*ScanResult, error)
+}
+
+// SetStateDir sets the directory where local nmap scans record their progress, so that
+// failed and cancelled scans can be resumed. An empty directory disables resumption.
+func (s *ScanService) SetStateDir(stateDir string) {
+	s.stateDir = stateDir
+}
+
+// resumableOptions returns the options of a scan with the state file set if the scan
+// runs with the local nmap and can be resumed
+func (s *ScanService) resumableOptions(scan *Scan, options ScanOptions) ScanOptions {
+	if _, ok := s.adapter.(ScanResumer); !ok || s.stateDir == "" {
+		return options
+	}
+	if options.Agent != "" || options.ResolvedEngine() != ScanEngineNmap {
+		return options
+	}
+	options.StateFile = s.stateFile(scan)
+	return options
+}
+
+// stateFile returns the path of the state file of a scan
+func (s *ScanService) stateFile(scan *Scan) string {
+	return filepath.Join(s.stateDir, scan.ID+".gnmap")
+}
+
+// canResume reports whether progress of the scan was saved
+func (s *ScanService) canResume(scan *Scan, options ScanOptions) bool {
+	resumer, ok := s.adapter.(ScanResumer)
+	return ok && options.StateFile != "" && resumer.CanResume(options)
+}
+
+// ResumeScan restarts a failed or cancelled scan from the progress nmap saved, so that
+// hosts already scanned are not scanned again.
+// The caller must have the operator role and own the scan, or be an admin.
+func (s *ScanService) ResumeScan(ctx context.Context, id string) (*Scan, error) {
+	// Check permissions
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get scan
+	scan, err := s.getScan(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !principal.CanAccess(scan.UserID) {
+		return nil, errors.NewNotFound("scan not found", nil)
+	}
+
+	if scan.DeletedAt != nil {
+		return nil, errors.NewInvalidInput("scan is in the trash, restore it first", nil)
+	}
+	if scan.Status != ScanStatusFailed && scan.Status != ScanStatusCancelled {
+		return nil, errors.NewInvalidInput("only failed or cancelled scans can be resumed", nil)
+	}
+	if !scan.Resumable || !s.canResume(scan, s.resumableOptions(scan, scan.Options)) {
+		return nil, errors.NewInvalidInput("scan has no saved progress to resume", nil)
+	}
+
+	// The target scope and option policy may have changed since the scan started
+	if err := s.CheckScan(ctx, scan.Options); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if s.draining {
+		s.mu.Unlock()
+		return nil, errDraining
+	}
+	if _, active := s.pool.liveScan(scan.ID); active {
+		s.mu.Unlock()
+		return nil, errors.NewInvalidInput("scan is already running", nil)
+	}
+	if err := s.pool.admit(scan); err != nil {
+		s.mu.Unlock()
+		if err == errScanLimitReached {
+			return nil, s.scanLimitError()
+		}
+		return nil, err
+	}
+	scan.Status = ScanStatusPending
+	scan.Error = ""
+	scan.CompletedAt = nil
+	resumed := *scan
+	s.mu.Unlock()
+
+	if err := s.repository.UpdateScan(&resumed); err != nil {
+		s.pool.withdraw(scan)
+		return nil, errors.NewInternal("failed to update scan", err)
+	}
+
+	// Queue scan for a worker, detached from the cancellation of the request
+	s.pool.submit(scanJob{ctx: context.WithoutCancel(ctx), scan: scan, resume: true})
+
+	return &resumed, nil
+}
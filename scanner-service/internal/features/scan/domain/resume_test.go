@@ -0,0 +1,104 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// resumableScanAdapter is a scan adapter failing every scan and resuming it from saved progress
+type resumableScanAdapter struct {
+	MockScanAdapter
+	resumed chan domain.ScanOptions
+}
+
+func (a *resumableScanAdapter) ExecuteScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	return nil, errors.New("nmap scan failed")
+}
+
+func (a *resumableScanAdapter) CanResume(options domain.ScanOptions) bool {
+	return options.StateFile != ""
+}
+
+func (a *resumableScanAdapter) ResumeScan(ctx context.Context, options domain.ScanOptions) (*domain.ScanResult, error) {
+	a.resumed <- options
+	return &domain.ScanResult{ID: "resumed-result", TotalHosts: 256, UpHosts: 1}, nil
+}
+
+func TestResumeScan(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	adapter := &resumableScanAdapter{resumed: make(chan domain.ScanOptions, 1)}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+	repository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(adapter, repository, log, 10)
+	service.SetStateDir("/var/lib/scanner")
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	// A failed scan with saved progress is resumable
+	scan, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.0/24", Timeout: time.Minute})
+	require.NoError(t, err)
+	repository.On("GetScanByID", scan.ID).Return(scans.get, nil)
+	assert.Eventually(t, func() bool {
+		current, err := service.GetScan(ctx, scan.ID)
+		return err == nil && current.Status == domain.ScanStatusFailed && current.Resumable
+	}, time.Second, 10*time.Millisecond)
+
+	// Other users cannot resume the scan
+	_, err = service.ResumeScan(principalContext("bob", authdomain.RoleOperator), scan.ID)
+	assert.Error(t, err)
+
+	resumed, err := service.ResumeScan(ctx, scan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, scan.ID, resumed.ID)
+
+	select {
+	case options := <-adapter.resumed:
+		assert.Equal(t, "/var/lib/scanner/"+scan.ID+".gnmap", options.StateFile)
+	case <-time.After(time.Second):
+		t.Fatal("scan was not resumed")
+	}
+	assert.Eventually(t, func() bool {
+		current, err := service.GetScan(ctx, scan.ID)
+		return err == nil && current.Status == domain.ScanStatusCompleted && current.ResultID == "resumed-result"
+	}, time.Second, 10*time.Millisecond)
+
+	// Completed scans cannot be resumed
+	_, err = service.ResumeScan(ctx, scan.ID)
+	assert.Error(t, err)
+}
+
+func TestResumeScanWithoutSavedProgress(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	repository.On("GetScanByID", "failed").Return(&domain.Scan{
+		ID:      "failed",
+		UserID:  "alice",
+		Status:  domain.ScanStatusFailed,
+		Options: domain.ScanOptions{Target: "10.0.0.1"},
+	}, nil)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+
+	// Resumption is disabled without a state directory
+	service := domain.NewScanService(&resumableScanAdapter{}, repository, log, 10)
+	_, err := service.ResumeScan(ctx, "failed")
+	assert.Error(t, err)
+
+	// The scan failed before saving progress
+	service.SetStateDir("/var/lib/scanner")
+	_, err = service.ResumeScan(ctx, "failed")
+	assert.Error(t, err)
+}
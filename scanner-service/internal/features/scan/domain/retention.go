@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// DataClass represents a kind of stored scan data with its own retention period
+type DataClass string
+
+// Data class constants
+const (
+	DataClassResults   DataClass = "results"    // Scan results with the hosts, ports and script output
+	DataClassRawOutput DataClass = "raw_output" // nmap output kept in the state directory to resume scans
+)
+
+// DataClasses lists the data classes retention rules can be set for
+var DataClasses = []DataClass{DataClassResults, DataClassRawOutput}
+
+// RetentionItem represents stored data that expired under a retention rule
+type RetentionItem struct {
+	Class     DataClass `json:"class"`             // Class of the data
+	ID        string    `json:"id"`                // Result ID, or state file name for raw output
+	ScanID    string    `json:"scan_id"`           // Scan the data belongs to
+	UserID    string    `json:"user_id,omitempty"` // Owner of the scan, empty if the scan no longer exists
+	Timestamp time.Time `json:"timestamp"`         // When the data was written
+}
+
+// RetentionReport represents the outcome of applying the retention rules once
+type RetentionReport struct {
+	DryRun    bool                    `json:"dry_run"`   // Whether the expired data was only reported
+	Cutoffs   map[DataClass]time.Time `json:"cutoffs"`   // Data of a class written before its cutoff expired
+	Deleted   map[DataClass]int       `json:"deleted"`   // Number of expired items per class
+	Items     []RetentionItem         `json:"items"`     // Expired items, oldest first
+	Timestamp time.Time               `json:"timestamp"` // When the rules were applied
+}
+
+// SetRetentionRules sets how long the data of each class is kept. Data of classes
+// without a rule is kept as long as its scan, which the repository expires.
+func (s *ScanService) SetRetentionRules(rules map[DataClass]time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.retentionRules = rules
+}
+
+// StartRetention starts applying the retention rules every interval. In dry-run mode
+// the expired data is only logged.
+func (s *ScanService) StartRetention(interval time.Duration, dryRun bool) {
+	s.retentionStop = make(chan struct{})
+	stop := s.retentionStop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				if _, err := s.applyRetention(now, dryRun); err != nil {
+					s.logger.Error("Failed to apply retention rules", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// StopRetention stops applying the retention rules
+func (s *ScanService) StopRetention() {
+	if s.retentionStop != nil {
+		close(s.retentionStop)
+	}
+}
+
+// ApplyRetention deletes the data that expired under the retention rules, or with
+// dryRun only reports it. The caller must be an admin.
+func (s *ScanService) ApplyRetention(ctx context.Context, dryRun bool) (*RetentionReport, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.applyRetention(time.Now(), dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		s.logger.Info("Retention rules applied on demand",
+			zap.Int("deleted", len(report.Items)),
+			zap.String("applied_by", principal.UserID),
+		)
+	}
+
+	return report, nil
+}
+
+// applyRetention deletes or reports the data that expired under the retention rules at now
+func (s *ScanService) applyRetention(now time.Time, dryRun bool) (*RetentionReport, error) {
+	s.mu.Lock()
+	rules := s.retentionRules
+	s.mu.Unlock()
+
+	report := &RetentionReport{
+		DryRun:    dryRun,
+		Cutoffs:   make(map[DataClass]time.Time),
+		Deleted:   make(map[DataClass]int),
+		Items:     []RetentionItem{},
+		Timestamp: now,
+	}
+
+	if period, ok := rules[DataClassResults]; ok {
+		cutoff := now.Add(-period)
+		report.Cutoffs[DataClassResults] = cutoff
+
+		results, err := s.repository.ListExpiredScanResults(cutoff)
+		if err != nil {
+			return nil, errors.NewInternal("failed to list expired scan results", err)
+		}
+		for _, result := range results {
+			report.Items = append(report.Items, RetentionItem{
+				Class:     DataClassResults,
+				ID:        result.ID,
+				ScanID:    result.ScanID,
+				UserID:    result.UserID,
+				Timestamp: result.EndTime,
+			})
+		}
+	}
+
+	if period, ok := rules[DataClassRawOutput]; ok && s.stateDir != "" {
+		cutoff := now.Add(-period)
+		report.Cutoffs[DataClassRawOutput] = cutoff
+
+		items, err := s.expiredStateFiles(cutoff)
+		if err != nil {
+			return nil, errors.NewInternal("failed to list expired state files", err)
+		}
+		report.Items = append(report.Items, items...)
+	}
+
+	slices.SortStableFunc(report.Items, func(a, b RetentionItem) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	for _, item := range report.Items {
+		if dryRun {
+			s.logger.Info("Data expired under retention rule, dry run keeps it",
+				zap.String("class", string(item.Class)),
+				zap.String("id", item.ID),
+				zap.String("scan_id", item.ScanID),
+				zap.Time("timestamp", item.Timestamp),
+			)
+		} else if err := s.deleteExpired(item); err != nil {
+			return nil, err
+		}
+		report.Deleted[item.Class]++
+	}
+
+	if len(report.Items) > 0 {
+		s.logger.Info("Retention rules applied",
+			zap.Bool("dry_run", dryRun),
+			zap.Int("results", report.Deleted[DataClassResults]),
+			zap.Int("raw_output", report.Deleted[DataClassRawOutput]),
+		)
+	}
+
+	return report, nil
+}
+
+// expiredStateFiles returns the state files in the state directory last written before
+// the cutoff. State files of scans that run again after a resume are in use and kept.
+func (s *ScanService) expiredStateFiles(cutoff time.Time) ([]RetentionItem, error) {
+	entries, err := os.ReadDir(s.stateDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var items []RetentionItem
+	for _, entry := range entries {
+		scanID, ok := strings.CutSuffix(entry.Name(), ".gnmap")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+
+		item := RetentionItem{Class: DataClassRawOutput, ID: entry.Name(), ScanID: scanID, Timestamp: info.ModTime()}
+		if scan, err := s.repository.GetScanByID(scanID); err == nil {
+			if !scan.Status.Terminal() {
+				continue
+			}
+			item.UserID = scan.UserID
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// deleteExpired deletes an expired item. Items deleted meanwhile are skipped.
+func (s *ScanService) deleteExpired(item RetentionItem) error {
+	switch item.Class {
+	case DataClassResults:
+		if err := s.repository.DeleteScanResult(item.ID); err != nil {
+			if errors.From(err).Type == errors.ErrNotFound {
+				return nil
+			}
+			return errors.NewInternal("failed to delete scan result", err)
+		}
+	case DataClassRawOutput:
+		if err := os.Remove(filepath.Join(s.stateDir, item.ID)); err != nil && !os.IsNotExist(err) {
+			return errors.NewInternal("failed to delete state file", err)
+		}
+		// Without its state file the scan can no longer be resumed
+		if scan, err := s.repository.GetScanByID(item.ScanID); err == nil && scan.Resumable {
+			scan.Resumable = false
+			if err := s.repository.UpdateScan(scan); err != nil {
+				return errors.NewInternal("failed to update scan", err)
+			}
+		}
+	}
+	return nil
+}
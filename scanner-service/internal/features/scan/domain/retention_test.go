@@ -0,0 +1,74 @@
+package domain_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestApplyRetention(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	service := domain.NewScanService(new(MockScanAdapter), repository, log, 10)
+	stateDir := t.TempDir()
+	service.SetStateDir(stateDir)
+	service.SetRetentionRules(map[domain.DataClass]time.Duration{
+		domain.DataClassResults:   30 * 24 * time.Hour,
+		domain.DataClassRawOutput: 7 * 24 * time.Hour,
+	})
+	admin := principalContext("admin", authdomain.RoleAdmin)
+
+	// State files of a cancelled scan and a resumed scan running again expired, a recent one did not
+	old := time.Now().Add(-8 * 24 * time.Hour)
+	for _, name := range []string{"cancelled.gnmap", "resumed.gnmap", "recent.gnmap"} {
+		require.NoError(t, os.WriteFile(filepath.Join(stateDir, name), []byte("# Nmap"), 0o600))
+	}
+	require.NoError(t, os.Chtimes(filepath.Join(stateDir, "cancelled.gnmap"), old, old))
+	require.NoError(t, os.Chtimes(filepath.Join(stateDir, "resumed.gnmap"), old, old))
+	repository.On("GetScanByID", "cancelled").Return(&domain.Scan{ID: "cancelled", UserID: "alice", Status: domain.ScanStatusCancelled, Resumable: true}, nil)
+	repository.On("GetScanByID", "resumed").Return(&domain.Scan{ID: "resumed", UserID: "alice", Status: domain.ScanStatusRunning}, nil)
+
+	ended := time.Now().Add(-31 * 24 * time.Hour)
+	repository.On("ListExpiredScanResults", mock.AnythingOfType("time.Time")).
+		Return([]*domain.ScanResult{{ID: "result-1", ScanID: "scan-1", UserID: "bob", EndTime: ended}}, nil)
+
+	report, err := service.ApplyRetention(admin, true)
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, map[domain.DataClass]int{domain.DataClassResults: 1, domain.DataClassRawOutput: 1}, report.Deleted)
+	require.Len(t, report.Items, 2)
+	assert.Equal(t, "result-1", report.Items[0].ID)
+	assert.Equal(t, domain.RetentionItem{Class: domain.DataClassRawOutput, ID: "cancelled.gnmap", ScanID: "cancelled", UserID: "alice", Timestamp: report.Items[1].Timestamp}, report.Items[1])
+	assert.FileExists(t, filepath.Join(stateDir, "cancelled.gnmap"))
+	repository.AssertNotCalled(t, "DeleteScanResult", mock.Anything)
+
+	// Applying the rules deletes the expired data, the scan can no longer be resumed
+	repository.On("DeleteScanResult", "result-1").Return(nil).Once()
+	repository.On("UpdateScan", mock.MatchedBy(func(scan *domain.Scan) bool {
+		return scan.ID == "cancelled" && !scan.Resumable
+	})).Return(nil).Once()
+
+	report, err = service.ApplyRetention(admin, false)
+	require.NoError(t, err)
+	assert.Len(t, report.Items, 2)
+	assert.NoFileExists(t, filepath.Join(stateDir, "cancelled.gnmap"))
+	assert.FileExists(t, filepath.Join(stateDir, "resumed.gnmap"))
+	assert.FileExists(t, filepath.Join(stateDir, "recent.gnmap"))
+	repository.AssertExpectations(t)
+
+	// Only admins apply the rules
+	_, err = service.ApplyRetention(principalContext("alice", authdomain.RoleOperator), true)
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrForbidden, scanErr.Type)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// scanSlotRetryInterval is how long RunScan waits for room in the worker pool before retrying
+const scanSlotRetryInterval = 5 * time.Second
+
+// CheckScan validates scan options and checks them against the option policy
+// and the target scope of the caller
+func (s *ScanService) CheckScan(ctx context.Context, options ScanOptions) error {
+	// Validate options
+	if err := s.validateScanOptions(ctx, options); err != nil {
+		return err
+	}
+
+	// Check option policy
+	if s.optionAuthorizer != nil {
+		if err := s.optionAuthorizer.AuthorizeOptions(ctx, options); err != nil {
+			return err
+		}
+	}
+
+	// Check target scope
+	if s.targetAuthorizer != nil {
+		if err := s.targetAuthorizer.AuthorizeTarget(ctx, options.Target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunScan runs a scan to completion and returns its result.
+// The scan needs UserID and Options set; the options must have been checked with CheckScan.
+// RunScan waits for room if the workers and the queue are full, and cancelling ctx
+// cancels the scan. The caller must have the operator role.
+func (s *ScanService) RunScan(ctx context.Context, scan *Scan) (*ScanResult, error) {
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	// Wait for room in the worker pool
+	for {
+		_, err := s.newScan(ctx, scan)
+		if err == nil {
+			break
+		}
+		if err != errScanLimitReached {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(scanSlotRetryInterval):
+		}
+	}
+
+	// The scan is cancelled through abortScan, so it is recorded as cancelled rather than failed
+	done := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		s.abortScan(scan, "")
+	})
+	s.pool.submit(scanJob{ctx: context.WithoutCancel(ctx), scan: scan, done: done})
+	<-done
+	stop()
+
+	s.mu.Lock()
+	status, scanError, resultID := scan.Status, scan.Error, scan.ResultID
+	s.mu.Unlock()
+
+	switch status {
+	case ScanStatusCompleted:
+	case ScanStatusCancelled:
+		return nil, errors.NewUnavailable("scan cancelled", nil)
+	default:
+		return nil, errors.NewInternal("scan failed: "+scanError, nil)
+	}
+
+	result, err := s.repository.GetScanResultByID(resultID)
+	if err != nil {
+		return nil, errors.NewInternal("failed to get scan result", err)
+	}
+
+	return result, nil
+}
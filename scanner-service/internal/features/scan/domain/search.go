@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+)
+
+// MaxSearchResults is the maximum number of hosts returned by a single search
+const MaxSearchResults = 200
+
+// HostQuery represents a full-text search over the hosts of stored scan results
+type HostQuery struct {
+	UserID string   // Owner of the results, empty for all users
+	Terms  []string // Lowercase terms that must all occur in a host
+	Limit  int      // Maximum number of hosts to return
+}
+
+// HostMatch represents a host of a stored scan result matching a search
+type HostMatch struct {
+	ScanID   string    `json:"scan_id"`   // Scan the host was found by
+	ResultID string    `json:"result_id"` // Result the host belongs to
+	UserID   string    `json:"user_id"`   // User who initiated the scan
+	ScanTime time.Time `json:"scan_time"` // When the scan ended
+	Host     Host      `json:"host"`      // Matching host
+	Fields   []string  `json:"fields"`    // Fields the terms matched, e.g. "os" or "port 22/tcp"
+}
+
+// HostSearchResult represents the hosts matching a search, most recent scans first
+type HostSearchResult struct {
+	Hosts      []*HostMatch `json:"hosts"`       // Matching hosts, at most the query limit
+	TotalCount int          `json:"total_count"` // Number of matching hosts
+}
+
+// ParseSearchTerms splits a search query into lowercase terms
+func ParseSearchTerms(query string) []string {
+	return strings.Fields(strings.ToLower(query))
+}
+
+// MatchHost reports whether all terms occur in the searchable fields of the host
+// (hostnames, OS, service names, banners and script output) and which fields they matched.
+func MatchHost(host Host, terms []string) ([]string, bool) {
+	type field struct {
+		name string
+		text string
+	}
+
+	fields := []field{
+		{"ip", host.IP},
+		{"hostname", strings.Join(host.Hostnames, " ")},
+		{"os", host.OS},
+	}
+	for _, port := range host.Ports {
+		fields = append(fields, field{
+			name: fmt.Sprintf("port %d/%s", port.Port, port.Protocol),
+			text: strings.Join([]string{port.Service, port.Product, port.Version, port.ExtraInfo}, " "),
+		})
+	}
+	for _, script := range host.Scripts {
+		fields = append(fields, field{name: "script " + script.ID, text: script.Output})
+	}
+
+	var matched []string
+	for _, term := range terms {
+		found := false
+		for _, f := range fields {
+			if !strings.Contains(strings.ToLower(f.text), term) {
+				continue
+			}
+			found = true
+			if !contains(matched, f.name) {
+				matched = append(matched, f.name)
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+
+	return matched, len(terms) > 0
+}
+
+// HostFilter selects hosts of a scan result. Empty fields match every host.
+type HostFilter struct {
+	Status  string   // Host status, "up" or "down"
+	Port    int      // Port the host has open
+	Service string   // Service the host runs on an open port, case-insensitive
+	Terms   []string // Lowercase search terms that must all occur in the host, see MatchHost
+}
+
+// Validate checks the host status and port of the filter
+func (f HostFilter) Validate() error {
+	if f.Status != "" && f.Status != "up" && f.Status != "down" {
+		return errors.NewInvalidField("status", "oneof", "status must be up or down")
+	}
+	if f.Port < 0 || f.Port > 65535 {
+		return errors.NewInvalidField("port", "range", "port must be between 1 and 65535")
+	}
+	return nil
+}
+
+// Matches reports whether the host passes the filter
+func (f HostFilter) Matches(host Host) bool {
+	if f.Status != "" && host.Status != f.Status {
+		return false
+	}
+	if f.Port > 0 || f.Service != "" {
+		found := false
+		for _, port := range host.Ports {
+			if port.State != "open" {
+				continue
+			}
+			if (f.Port == 0 || port.Port == f.Port) && (f.Service == "" || strings.EqualFold(port.Service, f.Service)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Terms) > 0 {
+		if _, ok := MatchHost(host, f.Terms); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// contains reports whether the slice contains the value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchHosts searches the hosts of stored scan results, e.g. "openssh 7.2".
+// Non-admins only search their own results; admins search all results
+// unless userID is set.
+func (s *ScanService) SearchHosts(ctx context.Context, query, userID string, limit int) (*HostSearchResult, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if !principal.IsAdmin() {
+		if userID != "" && userID != principal.UserID {
+			return nil, errors.NewForbidden("cannot search results of other users", nil)
+		}
+		userID = principal.UserID
+	}
+
+	terms := ParseSearchTerms(query)
+	if len(terms) == 0 {
+		return nil, errors.NewInvalidInput("search query is required", nil)
+	}
+
+	if limit < 1 || limit > MaxSearchResults {
+		return nil, errors.NewInvalidInput(fmt.Sprintf("limit must be between 1 and %d", MaxSearchResults), nil)
+	}
+
+	result, err := s.repository.SearchHosts(HostQuery{UserID: userID, Terms: terms, Limit: limit})
+	if err != nil {
+		return nil, errors.NewInternal("failed to search scan results", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"testing"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMatchHost(t *testing.T) {
+	host := domain.Host{
+		IP:        "10.0.0.1",
+		Hostnames: []string{"web.example.com"},
+		OS:        "Linux 4.15",
+		Ports: []domain.Port{
+			{Port: 22, Protocol: "tcp", Service: "ssh", Product: "OpenSSH", Version: "7.2p2 Ubuntu 4ubuntu2.8"},
+			{Port: 443, Protocol: "tcp", Service: "https", Product: "nginx"},
+		},
+		Scripts: []domain.Script{
+			{ID: "ssl-cert", Output: "Subject: commonName=web.example.com"},
+		},
+	}
+
+	fields, ok := domain.MatchHost(host, domain.ParseSearchTerms("OpenSSH 7.2"))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"port 22/tcp"}, fields)
+
+	fields, ok = domain.MatchHost(host, domain.ParseSearchTerms("example.com linux"))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"hostname", "script ssl-cert", "os"}, fields)
+
+	_, ok = domain.MatchHost(host, domain.ParseSearchTerms("openssh 8.0"))
+	assert.False(t, ok)
+
+	_, ok = domain.MatchHost(host, nil)
+	assert.False(t, ok)
+}
+
+func TestHostFilter(t *testing.T) {
+	web := domain.Host{
+		IP:     "10.0.0.1",
+		Status: "up",
+		Ports: []domain.Port{
+			{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH"},
+			{Port: 80, Protocol: "tcp", State: "closed", Service: "http"},
+		},
+	}
+	down := domain.Host{IP: "10.0.0.2", Status: "down"}
+
+	assert.True(t, domain.HostFilter{}.Matches(web))
+	assert.True(t, domain.HostFilter{}.Matches(down))
+	assert.True(t, domain.HostFilter{Status: "up"}.Matches(web))
+	assert.False(t, domain.HostFilter{Status: "up"}.Matches(down))
+	assert.True(t, domain.HostFilter{Port: 22}.Matches(web))
+	assert.False(t, domain.HostFilter{Port: 80}.Matches(web), "closed ports do not match")
+	assert.True(t, domain.HostFilter{Service: "SSH"}.Matches(web))
+	assert.False(t, domain.HostFilter{Port: 22, Service: "http"}.Matches(web))
+	assert.True(t, domain.HostFilter{Terms: domain.ParseSearchTerms("openssh")}.Matches(web))
+	assert.False(t, domain.HostFilter{Terms: domain.ParseSearchTerms("nginx")}.Matches(web))
+
+	assert.NoError(t, domain.HostFilter{Status: "down", Port: 443}.Validate())
+	assert.Error(t, domain.HostFilter{Status: "unknown"}.Validate())
+	assert.Error(t, domain.HostFilter{Port: 70000}.Validate())
+}
+
+func TestSearchHosts(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	mockRepository := new(MockScanRepository)
+	service := domain.NewScanService(new(MockScanAdapter), mockRepository, log, 10)
+
+	expected := &domain.HostSearchResult{Hosts: []*domain.HostMatch{{ScanID: "scan"}}, TotalCount: 1}
+	mockRepository.On("SearchHosts", domain.HostQuery{UserID: "alice", Terms: []string{"openssh"}, Limit: 10}).Return(expected, nil)
+	mockRepository.On("SearchHosts", domain.HostQuery{Terms: []string{"openssh"}, Limit: 10}).Return(expected, nil)
+
+	// Viewers search their own results
+	result, err := service.SearchHosts(principalContext("alice", authdomain.RoleViewer), "OpenSSH", "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	_, err = service.SearchHosts(principalContext("alice", authdomain.RoleViewer), "OpenSSH", "bob", 10)
+	assert.Error(t, err)
+
+	// Admins search all results
+	_, err = service.SearchHosts(principalContext("admin", authdomain.RoleAdmin), "OpenSSH", "", 10)
+	assert.NoError(t, err)
+
+	// The query and limit are validated
+	_, err = service.SearchHosts(principalContext("alice", authdomain.RoleViewer), "  ", "", 10)
+	assert.Error(t, err)
+	_, err = service.SearchHosts(principalContext("alice", authdomain.RoleViewer), "ssh", "", 0)
+	assert.Error(t, err)
+
+	mockRepository.AssertExpectations(t)
+}
@@ -2,108 +2,323 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-// ScanAdapter defines the interface for nmap adapter
+// ScanAdapter defines the interface for nmap adapter. ExecuteScan may return a partial
+// result together with the error of a scan that timed out or was cancelled.
 type ScanAdapter interface {
 	ExecuteScan(ctx context.Context, options ScanOptions) (*ScanResult, error)
 	GetVersion() (string, error)
 	IsAvailable() bool
 }
 
+// AgentLocator is implemented by scan adapters that can run scans on remote agents
+type AgentLocator interface {
+	CheckAgent(options ScanOptions) error
+}
+
+// EngineChecker is implemented by scan adapters that select the engine running each scan
+type EngineChecker interface {
+	CheckEngine(options ScanOptions) error
+}
+
 // ScanRepository defines the interface for scan repository
 type ScanRepository interface {
 	SaveScan(scan *Scan) error
 	UpdateScan(scan *Scan) error
 	GetScanByID(id string) (*Scan, error)
-	ListScans(userID string, limit, offset int) ([]*Scan, error)
+	ListScans(filter ScanFilter, sort ScanSort, page PageRequest) (*ScanPage, error)
+	CountScans(filter ScanFilter) (int, error)
 	DeleteScan(id string) error
 	SaveScanResult(result *ScanResult) error
 	GetScanResultByID(id string) (*ScanResult, error)
 	DeleteScanResult(id string) error
+	PurgeScanResults(before time.Time) (int, error)
+	ListExpiredScanResults(before time.Time) ([]*ScanResult, error)
+	AppendPartialHosts(scanID string, hosts []Host) error
+	GetPartialHosts(scanID string) ([]Host, error)
+	DeletePartialHosts(scanID string) error
+	SearchHosts(query HostQuery) (*HostSearchResult, error)
+	AggregateSurface(userID string, limit int) (*AttackSurface, error)
+	SavePipeline(pipeline *Pipeline) error
+	UpdatePipeline(pipeline *Pipeline) error
+	GetPipelineByID(id string) (*Pipeline, error)
+	ListPipelines(userID string) ([]*Pipeline, error)
+	SaveBaseline(baseline *Baseline) error
+	GetBaselineByID(id string) (*Baseline, error)
+	ListBaselines(userID string) ([]*Baseline, error)
+	DeleteBaseline(id string) error
+}
+
+// TargetAuthorizer defines the interface for checking whether the caller may scan a target
+type TargetAuthorizer interface {
+	AuthorizeTarget(ctx context.Context, target string) error
+}
+
+// OptionAuthorizer defines the interface for checking whether the caller may use scan options
+type OptionAuthorizer interface {
+	AuthorizeOptions(ctx context.Context, options ScanOptions) error
+}
+
+// TargetBlocklist defines the interface for rejecting targets in forbidden networks
+type TargetBlocklist interface {
+	CheckTarget(ctx context.Context, target string) error
+}
+
+// ResultEnricher defines the interface for attaching additional data to scan results
+// before they are stored. Enrichment failures do not fail the scan.
+type ResultEnricher interface {
+	Name() string
+	Enrich(ctx context.Context, result *ScanResult) error
+}
+
+// CompletionListener defines the interface for reacting to completed scans. Listeners
+// are called after the result is stored and checked against the baselines, and must
+// not change the scan or the result.
+type CompletionListener interface {
+	ScanCompleted(ctx context.Context, scan *Scan, result *ScanResult)
 }
 
 // ScanService handles scan operations
 type ScanService struct {
-	adapter            ScanAdapter
-	repository         ScanRepository
-	logger             *logger.Logger
-	maxConcurrentScans int
-	activeScans        map[string]*Scan
-	mu                 sync.Mutex
+	adapter          ScanAdapter
+	repository       ScanRepository
+	targetAuthorizer TargetAuthorizer
+	targetBlocklist  TargetBlocklist
+	optionAuthorizer OptionAuthorizer
+	discoverer       TargetDiscoverer
+	enrichers        []ResultEnricher
+	listeners        []CompletionListener
+	logger           *logger.Logger
+	pool             *workerPool // Runs the scans, and holds the queued and running ones
+	cancelFuncs      map[string]context.CancelFunc
+	pipelineCancels  map[string]context.CancelFunc
+	shardSize        int           // Maximum addresses per child scan, 0 to disable sharding
+	shardConcurrency int           // Maximum child scans of a scan running in parallel
+	maxTimeout       time.Duration // Maximum scan timeout, 0 for no limit
+	maxHosts         int           // Maximum addresses covered by a scan target, 0 for no limit
+	maxRate          int           // Highest packet rate a scan may request, 0 for no limit
+	maxParallelism   int           // Highest probe parallelism a scan may request, 0 for no limit
+	evasionEnabled   bool          // Whether callers with the advanced role may use evasion options
+	stateDir         string        // Directory of the state files of resumable scans, empty to disable
+	draining         bool          // Whether new scans are rejected because the service shuts down
+	healthCheckers   []HealthChecker
+	build            BuildInfo
+	startedAt        time.Time
+	duplicateScans   DuplicateScanPolicy         // How scans identical to a running scan of the user are handled
+	scanLogs         scanLogStore                // Output of the scanner processes of recent scans
+	retentionRules   map[DataClass]time.Duration // How long the data of each class is kept
+	retentionStop    chan struct{}               // Closed to stop applying the retention rules
+	mu               sync.Mutex                  // Guards the fields of the active scans and the state of the service
+	notesMu          sync.Mutex                  // Serializes note changes, which rewrite the whole scan or result
+	baselineMu       sync.Mutex                  // Serializes baseline changes and checks, which rewrite the violations
 }
 
-// NewScanService creates a new ScanService
+// NewScanService creates a new ScanService running scans on maxConcurrentScans workers
 func NewScanService(adapter ScanAdapter, repository ScanRepository, logger *logger.Logger, maxConcurrentScans int) *ScanService {
-	return &ScanService{
-		adapter:            adapter,
-		repository:         repository,
-		logger:             logger,
-		maxConcurrentScans: maxConcurrentScans,
-		activeScans:        make(map[string]*Scan),
+	service := &ScanService{
+		adapter:         adapter,
+		repository:      repository,
+		logger:          logger,
+		cancelFuncs:     make(map[string]context.CancelFunc),
+		pipelineCancels: make(map[string]context.CancelFunc),
+		build:           BuildInfo{GoVersion: runtime.Version()},
+		startedAt:       time.Now(),
 	}
+	service.pool = newWorkerPool(maxConcurrentScans, &service.mu, service.executeScan, logger)
+	return service
 }
 
-// StartScan starts a new scan
+// SetScanQueueSize sets how many scans may wait for a worker once all workers are busy.
+// Further scans are rejected; by default no scan waits.
+func (s *ScanService) SetScanQueueSize(queueSize int) {
+	s.pool.setQueueSize(queueSize)
+}
+
+// SetTargetAuthorizer sets the authorizer used to restrict scan targets
+func (s *ScanService) SetTargetAuthorizer(targetAuthorizer TargetAuthorizer) {
+	s.targetAuthorizer = targetAuthorizer
+}
+
+// SetTargetBlocklist sets the blocklist checked while validating scan options
+func (s *ScanService) SetTargetBlocklist(targetBlocklist TargetBlocklist) {
+	s.targetBlocklist = targetBlocklist
+}
+
+// SetOptionAuthorizer sets the authorizer used to restrict scan options by role
+func (s *ScanService) SetOptionAuthorizer(optionAuthorizer OptionAuthorizer) {
+	s.optionAuthorizer = optionAuthorizer
+}
+
+// SetScanLimits sets the maximum timeout of a scan and the maximum number of addresses
+// its target may cover. Zero disables a limit.
+func (s *ScanService) SetScanLimits(maxTimeout time.Duration, maxHosts int) {
+	s.maxTimeout = maxTimeout
+	s.maxHosts = maxHosts
+}
+
+// SetTargetDiscoverer sets the discoverer used to expand domain targets into hosts
+func (s *ScanService) SetTargetDiscoverer(discoverer TargetDiscoverer) {
+	s.discoverer = discoverer
+}
+
+// AddResultEnricher adds an enricher run on every successful scan result
+func (s *ScanService) AddResultEnricher(enricher ResultEnricher) {
+	s.enrichers = append(s.enrichers, enricher)
+}
+
+// AddCompletionListener adds a listener called with every completed scan and its result
+func (s *ScanService) AddCompletionListener(listener CompletionListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// StartScan starts a new scan.
+// The caller must have the operator role.
 func (s *ScanService) StartScan(ctx context.Context, userID string, options ScanOptions) (*Scan, error) {
-	// Validate options
-	if err := s.validateScanOptions(options); err != nil {
+	// Check permissions
+	if _, err := authdomain.Authorize(ctx, authdomain.RoleOperator); err != nil {
+		return nil, err
+	}
+
+	// Normalize target
+	target, err := NormalizeTarget(options.Target)
+	if err != nil {
+		return nil, err
+	}
+	options.Target = target
+
+	// Validate options, option policy and target scope
+	if err := s.CheckScan(ctx, options); err != nil {
 		return nil, err
 	}
 
+	scan := &Scan{UserID: userID, Options: options}
+	started, err := s.newScan(ctx, scan)
+	if err == errScanLimitReached {
+		return nil, s.scanLimitError()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if started.ID != scan.ID {
+		// An identical scan is already running
+		return started, nil
+	}
+
+	// Queue scan for a worker, detached from the cancellation of the request
+	s.pool.submit(scanJob{ctx: context.WithoutCancel(ctx), scan: scan})
+
+	return started, nil
+}
+
+// errScanLimitReached is returned by newScan when the workers and the queue are full
+var errScanLimitReached = errors.NewRateLimited("maximum concurrent scans reached", nil)
+
+// defaultScanDuration is the assumed duration of scans before the first scan finished
+const defaultScanDuration = 30 * time.Second
+
+// scanLimitError returns errScanLimitReached with the estimated time until the worker
+// pool has room, assuming that each waiting scan and the rejected one take the average
+// scan duration spread over the workers
+func (s *ScanService) scanLimitError() error {
+	state := s.pool.state()
+	average := state.average
+	if average == 0 {
+		average = defaultScanDuration
+	}
+	retryAfter := average * time.Duration(len(state.waiting)+1) / time.Duration(max(state.size, 1))
+	return errors.WithRetryAfter(errScanLimitReached, max(retryAfter, time.Second))
+}
+
+// newScan stores a new pending scan if the worker pool has room for it and returns a
+// copy of it. The scan is completed with its ID, status and creation time, and must be
+// submitted to the pool. When duplicates are reused and an identical scan is active, a
+// copy of that scan is returned instead and scan is not stored.
+func (s *ScanService) newScan(ctx context.Context, scan *Scan) (*Scan, error) {
 	// Check if we can run more scans
 	s.mu.Lock()
-	if len(s.activeScans) >= s.maxConcurrentScans {
+	if s.draining {
 		s.mu.Unlock()
-		return nil, errors.NewUnavailable("maximum concurrent scans reached", nil)
+		return nil, errDraining
+	}
+	if duplicate := s.duplicateOf(scan); duplicate != nil {
+		s.mu.Unlock()
+		if s.duplicateScans == DuplicateScansReject {
+			return nil, errors.NewAlreadyExists("identical scan "+duplicate.ID+" is already running", nil)
+		}
+		s.logger.WithContext(ctx).Info("Reusing identical running scan",
+			zap.String("scan_id", duplicate.ID),
+			zap.String("user_id", scan.UserID),
+		)
+		return duplicate, nil
 	}
 
 	// Create scan
-	now := time.Now()
-	scan := &Scan{
-		ID:        uuid.New().String(),
-		UserID:    userID,
-		Options:   options,
-		Status:    ScanStatusPending,
-		Progress:  0,
-		CreatedAt: now,
+	scan.ID = uuid.New().String()
+	scan.Status = ScanStatusPending
+	scan.Progress = 0
+	scan.CreatedAt = time.Now()
+	scan.RequestID = requestid.FromContext(ctx)
+	if principal, ok := authdomain.PrincipalFromContext(ctx); ok && scan.TenantID == "" {
+		scan.TenantID = principal.TenantID
 	}
 
-	// Add to active scans
-	s.activeScans[scan.ID] = scan
+	// Add to active scans, if the workers and the queue have room
+	if err := s.pool.admit(scan); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	created := *scan
 	s.mu.Unlock()
 
 	// Save to repository
-	if err := s.repository.SaveScan(scan); err != nil {
-		s.mu.Lock()
-		delete(s.activeScans, scan.ID)
-		s.mu.Unlock()
+	if err := s.repository.SaveScan(&created); err != nil {
+		s.pool.withdraw(scan)
 		return nil, errors.NewInternal("failed to save scan", err)
 	}
 
-	// Start scan in a goroutine
-	go s.executeScan(ctx, scan)
+	return &created, nil
+}
+
+// GetScan gets a scan by ID.
+// Scans owned by other users are reported as not found unless the caller is an admin.
+func (s *ScanService) GetScan(ctx context.Context, id string) (*Scan, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	scan, err := s.getScan(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !principal.CanAccess(scan.UserID) {
+		return nil, errors.NewNotFound("scan not found", nil)
+	}
 
 	return scan, nil
 }
 
-// GetScan gets a scan by ID
-func (s *ScanService) GetScan(id string) (*Scan, error) {
+// getScan gets a copy of a scan by ID without permission checks
+func (s *ScanService) getScan(id string) (*Scan, error) {
 	// Check active scans first
-	s.mu.Lock()
-	if scan, ok := s.activeScans[id]; ok {
-		s.mu.Unlock()
+	if scan, ok := s.pool.active(id); ok {
 		return scan, nil
 	}
-	s.mu.Unlock()
 
 	// Check repository
 	scan, err := s.repository.GetScanByID(id)
@@ -114,54 +329,145 @@ func (s *ScanService) GetScan(id string) (*Scan, error) {
 	return scan, nil
 }
 
-// ListScans lists scans for a user
-func (s *ScanService) ListScans(userID string, limit, offset int) ([]*Scan, error) {
-	scans, err := s.repository.ListScans(userID, limit, offset)
+// ListScans lists one page of the scans matching the filter.
+// Only admins may list other users' scans or all scans (empty filter.UserID).
+func (s *ScanService) ListScans(ctx context.Context, filter ScanFilter, sort ScanSort, page PageRequest) (*ScanPage, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkScanFilter(principal, filter); err != nil {
+		return nil, err
+	}
+
+	if page.Limit < 1 {
+		return nil, errors.NewInvalidInput("limit must be at least 1", nil)
+	}
+	if page.Cursor != "" {
+		if _, err := DecodeScanCursor(page.Cursor, sort); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := s.repository.ListScans(filter, sort, page)
 	if err != nil {
 		return nil, errors.NewInternal("failed to list scans", err)
 	}
 
-	return scans, nil
+	return result, nil
+}
+
+// CountScans counts the scans matching the filter, with the permissions of ListScans
+func (s *ScanService) CountScans(ctx context.Context, filter ScanFilter) (int, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkScanFilter(principal, filter); err != nil {
+		return 0, err
+	}
+
+	count, err := s.repository.CountScans(filter)
+	if err != nil {
+		return 0, errors.NewInternal("failed to count scans", err)
+	}
+
+	return count, nil
+}
+
+// checkScanFilter checks that the caller may list the scans matching the filter and
+// that its created range is valid
+func checkScanFilter(principal *authdomain.Principal, filter ScanFilter) error {
+	if filter.UserID != principal.UserID && !principal.IsAdmin() {
+		return errors.NewForbidden("cannot list scans of other users", nil)
+	}
+	if !filter.CreatedAfter.IsZero() && !filter.CreatedBefore.IsZero() && !filter.CreatedAfter.Before(filter.CreatedBefore) {
+		return errors.NewInvalidInput("created_after must be before created_before", nil)
+	}
+	return nil
 }
 
-// CancelScan cancels a running scan
-func (s *ScanService) CancelScan(id string) error {
+// CancelScan cancels a running scan.
+// The caller must have the operator role and own the scan, or be an admin.
+func (s *ScanService) CancelScan(ctx context.Context, id string) error {
+	// Check permissions
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
 	// Get scan
-	scan, err := s.GetScan(id)
+	scan, err := s.getScan(id)
 	if err != nil {
 		return err
 	}
 
+	if !principal.CanAccess(scan.UserID) {
+		return errors.NewNotFound("scan not found", nil)
+	}
+
 	// Check if scan is running
 	if scan.Status != ScanStatusRunning && scan.Status != ScanStatusPending {
 		return errors.NewInvalidInput("scan is not running or pending", nil)
 	}
 
-	// Update scan status
+	return s.abortScan(scan, "")
+}
+
+// abortScan marks a scan as cancelled with an optional reason and stops its process.
+// An active scan is changed in place rather than the given copy.
+func (s *ScanService) abortScan(scan *Scan, reason string) error {
+	// Update scan status and stop the running process
+	s.mu.Lock()
+	if active, ok := s.pool.liveScan(scan.ID); ok {
+		scan = active
+	}
+	if scan.Status != ScanStatusRunning && scan.Status != ScanStatusPending {
+		s.mu.Unlock()
+		return nil
+	}
 	scan.Status = ScanStatusCancelled
+	scan.Error = reason
+	scan.EstimatedCompletion = nil
 	now := time.Now()
 	scan.CompletedAt = &now
+	if cancel, ok := s.cancelFuncs[scan.ID]; ok {
+		cancel()
+		delete(s.cancelFuncs, scan.ID)
+	}
+	aborted := *scan
+	s.mu.Unlock()
+
+	// A queued scan never runs, a running scan leaves the pool once its worker finished
+	s.pool.cancel(scan.ID)
 
 	// Update in repository
-	if err := s.repository.UpdateScan(scan); err != nil {
+	if err := s.repository.UpdateScan(&aborted); err != nil {
 		return errors.NewInternal("failed to update scan", err)
 	}
 
-	// Remove from active scans
-	s.mu.Lock()
-	delete(s.activeScans, id)
-	s.mu.Unlock()
-
 	return nil
 }
 
-// GetScanResult gets a scan result by ID
-func (s *ScanService) GetScanResult(id string) (*ScanResult, error) {
+// GetScanResult gets a scan result by ID.
+// Results owned by other users are reported as not found unless the caller is an admin.
+func (s *ScanService) GetScanResult(ctx context.Context, id string) (*ScanResult, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := s.repository.GetScanResultByID(id)
 	if err != nil {
 		return nil, errors.NewNotFound("scan result not found", err)
 	}
 
+	if !principal.CanAccess(result.UserID) {
+		return nil, errors.NewNotFound("scan result not found", nil)
+	}
+
 	return result, nil
 }
 
@@ -184,96 +490,318 @@ func (s *ScanService) GetNmapVersion() (string, error) {
 	return version, nil
 }
 
-// executeScan executes a scan
-func (s *ScanService) executeScan(ctx context.Context, scan *Scan) {
+// executeScan executes a scan, or resumes it from its saved progress. The fields of the
+// scan other than its ID, owner and options are changed under s.mu, as GetScan and the
+// other workers read them meanwhile.
+func (s *ScanService) executeScan(ctx context.Context, scan *Scan, resume bool) {
 	// Create a cancellable context
 	ctx, cancel := context.WithTimeout(ctx, scan.Options.Timeout)
 	defer cancel()
 
-	// Update scan status
+	log := s.logger.WithContext(ctx)
+
+	// Register the cancel function so the scan can be stopped by CancelScan, and update
+	// scan status
+	s.mu.Lock()
+	if scan.Status == ScanStatusCancelled {
+		s.mu.Unlock()
+		return
+	}
+	s.cancelFuncs[scan.ID] = cancel
 	now := time.Now()
 	scan.Status = ScanStatusRunning
 	scan.StartedAt = &now
 	scan.Progress = 0
+	scan.CurrentPhase = ""
+	scan.EstimatedCompletion = nil
+	scan.Failure = nil
+	running := *scan
+	s.mu.Unlock()
 
 	// Update in repository
-	if err := s.repository.UpdateScan(scan); err != nil {
-		s.logger.Error("Failed to update scan status",
+	if err := s.repository.UpdateScan(&running); err != nil {
+		log.Error("Failed to update scan status",
 			zap.String("scan_id", scan.ID),
 			zap.Error(err),
 		)
 	}
 
 	// Execute scan
-	s.logger.Info("Starting scan",
+	log.Info("Starting scan",
 		zap.String("scan_id", scan.ID),
 		zap.String("target", scan.Options.Target),
+		zap.Bool("resume", resume),
 	)
 
-	result, err := s.adapter.ExecuteScan(ctx, scan.Options)
+	// Capture the output of the scanner processes. A resumed scan appends to the log
+	// of the interrupted run.
+	scanLog, ok := s.scanLogs.get(scan.ID)
+	if !resume || !ok {
+		scanLog = s.scanLogs.create(scan.ID)
+	}
+	if scanLog != nil {
+		ctx = WithScanLog(ctx, scanLog)
+	}
+	ctx = WithProgressReporter(ctx, s.progressReporter(scan))
+
+	// Store the hosts of the scan as the scanner completes them
+	var partialHosts atomic.Bool
+	ctx = WithHostReporter(ctx, s.hostReporter(ctx, scan, &partialHosts))
+
+	options := scan.Options
+	var result *ScanResult
+	var err error
+	if resume {
+		// nmap repeats the scan recorded in the state file, including discovered targets
+		options = s.resumableOptions(scan, options)
+		result, err = s.adapter.(ScanResumer).ResumeScan(ctx, options)
+	} else {
+		// Expand a domain target into the discovered hosts
+		if len(options.Discovery) > 0 {
+			options, err = s.discoverTargets(ctx, scan)
+		}
+
+		// Split large networks into child scans
+		if err == nil {
+			if shards := shardTargets(options.Target, s.shardSize); shards != nil {
+				result, err = s.executeShards(ctx, scan, options, shards)
+			} else {
+				options = s.resumableOptions(scan, options)
+				result, err = s.adapter.ExecuteScan(ctx, options)
+			}
+		}
+	}
+	resumable := err != nil && s.canResume(scan, options)
 
-	// Update scan status and result
+	// A cancelled scan has already been finalized by CancelScan
+	s.mu.Lock()
+	delete(s.cancelFuncs, scan.ID)
+	cancelled := scan.Status == ScanStatusCancelled
+	scan.Resumable = resumable
+	if !cancelled {
+		scan.EstimatedCompletion = nil
+	}
+	s.mu.Unlock()
+	if cancelled {
+		log.Info("Scan cancelled", zap.String("scan_id", scan.ID), zap.Bool("resumable", resumable))
+		if result != nil {
+			s.storeResult(ctx, scan, result, partialHosts.Load())
+		}
+		if resumable || result != nil {
+			if err := s.repository.UpdateScan(s.snapshot(scan)); err != nil {
+				log.Error("Failed to update scan status",
+					zap.String("scan_id", scan.ID),
+					zap.Error(err),
+				)
+			}
+		}
+		return
+	}
+
+	// Store the result
 	if err != nil {
-		s.logger.Error("Scan failed",
+		log.Error("Scan failed",
 			zap.String("scan_id", scan.ID),
 			zap.Error(err),
 		)
 
-		scan.Status = ScanStatusFailed
-		scan.Error = err.Error()
+		// Keep the hosts a scan that timed out completed
+		if result != nil {
+			s.storeResult(ctx, scan, result, partialHosts.Load())
+		}
 	} else {
-		s.logger.Info("Scan completed",
+		log.Info("Scan completed",
 			zap.String("scan_id", scan.ID),
 			zap.Int("total_hosts", result.TotalHosts),
 			zap.Int("up_hosts", result.UpHosts),
 		)
 
-		scan.Status = ScanStatusCompleted
-		scan.Progress = 100
-		scan.ResultID = result.ID
-
 		// Set scan ID in result
 		result.ScanID = scan.ID
 		result.UserID = scan.UserID
 
-		// Save scan result
-		if err := s.repository.SaveScanResult(result); err != nil {
-			s.logger.Error("Failed to save scan result",
-				zap.String("scan_id", scan.ID),
-				zap.Error(err),
-			)
-		}
+		s.enrichResult(ctx, result)
+		s.storeResult(ctx, scan, result, partialHosts.Load())
+		s.checkBaselines(ctx, scan, result)
 	}
 
-	// Set completion time
+	// Update scan status and completion time
 	completedAt := time.Now()
+	s.mu.Lock()
+	if err != nil {
+		scan.Status = ScanStatusFailed
+		scan.Error = err.Error()
+		scan.Failure = failureFromError(err)
+	} else {
+		scan.Status = ScanStatusCompleted
+		scan.Progress = 100
+		scan.CurrentPhase = ""
+	}
 	scan.CompletedAt = &completedAt
+	finished := *scan
+	s.mu.Unlock()
 
 	// Update in repository
-	if err := s.repository.UpdateScan(scan); err != nil {
-		s.logger.Error("Failed to update scan status",
+	if err := s.repository.UpdateScan(&finished); err != nil {
+		log.Error("Failed to update scan status",
 			zap.String("scan_id", scan.ID),
 			zap.Error(err),
 		)
 	}
 
-	// Remove from active scans
+	if finished.Status == ScanStatusCompleted {
+		for _, listener := range s.listeners {
+			listener.ScanCompleted(ctx, &finished, result)
+		}
+	}
+}
+
+// snapshot returns a copy of a scan taken under s.mu, consistent while a worker changes
+// the scan
+func (s *ScanService) snapshot(scan *Scan) *Scan {
 	s.mu.Lock()
-	delete(s.activeScans, scan.ID)
+	defer s.mu.Unlock()
+	scanCopy := *scan
+	return &scanCopy
+}
+
+// storeResult saves the result of a scan. It replaces the partial hosts the scan stored
+// while running, if any, and the partial result of an interrupted run of a resumed scan.
+func (s *ScanService) storeResult(ctx context.Context, scan *Scan, result *ScanResult, partialHosts bool) {
+	log := s.logger.WithContext(ctx)
+
+	result.ScanID = scan.ID
+	result.UserID = scan.UserID
+	if err := s.repository.SaveScanResult(result); err != nil {
+		log.Error("Failed to save scan result",
+			zap.String("scan_id", scan.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.mu.Lock()
+	previous := scan.ResultID
+	scan.ResultID = result.ID
 	s.mu.Unlock()
+	if previous != "" && previous != result.ID {
+		if err := s.repository.DeleteScanResult(previous); err != nil {
+			log.Error("Failed to delete previous scan result",
+				zap.String("scan_id", scan.ID),
+				zap.String("result_id", previous),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if partialHosts {
+		if err := s.repository.DeletePartialHosts(scan.ID); err != nil {
+			log.Error("Failed to delete partial scan hosts",
+				zap.String("scan_id", scan.ID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// enrichResult runs the result enrichers, logging failures
+func (s *ScanService) enrichResult(ctx context.Context, result *ScanResult) {
+	for _, enricher := range s.enrichers {
+		if err := enricher.Enrich(ctx, result); err != nil {
+			s.logger.WithContext(ctx).Warn("Failed to enrich scan result",
+				zap.String("scan_id", result.ScanID),
+				zap.String("enricher", enricher.Name()),
+				zap.Error(err),
+			)
+		}
+	}
 }
 
 // validateScanOptions validates scan options
-func (s *ScanService) validateScanOptions(options ScanOptions) error {
+func (s *ScanService) validateScanOptions(ctx context.Context, options ScanOptions) error {
 	// Validate target
-	if options.Target == "" {
-		return errors.NewInvalidInput("target is required", nil)
+	if _, err := NormalizeTarget(options.Target); err != nil {
+		return errors.WithField(err, "target", "format")
+	}
+	if s.maxHosts > 0 {
+		if count := CountTargetAddresses(options.Target); count > s.maxHosts {
+			return errors.NewInvalidField("target", "max_hosts", fmt.Sprintf("target covers %d addresses, more than the maximum of %d", count, s.maxHosts))
+		}
+	}
+
+	// Reject targets in blocked networks
+	if s.targetBlocklist != nil {
+		if err := s.targetBlocklist.CheckTarget(ctx, options.Target); err != nil {
+			return errors.WithField(err, "target", "blocked")
+		}
+	}
+
+	// Validate tags
+	if err := validateTags(options.Tags); err != nil {
+		return err
+	}
+
+	// Validate discovery
+	if err := s.validateDiscovery(options); err != nil {
+		return errors.WithField(err, "discovery", "supported")
+	}
+
+	// Validate agent
+	if options.Agent != "" {
+		locator, ok := s.adapter.(AgentLocator)
+		if !ok {
+			return errors.NewInvalidField("agent", "enabled", "scanning agents are not enabled")
+		}
+		if err := locator.CheckAgent(options); err != nil {
+			return errors.WithField(err, "agent", "available")
+		}
+	}
+
+	// Validate mode
+	switch options.Mode {
+	case "":
+	case ScanModeFast:
+		if options.Engine != "" && options.Engine != ScanEngineRustscan {
+			return errors.NewInvalidField("mode", "engine", "fast mode cannot be combined with the "+string(options.Engine)+" engine")
+		}
+	default:
+		return errors.NewInvalidField("mode", "oneof", "invalid scan mode "+string(options.Mode))
+	}
+
+	// Validate engine, adapters without an engine registry only run nmap
+	if checker, ok := s.adapter.(EngineChecker); ok {
+		if err := checker.CheckEngine(options); err != nil {
+			return errors.WithField(err, "engine", "enabled")
+		}
+	} else if engine := options.ResolvedEngine(); engine != ScanEngineNmap {
+		return errors.NewInvalidField("engine", "enabled", "scan engine "+string(engine)+" is not enabled")
 	}
 
 	// Validate timeout
 	if options.Timeout == 0 {
 		options.Timeout = 5 * time.Minute // Default timeout
 	}
+	if s.maxTimeout > 0 && options.Timeout > s.maxTimeout {
+		return errors.NewInvalidField("timeout", "max", fmt.Sprintf("timeout %s exceeds the maximum of %s", options.Timeout, s.maxTimeout))
+	}
+
+	// Validate host timeout
+	if options.HostTimeout < 0 {
+		return errors.NewInvalidField("host_timeout", "min", "host timeout must not be negative")
+	}
+	if options.HostTimeout > options.Timeout {
+		return errors.NewInvalidField("host_timeout", "max", fmt.Sprintf("host timeout %s exceeds the scan timeout of %s", options.HostTimeout, options.Timeout))
+	}
+
+	// Validate packet rate and parallelism
+	if err := s.validateRateOptions(options); err != nil {
+		return err
+	}
+
+	// Validate evasion options
+	if err := s.validateEvasion(ctx, options); err != nil {
+		return err
+	}
 
 	// Validate ports
 	if options.Ports == "" {
@@ -291,13 +819,14 @@ func (s *ScanService) validateScanOptions(options ScanOptions) error {
 // CreateScanSummary creates a scan summary from a scan and its result
 func (s *ScanService) CreateScanSummary(scan *Scan, result *ScanResult) *ScanSummary {
 	summary := &ScanSummary{
-		ID:         scan.ID,
-		UserID:     scan.UserID,
-		Target:     scan.Options.Target,
-		Status:     scan.Status,
-		StartTime:  scan.StartedAt,
-		EndTime:    scan.CompletedAt,
-		HasResults: result != nil,
+		ID:                 scan.ID,
+		UserID:             scan.UserID,
+		Target:             scan.Options.Target,
+		Status:             scan.Status,
+		StartTime:          scan.StartedAt,
+		EndTime:            scan.CompletedAt,
+		HasResults:         result != nil,
+		BaselineViolations: scan.BaselineViolations,
 	}
 
 	if scan.StartedAt != nil && scan.CompletedAt != nil {
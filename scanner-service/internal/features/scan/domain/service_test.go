@@ -3,9 +3,11 @@ package domain_test
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
 	"github.com/stretchr/testify/assert"
@@ -56,15 +58,39 @@ func (m *MockScanRepository) GetScanByID(id string) (*domain.Scan, error) {
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	if get, ok := args.Get(0).(func(string) *domain.Scan); ok {
+		return get(id), args.Error(1)
+	}
 	return args.Get(0).(*domain.Scan), args.Error(1)
 }
 
-func (m *MockScanRepository) ListScans(userID string, limit, offset int) ([]*domain.Scan, error) {
-	args := m.Called(userID, limit, offset)
+func (m *MockScanRepository) ListScans(filter domain.ScanFilter, sort domain.ScanSort, page domain.PageRequest) (*domain.ScanPage, error) {
+	args := m.Called(filter, sort, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScanPage), args.Error(1)
+}
+
+func (m *MockScanRepository) CountScans(filter domain.ScanFilter) (int, error) {
+	args := m.Called(filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockScanRepository) SearchHosts(query domain.HostQuery) (*domain.HostSearchResult, error) {
+	args := m.Called(query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*domain.Scan), args.Error(1)
+	return args.Get(0).(*domain.HostSearchResult), args.Error(1)
+}
+
+func (m *MockScanRepository) AggregateSurface(userID string, limit int) (*domain.AttackSurface, error) {
+	args := m.Called(userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttackSurface), args.Error(1)
 }
 
 func (m *MockScanRepository) DeleteScan(id string) error {
@@ -90,6 +116,124 @@ func (m *MockScanRepository) DeleteScanResult(id string) error {
 	return args.Error(0)
 }
 
+func (m *MockScanRepository) PurgeScanResults(before time.Time) (int, error) {
+	args := m.Called(before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockScanRepository) ListExpiredScanResults(before time.Time) ([]*domain.ScanResult, error) {
+	args := m.Called(before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ScanResult), args.Error(1)
+}
+
+func (m *MockScanRepository) AppendPartialHosts(scanID string, hosts []domain.Host) error {
+	args := m.Called(scanID, hosts)
+	return args.Error(0)
+}
+
+func (m *MockScanRepository) GetPartialHosts(scanID string) ([]domain.Host, error) {
+	args := m.Called(scanID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Host), args.Error(1)
+}
+
+func (m *MockScanRepository) DeletePartialHosts(scanID string) error {
+	args := m.Called(scanID)
+	return args.Error(0)
+}
+
+func (m *MockScanRepository) SavePipeline(pipeline *domain.Pipeline) error {
+	args := m.Called(pipeline)
+	return args.Error(0)
+}
+
+func (m *MockScanRepository) UpdatePipeline(pipeline *domain.Pipeline) error {
+	args := m.Called(pipeline)
+	return args.Error(0)
+}
+
+func (m *MockScanRepository) GetPipelineByID(id string) (*domain.Pipeline, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pipeline), args.Error(1)
+}
+
+func (m *MockScanRepository) ListPipelines(userID string) ([]*domain.Pipeline, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Pipeline), args.Error(1)
+}
+
+func (m *MockScanRepository) SaveBaseline(baseline *domain.Baseline) error {
+	args := m.Called(baseline)
+	return args.Error(0)
+}
+
+func (m *MockScanRepository) GetBaselineByID(id string) (*domain.Baseline, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Baseline), args.Error(1)
+}
+
+func (m *MockScanRepository) ListBaselines(userID string) ([]*domain.Baseline, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Baseline), args.Error(1)
+}
+
+func (m *MockScanRepository) DeleteBaseline(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// scanStore keeps the last version of the scans updated through a mock repository, so
+// that tests can read scans that are no longer active
+type scanStore struct {
+	mu    sync.Mutex
+	scans map[string]*domain.Scan
+}
+
+func newScanStore() *scanStore {
+	return &scanStore{scans: make(map[string]*domain.Scan)}
+}
+
+// update records the scan passed to UpdateScan
+func (s *scanStore) update(args mock.Arguments) {
+	scan := *args.Get(0).(*domain.Scan)
+	s.mu.Lock()
+	s.scans[scan.ID] = &scan
+	s.mu.Unlock()
+}
+
+// get returns a copy of the last version of a scan, as GetScanByID does
+func (s *scanStore) get(id string) *domain.Scan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scan := *s.scans[id]
+	return &scan
+}
+
+// principalContext returns a context authenticated as userID with the given role
+func principalContext(userID string, role authdomain.Role) context.Context {
+	return authdomain.WithPrincipal(context.Background(), &authdomain.Principal{
+		UserID: userID,
+		Roles:  []authdomain.Role{role},
+	})
+}
+
 func TestStartScan(t *testing.T) {
 	// Create mocks
 	mockAdapter := new(MockScanAdapter)
@@ -112,9 +256,11 @@ func TestStartScan(t *testing.T) {
 
 	// Set up expectations
 	mockRepository.On("SaveScan", mock.AnythingOfType("*domain.Scan")).Return(nil)
+	mockRepository.On("UpdateScan", mock.AnythingOfType("*domain.Scan")).Return(nil).Maybe()
+	mockAdapter.On("ExecuteScan", mock.Anything, mock.Anything).Return(nil, errors.New("not executed")).Maybe()
 
 	// Execute test
-	scan, err := service.StartScan(context.Background(), userID, options)
+	scan, err := service.StartScan(principalContext(userID, authdomain.RoleOperator), userID, options)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -151,7 +297,7 @@ func TestGetScan(t *testing.T) {
 	mockRepository.On("GetScanByID", scanID).Return(expectedScan, nil)
 
 	// Execute test
-	scan, err := service.GetScan(scanID)
+	scan, err := service.GetScan(principalContext("test-user", authdomain.RoleViewer), scanID)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -182,7 +328,7 @@ func TestGetScanNotFound(t *testing.T) {
 	mockRepository.On("GetScanByID", scanID).Return(nil, errors.New("scan not found"))
 
 	// Execute test
-	scan, err := service.GetScan(scanID)
+	scan, err := service.GetScan(principalContext("test-user", authdomain.RoleViewer), scanID)
 
 	// Assertions
 	assert.Error(t, err)
@@ -217,7 +363,7 @@ func TestCancelScan(t *testing.T) {
 	mockRepository.On("UpdateScan", mock.AnythingOfType("*domain.Scan")).Return(nil)
 
 	// Execute test
-	err := service.CancelScan(scanID)
+	err := service.CancelScan(principalContext("test-user", authdomain.RoleOperator), scanID)
 
 	// Assertions
 	assert.NoError(t, err)
@@ -229,6 +375,129 @@ func TestCancelScan(t *testing.T) {
 	assert.Equal(t, domain.ScanStatusCancelled, scan.Status)
 }
 
+func TestStartScanRequiresOperator(t *testing.T) {
+	// Create mocks
+	mockAdapter := new(MockScanAdapter)
+	mockRepository := new(MockScanRepository)
+
+	// Create logger
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	// Create service
+	service := domain.NewScanService(mockAdapter, mockRepository, log, 10)
+
+	options := domain.ScanOptions{Target: "192.168.1.1"}
+
+	// Viewers cannot start scans
+	scan, err := service.StartScan(principalContext("viewer", authdomain.RoleViewer), "viewer", options)
+	assert.Error(t, err)
+	assert.Nil(t, scan)
+
+	// Unauthenticated callers cannot start scans
+	scan, err = service.StartScan(context.Background(), "anonymous", options)
+	assert.Error(t, err)
+	assert.Nil(t, scan)
+
+	// No scan must have been saved
+	mockRepository.AssertNotCalled(t, "SaveScan", mock.Anything)
+}
+
+func TestScanOwnership(t *testing.T) {
+	// Create mocks
+	mockAdapter := new(MockScanAdapter)
+	mockRepository := new(MockScanRepository)
+
+	// Create logger
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	// Create service
+	service := domain.NewScanService(mockAdapter, mockRepository, log, 10)
+
+	// Test data
+	scan := &domain.Scan{
+		ID:       "owned-scan-id",
+		UserID:   "owner",
+		Status:   domain.ScanStatusCompleted,
+		ResultID: "owned-result-id",
+	}
+	result := &domain.ScanResult{
+		ID:     "owned-result-id",
+		ScanID: "owned-scan-id",
+		UserID: "owner",
+	}
+
+	// Set up expectations
+	mockRepository.On("GetScanByID", scan.ID).Return(scan, nil)
+	mockRepository.On("GetScanResultByID", result.ID).Return(result, nil)
+
+	// Other users cannot see the scan or its result
+	_, err := service.GetScan(principalContext("intruder", authdomain.RoleOperator), scan.ID)
+	assert.Error(t, err)
+	_, err = service.GetScanResult(principalContext("intruder", authdomain.RoleOperator), result.ID)
+	assert.Error(t, err)
+	_, err = service.ListScans(principalContext("intruder", authdomain.RoleOperator), domain.ScanFilter{UserID: "owner"}, domain.ScanSort{}, domain.PageRequest{Limit: 10})
+	assert.Error(t, err)
+
+	// The owner and admins can
+	_, err = service.GetScan(principalContext("owner", authdomain.RoleViewer), scan.ID)
+	assert.NoError(t, err)
+	_, err = service.GetScanResult(principalContext("admin", authdomain.RoleAdmin), result.ID)
+	assert.NoError(t, err)
+}
+
+func TestAdminOperations(t *testing.T) {
+	// Create mocks
+	mockAdapter := new(MockScanAdapter)
+	mockRepository := new(MockScanRepository)
+
+	// Create logger
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+
+	// Create service
+	service := domain.NewScanService(mockAdapter, mockRepository, log, 10)
+
+	adminCtx := principalContext("admin", authdomain.RoleAdmin)
+	operatorCtx := principalContext("operator", authdomain.RoleOperator)
+
+	// Only admins may change limits
+	assert.Error(t, service.SetMaxConcurrentScans(operatorCtx, 1))
+	assert.Error(t, service.SetMaxConcurrentScans(adminCtx, 0))
+	assert.NoError(t, service.SetMaxConcurrentScans(adminCtx, 2))
+
+	stats, err := service.GetScanStats(adminCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.MaxConcurrentScans)
+	assert.Equal(t, 0, stats.ActiveScans)
+
+	_, err = service.GetScanStats(operatorCtx)
+	assert.Error(t, err)
+
+	// Only admins may purge results
+	mockRepository.On("PurgeScanResults", mock.AnythingOfType("time.Time")).Return(3, nil).Once()
+	purged, err := service.PurgeScanResults(adminCtx, time.Hour, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, purged)
+
+	_, err = service.PurgeScanResults(operatorCtx, time.Hour, false)
+	assert.Error(t, err)
+
+	// Purging every result must be asked for explicitly
+	_, err = service.PurgeScanResults(adminCtx, 0, false)
+	assert.Error(t, err)
+	_, err = service.PurgeScanResults(adminCtx, time.Hour, true)
+	assert.Error(t, err)
+
+	mockRepository.On("PurgeScanResults", mock.AnythingOfType("time.Time")).Return(5, nil).Once()
+	purged, err = service.PurgeScanResults(adminCtx, 0, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, purged)
+
+	mockRepository.AssertExpectations(t)
+}
+
 func TestValidateNmap(t *testing.T) {
 	// Create mocks
 	mockAdapter := new(MockScanAdapter)
@@ -283,3 +552,101 @@ func TestGetNmapVersion(t *testing.T) {
 	// Verify expectations
 	mockAdapter.AssertExpectations(t)
 }
+
+// engineScanAdapter is a MockScanAdapter supporting the nmap, masscan and rustscan engines
+type engineScanAdapter struct {
+	MockScanAdapter
+}
+
+func (a *engineScanAdapter) CheckEngine(options domain.ScanOptions) error {
+	switch engine := options.ResolvedEngine(); engine {
+	case domain.ScanEngineNmap, domain.ScanEngineMasscan, domain.ScanEngineRustscan:
+		return nil
+	default:
+		return errors.New("scan engine " + string(engine) + " is not enabled")
+	}
+}
+
+func TestCheckScanEngine(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	ctx := principalContext("test-user", authdomain.RoleOperator)
+
+	// Adapters without engines only run nmap
+	service := domain.NewScanService(new(MockScanAdapter), new(MockScanRepository), log, 10)
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Engine: domain.ScanEngineNmap}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Engine: domain.ScanEngineMasscan}))
+
+	service = domain.NewScanService(new(engineScanAdapter), new(MockScanRepository), log, 10)
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Engine: domain.ScanEngineMasscan}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Engine: domain.ScanEngineZmap}))
+}
+
+func TestCheckScanMode(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	ctx := principalContext("test-user", authdomain.RoleOperator)
+
+	// Fast mode needs the rustscan engine
+	service := domain.NewScanService(new(MockScanAdapter), new(MockScanRepository), log, 10)
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Mode: domain.ScanModeFast}))
+
+	service = domain.NewScanService(new(engineScanAdapter), new(MockScanRepository), log, 10)
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Mode: domain.ScanModeFast}))
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Mode: domain.ScanModeFast, Engine: domain.ScanEngineRustscan}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Mode: domain.ScanModeFast, Engine: domain.ScanEngineMasscan}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Mode: "slow"}))
+}
+
+func TestCheckScanLimits(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	ctx := principalContext("test-user", authdomain.RoleOperator)
+
+	service := domain.NewScanService(new(MockScanAdapter), new(MockScanRepository), log, 10)
+	service.SetScanLimits(time.Hour, 256)
+
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Timeout: time.Hour}))
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "scanme.nmap.org 10.0.1.1-255"}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Timeout: 2 * time.Hour}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/23"}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24 10.0.1.1"}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "2001:db8::/32"}))
+}
+
+func TestCheckScanHostTimeout(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	ctx := principalContext("test-user", authdomain.RoleOperator)
+
+	service := domain.NewScanService(new(MockScanAdapter), new(MockScanRepository), log, 10)
+
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Timeout: time.Hour, HostTimeout: time.Minute}))
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", HostTimeout: time.Minute}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", Timeout: time.Minute, HostTimeout: time.Hour}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.0/24", HostTimeout: -time.Second}))
+}
+
+func TestCheckScanRateLimits(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	log := &logger.Logger{Logger: zapLogger}
+	ctx := principalContext("test-user", authdomain.RoleOperator)
+
+	service := domain.NewScanService(new(MockScanAdapter), new(MockScanRepository), log, 10)
+	service.SetRateLimits(1000, 64)
+
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", MinRate: 100, MaxRate: 1000, MaxParallelism: 64}))
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", ExtraOptions: []string{"--max-rate", "500"}}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", MinRate: 500, MaxRate: 100}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", MaxRate: 5000}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", MaxParallelism: 128}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", MinRate: -1}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", ExtraOptions: []string{"--min-rate=50000"}}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", ExtraOptions: []string{"--min-parallelism", "512"}}))
+
+	retries := func(n int) *int { return &n }
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", MaxRetries: retries(0)}))
+	assert.NoError(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", MaxRetries: retries(10)}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", MaxRetries: retries(-1)}))
+	assert.Error(t, service.CheckScan(ctx, domain.ScanOptions{Target: "10.0.0.1", MaxRetries: retries(100)}))
+}
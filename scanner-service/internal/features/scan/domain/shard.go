@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// maxShardsPerNetwork limits the child scans of a single network.
+// Larger networks are split into larger chunks.
+const maxShardsPerNetwork = 256
+
+// SetSharding splits scans of networks with more than shardSize addresses into child
+// scans of at most shardSize addresses, running up to concurrency of them in parallel.
+// A shardSize of 0 disables sharding.
+func (s *ScanService) SetSharding(shardSize, concurrency int) {
+	s.shardSize = shardSize
+	s.shardConcurrency = max(concurrency, 1)
+}
+
+// shardTargets splits the networks of a target larger than the shard size into chunks.
+// Targets not split are kept together in one extra shard. It returns nil if nothing is split.
+func shardTargets(target string, shardSize int) []string {
+	if shardSize <= 0 {
+		return nil
+	}
+
+	var shards, rest []string
+	for _, item := range utils.SplitTargets(target) {
+		chunks := splitNetwork(item, shardSize)
+		if chunks == nil {
+			rest = append(rest, item)
+			continue
+		}
+		shards = append(shards, chunks...)
+	}
+
+	if len(shards) == 0 {
+		return nil
+	}
+	if len(rest) > 0 {
+		shards = append(shards, strings.Join(rest, " "))
+	}
+	return shards
+}
+
+// splitNetwork splits a CIDR with more than shardSize addresses into sub-networks
+// of at most shardSize addresses. It returns nil for other targets.
+func splitNetwork(target string, shardSize int) []string {
+	_, network, err := net.ParseCIDR(target)
+	if err != nil {
+		return nil
+	}
+
+	ones, size := network.Mask.Size()
+	hostBits := size - ones
+	shardBits := bits.Len(uint(shardSize)) - 1 // Largest power of two not above the shard size
+	if hostBits <= shardBits {
+		return nil
+	}
+
+	// Keep the number of chunks bounded
+	splitBits := min(hostBits-shardBits, bits.Len(maxShardsPerNetwork)-1)
+	prefix := ones + splitBits
+
+	chunks := make([]string, 0, 1<<splitBits)
+	ip := network.IP
+	for i := 0; i < 1<<splitBits; i++ {
+		chunk := &net.IPNet{IP: ip, Mask: net.CIDRMask(prefix, size)}
+		chunks = append(chunks, chunk.String())
+		ip = nextNetwork(ip, size-prefix)
+	}
+	return chunks
+}
+
+// nextNetwork returns the first address after the network starting at ip with the given host bits
+func nextNetwork(ip net.IP, hostBits int) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+
+	// Add 1 << hostBits to the address
+	carry := 1 << (hostBits % 8)
+	for i := len(next) - 1 - hostBits/8; i >= 0 && carry > 0; i-- {
+		sum := int(next[i]) + carry
+		next[i] = byte(sum)
+		carry = sum >> 8
+	}
+	return next
+}
+
+// executeShards runs the shards of a scan as parallel child scans and merges their results.
+// The first failing shard cancels the others and fails the scan.
+func (s *ScanService) executeShards(ctx context.Context, scan *Scan, options ScanOptions, shards []string) (*ScanResult, error) {
+	log := s.logger.WithContext(ctx)
+	log.Info("Splitting scan into shards",
+		zap.String("scan_id", scan.ID),
+		zap.Int("shards", len(shards)),
+	)
+
+	s.mu.Lock()
+	scan.ShardCount = len(shards)
+	s.mu.Unlock()
+
+	// Create the child scans up front so they are visible while waiting
+	children := make([]*Scan, len(shards))
+	for i, target := range shards {
+		childOptions := options
+		childOptions.Target = target
+		childOptions.Discovery = nil
+
+		children[i] = &Scan{
+			ID:        uuid.New().String(),
+			UserID:    scan.UserID,
+			TenantID:  scan.TenantID,
+			Options:   childOptions,
+			Status:    ScanStatusPending,
+			CreatedAt: time.Now(),
+			RequestID: scan.RequestID,
+			ParentID:  scan.ID,
+		}
+		if err := s.repository.SaveScan(children[i]); err != nil {
+			return nil, errors.NewInternal("failed to save shard scan", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*ScanResult, len(shards))
+	var (
+		wg       sync.WaitGroup
+		errMu    sync.Mutex
+		firstErr error
+		finished int
+	)
+	slots := make(chan struct{}, s.shardConcurrency)
+
+	for i, child := range children {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				s.finishShard(ctx, child, ctx.Err())
+				return
+			}
+
+			result, err := s.executeShard(ctx, child)
+			results[i] = result
+
+			errMu.Lock()
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("shard %s failed: %w", child.Options.Target, err)
+				cancel()
+			}
+			errMu.Unlock()
+
+			// Progress follows the finished shards
+			s.mu.Lock()
+			finished++
+			scan.Progress = float64(finished) * 100 / float64(len(children))
+			s.mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if ctx.Err() != nil {
+		// The scan was cancelled while shards were waiting
+		return nil, ctx.Err()
+	}
+	return mergeResults(results), nil
+}
+
+// executeShard runs a child scan and records its outcome
+func (s *ScanService) executeShard(ctx context.Context, child *Scan) (*ScanResult, error) {
+	if ctx.Err() != nil {
+		s.finishShard(ctx, child, ctx.Err())
+		return nil, ctx.Err()
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	child.Status = ScanStatusRunning
+	child.StartedAt = &now
+	running := *child
+	s.mu.Unlock()
+	if err := s.repository.UpdateScan(&running); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update shard scan status",
+			zap.String("scan_id", child.ID),
+			zap.Error(err),
+		)
+	}
+
+	// Shards report their own progress rather than the progress of the parent scan
+	ctx = WithProgressReporter(ctx, s.progressReporter(child))
+	result, err := s.adapter.ExecuteScan(ctx, child.Options)
+	s.finishShard(ctx, child, err)
+	return result, err
+}
+
+// finishShard records the outcome of a child scan.
+// Shards stopped because the scan or another shard ended are recorded as cancelled.
+func (s *ScanService) finishShard(ctx context.Context, child *Scan, err error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	switch {
+	case err == nil:
+		child.Status = ScanStatusCompleted
+		child.Progress = 100
+		child.CurrentPhase = ""
+	case ctx.Err() != nil:
+		child.Status = ScanStatusCancelled
+	default:
+		child.Status = ScanStatusFailed
+		child.Error = err.Error()
+		child.Failure = failureFromError(err)
+	}
+	child.EstimatedCompletion = nil
+	child.CompletedAt = &now
+	finished := *child
+	s.mu.Unlock()
+
+	if err := s.repository.UpdateScan(&finished); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update shard scan status",
+			zap.String("scan_id", child.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// mergeResults merges the results of the shards of a scan into one result
+func mergeResults(results []*ScanResult) *ScanResult {
+	merged := &ScanResult{
+		ID:    uuid.New().String(),
+		Hosts: make([]Host, 0),
+	}
+
+	commands := make([]string, 0, len(results))
+	for _, result := range results {
+		if merged.StartTime.IsZero() || result.StartTime.Before(merged.StartTime) {
+			merged.StartTime = result.StartTime
+		}
+		if result.EndTime.After(merged.EndTime) {
+			merged.EndTime = result.EndTime
+		}
+		merged.TotalHosts += result.TotalHosts
+		merged.UpHosts += result.UpHosts
+		merged.Hosts = append(merged.Hosts, result.Hosts...)
+		commands = append(commands, result.Command)
+	}
+
+	merged.Duration = merged.EndTime.Sub(merged.StartTime).Seconds()
+	merged.Command = strings.Join(commands, "\n")
+	merged.Summary = fmt.Sprintf("%d shards: %d IP addresses (%d hosts up) scanned in %.2f seconds",
+		len(results), merged.TotalHosts, merged.UpHosts, merged.Duration)
+
+	return merged
+}
@@ -0,0 +1,119 @@
+package domain_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newShardingService creates a scan service splitting networks into shards of 64 addresses
+func newShardingService(mockAdapter *MockScanAdapter, mockRepository *MockScanRepository) *domain.ScanService {
+	zapLogger, _ := zap.NewDevelopment()
+	service := domain.NewScanService(mockAdapter, mockRepository, &logger.Logger{Logger: zapLogger}, 10)
+	service.SetSharding(64, 2)
+	return service
+}
+
+func TestRunScan_ShardsLargeNetworks(t *testing.T) {
+	mockAdapter := new(MockScanAdapter)
+	mockRepository := new(MockScanRepository)
+	service := newShardingService(mockAdapter, mockRepository)
+
+	var mu sync.Mutex
+	var children []*domain.Scan
+	mockRepository.On("SaveScan", mock.Anything).Run(func(args mock.Arguments) {
+		scan := args.Get(0).(*domain.Scan)
+		if scan.ParentID != "" {
+			mu.Lock()
+			children = append(children, scan)
+			mu.Unlock()
+		}
+	}).Return(nil)
+	mockRepository.On("UpdateScan", mock.Anything).Return(nil)
+
+	mockRepository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	mockRepository.On("SaveScanResult", mock.Anything).Run(func(args mock.Arguments) {
+		saved := args.Get(0).(*domain.ScanResult)
+		mockRepository.On("GetScanResultByID", saved.ID).Return(saved, nil)
+	}).Return(nil)
+
+	start := time.Now()
+	results := map[string]*domain.ScanResult{
+		"10.0.0.0/26":   {StartTime: start, EndTime: start.Add(time.Second), TotalHosts: 64, UpHosts: 1, Hosts: []domain.Host{upHost("10.0.0.1")}},
+		"10.0.0.64/26":  {StartTime: start, EndTime: start.Add(3 * time.Second), TotalHosts: 64},
+		"10.0.0.128/26": {StartTime: start, EndTime: start.Add(2 * time.Second), TotalHosts: 64, UpHosts: 1, Hosts: []domain.Host{upHost("10.0.0.130")}},
+		"10.0.0.192/26": {StartTime: start, EndTime: start.Add(time.Second), TotalHosts: 64},
+		"192.168.1.1":   {StartTime: start, EndTime: start.Add(time.Second), TotalHosts: 1, UpHosts: 1, Hosts: []domain.Host{upHost("192.168.1.1")}},
+	}
+	for target, result := range results {
+		mockAdapter.On("ExecuteScan", mock.Anything, mock.MatchedBy(func(options domain.ScanOptions) bool {
+			return options.Target == target
+		})).Return(result, nil)
+	}
+
+	scan := &domain.Scan{UserID: "alice", Options: domain.ScanOptions{Target: "10.0.0.0/24 192.168.1.1", Timeout: time.Minute}}
+	result, err := service.RunScan(principalContext("alice", authdomain.RoleOperator), scan)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, scan.ShardCount)
+	assert.Equal(t, float64(100), scan.Progress)
+	assert.Equal(t, 257, result.TotalHosts)
+	assert.Equal(t, 3, result.UpHosts)
+	assert.Len(t, result.Hosts, 3)
+	assert.Equal(t, float64(3), result.Duration)
+	assert.Equal(t, scan.ID, result.ScanID)
+
+	require.Len(t, children, 5)
+	for _, child := range children {
+		assert.Equal(t, scan.ID, child.ParentID)
+		assert.Equal(t, domain.ScanStatusCompleted, child.Status)
+	}
+}
+
+func TestRunScan_FailedShardFailsScan(t *testing.T) {
+	mockAdapter := new(MockScanAdapter)
+	mockRepository := new(MockScanRepository)
+	service := newShardingService(mockAdapter, mockRepository)
+
+	mockRepository.On("SaveScan", mock.Anything).Return(nil)
+	mockRepository.On("UpdateScan", mock.Anything).Return(nil)
+	mockAdapter.On("ExecuteScan", mock.Anything, mock.MatchedBy(func(options domain.ScanOptions) bool {
+		return options.Target == "10.0.0.64/26"
+	})).Return(nil, errors.New("nmap crashed"))
+	mockAdapter.On("ExecuteScan", mock.Anything, mock.Anything).Return(&domain.ScanResult{}, nil)
+
+	scan := &domain.Scan{UserID: "alice", Options: domain.ScanOptions{Target: "10.0.0.0/25", Timeout: time.Minute}}
+	_, err := service.RunScan(principalContext("alice", authdomain.RoleOperator), scan)
+	require.Error(t, err)
+	assert.Contains(t, scan.Error, "shard 10.0.0.64/26 failed")
+	assert.Equal(t, domain.ScanStatusFailed, scan.Status)
+}
+
+func TestRunScan_SmallNetworksAreNotSharded(t *testing.T) {
+	mockAdapter := new(MockScanAdapter)
+	mockRepository := new(MockScanRepository)
+	service := newShardingService(mockAdapter, mockRepository)
+
+	result := &domain.ScanResult{ID: "r1"}
+	mockRepository.On("SaveScan", mock.Anything).Return(nil)
+	mockRepository.On("UpdateScan", mock.Anything).Return(nil)
+	mockRepository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	mockRepository.On("SaveScanResult", result).Return(nil)
+	mockRepository.On("GetScanResultByID", "r1").Return(result, nil)
+	mockAdapter.On("ExecuteScan", mock.Anything, mock.Anything).Return(result, nil)
+
+	scan := &domain.Scan{UserID: "alice", Options: domain.ScanOptions{Target: "10.0.0.0/26", Timeout: time.Minute}}
+	_, err := service.RunScan(principalContext("alice", authdomain.RoleOperator), scan)
+	require.NoError(t, err)
+	assert.Zero(t, scan.ShardCount)
+	mockRepository.AssertNumberOfCalls(t, "SaveScan", 1)
+}
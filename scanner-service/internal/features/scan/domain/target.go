@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"bytes"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/utils"
+)
+
+// MaxTargetItems is the maximum number of addresses, networks and host names of a target
+const MaxTargetItems = 1024
+
+// maxTargetAddresses caps the address count of a target, e.g. for IPv6 networks
+const maxTargetAddresses = 1 << 40
+
+// NormalizeTarget validates a target list separated by commas or whitespace and returns
+// it in canonical form: addresses and networks in standard notation (10.0.0.5/24 becomes
+// 10.0.0.0/24), host names in lower case, duplicates removed, items separated by spaces.
+// Items that are not an IP address, network, address range or host name are rejected.
+func NormalizeTarget(target string) (string, error) {
+	items := utils.SplitTargets(target)
+	if len(items) == 0 {
+		return "", errors.NewInvalidInput("target is required", nil)
+	}
+	if len(items) > MaxTargetItems {
+		return "", errors.NewInvalidInput("a target may list at most "+strconv.Itoa(MaxTargetItems)+" items", nil)
+	}
+
+	normalized := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		canonical, ok := normalizeTargetItem(item)
+		if !ok {
+			return "", errors.NewInvalidInput("invalid target "+strconv.Quote(item)+": expected an IP address, network, address range or host name", nil)
+		}
+		if !seen[canonical] {
+			seen[canonical] = true
+			normalized = append(normalized, canonical)
+		}
+	}
+
+	return strings.Join(normalized, " "), nil
+}
+
+// normalizeTargetItem returns the canonical form of a single target item
+func normalizeTargetItem(item string) (string, bool) {
+	if ip := net.ParseIP(item); ip != nil {
+		return ip.String(), true
+	}
+
+	if _, network, err := net.ParseCIDR(item); err == nil {
+		return network.String(), true
+	}
+
+	if dash := strings.Index(item, "-"); dash > 0 {
+		return normalizeAddressRange(item[:dash], item[dash+1:])
+	}
+
+	if utils.ValidateHostname(item) {
+		return strings.ToLower(strings.TrimSuffix(item, ".")), true
+	}
+
+	return "", false
+}
+
+// normalizeAddressRange returns the canonical form of an address range, either between
+// two addresses ("10.0.0.1-10.0.0.50") or up to a last octet ("10.0.0.1-50")
+func normalizeAddressRange(first, last string) (string, bool) {
+	start := net.ParseIP(first)
+	if start == nil {
+		return "", false
+	}
+
+	if end := net.ParseIP(last); end != nil {
+		if (start.To4() == nil) != (end.To4() == nil) || bytes.Compare(start.To16(), end.To16()) > 0 {
+			return "", false
+		}
+		return start.String() + "-" + end.String(), true
+	}
+
+	start4 := start.To4()
+	lastOctet, err := strconv.Atoi(last)
+	if start4 == nil || err != nil || lastOctet < int(start4[3]) || lastOctet > 255 {
+		return "", false
+	}
+	return start4.String() + "-" + strconv.Itoa(lastOctet), true
+}
+
+// CountTargetAddresses returns the number of addresses covered by a target.
+// Host names count as one address.
+func CountTargetAddresses(target string) int {
+	total := 0
+	for _, item := range utils.SplitTargets(target) {
+		total = min(total+countTargetItem(item), maxTargetAddresses)
+	}
+	return total
+}
+
+// countTargetItem returns the number of addresses of a single target item
+func countTargetItem(item string) int {
+	if _, network, err := net.ParseCIDR(item); err == nil {
+		ones, size := network.Mask.Size()
+		if size-ones >= 40 {
+			return maxTargetAddresses
+		}
+		return 1 << (size - ones)
+	}
+
+	dash := strings.Index(item, "-")
+	if dash <= 0 {
+		return 1 // Address or host name
+	}
+	start := net.ParseIP(item[:dash])
+	if start == nil {
+		return 1
+	}
+	end := net.ParseIP(item[dash+1:])
+	if end == nil {
+		// Range up to a last octet
+		lastOctet, err := strconv.Atoi(item[dash+1:])
+		if start.To4() == nil || err != nil || lastOctet < int(start.To4()[3]) {
+			return 1
+		}
+		return lastOctet - int(start.To4()[3]) + 1
+	}
+
+	count := new(big.Int).Sub(new(big.Int).SetBytes(end.To16()), new(big.Int).SetBytes(start.To16()))
+	if count.Sign() < 0 {
+		return 1
+	}
+	count.Add(count, big.NewInt(1))
+	if !count.IsInt64() || count.Int64() > maxTargetAddresses {
+		return maxTargetAddresses
+	}
+	return int(count.Int64())
+}
+
+// ParseTargetRange parses an IP address, network or last-octet range ("10.0.0.1-50") into
+// its first and last address. Targets that look numeric but cannot be parsed, such as
+// "10.0.*.1", are reported as addresses without a range, so that callers never match
+// them as host names.
+func ParseTargetRange(target string) (first, last net.IP, isAddress bool) {
+	if ip := net.ParseIP(target); ip != nil {
+		return ip, ip, true
+	}
+
+	if _, network, err := net.ParseCIDR(target); err == nil {
+		last := make(net.IP, len(network.IP))
+		for i := range network.IP {
+			last[i] = network.IP[i] | ^network.Mask[i]
+		}
+		return network.IP, last, true
+	}
+
+	if dash := strings.LastIndex(target, "-"); dash > 0 {
+		start := net.ParseIP(target[:dash]).To4()
+		lastOctet, err := strconv.Atoi(target[dash+1:])
+		if start != nil && err == nil && lastOctet >= int(start[3]) && lastOctet <= 255 {
+			end := make(net.IP, len(start))
+			copy(end, start)
+			end[3] = byte(lastOctet)
+			return start, end, true
+		}
+	}
+
+	if strings.Trim(target, "0123456789.-*/") == "" || strings.Contains(target, ":") {
+		return nil, nil, true
+	}
+
+	return nil, nil, false
+}
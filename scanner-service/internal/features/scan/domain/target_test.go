@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTarget(t *testing.T) {
+	tests := []struct {
+		target   string
+		expected string
+	}{
+		{"10.0.0.1", "10.0.0.1"},
+		{"10.0.0.5/24", "10.0.0.0/24"},
+		{"10.0.0.1, 10.0.0.2,10.0.0.1", "10.0.0.1 10.0.0.2"},
+		{"Scanme.Nmap.org.", "scanme.nmap.org"},
+		{"2001:DB8::1 2001:db8::/32", "2001:db8::1 2001:db8::/32"},
+		{"10.0.0.1-50", "10.0.0.1-50"},
+		{"10.0.0.1-10.0.1.20", "10.0.0.1-10.0.1.20"},
+		{"localhost", "localhost"},
+	}
+	for _, tt := range tests {
+		normalized, err := domain.NormalizeTarget(tt.target)
+		require.NoError(t, err, tt.target)
+		assert.Equal(t, tt.expected, normalized, tt.target)
+	}
+
+	invalid := []string{
+		"",
+		" , ",
+		"10.0.0.1;id",
+		"$(reboot)",
+		"-oN/tmp/x",
+		"10.0.0.0/33",
+		"10.0.1",
+		"10.0.0.50-10",
+		"10.0.0.9-10.0.0.1",
+		"host_name`x`",
+	}
+	for _, target := range invalid {
+		_, err := domain.NormalizeTarget(target)
+		var scanErr *errors.Error
+		require.ErrorAs(t, err, &scanErr, target)
+		assert.Equal(t, errors.ErrInvalidInput, scanErr.Type, target)
+	}
+}
+
+func TestCountTargetAddresses(t *testing.T) {
+	assert.Equal(t, 1, domain.CountTargetAddresses("10.0.0.1"))
+	assert.Equal(t, 256, domain.CountTargetAddresses("10.0.0.0/24"))
+	assert.Equal(t, 50, domain.CountTargetAddresses("10.0.0.1-50"))
+	assert.Equal(t, 276, domain.CountTargetAddresses("10.0.0.1-10.0.1.20"))
+	assert.Equal(t, 258, domain.CountTargetAddresses("scanme.nmap.org, 10.0.0.0/24 10.0.1.1"))
+	assert.Equal(t, 1<<40, domain.CountTargetAddresses("2001:db8::/32"))
+}
+
+func TestParseTargetRange(t *testing.T) {
+	tests := []struct {
+		target    string
+		first     string
+		last      string
+		isAddress bool
+	}{
+		{"10.0.0.1", "10.0.0.1", "10.0.0.1", true},
+		{"10.0.0.0/30", "10.0.0.0", "10.0.0.3", true},
+		{"10.0.0.1-50", "10.0.0.1", "10.0.0.50", true},
+		{"2001:db8::/127", "2001:db8::", "2001:db8::1", true},
+		{"10.0.*.1", "", "", true},
+		{"10.0.0.50-10", "", "", true},
+		{"10.0.0.1-300", "", "", true},
+		{"fe80::1%eth0", "", "", true},
+		{"scanme.nmap.org", "", "", false},
+		{"db-1.internal", "", "", false},
+	}
+	for _, tt := range tests {
+		first, last, isAddress := domain.ParseTargetRange(tt.target)
+		assert.Equal(t, tt.isAddress, isAddress, tt.target)
+		if tt.first == "" {
+			assert.Nil(t, first, tt.target)
+			assert.Nil(t, last, tt.target)
+			continue
+		}
+		assert.Equal(t, tt.first, first.String(), tt.target)
+		assert.Equal(t, tt.last, last.String(), tt.target)
+	}
+}
@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"testing"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTrashAndRestoreScan(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repository := new(MockScanRepository)
+	scan := &domain.Scan{ID: "scan-1", UserID: "alice", Status: domain.ScanStatusCompleted}
+	repository.On("GetScanByID", "scan-1").Return(scan, nil)
+	repository.On("GetScanByID", "scan-2").Return(&domain.Scan{ID: "scan-2", UserID: "alice", Status: domain.ScanStatusRunning}, nil)
+	repository.On("UpdateScan", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(new(MockScanAdapter), repository, log, 10)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	var scanErr *errors.Error
+
+	// Scans of other users are reported as not found
+	_, err := service.TrashScan(principalContext("bob", authdomain.RoleOperator), "scan-1")
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrNotFound, scanErr.Type)
+
+	// Active scans must be cancelled first
+	_, err = service.TrashScan(ctx, "scan-2")
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrInvalidInput, scanErr.Type)
+
+	// Scans not in the trash cannot be restored
+	_, err = service.RestoreScan(ctx, "scan-1")
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrInvalidInput, scanErr.Type)
+
+	trashed, err := service.TrashScan(ctx, "scan-1")
+	require.NoError(t, err)
+	assert.NotNil(t, trashed.DeletedAt)
+
+	_, err = service.TrashScan(ctx, "scan-1")
+	assert.Error(t, err)
+
+	restored, err := service.RestoreScan(ctx, "scan-1")
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	repository.AssertNumberOfCalls(t, "UpdateScan", 2)
+}
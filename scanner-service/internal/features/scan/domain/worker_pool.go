@@ -0,0 +1,355 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// scanJob is a scan waiting for or running on a worker
+type scanJob struct {
+	ctx    context.Context // Context of the scan, detached from the cancellation of the request
+	scan   *Scan
+	resume bool          // Whether the scan resumes from its saved progress
+	done   chan struct{} // Closed once the scan finished or was removed from the queue, nil if nobody waits
+}
+
+// workerPool runs scans on a bounded number of workers. Scans are admitted while the
+// workers and the queue have room, queued in submission order and handed by a
+// dispatcher to the next idle worker over the job channel. The pool owns the active
+// scans: a scan is active from its admission until its worker finished it or it was
+// removed from the queue. The fields of the scans are guarded by scanMu, which is never
+// taken while holding mu.
+type workerPool struct {
+	execute func(ctx context.Context, scan *Scan, resume bool)
+	logger  *logger.Logger
+	scanMu  *sync.Mutex   // Guards the fields of the active scans
+	jobs    chan scanJob  // Hands queued jobs to idle workers
+	wake    chan struct{} // Tells the dispatcher that jobs were queued or the pool closed
+	wg      sync.WaitGroup
+
+	mu         sync.Mutex
+	scans      map[string]*Scan     // Active scans, queued or running
+	queue      []scanJob            // Submitted jobs the dispatcher did not take yet
+	waiting    map[string]time.Time // Submission times of the jobs no worker took yet
+	running    int                  // Jobs running on a worker
+	average    time.Duration        // Moving average of the job durations, weighting recent jobs
+	size       int                  // Number of workers
+	queueSize  int                  // Scans that may wait beyond one per worker
+	workers    int                  // Workers not retired yet
+	nextWorker int                  // ID of the next started worker
+	shrunk     chan struct{}        // Closed to wake idle workers when the pool shrinks
+	started    bool
+	closed     bool
+}
+
+// newWorkerPool creates a worker pool running scans with execute, whose fields are
+// written under scanMu. Its workers start with the first admitted scan.
+func newWorkerPool(size int, scanMu *sync.Mutex, execute func(ctx context.Context, scan *Scan, resume bool), logger *logger.Logger) *workerPool {
+	return &workerPool{
+		execute: execute,
+		logger:  logger,
+		scanMu:  scanMu,
+		jobs:    make(chan scanJob),
+		wake:    make(chan struct{}, 1),
+		scans:   make(map[string]*Scan),
+		waiting: make(map[string]time.Time),
+		size:    size,
+		shrunk:  make(chan struct{}),
+	}
+}
+
+// admit makes scan active if the workers and the queue have room for it. The scan must
+// be submitted or withdrawn afterwards.
+func (p *workerPool) admit(scan *Scan) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return errDraining
+	}
+	if len(p.scans) >= p.size+p.queueSize {
+		return errScanLimitReached
+	}
+	p.scans[scan.ID] = scan
+
+	if !p.started {
+		p.started = true
+		p.wg.Add(1)
+		go p.dispatch()
+		p.startWorkers()
+	}
+	return nil
+}
+
+// withdraw removes an admitted scan that could not be submitted
+func (p *workerPool) withdraw(scan *Scan) {
+	p.mu.Lock()
+	delete(p.scans, scan.ID)
+	p.mu.Unlock()
+}
+
+// submit queues the job of an admitted scan
+func (p *workerPool) submit(job scanJob) {
+	p.mu.Lock()
+	p.queue = append(p.queue, job)
+	p.waiting[job.scan.ID] = time.Now()
+	p.mu.Unlock()
+	p.signal()
+}
+
+// cancel removes a queued scan, so that it never runs, and reports whether it was queued
+func (p *workerPool) cancel(id string) bool {
+	p.mu.Lock()
+	index := slices.IndexFunc(p.queue, func(job scanJob) bool { return job.scan.ID == id })
+	if index < 0 {
+		p.mu.Unlock()
+		return false
+	}
+	job := p.queue[index]
+	p.queue = slices.Delete(p.queue, index, index+1)
+	delete(p.scans, id)
+	delete(p.waiting, id)
+	p.mu.Unlock()
+
+	if job.done != nil {
+		close(job.done)
+	}
+	return true
+}
+
+// active returns a copy of an active scan
+func (p *workerPool) active(id string) (*Scan, bool) {
+	scan, ok := p.liveScan(id)
+	if !ok {
+		return nil, false
+	}
+
+	p.scanMu.Lock()
+	defer p.scanMu.Unlock()
+	scanCopy := *scan
+	return &scanCopy, true
+}
+
+// activeScans returns copies of the active scans
+func (p *workerPool) activeScans() []*Scan {
+	scans := p.liveScans()
+
+	p.scanMu.Lock()
+	defer p.scanMu.Unlock()
+	for i, scan := range scans {
+		scanCopy := *scan
+		scans[i] = &scanCopy
+	}
+	return scans
+}
+
+// liveScan returns an active scan as the worker running it changes it. The caller must
+// hold scanMu to access its fields.
+func (p *workerPool) liveScan(id string) (*Scan, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	scan, ok := p.scans[id]
+	return scan, ok
+}
+
+// liveScans returns the active scans as the workers running them change them. The
+// caller must hold scanMu to access their fields.
+func (p *workerPool) liveScans() []*Scan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	scans := make([]*Scan, 0, len(p.scans))
+	for _, scan := range p.scans {
+		scans = append(scans, scan)
+	}
+	return scans
+}
+
+// idle reports whether no scan is active
+func (p *workerPool) idle() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.scans) == 0
+}
+
+// capacity returns the number of workers and the number of scans that may wait
+func (p *workerPool) capacity() (int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.size, p.queueSize
+}
+
+// poolState is the state of the queue and the workers of a pool
+type poolState struct {
+	waiting   []time.Time   // Submission times of the jobs no worker took yet, oldest first
+	running   int           // Jobs running on a worker
+	average   time.Duration // Moving average of the job durations, 0 before the first job finished
+	size      int           // Number of workers
+	queueSize int           // Scans that may wait beyond one per worker
+}
+
+// state returns the state of the queue and the workers
+func (p *workerPool) state() poolState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state := poolState{running: p.running, average: p.average, size: p.size, queueSize: p.queueSize}
+	for _, submitted := range p.waiting {
+		state.waiting = append(state.waiting, submitted)
+	}
+	slices.SortFunc(state.waiting, func(a, b time.Time) int { return a.Compare(b) })
+	return state
+}
+
+// resize changes the number of workers. Surplus workers retire once their running scan
+// finished.
+func (p *workerPool) resize(size int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.size = size
+	if !p.started {
+		return
+	}
+	p.startWorkers()
+	if p.workers > p.size {
+		close(p.shrunk)
+		p.shrunk = make(chan struct{})
+	}
+}
+
+// setQueueSize changes the number of scans that may wait beyond one per worker
+func (p *workerPool) setQueueSize(queueSize int) {
+	p.mu.Lock()
+	p.queueSize = queueSize
+	p.mu.Unlock()
+}
+
+// close stops admitting scans. The queued scans still run, then the workers stop.
+func (p *workerPool) close() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	p.signal()
+}
+
+// wait waits until the dispatcher and the workers of a closed pool stopped or ctx is done
+func (p *workerPool) wait(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+	}
+}
+
+// signal wakes the dispatcher
+func (p *workerPool) signal() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// startWorkers starts workers until the pool has its size. The caller must hold p.mu.
+func (p *workerPool) startWorkers() {
+	for p.workers < p.size {
+		p.workers++
+		p.nextWorker++
+		p.wg.Add(1)
+		go p.work(p.nextWorker)
+	}
+}
+
+// dispatch hands the queued jobs to the workers in submission order until the pool is
+// closed and its queue is empty
+func (p *workerPool) dispatch() {
+	defer p.wg.Done()
+
+	for {
+		p.mu.Lock()
+		if len(p.queue) == 0 {
+			closed := p.closed
+			p.mu.Unlock()
+			if closed {
+				close(p.jobs)
+				return
+			}
+			<-p.wake
+			continue
+		}
+		job := p.queue[0]
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+
+		p.jobs <- job
+	}
+}
+
+// work runs jobs until the pool shrinks below the worker or is closed
+func (p *workerPool) work(id int) {
+	defer p.wg.Done()
+	log := p.logger.With(zap.Int("worker", id))
+	log.Info("Scan worker started")
+
+	for {
+		p.mu.Lock()
+		if p.workers > p.size {
+			p.workers--
+			p.mu.Unlock()
+			log.Info("Scan worker retired")
+			return
+		}
+		shrunk := p.shrunk
+		p.mu.Unlock()
+
+		select {
+		case job, ok := <-p.jobs:
+			if !ok {
+				log.Info("Scan worker stopped")
+				return
+			}
+			p.run(log, job)
+		case <-shrunk:
+		}
+	}
+}
+
+// run runs a job and makes its scan inactive
+func (p *workerPool) run(log *logger.Logger, job scanJob) {
+	p.mu.Lock()
+	p.running++
+	delete(p.waiting, job.scan.ID)
+	p.mu.Unlock()
+
+	log.Debug("Scan worker took scan", zap.String("scan_id", job.scan.ID))
+	start := time.Now()
+	p.execute(job.ctx, job.scan, job.resume)
+	duration := time.Since(start)
+
+	p.mu.Lock()
+	p.running--
+	if p.average == 0 {
+		p.average = duration
+	} else {
+		p.average += (duration - p.average) / 5
+	}
+	delete(p.scans, job.scan.ID)
+	p.mu.Unlock()
+	if job.done != nil {
+		close(job.done)
+	}
+
+	log.Debug("Scan worker finished scan",
+		zap.String("scan_id", job.scan.ID),
+		zap.Duration("duration", duration),
+	)
+}
@@ -0,0 +1,101 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestScansWaitForAWorker(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	adapter := &blockingScanAdapter{started: make(chan struct{}, 2), release: make(chan struct{})}
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	scans := newScanStore()
+	repository.On("UpdateScan", mock.Anything).Run(scans.update).Return(nil)
+	repository.On("GetScanByID", mock.Anything).Return(scans.get, nil)
+	repository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(adapter, repository, log, 1)
+	service.SetScanQueueSize(1)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	options := func(target string) domain.ScanOptions {
+		return domain.ScanOptions{Target: target, Timeout: time.Minute}
+	}
+	status := func(scan *domain.Scan) domain.ScanStatus {
+		current, err := service.GetScan(ctx, scan.ID)
+		require.NoError(t, err)
+		return current.Status
+	}
+
+	running, err := service.StartScan(ctx, "alice", options("10.0.0.1"))
+	require.NoError(t, err)
+	<-adapter.started
+
+	// The second scan waits for the worker, the third finds the queue full and is told to
+	// retry once the waiting scan and itself may have run with the default duration
+	queued, err := service.StartScan(ctx, "alice", options("10.0.0.2"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScanStatusPending, queued.Status)
+
+	_, err = service.StartScan(ctx, "alice", options("10.0.0.3"))
+	var scanErr *errors.Error
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, errors.ErrRateLimited, scanErr.Type)
+	assert.Equal(t, time.Minute, scanErr.RetryAfter)
+
+	// Cancelling the queued scan frees its place at once
+	require.NoError(t, service.CancelScan(ctx, queued.ID))
+	next, err := service.StartScan(ctx, "alice", options("10.0.0.4"))
+	require.NoError(t, err)
+
+	// The queued scan runs once the worker finished the running one
+	close(adapter.release)
+	assert.Eventually(t, func() bool {
+		return status(next) == domain.ScanStatusCompleted
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, domain.ScanStatusCompleted, status(running))
+	assert.Equal(t, domain.ScanStatusCancelled, status(queued))
+}
+
+func TestSetMaxConcurrentScansStartsWorkers(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	adapter := &blockingScanAdapter{started: make(chan struct{}, 2), release: make(chan struct{})}
+	defer close(adapter.release)
+	repository := new(MockScanRepository)
+	repository.On("SaveScan", mock.Anything).Return(nil)
+	repository.On("UpdateScan", mock.Anything).Return(nil)
+	repository.On("ListBaselines", mock.Anything).Return([]*domain.Baseline{}, nil)
+	repository.On("SaveScanResult", mock.Anything).Return(nil)
+
+	service := domain.NewScanService(adapter, repository, log, 1)
+	service.SetScanQueueSize(1)
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	admin := principalContext("admin", authdomain.RoleAdmin)
+
+	_, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.1", Timeout: time.Minute})
+	require.NoError(t, err)
+	<-adapter.started
+	queued, err := service.StartScan(ctx, "alice", domain.ScanOptions{Target: "10.0.0.2", Timeout: time.Minute})
+	require.NoError(t, err)
+
+	// A new worker takes the queued scan
+	require.NoError(t, service.SetMaxConcurrentScans(admin, 2))
+	select {
+	case <-adapter.started:
+	case <-time.After(time.Second):
+		t.Fatal("queued scan did not start on the new worker")
+	}
+	current, err := service.GetScan(ctx, queued.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScanStatusRunning, current.Status)
+}
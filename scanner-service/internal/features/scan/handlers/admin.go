@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// UpdateLimitsRequest represents the request body for changing runtime limits
+type UpdateLimitsRequest struct {
+	MaxConcurrentScans int `json:"max_concurrent_scans" binding:"required"`
+}
+
+// AdminListScans handles the request to list the scans of all users
+func (h *ScanHandler) AdminListScans(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	filter, order, err := parseScanListQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	filter.UserID = c.Query("user_id")
+
+	page, err := h.scanService.ListScans(c.Request.Context(), filter, order, domain.PageRequest{
+		Limit:  limit,
+		Offset: offset,
+		Cursor: c.Query("cursor"),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"scans":       page.Scans,
+		"limit":       limit,
+		"offset":      offset,
+		"count":       len(page.Scans),
+		"total_count": page.TotalCount,
+		"has_more":    page.HasMore,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+// AdminCancelScan handles the request to cancel any user's scan
+func (h *ScanHandler) AdminCancelScan(c *gin.Context) {
+	scanID := c.Param("id")
+
+	if err := h.scanService.CancelScan(c.Request.Context(), scanID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Scan force-cancelled by admin",
+		zap.String("scan_id", scanID),
+		zap.String("admin_id", c.GetString("user_id")),
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scan cancelled",
+		"scan_id": scanID,
+	})
+}
+
+// AdminPurgeResults handles the request to purge scan results.
+// Either the older_than query parameter, a duration such as "72h", or all=true to
+// purge every result is required.
+func (h *ScanHandler) AdminPurgeResults(c *gin.Context) {
+	var olderThan time.Duration
+	if value := c.Query("older_than"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			c.Error(errors.NewInvalidField("older_than", "format", "invalid older_than: "+err.Error()))
+			return
+		}
+		olderThan = parsed
+	}
+
+	purged, err := h.scanService.PurgeScanResults(c.Request.Context(), olderThan, c.Query("all") == "true")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scan results purged",
+		"purged":  purged,
+	})
+}
+
+// AdminGetRetention handles the request to report the data the retention rules would
+// delete now, without deleting it
+func (h *ScanHandler) AdminGetRetention(c *gin.Context) {
+	report, err := h.scanService.ApplyRetention(c.Request.Context(), true)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// AdminApplyRetention handles the request to delete the data that expired under the
+// retention rules now. With ?dry_run=true the data is only reported.
+func (h *ScanHandler) AdminApplyRetention(c *gin.Context) {
+	report, err := h.scanService.ApplyRetention(c.Request.Context(), c.Query("dry_run") == "true")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// AdminGetStats handles the request to get the queue depth and active scans
+func (h *ScanHandler) AdminGetStats(c *gin.Context) {
+	stats, err := h.scanService.GetScanStats(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// AdminGetQueue handles the request to get the state of the scan queue and the workers
+func (h *ScanHandler) AdminGetQueue(c *gin.Context) {
+	stats, err := h.scanService.GetQueueStats(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// AdminUpdateLimits handles the request to change runtime limits
+func (h *ScanHandler) AdminUpdateLimits(c *gin.Context) {
+	var req UpdateLimitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	if err := h.scanService.SetMaxConcurrentScans(c.Request.Context(), req.MaxConcurrentScans); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":              "Limits updated",
+		"max_concurrent_scans": req.MaxConcurrentScans,
+	})
+}
@@ -0,0 +1,55 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/repository"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/server"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAdminPurgeResultsRequiresScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := &logger.Logger{Logger: zap.NewNop()}
+	repo := repository.NewMemoryScanRepository(log, 0)
+	completedAt := time.Now().Add(-time.Hour)
+	require.NoError(t, repo.SaveScanResult(&domain.ScanResult{ID: "result-1", ScanID: "scan-1", UserID: "alice", EndTime: completedAt}))
+
+	router := gin.New()
+	router.Use(server.ErrorMiddleware(log), func(c *gin.Context) {
+		c.Request = c.Request.WithContext(authdomain.WithPrincipal(c.Request.Context(), &authdomain.Principal{
+			UserID: "root",
+			Roles:  []authdomain.Role{authdomain.RoleAdmin},
+		}))
+	})
+	handlers.NewScanHandler(domain.NewScanService(nil, repo, log, 1), log).RegisterRoutes(router)
+
+	purge := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/admin/results"+query, nil))
+		return rec
+	}
+
+	// A bare request does not purge every result
+	for _, query := range []string{"", "?older_than=0s", "?older_than=soon", "?all=true&older_than=1h"} {
+		rec := purge(query)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		assert.Contains(t, rec.Body.String(), "older_than", query)
+	}
+	_, err := repo.GetScanResultByID("result-1")
+	require.NoError(t, err)
+
+	rec := purge("?all=true")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"message":"Scan results purged","purged":1}`, rec.Body.String())
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BaselineRequest represents the request body for creating or updating a baseline
+type BaselineRequest struct {
+	Name      string `json:"name" binding:"required"`
+	Target    string `json:"target" binding:"required"`
+	OpenPorts []int  `json:"open_ports"`
+	Protocol  string `json:"protocol,omitempty"`
+}
+
+// toBaseline creates a baseline from the request
+func (r BaselineRequest) toBaseline() domain.Baseline {
+	return domain.Baseline{
+		Name:      r.Name,
+		Target:    r.Target,
+		OpenPorts: r.OpenPorts,
+		Protocol:  r.Protocol,
+	}
+}
+
+// listUserID returns the user whose data a listing request asks for.
+// Admins may ask for another user's data with ?user_id= or for all data with ?all=true.
+func listUserID(c *gin.Context) string {
+	userID := c.GetString("user_id")
+	if queryUserID := c.Query("user_id"); queryUserID != "" {
+		userID = queryUserID
+	}
+	if c.Query("all") == "true" {
+		userID = ""
+	}
+	return userID
+}
+
+// CreateBaseline handles the request to create a baseline
+func (h *ScanHandler) CreateBaseline(c *gin.Context) {
+	var req BaselineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	baseline, err := h.scanService.CreateBaseline(c.Request.Context(), c.GetString("user_id"), req.toBaseline())
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to create baseline",
+			zap.Error(err),
+			zap.String("target", req.Target),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, baseline)
+}
+
+// UpdateBaseline handles the request to update a baseline
+func (h *ScanHandler) UpdateBaseline(c *gin.Context) {
+	var req BaselineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	baseline, err := h.scanService.UpdateBaseline(c.Request.Context(), c.Param("id"), req.toBaseline())
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to update baseline",
+			zap.Error(err),
+			zap.String("baseline_id", c.Param("id")),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, baseline)
+}
+
+// GetBaseline handles the request to get a baseline with its current violations
+func (h *ScanHandler) GetBaseline(c *gin.Context) {
+	baseline, err := h.scanService.GetBaseline(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, baseline)
+}
+
+// ListBaselines handles the request to list baselines.
+// Admins may list another user's baselines with ?user_id= or all baselines with ?all=true.
+func (h *ScanHandler) ListBaselines(c *gin.Context) {
+	userID := listUserID(c)
+
+	baselines, err := h.scanService.ListBaselines(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to list baselines",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"baselines": baselines,
+		"count":     len(baselines),
+	})
+}
+
+// DeleteBaseline handles the request to delete a baseline
+func (h *ScanHandler) DeleteBaseline(c *gin.Context) {
+	baselineID := c.Param("id")
+
+	if err := h.scanService.DeleteBaseline(c.Request.Context(), baselineID); err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to delete baseline",
+			zap.Error(err),
+			zap.String("baseline_id", baselineID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Baseline deleted",
+		"baseline_id": baselineID,
+	})
+}
+
+// ListBaselineViolations handles the request to list the current violations of baselines.
+// Admins may list the violations of another user's baselines with ?user_id= or of all
+// baselines with ?all=true.
+func (h *ScanHandler) ListBaselineViolations(c *gin.Context) {
+	userID := listUserID(c)
+
+	violations, err := h.scanService.ListBaselineViolations(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to list baseline violations",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"violations": violations,
+		"count":      len(violations),
+	})
+}
@@ -0,0 +1,12 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCapabilities handles the request to get the scan features the scanner supports
+func (h *ScanHandler) GetCapabilities(c *gin.Context) {
+	c.JSON(http.StatusOK, h.scanService.GetCapabilities(c.Request.Context()))
+}
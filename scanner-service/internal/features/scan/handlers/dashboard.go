@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// GetAttackSurface handles the request to get the top services, products and ports
+// across stored results. Admins may aggregate another user's results with ?user_id=
+// or all results with ?all=true.
+func (h *ScanHandler) GetAttackSurface(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if queryUserID := c.Query("user_id"); queryUserID != "" {
+		userID = queryUserID
+	}
+	if c.Query("all") == "true" {
+		userID = ""
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 {
+		limit = 10
+	} else if limit > domain.MaxSurfaceEntries {
+		limit = domain.MaxSurfaceEntries
+	}
+
+	surface, err := h.scanService.GetAttackSurface(c.Request.Context(), userID, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, surface)
+}
+
+// GetUserActivity handles the request to get the latest scan lifecycle events of a user
+func (h *ScanHandler) GetUserActivity(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 {
+		limit = 20
+	} else if limit > domain.MaxActivityEvents {
+		limit = domain.MaxActivityEvents
+	}
+
+	events, err := h.scanService.GetUserActivity(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
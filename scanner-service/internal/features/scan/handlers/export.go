@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// ExportScanResult handles the request to download a scan result as a JSON, XML or
+// HTML document, selected with ?format= (JSON by default). With ?anonymize=true the IP
+// addresses, hostnames and MAC addresses of the result are replaced by pseudonyms.
+func (h *ScanHandler) ExportScanResult(c *gin.Context) {
+	format, err := domain.ParseExportFormat(c.DefaultQuery("format", string(domain.ExportFormatJSON)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resultID := c.Param("id")
+	result, err := h.scanService.GetScanResult(c.Request.Context(), resultID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if c.Query("anonymize") == "true" {
+		result = domain.AnonymizeResult(result)
+	}
+
+	// The document is rendered before responding so that failures get an error response
+	var buf bytes.Buffer
+	if err := domain.ExportResult(&buf, result, format); err != nil {
+		c.Error(errors.NewInternal("failed to export scan result", err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="scan-result-%s.%s"`, result.ID, format))
+	c.Data(http.StatusOK, format.ContentType(), buf.Bytes())
+}
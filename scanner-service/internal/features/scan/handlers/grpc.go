@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	scannerv1 "github.com/furkansarikaya/nmap-ui-microservices/api/proto/scanner/v1"
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ScanGRPCHandler serves the scanner API of api/proto over gRPC
+type ScanGRPCHandler struct {
+	scannerv1.UnimplementedScannerServiceServer
+	scanService *domain.ScanService
+	logger      *logger.Logger
+}
+
+// NewScanGRPCHandler creates a new ScanGRPCHandler
+func NewScanGRPCHandler(scanService *domain.ScanService, logger *logger.Logger) *ScanGRPCHandler {
+	return &ScanGRPCHandler{
+		scanService: scanService,
+		logger:      logger,
+	}
+}
+
+// Register registers the scanner service on the gRPC server
+func (h *ScanGRPCHandler) Register(server *grpc.Server) {
+	scannerv1.RegisterScannerServiceServer(server, h)
+}
+
+// StartScan starts a scan for the caller
+func (h *ScanGRPCHandler) StartScan(ctx context.Context, req *scannerv1.StartScanRequest) (*scannerv1.Scan, error) {
+	principal, ok := authdomain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, errors.NewUnauthorized("unauthorized", nil)
+	}
+	options := req.GetOptions()
+	if options.GetTarget() == "" {
+		return nil, errors.NewInvalidField("target", "required", "target is required")
+	}
+
+	scan, err := h.scanService.StartScan(ctx, principal.UserID, scanOptionsRequestFromProto(options).toScanOptions(options.GetTarget()))
+	if err != nil {
+		h.logger.WithContext(ctx).Error("Failed to start scan",
+			zap.Error(err),
+			zap.String("target", options.GetTarget()),
+		)
+		return nil, errors.RenameFields(err, requestFieldNames)
+	}
+
+	h.logger.WithContext(ctx).Info("Scan started",
+		zap.String("scan_id", scan.ID),
+		zap.String("target", options.GetTarget()),
+	)
+	return scanToProto(scan), nil
+}
+
+// GetScan returns a scan by ID
+func (h *ScanGRPCHandler) GetScan(ctx context.Context, req *scannerv1.GetScanRequest) (*scannerv1.Scan, error) {
+	if req.GetId() == "" {
+		return nil, errors.NewInvalidField("id", "required", "scan ID is required")
+	}
+
+	scan, err := h.scanService.GetScan(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return scanToProto(scan), nil
+}
+
+// ListScans returns a page of scans. Admins may list another user's scans with
+// user_id or all scans with all.
+func (h *ScanGRPCHandler) ListScans(ctx context.Context, req *scannerv1.ListScansRequest) (*scannerv1.ListScansResponse, error) {
+	principal, ok := authdomain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, errors.NewUnauthorized("unauthorized", nil)
+	}
+
+	filter := domain.ScanFilter{
+		UserID:   principal.UserID,
+		Target:   req.GetTarget(),
+		ParentID: req.GetParentId(),
+		Deleted:  req.GetDeleted(),
+	}
+	if req.GetUserId() != "" {
+		filter.UserID = req.GetUserId()
+	}
+	if req.GetAll() {
+		filter.UserID = ""
+	}
+	if req.GetStatus() != scannerv1.ScanStatus_SCAN_STATUS_UNSPECIFIED {
+		status, ok := scanStatusFromProto(req.GetStatus())
+		if !ok {
+			return nil, errors.NewInvalidField("status", "oneof", "invalid status: "+req.GetStatus().String())
+		}
+		filter.Statuses = []domain.ScanStatus{status}
+	}
+	if req.GetCreatedAfter() != nil {
+		filter.CreatedAfter = req.GetCreatedAfter().AsTime()
+	}
+	if req.GetCreatedBefore() != nil {
+		filter.CreatedBefore = req.GetCreatedBefore().AsTime()
+	}
+
+	order, err := domain.ParseScanSort(req.GetSort(), req.GetOrder())
+	if err != nil {
+		return nil, err
+	}
+
+	// Same bounds as the REST API
+	limit := int(req.GetLimit())
+	if limit < 1 {
+		limit = 10
+	} else if limit > 100 {
+		limit = 100
+	}
+
+	page, err := h.scanService.ListScans(ctx, filter, order, domain.PageRequest{
+		Limit:  limit,
+		Offset: max(int(req.GetOffset()), 0),
+		Cursor: req.GetCursor(),
+	})
+	if err != nil {
+		h.logger.WithContext(ctx).Error("Failed to list scans",
+			zap.Error(err),
+			zap.String("user_id", filter.UserID),
+		)
+		return nil, err
+	}
+
+	resp := &scannerv1.ListScansResponse{
+		Scans:      make([]*scannerv1.Scan, len(page.Scans)),
+		TotalCount: int32(page.TotalCount),
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
+	}
+	for i, scan := range page.Scans {
+		resp.Scans[i] = scanToProto(scan)
+	}
+	return resp, nil
+}
+
+// CancelScan cancels a pending or running scan
+func (h *ScanGRPCHandler) CancelScan(ctx context.Context, req *scannerv1.CancelScanRequest) (*scannerv1.CancelScanResponse, error) {
+	if req.GetId() == "" {
+		return nil, errors.NewInvalidField("id", "required", "scan ID is required")
+	}
+
+	if err := h.scanService.CancelScan(ctx, req.GetId()); err != nil {
+		h.logger.WithContext(ctx).Error("Failed to cancel scan",
+			zap.Error(err),
+			zap.String("scan_id", req.GetId()),
+		)
+		return nil, err
+	}
+
+	h.logger.WithContext(ctx).Info("Scan cancelled", zap.String("scan_id", req.GetId()))
+	return &scannerv1.CancelScanResponse{}, nil
+}
+
+// GetScanResult returns a scan result by ID
+func (h *ScanGRPCHandler) GetScanResult(ctx context.Context, req *scannerv1.GetScanResultRequest) (*scannerv1.ScanResult, error) {
+	if req.GetId() == "" {
+		return nil, errors.NewInvalidField("id", "required", "result ID is required")
+	}
+
+	result, err := h.scanService.GetScanResult(ctx, req.GetId())
+	if err != nil {
+		h.logger.WithContext(ctx).Error("Failed to get scan result",
+			zap.Error(err),
+			zap.String("result_id", req.GetId()),
+		)
+		return nil, err
+	}
+	return domain.ScanResultToProto(result), nil
+}
+
+// scanOptionsRequestFromProto converts protobuf scan options into the options of a
+// REST request, so both APIs apply the same defaults
+func scanOptionsRequestFromProto(options *scannerv1.ScanOptions) ScanOptionsRequest {
+	req := ScanOptionsRequest{
+		Ports:              options.GetPorts(),
+		ScanType:           domain.ScanType(options.GetScanType()),
+		TimingTemplate:     domain.TimingTemplate(options.GetTimingTemplate()),
+		ServiceDetection:   options.GetServiceDetection(),
+		OSDetection:        options.GetOsDetection(),
+		ScriptScan:         options.GetScriptScan(),
+		ExtraOptions:       options.GetExtraOptions(),
+		TimeoutSeconds:     int(options.GetTimeoutSeconds()),
+		HostTimeoutSeconds: int(options.GetHostTimeoutSeconds()),
+		MinRate:            int(options.GetMinRate()),
+		MaxRate:            int(options.GetMaxRate()),
+		MaxParallelism:     int(options.GetMaxParallelism()),
+		Decoys:             options.GetDecoys(),
+		SourcePort:         int(options.GetSourcePort()),
+		Fragment:           options.GetFragment(),
+		DataLength:         int(options.GetDataLength()),
+		Agent:              options.GetAgent(),
+		Engine:             domain.ScanEngine(options.GetEngine()),
+		Mode:               domain.ScanMode(options.GetMode()),
+	}
+	if options.MaxRetries != nil {
+		retries := int(options.GetMaxRetries())
+		req.MaxRetries = &retries
+	}
+	for _, method := range options.GetDiscovery() {
+		req.Discovery = append(req.Discovery, domain.DiscoveryMethod(method))
+	}
+	return req
+}
+
+// scanToProto converts a scan into its protobuf message
+func scanToProto(scan *domain.Scan) *scannerv1.Scan {
+	options := scan.Options
+	msg := &scannerv1.Scan{
+		Id:       scan.ID,
+		UserId:   scan.UserID,
+		TenantId: scan.TenantID,
+		Options: &scannerv1.ScanOptions{
+			Target:             options.Target,
+			Ports:              options.Ports,
+			ScanType:           string(options.ScanType),
+			TimingTemplate:     int32(options.TimingTemplate),
+			ServiceDetection:   options.ServiceDetection,
+			OsDetection:        options.OSDetection,
+			ScriptScan:         options.ScriptScan,
+			ExtraOptions:       options.ExtraOptions,
+			TimeoutSeconds:     int32(options.Timeout / time.Second),
+			HostTimeoutSeconds: int32(options.HostTimeout / time.Second),
+			MinRate:            int32(options.MinRate),
+			MaxRate:            int32(options.MaxRate),
+			MaxParallelism:     int32(options.MaxParallelism),
+			Decoys:             options.Decoys,
+			SourcePort:         int32(options.SourcePort),
+			Fragment:           options.Fragment,
+			DataLength:         int32(options.DataLength),
+			Agent:              options.Agent,
+			Engine:             string(options.Engine),
+			Mode:               string(options.Mode),
+		},
+		Status:              scanStatusToProto(scan.Status),
+		Progress:            scan.Progress,
+		CreatedAt:           timestamppb.New(scan.CreatedAt),
+		StartedAt:           timestampToProto(scan.StartedAt),
+		CompletedAt:         timestampToProto(scan.CompletedAt),
+		Error:               scan.Error,
+		ResultId:            scan.ResultID,
+		RequestId:           scan.RequestID,
+		PipelineId:          scan.PipelineID,
+		WorkflowRunId:       scan.WorkflowRunID,
+		ParentId:            scan.ParentID,
+		ShardCount:          int32(scan.ShardCount),
+		Resumable:           scan.Resumable,
+		DeletedAt:           timestampToProto(scan.DeletedAt),
+		CurrentPhase:        string(scan.CurrentPhase),
+		EstimatedCompletion: timestampToProto(scan.EstimatedCompletion),
+	}
+	if options.MaxRetries != nil {
+		retries := int32(*options.MaxRetries)
+		msg.Options.MaxRetries = &retries
+	}
+	for _, method := range options.Discovery {
+		msg.Options.Discovery = append(msg.Options.Discovery, string(method))
+	}
+	if scan.Failure != nil {
+		msg.Failure = &scannerv1.ScanFailure{
+			Command:  scan.Failure.Command,
+			ExitCode: int32(scan.Failure.ExitCode),
+			Stderr:   scan.Failure.Stderr,
+			Type:     string(scan.Failure.Type),
+			Hint:     scan.Failure.Hint,
+		}
+	}
+	return msg
+}
+
+// scanStatusToProto converts a scan status into its protobuf enum value
+func scanStatusToProto(status domain.ScanStatus) scannerv1.ScanStatus {
+	return scannerv1.ScanStatus(scannerv1.ScanStatus_value["SCAN_STATUS_"+string(status)])
+}
+
+// scanStatusFromProto converts a protobuf scan status into the scan status, false for
+// unknown values
+func scanStatusFromProto(status scannerv1.ScanStatus) (domain.ScanStatus, bool) {
+	return domain.ParseScanStatus(strings.TrimPrefix(scannerv1.ScanStatus_name[int32(status)], "SCAN_STATUS_"))
+}
+
+// timestampToProto converts an optional time into a protobuf timestamp, nil if unset
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
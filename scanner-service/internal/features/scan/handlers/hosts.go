@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// hostFlushInterval is the number of hosts written between flushes of a host stream
+const hostFlushInterval = 100
+
+// StreamResultHosts handles the request to stream the hosts of a scan result as
+// newline-delimited JSON, one host per line. Hosts are filtered with ?status=, ?port=,
+// ?service= and ?q= (search terms).
+func (h *ScanHandler) StreamResultHosts(c *gin.Context) {
+	filter := domain.HostFilter{
+		Status:  c.Query("status"),
+		Service: c.Query("service"),
+		Terms:   domain.ParseSearchTerms(c.Query("q")),
+	}
+	if value := c.Query("port"); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			c.Error(errors.NewInvalidField("port", "type", "port must be a number"))
+			return
+		}
+		filter.Port = port
+	}
+	if err := filter.Validate(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	resultID := c.Param("id")
+	result, err := h.scanService.GetScanResult(c.Request.Context(), resultID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(c.Writer)
+	written := 0
+	for _, host := range result.Hosts {
+		if !filter.Matches(host) {
+			continue
+		}
+		if err := c.Request.Context().Err(); err != nil {
+			return
+		}
+		if err := encoder.Encode(host); err != nil {
+			h.logger.WithContext(c.Request.Context()).Warn("Failed to stream result hosts",
+				zap.Error(err),
+				zap.String("result_id", resultID),
+			)
+			return
+		}
+
+		written++
+		if written%hostFlushInterval == 0 {
+			c.Writer.Flush()
+		}
+	}
+}
@@ -3,10 +3,15 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -25,70 +30,108 @@ func NewScanHandler(scanService *domain.ScanService, logger *logger.Logger) *Sca
 	}
 }
 
+// ScanOptionsRequest represents the scan options of a request
+type ScanOptionsRequest struct {
+	Ports              string                   `json:"ports,omitempty"`
+	ScanType           domain.ScanType          `json:"scan_type,omitempty"`
+	TimingTemplate     domain.TimingTemplate    `json:"timing_template,omitempty"`
+	ServiceDetection   bool                     `json:"service_detection,omitempty"`
+	OSDetection        bool                     `json:"os_detection,omitempty"`
+	ScriptScan         bool                     `json:"script_scan,omitempty"`
+	ExtraOptions       []string                 `json:"extra_options,omitempty"`
+	TimeoutSeconds     int                      `json:"timeout_seconds,omitempty"`
+	HostTimeoutSeconds int                      `json:"host_timeout_seconds,omitempty"`
+	MinRate            int                      `json:"min_rate,omitempty"`
+	MaxRate            int                      `json:"max_rate,omitempty"`
+	MaxParallelism     int                      `json:"max_parallelism,omitempty"`
+	MaxRetries         *int                     `json:"max_retries,omitempty"`
+	Decoys             []string                 `json:"decoys,omitempty"`
+	SourcePort         int                      `json:"source_port,omitempty"`
+	Fragment           bool                     `json:"fragment,omitempty"`
+	DataLength         int                      `json:"data_length,omitempty"`
+	Discovery          []domain.DiscoveryMethod `json:"discovery,omitempty"`
+	Agent              string                   `json:"agent,omitempty"`
+	Engine             domain.ScanEngine        `json:"engine,omitempty"`
+	Mode               domain.ScanMode          `json:"mode,omitempty"`
+	Tags               []string                 `json:"tags,omitempty"`
+}
+
+// requestFieldNames maps the scan option fields named differently in requests
+var requestFieldNames = map[string]string{
+	"timeout":      "timeout_seconds",
+	"host_timeout": "host_timeout_seconds",
+}
+
+// toScanOptions creates scan options for the target from the request
+func (r ScanOptionsRequest) toScanOptions(target string) domain.ScanOptions {
+	options := domain.ScanOptions{
+		Target:           target,
+		Ports:            r.Ports,
+		ScanType:         r.ScanType,
+		TimingTemplate:   r.TimingTemplate,
+		ServiceDetection: r.ServiceDetection,
+		OSDetection:      r.OSDetection,
+		ScriptScan:       r.ScriptScan,
+		ExtraOptions:     r.ExtraOptions,
+		HostTimeout:      time.Duration(r.HostTimeoutSeconds) * time.Second,
+		MinRate:          r.MinRate,
+		MaxRate:          r.MaxRate,
+		MaxParallelism:   r.MaxParallelism,
+		MaxRetries:       r.MaxRetries,
+		Decoys:           r.Decoys,
+		SourcePort:       r.SourcePort,
+		Fragment:         r.Fragment,
+		DataLength:       r.DataLength,
+		Discovery:        r.Discovery,
+		Agent:            r.Agent,
+		Engine:           r.Engine,
+		Mode:             r.Mode,
+		Tags:             r.Tags,
+	}
+
+	// Set timeout
+	if r.TimeoutSeconds > 0 {
+		options.Timeout = time.Duration(r.TimeoutSeconds) * time.Second
+	} else {
+		options.Timeout = 5 * time.Minute // Default timeout
+	}
+
+	return options
+}
+
 // StartScanRequest represents the request body for starting a scan
 type StartScanRequest struct {
-	Target           string                `json:"target" binding:"required"`
-	Ports            string                `json:"ports,omitempty"`
-	ScanType         domain.ScanType       `json:"scan_type,omitempty"`
-	TimingTemplate   domain.TimingTemplate `json:"timing_template,omitempty"`
-	ServiceDetection bool                  `json:"service_detection,omitempty"`
-	OSDetection      bool                  `json:"os_detection,omitempty"`
-	ScriptScan       bool                  `json:"script_scan,omitempty"`
-	ExtraOptions     []string              `json:"extra_options,omitempty"`
-	TimeoutSeconds   int                   `json:"timeout_seconds,omitempty"`
+	Target string `json:"target" binding:"required"`
+	ScanOptionsRequest
 }
 
 // StartScan handles the request to start a scan
 func (h *ScanHandler) StartScan(c *gin.Context) {
 	var req StartScanRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request: " + err.Error(),
-		})
+		c.Error(validation.FromBindError(err))
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	// For now, use a default user ID
 	userID := c.GetString("user_id")
-	if userID == "" {
-		userID = "default-user" // Will be replaced with actual auth
-	}
 
 	// Create scan options from request
-	options := domain.ScanOptions{
-		Target:           req.Target,
-		Ports:            req.Ports,
-		ScanType:         req.ScanType,
-		TimingTemplate:   req.TimingTemplate,
-		ServiceDetection: req.ServiceDetection,
-		OSDetection:      req.OSDetection,
-		ScriptScan:       req.ScriptScan,
-		ExtraOptions:     req.ExtraOptions,
-	}
-
-	// Set timeout
-	if req.TimeoutSeconds > 0 {
-		options.Timeout = time.Duration(req.TimeoutSeconds) * time.Second
-	} else {
-		options.Timeout = 5 * time.Minute // Default timeout
-	}
+	options := req.toScanOptions(req.Target)
 
 	// Start scan
 	scan, err := h.scanService.StartScan(c.Request.Context(), userID, options)
 	if err != nil {
-		h.logger.Error("Failed to start scan",
+		h.logger.WithContext(c.Request.Context()).Error("Failed to start scan",
 			zap.Error(err),
 			zap.String("target", req.Target),
 		)
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to start scan: " + err.Error(),
-		})
+		c.Error(errors.RenameFields(err, requestFieldNames))
 		return
 	}
 
-	h.logger.Info("Scan started",
+	h.logger.WithContext(c.Request.Context()).Info("Scan started",
 		zap.String("scan_id", scan.ID),
 		zap.String("target", req.Target),
 	)
@@ -99,42 +142,94 @@ func (h *ScanHandler) StartScan(c *gin.Context) {
 	})
 }
 
+// LintScan handles the request to check scan options for conflicts before submission
+func (h *ScanHandler) LintScan(c *gin.Context) {
+	var req StartScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	report := h.scanService.LintScan(c.Request.Context(), req.toScanOptions(req.Target))
+	c.JSON(http.StatusOK, report)
+}
+
 // GetScan handles the request to get a scan
 func (h *ScanHandler) GetScan(c *gin.Context) {
 	scanID := c.Param("id")
 	if scanID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Scan ID is required",
-		})
+		c.Error(errors.NewInvalidInput("scan ID is required", nil))
 		return
 	}
 
-	scan, err := h.scanService.GetScan(scanID)
+	scan, err := h.scanService.GetScan(c.Request.Context(), scanID)
 	if err != nil {
 		h.logger.Error("Failed to get scan",
 			zap.Error(err),
 			zap.String("scan_id", scanID),
 		)
 
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Failed to get scan: " + err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
+	// Scans that stopped running only change when annotated or resumed
+	if scan.Status.Terminal() {
+		jsonConditional(c, scan)
+		return
+	}
 	c.JSON(http.StatusOK, scan)
 }
 
+// GetScanLogs handles the request to get the output nmap wrote while running a scan
+func (h *ScanHandler) GetScanLogs(c *gin.Context) {
+	scanID := c.Param("id")
+	if scanID == "" {
+		c.Error(errors.NewInvalidInput("scan ID is required", nil))
+		return
+	}
+
+	logs, err := h.scanService.GetScanLogs(c.Request.Context(), scanID)
+	if err != nil {
+		h.logger.Error("Failed to get scan logs",
+			zap.Error(err),
+			zap.String("scan_id", scanID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
+
+// GetPartialResult handles the request to get the hosts a running scan found so far
+func (h *ScanHandler) GetPartialResult(c *gin.Context) {
+	scanID := c.Param("id")
+	if scanID == "" {
+		c.Error(errors.NewInvalidInput("scan ID is required", nil))
+		return
+	}
+
+	partial, err := h.scanService.GetPartialResult(c.Request.Context(), scanID)
+	if err != nil {
+		h.logger.Error("Failed to get partial scan result",
+			zap.Error(err),
+			zap.String("scan_id", scanID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, partial)
+}
+
 // ListScans handles the request to list scans
 func (h *ScanHandler) ListScans(c *gin.Context) {
-	// Get user ID from context (set by auth middleware)
-	// For now, use a default user ID
-	userID := c.GetString("user_id")
-	if userID == "" {
-		userID = "default-user" // Will be replaced with actual auth
-	}
+	userID := scanListUserID(c)
 
-	// Parse pagination parameters
+	// Parse pagination parameters. A cursor from a previous page takes precedence over the offset.
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
@@ -149,47 +244,148 @@ func (h *ScanHandler) ListScans(c *gin.Context) {
 		offset = 0
 	}
 
-	scans, err := h.scanService.ListScans(userID, limit, offset)
+	filter, order, err := parseScanListQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	filter.UserID = userID
+
+	page, err := h.scanService.ListScans(c.Request.Context(), filter, order, domain.PageRequest{
+		Limit:  limit,
+		Offset: offset,
+		Cursor: c.Query("cursor"),
+	})
 	if err != nil {
 		h.logger.Error("Failed to list scans",
 			zap.Error(err),
 			zap.String("user_id", userID),
 		)
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to list scans: " + err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"scans":  scans,
-		"limit":  limit,
-		"offset": offset,
-		"count":  len(scans),
+		"scans":       page.Scans,
+		"limit":       limit,
+		"offset":      offset,
+		"count":       len(page.Scans),
+		"total_count": page.TotalCount,
+		"has_more":    page.HasMore,
+		"next_cursor": page.NextCursor,
 	})
 }
 
+// scanListUserID returns the owner of the listed scans: the caller (set by the auth
+// middleware), or for admins another user with ?user_id= or all users with ?all=true
+func scanListUserID(c *gin.Context) string {
+	userID := c.GetString("user_id")
+	if queryUserID := c.Query("user_id"); queryUserID != "" {
+		userID = queryUserID
+	}
+	if c.Query("all") == "true" {
+		userID = ""
+	}
+	return userID
+}
+
+// CountScans handles the request to count the scans matching the filters of ListScans
+func (h *ScanHandler) CountScans(c *gin.Context) {
+	filter, err := parseScanFilter(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	filter.UserID = scanListUserID(c)
+
+	count, err := h.scanService.CountScans(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
+
+// parseScanListQuery parses the filter and sort query parameters of scan listings
+func parseScanListQuery(c *gin.Context) (domain.ScanFilter, domain.ScanSort, error) {
+	filter, err := parseScanFilter(c)
+	if err != nil {
+		return filter, domain.ScanSort{}, err
+	}
+
+	order, err := domain.ParseScanSort(c.Query("sort"), c.Query("order"))
+	if err != nil {
+		return filter, order, err
+	}
+
+	return filter, order, nil
+}
+
+// parseScanFilter parses the status, target, tag, created range, parent and trash
+// filters of a scan listing
+func parseScanFilter(c *gin.Context) (domain.ScanFilter, error) {
+	filter := domain.ScanFilter{
+		Target:   c.Query("target"),
+		Tags:     queryList(c, "tag"),
+		ParentID: c.Query("parent_id"),
+		Deleted:  c.Query("deleted") == "true",
+	}
+
+	for _, value := range queryList(c, "status") {
+		status, ok := domain.ParseScanStatus(value)
+		if !ok {
+			return filter, errors.NewInvalidInput("invalid status: "+value, nil)
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	for name, dest := range map[string]*time.Time{
+		"created_after":  &filter.CreatedAfter,
+		"created_before": &filter.CreatedBefore,
+	} {
+		if value := c.Query(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return filter, errors.NewInvalidInput("invalid "+name+": expected RFC 3339 time", nil)
+			}
+			*dest = parsed
+		}
+	}
+
+	return filter, nil
+}
+
+// queryList returns the values of a query parameter given repeatedly or separated by commas
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, param := range c.QueryArray(name) {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
 // CancelScan handles the request to cancel a scan
 func (h *ScanHandler) CancelScan(c *gin.Context) {
 	scanID := c.Param("id")
 	if scanID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Scan ID is required",
-		})
+		c.Error(errors.NewInvalidInput("scan ID is required", nil))
 		return
 	}
 
-	err := h.scanService.CancelScan(scanID)
+	err := h.scanService.CancelScan(c.Request.Context(), scanID)
 	if err != nil {
 		h.logger.Error("Failed to cancel scan",
 			zap.Error(err),
 			zap.String("scan_id", scanID),
 		)
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to cancel scan: " + err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -201,74 +397,249 @@ func (h *ScanHandler) CancelScan(c *gin.Context) {
 	})
 }
 
+// ResumeScan handles the request to resume a failed or cancelled scan
+func (h *ScanHandler) ResumeScan(c *gin.Context) {
+	scanID := c.Param("id")
+	if scanID == "" {
+		c.Error(errors.NewInvalidInput("scan ID is required", nil))
+		return
+	}
+
+	scan, err := h.scanService.ResumeScan(c.Request.Context(), scanID)
+	if err != nil {
+		h.logger.Error("Failed to resume scan",
+			zap.Error(err),
+			zap.String("scan_id", scanID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Scan resumed", zap.String("scan_id", scan.ID))
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Scan resumed",
+		"scan_id": scan.ID,
+	})
+}
+
+// TrashScan handles the request to move a scan to the trash
+func (h *ScanHandler) TrashScan(c *gin.Context) {
+	scanID := c.Param("id")
+	if scanID == "" {
+		c.Error(errors.NewInvalidInput("scan ID is required", nil))
+		return
+	}
+
+	scan, err := h.scanService.TrashScan(c.Request.Context(), scanID)
+	if err != nil {
+		h.logger.Error("Failed to delete scan",
+			zap.Error(err),
+			zap.String("scan_id", scanID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Scan moved to trash", zap.String("scan_id", scan.ID))
+
+	c.JSON(http.StatusOK, scan)
+}
+
+// RestoreScan handles the request to restore a scan from the trash
+func (h *ScanHandler) RestoreScan(c *gin.Context) {
+	scanID := c.Param("id")
+	if scanID == "" {
+		c.Error(errors.NewInvalidInput("scan ID is required", nil))
+		return
+	}
+
+	scan, err := h.scanService.RestoreScan(c.Request.Context(), scanID)
+	if err != nil {
+		h.logger.Error("Failed to restore scan",
+			zap.Error(err),
+			zap.String("scan_id", scanID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Scan restored from trash", zap.String("scan_id", scan.ID))
+
+	c.JSON(http.StatusOK, scan)
+}
+
+// PurgeScan handles the request to permanently delete a scan in the trash and its results
+func (h *ScanHandler) PurgeScan(c *gin.Context) {
+	scanID := c.Param("id")
+	if scanID == "" {
+		c.Error(errors.NewInvalidInput("scan ID is required", nil))
+		return
+	}
+
+	if err := h.scanService.PurgeScan(c.Request.Context(), scanID); err != nil {
+		h.logger.Error("Failed to purge scan",
+			zap.Error(err),
+			zap.String("scan_id", scanID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Scan purged", zap.String("scan_id", scanID))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scan purged",
+		"scan_id": scanID,
+	})
+}
+
 // GetScanResult handles the request to get a scan result
 func (h *ScanHandler) GetScanResult(c *gin.Context) {
 	resultID := c.Param("id")
 	if resultID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Result ID is required",
-		})
+		c.Error(errors.NewInvalidInput("result ID is required", nil))
 		return
 	}
 
-	result, err := h.scanService.GetScanResult(resultID)
+	result, err := h.scanService.GetScanResult(c.Request.Context(), resultID)
 	if err != nil {
 		h.logger.Error("Failed to get scan result",
 			zap.Error(err),
 			zap.String("result_id", resultID),
 		)
 
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Failed to get scan result: " + err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	jsonConditional(c, result)
 }
 
-// GetHealth handles the health check endpoint
-func (h *ScanHandler) GetHealth(c *gin.Context) {
-	// Check nmap installation
-	err := h.scanService.ValidateNmap()
-	if err != nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"status": "unhealthy",
-			"error":  "Nmap is not available: " + err.Error(),
-		})
+// DeleteScanResult handles the request to delete a scan result
+func (h *ScanHandler) DeleteScanResult(c *gin.Context) {
+	resultID := c.Param("id")
+	if resultID == "" {
+		c.Error(errors.NewInvalidInput("result ID is required", nil))
 		return
 	}
 
-	// Get nmap version
-	version, err := h.scanService.GetNmapVersion()
-	if err != nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"status": "unhealthy",
-			"error":  "Failed to get nmap version: " + err.Error(),
-		})
+	if err := h.scanService.DeleteScanResult(c.Request.Context(), resultID); err != nil {
+		h.logger.Error("Failed to delete scan result",
+			zap.Error(err),
+			zap.String("result_id", resultID),
+		)
+
+		c.Error(err)
 		return
 	}
 
+	h.logger.WithContext(c.Request.Context()).Info("Scan result deleted", zap.String("result_id", resultID))
+
 	c.JSON(http.StatusOK, gin.H{
-		"status":       "healthy",
-		"nmap_version": version,
-		"timestamp":    time.Now().Format(time.RFC3339),
+		"message":   "Scan result deleted",
+		"result_id": resultID,
 	})
 }
 
-// RegisterRoutes registers the scan handler routes to the router
-func (h *ScanHandler) RegisterRoutes(router *gin.Engine) {
-	api := router.Group("/api/v1")
+// GetHealth handles the health check endpoint. It reports the status of every
+// dependency and answers 503 while the service is unhealthy or draining.
+func (h *ScanHandler) GetHealth(c *gin.Context) {
+	report := h.scanService.CheckHealth(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status == domain.HealthStatusUnhealthy || report.Status == domain.HealthStatusDraining {
+		status = http.StatusServiceUnavailable
+	}
+
+	response := gin.H{
+		"status":         report.Status,
+		"checks":         report.Checks,
+		"started_at":     report.StartedAt.Format(time.RFC3339),
+		"uptime_seconds": report.UptimeSeconds,
+		"active_scans":   report.ActiveScans,
+		"build":          report.Build,
+		"timestamp":      report.Timestamp.Format(time.RFC3339),
+	}
+	if nmap, ok := report.Check("nmap"); ok && nmap.Details != nil {
+		response["nmap_version"] = nmap.Details["version"]
+	}
+
+	c.JSON(status, response)
+}
+
+// RegisterRoutes registers the scan handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *ScanHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1", middleware...)
+
+	viewer := authhandlers.RequireRole(authdomain.RoleViewer)
+	operator := authhandlers.RequireRole(authdomain.RoleOperator)
 
 	// Scan endpoints
-	api.POST("/scans", h.StartScan)
-	api.GET("/scans/:id", h.GetScan)
-	api.GET("/scans", h.ListScans)
-	api.DELETE("/scans/:id", h.CancelScan)
+	api.POST("/scans", operator, h.StartScan)
+	api.POST("/scans/lint", operator, h.LintScan)
+	api.GET("/scans/:id", viewer, h.GetScan)
+	api.GET("/scans/:id/logs", viewer, h.GetScanLogs)
+	api.GET("/scans/:id/partial", viewer, h.GetPartialResult)
+	api.GET("/scans", viewer, h.ListScans)
+	api.GET("/scans/count", viewer, h.CountScans)
+	api.DELETE("/scans/:id", operator, h.CancelScan)
+	api.POST("/scans/:id/resume", operator, h.ResumeScan)
+	api.POST("/scans/:id/trash", operator, h.TrashScan)
+	api.POST("/scans/:id/restore", operator, h.RestoreScan)
+	api.POST("/scans/:id/purge", operator, h.PurgeScan)
+	api.POST("/scans/:id/notes", operator, h.AddScanNote)
+	api.DELETE("/scans/:id/notes/:note_id", operator, h.DeleteScanNote)
 
 	// Scan result endpoints
-	api.GET("/results/:id", h.GetScanResult)
-
-	// Health check endpoint
+	api.GET("/results/:id", viewer, h.GetScanResult)
+	api.GET("/results/:id/hosts", viewer, h.StreamResultHosts)
+	api.GET("/results/:id/export", viewer, h.ExportScanResult)
+	api.DELETE("/results/:id", operator, h.DeleteScanResult)
+	api.POST("/results/:id/hosts/:ip/notes", operator, h.AddHostNote)
+	api.DELETE("/results/:id/hosts/:ip/notes/:note_id", operator, h.DeleteHostNote)
+
+	// Search endpoints
+	api.GET("/search", viewer, h.SearchHosts)
+
+	// Pipeline endpoints
+	api.POST("/pipelines", operator, h.StartPipeline)
+	api.GET("/pipelines", viewer, h.ListPipelines)
+	api.GET("/pipelines/:id", viewer, h.GetPipeline)
+	api.DELETE("/pipelines/:id", operator, h.CancelPipeline)
+
+	// Baseline endpoints
+	api.POST("/baselines", operator, h.CreateBaseline)
+	api.GET("/baselines", viewer, h.ListBaselines)
+	api.GET("/baselines/violations", viewer, h.ListBaselineViolations)
+	api.GET("/baselines/:id", viewer, h.GetBaseline)
+	api.PUT("/baselines/:id", operator, h.UpdateBaseline)
+	api.DELETE("/baselines/:id", operator, h.DeleteBaseline)
+
+	// Dashboard endpoints
+	api.GET("/dashboard/surface", viewer, h.GetAttackSurface)
+	api.GET("/users/:id/activity", viewer, h.GetUserActivity)
+
+	// Capability endpoints
+	api.GET("/capabilities", viewer, h.GetCapabilities)
+
+	// Admin endpoints
+	admin := api.Group("/admin", authhandlers.RequireRole(authdomain.RoleAdmin))
+	admin.GET("/scans", h.AdminListScans)
+	admin.POST("/scans/:id/cancel", h.AdminCancelScan)
+	admin.DELETE("/results", h.AdminPurgeResults)
+	admin.GET("/retention", h.AdminGetRetention)
+	admin.POST("/retention", h.AdminApplyRetention)
+	admin.GET("/stats", h.AdminGetStats)
+	admin.GET("/queue", h.AdminGetQueue)
+	admin.PUT("/limits", h.AdminUpdateLimits)
+
+	// Health check and metrics endpoints
 	router.GET("/health", h.GetHealth)
+	router.GET("/metrics", h.GetMetrics)
 }
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMetrics handles the request to get the metrics of the scan queue and the workers
+// in the Prometheus text format
+func (h *ScanHandler) GetMetrics(c *gin.Context) {
+	stats := h.scanService.QueueStats()
+
+	var b strings.Builder
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n%s %g\n", name, help, name, name, value)
+	}
+
+	fmt.Fprintf(&b, "# HELP nmap_scanner_queue_depth Scans waiting for a worker by priority.\n# TYPE nmap_scanner_queue_depth gauge\n")
+	priorities := make([]string, 0, len(stats.DepthByPriority))
+	for priority := range stats.DepthByPriority {
+		priorities = append(priorities, priority)
+	}
+	slices.Sort(priorities)
+	for _, priority := range priorities {
+		fmt.Fprintf(&b, "nmap_scanner_queue_depth{priority=%q} %d\n", priority, stats.DepthByPriority[priority])
+	}
+	gauge("nmap_scanner_queue_oldest_wait_seconds", "How long the longest waiting scan waits for a worker.", stats.OldestWaitSeconds)
+	gauge("nmap_scanner_queue_capacity", "Scans that may wait beyond one per worker.", float64(stats.QueueCapacity))
+	gauge("nmap_scanner_workers", "Workers running scans.", float64(stats.Workers))
+	gauge("nmap_scanner_workers_busy", "Workers running a scan.", float64(stats.BusyWorkers))
+	gauge("nmap_scanner_worker_utilization", "Share of busy workers, from 0 to 1.", stats.WorkerUtilization)
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+}
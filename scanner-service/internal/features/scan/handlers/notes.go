@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// NoteRequest represents the request body for attaching a note to a scan or host
+type NoteRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// AddScanNote handles the request to attach a note to a scan
+func (h *ScanHandler) AddScanNote(c *gin.Context) {
+	var req NoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	note, err := h.scanService.AddScanNote(c.Request.Context(), c.Param("id"), req.Text)
+	if err != nil {
+		h.logger.Error("Failed to add scan note",
+			zap.Error(err),
+			zap.String("scan_id", c.Param("id")),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// DeleteScanNote handles the request to delete a note of a scan
+func (h *ScanHandler) DeleteScanNote(c *gin.Context) {
+	if err := h.scanService.DeleteScanNote(c.Request.Context(), c.Param("id"), c.Param("note_id")); err != nil {
+		h.logger.Error("Failed to delete scan note",
+			zap.Error(err),
+			zap.String("scan_id", c.Param("id")),
+			zap.String("note_id", c.Param("note_id")),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Note deleted",
+		"note_id": c.Param("note_id"),
+	})
+}
+
+// AddHostNote handles the request to attach an annotation to a host of a scan result
+func (h *ScanHandler) AddHostNote(c *gin.Context) {
+	var req NoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	note, err := h.scanService.AddHostNote(c.Request.Context(), c.Param("id"), c.Param("ip"), req.Text)
+	if err != nil {
+		h.logger.Error("Failed to add host note",
+			zap.Error(err),
+			zap.String("result_id", c.Param("id")),
+			zap.String("host", c.Param("ip")),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// DeleteHostNote handles the request to delete an annotation of a host of a scan result
+func (h *ScanHandler) DeleteHostNote(c *gin.Context) {
+	if err := h.scanService.DeleteHostNote(c.Request.Context(), c.Param("id"), c.Param("ip"), c.Param("note_id")); err != nil {
+		h.logger.Error("Failed to delete host note",
+			zap.Error(err),
+			zap.String("result_id", c.Param("id")),
+			zap.String("host", c.Param("ip")),
+			zap.String("note_id", c.Param("note_id")),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Note deleted",
+		"note_id": c.Param("note_id"),
+	})
+}
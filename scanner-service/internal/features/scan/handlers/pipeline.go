@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PipelineStageRequest represents one stage of a pipeline request
+type PipelineStageRequest struct {
+	Name string `json:"name,omitempty"`
+	ScanOptionsRequest
+	Hosts domain.StageHostFilter `json:"hosts"`
+}
+
+// StartPipelineRequest represents the request body for starting a pipeline
+type StartPipelineRequest struct {
+	Name   string                 `json:"name,omitempty"`
+	Target string                 `json:"target" binding:"required"`
+	Stages []PipelineStageRequest `json:"stages" binding:"required,min=1"`
+}
+
+// StartPipeline handles the request to start a pipeline
+func (h *ScanHandler) StartPipeline(c *gin.Context) {
+	var req StartPipelineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.FromBindError(err))
+		return
+	}
+
+	stages := make([]domain.PipelineStage, 0, len(req.Stages))
+	for _, stage := range req.Stages {
+		stages = append(stages, domain.PipelineStage{
+			Name:    stage.Name,
+			Options: stage.toScanOptions(req.Target),
+			Hosts:   stage.Hosts,
+		})
+	}
+
+	pipeline, err := h.scanService.StartPipeline(c.Request.Context(), c.GetString("user_id"), req.Name, req.Target, stages)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to start pipeline",
+			zap.Error(err),
+			zap.String("target", req.Target),
+		)
+
+		c.Error(errors.RenameFields(err, requestFieldNames))
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Pipeline started",
+		zap.String("pipeline_id", pipeline.ID),
+		zap.String("target", req.Target),
+		zap.Int("stages", len(pipeline.Stages)),
+	)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":     "Pipeline started",
+		"pipeline_id": pipeline.ID,
+	})
+}
+
+// GetPipeline handles the request to get a pipeline with the status of its stages
+func (h *ScanHandler) GetPipeline(c *gin.Context) {
+	pipelineID := c.Param("id")
+
+	pipeline, err := h.scanService.GetPipeline(c.Request.Context(), pipelineID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pipeline)
+}
+
+// ListPipelines handles the request to list pipelines.
+// Admins may list another user's pipelines with ?user_id= or all pipelines with ?all=true.
+func (h *ScanHandler) ListPipelines(c *gin.Context) {
+	userID := listUserID(c)
+
+	pipelines, err := h.scanService.ListPipelines(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to list pipelines",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pipelines": pipelines,
+		"count":     len(pipelines),
+	})
+}
+
+// CancelPipeline handles the request to cancel a pipeline
+func (h *ScanHandler) CancelPipeline(c *gin.Context) {
+	pipelineID := c.Param("id")
+
+	if err := h.scanService.CancelPipeline(c.Request.Context(), pipelineID); err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to cancel pipeline",
+			zap.Error(err),
+			zap.String("pipeline_id", pipelineID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Pipeline cancelled", zap.String("pipeline_id", pipelineID))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Pipeline cancelled",
+		"pipeline_id": pipelineID,
+	})
+}
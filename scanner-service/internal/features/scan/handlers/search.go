@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SearchHosts handles the request to search the hosts of stored scan results.
+// Admins may restrict the search to one user with ?user_id=.
+func (h *ScanHandler) SearchHosts(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		c.Error(errors.NewInvalidInput("query parameter q is required", nil))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit < 1 {
+		limit = 50
+	} else if limit > domain.MaxSearchResults {
+		limit = domain.MaxSearchResults
+	}
+
+	result, err := h.scanService.SearchHosts(c.Request.Context(), query, c.Query("user_id"), limit)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to search scan results",
+			zap.Error(err),
+			zap.String("query", query),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":       query,
+		"hosts":       result.Hosts,
+		"count":       len(result.Hosts),
+		"total_count": result.TotalCount,
+	})
+}
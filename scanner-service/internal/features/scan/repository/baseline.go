@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// SaveBaseline saves a new or changed baseline to the repository
+func (r *MemoryScanRepository) SaveBaseline(baseline *domain.Baseline) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.baselines[baseline.ID] = baseline.Copy()
+
+	r.logger.Debug("Saved baseline",
+		zap.String("baseline_id", baseline.ID),
+		zap.String("user_id", baseline.UserID),
+	)
+
+	return nil
+}
+
+// GetBaselineByID gets a baseline by ID from the repository
+func (r *MemoryScanRepository) GetBaselineByID(id string) (*domain.Baseline, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	baseline, ok := r.baselines[id]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("baseline with ID %s not found", id), nil)
+	}
+
+	return baseline.Copy(), nil
+}
+
+// ListBaselines lists the baselines of a user, or of all users if userID is empty, by name
+func (r *MemoryScanRepository) ListBaselines(userID string) ([]*domain.Baseline, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	baselines := make([]*domain.Baseline, 0)
+	for _, baseline := range r.baselines {
+		if userID == "" || baseline.UserID == userID {
+			baselines = append(baselines, baseline.Copy())
+		}
+	}
+
+	sort.Slice(baselines, func(i, j int) bool {
+		if baselines[i].Name != baselines[j].Name {
+			return baselines[i].Name < baselines[j].Name
+		}
+		return baselines[i].ID < baselines[j].ID
+	})
+
+	return baselines, nil
+}
+
+// DeleteBaseline deletes a baseline from the repository
+func (r *MemoryScanRepository) DeleteBaseline(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.baselines[id]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("baseline with ID %s not found", id), nil)
+	}
+
+	delete(r.baselines, id)
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"go.uber.org/zap"
+)
+
+// AppendPartialHosts adds hosts to the hosts a running scan found so far
+func (r *MemoryScanRepository) AppendPartialHosts(scanID string, hosts []domain.Host) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.partialHosts[scanID] = append(r.partialHosts[scanID], hosts...)
+
+	r.logger.Debug("Stored partial scan hosts",
+		zap.String("scan_id", scanID),
+		zap.Int("hosts", len(hosts)),
+		zap.Int("total_hosts", len(r.partialHosts[scanID])),
+	)
+
+	return nil
+}
+
+// GetPartialHosts returns a copy of the hosts a scan found so far
+func (r *MemoryScanRepository) GetPartialHosts(scanID string) ([]domain.Host, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hosts := make([]domain.Host, len(r.partialHosts[scanID]))
+	copy(hosts, r.partialHosts[scanID])
+	return hosts, nil
+}
+
+// DeletePartialHosts removes the hosts a scan found so far, once its result is stored
+func (r *MemoryScanRepository) DeletePartialHosts(scanID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.partialHosts, scanID)
+	return nil
+}
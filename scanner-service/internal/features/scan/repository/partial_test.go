@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPartialHosts(t *testing.T) {
+	repo := NewMemoryScanRepository(&logger.Logger{Logger: zap.NewNop()}, time.Hour)
+	require.NoError(t, repo.SaveScan(&domain.Scan{ID: "scan-1"}))
+
+	require.NoError(t, repo.AppendPartialHosts("scan-1", []domain.Host{{IP: "10.0.0.1"}}))
+	require.NoError(t, repo.AppendPartialHosts("scan-1", []domain.Host{{IP: "10.0.0.2"}}))
+
+	// Callers get a copy
+	hosts, err := repo.GetPartialHosts("scan-1")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Host{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}}, hosts)
+	hosts[0].IP = "changed"
+	hosts, _ = repo.GetPartialHosts("scan-1")
+	assert.Equal(t, "10.0.0.1", hosts[0].IP)
+
+	// Hosts are removed with their scan
+	require.NoError(t, repo.DeleteScan("scan-1"))
+	hosts, err = repo.GetPartialHosts("scan-1")
+	require.NoError(t, err)
+	assert.Empty(t, hosts)
+
+	// Scans without hosts have none
+	require.NoError(t, repo.DeletePartialHosts("scan-2"))
+	hosts, _ = repo.GetPartialHosts("scan-2")
+	assert.Empty(t, hosts)
+}
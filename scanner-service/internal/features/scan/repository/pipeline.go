@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// SavePipeline saves a pipeline to the repository
+func (r *MemoryScanRepository) SavePipeline(pipeline *domain.Pipeline) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pipelines[pipeline.ID] = pipeline.Copy()
+
+	r.logger.Debug("Saved pipeline",
+		zap.String("pipeline_id", pipeline.ID),
+		zap.String("user_id", pipeline.UserID),
+	)
+
+	return nil
+}
+
+// UpdatePipeline updates a pipeline in the repository
+func (r *MemoryScanRepository) UpdatePipeline(pipeline *domain.Pipeline) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pipelines[pipeline.ID]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("pipeline with ID %s not found", pipeline.ID), nil)
+	}
+
+	r.pipelines[pipeline.ID] = pipeline.Copy()
+
+	return nil
+}
+
+// GetPipelineByID gets a pipeline by ID from the repository
+func (r *MemoryScanRepository) GetPipelineByID(id string) (*domain.Pipeline, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pipeline, ok := r.pipelines[id]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("pipeline with ID %s not found", id), nil)
+	}
+
+	return pipeline.Copy(), nil
+}
+
+// ListPipelines lists the pipelines of a user, or of all users if userID is empty, newest first
+func (r *MemoryScanRepository) ListPipelines(userID string) ([]*domain.Pipeline, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pipelines := make([]*domain.Pipeline, 0)
+	for _, pipeline := range r.pipelines {
+		if userID == "" || pipeline.UserID == userID {
+			pipelines = append(pipelines, pipeline.Copy())
+		}
+	}
+
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].CreatedAt.After(pipelines[j].CreatedAt)
+	})
+
+	return pipelines, nil
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,9 +17,15 @@ import (
 type MemoryScanRepository struct {
 	logger          *logger.Logger
 	scans           map[string]*domain.Scan
-	scanResults     map[string]*domain.ScanResult
+	scanResults     map[string]*storedResult
+	pipelines       map[string]*domain.Pipeline
+	baselines       map[string]*domain.Baseline
+	partialHosts    map[string][]domain.Host // Scan ID -> hosts of the running scan completed so far
 	mu              sync.RWMutex
 	retentionPeriod time.Duration
+	userRetention   map[string]time.Duration
+	tenantRetention map[string]time.Duration
+	compressResults bool
 }
 
 // NewMemoryScanRepository creates a new MemoryScanRepository
@@ -25,7 +33,10 @@ func NewMemoryScanRepository(logger *logger.Logger, retentionPeriod time.Duratio
 	repo := &MemoryScanRepository{
 		logger:          logger,
 		scans:           make(map[string]*domain.Scan),
-		scanResults:     make(map[string]*domain.ScanResult),
+		scanResults:     make(map[string]*storedResult),
+		pipelines:       make(map[string]*domain.Pipeline),
+		baselines:       make(map[string]*domain.Baseline),
+		partialHosts:    make(map[string][]domain.Host),
 		retentionPeriod: retentionPeriod,
 	}
 
@@ -35,6 +46,28 @@ func NewMemoryScanRepository(logger *logger.Logger, retentionPeriod time.Duratio
 	return repo
 }
 
+// SetRetentionOverrides sets retention periods that replace the global one for the
+// scans of the given users and tenants. A user override takes precedence over the
+// override of the user's tenant.
+func (r *MemoryScanRepository) SetRetentionOverrides(users, tenants map[string]time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.userRetention = users
+	r.tenantRetention = tenants
+}
+
+// retentionFor returns the retention period of a scan
+func (r *MemoryScanRepository) retentionFor(scan *domain.Scan) time.Duration {
+	if retention, ok := r.userRetention[scan.UserID]; ok {
+		return retention
+	}
+	if retention, ok := r.tenantRetention[scan.TenantID]; ok && scan.TenantID != "" {
+		return retention
+	}
+	return r.retentionPeriod
+}
+
 // SaveScan saves a scan to the repository
 func (r *MemoryScanRepository) SaveScan(scan *domain.Scan) error {
 	r.mu.Lock()
@@ -88,44 +121,75 @@ func (r *MemoryScanRepository) GetScanByID(id string) (*domain.Scan, error) {
 	return &scanCopy, nil
 }
 
-// ListScans lists scans from the repository
-func (r *MemoryScanRepository) ListScans(userID string, limit, offset int) ([]*domain.Scan, error) {
+// CountScans counts the scans matching the filter
+func (r *MemoryScanRepository) CountScans(filter domain.ScanFilter) (int, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var scans []*domain.Scan
-
-	// Filter by user ID if provided
+	count := 0
 	for _, scan := range r.scans {
-		if userID == "" || scan.UserID == userID {
-			// Make a copy to avoid modifying the original
-			scanCopy := *scan
-			scans = append(scans, &scanCopy)
+		if filter.Matches(scan) {
+			count++
 		}
 	}
+	return count, nil
+}
 
-	// Sort by created at (newest first)
-	// In a real implementation, you would use a database query with ORDER BY
-	// This is just a simple implementation for the in-memory repository
-	for i := 0; i < len(scans)-1; i++ {
-		for j := i + 1; j < len(scans); j++ {
-			if scans[i].CreatedAt.Before(scans[j].CreatedAt) {
-				scans[i], scans[j] = scans[j], scans[i]
-			}
+// ListScans lists one page of the scans matching the filter in the given order
+func (r *MemoryScanRepository) ListScans(filter domain.ScanFilter, order domain.ScanSort, page domain.PageRequest) (*domain.ScanPage, error) {
+	var cursor *domain.ScanCursor
+	if page.Cursor != "" {
+		decoded, err := domain.DecodeScanCursor(page.Cursor, order)
+		if err != nil {
+			return nil, err
 		}
+		cursor = &decoded
 	}
 
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var scans []*domain.Scan
+
+	for _, scan := range r.scans {
+		if filter.Matches(scan) {
+			scans = append(scans, scan)
+		}
+	}
+
+	slices.SortFunc(scans, order.Compare)
+
+	result := &domain.ScanPage{TotalCount: len(scans)}
+
 	// Apply pagination
-	if offset >= len(scans) {
-		return []*domain.Scan{}, nil
+	start := page.Offset
+	if cursor != nil {
+		start = sort.Search(len(scans), func(i int) bool {
+			return cursor.After(scans[i])
+		})
+	}
+	if start > len(scans) {
+		start = len(scans)
 	}
 
-	end := offset + limit
+	end := start + page.Limit
 	if end > len(scans) {
 		end = len(scans)
 	}
 
-	return scans[offset:end], nil
+	result.Scans = make([]*domain.Scan, 0, end-start)
+	for _, scan := range scans[start:end] {
+		// Make a copy to avoid modifying the original
+		scanCopy := *scan
+		result.Scans = append(result.Scans, &scanCopy)
+	}
+
+	result.HasMore = end < len(scans)
+	if result.HasMore && end > 0 {
+		result.NextCursor = domain.NewScanCursor(scans[end-1], order).Encode()
+	}
+
+	return result, nil
 }
 
 // DeleteScan deletes a scan from the repository
@@ -138,6 +202,7 @@ func (r *MemoryScanRepository) DeleteScan(id string) error {
 	}
 
 	delete(r.scans, id)
+	delete(r.partialHosts, id)
 
 	r.logger.Debug("Deleted scan", zap.String("scan_id", id))
 
@@ -149,13 +214,17 @@ func (r *MemoryScanRepository) SaveScanResult(result *domain.ScanResult) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Make a deep copy to avoid modifying the original
-	resultCopy := *result
-	r.scanResults[result.ID] = &resultCopy
+	// Store a copy to avoid modifying the original
+	stored, err := newStoredResult(result, r.compressResults)
+	if err != nil {
+		return errors.NewInternal("failed to store scan result", err)
+	}
+	r.scanResults[result.ID] = stored
 
 	r.logger.Debug("Saved scan result",
 		zap.String("result_id", result.ID),
 		zap.String("scan_id", result.ScanID),
+		zap.Int("compressed_hosts_bytes", len(stored.compressedHosts)),
 	)
 
 	return nil
@@ -172,26 +241,168 @@ func (r *MemoryScanRepository) GetScanResultByID(id string) (*domain.ScanResult,
 	}
 
 	// Return a copy to avoid modifying the original
-	resultCopy := *result
-	return &resultCopy, nil
+	resultCopy, err := result.result()
+	if err != nil {
+		return nil, errors.NewInternal("failed to load scan result", err)
+	}
+	return resultCopy, nil
 }
 
-// DeleteScanResult deletes a scan result from the repository
+// DeleteScanResult deletes a scan result from the repository and detaches it from its scan
 func (r *MemoryScanRepository) DeleteScanResult(id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, ok := r.scanResults[id]; !ok {
+	result, ok := r.scanResults[id]
+	if !ok {
 		return errors.NewNotFound(fmt.Sprintf("scan result with ID %s not found", id), nil)
 	}
 
 	delete(r.scanResults, id)
 
+	if scan, ok := r.scans[result.ScanID]; ok && scan.ResultID == id {
+		scan.ResultID = ""
+	}
+
 	r.logger.Debug("Deleted scan result", zap.String("result_id", id))
 
 	return nil
 }
 
+// SearchHosts returns the hosts of stored results matching all query terms,
+// most recent results first. Results of scans in the trash are left out.
+func (r *MemoryScanRepository) SearchHosts(query domain.HostQuery) (*domain.HostSearchResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []*domain.HostMatch
+	for _, result := range r.scanResults {
+		if query.UserID != "" && result.UserID != query.UserID {
+			continue
+		}
+		if r.trashed(result.ScanID) {
+			continue
+		}
+
+		hosts, err := result.hosts()
+		if err != nil {
+			return nil, errors.NewInternal("failed to load scan result", err)
+		}
+		for _, host := range hosts {
+			fields, ok := domain.MatchHost(host, query.Terms)
+			if !ok {
+				continue
+			}
+
+			matches = append(matches, &domain.HostMatch{
+				ScanID:   result.ScanID,
+				ResultID: result.ID,
+				UserID:   result.UserID,
+				ScanTime: result.EndTime,
+				Host:     host,
+				Fields:   fields,
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if !matches[i].ScanTime.Equal(matches[j].ScanTime) {
+			return matches[i].ScanTime.After(matches[j].ScanTime)
+		}
+		if matches[i].ResultID != matches[j].ResultID {
+			return matches[i].ResultID < matches[j].ResultID
+		}
+		return matches[i].Host.IP < matches[j].Host.IP
+	})
+
+	searchResult := &domain.HostSearchResult{TotalCount: len(matches)}
+	if len(matches) > query.Limit {
+		matches = matches[:query.Limit]
+	}
+	searchResult.Hosts = matches
+
+	return searchResult, nil
+}
+
+// AggregateSurface aggregates the open ports of the stored results of a user,
+// or of all users if userID is empty. Results of scans in the trash are left out.
+func (r *MemoryScanRepository) AggregateSurface(userID string, limit int) (*domain.AttackSurface, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	aggregator := domain.NewSurfaceAggregator()
+	for _, stored := range r.scanResults {
+		if userID != "" && stored.UserID != userID {
+			continue
+		}
+		if r.trashed(stored.ScanID) {
+			continue
+		}
+		result, err := stored.result()
+		if err != nil {
+			return nil, errors.NewInternal("failed to load scan result", err)
+		}
+		aggregator.Add(result)
+	}
+
+	return aggregator.Surface(limit), nil
+}
+
+// trashed reports whether the scan of a result is in the trash. The caller must hold r.mu.
+func (r *MemoryScanRepository) trashed(scanID string) bool {
+	scan, ok := r.scans[scanID]
+	return ok && scan.DeletedAt != nil
+}
+
+// PurgeScanResults deletes all scan results that ended before the given time
+// and detaches them from their scans
+func (r *MemoryScanRepository) PurgeScanResults(before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := 0
+	for id, result := range r.scanResults {
+		if !result.EndTime.Before(before) {
+			continue
+		}
+
+		delete(r.scanResults, id)
+		purged++
+
+		if scan, ok := r.scans[result.ScanID]; ok && scan.ResultID == id {
+			scan.ResultID = ""
+		}
+	}
+
+	r.logger.Debug("Purged scan results", zap.Int("count", purged))
+
+	return purged, nil
+}
+
+// ListExpiredScanResults lists the scan results that ended before the given time
+// without their hosts, oldest first
+func (r *MemoryScanRepository) ListExpiredScanResults(before time.Time) ([]*domain.ScanResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var results []*domain.ScanResult
+	for _, stored := range r.scanResults {
+		if !stored.EndTime.Before(before) {
+			continue
+		}
+
+		result := stored.ScanResult
+		result.Hosts = nil
+		results = append(results, &result)
+	}
+
+	slices.SortFunc(results, func(a, b *domain.ScanResult) int {
+		return a.EndTime.Compare(b.EndTime)
+	})
+
+	return results, nil
+}
+
 // cleanupOldScans periodically removes old scans and results
 func (r *MemoryScanRepository) cleanupOldScans() {
 	ticker := time.NewTicker(6 * time.Hour) // Run cleanup every 6 hours
@@ -200,13 +411,15 @@ func (r *MemoryScanRepository) cleanupOldScans() {
 	for range ticker.C {
 		r.mu.Lock()
 
-		cutoffTime := time.Now().Add(-r.retentionPeriod)
+		now := time.Now()
+		cutoffTime := now.Add(-r.retentionPeriod)
 
-		// Clean up old scans
+		// Clean up old scans, honoring user and tenant retention overrides
 		for id, scan := range r.scans {
-			if scan.CreatedAt.Before(cutoffTime) {
+			if scan.CreatedAt.Before(now.Add(-r.retentionFor(scan))) {
 				// Delete scan
 				delete(r.scans, id)
+				delete(r.partialHosts, id)
 
 				// Delete associated result if exists
 				if scan.ResultID != "" {
@@ -220,6 +433,13 @@ func (r *MemoryScanRepository) cleanupOldScans() {
 			}
 		}
 
+		// Clean up old pipelines, their stage scans expire on their own
+		for id, pipeline := range r.pipelines {
+			if pipeline.CreatedAt.Before(cutoffTime) {
+				delete(r.pipelines, id)
+			}
+		}
+
 		// Clean up orphaned results (results without a scan)
 		for resultID, result := range r.scanResults {
 			if result.ScanID != "" {
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+)
+
+// storedResult is a scan result as kept by the repository. With compression enabled
+// the hosts, which make up most of a result, are kept as gzip-compressed JSON apart
+// from the summary fields and Hosts is nil.
+type storedResult struct {
+	domain.ScanResult
+	compressedHosts []byte
+}
+
+// SetResultCompression sets whether the hosts of scan results saved from now on are
+// stored compressed. Stored results keep their format.
+func (r *MemoryScanRepository) SetResultCompression(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.compressResults = enabled
+}
+
+// newStoredResult copies a result for storage, compressing its hosts if enabled
+func newStoredResult(result *domain.ScanResult, compress bool) (*storedResult, error) {
+	stored := &storedResult{ScanResult: *result}
+	if !compress || len(result.Hosts) == 0 {
+		return stored, nil
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(result.Hosts); err != nil {
+		return nil, fmt.Errorf("failed to encode hosts: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress hosts: %w", err)
+	}
+
+	stored.Hosts = nil
+	stored.compressedHosts = buf.Bytes()
+	return stored, nil
+}
+
+// hosts returns the hosts of the result, decompressing them if needed
+func (s *storedResult) hosts() ([]domain.Host, error) {
+	if s.compressedHosts == nil {
+		return s.Hosts, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(s.compressedHosts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress hosts of result %s: %w", s.ID, err)
+	}
+	defer reader.Close()
+
+	var hosts []domain.Host
+	if err := json.NewDecoder(reader).Decode(&hosts); err != nil {
+		return nil, fmt.Errorf("failed to decode hosts of result %s: %w", s.ID, err)
+	}
+	return hosts, nil
+}
+
+// result returns a copy of the whole result
+func (s *storedResult) result() (*domain.ScanResult, error) {
+	hosts, err := s.hosts()
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.ScanResult
+	result.Hosts = hosts
+	return &result, nil
+}
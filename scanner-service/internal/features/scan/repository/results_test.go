@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCompressedResults(t *testing.T) {
+	repo := NewMemoryScanRepository(&logger.Logger{Logger: zap.NewNop()}, time.Hour)
+	repo.SetResultCompression(true)
+
+	endTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	result := &domain.ScanResult{ID: "result-1", ScanID: "scan-1", UserID: "alice", EndTime: endTime, TotalHosts: 100}
+	for i := range 100 {
+		result.Hosts = append(result.Hosts, domain.Host{
+			IP:     fmt.Sprintf("10.0.0.%d", i),
+			Status: "up",
+			Ports: []domain.Port{
+				{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH", Version: "8.9p1"},
+				{Port: 443, Protocol: "tcp", State: "open", Service: "https", Product: "nginx"},
+			},
+			Notes: []domain.Note{{ID: "note-1", UserID: "alice", Text: "owned by the web team", CreatedAt: endTime}},
+		})
+	}
+	require.NoError(t, repo.SaveScanResult(result))
+
+	stored := repo.scanResults["result-1"]
+	assert.Nil(t, stored.Hosts)
+	assert.NotEmpty(t, stored.compressedHosts)
+	assert.Equal(t, "alice", stored.UserID)
+
+	loaded, err := repo.GetScanResultByID("result-1")
+	require.NoError(t, err)
+	assert.Equal(t, result, loaded)
+
+	// Search and aggregation read the compressed hosts
+	search, err := repo.SearchHosts(domain.HostQuery{Terms: []string{"openssh"}, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 100, search.TotalCount)
+
+	surface, err := repo.AggregateSurface("alice", 10)
+	require.NoError(t, err)
+	assert.NotEmpty(t, surface.TopPorts)
+
+	// Results saved without compression keep their hosts as they are
+	repo.SetResultCompression(false)
+	require.NoError(t, repo.SaveScanResult(result))
+	assert.Len(t, repo.scanResults["result-1"].Hosts, 100)
+	assert.Nil(t, repo.scanResults["result-1"].compressedHosts)
+}
+
+func TestTrashedScansAreHiddenFromSearchAndSurface(t *testing.T) {
+	repo := NewMemoryScanRepository(&logger.Logger{Logger: zap.NewNop()}, time.Hour)
+
+	endTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, id := range []string{"1", "2"} {
+		require.NoError(t, repo.SaveScan(&domain.Scan{ID: "scan-" + id, UserID: "alice", Status: domain.ScanStatusCompleted, ResultID: "result-" + id}))
+		require.NoError(t, repo.SaveScanResult(&domain.ScanResult{ID: "result-" + id, ScanID: "scan-" + id, UserID: "alice", EndTime: endTime, Hosts: []domain.Host{{
+			IP:     "10.0.0." + id,
+			Status: "up",
+			Ports:  []domain.Port{{Port: 22, Protocol: "tcp", State: "open", Service: "ssh", Product: "OpenSSH"}},
+		}}}))
+	}
+
+	scan, err := repo.GetScanByID("scan-2")
+	require.NoError(t, err)
+	deletedAt := endTime.Add(time.Hour)
+	scan.DeletedAt = &deletedAt
+	require.NoError(t, repo.UpdateScan(scan))
+
+	search, err := repo.SearchHosts(domain.HostQuery{Terms: []string{"openssh"}, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, search.TotalCount)
+	require.Len(t, search.Hosts, 1)
+	assert.Equal(t, "scan-1", search.Hosts[0].ScanID)
+
+	surface, err := repo.AggregateSurface("", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, surface.Results)
+	assert.Equal(t, 1, surface.Hosts)
+
+	// Restored scans are found again
+	scan.DeletedAt = nil
+	require.NoError(t, repo.UpdateScan(scan))
+
+	search, err = repo.SearchHosts(domain.HostQuery{Terms: []string{"openssh"}, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, search.TotalCount)
+
+	surface, err = repo.AggregateSurface("alice", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, surface.Results)
+	assert.Equal(t, 2, surface.Hosts)
+}
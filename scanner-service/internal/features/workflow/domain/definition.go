@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// MaxWorkflowSteps is the maximum number of steps of a workflow
+const MaxWorkflowSteps = 20
+
+// defaultStepTimeout is the scan timeout of steps that do not set one
+const defaultStepTimeout = 5 * time.Minute
+
+// Definition describes the steps of a workflow and when it runs
+type Definition struct {
+	Name     string `json:"name" yaml:"name"`                             // Workflow name
+	Target   string `json:"target" yaml:"target"`                         // Target of the steps without dependencies
+	Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty"` // Cron expression for scheduled runs
+	Steps    []Step `json:"steps" yaml:"steps"`                           // Steps of the workflow
+}
+
+// Step is a scan of a workflow. A step without dependencies scans the workflow target;
+// other steps scan the hosts found by their dependencies that match the When condition.
+type Step struct {
+	ID    string                      `json:"id" yaml:"id"`                           // Unique step ID
+	Needs []string                    `json:"needs,omitempty" yaml:"needs,omitempty"` // Steps whose hosts are scanned
+	When  *scandomain.StageHostFilter `json:"when,omitempty" yaml:"when,omitempty"`   // Condition on the hosts of the dependencies
+	Scan  StepScan                    `json:"scan" yaml:"scan"`                       // Scan options
+}
+
+// StepScan represents the scan options of a step
+type StepScan struct {
+	Ports              string                       `json:"ports,omitempty" yaml:"ports,omitempty"`
+	ScanType           scandomain.ScanType          `json:"scan_type,omitempty" yaml:"scan_type,omitempty"`
+	TimingTemplate     scandomain.TimingTemplate    `json:"timing_template,omitempty" yaml:"timing_template,omitempty"`
+	ServiceDetection   bool                         `json:"service_detection,omitempty" yaml:"service_detection,omitempty"`
+	OSDetection        bool                         `json:"os_detection,omitempty" yaml:"os_detection,omitempty"`
+	ScriptScan         bool                         `json:"script_scan,omitempty" yaml:"script_scan,omitempty"`
+	ExtraOptions       []string                     `json:"extra_options,omitempty" yaml:"extra_options,omitempty"`
+	TimeoutSeconds     int                          `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"`
+	HostTimeoutSeconds int                          `json:"host_timeout_seconds,omitempty" yaml:"host_timeout_seconds,omitempty"`
+	MinRate            int                          `json:"min_rate,omitempty" yaml:"min_rate,omitempty"`
+	MaxRate            int                          `json:"max_rate,omitempty" yaml:"max_rate,omitempty"`
+	MaxParallelism     int                          `json:"max_parallelism,omitempty" yaml:"max_parallelism,omitempty"`
+	MaxRetries         *int                         `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
+	Decoys             []string                     `json:"decoys,omitempty" yaml:"decoys,omitempty"`
+	SourcePort         int                          `json:"source_port,omitempty" yaml:"source_port,omitempty"`
+	Fragment           bool                         `json:"fragment,omitempty" yaml:"fragment,omitempty"`
+	DataLength         int                          `json:"data_length,omitempty" yaml:"data_length,omitempty"`
+	Discovery          []scandomain.DiscoveryMethod `json:"discovery,omitempty" yaml:"discovery,omitempty"`
+	Engine             scandomain.ScanEngine        `json:"engine,omitempty" yaml:"engine,omitempty"`
+	Mode               scandomain.ScanMode          `json:"mode,omitempty" yaml:"mode,omitempty"`
+}
+
+// Options returns the scan options of the step for the target
+func (s StepScan) Options(target string) scandomain.ScanOptions {
+	options := scandomain.ScanOptions{
+		Target:           target,
+		Ports:            s.Ports,
+		ScanType:         s.ScanType,
+		TimingTemplate:   s.TimingTemplate,
+		ServiceDetection: s.ServiceDetection,
+		OSDetection:      s.OSDetection,
+		ScriptScan:       s.ScriptScan,
+		ExtraOptions:     s.ExtraOptions,
+		Timeout:          defaultStepTimeout,
+		HostTimeout:      time.Duration(s.HostTimeoutSeconds) * time.Second,
+		MinRate:          s.MinRate,
+		MaxRate:          s.MaxRate,
+		MaxParallelism:   s.MaxParallelism,
+		MaxRetries:       s.MaxRetries,
+		Decoys:           s.Decoys,
+		SourcePort:       s.SourcePort,
+		Fragment:         s.Fragment,
+		DataLength:       s.DataLength,
+		Discovery:        s.Discovery,
+		Engine:           s.Engine,
+		Mode:             s.Mode,
+	}
+	if s.TimeoutSeconds > 0 {
+		options.Timeout = time.Duration(s.TimeoutSeconds) * time.Second
+	}
+	return options
+}
+
+// ParseDefinition parses a JSON or YAML workflow definition and validates its structure.
+// Unknown fields are rejected so that misspelled conditions are not silently ignored.
+func ParseDefinition(data []byte) (*Definition, error) {
+	var definition Definition
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&definition); err != nil {
+			return nil, errors.NewInvalidInput("invalid workflow definition: "+err.Error(), err)
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&definition); err != nil {
+			return nil, errors.NewInvalidInput("invalid workflow definition: "+err.Error(), err)
+		}
+	}
+
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &definition, nil
+}
+
+// Validate checks the structure of the definition: step IDs, dependencies and schedule.
+// Scan options are checked by the workflow service against the caller's policies.
+func (d *Definition) Validate() error {
+	if d.Target == "" {
+		return errors.NewInvalidInput("target is required", nil)
+	}
+	if len(d.Steps) == 0 || len(d.Steps) > MaxWorkflowSteps {
+		return errors.NewInvalidInput(fmt.Sprintf("a workflow must have between 1 and %d steps", MaxWorkflowSteps), nil)
+	}
+
+	if d.Schedule != "" {
+		if _, err := cron.ParseStandard(d.Schedule); err != nil {
+			return errors.NewInvalidInput("invalid schedule: "+err.Error(), err)
+		}
+	}
+
+	ids := make(map[string]bool, len(d.Steps))
+	for _, step := range d.Steps {
+		if step.ID == "" {
+			return errors.NewInvalidInput("every step needs an id", nil)
+		}
+		if ids[step.ID] {
+			return errors.NewInvalidInput(fmt.Sprintf("duplicate step id %q", step.ID), nil)
+		}
+		ids[step.ID] = true
+	}
+
+	for _, step := range d.Steps {
+		for _, need := range step.Needs {
+			if !ids[need] {
+				return errors.NewInvalidInput(fmt.Sprintf("step %q needs unknown step %q", step.ID, need), nil)
+			}
+		}
+		if len(step.Needs) == 0 && step.When != nil {
+			return errors.NewInvalidInput(fmt.Sprintf("step %q has a condition but no dependencies", step.ID), nil)
+		}
+		if len(step.Needs) > 0 && len(step.Scan.Discovery) > 0 {
+			return errors.NewInvalidInput(fmt.Sprintf("step %q: target discovery is only supported in steps without dependencies", step.ID), nil)
+		}
+	}
+
+	if _, err := d.order(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// order returns the step indexes sorted so that every step comes after the steps it needs
+func (d *Definition) order() ([]int, error) {
+	index := make(map[string]int, len(d.Steps))
+	for i, step := range d.Steps {
+		index[step.ID] = i
+	}
+
+	// Kahn's algorithm, visiting steps in definition order
+	pending := make([]int, len(d.Steps))
+	dependents := make([][]int, len(d.Steps))
+	for i, step := range d.Steps {
+		pending[i] = len(step.Needs)
+		for _, need := range step.Needs {
+			dependents[index[need]] = append(dependents[index[need]], i)
+		}
+	}
+
+	var ready, order []int
+	for i := range d.Steps {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		order = append(order, i)
+		for _, dependent := range dependents[i] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(d.Steps) {
+		return nil, errors.NewInvalidInput("step dependencies contain a cycle", nil)
+	}
+
+	return order, nil
+}
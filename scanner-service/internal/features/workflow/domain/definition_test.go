@@ -0,0 +1,91 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDefinitionYAML(t *testing.T) {
+	definition, err := domain.ParseDefinition([]byte(`
+name: smb-audit
+target: 10.0.0.0/24
+schedule: "0 3 * * 1"
+steps:
+  - id: discover
+    scan:
+      scan_type: CONNECT
+      ports: "445,3389"
+  - id: smb
+    needs: [discover]
+    when:
+      open_ports: [445]
+    scan:
+      ports: "445"
+      extra_options: ["--script", "smb-protocols"]
+      timeout_seconds: 600
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "smb-audit", definition.Name)
+	assert.Equal(t, "0 3 * * 1", definition.Schedule)
+	require.Len(t, definition.Steps, 2)
+	assert.Equal(t, []string{"discover"}, definition.Steps[1].Needs)
+	assert.Equal(t, []int{445}, definition.Steps[1].When.OpenPorts)
+
+	options := definition.Steps[1].Scan.Options("10.0.0.5")
+	assert.Equal(t, "10.0.0.5", options.Target)
+	assert.Equal(t, []string{"--script", "smb-protocols"}, options.ExtraOptions)
+	assert.Equal(t, 10*time.Minute, options.Timeout)
+	assert.Equal(t, 5*time.Minute, definition.Steps[0].Scan.Options("10.0.0.0/24").Timeout)
+	assert.Equal(t, scandomain.ScanTypeConnect, definition.Steps[0].Scan.ScanType)
+}
+
+func TestParseDefinitionJSON(t *testing.T) {
+	definition, err := domain.ParseDefinition([]byte(`{
+		"target": "10.0.0.1",
+		"steps": [{"id": "tcp", "scan": {"ports": "1-1024"}}]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, "1-1024", definition.Steps[0].Scan.Ports)
+
+	_, err = domain.ParseDefinition([]byte(`{"target": "10.0.0.1", "steps": [{"id": "tcp", "if": {}}]}`))
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestDefinitionValidation(t *testing.T) {
+	step := func(id string, needs ...string) domain.Step {
+		return domain.Step{ID: id, Needs: needs}
+	}
+
+	for name, test := range map[string]struct {
+		definition domain.Definition
+		err        string
+	}{
+		"missing target":     {domain.Definition{Steps: []domain.Step{step("a")}}, "target is required"},
+		"no steps":           {domain.Definition{Target: "10.0.0.1"}, "between 1 and"},
+		"missing id":         {domain.Definition{Target: "10.0.0.1", Steps: []domain.Step{step("")}}, "needs an id"},
+		"duplicate id":       {domain.Definition{Target: "10.0.0.1", Steps: []domain.Step{step("a"), step("a")}}, "duplicate step id"},
+		"unknown dependency": {domain.Definition{Target: "10.0.0.1", Steps: []domain.Step{step("a", "b")}}, "unknown step"},
+		"cycle":              {domain.Definition{Target: "10.0.0.1", Steps: []domain.Step{step("a"), step("b", "a", "c"), step("c", "b")}}, "cycle"},
+		"invalid schedule":   {domain.Definition{Target: "10.0.0.1", Schedule: "daily", Steps: []domain.Step{step("a")}}, "invalid schedule"},
+		"condition on root": {domain.Definition{Target: "10.0.0.1", Steps: []domain.Step{
+			{ID: "a", When: &scandomain.StageHostFilter{OpenPorts: []int{445}}},
+		}}, "no dependencies"},
+		"discovery after root": {domain.Definition{Target: "example.com", Steps: []domain.Step{
+			step("a"),
+			{ID: "b", Needs: []string{"a"}, Scan: domain.StepScan{Discovery: []scandomain.DiscoveryMethod{scandomain.DiscoveryCT}}},
+		}}, "target discovery"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorContains(t, test.definition.Validate(), test.err)
+		})
+	}
+
+	valid := domain.Definition{Target: "10.0.0.1", Steps: []domain.Step{step("c", "a", "b"), step("b", "a"), step("a")}}
+	assert.NoError(t, valid.Validate())
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"go.uber.org/zap"
+)
+
+// stepOutcome is the result of a step scan reported back to the run loop
+type stepOutcome struct {
+	index  int
+	scanID string
+	result *scandomain.ScanResult
+	err    error
+}
+
+// executeRun runs the steps of a workflow as soon as the steps they need have finished.
+// Independent steps run concurrently; only this goroutine changes and stores the run.
+func (s *WorkflowService) executeRun(ctx context.Context, definition Definition, run *Run) {
+	log := s.logger.WithContext(ctx)
+	defer s.finishRun(run.WorkflowID, run.ID)
+
+	// Validated when the workflow was stored
+	order, _ := definition.order()
+
+	now := time.Now()
+	run.Status = scandomain.ScanStatusRunning
+	run.StartedAt = &now
+	s.updateRun(ctx, run)
+
+	log.Info("Starting workflow run",
+		zap.String("workflow_id", run.WorkflowID),
+		zap.String("run_id", run.ID),
+		zap.String("trigger", string(run.Trigger)),
+		zap.Int("steps", len(definition.Steps)),
+	)
+
+	index := make(map[string]int, len(definition.Steps))
+	for i, step := range definition.Steps {
+		index[step.ID] = i
+	}
+
+	results := make([]*scandomain.ScanResult, len(definition.Steps))
+	outcomes := make(chan stepOutcome)
+	running := 0
+
+	for {
+		// Start or skip every pending step whose dependencies have finished,
+		// in dependency order so that skips propagate in a single pass
+		for _, i := range order {
+			stepRun := &run.Steps[i]
+			if stepRun.Status != scandomain.ScanStatusPending {
+				continue
+			}
+
+			step := definition.Steps[i]
+			target, ready, skip := stepTarget(definition, step, run.Steps, results, index)
+			if !ready {
+				continue
+			}
+			if skip || ctx.Err() != nil {
+				stepRun.Status = scandomain.ScanStatusSkipped
+				continue
+			}
+
+			startedAt := time.Now()
+			stepRun.Status = scandomain.ScanStatusRunning
+			stepRun.StartedAt = &startedAt
+			stepRun.HostCount = len(strings.Fields(target))
+			running++
+
+			scan := &scandomain.Scan{
+				UserID:        run.UserID,
+				Options:       step.Scan.Options(target),
+				WorkflowRunID: run.ID,
+			}
+			go func(i int) {
+				result, err := s.scanRunner.RunScan(ctx, scan)
+				outcomes <- stepOutcome{index: i, scanID: scan.ID, result: result, err: err}
+			}(i)
+		}
+		s.updateRun(ctx, run)
+
+		if running == 0 {
+			break
+		}
+
+		outcome := <-outcomes
+		running--
+
+		completedAt := time.Now()
+		stepRun := &run.Steps[outcome.index]
+		stepRun.ScanID = outcome.scanID
+		stepRun.CompletedAt = &completedAt
+
+		switch {
+		case outcome.err == nil:
+			stepRun.Status = scandomain.ScanStatusCompleted
+			results[outcome.index] = outcome.result
+		case ctx.Err() != nil:
+			stepRun.Status = scandomain.ScanStatusCancelled
+			stepRun.Error = outcome.err.Error()
+		default:
+			stepRun.Status = scandomain.ScanStatusFailed
+			stepRun.Error = outcome.err.Error()
+			if run.Error == "" {
+				run.Error = fmt.Sprintf("step %s: %v", stepRun.ID, outcome.err)
+			}
+		}
+	}
+
+	status := scandomain.ScanStatusCompleted
+	for _, stepRun := range run.Steps {
+		if stepRun.Status == scandomain.ScanStatusFailed {
+			status = scandomain.ScanStatusFailed
+		}
+	}
+	if ctx.Err() != nil {
+		status = scandomain.ScanStatusCancelled
+	}
+
+	completedAt := time.Now()
+	run.Status = status
+	run.CompletedAt = &completedAt
+	s.updateRun(ctx, run)
+
+	log.Info("Workflow run finished",
+		zap.String("workflow_id", run.WorkflowID),
+		zap.String("run_id", run.ID),
+		zap.String("status", string(status)),
+	)
+}
+
+// stepTarget returns the hosts a step scans once all the steps it needs have finished.
+// Steps without dependencies scan the workflow target. A step is skipped if a step
+// it needs did not complete or no host of the dependencies matches its condition.
+func stepTarget(definition Definition, step Step, stepRuns []StepRun, results []*scandomain.ScanResult, index map[string]int) (target string, ready, skip bool) {
+	if len(step.Needs) == 0 {
+		return definition.Target, true, false
+	}
+
+	for _, need := range step.Needs {
+		switch stepRuns[index[need]].Status {
+		case scandomain.ScanStatusPending, scandomain.ScanStatusRunning:
+			return "", false, false
+		}
+	}
+
+	var condition scandomain.StageHostFilter
+	if step.When != nil {
+		condition = *step.When
+	}
+
+	seen := make(map[string]bool)
+	var addresses []string
+	for _, need := range step.Needs {
+		result := results[index[need]]
+		if result == nil {
+			return "", true, true
+		}
+		for _, host := range result.Hosts {
+			if !seen[host.IP] && condition.Matches(host) {
+				seen[host.IP] = true
+				addresses = append(addresses, host.IP)
+			}
+		}
+	}
+
+	if len(addresses) == 0 {
+		return "", true, true
+	}
+	return strings.Join(addresses, " "), true, false
+}
+
+// updateRun stores the state of a run, logging failures
+func (s *WorkflowService) updateRun(ctx context.Context, run *Run) {
+	if err := s.repository.UpdateRun(run); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update workflow run",
+			zap.String("run_id", run.ID),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+)
+
+// RunTrigger represents what started a workflow run
+type RunTrigger string
+
+// Run trigger constants
+const (
+	RunTriggerManual   RunTrigger = "manual"   // Started through the API
+	RunTriggerSchedule RunTrigger = "schedule" // Started by the workflow schedule
+	RunTriggerRunNow   RunTrigger = "run_now"  // Started through the API as the schedule owner
+)
+
+// Workflow represents a stored workflow definition
+type Workflow struct {
+	ID         string                `json:"id"`          // Unique identifier
+	UserID     string                `json:"user_id"`     // Owner of the workflow
+	Definition Definition            `json:"definition"`  // Steps and schedule
+	Owner      *authdomain.Principal `json:"-"`           // Principal scheduled runs are executed as
+	CreatedAt  time.Time             `json:"created_at"`  // When the workflow was created
+	UpdatedAt  time.Time             `json:"updated_at"`  // When the workflow was last updated
+	LastRunID  string                `json:"last_run_id"` // Most recent run
+	Schedule   ScheduleState         `json:"schedule"`    // State of the schedule
+}
+
+// ScheduleState represents whether the schedule of a workflow is paused and which scheduled runs were skipped
+type ScheduleState struct {
+	Paused         bool       `json:"paused"`                     // Whether scheduled runs are skipped
+	PausedAt       *time.Time `json:"paused_at,omitempty"`        // When the schedule was paused
+	PausedBy       string     `json:"paused_by,omitempty"`        // User who paused the schedule
+	SkippedRuns    int        `json:"skipped_runs"`               // Number of scheduled runs that were not started
+	LastSkippedAt  *time.Time `json:"last_skipped_at,omitempty"`  // When the last scheduled run was skipped
+	LastSkipReason string     `json:"last_skip_reason,omitempty"` // Why the last scheduled run was skipped
+}
+
+// Copy returns a copy of the workflow that does not share its steps
+func (w *Workflow) Copy() *Workflow {
+	workflowCopy := *w
+	workflowCopy.Definition.Steps = append([]Step(nil), w.Definition.Steps...)
+	return &workflowCopy
+}
+
+// StepRun represents the state of a step in a workflow run
+type StepRun struct {
+	ID          string                `json:"id"`                     // Step ID from the definition
+	Status      scandomain.ScanStatus `json:"status"`                 // Current status
+	ScanID      string                `json:"scan_id,omitempty"`      // Scan started for the step
+	HostCount   int                   `json:"host_count"`             // Number of hosts scanned by the step
+	Error       string                `json:"error,omitempty"`        // Error message if failed
+	StartedAt   *time.Time            `json:"started_at,omitempty"`   // When the step started
+	CompletedAt *time.Time            `json:"completed_at,omitempty"` // When the step completed
+}
+
+// Run represents one execution of a workflow
+type Run struct {
+	ID          string                `json:"id"`           // Unique identifier
+	WorkflowID  string                `json:"workflow_id"`  // Workflow that was run
+	UserID      string                `json:"user_id"`      // Owner of the workflow
+	Trigger     RunTrigger            `json:"trigger"`      // What started the run
+	Status      scandomain.ScanStatus `json:"status"`       // Current status
+	Steps       []StepRun             `json:"steps"`        // Steps in definition order
+	CreatedAt   time.Time             `json:"created_at"`   // When the run was created
+	StartedAt   *time.Time            `json:"started_at"`   // When the run started
+	CompletedAt *time.Time            `json:"completed_at"` // When the run completed
+	Error       string                `json:"error"`        // Error message if failed
+	RequestID   string                `json:"request_id"`   // ID of the API request that started the run
+}
+
+// Copy returns a copy of the run that does not share its steps
+func (r *Run) Copy() *Run {
+	runCopy := *r
+	runCopy.Steps = append([]StepRun(nil), r.Steps...)
+	return &runCopy
+}
+
+// IsActive reports whether the run has not finished yet
+func (r *Run) IsActive() bool {
+	return r.Status == scandomain.ScanStatusPending || r.Status == scandomain.ScanStatusRunning
+}
@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Start starts running scheduled workflows
+func (s *WorkflowService) Start() {
+	s.scheduler.Start()
+}
+
+// Stop stops scheduling workflows and cancels the active runs.
+// The returned context is done when the running schedule jobs have returned.
+func (s *WorkflowService) Stop() context.Context {
+	ctx := s.scheduler.Stop()
+
+	s.mu.Lock()
+	for _, cancel := range s.runCancels {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	return ctx
+}
+
+// schedule replaces the schedule entry of a workflow with its current schedule
+func (s *WorkflowService) schedule(workflow *Workflow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[workflow.ID]; ok {
+		s.scheduler.Remove(entry)
+		delete(s.entries, workflow.ID)
+	}
+
+	if workflow.Definition.Schedule == "" {
+		return nil
+	}
+
+	workflowID := workflow.ID
+	entry, err := s.scheduler.AddFunc(workflow.Definition.Schedule, func() {
+		s.runScheduled(workflowID)
+	})
+	if err != nil {
+		return errors.NewInvalidInput("invalid schedule: "+err.Error(), err)
+	}
+	s.entries[workflow.ID] = entry
+
+	return nil
+}
+
+// runScheduled starts a scheduled run of a workflow as its owner.
+// The run is skipped if the schedule is paused or the previous run is still active.
+func (s *WorkflowService) runScheduled(workflowID string) {
+	log := s.logger.With(zap.String("workflow_id", workflowID))
+
+	workflow, err := s.repository.GetWorkflowByID(workflowID)
+	if err != nil {
+		log.Error("Failed to get scheduled workflow", zap.Error(err))
+		return
+	}
+
+	if workflow.Schedule.Paused {
+		s.skipScheduledRun(workflow, "schedule is paused")
+		return
+	}
+
+	ctx := authdomain.WithPrincipal(context.Background(), workflow.Owner)
+	run, err := s.startRun(ctx, workflow, RunTriggerSchedule)
+	if err != nil {
+		s.skipScheduledRun(workflow, err.Error())
+		return
+	}
+
+	log.Info("Scheduled workflow run started", zap.String("run_id", run.ID))
+}
+
+// skipScheduledRun records that a scheduled run of a workflow was not started
+func (s *WorkflowService) skipScheduledRun(workflow *Workflow, reason string) {
+	log := s.logger.With(zap.String("workflow_id", workflow.ID))
+	log.Warn("Scheduled workflow run skipped", zap.String("reason", reason))
+
+	now := time.Now()
+	workflow.Schedule.SkippedRuns++
+	workflow.Schedule.LastSkippedAt = &now
+	workflow.Schedule.LastSkipReason = reason
+	if err := s.repository.UpdateWorkflow(workflow); err != nil {
+		log.Error("Failed to update workflow", zap.Error(err))
+	}
+}
+
+// PauseSchedule pauses the schedule of a workflow. Scheduled runs are skipped until the
+// schedule is resumed, while the definition and manual runs are not affected.
+// The caller must have the operator role and own the workflow, or be an admin.
+func (s *WorkflowService) PauseSchedule(ctx context.Context, id string) (*Workflow, error) {
+	return s.setSchedulePaused(ctx, id, true)
+}
+
+// ResumeSchedule resumes the paused schedule of a workflow.
+// The caller must have the operator role and own the workflow, or be an admin.
+func (s *WorkflowService) ResumeSchedule(ctx context.Context, id string) (*Workflow, error) {
+	return s.setSchedulePaused(ctx, id, false)
+}
+
+// setSchedulePaused pauses or resumes the schedule of a workflow
+func (s *WorkflowService) setSchedulePaused(ctx context.Context, id string, paused bool) (*Workflow, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.repository.GetWorkflowByID(id)
+	if err != nil || !principal.CanAccess(workflow.UserID) {
+		return nil, errors.NewNotFound("workflow not found", err)
+	}
+
+	if workflow.Definition.Schedule == "" {
+		return nil, errors.NewInvalidInput("workflow has no schedule", nil)
+	}
+	if workflow.Schedule.Paused == paused {
+		if paused {
+			return nil, errors.NewInvalidInput("schedule is already paused", nil)
+		}
+		return nil, errors.NewInvalidInput("schedule is not paused", nil)
+	}
+
+	workflow.Schedule.Paused = paused
+	workflow.Schedule.PausedAt = nil
+	workflow.Schedule.PausedBy = ""
+	if paused {
+		now := time.Now()
+		workflow.Schedule.PausedAt = &now
+		workflow.Schedule.PausedBy = principal.UserID
+	}
+
+	if err := s.repository.UpdateWorkflow(workflow); err != nil {
+		return nil, errors.NewInternal("failed to update workflow", err)
+	}
+
+	s.logger.WithContext(ctx).Info("Workflow schedule changed",
+		zap.String("workflow_id", workflow.ID),
+		zap.Bool("paused", paused),
+		zap.String("changed_by", principal.UserID),
+	)
+
+	return workflow, nil
+}
+
+// RunScheduleNow starts a run of a scheduled workflow as its owner, exactly like the
+// schedule would, even if the schedule is paused.
+// The caller must have the operator role and own the workflow, or be an admin.
+func (s *WorkflowService) RunScheduleNow(ctx context.Context, id string) (*Run, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.repository.GetWorkflowByID(id)
+	if err != nil || !principal.CanAccess(workflow.UserID) {
+		return nil, errors.NewNotFound("workflow not found", err)
+	}
+
+	if workflow.Definition.Schedule == "" {
+		return nil, errors.NewInvalidInput("workflow has no schedule", nil)
+	}
+
+	return s.startRun(authdomain.WithPrincipal(ctx, workflow.Owner), workflow, RunTriggerRunNow)
+}
@@ -0,0 +1,92 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPauseSchedule(t *testing.T) {
+	mockRepository := new(MockWorkflowRepository)
+	runner := &fakeScanRunner{
+		targets: make(map[string]string),
+		results: map[string]*scandomain.ScanResult{"22": {Hosts: []scandomain.Host{upHost("10.0.0.1", scandomain.Port{Port: 22})}}},
+	}
+	service := domain.NewWorkflowService(mockRepository, runner, &logger.Logger{Logger: zap.NewNop()})
+
+	skipped := make(chan domain.ScheduleState, 1)
+	mockRepository.On("SaveWorkflow", mock.Anything).Return(nil)
+	mockRepository.On("UpdateWorkflow", mock.Anything).Run(func(args mock.Arguments) {
+		workflow := args.Get(0).(*domain.Workflow)
+		if workflow.Schedule.SkippedRuns > 0 {
+			select {
+			case skipped <- workflow.Schedule:
+			default:
+			}
+		}
+	}).Return(nil)
+	mockRepository.On("SaveRun", mock.Anything).Return(nil)
+	mockRepository.On("UpdateRun", mock.Anything).Return(nil)
+
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	unscheduled, err := service.CreateWorkflow(ctx, "alice", domain.Definition{Target: "10.0.0.1", Steps: []domain.Step{{ID: "ssh", Scan: domain.StepScan{Ports: "22"}}}})
+	require.NoError(t, err)
+	mockRepository.On("GetWorkflowByID", unscheduled.ID).Return(unscheduled, nil)
+
+	// Workflows without a schedule cannot be paused or run as scheduled
+	_, err = service.PauseSchedule(ctx, unscheduled.ID)
+	assert.ErrorContains(t, err, "workflow has no schedule")
+	_, err = service.RunScheduleNow(ctx, unscheduled.ID)
+	assert.ErrorContains(t, err, "workflow has no schedule")
+
+	workflow, err := service.CreateWorkflow(ctx, "alice", domain.Definition{
+		Target:   "10.0.0.1",
+		Schedule: "@every 1s",
+		Steps:    []domain.Step{{ID: "ssh", Scan: domain.StepScan{Ports: "22"}}},
+	})
+	require.NoError(t, err)
+	mockRepository.On("GetWorkflowByID", workflow.ID).Return(workflow, nil)
+
+	// Other users cannot pause the schedule
+	_, err = service.PauseSchedule(principalContext("bob", authdomain.RoleOperator), workflow.ID)
+	assert.ErrorContains(t, err, "workflow not found")
+
+	paused, err := service.PauseSchedule(ctx, workflow.ID)
+	require.NoError(t, err)
+	assert.True(t, paused.Schedule.Paused)
+	assert.Equal(t, "alice", paused.Schedule.PausedBy)
+	_, err = service.PauseSchedule(ctx, workflow.ID)
+	assert.ErrorContains(t, err, "already paused")
+
+	// Scheduled runs of the paused workflow are skipped
+	service.Start()
+	defer service.Stop()
+	select {
+	case state := <-skipped:
+		assert.Equal(t, "schedule is paused", state.LastSkipReason)
+		assert.NotNil(t, state.LastSkippedAt)
+	case <-time.After(3 * time.Second):
+		t.Fatal("scheduled run was not skipped")
+	}
+	mockRepository.AssertNotCalled(t, "SaveRun", mock.Anything)
+
+	// The schedule can still be run on demand
+	run, err := service.RunScheduleNow(ctx, workflow.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RunTriggerRunNow, run.Trigger)
+
+	resumed, err := service.ResumeSchedule(ctx, workflow.ID)
+	require.NoError(t, err)
+	assert.False(t, resumed.Schedule.Paused)
+	assert.Nil(t, resumed.Schedule.PausedAt)
+	_, err = service.ResumeSchedule(ctx, workflow.ID)
+	assert.ErrorContains(t, err, "not paused")
+}
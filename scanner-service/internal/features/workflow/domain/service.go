@@ -0,0 +1,359 @@
+package domain
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// WorkflowRepository defines the interface for workflow storage
+type WorkflowRepository interface {
+	SaveWorkflow(workflow *Workflow) error
+	UpdateWorkflow(workflow *Workflow) error
+	GetWorkflowByID(id string) (*Workflow, error)
+	ListWorkflows(userID string) ([]*Workflow, error)
+	DeleteWorkflow(id string) error
+	SaveRun(run *Run) error
+	UpdateRun(run *Run) error
+	GetRunByID(id string) (*Run, error)
+	ListRuns(workflowID string) ([]*Run, error)
+}
+
+// ScanRunner checks and runs the scans of workflow steps
+type ScanRunner interface {
+	CheckScan(ctx context.Context, options scandomain.ScanOptions) error
+	RunScan(ctx context.Context, scan *scandomain.Scan) (*scandomain.ScanResult, error)
+}
+
+// WorkflowService implements the business logic for workflows
+type WorkflowService struct {
+	repository WorkflowRepository
+	scanRunner ScanRunner
+	logger     *logger.Logger
+	scheduler  *cron.Cron
+	mu         sync.Mutex
+	entries    map[string]cron.EntryID       // Schedule entries by workflow ID
+	activeRuns map[string]string             // Active run ID by workflow ID
+	runCancels map[string]context.CancelFunc // Cancel functions by run ID
+}
+
+// NewWorkflowService creates a new WorkflowService
+func NewWorkflowService(repository WorkflowRepository, scanRunner ScanRunner, logger *logger.Logger) *WorkflowService {
+	return &WorkflowService{
+		repository: repository,
+		scanRunner: scanRunner,
+		logger:     logger,
+		scheduler:  cron.New(),
+		entries:    make(map[string]cron.EntryID),
+		activeRuns: make(map[string]string),
+		runCancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// CreateWorkflow stores a workflow after checking its steps against the caller's policies,
+// and schedules it if the definition has a schedule. The caller must have the operator role.
+func (s *WorkflowService) CreateWorkflow(ctx context.Context, userID string, definition Definition) (*Workflow, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDefinition(ctx, definition); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	workflow := &Workflow{
+		ID:         uuid.New().String(),
+		UserID:     userID,
+		Definition: definition,
+		Owner:      principal,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.repository.SaveWorkflow(workflow); err != nil {
+		return nil, errors.NewInternal("failed to save workflow", err)
+	}
+
+	if err := s.schedule(workflow); err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// UpdateWorkflow replaces the definition of a workflow. Scheduled runs are executed
+// as the caller from now on. The caller must have the operator role and own the workflow, or be an admin.
+func (s *WorkflowService) UpdateWorkflow(ctx context.Context, id string, definition Definition) (*Workflow, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.repository.GetWorkflowByID(id)
+	if err != nil || !principal.CanAccess(workflow.UserID) {
+		return nil, errors.NewNotFound("workflow not found", err)
+	}
+
+	if err := s.checkDefinition(ctx, definition); err != nil {
+		return nil, err
+	}
+
+	workflow.Definition = definition
+	workflow.Owner = principal
+	workflow.UpdatedAt = time.Now()
+	if definition.Schedule == "" {
+		workflow.Schedule = ScheduleState{}
+	}
+
+	if err := s.repository.UpdateWorkflow(workflow); err != nil {
+		return nil, errors.NewInternal("failed to update workflow", err)
+	}
+
+	if err := s.schedule(workflow); err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// GetWorkflow gets a workflow by ID.
+// Workflows owned by other users are reported as not found unless the caller is an admin.
+func (s *WorkflowService) GetWorkflow(ctx context.Context, id string) (*Workflow, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.repository.GetWorkflowByID(id)
+	if err != nil || !principal.CanAccess(workflow.UserID) {
+		return nil, errors.NewNotFound("workflow not found", err)
+	}
+
+	return workflow, nil
+}
+
+// ListWorkflows lists the workflows of a user.
+// Only admins may list other users' workflows or all workflows (empty userID).
+func (s *WorkflowService) ListWorkflows(ctx context.Context, userID string) ([]*Workflow, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != principal.UserID && !principal.IsAdmin() {
+		return nil, errors.NewForbidden("cannot list workflows of other users", nil)
+	}
+
+	workflows, err := s.repository.ListWorkflows(userID)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list workflows", err)
+	}
+
+	return workflows, nil
+}
+
+// DeleteWorkflow unschedules and deletes a workflow, cancelling its active run.
+// The caller must have the operator role and own the workflow, or be an admin.
+func (s *WorkflowService) DeleteWorkflow(ctx context.Context, id string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
+	workflow, err := s.repository.GetWorkflowByID(id)
+	if err != nil || !principal.CanAccess(workflow.UserID) {
+		return errors.NewNotFound("workflow not found", err)
+	}
+
+	s.mu.Lock()
+	if entry, ok := s.entries[id]; ok {
+		s.scheduler.Remove(entry)
+		delete(s.entries, id)
+	}
+	if cancel, ok := s.runCancels[s.activeRuns[id]]; ok {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	if err := s.repository.DeleteWorkflow(id); err != nil {
+		return errors.NewInternal("failed to delete workflow", err)
+	}
+
+	return nil
+}
+
+// RunWorkflow starts a run of a workflow in the background.
+// The caller must have the operator role and own the workflow, or be an admin.
+func (s *WorkflowService) RunWorkflow(ctx context.Context, id string) (*Run, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.repository.GetWorkflowByID(id)
+	if err != nil || !principal.CanAccess(workflow.UserID) {
+		return nil, errors.NewNotFound("workflow not found", err)
+	}
+
+	return s.startRun(ctx, workflow, RunTriggerManual)
+}
+
+// GetRun gets a run of a workflow by ID.
+// Runs owned by other users are reported as not found unless the caller is an admin.
+func (s *WorkflowService) GetRun(ctx context.Context, workflowID, runID string) (*Run, error) {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := s.repository.GetRunByID(runID)
+	if err != nil || run.WorkflowID != workflowID || !principal.CanAccess(run.UserID) {
+		return nil, errors.NewNotFound("workflow run not found", err)
+	}
+
+	return run, nil
+}
+
+// ListRuns lists the runs of a workflow, newest first
+func (s *WorkflowService) ListRuns(ctx context.Context, workflowID string) ([]*Run, error) {
+	if _, err := s.GetWorkflow(ctx, workflowID); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.repository.ListRuns(workflowID)
+	if err != nil {
+		return nil, errors.NewInternal("failed to list workflow runs", err)
+	}
+
+	return runs, nil
+}
+
+// CancelRun cancels an active run and the scans of its running steps.
+// The caller must have the operator role and own the workflow, or be an admin.
+func (s *WorkflowService) CancelRun(ctx context.Context, workflowID, runID string) error {
+	principal, err := authdomain.Authorize(ctx, authdomain.RoleOperator)
+	if err != nil {
+		return err
+	}
+
+	run, err := s.repository.GetRunByID(runID)
+	if err != nil || run.WorkflowID != workflowID || !principal.CanAccess(run.UserID) {
+		return errors.NewNotFound("workflow run not found", err)
+	}
+
+	s.mu.Lock()
+	cancel, running := s.runCancels[runID]
+	s.mu.Unlock()
+
+	if !running {
+		return errors.NewInvalidInput("workflow run is not running or pending", nil)
+	}
+
+	cancel()
+
+	return nil
+}
+
+// checkDefinition checks the steps of a definition against the caller's policies.
+// Steps with dependencies scan a subset of the workflow target, so every step is checked against it.
+func (s *WorkflowService) checkDefinition(ctx context.Context, definition Definition) error {
+	if err := definition.Validate(); err != nil {
+		return err
+	}
+
+	for _, step := range definition.Steps {
+		if err := s.scanRunner.CheckScan(ctx, step.Scan.Options(definition.Target)); err != nil {
+			// Keep the type of the error, a policy violation stays forbidden
+			errType := errors.ErrInvalidInput
+			var appErr *errors.Error
+			if stderrors.As(err, &appErr) {
+				errType = appErr.Type
+			}
+			return errors.New(errType, fmt.Sprintf("step %q", step.ID), err)
+		}
+	}
+
+	return nil
+}
+
+// startRun creates a run of the workflow and executes it in the background.
+// A workflow has at most one active run at a time.
+func (s *WorkflowService) startRun(ctx context.Context, workflow *Workflow, trigger RunTrigger) (*Run, error) {
+	// Policies may have changed since the workflow was stored
+	if err := s.checkDefinition(ctx, workflow.Definition); err != nil {
+		return nil, err
+	}
+
+	steps := make([]StepRun, len(workflow.Definition.Steps))
+	for i, step := range workflow.Definition.Steps {
+		steps[i] = StepRun{ID: step.ID, Status: scandomain.ScanStatusPending}
+	}
+
+	run := &Run{
+		ID:         uuid.New().String(),
+		WorkflowID: workflow.ID,
+		UserID:     workflow.UserID,
+		Trigger:    trigger,
+		Status:     scandomain.ScanStatusPending,
+		Steps:      steps,
+		CreatedAt:  time.Now(),
+		RequestID:  requestid.FromContext(ctx),
+	}
+
+	// Run detached from the cancellation of the request
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	s.mu.Lock()
+	if activeRunID, ok := s.activeRuns[workflow.ID]; ok {
+		s.mu.Unlock()
+		cancel()
+		return nil, errors.NewAlreadyExists("workflow run "+activeRunID+" is still active", nil)
+	}
+	s.activeRuns[workflow.ID] = run.ID
+	s.runCancels[run.ID] = cancel
+	s.mu.Unlock()
+
+	if err := s.repository.SaveRun(run); err != nil {
+		s.finishRun(workflow.ID, run.ID)
+		return nil, errors.NewInternal("failed to save workflow run", err)
+	}
+
+	workflow.LastRunID = run.ID
+	if err := s.repository.UpdateWorkflow(workflow); err != nil {
+		s.logger.WithContext(ctx).Error("Failed to update workflow",
+			zap.String("workflow_id", workflow.ID),
+			zap.Error(err),
+		)
+	}
+
+	go s.executeRun(runCtx, workflow.Definition, run.Copy())
+
+	return run, nil
+}
+
+// finishRun releases the active run of a workflow
+func (s *WorkflowService) finishRun(workflowID, runID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if cancel, ok := s.runCancels[runID]; ok {
+		cancel()
+		delete(s.runCancels, runID)
+	}
+	if s.activeRuns[workflowID] == runID {
+		delete(s.activeRuns, workflowID)
+	}
+}
@@ -0,0 +1,232 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	scandomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/scan/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockWorkflowRepository is a mock implementation of the WorkflowRepository interface
+type MockWorkflowRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkflowRepository) SaveWorkflow(workflow *domain.Workflow) error {
+	args := m.Called(workflow)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) UpdateWorkflow(workflow *domain.Workflow) error {
+	args := m.Called(workflow)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) GetWorkflowByID(id string) (*domain.Workflow, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Workflow), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) ListWorkflows(userID string) ([]*domain.Workflow, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*domain.Workflow), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) DeleteWorkflow(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) SaveRun(run *domain.Run) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) UpdateRun(run *domain.Run) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) GetRunByID(id string) (*domain.Run, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Run), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) ListRuns(workflowID string) ([]*domain.Run, error) {
+	args := m.Called(workflowID)
+	return args.Get(0).([]*domain.Run), args.Error(1)
+}
+
+// fakeScanRunner returns a fixed result per port specification and records the scanned targets
+type fakeScanRunner struct {
+	mu       sync.Mutex
+	results  map[string]*scandomain.ScanResult
+	targets  map[string]string
+	checkErr error
+}
+
+func (f *fakeScanRunner) CheckScan(ctx context.Context, options scandomain.ScanOptions) error {
+	return f.checkErr
+}
+
+func (f *fakeScanRunner) RunScan(ctx context.Context, scan *scandomain.Scan) (*scandomain.ScanResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	scan.ID = "scan-" + scan.Options.Ports
+	f.targets[scan.Options.Ports] = scan.Options.Target
+
+	result, ok := f.results[scan.Options.Ports]
+	if !ok {
+		return nil, errors.New("nmap failed")
+	}
+	return result, nil
+}
+
+func principalContext(userID string, role authdomain.Role) context.Context {
+	return authdomain.WithPrincipal(context.Background(), &authdomain.Principal{
+		UserID: userID,
+		Roles:  []authdomain.Role{role},
+	})
+}
+
+func upHost(ip string, ports ...scandomain.Port) scandomain.Host {
+	for i := range ports {
+		ports[i].State = "open"
+	}
+	return scandomain.Host{IP: ip, Status: "up", Ports: ports}
+}
+
+// runWorkflow creates a workflow with the definition, runs it and returns the finished run
+func runWorkflow(t *testing.T, runner *fakeScanRunner, definition domain.Definition) domain.Run {
+	mockRepository := new(MockWorkflowRepository)
+	zapLogger, _ := zap.NewDevelopment()
+	service := domain.NewWorkflowService(mockRepository, runner, &logger.Logger{Logger: zapLogger})
+
+	finished := make(chan domain.Run, 1)
+	mockRepository.On("SaveWorkflow", mock.Anything).Return(nil)
+	mockRepository.On("UpdateWorkflow", mock.Anything).Return(nil)
+	mockRepository.On("SaveRun", mock.Anything).Return(nil)
+	mockRepository.On("UpdateRun", mock.Anything).Run(func(args mock.Arguments) {
+		run := args.Get(0).(*domain.Run)
+		if run.CompletedAt != nil {
+			finished <- *run.Copy()
+		}
+	}).Return(nil)
+
+	ctx := principalContext("alice", authdomain.RoleOperator)
+	workflow, err := service.CreateWorkflow(ctx, "alice", definition)
+	require.NoError(t, err)
+	mockRepository.On("GetWorkflowByID", workflow.ID).Return(workflow, nil)
+
+	run, err := service.RunWorkflow(ctx, workflow.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RunTriggerManual, run.Trigger)
+
+	select {
+	case run := <-finished:
+		return run
+	case <-time.After(5 * time.Second):
+		t.Fatal("workflow run did not finish")
+		return domain.Run{}
+	}
+}
+
+func TestRunWorkflowBranches(t *testing.T) {
+	runner := &fakeScanRunner{
+		targets: make(map[string]string),
+		results: map[string]*scandomain.ScanResult{
+			"1-1024": {ID: "r1", Hosts: []scandomain.Host{
+				upHost("10.0.0.1", scandomain.Port{Port: 445, Service: "microsoft-ds"}),
+				upHost("10.0.0.2", scandomain.Port{Port: 8080, Service: "http"}),
+				{IP: "10.0.0.3", Status: "down"},
+			}},
+			"445":  {ID: "r2", Hosts: []scandomain.Host{upHost("10.0.0.1")}},
+			"8080": {ID: "r3", Hosts: []scandomain.Host{upHost("10.0.0.2")}},
+			"22":   {ID: "r4"},
+		},
+	}
+
+	run := runWorkflow(t, runner, domain.Definition{
+		Target: "10.0.0.0/29",
+		Steps: []domain.Step{
+			{ID: "tcp", Scan: domain.StepScan{Ports: "1-1024"}},
+			{ID: "smb", Needs: []string{"tcp"}, When: &scandomain.StageHostFilter{OpenPorts: []int{445}}, Scan: domain.StepScan{Ports: "445"}},
+			{ID: "web", Needs: []string{"tcp"}, When: &scandomain.StageHostFilter{Services: []string{"http"}}, Scan: domain.StepScan{Ports: "8080"}},
+			{ID: "rdp", Needs: []string{"tcp"}, When: &scandomain.StageHostFilter{OpenPorts: []int{3389}}, Scan: domain.StepScan{Ports: "3389"}},
+			{ID: "report", Needs: []string{"smb", "rdp"}, Scan: domain.StepScan{Ports: "1"}},
+			{ID: "all", Needs: []string{"smb", "web"}, Scan: domain.StepScan{Ports: "22"}},
+		},
+	})
+
+	assert.Equal(t, scandomain.ScanStatusCompleted, run.Status)
+	statuses := make(map[string]scandomain.ScanStatus)
+	for _, step := range run.Steps {
+		statuses[step.ID] = step.Status
+	}
+	assert.Equal(t, map[string]scandomain.ScanStatus{
+		"tcp":    scandomain.ScanStatusCompleted,
+		"smb":    scandomain.ScanStatusCompleted,
+		"web":    scandomain.ScanStatusCompleted,
+		"rdp":    scandomain.ScanStatusSkipped,
+		"report": scandomain.ScanStatusSkipped,
+		"all":    scandomain.ScanStatusCompleted,
+	}, statuses)
+
+	// Steps scan the matching hosts of the steps they need
+	assert.Equal(t, map[string]string{
+		"1-1024": "10.0.0.0/29",
+		"445":    "10.0.0.1",
+		"8080":   "10.0.0.2",
+		"22":     "10.0.0.1 10.0.0.2",
+	}, runner.targets)
+	assert.Equal(t, "scan-445", run.Steps[1].ScanID)
+	assert.Equal(t, 2, run.Steps[5].HostCount)
+}
+
+func TestRunWorkflowFailure(t *testing.T) {
+	runner := &fakeScanRunner{targets: make(map[string]string)}
+
+	run := runWorkflow(t, runner, domain.Definition{
+		Target: "10.0.0.1",
+		Steps: []domain.Step{
+			{ID: "tcp", Scan: domain.StepScan{Ports: "1-1024"}},
+			{ID: "smb", Needs: []string{"tcp"}, Scan: domain.StepScan{Ports: "445"}},
+		},
+	})
+
+	assert.Equal(t, scandomain.ScanStatusFailed, run.Status)
+	assert.Equal(t, scandomain.ScanStatusFailed, run.Steps[0].Status)
+	assert.Equal(t, scandomain.ScanStatusSkipped, run.Steps[1].Status)
+	assert.Contains(t, run.Error, "step tcp: nmap failed")
+}
+
+func TestCreateWorkflowChecksSteps(t *testing.T) {
+	zapLogger, _ := zap.NewDevelopment()
+	runner := &fakeScanRunner{checkErr: errors.New("target not allowed")}
+	service := domain.NewWorkflowService(new(MockWorkflowRepository), runner, &logger.Logger{Logger: zapLogger})
+	definition := domain.Definition{Target: "10.0.0.1", Steps: []domain.Step{{ID: "tcp"}}}
+
+	_, err := service.CreateWorkflow(principalContext("alice", authdomain.RoleViewer), "alice", definition)
+	assert.ErrorContains(t, err, "role operator required")
+
+	_, err = service.CreateWorkflow(principalContext("alice", authdomain.RoleOperator), "alice", definition)
+	assert.ErrorContains(t, err, `step "tcp"`)
+	assert.ErrorContains(t, err, "target not allowed")
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	schedulerv1 "github.com/furkansarikaya/nmap-ui-microservices/api/proto/scheduler/v1"
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ScheduleGRPCHandler serves the scheduler API of api/proto over gRPC
+type ScheduleGRPCHandler struct {
+	schedulerv1.UnimplementedSchedulerServiceServer
+	workflowService *domain.WorkflowService
+	logger          *logger.Logger
+}
+
+// NewScheduleGRPCHandler creates a new ScheduleGRPCHandler
+func NewScheduleGRPCHandler(workflowService *domain.WorkflowService, logger *logger.Logger) *ScheduleGRPCHandler {
+	return &ScheduleGRPCHandler{
+		workflowService: workflowService,
+		logger:          logger,
+	}
+}
+
+// Register registers the scheduler service on the gRPC server
+func (h *ScheduleGRPCHandler) Register(server *grpc.Server) {
+	schedulerv1.RegisterSchedulerServiceServer(server, h)
+}
+
+// ListSchedules returns the schedules of the workflows of the caller, or of all users
+// for admins with all
+func (h *ScheduleGRPCHandler) ListSchedules(ctx context.Context, req *schedulerv1.ListSchedulesRequest) (*schedulerv1.ListSchedulesResponse, error) {
+	principal, ok := authdomain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, errors.NewUnauthorized("unauthorized", nil)
+	}
+
+	userID := principal.UserID
+	if req.GetAll() {
+		userID = ""
+	}
+
+	workflows, err := h.workflowService.ListWorkflows(ctx, userID)
+	if err != nil {
+		h.logger.WithContext(ctx).Error("Failed to list workflows",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+		return nil, err
+	}
+
+	resp := &schedulerv1.ListSchedulesResponse{}
+	for _, workflow := range workflows {
+		if workflow.Definition.Schedule != "" {
+			resp.Schedules = append(resp.Schedules, scheduleToProto(workflow))
+		}
+	}
+	return resp, nil
+}
+
+// PauseSchedule pauses the schedule of a workflow
+func (h *ScheduleGRPCHandler) PauseSchedule(ctx context.Context, req *schedulerv1.PauseScheduleRequest) (*schedulerv1.Schedule, error) {
+	workflow, err := h.workflowService.PauseSchedule(ctx, req.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	return scheduleToProto(workflow), nil
+}
+
+// ResumeSchedule resumes the paused schedule of a workflow
+func (h *ScheduleGRPCHandler) ResumeSchedule(ctx context.Context, req *schedulerv1.ResumeScheduleRequest) (*schedulerv1.Schedule, error) {
+	workflow, err := h.workflowService.ResumeSchedule(ctx, req.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	return scheduleToProto(workflow), nil
+}
+
+// RunSchedule starts a run of a scheduled workflow as its owner
+func (h *ScheduleGRPCHandler) RunSchedule(ctx context.Context, req *schedulerv1.RunScheduleRequest) (*schedulerv1.RunScheduleResponse, error) {
+	run, err := h.workflowService.RunScheduleNow(ctx, req.GetWorkflowId())
+	if err != nil {
+		h.logger.WithContext(ctx).Error("Failed to run scheduled workflow",
+			zap.Error(err),
+			zap.String("workflow_id", req.GetWorkflowId()),
+		)
+		return nil, err
+	}
+
+	h.logger.WithContext(ctx).Info("Scheduled workflow run started",
+		zap.String("workflow_id", req.GetWorkflowId()),
+		zap.String("run_id", run.ID),
+	)
+	return &schedulerv1.RunScheduleResponse{RunId: run.ID}, nil
+}
+
+// scheduleToProto converts the schedule of a workflow into its protobuf message
+func scheduleToProto(workflow *domain.Workflow) *schedulerv1.Schedule {
+	state := workflow.Schedule
+	return &schedulerv1.Schedule{
+		WorkflowId:     workflow.ID,
+		Name:           workflow.Definition.Name,
+		Cron:           workflow.Definition.Schedule,
+		Paused:         state.Paused,
+		PausedAt:       timestampToProto(state.PausedAt),
+		PausedBy:       state.PausedBy,
+		SkippedRuns:    int32(state.SkippedRuns),
+		LastSkippedAt:  timestampToProto(state.LastSkippedAt),
+		LastSkipReason: state.LastSkipReason,
+		LastRunId:      workflow.LastRunID,
+	}
+}
+
+// timestampToProto converts an optional time into a protobuf timestamp, nil if unset
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
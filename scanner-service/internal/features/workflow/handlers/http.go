@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"net/http"
+
+	authdomain "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/domain"
+	authhandlers "github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/auth/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// WorkflowHandler handles HTTP requests for workflows
+type WorkflowHandler struct {
+	workflowService *domain.WorkflowService
+	logger          *logger.Logger
+}
+
+// NewWorkflowHandler creates a new WorkflowHandler
+func NewWorkflowHandler(workflowService *domain.WorkflowService, logger *logger.Logger) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+		logger:          logger,
+	}
+}
+
+// parseDefinition reads a JSON or YAML workflow definition from the request body
+func parseDefinition(c *gin.Context) (*domain.Definition, bool) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.Error(errors.NewInvalidInput("invalid request: "+err.Error(), err))
+		return nil, false
+	}
+
+	definition, err := domain.ParseDefinition(body)
+	if err != nil {
+		c.Error(err)
+		return nil, false
+	}
+
+	return definition, true
+}
+
+// CreateWorkflow handles the request to create a workflow from a JSON or YAML definition
+func (h *WorkflowHandler) CreateWorkflow(c *gin.Context) {
+	definition, ok := parseDefinition(c)
+	if !ok {
+		return
+	}
+
+	workflow, err := h.workflowService.CreateWorkflow(c.Request.Context(), c.GetString("user_id"), *definition)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to create workflow",
+			zap.Error(err),
+			zap.String("name", definition.Name),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Workflow created",
+		zap.String("workflow_id", workflow.ID),
+		zap.String("name", definition.Name),
+		zap.Int("steps", len(definition.Steps)),
+	)
+
+	c.JSON(http.StatusCreated, workflow)
+}
+
+// UpdateWorkflow handles the request to replace the definition of a workflow
+func (h *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
+	workflowID := c.Param("id")
+
+	definition, ok := parseDefinition(c)
+	if !ok {
+		return
+	}
+
+	workflow, err := h.workflowService.UpdateWorkflow(c.Request.Context(), workflowID, *definition)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to update workflow",
+			zap.Error(err),
+			zap.String("workflow_id", workflowID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+// GetWorkflow handles the request to get a workflow
+func (h *WorkflowHandler) GetWorkflow(c *gin.Context) {
+	workflow, err := h.workflowService.GetWorkflow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+// ListWorkflows handles the request to list workflows.
+// Admins may list another user's workflows with ?user_id= or all workflows with ?all=true.
+func (h *WorkflowHandler) ListWorkflows(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if queryUserID := c.Query("user_id"); queryUserID != "" {
+		userID = queryUserID
+	}
+	if c.Query("all") == "true" {
+		userID = ""
+	}
+
+	workflows, err := h.workflowService.ListWorkflows(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to list workflows",
+			zap.Error(err),
+			zap.String("user_id", userID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"workflows": workflows,
+		"count":     len(workflows),
+	})
+}
+
+// DeleteWorkflow handles the request to delete a workflow
+func (h *WorkflowHandler) DeleteWorkflow(c *gin.Context) {
+	workflowID := c.Param("id")
+
+	if err := h.workflowService.DeleteWorkflow(c.Request.Context(), workflowID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Workflow deleted", zap.String("workflow_id", workflowID))
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workflow deleted",
+	})
+}
+
+// RunWorkflow handles the request to start a run of a workflow
+func (h *WorkflowHandler) RunWorkflow(c *gin.Context) {
+	workflowID := c.Param("id")
+
+	run, err := h.workflowService.RunWorkflow(c.Request.Context(), workflowID)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to run workflow",
+			zap.Error(err),
+			zap.String("workflow_id", workflowID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Workflow run started",
+		zap.String("workflow_id", workflowID),
+		zap.String("run_id", run.ID),
+	)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Workflow run started",
+		"run_id":  run.ID,
+	})
+}
+
+// PauseSchedule handles the request to pause the schedule of a workflow
+func (h *WorkflowHandler) PauseSchedule(c *gin.Context) {
+	workflow, err := h.workflowService.PauseSchedule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+// ResumeSchedule handles the request to resume the paused schedule of a workflow
+func (h *WorkflowHandler) ResumeSchedule(c *gin.Context) {
+	workflow, err := h.workflowService.ResumeSchedule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workflow)
+}
+
+// RunScheduleNow handles the request to start a run of a scheduled workflow as its owner
+func (h *WorkflowHandler) RunScheduleNow(c *gin.Context) {
+	workflowID := c.Param("id")
+
+	run, err := h.workflowService.RunScheduleNow(c.Request.Context(), workflowID)
+	if err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to run workflow schedule",
+			zap.Error(err),
+			zap.String("workflow_id", workflowID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	h.logger.WithContext(c.Request.Context()).Info("Workflow run started",
+		zap.String("workflow_id", workflowID),
+		zap.String("run_id", run.ID),
+		zap.String("trigger", string(run.Trigger)),
+	)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Workflow run started",
+		"run_id":  run.ID,
+	})
+}
+
+// ListRuns handles the request to list the runs of a workflow
+func (h *WorkflowHandler) ListRuns(c *gin.Context) {
+	runs, err := h.workflowService.ListRuns(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"runs":  runs,
+		"count": len(runs),
+	})
+}
+
+// GetRun handles the request to get a workflow run with the status of its steps
+func (h *WorkflowHandler) GetRun(c *gin.Context) {
+	run, err := h.workflowService.GetRun(c.Request.Context(), c.Param("id"), c.Param("run_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// CancelRun handles the request to cancel a workflow run
+func (h *WorkflowHandler) CancelRun(c *gin.Context) {
+	workflowID := c.Param("id")
+	runID := c.Param("run_id")
+
+	if err := h.workflowService.CancelRun(c.Request.Context(), workflowID, runID); err != nil {
+		h.logger.WithContext(c.Request.Context()).Error("Failed to cancel workflow run",
+			zap.Error(err),
+			zap.String("run_id", runID),
+		)
+
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Workflow run cancelled",
+		"run_id":  runID,
+	})
+}
+
+// RegisterRoutes registers the workflow handler routes to the router.
+// The given middleware is applied to all /api/v1 routes.
+func (h *WorkflowHandler) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	api := router.Group("/api/v1/workflows", middleware...)
+
+	viewer := authhandlers.RequireRole(authdomain.RoleViewer)
+	operator := authhandlers.RequireRole(authdomain.RoleOperator)
+
+	api.POST("", operator, h.CreateWorkflow)
+	api.GET("", viewer, h.ListWorkflows)
+	api.GET("/:id", viewer, h.GetWorkflow)
+	api.PUT("/:id", operator, h.UpdateWorkflow)
+	api.DELETE("/:id", operator, h.DeleteWorkflow)
+
+	api.POST("/:id/schedule/pause", operator, h.PauseSchedule)
+	api.POST("/:id/schedule/resume", operator, h.ResumeSchedule)
+	api.POST("/:id/schedule/run", operator, h.RunScheduleNow)
+
+	api.POST("/:id/runs", operator, h.RunWorkflow)
+	api.GET("/:id/runs", viewer, h.ListRuns)
+	api.GET("/:id/runs/:run_id", viewer, h.GetRun)
+	api.DELETE("/:id/runs/:run_id", operator, h.CancelRun)
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/features/workflow/domain"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MemoryWorkflowRepository is an in-memory implementation of the WorkflowRepository interface
+type MemoryWorkflowRepository struct {
+	logger    *logger.Logger
+	workflows map[string]*domain.Workflow
+	runs      map[string]*domain.Run
+	mu        sync.RWMutex
+}
+
+// NewMemoryWorkflowRepository creates a new MemoryWorkflowRepository
+func NewMemoryWorkflowRepository(logger *logger.Logger) *MemoryWorkflowRepository {
+	return &MemoryWorkflowRepository{
+		logger:    logger,
+		workflows: make(map[string]*domain.Workflow),
+		runs:      make(map[string]*domain.Run),
+	}
+}
+
+// SaveWorkflow saves a workflow to the repository
+func (r *MemoryWorkflowRepository) SaveWorkflow(workflow *domain.Workflow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.workflows[workflow.ID] = workflow.Copy()
+
+	r.logger.Debug("Saved workflow",
+		zap.String("workflow_id", workflow.ID),
+		zap.String("user_id", workflow.UserID),
+	)
+
+	return nil
+}
+
+// UpdateWorkflow updates a workflow in the repository
+func (r *MemoryWorkflowRepository) UpdateWorkflow(workflow *domain.Workflow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.workflows[workflow.ID]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("workflow with ID %s not found", workflow.ID), nil)
+	}
+
+	r.workflows[workflow.ID] = workflow.Copy()
+
+	return nil
+}
+
+// GetWorkflowByID gets a workflow by ID from the repository
+func (r *MemoryWorkflowRepository) GetWorkflowByID(id string) (*domain.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workflow, ok := r.workflows[id]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("workflow with ID %s not found", id), nil)
+	}
+
+	return workflow.Copy(), nil
+}
+
+// ListWorkflows lists the workflows of a user, or of all users if userID is empty, newest first
+func (r *MemoryWorkflowRepository) ListWorkflows(userID string) ([]*domain.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workflows := make([]*domain.Workflow, 0)
+	for _, workflow := range r.workflows {
+		if userID == "" || workflow.UserID == userID {
+			workflows = append(workflows, workflow.Copy())
+		}
+	}
+
+	sort.Slice(workflows, func(i, j int) bool {
+		return workflows[i].CreatedAt.After(workflows[j].CreatedAt)
+	})
+
+	return workflows, nil
+}
+
+// DeleteWorkflow deletes a workflow and its runs from the repository
+func (r *MemoryWorkflowRepository) DeleteWorkflow(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.workflows[id]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("workflow with ID %s not found", id), nil)
+	}
+
+	delete(r.workflows, id)
+	for runID, run := range r.runs {
+		if run.WorkflowID == id {
+			delete(r.runs, runID)
+		}
+	}
+
+	r.logger.Debug("Deleted workflow", zap.String("workflow_id", id))
+
+	return nil
+}
+
+// SaveRun saves a workflow run to the repository
+func (r *MemoryWorkflowRepository) SaveRun(run *domain.Run) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.runs[run.ID] = run.Copy()
+
+	r.logger.Debug("Saved workflow run",
+		zap.String("run_id", run.ID),
+		zap.String("workflow_id", run.WorkflowID),
+	)
+
+	return nil
+}
+
+// UpdateRun updates a workflow run in the repository.
+// Runs of deleted workflows are dropped.
+func (r *MemoryWorkflowRepository) UpdateRun(run *domain.Run) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.runs[run.ID]; !ok {
+		return errors.NewNotFound(fmt.Sprintf("workflow run with ID %s not found", run.ID), nil)
+	}
+
+	r.runs[run.ID] = run.Copy()
+
+	return nil
+}
+
+// GetRunByID gets a workflow run by ID from the repository
+func (r *MemoryWorkflowRepository) GetRunByID(id string) (*domain.Run, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	run, ok := r.runs[id]
+	if !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("workflow run with ID %s not found", id), nil)
+	}
+
+	return run.Copy(), nil
+}
+
+// ListRuns lists the runs of a workflow, newest first
+func (r *MemoryWorkflowRepository) ListRuns(workflowID string) ([]*domain.Run, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	runs := make([]*domain.Run, 0)
+	for _, run := range r.runs {
+		if run.WorkflowID == workflowID {
+			runs = append(runs, run.Copy())
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].CreatedAt.After(runs[j].CreatedAt)
+	})
+
+	return runs, nil
+}
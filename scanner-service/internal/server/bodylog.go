@@ -0,0 +1,110 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// BodyLoggingMiddleware logs the request and response bodies of each request at debug
+// level, e.g. to diagnose client integrations in staging. Bodies are cut after the
+// configured size and masked by the redaction of the logger; bodies of binary content
+// types are omitted. Requests are passed through unchanged while debug logs are disabled.
+func BodyLoggingMiddleware(cfg config.BodyLoggingConfig, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !log.Core().Enabled(zapcore.DebugLevel) {
+			c.Next()
+			return
+		}
+
+		// Read the start of the request body and replay it to the handler
+		var requestBody []byte
+		requestTruncated := false
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			head, _ := io.ReadAll(io.LimitReader(c.Request.Body, int64(cfg.MaxSize)+1))
+			c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(head), c.Request.Body), c.Request.Body}
+			requestBody, requestTruncated = truncateBody(head, cfg.MaxSize)
+		}
+
+		writer := &bodyLogWriter{ResponseWriter: c.Writer, maxSize: cfg.MaxSize}
+		c.Writer = writer
+		defer func() {
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+
+		log.WithContext(c.Request.Context()).Debug("HTTP request body",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.String("request_body", loggedBody(c.ContentType(), requestBody, requestTruncated)),
+			zap.String("response_body", loggedBody(c.Writer.Header().Get("Content-Type"), writer.body, writer.truncated)),
+		)
+	}
+}
+
+// readCloser reads from a reader and closes the original body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// bodyLogWriter keeps the start of the response body for logging
+type bodyLogWriter struct {
+	gin.ResponseWriter
+	maxSize   int
+	body      []byte
+	truncated bool
+}
+
+// Write keeps up to the maximum size of the written bytes and writes p
+func (w *bodyLogWriter) Write(p []byte) (int, error) {
+	if remaining := w.maxSize - len(w.body); remaining < len(p) {
+		w.body = append(w.body, p[:max(remaining, 0)]...)
+		w.truncated = true
+	} else {
+		w.body = append(w.body, p...)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// WriteString writes s
+func (w *bodyLogWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// truncateBody cuts body after maxSize bytes
+func truncateBody(body []byte, maxSize int) ([]byte, bool) {
+	if len(body) > maxSize {
+		return body[:maxSize], true
+	}
+	return body, false
+}
+
+// loggedBody returns the body as logged, a placeholder for bodies of binary content types
+func loggedBody(contentType string, body []byte, truncated bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if !textual(contentType) {
+		return "[binary body omitted]"
+	}
+	if truncated {
+		return string(body) + "...[truncated]"
+	}
+	return string(body)
+}
+
+// textual reports whether a body of the content type is text and can be logged
+func textual(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return compressible(contentType) || mediaType == "application/x-www-form-urlencoded"
+}
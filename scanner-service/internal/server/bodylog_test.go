@@ -0,0 +1,60 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLoggingMiddleware(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "service.log")
+	log, err := logger.NewLogger(logger.Config{Level: "debug", Format: "json", Output: output})
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(BodyLoggingMiddleware(config.BodyLoggingConfig{Enabled: true, MaxSize: 64}, log))
+	router.POST("/api/v1/scans", func(c *gin.Context) {
+		// The handler still reads the whole request body
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"api_key":"nmk_secret"`)
+		c.JSON(http.StatusAccepted, gin.H{"id": "scan-1", "status": "pending"})
+	})
+	router.GET("/api/v1/results/:id/export", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/pdf", []byte("%PDF-1.7"))
+	})
+
+	body := `{"target":"10.0.0.1","api_key":"nmk_secret","options":"` + strings.Repeat("x", 100) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/results/r1/export", nil))
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "HTTP request body", entry["msg"])
+	assert.Equal(t, float64(http.StatusAccepted), entry["status"])
+	assert.Equal(t, `{"target":"10.0.0.1","api_key":"[REDACTED]","options":"xxxxxxxxx...[truncated]`, entry["request_body"])
+	assert.Equal(t, `{"id":"scan-1","status":"pending"}`, entry["response_body"])
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "", entry["request_body"])
+	assert.Equal(t, "[binary body omitted]", entry["response_body"])
+}
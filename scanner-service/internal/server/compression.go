@@ -0,0 +1,276 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// compressor creates the encoders of a content encoding and reuses them between responses
+type compressor struct {
+	pool sync.Pool
+}
+
+// encoder is a pooled compressing writer
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// newCompressor creates a compressor of the encoders created by newEncoder
+func newCompressor(newEncoder func() encoder) *compressor {
+	return &compressor{pool: sync.Pool{New: func() any { return newEncoder() }}}
+}
+
+// get returns an encoder writing to w
+func (c *compressor) get(w io.Writer) encoder {
+	enc := c.pool.Get().(encoder)
+	enc.Reset(w)
+	return enc
+}
+
+// put returns a closed encoder to the pool
+func (c *compressor) put(enc encoder) {
+	enc.Reset(io.Discard)
+	c.pool.Put(enc)
+}
+
+// CompressionMiddleware compresses responses with the encoding preferred by the
+// Accept-Encoding header of the client. Responses smaller than the configured minimum,
+// already encoded or of content types that do not compress well are sent unchanged.
+func CompressionMiddleware(cfg config.CompressionConfig) gin.HandlerFunc {
+	compressors := make(map[string]*compressor, len(cfg.Encodings))
+	for _, encoding := range cfg.Encodings {
+		switch encoding {
+		case "gzip":
+			compressors[encoding] = newCompressor(func() encoder {
+				enc, _ := gzip.NewWriterLevel(io.Discard, cfg.GzipLevel) // The level was validated with the configuration
+				return enc
+			})
+		case "br":
+			compressors[encoding] = newCompressor(func() encoder {
+				return brotli.NewWriterLevel(io.Discard, cfg.BrotliLevel)
+			})
+		}
+	}
+
+	return func(c *gin.Context) {
+		// Ranges refer to the unencoded content and upgraded connections are not HTTP responses
+		if c.Request.Method == http.MethodHead || c.GetHeader("Range") != "" || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"), cfg.Encodings)
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{
+			ResponseWriter: c.Writer,
+			encoding:       encoding,
+			compressor:     compressors[encoding],
+			minSize:        cfg.MinSize,
+		}
+		c.Writer = writer
+		defer func() {
+			writer.close()
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding returns the supported encoding the client accepts with the highest
+// quality, preferring earlier supported encodings on ties, or "" for no encoding
+func negotiateEncoding(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+		if coding == "*" {
+			wildcard = quality
+		} else {
+			qualities[coding] = quality
+		}
+	}
+
+	best, bestQuality := "", 0.0
+	for _, encoding := range supported {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality = wildcard
+		}
+		if quality > bestQuality {
+			best, bestQuality = encoding, quality
+		}
+	}
+	return best
+}
+
+// compressibleTypes lists the media types worth compressing besides text/*
+var compressibleTypes = []string{
+	"application/json",
+	"application/x-ndjson",
+	"application/xml",
+	"application/javascript",
+	"application/yaml",
+	"application/graphql-response+json",
+	"image/svg+xml",
+}
+
+// compressible reports whether a response of the content type is worth compressing
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "+xml") || slices.Contains(compressibleTypes, mediaType)
+}
+
+// compressWriter buffers the start of a response until it is known whether the response
+// is compressed, then writes it through an encoder or unchanged
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding   string
+	compressor *compressor
+	minSize    int
+
+	buf     []byte
+	decided bool
+	encoder encoder
+}
+
+// Write buffers p until the minimum size is reached, then compresses the response
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, p...)
+		if len(w.buf) < w.minSize {
+			return len(p), nil
+		}
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// WriteString writes s
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written reports whether the response was started, including buffered content
+func (w *compressWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends the buffered response. Streamed responses are compressed regardless of
+// the minimum size.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.start(true); err != nil {
+			return
+		}
+	}
+	if w.encoder != nil {
+		if err := w.encoder.Flush(); err != nil {
+			return
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+// start decides whether the response is compressed and writes the buffered content
+func (w *compressWriter) start(compress bool) error {
+	w.decided = true
+
+	header := w.ResponseWriter.Header()
+	if header.Get("Content-Type") == "" && len(w.buf) > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+	status := w.ResponseWriter.Status()
+	compress = compress && !w.ResponseWriter.Written() && header.Get("Content-Encoding") == "" &&
+		compressible(header.Get("Content-Type")) && status != http.StatusNoContent && status != http.StatusNotModified
+	if compress {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		w.weakenETag()
+		w.encoder = w.compressor.get(w.ResponseWriter)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// weakenETag marks the ETag of the response as weak, as the encoded representation
+// differs from the unencoded one
+func (w *compressWriter) weakenETag() {
+	header := w.ResponseWriter.Header()
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+}
+
+// close sends a response that stayed below the minimum size unchanged and finishes
+// the compressed stream otherwise
+func (w *compressWriter) close() {
+	if !w.decided {
+		if len(w.buf) == 0 {
+			if w.ResponseWriter.Status() == http.StatusNotModified {
+				// Not modified responses carry the ETag of the encoded representation
+				w.weakenETag()
+			}
+			return
+		}
+		_ = w.start(false)
+		return
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+		w.compressor.put(w.encoder)
+		w.encoder = nil
+	}
+}
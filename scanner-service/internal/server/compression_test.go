@@ -0,0 +1,151 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	supported := []string{"br", "gzip"}
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"gzip;q=1.0, br;q=0.5", "gzip"},
+		{"br;q=0, gzip", "gzip"},
+		{"deflate, identity", ""},
+		{"*", "br"},
+		{"*;q=0.1, gzip;q=0.5", "gzip"},
+		{"gzip;q=0, *", "br"},
+		{"GZIP", "gzip"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, negotiateEncoding(tt.acceptEncoding, supported), tt.acceptEncoding)
+	}
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CompressionMiddleware(config.CompressionConfig{
+		Enabled:     true,
+		Encodings:   []string{"br", "gzip"},
+		MinSize:     1024,
+		GzipLevel:   6,
+		BrotliLevel: 4,
+	}))
+	router.Use(ErrorMiddleware(&logger.Logger{Logger: zap.NewNop()}))
+
+	large := strings.Repeat(`{"port": 443, "state": "open"}`, 100)
+	router.GET("/large", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(large))
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/binary", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/octet-stream", []byte(large))
+	})
+	router.GET("/tagged", func(c *gin.Context) {
+		c.Header("ETag", `"v1"`)
+		if c.GetHeader("If-None-Match") != "" {
+			c.Status(http.StatusNotModified)
+			return
+		}
+		c.Data(http.StatusOK, "application/json", []byte(large))
+	})
+	router.GET("/error", func(c *gin.Context) {
+		c.Error(errors.NewNotFound(strings.Repeat("scan not found ", 100), nil))
+	})
+
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("gzip", func(t *testing.T) {
+		rec := get("/large", "gzip")
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Contains(t, rec.Header().Values("Vary"), "Accept-Encoding")
+		assert.Less(t, rec.Body.Len(), len(large))
+
+		reader, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, large, string(body))
+	})
+
+	t.Run("brotli", func(t *testing.T) {
+		rec := get("/large", "gzip, br")
+		assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+
+		body, err := io.ReadAll(brotli.NewReader(rec.Body))
+		require.NoError(t, err)
+		assert.Equal(t, large, string(body))
+	})
+
+	t.Run("not accepted", func(t *testing.T) {
+		rec := get("/large", "")
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+
+	t.Run("below minimum size", func(t *testing.T) {
+		rec := get("/small", "gzip")
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.JSONEq(t, `{"status": "ok"}`, rec.Body.String())
+	})
+
+	t.Run("incompressible content type", func(t *testing.T) {
+		rec := get("/binary", "gzip")
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, large, rec.Body.String())
+	})
+
+	t.Run("etag", func(t *testing.T) {
+		rec := get("/tagged", "gzip")
+		assert.Equal(t, `W/"v1"`, rec.Header().Get("ETag"))
+
+		req := httptest.NewRequest(http.MethodGet, "/tagged", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		req.Header.Set("If-None-Match", `W/"v1"`)
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Equal(t, `W/"v1"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("rendered errors", func(t *testing.T) {
+		rec := get("/error", "gzip")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+
+		reader, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"type":"NOT_FOUND"`)
+	})
+}
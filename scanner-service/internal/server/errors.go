@@ -0,0 +1,56 @@
+package server
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Type      errors.Type         `json:"type"`
+	Message   string              `json:"message"`
+	Fields    []errors.FieldError `json:"fields,omitempty"`
+	Hint      string              `json:"hint,omitempty"`
+	RequestID string              `json:"request_id,omitempty"`
+}
+
+// ErrorMiddleware renders the last error added with c.Error as an ErrorResponse with the
+// status code of its type. Errors that are not *errors.Error are logged and reported as
+// internal errors without their details.
+func ErrorMiddleware(log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := errors.From(c.Errors.Last().Err)
+		if err.Type == errors.ErrInternal {
+			log.WithContext(c.Request.Context()).Error("Request failed",
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.Error(err),
+			)
+		}
+
+		if err.RetryAfter > 0 {
+			seconds := int(math.Ceil(err.RetryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(seconds))
+		}
+
+		c.JSON(err.StatusCode(), ErrorResponse{
+			Type:      err.Type,
+			Message:   err.Message,
+			Fields:    err.Fields,
+			Hint:      err.Hint,
+			RequestID: requestid.FromContext(c.Request.Context()),
+		})
+	}
+}
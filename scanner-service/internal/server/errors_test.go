@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestErrorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), ErrorMiddleware(&logger.Logger{Logger: zap.NewNop()}))
+	router.GET("/limit", func(c *gin.Context) {
+		c.Error(errors.WithRetryAfter(errors.NewRateLimited("maximum concurrent scans reached", nil), 90500*time.Millisecond))
+	})
+	router.GET("/wrapped", func(c *gin.Context) {
+		c.Error(errors.NewInternal("failed to save scan", stderrors.New("disk full")))
+	})
+	router.GET("/plain", func(c *gin.Context) {
+		c.Error(stderrors.New("connection reset by peer"))
+	})
+	router.GET("/invalid", func(c *gin.Context) {
+		c.Error(errors.NewInvalidField("ports", "format", "invalid port range"))
+	})
+	router.GET("/privileged", func(c *gin.Context) {
+		c.Error(errors.WithHint(errors.NewPermissionDenied("raw sockets not permitted", nil), "use a connect scan"))
+	})
+
+	tests := []struct {
+		path       string
+		status     int
+		errType    errors.Type
+		message    string
+		fields     []errors.FieldError
+		hint       string
+		retryAfter string
+	}{
+		{"/limit", http.StatusTooManyRequests, errors.ErrRateLimited, "maximum concurrent scans reached", nil, "", "91"},
+		{"/wrapped", http.StatusInternalServerError, errors.ErrInternal, "failed to save scan", nil, "", ""},
+		{"/plain", http.StatusInternalServerError, errors.ErrInternal, "internal server error", nil, "", ""},
+		{"/invalid", http.StatusBadRequest, errors.ErrInvalidInput, "invalid port range", []errors.FieldError{
+			{Field: "ports", Constraint: "format", Message: "invalid port range"},
+		}, "", ""},
+		{"/privileged", http.StatusUnprocessableEntity, errors.ErrPermissionDenied, "raw sockets not permitted", nil, "use a connect scan", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-Request-ID", "req-1")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.retryAfter, rec.Header().Get("Retry-After"))
+			var body ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, ErrorResponse{Type: tt.errType, Message: tt.message, Fields: tt.fields, Hint: tt.hint, RequestID: "req-1"}, body)
+		})
+	}
+}
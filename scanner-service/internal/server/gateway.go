@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// GatewayPrefix is the path prefix of the REST API generated from api/proto
+const GatewayPrefix = "/api/v2"
+
+// apiKeyHeader is the HTTP header and gRPC metadata key carrying an API key
+const apiKeyHeader = "x-api-key"
+
+// GatewayRegistrar registers the REST handlers of a service on the gateway, e.g.
+// scannerv1.RegisterScannerServiceHandler
+type GatewayRegistrar func(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error
+
+// Gateway serves the REST API transcoded from the google.api.http annotations of
+// api/proto. Requests are forwarded to the gRPC server, so they pass the same
+// authentication and error interceptors as gRPC calls.
+type Gateway struct {
+	mux  *runtime.ServeMux
+	conn *grpc.ClientConn
+}
+
+// NewGateway creates a gateway forwarding to the gRPC server at addr
+func NewGateway(ctx context.Context, addr string, registrars ...GatewayRegistrar) (*Gateway, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayIncomingHeader),
+		runtime.WithOutgoingHeaderMatcher(gatewayOutgoingHeader),
+		runtime.WithMetadata(gatewayMetadata),
+	)
+	for _, register := range registrars {
+		if err := register(ctx, mux, conn); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to register gateway handler: %w", err)
+		}
+	}
+
+	return &Gateway{
+		mux:  mux,
+		conn: conn,
+	}, nil
+}
+
+// ServeHTTP serves a REST request
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// RegisterRoutes mounts the gateway under GatewayPrefix.
+// The given middleware is applied to all gateway routes.
+func (g *Gateway) RegisterRoutes(router *gin.Engine, middleware ...gin.HandlerFunc) {
+	router.Group(GatewayPrefix, middleware...).Any("/*path", gin.WrapH(g))
+}
+
+// Close closes the connection to the gRPC server
+func (g *Gateway) Close() error {
+	return g.conn.Close()
+}
+
+// gatewayIncomingHeader forwards API keys in addition to the headers forwarded by
+// default. Bearer tokens are forwarded as authorization metadata by the gateway.
+func gatewayIncomingHeader(key string) (string, bool) {
+	if strings.EqualFold(key, apiKeyHeader) {
+		return apiKeyHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// gatewayOutgoingHeader drops the request ID header of the gRPC server, which the
+// request ID middleware already set, and prefixes all other metadata like the default
+func gatewayOutgoingHeader(key string) (string, bool) {
+	if key == requestid.MetadataKey {
+		return "", false
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// gatewayMetadata passes the request ID of the HTTP request on to the gRPC call
+func gatewayMetadata(ctx context.Context, r *http.Request) metadata.MD {
+	return metadata.Pairs(requestid.MetadataKey, requestid.FromContext(r.Context()))
+}
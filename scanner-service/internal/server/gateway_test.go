@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	scannerv1 "github.com/furkansarikaya/nmap-ui-microservices/api/proto/scanner/v1"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeScannerServer records the metadata of the calls it serves
+type fakeScannerServer struct {
+	scannerv1.UnimplementedScannerServiceServer
+	metadata metadata.MD
+}
+
+func (s *fakeScannerServer) StartScan(ctx context.Context, req *scannerv1.StartScanRequest) (*scannerv1.Scan, error) {
+	return &scannerv1.Scan{Id: "scan-1", Options: req.GetOptions(), Status: scannerv1.ScanStatus_SCAN_STATUS_PENDING}, nil
+}
+
+func (s *fakeScannerServer) GetScan(ctx context.Context, req *scannerv1.GetScanRequest) (*scannerv1.Scan, error) {
+	s.metadata, _ = metadata.FromIncomingContext(ctx)
+	if req.GetId() != "scan-1" {
+		return nil, errors.NewNotFound("scan not found", nil)
+	}
+	return &scannerv1.Scan{Id: req.GetId(), Status: scannerv1.ScanStatus_SCAN_STATUS_RUNNING}, nil
+}
+
+// newTestGateway serves fake through a gRPC server on a random port and returns the
+// router the gateway is mounted on with the given middleware
+func newTestGateway(t *testing.T, fake *fakeScannerServer, middleware ...gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+
+	log := &logger.Logger{Logger: zap.NewNop()}
+	grpcServer, err := NewGRPCServer(config.GRPCServerConfig{}, log)
+	require.NoError(t, err)
+	scannerv1.RegisterScannerServiceServer(grpcServer.Server(), fake)
+	go grpcServer.Start()
+	t.Cleanup(grpcServer.Stop)
+
+	gateway, err := NewGateway(context.Background(), grpcServer.LocalAddr(), scannerv1.RegisterScannerServiceHandler)
+	require.NoError(t, err)
+	t.Cleanup(func() { gateway.Close() })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	gateway.RegisterRoutes(router, middleware...)
+	return router
+}
+
+func TestGatewayTranscodesRequests(t *testing.T) {
+	fake := &fakeScannerServer{}
+	router := newTestGateway(t, fake)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/scans/scan-1", nil)
+	req.Header.Set("X-API-Key", "secret-key")
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Request-ID", "req-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var scan map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &scan))
+	assert.Equal(t, "scan-1", scan["id"])
+	assert.Equal(t, "SCAN_STATUS_RUNNING", scan["status"])
+
+	// Credentials and the request ID are passed on to the gRPC call
+	assert.Equal(t, []string{"secret-key"}, fake.metadata.Get("x-api-key"))
+	assert.Equal(t, []string{"Bearer token"}, fake.metadata.Get("authorization"))
+	assert.Equal(t, []string{"req-1"}, fake.metadata.Get("x-request-id"))
+	assert.Equal(t, []string{"req-1"}, w.Header().Values("X-Request-ID"))
+}
+
+func TestGatewayRequestBody(t *testing.T) {
+	router := newTestGateway(t, &fakeScannerServer{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/scans", strings.NewReader(`{"target":"10.0.0.1","ports":"22"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var scan struct {
+		Options struct {
+			Target string `json:"target"`
+			Ports  string `json:"ports"`
+		} `json:"options"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &scan))
+	assert.Equal(t, "10.0.0.1", scan.Options.Target)
+	assert.Equal(t, "22", scan.Options.Ports)
+}
+
+func TestGatewayMapsErrors(t *testing.T) {
+	router := newTestGateway(t, &fakeScannerServer{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/scans/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "scan not found")
+
+	// Methods without a transcoded implementation are reported as such
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/v2/scans/scan-1", nil))
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+}
+
+func TestGatewayRouteRateLimit(t *testing.T) {
+	log := &logger.Logger{Logger: zap.NewNop()}
+	limiter := ratelimit.NewTokenBucket(1, 1)
+	router := newTestGateway(t, &fakeScannerServer{},
+		ErrorMiddleware(log),
+		RouteRateLimitMiddleware(http.MethodPost, GatewayPrefix+"/scans", limiter, ClientIPKey, log),
+	)
+
+	startScan := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/api/v2/scans", strings.NewReader(`{"target":"10.0.0.1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, startScan())
+	assert.Equal(t, http.StatusTooManyRequests, startScan())
+
+	// Other gateway routes are not limited
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/scans/scan-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -7,42 +7,75 @@ import (
 	"time"
 
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // GRPCServer represents a gRPC server
 type GRPCServer struct {
-	server *grpc.Server
-	config config.GRPCServerConfig
-	logger *logger.Logger
-	lis    net.Listener
+	server       *grpc.Server
+	config       config.GRPCServerConfig
+	logger       *logger.Logger
+	accessLogger *logger.Logger // Logger of completed requests, the server logger unless set
+	panicHook    PanicHook
+	lis          net.Listener
 }
 
-// NewGRPCServer creates a new gRPC server
-func NewGRPCServer(cfg config.GRPCServerConfig, log *logger.Logger) (*GRPCServer, error) {
+// NewGRPCServer creates a new gRPC server. The interceptors, e.g. authentication, run
+// after the request ID, logging and error interceptors.
+func NewGRPCServer(cfg config.GRPCServerConfig, log *logger.Logger, interceptors ...grpc.UnaryServerInterceptor) (*GRPCServer, error) {
 	// Create listener
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen: %w", err)
 	}
 
+	s := &GRPCServer{
+		config:       cfg,
+		logger:       log,
+		accessLogger: log,
+		lis:          lis,
+	}
+
 	// Create server with interceptors
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(loggingInterceptor(log)),
+	s.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{
+			requestIDInterceptor(),
+			s.loggingInterceptor(),
+			recoveryInterceptor(log, func(ctx context.Context, report PanicReport) {
+				if s.panicHook != nil {
+					s.panicHook(ctx, report)
+				}
+			}),
+			errorInterceptor(log),
+		}, interceptors...)...),
 	)
 
 	// Enable reflection for grpcurl
-	reflection.Register(server)
-
-	return &GRPCServer{
-		server: server,
-		config: cfg,
-		logger: log,
-		lis:    lis,
-	}, nil
+	reflection.Register(s.server)
+
+	return s, nil
+}
+
+// SetPanicHook sets the hook called with panics recovered while serving requests. It must
+// be called before the server is started.
+func (s *GRPCServer) SetPanicHook(hook PanicHook) {
+	s.panicHook = hook
+}
+
+// SetAccessLogger sets the logger completed requests are logged to, e.g. a separate
+// access log. It must be called before the server is started.
+func (s *GRPCServer) SetAccessLogger(log *logger.Logger) {
+	s.accessLogger = log
 }
 
 // Start starts the gRPC server
@@ -62,8 +95,37 @@ func (s *GRPCServer) Server() *grpc.Server {
 	return s.server
 }
 
-// loggingInterceptor creates a logging interceptor for gRPC
-func loggingInterceptor(log *logger.Logger) grpc.UnaryServerInterceptor {
+// LocalAddr returns the address the server is reached at from this host
+func (s *GRPCServer) LocalAddr() string {
+	return fmt.Sprintf("localhost:%d", s.lis.Addr().(*net.TCPAddr).Port)
+}
+
+// requestIDInterceptor accepts the x-request-id metadata of the client or generates a new one.
+// The ID is returned in the response header and carried in the request context.
+func requestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestid.MetadataKey); len(values) > 0 {
+				id = values[0]
+			}
+		}
+		id = requestid.Ensure(id)
+
+		ctx = requestid.NewContext(ctx, id)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+
+		return handler(ctx, req)
+	}
+}
+
+// loggingInterceptor creates a logging interceptor for gRPC writing to the access logger
+func (s *GRPCServer) loggingInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req interface{},
@@ -80,15 +142,79 @@ func loggingInterceptor(log *logger.Logger) grpc.UnaryServerInterceptor {
 		fields := []zap.Field{
 			zap.String("method", info.FullMethod),
 			zap.Duration("duration", duration),
+			zap.String("request_id", requestid.FromContext(ctx)),
 		}
 
 		if err != nil {
 			fields = append(fields, zap.Error(err))
-			log.Error("gRPC request failed", fields...)
+			s.accessLogger.Error("gRPC request failed", fields...)
 		} else {
-			log.Info("gRPC request completed", fields...)
+			s.accessLogger.Info("gRPC request completed", fields...)
 		}
 
 		return resp, err
 	}
 }
+
+// errorInterceptor converts application errors into gRPC status errors with the code of
+// their type, attaching field errors as BadRequest details. Errors that are neither
+// *errors.Error nor status errors are logged and reported as internal errors without
+// their details, as in ErrorMiddleware.
+func errorInterceptor(log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return resp, err
+		}
+
+		appErr := errors.From(err)
+		if appErr.Type == errors.ErrInternal {
+			log.WithContext(ctx).Error("gRPC request failed",
+				zap.String("method", info.FullMethod),
+				zap.Error(err),
+			)
+		}
+		return nil, grpcStatus(appErr).Err()
+	}
+}
+
+// grpcStatus returns the gRPC status of an application error, detailing its invalid
+// fields, its hint and when to retry it
+func grpcStatus(err *errors.Error) *status.Status {
+	st := status.New(err.GRPCCode(), err.Message)
+
+	var details []protoadapt.MessageV1
+	if len(err.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(err.Fields))
+		for i, field := range err.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+				Reason:      field.Constraint,
+			}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if err.Hint != "" {
+		details = append(details, &errdetails.LocalizedMessage{Locale: "en-US", Message: err.Hint})
+	}
+	if err.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(err.RetryAfter)})
+	}
+	if len(details) == 0 {
+		return st
+	}
+
+	if detailed, detailsErr := st.WithDetails(details...); detailsErr == nil {
+		return detailed
+	}
+	return st
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorInterceptor(t *testing.T) {
+	interceptor := errorInterceptor(&logger.Logger{Logger: zap.NewNop()})
+	info := &grpc.UnaryServerInfo{FullMethod: "/nmapui.scanner.v1.ScannerService/StartScan"}
+
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{"not found", errors.NewNotFound("scan not found", nil), codes.NotFound, "scan not found"},
+		{"forbidden", errors.NewForbidden("operator role required", nil), codes.PermissionDenied, "operator role required"},
+		{"unavailable", errors.NewUnavailable("maximum concurrent scans reached", nil), codes.Unavailable, "maximum concurrent scans reached"},
+		{"plain", stderrors.New("connection reset by peer"), codes.Internal, "internal server error"},
+		{"status", status.Error(codes.Unauthenticated, "invalid agent token"), codes.Unauthenticated, "invalid agent token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.err
+			})
+
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.message, st.Message())
+		})
+	}
+}
+
+func TestErrorInterceptorFieldViolations(t *testing.T) {
+	interceptor := errorInterceptor(&logger.Logger{Logger: zap.NewNop()})
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.NewInvalidField("ports", "format", "invalid port range")
+	})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	violation := badRequest.GetFieldViolations()[0]
+	assert.Equal(t, "ports", violation.GetField())
+	assert.Equal(t, "format", violation.GetReason())
+	assert.Equal(t, "invalid port range", violation.GetDescription())
+}
+
+func TestErrorInterceptorHint(t *testing.T) {
+	interceptor := errorInterceptor(&logger.Logger{Logger: zap.NewNop()})
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.WithHint(errors.NewPermissionDenied("raw sockets not permitted", nil), "use a connect scan")
+	})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Len(t, st.Details(), 1)
+	hint, ok := st.Details()[0].(*errdetails.LocalizedMessage)
+	require.True(t, ok)
+	assert.Equal(t, "use a connect scan", hint.GetMessage())
+}
+
+func TestErrorInterceptorRetryInfo(t *testing.T) {
+	interceptor := errorInterceptor(&logger.Logger{Logger: zap.NewNop()})
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.WithRetryAfter(errors.NewRateLimited("maximum concurrent scans reached", nil), time.Minute)
+	})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, time.Minute, retryInfo.GetRetryDelay().AsDuration())
+}
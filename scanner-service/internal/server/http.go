@@ -2,22 +2,29 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
 	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/acme/autocert"
 )
 
 // HTTPServer represents an HTTP server
 type HTTPServer struct {
-	server *http.Server
-	router *gin.Engine
-	logger *logger.Logger
-	config config.HTTPServerConfig
+	server          *http.Server
+	challengeServer *http.Server // Serves ACME challenges and redirects to HTTPS when autocert is enabled
+	router          *gin.Engine
+	logger          *logger.Logger
+	accessLogger    *logger.Logger // Logger of completed requests, the server logger unless set
+	panicHook       PanicHook
+	config          config.HTTPServerConfig
 }
 
 // NewHTTPServer creates a new HTTP server
@@ -38,12 +45,38 @@ func NewHTTPServer(cfg config.HTTPServerConfig, log *logger.Logger) *HTTPServer
 		WriteTimeout: cfg.WriteTimeout,
 	}
 
-	return &HTTPServer{
-		server: server,
-		router: router,
-		logger: log,
-		config: cfg,
+	httpServer := &HTTPServer{
+		server:       server,
+		router:       router,
+		logger:       log,
+		accessLogger: log,
+		config:       cfg,
 	}
+
+	// The TLS settings are prepared before Start runs, so that Stop, which may run
+	// concurrently with it, only reads them
+	switch tlsConfig := cfg.TLS; {
+	case tlsConfig.Enabled && tlsConfig.Autocert.Enabled:
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(tlsConfig.Autocert.Domains...),
+			Cache:      autocert.DirCache(tlsConfig.Autocert.CacheDir),
+			Email:      tlsConfig.Autocert.Email,
+		}
+
+		server.TLSConfig = manager.TLSConfig()
+		server.TLSConfig.MinVersion = tls.VersionTLS12
+
+		httpServer.challengeServer = &http.Server{
+			Addr:              fmt.Sprintf(":%d", tlsConfig.Autocert.HTTPPort),
+			Handler:           manager.HTTPHandler(nil),
+			ReadHeaderTimeout: cfg.ReadTimeout,
+		}
+	case tlsConfig.Enabled:
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return httpServer
 }
 
 // Router returns the Gin router
@@ -51,18 +84,65 @@ func (s *HTTPServer) Router() *gin.Engine {
 	return s.router
 }
 
-// Start starts the HTTP server
+// Start starts the HTTP server, serving HTTPS when TLS is enabled
 func (s *HTTPServer) Start() error {
-	s.logger.Info("Starting HTTP server", zap.Int("port", s.config.Port))
-	return s.server.ListenAndServe()
+	tlsConfig := s.config.TLS
+	if !tlsConfig.Enabled {
+		s.logger.Info("Starting HTTP server", zap.Int("port", s.config.Port))
+		return s.server.ListenAndServe()
+	}
+
+	if tlsConfig.Autocert.Enabled {
+		if len(tlsConfig.Autocert.Domains) == 0 {
+			return fmt.Errorf("autocert requires at least one domain")
+		}
+
+		go func() {
+			if err := s.challengeServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				s.logger.Error("ACME challenge server failed", zap.Error(err))
+			}
+		}()
+
+		s.logger.Info("Starting HTTPS server with automatic certificates",
+			zap.Int("port", s.config.Port),
+			zap.Strings("domains", tlsConfig.Autocert.Domains),
+		)
+		return s.server.ListenAndServeTLS("", "")
+	}
+
+	if tlsConfig.CertFile == "" || tlsConfig.KeyFile == "" {
+		return fmt.Errorf("TLS requires cert_file and key_file or autocert")
+	}
+
+	s.logger.Info("Starting HTTPS server", zap.Int("port", s.config.Port))
+	return s.server.ListenAndServeTLS(tlsConfig.CertFile, tlsConfig.KeyFile)
 }
 
 // Stop stops the HTTP server
 func (s *HTTPServer) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping HTTP server")
+
+	if s.challengeServer != nil {
+		if err := s.challengeServer.Shutdown(ctx); err != nil {
+			s.logger.Warn("Failed to stop ACME challenge server", zap.Error(err))
+		}
+	}
+
 	return s.server.Shutdown(ctx)
 }
 
+// SetAccessLogger sets the logger completed requests are logged to, e.g. a separate
+// access log. It must be called before the server is started.
+func (s *HTTPServer) SetAccessLogger(log *logger.Logger) {
+	s.accessLogger = log
+}
+
+// SetPanicHook sets the hook called with panics recovered while serving requests. It must
+// be called before SetupMiddleware.
+func (s *HTTPServer) SetPanicHook(hook PanicHook) {
+	s.panicHook = hook
+}
+
 // RegisterRoutes registers all HTTP routes
 func (s *HTTPServer) RegisterRoutes(registerFunc func(router *gin.Engine)) {
 	registerFunc(s.router)
@@ -70,8 +150,13 @@ func (s *HTTPServer) RegisterRoutes(registerFunc func(router *gin.Engine)) {
 
 // SetupMiddleware sets up common middleware
 func (s *HTTPServer) SetupMiddleware() {
-	// Recovery middleware
-	s.router.Use(gin.Recovery())
+	// Request ID middleware
+	s.router.Use(RequestIDMiddleware())
+
+	// Security headers, set before any response is written
+	if s.config.Security.Enabled {
+		s.router.Use(SecurityHeadersMiddleware(s.config.Security))
+	}
 
 	// Logger middleware
 	s.router.Use(func(c *gin.Context) {
@@ -88,20 +173,36 @@ func (s *HTTPServer) SetupMiddleware() {
 			path = path + "?" + query
 		}
 
-		s.logger.Info("HTTP request",
+		s.accessLogger.Info("HTTP request",
 			zap.String("method", c.Request.Method),
 			zap.String("path", path),
 			zap.Int("status", status),
 			zap.Duration("latency", latency),
 			zap.String("client_ip", c.ClientIP()),
+			zap.String("request_id", requestid.FromContext(c.Request.Context())),
 		)
 	})
 
+	// Recovery middleware, after the logger so failed requests are logged as well
+	s.router.Use(RecoveryMiddleware(s.logger, s.panicHook))
+
+	// Compress responses, including rendered errors
+	if s.config.Compression.Enabled {
+		s.router.Use(CompressionMiddleware(s.config.Compression))
+	}
+
+	// Render errors recorded by handlers and middleware
+	s.router.Use(ErrorMiddleware(s.logger))
+	s.router.NoRoute(func(c *gin.Context) {
+		c.Error(errors.NewNotFound("route not found", nil))
+	})
+
 	// CORS middleware
 	s.router.Use(func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, X-Request-ID, If-None-Match")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, Retry-After, ETag, Content-Disposition")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
@@ -111,3 +212,17 @@ func (s *HTTPServer) SetupMiddleware() {
 		c.Next()
 	})
 }
+
+// RequestIDMiddleware accepts the X-Request-ID of the client or generates a new one.
+// The ID is echoed in the response and carried in the request context.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := requestid.Ensure(c.GetHeader(requestid.Header))
+
+		c.Set("request_id", id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
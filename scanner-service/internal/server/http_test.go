@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStopWhileStartingAutocert(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	server := NewHTTPServer(config.HTTPServerConfig{
+		Port: port,
+		TLS: config.TLSConfig{
+			Enabled: true,
+			Autocert: config.AutocertConfig{
+				Enabled:  true,
+				Domains:  []string{"scanner.example.com"},
+				CacheDir: t.TempDir(),
+			},
+		},
+	}, &logger.Logger{Logger: zap.NewNop()})
+	require.NotNil(t, server.challengeServer)
+
+	// Start and Stop run in different goroutines, as in main
+	started := make(chan error, 1)
+	go func() {
+		started <- server.Start()
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, server.Stop(ctx))
+	assert.ErrorIs(t, <-started, http.ErrServerClosed)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KeyFunc extracts the rate limiting key of a request
+type KeyFunc func(c *gin.Context) string
+
+// ClientIPKey limits requests per client IP
+func ClientIPKey(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// UserKey limits requests per authenticated user, falling back to the client IP
+func UserKey(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return "user:" + userID
+	}
+	return ClientIPKey(c)
+}
+
+// RateLimitMiddleware rejects requests over the limit with 429 Too Many Requests
+func RateLimitMiddleware(limiter ratelimit.Limiter, keyFunc KeyFunc, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := keyFunc(c)
+
+		allowed, retryAfter := limiter.Allow(key)
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+
+			log.Warn("Rate limit exceeded",
+				zap.String("key", key),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+			)
+
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			c.Error(errors.NewRateLimited("rate limit exceeded, retry after "+strconv.Itoa(seconds)+" seconds", nil))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RouteRateLimitMiddleware applies a rate limit only to the route matching method and path.
+// The path is the route pattern, e.g. "/api/v1/scans/:id"; an empty method matches any method.
+// Requests served by the REST gateway have no route pattern of their own, so a path without
+// parameters also matches the request path, e.g. "/api/v2/scans".
+func RouteRateLimitMiddleware(method, path string, limiter ratelimit.Limiter, keyFunc KeyFunc, log *logger.Logger) gin.HandlerFunc {
+	limit := RateLimitMiddleware(limiter, keyFunc, log)
+
+	return func(c *gin.Context) {
+		if (method != "" && c.Request.Method != method) || (c.FullPath() != path && c.Request.URL.Path != path) {
+			c.Next()
+			return
+		}
+
+		limit(c)
+	}
+}
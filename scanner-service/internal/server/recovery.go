@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PanicReport describes a panic recovered while serving a request
+type PanicReport struct {
+	Value     string // Value the handler panicked with
+	Stack     string // Stack of the panicking goroutine
+	Method    string // HTTP method or full gRPC method
+	Path      string // HTTP path, empty for gRPC requests
+	RequestID string
+}
+
+// PanicHook is called with the panics recovered while serving requests, e.g. to alert
+// operators. It is called on the request goroutine and should not block.
+type PanicHook func(ctx context.Context, report PanicReport)
+
+// RecoveryMiddleware recovers from panics of later handlers, logs them with their stack
+// and responds with an internal error in the ErrorResponse format. hook, if not nil, is
+// called with every recovered panic.
+func RecoveryMiddleware(log *logger.Logger, hook PanicHook) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+			// Aborted responses are expected to panic up to net/http
+			if value == http.ErrAbortHandler {
+				panic(value)
+			}
+
+			ctx := c.Request.Context()
+			recovered(ctx, log, hook, PanicReport{
+				Value:     fmt.Sprint(value),
+				Stack:     string(debug.Stack()),
+				Method:    c.Request.Method,
+				Path:      c.Request.URL.Path,
+				RequestID: requestid.FromContext(ctx),
+			})
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			err := errors.NewInternal("internal server error", nil)
+			c.AbortWithStatusJSON(err.StatusCode(), ErrorResponse{
+				Type:      err.Type,
+				Message:   err.Message,
+				RequestID: requestid.FromContext(ctx),
+			})
+		}()
+
+		c.Next()
+	}
+}
+
+// recoveryInterceptor recovers from panics of later interceptors and handlers like
+// RecoveryMiddleware, returning an internal error status
+func recoveryInterceptor(log *logger.Logger, hook PanicHook) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		defer func() {
+			if value := recover(); value != nil {
+				recovered(ctx, log, hook, PanicReport{
+					Value:     fmt.Sprint(value),
+					Stack:     string(debug.Stack()),
+					Method:    info.FullMethod,
+					RequestID: requestid.FromContext(ctx),
+				})
+				resp, err = nil, status.Error(codes.Internal, "internal server error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// recovered logs a recovered panic and passes it to the hook. The stack of the panic is
+// logged as the stack trace of the error entry.
+func recovered(ctx context.Context, log *logger.Logger, hook PanicHook, report PanicReport) {
+	log.WithContext(ctx).Error("Recovered from panic",
+		zap.String("panic", report.Value),
+		zap.String("method", report.Method),
+		zap.String("path", report.Path),
+	)
+	if hook != nil {
+		hook(ctx, report)
+	}
+}
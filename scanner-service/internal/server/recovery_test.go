@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	var reports []PanicReport
+	hook := func(ctx context.Context, report PanicReport) {
+		reports = append(reports, report)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), RecoveryMiddleware(&logger.Logger{Logger: zap.NewNop()}, hook))
+	router.GET("/api/v1/scans/:id", func(c *gin.Context) {
+		var result map[string]string
+		result["id"] = c.Param("id") // Panics on the nil map
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1", nil)
+	req.Header.Set(requestid.Header, "req-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var body ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, ErrorResponse{Type: errors.ErrInternal, Message: "internal server error", RequestID: "req-1"}, body)
+
+	require.Len(t, reports, 1)
+	assert.Equal(t, "assignment to entry in nil map", reports[0].Value)
+	assert.Equal(t, http.MethodGet, reports[0].Method)
+	assert.Equal(t, "/api/v1/scans/scan-1", reports[0].Path)
+	assert.Equal(t, "req-1", reports[0].RequestID)
+	assert.Contains(t, reports[0].Stack, "recovery_test.go")
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	var reports []PanicReport
+	interceptor := recoveryInterceptor(&logger.Logger{Logger: zap.NewNop()}, func(ctx context.Context, report PanicReport) {
+		reports = append(reports, report)
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/nmapui.scanner.v1.ScannerService/GetScan"}
+
+	_, err := interceptor(requestid.NewContext(context.Background(), "req-1"), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("scan adapter not configured")
+	})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "internal server error", st.Message())
+
+	require.Len(t, reports, 1)
+	assert.Equal(t, "scan adapter not configured", reports[0].Value)
+	assert.Equal(t, info.FullMethod, reports[0].Method)
+	assert.Equal(t, "req-1", reports[0].RequestID)
+
+	// Requests without panic are passed through
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHeadersMiddleware sets the configured security headers on every response.
+// Empty values omit the header. Strict-Transport-Security is only sent on HTTPS
+// requests, including requests a TLS terminating proxy forwarded with X-Forwarded-Proto,
+// as browsers ignore it over plain HTTP. Handlers may override the headers, e.g. a
+// page-specific Content-Security-Policy.
+func SecurityHeadersMiddleware(cfg config.SecurityHeadersConfig) gin.HandlerFunc {
+	headers := map[string]string{
+		"X-Content-Type-Options":  cfg.ContentTypeOptions,
+		"X-Frame-Options":         cfg.FrameOptions,
+		"Content-Security-Policy": cfg.ContentSecurityPolicy,
+		"Referrer-Policy":         cfg.ReferrerPolicy,
+	}
+	for name, value := range headers {
+		if value == "" {
+			delete(headers, name)
+		}
+	}
+	hsts := strictTransportSecurity(cfg.HSTS)
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		for name, value := range headers {
+			header.Set(name, value)
+		}
+		if hsts != "" && (c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")) {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+
+		c.Next()
+	}
+}
+
+// strictTransportSecurity returns the Strict-Transport-Security header value of the
+// configuration, empty if HSTS is disabled
+func strictTransportSecurity(cfg config.HSTSConfig) string {
+	if !cfg.Enabled {
+		return ""
+	}
+	value := "max-age=" + strconv.Itoa(int(cfg.MaxAge.Seconds()))
+	if cfg.IncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if cfg.Preload {
+		value += "; preload"
+	}
+	return value
+}
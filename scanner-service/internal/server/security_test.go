@@ -0,0 +1,59 @@
+package server
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/internal/config"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(SecurityHeadersMiddleware(config.SecurityHeadersConfig{
+		Enabled:               true,
+		ContentTypeOptions:    "nosniff",
+		FrameOptions:          "DENY",
+		ContentSecurityPolicy: "default-src 'none'",
+		HSTS:                  config.HSTSConfig{Enabled: true, MaxAge: 365 * 24 * time.Hour, IncludeSubdomains: true},
+	}))
+	router.GET("/api/v1/scans", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"scans": []string{}})
+	})
+	router.GET("/api/v1/docs", func(c *gin.Context) {
+		c.Header("Content-Security-Policy", "default-src 'self'")
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "default-src 'none'", w.Header().Get("Content-Security-Policy"))
+	// Empty values omit the header, HSTS is not sent over plain HTTP
+	assert.NotContains(t, w.Header(), "Referrer-Policy")
+	assert.NotContains(t, w.Header(), "Strict-Transport-Security")
+
+	// HTTPS requests, directly or through a TLS terminating proxy
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	req.TLS = &tls.ConnectionState{}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, "max-age=31536000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, "max-age=31536000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+
+	// Handlers may override the headers
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+	assert.Equal(t, "default-src 'self'", w.Header().Get("Content-Security-Policy"))
+}
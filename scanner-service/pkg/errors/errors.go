@@ -1,8 +1,12 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
 )
 
 // Type represents an error type
@@ -33,13 +37,42 @@ const (
 
 	// ErrAlreadyExists is returned when a resource already exists
 	ErrAlreadyExists Type = "ALREADY_EXISTS"
+
+	// ErrRateLimited is returned when the caller sent too many requests
+	ErrRateLimited Type = "RATE_LIMITED"
+
+	// ErrUpstream is returned when an external service the request depends on failed
+	ErrUpstream Type = "UPSTREAM"
+
+	// ErrPermissionDenied is returned when the scanner lacks the privileges a scan needs,
+	// such as raw sockets
+	ErrPermissionDenied Type = "PERMISSION_DENIED"
+
+	// ErrUnresolvableHost is returned when a target host name does not resolve
+	ErrUnresolvableHost Type = "UNRESOLVABLE_HOST"
+
+	// ErrInterfaceNotFound is returned when the network interface of a scan does not exist
+	ErrInterfaceNotFound Type = "INTERFACE_NOT_FOUND"
+
+	// ErrInvalidPortSpec is returned when the scanner rejects the ports of a scan
+	ErrInvalidPortSpec Type = "INVALID_PORT_SPEC"
 )
 
 // Error represents an application error
 type Error struct {
-	Type    Type   `json:"type"`
-	Message string `json:"message"`
-	Err     error  `json:"-"`
+	Type       Type          `json:"type"`
+	Message    string        `json:"message"`
+	Fields     []FieldError  `json:"fields,omitempty"`
+	Hint       string        `json:"hint,omitempty"` // How the user can remedy the error
+	RetryAfter time.Duration `json:"-"`              // When the caller may retry a rejected request, 0 if unknown
+	Err        error         `json:"-"`
+}
+
+// FieldError describes why a field of a request is invalid
+type FieldError struct {
+	Field      string `json:"field"`      // JSON name of the field, e.g. "ports" or "decoys[2]"
+	Constraint string `json:"constraint"` // Violated constraint, e.g. "required" or "max"
+	Message    string `json:"message"`    // Human readable description
 }
 
 // Error returns the error message
@@ -60,8 +93,10 @@ func (e *Error) StatusCode() int {
 	switch e.Type {
 	case ErrNotFound:
 		return http.StatusNotFound
-	case ErrInvalidInput:
+	case ErrInvalidInput, ErrUnresolvableHost, ErrInvalidPortSpec:
 		return http.StatusBadRequest
+	case ErrPermissionDenied, ErrInterfaceNotFound:
+		return http.StatusUnprocessableEntity
 	case ErrTimeout:
 		return http.StatusGatewayTimeout
 	case ErrUnavailable:
@@ -72,11 +107,119 @@ func (e *Error) StatusCode() int {
 		return http.StatusForbidden
 	case ErrAlreadyExists:
 		return http.StatusConflict
+	case ErrRateLimited:
+		return http.StatusTooManyRequests
+	case ErrUpstream:
+		return http.StatusBadGateway
 	default:
 		return http.StatusInternalServerError
 	}
 }
 
+// GRPCCode returns the gRPC status code for the error
+func (e *Error) GRPCCode() codes.Code {
+	switch e.Type {
+	case ErrNotFound:
+		return codes.NotFound
+	case ErrInvalidInput, ErrUnresolvableHost, ErrInvalidPortSpec:
+		return codes.InvalidArgument
+	case ErrPermissionDenied, ErrInterfaceNotFound:
+		return codes.FailedPrecondition
+	case ErrTimeout:
+		return codes.DeadlineExceeded
+	case ErrUnavailable, ErrUpstream:
+		return codes.Unavailable
+	case ErrUnauthorized:
+		return codes.Unauthenticated
+	case ErrForbidden:
+		return codes.PermissionDenied
+	case ErrAlreadyExists:
+		return codes.AlreadyExists
+	case ErrRateLimited:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+// HTTPStatusCode returns the HTTP status code for any error.
+// Errors that do not wrap an *Error map to 500.
+func HTTPStatusCode(err error) int {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
+
+// From returns the *Error wrapped by err, or an internal Error wrapping err
+// if err is not an application error
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return NewInternal("internal server error", err)
+}
+
+// WithField attributes an invalid input error without field details to a field of the
+// request, keeping its message. Other errors are returned unchanged.
+func WithField(err error, field, constraint string) error {
+	var appErr *Error
+	if !errors.As(err, &appErr) || appErr.Type != ErrInvalidInput || len(appErr.Fields) > 0 {
+		return err
+	}
+
+	withField := *appErr
+	withField.Fields = []FieldError{{Field: field, Constraint: constraint, Message: appErr.Message}}
+	return &withField
+}
+
+// WithHint returns err with a hint on how the user can remedy it. Errors that are not
+// application errors are returned unchanged.
+func WithHint(err error, hint string) error {
+	var appErr *Error
+	if !errors.As(err, &appErr) {
+		return err
+	}
+
+	withHint := *appErr
+	withHint.Hint = hint
+	return &withHint
+}
+
+// WithRetryAfter returns err with the delay after which the caller may retry the
+// rejected request. Errors that are not application errors are returned unchanged.
+func WithRetryAfter(err error, retryAfter time.Duration) error {
+	var appErr *Error
+	if !errors.As(err, &appErr) {
+		return err
+	}
+
+	withRetryAfter := *appErr
+	withRetryAfter.RetryAfter = retryAfter
+	return &withRetryAfter
+}
+
+// RenameFields returns err with its fields renamed according to names, for requests
+// naming fields differently from the domain. Errors without fields are returned unchanged.
+func RenameFields(err error, names map[string]string) error {
+	var appErr *Error
+	if !errors.As(err, &appErr) || len(appErr.Fields) == 0 {
+		return err
+	}
+
+	renamed := *appErr
+	renamed.Fields = make([]FieldError, len(appErr.Fields))
+	for i, field := range appErr.Fields {
+		if name, ok := names[field.Field]; ok {
+			field.Field = name
+		}
+		renamed.Fields[i] = field
+	}
+	return &renamed
+}
+
 // New creates a new Error
 func New(errType Type, message string, err error) *Error {
 	return &Error{
@@ -125,3 +268,48 @@ func NewForbidden(message string, err error) *Error {
 func NewAlreadyExists(message string, err error) *Error {
 	return New(ErrAlreadyExists, message, err)
 }
+
+// NewRateLimited creates a new rate limited Error
+func NewRateLimited(message string, err error) *Error {
+	return New(ErrRateLimited, message, err)
+}
+
+// NewUpstream creates a new upstream Error
+func NewUpstream(message string, err error) *Error {
+	return New(ErrUpstream, message, err)
+}
+
+// NewPermissionDenied creates a new permission denied Error
+func NewPermissionDenied(message string, err error) *Error {
+	return New(ErrPermissionDenied, message, err)
+}
+
+// NewUnresolvableHost creates a new unresolvable host Error
+func NewUnresolvableHost(message string, err error) *Error {
+	return New(ErrUnresolvableHost, message, err)
+}
+
+// NewInterfaceNotFound creates a new interface not found Error
+func NewInterfaceNotFound(message string, err error) *Error {
+	return New(ErrInterfaceNotFound, message, err)
+}
+
+// NewInvalidPortSpec creates a new invalid port specification Error
+func NewInvalidPortSpec(message string, err error) *Error {
+	return New(ErrInvalidPortSpec, message, err)
+}
+
+// NewInvalidField creates a new invalid input Error caused by a single field
+func NewInvalidField(field, constraint, message string) *Error {
+	return NewValidation(message, []FieldError{{Field: field, Constraint: constraint, Message: message}}, nil)
+}
+
+// NewValidation creates a new invalid input Error listing the invalid fields
+func NewValidation(message string, fields []FieldError, err error) *Error {
+	return &Error{
+		Type:    ErrInvalidInput,
+		Message: message,
+		Fields:  fields,
+		Err:     err,
+	}
+}
@@ -0,0 +1,33 @@
+package grpcjson
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the name of the codec and the content subtype used by clients
+const Name = "json"
+
+// codec implements encoding.Codec with encoding/json. It lets services exchange
+// plain Go structs without generated protobuf code.
+type codec struct{}
+
+// Marshal encodes a message as JSON
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes a JSON message
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Name returns the name of the codec
+func (codec) Name() string {
+	return Name
+}
+
+func init() {
+	encoding.RegisterCodec(codec{})
+}
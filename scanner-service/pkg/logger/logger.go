@@ -1,8 +1,12 @@
 package logger
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/requestid"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -10,18 +14,50 @@ import (
 // Logger is a wrapper around zap logger
 type Logger struct {
 	*zap.Logger
+	level *zap.AtomicLevel // Level shared by the logger and its children, nil if it cannot be changed
 }
 
 // Config contains logger configuration
 type Config struct {
-	Level  string
-	Format string
-	Output string
+	Level     string
+	Format    string
+	Output    string
+	Sampling  SamplingConfig
+	Redaction RedactionConfig
+}
+
+// SamplingConfig limits the debug and info entries logged with the same message, e.g.
+// per-request entries under heavy load. Of the entries with the same message in each
+// Tick, the first Initial are logged and every Thereafter-th after that. Warnings and
+// errors are never sampled.
+type SamplingConfig struct {
+	Enabled    bool
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
 }
 
 // NewLogger creates a new Logger instance
 func NewLogger(config Config) (*Logger, error) {
-	level := getLogLevel(config.Level)
+	return newLogger(config,
+		zap.AddCaller(),
+		zap.AddCallerSkip(1),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+	)
+}
+
+// NewAccessLogger creates a Logger for HTTP and gRPC access logs, kept apart from
+// application logs so traffic can be analyzed on its own. Entries are logged without
+// caller and stack trace and are never sampled.
+func NewAccessLogger(config Config) (*Logger, error) {
+	config.Level = "info"
+	config.Sampling = SamplingConfig{}
+	return newLogger(config)
+}
+
+// newLogger creates a Logger writing entries as configured with the given options
+func newLogger(config Config, options ...zap.Option) (*Logger, error) {
+	level := zap.NewAtomicLevelAt(getLogLevel(config.Level))
 
 	// Configure encoder based on format
 	var encoder zapcore.Encoder
@@ -37,9 +73,12 @@ func NewLogger(config Config) (*Logger, error) {
 
 	// Configure output
 	var output zapcore.WriteSyncer
-	if config.Output == "stdout" || config.Output == "" {
+	switch config.Output {
+	case "stdout", "":
 		output = zapcore.AddSync(os.Stdout)
-	} else {
+	case "stderr":
+		output = zapcore.AddSync(os.Stderr)
+	default:
 		file, err := os.OpenFile(config.Output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 		if err != nil {
 			return nil, err
@@ -47,55 +86,105 @@ func NewLogger(config Config) (*Logger, error) {
 		output = zapcore.AddSync(file)
 	}
 
-	// Create core
-	core := zapcore.NewCore(
-		encoder,
-		output,
-		level,
-	)
+	// Create core masking sensitive fields, sampling debug and info entries if enabled
+	redactor := newRedactor(config.Redaction)
+	newCore := func(enabler zapcore.LevelEnabler) zapcore.Core {
+		return &redactingCore{
+			Core:     zapcore.NewCore(encoder, output, enabler),
+			redactor: redactor,
+		}
+	}
+	core := newCore(level)
+	if sampling := config.Sampling; sampling.Enabled {
+		low := newCore(zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return l < zapcore.WarnLevel && level.Enabled(l)
+		}))
+		high := newCore(zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return l >= zapcore.WarnLevel && level.Enabled(l)
+		}))
+		core = zapcore.NewTee(
+			zapcore.NewSamplerWithOptions(low, sampling.Tick, sampling.Initial, sampling.Thereafter),
+			high,
+		)
+	}
 
 	// Create logger
-	zapLogger := zap.New(
-		core,
-		zap.AddCaller(),
-		zap.AddCallerSkip(1),
-		zap.AddStacktrace(zapcore.ErrorLevel),
-	)
+	zapLogger := zap.New(core, options...)
 
 	return &Logger{
 		Logger: zapLogger,
+		level:  &level,
 	}, nil
 }
 
-// getLogLevel converts string level to zapcore.Level
+// getLogLevel converts string level to zapcore.Level, info for unknown levels
 func getLogLevel(level string) zapcore.Level {
+	if l, ok := parseLevel(level); ok {
+		return l
+	}
+	return zapcore.InfoLevel
+}
+
+// parseLevel converts a configurable string level to zapcore.Level
+func parseLevel(level string) (zapcore.Level, bool) {
 	switch level {
 	case "debug":
-		return zapcore.DebugLevel
+		return zapcore.DebugLevel, true
 	case "info":
-		return zapcore.InfoLevel
+		return zapcore.InfoLevel, true
 	case "warn":
-		return zapcore.WarnLevel
+		return zapcore.WarnLevel, true
 	case "error":
-		return zapcore.ErrorLevel
+		return zapcore.ErrorLevel, true
 	case "fatal":
-		return zapcore.FatalLevel
+		return zapcore.FatalLevel, true
 	default:
-		return zapcore.InfoLevel
+		return zapcore.InfoLevel, false
+	}
+}
+
+// Level returns the current level of the Logger, empty if it was not created by NewLogger
+func (l *Logger) Level() string {
+	if l.level == nil {
+		return ""
+	}
+	return l.level.Level().String()
+}
+
+// SetLevel changes the level of the Logger and all loggers derived from it at runtime
+func (l *Logger) SetLevel(level string) error {
+	if l.level == nil {
+		return fmt.Errorf("log level cannot be changed")
 	}
+	parsed, ok := parseLevel(level)
+	if !ok {
+		return fmt.Errorf("unknown log level %q, supported: debug, info, warn, error, fatal", level)
+	}
+	l.level.SetLevel(parsed)
+	return nil
 }
 
 // With adds structured context to the Logger
 func (l *Logger) With(fields ...zap.Field) *Logger {
 	return &Logger{
 		Logger: l.Logger.With(fields...),
+		level:  l.level,
+	}
+}
+
+// WithContext adds the request ID carried by ctx to the Logger
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	if id := requestid.FromContext(ctx); id != "" {
+		return l.With(zap.String("request_id", id))
 	}
+	return l
 }
 
 // Named adds a sub-logger with the specified name
 func (l *Logger) Named(name string) *Logger {
 	return &Logger{
 		Logger: l.Logger.Named(name),
+		level:  l.level,
 	}
 }
 
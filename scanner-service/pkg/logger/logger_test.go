@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newFileLogger creates a JSON logger writing to a temporary file and returns a
+// function reading the messages logged so far
+func newFileLogger(t *testing.T, config Config) (*Logger, func() []string) {
+	t.Helper()
+
+	config.Format = "json"
+	config.Output = filepath.Join(t.TempDir(), "service.log")
+	log, err := NewLogger(config)
+	require.NoError(t, err)
+
+	return log, func() []string {
+		data, err := os.ReadFile(config.Output)
+		require.NoError(t, err)
+
+		var messages []string
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			if line == "" {
+				continue
+			}
+			var entry struct {
+				Msg string `json:"msg"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			messages = append(messages, entry.Msg)
+		}
+		return messages
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	log, messages := newFileLogger(t, Config{Level: "info"})
+	child := log.With(zap.String("component", "scanner")).Named("scan")
+
+	child.Debug("hidden")
+	require.NoError(t, log.SetLevel("debug"))
+	assert.Equal(t, "debug", child.Level())
+	child.Debug("shown")
+
+	// Derived loggers follow the level of the logger they were derived from
+	require.NoError(t, child.SetLevel("error"))
+	assert.Equal(t, "error", log.Level())
+	log.Warn("hidden as well")
+
+	assert.Equal(t, []string{"shown"}, messages())
+
+	assert.Error(t, log.SetLevel("verbose"))
+	assert.Equal(t, "error", log.Level())
+
+	// Loggers not created by NewLogger have a fixed level
+	assert.Error(t, (&Logger{Logger: zap.NewNop()}).SetLevel("debug"))
+}
+
+func TestSampling(t *testing.T) {
+	log, messages := newFileLogger(t, Config{
+		Level:    "info",
+		Sampling: SamplingConfig{Enabled: true, Initial: 2, Thereafter: 5, Tick: time.Minute},
+	})
+
+	for range 12 {
+		log.Info("HTTP request")
+	}
+	for range 3 {
+		log.Error("Scan failed")
+	}
+
+	// Info entries 1, 2, 7 and 12 are logged; errors are not sampled
+	logged := messages()
+	assert.Equal(t, 4, countOf(logged, "HTTP request"))
+	assert.Equal(t, 3, countOf(logged, "Scan failed"))
+}
+
+// countOf returns how often message occurs in messages
+func countOf(messages []string, message string) int {
+	count := 0
+	for _, m := range messages {
+		if m == message {
+			count++
+		}
+	}
+	return count
+}
+
+func TestNewAccessLogger(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "access.log")
+	log, err := NewAccessLogger(Config{Level: "error", Format: "json", Output: output})
+	require.NoError(t, err)
+
+	// Requests are logged at info regardless of the application log level
+	log.Debug("hidden")
+	log.Info("HTTP request", zap.String("path", "/api/v1/scans?api_key=nmk_secret"))
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(data, &entry))
+	assert.Equal(t, "HTTP request", entry["msg"])
+	assert.Equal(t, "/api/v1/scans?api_key=[REDACTED]", entry["path"])
+	assert.NotContains(t, entry, "caller")
+}
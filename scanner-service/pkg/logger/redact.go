@@ -0,0 +1,156 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces the values of redacted log fields
+const Redacted = "[REDACTED]"
+
+// RedactionConfig configures which log fields are masked. Auth tokens, API keys and
+// other secrets are always masked.
+type RedactionConfig struct {
+	Targets bool     // Mask scan targets, i.e. IP addresses, hostnames and scanner arguments
+	Fields  []string // Additional field names to mask
+}
+
+// secretFieldParts are the parts of field names holding secrets, e.g. api_key or
+// refresh_token
+var secretFieldParts = []string{"token", "password", "secret", "api_key", "apikey", "authorization", "cookie", "credential"}
+
+// targetFields are the fields holding scan targets or output revealing them
+var targetFields = []string{"target", "targets", "host", "hosts", "ip", "address", "domain", "domains", "networks", "args", "stderr"}
+
+// secretPattern matches secrets embedded in strings, e.g. in URLs or error messages
+var secretPattern = regexp.MustCompile(`(?i)(bearer\s+|(?:api[_-]?key|access_token|token|password|secret)=)[^\s&"']+`)
+
+// jsonMemberPattern matches the members of JSON objects embedded in strings, e.g. in
+// logged request bodies, with a string, scalar or flat array value. Strings and arrays cut
+// off by truncation are matched as well.
+var jsonMemberPattern = regexp.MustCompile(`"([\w.-]+)"(\s*:\s*)("(?:[^"\\]|\\.)*(?:"|$)|\[[^\[\]{}]*(?:\]|$)|[\w.+-]+)`)
+
+// redactor masks sensitive field values
+type redactor struct {
+	fields map[string]bool // Lowercase names of the fields masked in addition to secrets
+}
+
+// newRedactor creates a redactor for the given configuration
+func newRedactor(config RedactionConfig) *redactor {
+	r := &redactor{fields: make(map[string]bool)}
+	if config.Targets {
+		for _, name := range targetFields {
+			r.fields[name] = true
+		}
+	}
+	for _, name := range config.Fields {
+		r.fields[strings.ToLower(name)] = true
+	}
+	return r
+}
+
+// masked reports whether the value of the field with the given key is masked
+func (r *redactor) masked(key string) bool {
+	key = strings.ToLower(key)
+	if r.fields[key] {
+		return true
+	}
+	// Identifiers of keys and tokens are not secret
+	if strings.HasSuffix(key, "_id") {
+		return false
+	}
+	for _, part := range secretFieldParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactFields returns the fields with sensitive values masked. fields is not modified.
+func (r *redactor) redactFields(fields []zapcore.Field) []zapcore.Field {
+	var result []zapcore.Field
+	for i, field := range fields {
+		redacted, changed := r.redactField(field)
+		if !changed {
+			if result != nil {
+				result = append(result, field)
+			}
+			continue
+		}
+		if result == nil {
+			result = make([]zapcore.Field, i, len(fields))
+			copy(result, fields[:i])
+		}
+		result = append(result, redacted)
+	}
+	if result == nil {
+		return fields
+	}
+	return result
+}
+
+// redactField masks the value of a sensitive field and secrets embedded in string values
+func (r *redactor) redactField(field zapcore.Field) (zapcore.Field, bool) {
+	if r.masked(field.Key) {
+		return zapcore.Field{Key: field.Key, Type: zapcore.StringType, String: Redacted}, true
+	}
+	switch field.Type {
+	case zapcore.StringType:
+		if value := r.redactString(field.String); value != field.String {
+			field.String = value
+			return field, true
+		}
+	case zapcore.ErrorType:
+		// Errors of failed requests may carry the URL or header they were sent with
+		if err, ok := field.Interface.(error); ok && err != nil {
+			if value := r.redactString(err.Error()); value != err.Error() {
+				return zapcore.Field{Key: field.Key, Type: zapcore.StringType, String: value}, true
+			}
+		}
+	}
+	return field, false
+}
+
+// redactString masks bearer tokens, credentials in query string form and the values of
+// sensitive JSON object members embedded in s
+func (r *redactor) redactString(s string) string {
+	s = secretPattern.ReplaceAllString(s, "${1}"+Redacted)
+	if !strings.Contains(s, `"`) {
+		return s
+	}
+	return jsonMemberPattern.ReplaceAllStringFunc(s, func(member string) string {
+		match := jsonMemberPattern.FindStringSubmatch(member)
+		if !r.masked(match[1]) {
+			return member
+		}
+		return `"` + match[1] + `"` + match[2] + `"` + Redacted + `"`
+	})
+}
+
+// redactingCore masks sensitive fields before passing entries to the wrapped core
+type redactingCore struct {
+	zapcore.Core
+	redactor *redactor
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{
+		Core:     c.Core.With(c.redactor.redactFields(fields)),
+		redactor: c.redactor,
+	}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = c.redactor.redactString(entry.Message)
+	return c.Core.Write(entry, c.redactor.redactFields(fields))
+}
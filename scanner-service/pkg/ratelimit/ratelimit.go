@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter decides whether a request identified by key may proceed.
+// When it may not, the returned duration is how long the caller should wait.
+type Limiter interface {
+	Allow(key string) (bool, time.Duration)
+}
+
+// bucket holds the state of a single key
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// TokenBucket is an in-memory token bucket limiter keyed by an arbitrary string
+type TokenBucket struct {
+	rate      float64 // Tokens added per second
+	burst     float64 // Maximum number of tokens
+	buckets   map[string]*bucket
+	lastPrune time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// NewTokenBucket creates a new TokenBucket that allows requestsPerMinute on average
+// with bursts of up to burst requests
+func NewTokenBucket(requestsPerMinute float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &TokenBucket{
+		rate:    requestsPerMinute / 60,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of key
+func (l *TokenBucket) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	// Refill tokens for the time passed since the last request
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.lastSeen).Seconds()*l.rate)
+	b.lastSeen = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	if l.rate <= 0 {
+		return false, time.Minute
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// prune removes buckets that have refilled completely, at most once a minute
+func (l *TokenBucket) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute {
+		return
+	}
+	l.lastPrune = now
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.lastSeen).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript takes a token from the bucket stored in the hash KEYS[1] with the
+// refill rate ARGV[1] in tokens per second and the capacity ARGV[2]. It uses the clock
+// of the Redis server, so replicas with skewed clocks agree. It returns whether the
+// token was taken and otherwise how many seconds to wait for one.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+elseif rate > 0 then
+  wait = (1 - tokens) / rate
+else
+  wait = 60
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+if rate > 0 then
+  redis.call('EXPIRE', KEYS[1], math.ceil(burst / rate) + 1)
+else
+  redis.call('EXPIRE', KEYS[1], 60)
+end
+return {allowed, tostring(wait)}
+`)
+
+// RedisTokenBucket is a token bucket limiter keeping its buckets in Redis, so replicas
+// sharing the Redis server enforce one limit. While Redis is unavailable, requests are
+// limited by an in-memory bucket of the replica instead.
+type RedisTokenBucket struct {
+	client   redis.UniversalClient
+	prefix   string
+	rate     float64 // Tokens added per second
+	burst    float64 // Maximum number of tokens
+	timeout  time.Duration
+	fallback *TokenBucket
+	onError  func(error)
+}
+
+// NewRedisTokenBucket creates a new RedisTokenBucket that allows requestsPerMinute on
+// average with bursts of up to burst requests. Buckets are stored under prefix + key.
+func NewRedisTokenBucket(client redis.UniversalClient, prefix string, requestsPerMinute float64, burst int) *RedisTokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RedisTokenBucket{
+		client:   client,
+		prefix:   prefix,
+		rate:     requestsPerMinute / 60,
+		burst:    float64(burst),
+		timeout:  time.Second,
+		fallback: NewTokenBucket(requestsPerMinute, burst),
+	}
+}
+
+// SetErrorHandler sets a function called with the errors of Redis requests, e.g. to log them
+func (l *RedisTokenBucket) SetErrorHandler(onError func(error)) {
+	l.onError = onError
+}
+
+// Allow takes a token from the bucket of key
+func (l *RedisTokenBucket) Allow(key string) (bool, time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	result, err := tokenBucketScript.Run(ctx, l.client, []string{l.prefix + key}, l.rate, l.burst).Slice()
+	if err == nil && len(result) != 2 {
+		err = fmt.Errorf("unexpected rate limit script reply %v", result)
+	}
+	if err != nil {
+		if l.onError != nil {
+			l.onError(err)
+		}
+		return l.fallback.Allow(key)
+	}
+
+	if allowed, _ := result[0].(int64); allowed == 1 {
+		return true, 0
+	}
+	value, _ := result[1].(string)
+	wait, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(wait, 0) || math.IsNaN(wait) {
+		return false, time.Minute
+	}
+	return false, time.Duration(wait * float64(time.Second))
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisTokenBucket(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	// Two replicas sharing the Redis server share the buckets
+	replica1 := NewRedisTokenBucket(client, "ratelimit:start_scan:", 60, 2)
+	replica2 := NewRedisTokenBucket(client, "ratelimit:start_scan:", 60, 2)
+
+	allowed, _ := replica1.Allow("ip:10.0.0.1")
+	assert.True(t, allowed)
+	allowed, _ = replica2.Allow("ip:10.0.0.1")
+	assert.True(t, allowed)
+
+	allowed, retryAfter := replica1.Allow("ip:10.0.0.1")
+	assert.False(t, allowed)
+	assert.InDelta(t, time.Second, retryAfter, float64(100*time.Millisecond))
+
+	// Keys are limited independently
+	allowed, _ = replica2.Allow("ip:10.0.0.2")
+	assert.True(t, allowed)
+
+	// Buckets expire once they would be full again
+	assert.True(t, server.Exists("ratelimit:start_scan:ip:10.0.0.1"))
+	assert.Equal(t, 3*time.Second, server.TTL("ratelimit:start_scan:ip:10.0.0.1"))
+}
+
+func TestRedisTokenBucketFallback(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	defer client.Close()
+
+	limiter := NewRedisTokenBucket(client, "ratelimit:per_ip:", 60, 1)
+	var errs []error
+	limiter.SetErrorHandler(func(err error) { errs = append(errs, err) })
+	server.Close()
+
+	// Requests are limited in memory while Redis is unavailable
+	allowed, _ := limiter.Allow("ip:10.0.0.1")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow("ip:10.0.0.1")
+	assert.False(t, allowed)
+	require.Len(t, errs, 2)
+}
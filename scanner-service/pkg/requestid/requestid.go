@@ -0,0 +1,59 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Header is the HTTP header carrying the request ID
+const Header = "X-Request-ID"
+
+// MetadataKey is the gRPC metadata key carrying the request ID
+const MetadataKey = "x-request-id"
+
+// maxLength is the maximum length of an accepted request ID
+const maxLength = 128
+
+// contextKey is the context key type of the request ID
+type contextKey struct{}
+
+// New generates a new request ID
+func New() string {
+	return uuid.New().String()
+}
+
+// NewContext returns a copy of ctx carrying the request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, if any
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Valid reports whether a client supplied request ID can be used as is.
+// IDs must be short and consist of printable ASCII characters only.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Ensure returns id if it is valid, or a newly generated request ID otherwise
+func Ensure(id string) string {
+	if Valid(id) {
+		return id
+	}
+	return New()
+}
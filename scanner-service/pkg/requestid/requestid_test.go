@@ -0,0 +1,27 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnsure(t *testing.T) {
+	assert.Equal(t, "abc-123", Ensure("abc-123"))
+
+	// Invalid IDs are replaced
+	for _, id := range []string{"", "has space", "new\nline", strings.Repeat("a", 129)} {
+		generated := Ensure(id)
+		assert.NotEqual(t, id, generated)
+		assert.True(t, Valid(generated))
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Empty(t, FromContext(context.Background()))
+
+	ctx := NewContext(context.Background(), "abc-123")
+	assert.Equal(t, "abc-123", FromContext(ctx))
+}
@@ -0,0 +1,164 @@
+// Package secrets resolves secret references in configuration values, so that secrets
+// can be read from environment variables, mounted files (Docker and Kubernetes secrets)
+// or HashiCorp Vault instead of being written into configuration files.
+//
+// A reference has the form "<scheme>:<name>":
+//
+//	env:SCANNER_JWT_SECRET            environment variable
+//	file:/run/secrets/jwt_secret      file, relative names are looked up in the secrets directory
+//	vault:secret/data/scanner#jwt     key of a Vault secret (KV version 1 or 2)
+//
+// Values without a known scheme are used as they are.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Schemes of secret references
+const (
+	SchemeEnv   = "env"
+	SchemeFile  = "file"
+	SchemeVault = "vault"
+)
+
+// Provider looks up secrets by name
+type Provider interface {
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// Resolver resolves secret references with the provider registered for their scheme
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// NewResolver creates a Resolver reading environment variables and files.
+// Relative file names are looked up in fileDir.
+func NewResolver(fileDir string) *Resolver {
+	return &Resolver{
+		providers: map[string]Provider{
+			SchemeEnv:  EnvProvider{},
+			SchemeFile: FileProvider{Dir: fileDir},
+		},
+	}
+}
+
+// Register sets the provider of a scheme
+func (r *Resolver) Register(scheme string, provider Provider) {
+	r.providers[scheme] = provider
+}
+
+// Resolve returns the secret a value refers to, or the value itself if it is not a reference
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	scheme, name, ok := strings.Cut(value, ":")
+	if !ok {
+		return value, nil
+	}
+
+	provider, registered := r.providers[scheme]
+	if !registered {
+		if scheme == SchemeVault {
+			return "", fmt.Errorf("secret %q refers to Vault, which is not configured", value)
+		}
+		return value, nil
+	}
+
+	secret, err := provider.GetSecret(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve secret %q: %w", value, err)
+	}
+	return secret, nil
+}
+
+// Secret resolves a configuration value into a Secret that can be refreshed
+func (r *Resolver) Secret(ctx context.Context, value string) (*Secret, error) {
+	secret := &Secret{resolver: r, reference: value}
+	resolved, err := r.Resolve(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	secret.value.Store(resolved)
+	return secret, nil
+}
+
+// Watch refreshes the secrets every interval until ctx is done, so that rotated
+// secrets are picked up by long-running processes. Failed refreshes keep the previous value.
+func (r *Resolver) Watch(ctx context.Context, interval time.Duration, logger *logger.Logger, secrets ...*Secret) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, secret := range secrets {
+				changed, err := secret.Refresh(ctx)
+				if err != nil {
+					logger.Warn("Failed to refresh secret", zap.String("reference", secret.reference), zap.Error(err))
+				} else if changed {
+					logger.Info("Secret rotated", zap.String("reference", secret.reference))
+				}
+			}
+		}
+	}
+}
+
+// Secret holds the current value of a secret reference
+type Secret struct {
+	resolver  *Resolver
+	reference string
+	value     atomic.Value
+}
+
+// Value returns the current value of the secret
+func (s *Secret) Value() string {
+	return s.value.Load().(string)
+}
+
+// Refresh resolves the reference again and reports whether the value changed
+func (s *Secret) Refresh(ctx context.Context) (bool, error) {
+	resolved, err := s.resolver.Resolve(ctx, s.reference)
+	if err != nil {
+		return false, err
+	}
+	return s.value.Swap(resolved).(string) != resolved, nil
+}
+
+// EnvProvider reads secrets from environment variables
+type EnvProvider struct{}
+
+// GetSecret returns the value of the environment variable name
+func (EnvProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+// FileProvider reads secrets from files, such as Docker and Kubernetes secrets
+type FileProvider struct {
+	Dir string // Directory of relative file names
+}
+
+// GetSecret returns the content of the file name without trailing line breaks
+func (p FileProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(p.Dir, name)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,117 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "jwt_secret"), []byte("from-file\n"), 0o600))
+	t.Setenv("SCANNER_TEST_SECRET", "from-env")
+
+	resolver := NewResolver(dir)
+
+	value, err := resolver.Resolve(ctx, "plain-value")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-value", value)
+
+	// Unknown schemes are not references
+	value, err = resolver.Resolve(ctx, "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", value)
+
+	value, err = resolver.Resolve(ctx, "env:SCANNER_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", value)
+
+	value, err = resolver.Resolve(ctx, "file:jwt_secret")
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", value)
+
+	value, err = resolver.Resolve(ctx, "file:"+filepath.Join(dir, "jwt_secret"))
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", value)
+
+	_, err = resolver.Resolve(ctx, "env:SCANNER_TEST_MISSING")
+	assert.Error(t, err)
+	_, err = resolver.Resolve(ctx, "vault:secret/data/scanner#jwt")
+	assert.ErrorContains(t, err, "not configured")
+}
+
+func TestSecretRefresh(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+
+	secret, err := NewResolver(dir).Secret(ctx, "file:token")
+	require.NoError(t, err)
+	assert.Equal(t, "first", secret.Value())
+
+	changed, err := secret.Refresh(ctx)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// The rotated value is picked up
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	changed, err = secret.Refresh(ctx)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "second", secret.Value())
+
+	// Failed refreshes keep the previous value
+	require.NoError(t, os.Remove(path))
+	_, err = secret.Refresh(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, "second", secret.Value())
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/scanner":
+			w.Write([]byte(`{"data":{"data":{"jwt":"kv2-secret"},"metadata":{"version":3}}}`))
+		case "/v1/kv/scanner":
+			w.Write([]byte(`{"data":{"jwt":"kv1-secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	resolver := NewResolver("")
+	resolver.Register(SchemeVault, NewVaultProvider(VaultConfig{Address: server.URL, Token: "vault-token"}))
+
+	value, err := resolver.Resolve(ctx, "vault:secret/data/scanner#jwt")
+	require.NoError(t, err)
+	assert.Equal(t, "kv2-secret", value)
+
+	value, err = resolver.Resolve(ctx, "vault:kv/scanner#jwt")
+	require.NoError(t, err)
+	assert.Equal(t, "kv1-secret", value)
+
+	_, err = resolver.Resolve(ctx, "vault:secret/data/scanner#missing")
+	assert.Error(t, err)
+	_, err = resolver.Resolve(ctx, "vault:secret/data/other#jwt")
+	assert.Error(t, err)
+	_, err = resolver.Resolve(ctx, "vault:secret/data/scanner")
+	assert.Error(t, err)
+
+	unauthorized := NewVaultProvider(VaultConfig{Address: server.URL, Token: "wrong"})
+	_, err = unauthorized.GetSecret(ctx, "secret/data/scanner#jwt")
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultConfig contains the connection settings of a Vault server
+type VaultConfig struct {
+	Address   string // Address of the server, e.g. https://vault.example.com:8200
+	Token     string // Token used to read secrets
+	Namespace string // Enterprise namespace, empty for none
+}
+
+// VaultProvider reads secrets from HashiCorp Vault over its HTTP API.
+// Names have the form "<path>#<key>", where path is the API path of the secret
+// below /v1, e.g. "secret/data/scanner#jwt_secret" for a KV version 2 engine.
+type VaultProvider struct {
+	config     VaultConfig
+	httpClient *http.Client
+}
+
+// NewVaultProvider creates a new VaultProvider
+func NewVaultProvider(config VaultConfig) *VaultProvider {
+	return &VaultProvider{
+		config:     config,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// vaultResponse represents the response to a secret read.
+// KV version 2 nests the secret data in data.data, version 1 returns it in data.
+type vaultResponse struct {
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+// GetSecret returns a key of a Vault secret
+func (p *VaultProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	path, key, ok := strings.Cut(name, "#")
+	if !ok || path == "" || key == "" {
+		return "", fmt.Errorf("vault secret %q must have the form <path>#<key>", name)
+	}
+
+	url := strings.TrimSuffix(p.config.Address, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.config.Token)
+	if p.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.config.Namespace)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %d for %s", resp.StatusCode, path)
+	}
+
+	var body vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid vault response: %w", err)
+	}
+
+	data := body.Data
+	if nested, ok := body.Data["data"]; ok {
+		var kv2 map[string]json.RawMessage
+		if err := json.Unmarshal(nested, &kv2); err == nil {
+			data = kv2
+		}
+	}
+
+	raw, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no key %s", path, key)
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("vault secret %s key %s is not a string", path, key)
+	}
+	return value, nil
+}
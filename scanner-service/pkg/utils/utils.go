@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"net"
 	"os/exec"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 // GenerateID generates a random ID
@@ -20,7 +22,7 @@ func GenerateID(length int) (string, error) {
 
 // CheckPortStatus checks if a port is open on a host
 func CheckPortStatus(host string, port int) bool {
-	address := fmt.Sprintf("%s:%d", host, port)
+	address := net.JoinHostPort(host, strconv.Itoa(port))
 	conn, err := net.DialTimeout("tcp", address, 5*1000*1000*1000) // 5 seconds
 	if err != nil {
 		return false
@@ -29,6 +31,17 @@ func CheckPortStatus(host string, port int) bool {
 	return true
 }
 
+// RawSocketCapable reports whether the process may open raw sockets, which nmap
+// needs for SYN and UDP scans and OS detection
+func RawSocketCapable() bool {
+	conn, err := net.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
 // IsNmapInstalled checks if nmap is installed
 func IsNmapInstalled(nmapPath string) bool {
 	path := nmapPath
@@ -80,6 +93,14 @@ func SanitizeInput(input string) string {
 	return replacer.Replace(input)
 }
 
+// SplitTargets splits a target specification into individual targets.
+// Targets may be separated by commas or whitespace.
+func SplitTargets(target string) []string {
+	return strings.FieldsFunc(target, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
 // ValidateIPAddress validates an IP address or CIDR range
 func ValidateIPAddress(ip string) bool {
 	// Check if it's a CIDR range
@@ -93,16 +114,28 @@ func ValidateIPAddress(ip string) bool {
 	return parsedIP != nil
 }
 
-// ValidateHostname validates a hostname
+// ValidateHostname validates the syntax of a hostname without resolving it,
+// since targets may only resolve on the scanning host
 func ValidateHostname(hostname string) bool {
-	// Simple validation, check for invalid characters
-	if strings.ContainsAny(hostname, " \t\n\r") {
+	name := strings.TrimSuffix(hostname, ".")
+	if name == "" || len(name) > 253 {
 		return false
 	}
 
-	// Try to resolve it
-	_, err := net.ResolveIPAddr("ip", hostname)
-	return err == nil
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return false
+			}
+		}
+	}
+
+	// The last label is never numeric, which rules out partial addresses like 10.0.1
+	last := name[strings.LastIndex(name, ".")+1:]
+	return strings.Trim(last, "0123456789") != ""
 }
 
 // PortRangeToSlice converts a port range string to a slice of integers
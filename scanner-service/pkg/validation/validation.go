@@ -0,0 +1,128 @@
+// Package validation turns request binding failures into invalid input errors
+// listing the offending fields by their JSON names.
+package validation
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Report fields by their JSON names rather than their Go names
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// FromBindError converts an error of binding a request body into an invalid input
+// error with one FieldError per invalid field
+func FromBindError(err error) *errors.Error {
+	var validationErrors validator.ValidationErrors
+	if stderrors.As(err, &validationErrors) {
+		fields := make([]errors.FieldError, len(validationErrors))
+		for i, fieldErr := range validationErrors {
+			fields[i] = errors.FieldError{
+				Field:      fieldErr.Field(),
+				Constraint: fieldErr.Tag(),
+				Message:    message(fieldErr),
+			}
+		}
+		return errors.NewValidation("invalid request: "+summary(fields), fields, err)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &typeErr) && typeErr.Field != "" {
+		field := errors.FieldError{
+			Field:      typeErr.Field,
+			Constraint: "type",
+			Message:    "must be of type " + jsonType(typeErr.Type),
+		}
+		return errors.NewValidation("invalid request: "+summary([]errors.FieldError{field}), []errors.FieldError{field}, err)
+	}
+
+	if stderrors.Is(err, io.EOF) {
+		return errors.NewInvalidInput("invalid request: body is required", err)
+	}
+
+	return errors.NewInvalidInput("invalid request: "+err.Error(), err)
+}
+
+// message describes a failed validation constraint
+func message(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min", "gte":
+		if isLength(fieldErr.Kind()) {
+			return "must have at least " + param + " elements or characters"
+		}
+		return "must be at least " + param
+	case "max", "lte":
+		if isLength(fieldErr.Kind()) {
+			return "must have at most " + param + " elements or characters"
+		}
+		return "must be at most " + param
+	case "gt":
+		return "must be greater than " + param
+	case "lt":
+		return "must be less than " + param
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(param, " ", ", ")
+	case "ip", "ipv4", "ipv6", "cidr", "url", "email", "hostname", "uuid":
+		return "must be a valid " + fieldErr.Tag()
+	default:
+		return "failed the " + fieldErr.Tag() + " constraint"
+	}
+}
+
+// isLength reports whether min and max constraints limit the length of a value of kind
+func isLength(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonType names the JSON type expected for a Go type
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// summary joins the field errors into a single message
+func summary(fields []errors.FieldError) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field.Field + " " + field.Message
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,79 @@
+package validation_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/errors"
+	"github.com/furkansarikaya/nmap-ui-microservices/scanner-service/pkg/validation"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type request struct {
+	Target   string   `json:"target" binding:"required"`
+	Stages   []string `json:"stages" binding:"required,min=1"`
+	Role     string   `json:"role,omitempty" binding:"omitempty,oneof=viewer operator"`
+	Priority int      `json:"priority,omitempty" binding:"omitempty,max=10"`
+}
+
+func bind(body string) error {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	var r request
+	return binding.JSON.Bind(req, &r)
+}
+
+func TestFromBindError(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		fields  []errors.FieldError
+		message string
+	}{
+		{
+			name: "missing fields",
+			body: `{"stages": []}`,
+			fields: []errors.FieldError{
+				{Field: "target", Constraint: "required", Message: "is required"},
+				{Field: "stages", Constraint: "min", Message: "must have at least 1 elements or characters"},
+			},
+			message: "invalid request: target is required, stages must have at least 1 elements or characters",
+		},
+		{
+			name: "constraints",
+			body: `{"target": "10.0.0.1", "stages": ["ping"], "role": "admin", "priority": 11}`,
+			fields: []errors.FieldError{
+				{Field: "role", Constraint: "oneof", Message: "must be one of viewer, operator"},
+				{Field: "priority", Constraint: "max", Message: "must be at most 10"},
+			},
+			message: "invalid request: role must be one of viewer, operator, priority must be at most 10",
+		},
+		{
+			name: "wrong type",
+			body: `{"target": "10.0.0.1", "stages": ["ping"], "priority": "high"}`,
+			fields: []errors.FieldError{
+				{Field: "priority", Constraint: "type", Message: "must be of type integer"},
+			},
+			message: "invalid request: priority must be of type integer",
+		},
+		{
+			name:    "empty body",
+			body:    ``,
+			message: "invalid request: body is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bind(tt.body)
+			require.Error(t, err)
+
+			appErr := validation.FromBindError(err)
+			assert.Equal(t, errors.ErrInvalidInput, appErr.Type)
+			assert.Equal(t, tt.message, appErr.Message)
+			assert.Equal(t, tt.fields, appErr.Fields)
+		})
+	}
+}
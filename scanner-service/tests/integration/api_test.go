@@ -81,6 +81,21 @@ func TestScanWorkflow(t *testing.T) {
 		result := getScanResult(t, resultID)
 		assert.NotNil(t, result)
 		assert.Equal(t, scanID, result["scan_id"])
+
+		// 4. Polling an unchanged result returns 304 Not Modified
+		resp, err := http.Get(fmt.Sprintf("%s/api/v1/results/%s", serverURL, resultID))
+		assert.NoError(t, err)
+		resp.Body.Close()
+		etag := resp.Header.Get("ETag")
+		assert.NotEmpty(t, etag)
+
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/results/%s", serverURL, resultID), nil)
+		assert.NoError(t, err)
+		req.Header.Set("If-None-Match", etag)
+		resp, err = http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
 	}
 }
 
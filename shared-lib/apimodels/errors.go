@@ -0,0 +1,16 @@
+package apimodels
+
+// FieldError describes why a field of a request is invalid
+type FieldError struct {
+	Field      string `json:"field"`      // JSON name of the field, e.g. "ports" or "decoys[2]"
+	Constraint string `json:"constraint"` // Violated constraint, e.g. "required" or "max"
+	Message    string `json:"message"`
+}
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Type      string       `json:"type"` // e.g. INVALID_INPUT or NOT_FOUND
+	Message   string       `json:"message"`
+	Fields    []FieldError `json:"fields,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
@@ -0,0 +1,84 @@
+package apimodels
+
+import "time"
+
+// ScanResult represents the result of a scan
+type ScanResult struct {
+	ID         string    `json:"id"`
+	ScanID     string    `json:"scan_id"`
+	UserID     string    `json:"user_id"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Duration   float64   `json:"duration"` // Duration in seconds
+	Command    string    `json:"command"`
+	Summary    string    `json:"summary"`
+	TotalHosts int       `json:"total_hosts"`
+	UpHosts    int       `json:"up_hosts"`
+	Hosts      []Host    `json:"hosts"`
+	Partial    bool      `json:"partial,omitempty"` // Set if the scan stopped before it completed all hosts
+}
+
+// Host represents a host from a scan result
+type Host struct {
+	IP        string        `json:"ip"`
+	Hostnames []string      `json:"hostnames"`
+	Status    string        `json:"status"` // up or down
+	OS        string        `json:"os"`
+	Ports     []Port        `json:"ports"`
+	Scripts   []Script      `json:"scripts"`
+	Metadata  HostMetadata  `json:"metadata"`
+	Geo       *GeoInfo      `json:"geo,omitempty"`
+	Owner     *NetworkOwner `json:"owner,omitempty"`
+	Notes     []Note        `json:"notes,omitempty"`
+}
+
+// Port represents a port from a scan result
+type Port struct {
+	Port      int    `json:"port"`
+	Protocol  string `json:"protocol"` // tcp or udp
+	State     string `json:"state"`    // open, closed or filtered
+	Service   string `json:"service"`
+	Product   string `json:"product"`
+	Version   string `json:"version"`
+	ExtraInfo string `json:"extra_info"`
+}
+
+// Script represents a script result from a scan
+type Script struct {
+	ID     string            `json:"id"`
+	Output string            `json:"output"`
+	Data   map[string]string `json:"data"`
+}
+
+// HostMetadata contains additional information about a host
+type HostMetadata struct {
+	Distance     int       `json:"distance"`
+	UpTime       float64   `json:"uptime"` // System uptime in seconds
+	LastBoot     time.Time `json:"last_boot"`
+	TCPSequence  string    `json:"tcp_sequence"`
+	IPIDSequence string    `json:"ip_id_sequence"`
+}
+
+// GeoInfo represents the geolocation and autonomous system of a host
+type GeoInfo struct {
+	CountryCode    string  `json:"country_code,omitempty"`
+	Country        string  `json:"country,omitempty"`
+	City           string  `json:"city,omitempty"`
+	Latitude       float64 `json:"latitude,omitempty"`
+	Longitude      float64 `json:"longitude,omitempty"`
+	ASN            uint    `json:"asn,omitempty"`
+	ASOrganization string  `json:"as_organization,omitempty"`
+	ISP            string  `json:"isp,omitempty"`
+}
+
+// NetworkOwner represents the registration data of the netblock containing a host
+type NetworkOwner struct {
+	Handle       string   `json:"handle"`
+	Name         string   `json:"name,omitempty"`
+	StartAddress string   `json:"start_address"`
+	EndAddress   string   `json:"end_address"`
+	Country      string   `json:"country,omitempty"`
+	Organization string   `json:"organization,omitempty"`
+	AbuseEmails  []string `json:"abuse_emails,omitempty"`
+	Source       string   `json:"source,omitempty"`
+}
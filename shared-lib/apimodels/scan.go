@@ -0,0 +1,164 @@
+// Package apimodels holds the request and response bodies of the scanner service API,
+// so clients and other services decode them into typed structs.
+package apimodels
+
+import "time"
+
+// ScanStatus represents the status of a scan
+type ScanStatus string
+
+// Scan status constants
+const (
+	ScanStatusPending   ScanStatus = "PENDING"
+	ScanStatusRunning   ScanStatus = "RUNNING"
+	ScanStatusCompleted ScanStatus = "COMPLETED"
+	ScanStatusFailed    ScanStatus = "FAILED"
+	ScanStatusCancelled ScanStatus = "CANCELLED"
+)
+
+// Terminal reports whether a scan in the status has stopped running
+func (s ScanStatus) Terminal() bool {
+	return s == ScanStatusCompleted || s == ScanStatusFailed || s == ScanStatusCancelled
+}
+
+// ScanOptionsRequest represents the scan options of a request
+type ScanOptionsRequest struct {
+	Ports              string   `json:"ports,omitempty"`                // Port specification (e.g., "22,80,443" or "1-1000")
+	ScanType           string   `json:"scan_type,omitempty"`            // SYN, CONNECT, UDP, VERSION, SCRIPT, ALL or PING
+	TimingTemplate     int      `json:"timing_template,omitempty"`      // Timing template (0-5)
+	ServiceDetection   bool     `json:"service_detection,omitempty"`    // Enable service/version detection
+	OSDetection        bool     `json:"os_detection,omitempty"`         // Enable OS detection
+	ScriptScan         bool     `json:"script_scan,omitempty"`          // Enable script scanning
+	ExtraOptions       []string `json:"extra_options,omitempty"`        // Extra command-line options
+	TimeoutSeconds     int      `json:"timeout_seconds,omitempty"`      // Scan timeout, 0 for the server default
+	HostTimeoutSeconds int      `json:"host_timeout_seconds,omitempty"` // Time spent on a single host, 0 for no limit
+	MinRate            int      `json:"min_rate,omitempty"`             // Packets per second sent at least
+	MaxRate            int      `json:"max_rate,omitempty"`             // Packets per second sent at most
+	MaxParallelism     int      `json:"max_parallelism,omitempty"`      // Probes outstanding at most
+	MaxRetries         *int     `json:"max_retries,omitempty"`          // Probe retransmissions at most, nil for the server default
+	Decoys             []string `json:"decoys,omitempty"`               // Decoy addresses (-D)
+	SourcePort         int      `json:"source_port,omitempty"`          // Source port of the probes (-g)
+	Fragment           bool     `json:"fragment,omitempty"`             // Split probes into small IP fragments (-f)
+	DataLength         int      `json:"data_length,omitempty"`          // Random bytes appended to the probes
+	Discovery          []string `json:"discovery,omitempty"`            // zone_transfer, bruteforce or ct
+	Agent              string   `json:"agent,omitempty"`                // Agent running the scan, empty for the local scanner
+	Engine             string   `json:"engine,omitempty"`               // Scanner running the scan, empty for nmap
+	Mode               string   `json:"mode,omitempty"`                 // Preset selecting the engine, e.g. fast
+}
+
+// StartScanRequest represents the request body for starting a scan
+type StartScanRequest struct {
+	Target string `json:"target"` // Target host(s) or network
+	ScanOptionsRequest
+}
+
+// StartScanResponse represents the response to starting a scan
+type StartScanResponse struct {
+	Message string `json:"message"`
+	ScanID  string `json:"scan_id"`
+}
+
+// ScanOptions represents the options a scan runs with
+type ScanOptions struct {
+	Target           string        `json:"target"`
+	Ports            string        `json:"ports"`
+	ScanType         string        `json:"scan_type"`
+	TimingTemplate   int           `json:"timing_template"`
+	ServiceDetection bool          `json:"service_detection"`
+	OSDetection      bool          `json:"os_detection"`
+	ScriptScan       bool          `json:"script_scan"`
+	ExtraOptions     []string      `json:"extra_options"`
+	Timeout          time.Duration `json:"timeout"` // Encoded in nanoseconds
+	HostTimeout      time.Duration `json:"host_timeout,omitempty"`
+	MinRate          int           `json:"min_rate,omitempty"`
+	MaxRate          int           `json:"max_rate,omitempty"`
+	MaxParallelism   int           `json:"max_parallelism,omitempty"`
+	MaxRetries       *int          `json:"max_retries,omitempty"`
+	Decoys           []string      `json:"decoys,omitempty"`
+	SourcePort       int           `json:"source_port,omitempty"`
+	Fragment         bool          `json:"fragment,omitempty"`
+	DataLength       int           `json:"data_length,omitempty"`
+	Discovery        []string      `json:"discovery,omitempty"`
+	Agent            string        `json:"agent,omitempty"`
+	Engine           string        `json:"engine,omitempty"`
+	Mode             string        `json:"mode,omitempty"`
+}
+
+// Scan represents a scan job
+type Scan struct {
+	ID                  string           `json:"id"`
+	UserID              string           `json:"user_id"`
+	TenantID            string           `json:"tenant_id,omitempty"`
+	Options             ScanOptions      `json:"options"`
+	Status              ScanStatus       `json:"status"`
+	Progress            float64          `json:"progress"`                       // Progress percentage (0-100)
+	CurrentPhase        string           `json:"current_phase,omitempty"`        // discovery, port_scan, service_detection or scripts
+	EstimatedCompletion *time.Time       `json:"estimated_completion,omitempty"` // Estimated completion of the current phase
+	CreatedAt           time.Time        `json:"created_at"`
+	StartedAt           *time.Time       `json:"started_at"`
+	CompletedAt         *time.Time       `json:"completed_at"`
+	Error               string           `json:"error"`
+	Failure             *ScanFailure     `json:"failure,omitempty"` // How the scanner process of the failed scan failed
+	ResultID            string           `json:"result_id"`         // Empty until the scan completed
+	RequestID           string           `json:"request_id"`
+	Discovery           *DiscoveryResult `json:"discovery,omitempty"`
+	PipelineID          string           `json:"pipeline_id,omitempty"`
+	WorkflowRunID       string           `json:"workflow_run_id,omitempty"`
+	ParentID            string           `json:"parent_id,omitempty"`
+	ShardCount          int              `json:"shard_count,omitempty"`
+	Resumable           bool             `json:"resumable,omitempty"`
+	DeletedAt           *time.Time       `json:"deleted_at,omitempty"` // Set while the scan is in the trash
+	Notes               []Note           `json:"notes,omitempty"`
+}
+
+// Duration returns how long the scan ran, or zero if it has not completed
+func (s *Scan) Duration() time.Duration {
+	if s.StartedAt == nil || s.CompletedAt == nil {
+		return 0
+	}
+	return s.CompletedAt.Sub(*s.StartedAt)
+}
+
+// ScanFailure describes the scanner process of a failed scan
+type ScanFailure struct {
+	Type     string `json:"type"` // INTERNAL, PERMISSION_DENIED, UNRESOLVABLE_HOST, INTERFACE_NOT_FOUND or INVALID_PORT_SPEC
+	Hint     string `json:"hint,omitempty"`
+	Command  string `json:"command"`
+	ExitCode int    `json:"exit_code"` // -1 if the process did not exit by itself
+	Stderr   string `json:"stderr,omitempty"`
+}
+
+// DiscoveredHost represents a host name found by target discovery
+type DiscoveredHost struct {
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+	Sources   []string `json:"sources,omitempty"`
+}
+
+// DiscoveryResult represents the outcome of the discovery stage of a scan
+type DiscoveryResult struct {
+	Domain  string           `json:"domain"`
+	Hosts   []DiscoveredHost `json:"hosts"`
+	Targets []string         `json:"targets"`
+	Skipped []string         `json:"skipped,omitempty"`
+	Errors  []string         `json:"errors,omitempty"`
+}
+
+// Note represents a note a user attached to a scan or host
+type Note struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ScanPage represents a page of the scan list
+type ScanPage struct {
+	Scans      []Scan `json:"scans"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Count      int    `json:"count"`       // Number of scans on the page
+	TotalCount int    `json:"total_count"` // Number of scans matching the filter
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"` // Cursor of the next page, empty on the last page
+}
@@ -0,0 +1,47 @@
+package apimodels
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WorkflowDefinition represents the steps and schedule of a workflow
+type WorkflowDefinition struct {
+	Name     string            `json:"name"`
+	Target   string            `json:"target"`
+	Schedule string            `json:"schedule,omitempty"` // Cron expression for scheduled runs
+	Steps    []json.RawMessage `json:"steps"`
+}
+
+// ScheduleState represents whether the schedule of a workflow is paused and which scheduled runs were skipped
+type ScheduleState struct {
+	Paused         bool       `json:"paused"`
+	PausedAt       *time.Time `json:"paused_at,omitempty"`
+	PausedBy       string     `json:"paused_by,omitempty"`
+	SkippedRuns    int        `json:"skipped_runs"`
+	LastSkippedAt  *time.Time `json:"last_skipped_at,omitempty"`
+	LastSkipReason string     `json:"last_skip_reason,omitempty"`
+}
+
+// Workflow represents a workflow of scans
+type Workflow struct {
+	ID         string             `json:"id"`
+	UserID     string             `json:"user_id"`
+	Definition WorkflowDefinition `json:"definition"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+	LastRunID  string             `json:"last_run_id"`
+	Schedule   ScheduleState      `json:"schedule"`
+}
+
+// WorkflowList represents the workflow list
+type WorkflowList struct {
+	Workflows []Workflow `json:"workflows"`
+	Count     int        `json:"count"`
+}
+
+// StartRunResponse represents the response to starting a workflow run
+type StartRunResponse struct {
+	Message string `json:"message"`
+	RunID   string `json:"run_id"`
+}
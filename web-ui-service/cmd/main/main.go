@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/web-ui-service/internal/config"
+	"github.com/furkansarikaya/nmap-ui-microservices/web-ui-service/internal/features/ui/handlers"
+	"github.com/furkansarikaya/nmap-ui-microservices/web-ui-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Printf("Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	log, err := logger.NewLogger(logger.Config{
+		Level:  cfg.Log.Level,
+		Format: cfg.Log.Format,
+		Output: cfg.Log.Output,
+	})
+	if err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer log.Sync()
+
+	log.Info("Starting Web UI Service",
+		zap.String("name", cfg.App.Name),
+		zap.String("version", cfg.App.Version),
+		zap.String("api_url", cfg.API.URL),
+	)
+
+	apiURL, err := url.Parse(cfg.API.URL)
+	if err != nil || apiURL.Scheme == "" || apiURL.Host == "" {
+		log.Fatal("Invalid API URL", zap.String("url", cfg.API.URL))
+	}
+
+	// Initialize router
+	router := gin.New()
+	router.Use(gin.Recovery())
+	if cfg.Server.SecurityHeaders.Enabled {
+		router.Use(handlers.SecurityHeaders(cfg.Server.SecurityHeaders))
+	}
+
+	// Register routes
+	uiHandler := handlers.NewUIHandler(apiURL, log)
+	uiHandler.RegisterRoutes(router)
+
+	// Initialize HTTP server
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:      router,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+	}
+
+	go func() {
+		log.Info("Starting HTTP server", zap.Int("port", cfg.Server.Port))
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("Failed to start HTTP server", zap.Error(err))
+		}
+	}()
+
+	// Wait for interrupt signal to gracefully shut down the server
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Info("Shutting down Web UI Service...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Error("HTTP server shutdown error", zap.Error(err))
+	}
+
+	log.Info("Web UI Service stopped")
+}
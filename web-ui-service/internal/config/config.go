@@ -0,0 +1,44 @@
+package config
+
+import "time"
+
+// Config represents the application configuration
+type Config struct {
+	App    AppConfig
+	Server ServerConfig
+	API    APIConfig
+	Log    LogConfig
+}
+
+// AppConfig contains application metadata
+type AppConfig struct {
+	Name    string
+	Version string
+}
+
+// ServerConfig contains HTTP server configuration
+type ServerConfig struct {
+	Port            int
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	SecurityHeaders SecurityHeadersConfig
+}
+
+// SecurityHeadersConfig contains the security headers of the frontend responses
+type SecurityHeadersConfig struct {
+	Enabled               bool
+	ContentSecurityPolicy string        // Empty to omit the header
+	HSTSMaxAge            time.Duration // Strict-Transport-Security max-age of HTTPS responses, 0 to omit the header
+}
+
+// APIConfig contains the address of the API the frontend talks to
+type APIConfig struct {
+	URL string // Base URL of the API gateway or scanner-service
+}
+
+// LogConfig contains logging configuration
+type LogConfig struct {
+	Level  string
+	Format string
+	Output string
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// LoadConfig loads configuration from file and environment variables
+func LoadConfig() (*Config, error) {
+	// Set default configuration file path
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("./configs")
+	viper.AddConfigPath("../configs")
+	viper.AddConfigPath("/etc/web-ui-service")
+
+	// Read environment variables with prefix WEBUI_
+	viper.SetEnvPrefix("WEBUI")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	// Read configuration file
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+			// Config file not found, continue with defaults and env vars
+			fmt.Println("Config file not found, using defaults and environment variables")
+		} else {
+			// Config file was found but another error occurred
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+
+	config := &Config{}
+
+	// App configuration
+	config.App.Name = viper.GetString("app.name")
+	config.App.Version = viper.GetString("app.version")
+
+	// Server configuration
+	config.Server.Port = viper.GetInt("server.port")
+	config.Server.ReadTimeout = viper.GetDuration("server.read_timeout")
+	config.Server.WriteTimeout = viper.GetDuration("server.write_timeout")
+	viper.SetDefault("server.security_headers.enabled", true)
+	viper.SetDefault("server.security_headers.content_security_policy", "default-src 'self'; frame-ancestors 'none'")
+	viper.SetDefault("server.security_headers.hsts_max_age", "8760h")
+	config.Server.SecurityHeaders.Enabled = viper.GetBool("server.security_headers.enabled")
+	config.Server.SecurityHeaders.ContentSecurityPolicy = viper.GetString("server.security_headers.content_security_policy")
+	config.Server.SecurityHeaders.HSTSMaxAge = viper.GetDuration("server.security_headers.hsts_max_age")
+
+	// API configuration
+	config.API.URL = viper.GetString("api.url")
+
+	// Logging configuration
+	config.Log.Level = viper.GetString("log.level")
+	config.Log.Format = viper.GetString("log.format")
+	config.Log.Output = viper.GetString("log.output")
+
+	// Set defaults if not provided
+	setDefaults(config)
+
+	return config, nil
+}
+
+// setDefaults sets default values for configuration if not provided
+func setDefaults(config *Config) {
+	// App defaults
+	if config.App.Name == "" {
+		config.App.Name = "web-ui-service"
+	}
+	if config.App.Version == "" {
+		config.App.Version = "0.1.0"
+	}
+
+	// Server defaults
+	if config.Server.Port == 0 {
+		config.Server.Port = 3000
+	}
+	if config.Server.ReadTimeout == 0 {
+		config.Server.ReadTimeout = 15 * time.Second
+	}
+	if config.Server.WriteTimeout == 0 {
+		config.Server.WriteTimeout = 60 * time.Second
+	}
+
+	// API defaults
+	if config.API.URL == "" {
+		config.API.URL = "http://localhost:8080"
+	}
+
+	// Logging defaults
+	if config.Log.Level == "" {
+		config.Log.Level = "info"
+	}
+	if config.Log.Format == "" {
+		config.Log.Format = "json"
+	}
+	if config.Log.Output == "" {
+		config.Log.Output = "stdout"
+	}
+}
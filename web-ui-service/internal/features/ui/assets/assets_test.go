@@ -0,0 +1,14 @@
+package assets
+
+import (
+	"io/fs"
+	"testing"
+)
+
+func TestStaticContainsFrontend(t *testing.T) {
+	for _, name := range []string{"index.html", "app.js", "style.css"} {
+		if _, err := fs.Stat(Static(), name); err != nil {
+			t.Errorf("embedded asset %s is missing: %v", name, err)
+		}
+	}
+}
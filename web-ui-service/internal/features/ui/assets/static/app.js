@@ -0,0 +1,300 @@
+// Nmap UI frontend. Talks to the API through the /api/ proxy of the web-ui-service.
+(function () {
+  "use strict";
+
+  const app = document.getElementById("app");
+  const POLL_INTERVAL = 2000;
+  let pollTimer = null;
+
+  // --- API ---------------------------------------------------------------
+
+  async function api(method, path, body) {
+    const headers = { "Content-Type": "application/json" };
+    const apiKey = localStorage.getItem("api_key");
+    const token = localStorage.getItem("token");
+    if (apiKey) headers["X-API-Key"] = apiKey;
+    if (token) headers["Authorization"] = "Bearer " + token;
+
+    const response = await fetch("/api/v1" + path, {
+      method: method,
+      headers: headers,
+      body: body ? JSON.stringify(body) : undefined,
+    });
+
+    const data = await response.json().catch(() => ({}));
+    if (!response.ok) {
+      throw new Error(data.error || data.message || response.statusText);
+    }
+    return data;
+  }
+
+  // --- Helpers -----------------------------------------------------------
+
+  function el(tag, attrs, children) {
+    const node = document.createElement(tag);
+    Object.entries(attrs || {}).forEach(([key, value]) => {
+      if (key === "text") node.textContent = value;
+      else node.setAttribute(key, value);
+    });
+    (children || []).forEach((child) => node.appendChild(child));
+    return node;
+  }
+
+  function formatDate(value) {
+    return value ? new Date(value).toLocaleString() : "-";
+  }
+
+  function setMessage(id, text, ok) {
+    const node = document.getElementById(id);
+    node.textContent = text || "";
+    node.classList.toggle("ok", !!ok);
+  }
+
+  function render(templateId) {
+    app.replaceChildren(document.getElementById(templateId).content.cloneNode(true));
+  }
+
+  function facts(container, entries) {
+    container.replaceChildren();
+    entries.forEach(([label, value]) => {
+      container.appendChild(el("dt", { text: label }));
+      container.appendChild(el("dd", { text: String(value) }));
+    });
+  }
+
+  function isActive(scan) {
+    return scan.status === "PENDING" || scan.status === "RUNNING";
+  }
+
+  function poll(fn) {
+    clearTimeout(pollTimer);
+    pollTimer = setTimeout(fn, POLL_INTERVAL);
+  }
+
+  // --- Scans list --------------------------------------------------------
+
+  function showScans() {
+    render("scans-view");
+
+    document.getElementById("scan-form").addEventListener("submit", async (event) => {
+      event.preventDefault();
+      const form = new FormData(event.target);
+      const request = {
+        target: form.get("target"),
+        ports: form.get("ports") || undefined,
+        scan_type: form.get("scan_type") || undefined,
+        timing_template: Number(form.get("timing_template")),
+        timeout_seconds: Number(form.get("timeout_seconds")) || undefined,
+        service_detection: form.get("service_detection") === "on",
+        os_detection: form.get("os_detection") === "on",
+        script_scan: form.get("script_scan") === "on",
+      };
+
+      try {
+        const response = await api("POST", "/scans", request);
+        location.hash = "#/scans/" + response.scan_id;
+      } catch (err) {
+        setMessage("scan-form-message", err.message);
+      }
+    });
+
+    applyCapabilities();
+    refreshScans();
+  }
+
+  // Disables the scan options the scanner does not support, e.g. SYN scans without root
+  async function applyCapabilities() {
+    const form = document.getElementById("scan-form");
+    try {
+      const capabilities = await api("GET", "/capabilities");
+      Array.from(form.scan_type.options).forEach((option) => {
+        option.disabled = option.value !== "" && !capabilities.scan_types.includes(option.value);
+      });
+      form.os_detection.disabled = !capabilities.os_detection;
+    } catch (err) {
+      // Without capabilities every option stays available
+    }
+  }
+
+  async function refreshScans() {
+    const rows = document.getElementById("scan-rows");
+    if (!rows) return;
+
+    try {
+      const data = await api("GET", "/scans?limit=50");
+      rows.replaceChildren(...data.scans.map((scan) => el("tr", {}, [
+        el("td", { text: scan.options.target }),
+        el("td", {}, [el("span", { class: "status " + scan.status, text: scan.status })]),
+        el("td", { text: Math.round(scan.progress) + "%" }),
+        el("td", { text: formatDate(scan.created_at) }),
+        el("td", {}, [el("a", { href: "#/scans/" + scan.id, text: "Details" })]),
+      ])));
+
+      if (data.scans.some(isActive)) poll(refreshScans);
+    } catch (err) {
+      rows.replaceChildren(el("tr", {}, [el("td", { colspan: "5", text: err.message })]));
+    }
+  }
+
+  // --- Scan details ------------------------------------------------------
+
+  function showScan(id) {
+    render("scan-view");
+
+    document.getElementById("cancel-scan").addEventListener("click", async () => {
+      try {
+        await api("DELETE", "/scans/" + id);
+        refreshScan(id);
+      } catch (err) {
+        setMessage("scan-message", err.message);
+      }
+    });
+    document.getElementById("print-report").addEventListener("click", () => window.print());
+
+    refreshScan(id);
+  }
+
+  async function refreshScan(id) {
+    if (!document.getElementById("scan-title")) return;
+
+    try {
+      const scan = await api("GET", "/scans/" + id);
+      document.getElementById("scan-title").textContent = "Scan of " + scan.options.target;
+      document.getElementById("scan-progress").style.width = scan.progress + "%";
+      document.getElementById("cancel-scan").hidden = !isActive(scan);
+
+      const entries = [
+        ["Status", scan.status],
+        ["Ports", scan.options.ports || "default"],
+        ["Created", formatDate(scan.created_at)],
+        ["Started", formatDate(scan.started_at)],
+        ["Completed", formatDate(scan.completed_at)],
+      ];
+      if (scan.current_phase) {
+        entries.splice(1, 0, ["Phase", scan.current_phase.replace("_", " ")]);
+      }
+      if (scan.estimated_completion) {
+        entries.push(["Phase ends (est.)", formatDate(scan.estimated_completion)]);
+      }
+      if (scan.failure) {
+        if (scan.failure.hint) entries.push(["Hint", scan.failure.hint]);
+        entries.push(["Command", scan.failure.command], ["Exit code", scan.failure.exit_code]);
+        if (scan.failure.stderr) entries.push(["Scanner output", scan.failure.stderr]);
+      }
+      facts(document.getElementById("scan-facts"), entries);
+      setMessage("scan-message", scan.error);
+
+      if (isActive(scan)) {
+        poll(() => refreshScan(id));
+      } else if (scan.result_id) {
+        showResult(await api("GET", "/results/" + scan.result_id));
+      }
+    } catch (err) {
+      setMessage("scan-message", err.message);
+    }
+  }
+
+  function showResult(result) {
+    const hosts = result.hosts || [];
+    const services = {};
+    let openPorts = 0;
+
+    hosts.forEach((host) => (host.ports || []).forEach((port) => {
+      if (port.state !== "open") return;
+      openPorts++;
+      const name = port.service || "unknown";
+      services[name] = (services[name] || 0) + 1;
+    }));
+
+    document.getElementById("report").hidden = false;
+    document.getElementById("hosts").hidden = false;
+    document.getElementById("print-report").hidden = false;
+
+    facts(document.getElementById("report-summary"), [
+      ["Command", result.command],
+      ["Duration", result.duration.toFixed(1) + " s"],
+      ["Hosts", result.up_hosts + " up / " + result.total_hosts + " scanned"],
+      ["Open ports", openPorts],
+    ]);
+
+    document.getElementById("service-rows").replaceChildren(
+      ...Object.entries(services)
+        .sort((a, b) => b[1] - a[1])
+        .map(([name, count]) => el("tr", {}, [el("td", { text: name }), el("td", { text: String(count) })])),
+    );
+
+    const filter = document.getElementById("host-filter");
+    filter.addEventListener("input", () => renderHosts(hosts, filter.value.trim().toLowerCase()));
+    renderHosts(hosts, "");
+  }
+
+  function renderHosts(hosts, query) {
+    const list = document.getElementById("host-list");
+    list.replaceChildren();
+
+    hosts.forEach((host) => {
+      const ports = (host.ports || []).filter((port) => !query ||
+        host.ip.includes(query) ||
+        (host.hostnames || []).some((name) => name.toLowerCase().includes(query)) ||
+        String(port.port) === query ||
+        (port.service || "").toLowerCase().includes(query) ||
+        (port.product || "").toLowerCase().includes(query));
+      if (query && ports.length === 0) return;
+
+      const title = host.ip + ((host.hostnames || []).length ? " (" + host.hostnames.join(", ") + ")" : "");
+      const children = [
+        el("h3", { text: title }),
+        el("p", { class: "hint", text: "Status: " + host.status + (host.os ? " · OS: " + host.os : "") }),
+        el("table", {}, [
+          el("thead", {}, [el("tr", {}, ["Port", "State", "Service", "Version"].map((h) => el("th", { text: h })))]),
+          el("tbody", {}, ports.map((port) => el("tr", {}, [
+            el("td", { text: port.port + "/" + port.protocol }),
+            el("td", { text: port.state }),
+            el("td", { text: port.service || "" }),
+            el("td", { text: [port.product, port.version, port.extra_info].filter(Boolean).join(" ") }),
+          ]))),
+        ]),
+      ];
+      (host.scripts || []).forEach((script) => {
+        children.push(el("pre", { text: script.id + "\n" + script.output }));
+      });
+
+      list.appendChild(el("div", { class: "host" }, children));
+    });
+  }
+
+  // --- Settings ----------------------------------------------------------
+
+  function showSettings() {
+    render("settings-view");
+
+    const form = document.getElementById("settings-form");
+    form.api_key.value = localStorage.getItem("api_key") || "";
+    form.token.value = localStorage.getItem("token") || "";
+
+    form.addEventListener("submit", (event) => {
+      event.preventDefault();
+      ["api_key", "token"].forEach((name) => {
+        if (form[name].value) localStorage.setItem(name, form[name].value);
+        else localStorage.removeItem(name);
+      });
+      setMessage("settings-message", "Saved", true);
+    });
+  }
+
+  // --- Routing -----------------------------------------------------------
+
+  function route() {
+    clearTimeout(pollTimer);
+
+    const hash = location.hash.replace(/^#/, "") || "/";
+    const scanMatch = hash.match(/^\/scans\/([\w-]+)$/);
+
+    if (scanMatch) showScan(scanMatch[1]);
+    else if (hash === "/settings") showSettings();
+    else showScans();
+  }
+
+  window.addEventListener("hashchange", route);
+  route();
+})();
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/web-ui-service/internal/features/ui/assets"
+	"github.com/furkansarikaya/nmap-ui-microservices/web-ui-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// UIHandler serves the frontend and forwards its API calls
+type UIHandler struct {
+	apiProxy *httputil.ReverseProxy
+	files    http.Handler
+	logger   *logger.Logger
+}
+
+// NewUIHandler creates a new UIHandler forwarding /api/ requests to apiURL
+func NewUIHandler(apiURL *url.URL, logger *logger.Logger) *UIHandler {
+	h := &UIHandler{
+		files:  http.FileServer(http.FS(assets.Static())),
+		logger: logger,
+	}
+
+	h.apiProxy = &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(apiURL)
+			r.SetXForwarded()
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			h.logger.Error("API request failed",
+				zap.String("path", r.URL.Path),
+				zap.Error(err),
+			)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"error":"API is unavailable"}`))
+		},
+	}
+
+	return h
+}
+
+// ProxyAPI handles an API request of the frontend
+func (h *UIHandler) ProxyAPI(c *gin.Context) {
+	h.apiProxy.ServeHTTP(c.Writer, c.Request)
+}
+
+// ServeStatic handles requests for the embedded frontend files
+func (h *UIHandler) ServeStatic(c *gin.Context) {
+	h.files.ServeHTTP(c.Writer, c.Request)
+}
+
+// GetHealth handles the health check endpoint
+func (h *UIHandler) GetHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "healthy",
+	})
+}
+
+// RegisterRoutes registers the UI handler routes to the router
+func (h *UIHandler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/health", h.GetHealth)
+	router.Any("/api/*path", h.ProxyAPI)
+	router.NoRoute(h.ServeStatic)
+}
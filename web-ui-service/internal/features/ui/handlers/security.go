@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/furkansarikaya/nmap-ui-microservices/web-ui-service/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHeaders sets the security headers of the frontend on every response.
+// Strict-Transport-Security is only sent on HTTPS requests, including requests a TLS
+// terminating proxy forwarded with X-Forwarded-Proto.
+func SecurityHeaders(cfg config.SecurityHeadersConfig) gin.HandlerFunc {
+	hsts := ""
+	if cfg.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(cfg.HSTSMaxAge.Seconds()))
+	}
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("Referrer-Policy", "no-referrer")
+		if cfg.ContentSecurityPolicy != "" {
+			header.Set("Content-Security-Policy", cfg.ContentSecurityPolicy)
+		}
+		if hsts != "" && (c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")) {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+
+		c.Next()
+	}
+}